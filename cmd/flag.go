@@ -42,6 +42,18 @@ type DurationFlag struct {
 	*Flag
 }
 
+type BoolFlag struct {
+	*Flag
+}
+
+type Float64Flag struct {
+	*Flag
+}
+
+type StringSliceFlag struct {
+	*Flag
+}
+
 type StringPFlag struct {
 	*Flag
 	sh string
@@ -88,6 +100,48 @@ func (f *Flag) Int() *IntFlag {
 	}
 }
 
+// Bind registers the flag with the command and binds it to the config
+func (f *BoolFlag) Bind(cmd *cobra.Command, value bool, usage string) {
+	cmd.PersistentFlags().Bool(f.Cli, value, usage)
+	if err := viper.BindPFlag(f.Config, cmd.PersistentFlags().Lookup(f.Cli)); err != nil {
+		panic(err)
+	}
+}
+
+func (f *Flag) Bool() *BoolFlag {
+	return &BoolFlag{
+		Flag: f,
+	}
+}
+
+// Bind registers the flag with the command and binds it to the config
+func (f *Float64Flag) Bind(cmd *cobra.Command, value float64, usage string) {
+	cmd.PersistentFlags().Float64(f.Cli, value, usage)
+	if err := viper.BindPFlag(f.Config, cmd.PersistentFlags().Lookup(f.Cli)); err != nil {
+		panic(err)
+	}
+}
+
+func (f *Flag) Float64() *Float64Flag {
+	return &Float64Flag{
+		Flag: f,
+	}
+}
+
+// Bind registers the flag with the command and binds it to the config
+func (f *StringSliceFlag) Bind(cmd *cobra.Command, value []string, usage string) {
+	cmd.PersistentFlags().StringSlice(f.Cli, value, usage)
+	if err := viper.BindPFlag(f.Config, cmd.PersistentFlags().Lookup(f.Cli)); err != nil {
+		panic(err)
+	}
+}
+
+func (f *Flag) StringSlice() *StringSliceFlag {
+	return &StringSliceFlag{
+		Flag: f,
+	}
+}
+
 // Bind registers the flag with the command and binds it to the config
 func (f *StringPFlag) Bind(cmd *cobra.Command, value, usage string) {
 	cmd.PersistentFlags().StringP(f.Cli, f.sh, value, usage)
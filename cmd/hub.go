@@ -0,0 +1,90 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/hub"
+)
+
+// NewCmdHub creates a new hub command
+func NewCmdHub() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hub",
+		Short: "Run sparrow in hub mode",
+		Long: "The hub accepts the results pushed by other sparrows and exposes the fleet-wide results via its API.\n" +
+			"No checks are run in hub mode.",
+		RunE: runHub(),
+	}
+
+	// The api configuration is shared with the run command, which already binds the api flags
+	NewFlag("hub.receiver.secret", "hubSecret").String().Bind(cmd, "", "hub: The shared secret used to verify the submissions")
+	NewFlag("hub.receiver.unhealthyThreshold", "hubUnhealthyThreshold").Duration().Bind(cmd, 0, "hub: The amount of time without submissions after which an instance is considered unhealthy")
+
+	return cmd
+}
+
+// runHub is the entry point to start the hub server
+func runHub() func(cmd *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, _ []string) error {
+		cfg := &hub.ServerConfig{}
+		err := viper.Unmarshal(cfg, viper.DecodeHook(config.DecodeHook()))
+		if err != nil {
+			return fmt.Errorf("failed to parse config: %w", err)
+		}
+
+		ctx, cancel := logger.NewContextWithLogger(context.Background())
+		log := logger.FromContext(ctx)
+		defer cancel()
+
+		if err = cfg.Validate(ctx); err != nil {
+			return fmt.Errorf("error while validating the config: %w", err)
+		}
+
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+		s := hub.NewServer(cfg)
+		cErr := make(chan error, 1)
+		log.Info("Running sparrow hub")
+		go func() {
+			cErr <- s.Run(ctx)
+		}()
+
+		select {
+		case <-sigChan:
+			log.Info("Signal received, shutting down")
+			cancel()
+			<-cErr
+			return s.Shutdown(ctx)
+		case err := <-cErr:
+			return err
+		}
+	}
+}
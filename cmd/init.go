@@ -0,0 +1,165 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/config"
+)
+
+const (
+	// startupFile is the name of the scaffolded startup configuration
+	startupFile = "sparrow.yaml"
+	// runtimeFile is the name of the scaffolded runtime configuration
+	runtimeFile = "checks.yaml"
+	// defaultScaffoldName is the DNS name of the sparrow if none is chosen
+	defaultScaffoldName = "sparrow.example.com"
+)
+
+// initOptions are the options of the init command
+type initOptions struct {
+	name        string
+	checks      []string
+	dir         string
+	force       bool
+	interactive bool
+}
+
+// NewCmdInit creates a new init command
+func NewCmdInit() *cobra.Command {
+	opts := &initOptions{}
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Scaffold a startup and a runtime configuration",
+		Long: "Init writes a valid startup configuration (" + startupFile + ") and an example runtime configuration\n" +
+			"(" + runtimeFile + ") with sensible defaults for the chosen checks. The startup configuration reads the\n" +
+			"runtime configuration with the file loader, so the sparrow can be started right away:\n\n" +
+			"  sparrow run --config " + startupFile,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runInit(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", defaultScaffoldName, "The DNS name of the sparrow")
+	cmd.Flags().StringSliceVar(&opts.checks, "checks", config.DefaultScaffoldChecks, fmt.Sprintf("The checks to add an example of: %s", strings.Join(config.ScaffoldChecks(), ", ")))
+	cmd.Flags().StringVar(&opts.dir, "dir", ".", "The directory the configurations are written to")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite existing configurations")
+	cmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", false, "Prompt for the options instead of using the flags")
+
+	_ = cmd.RegisterFlagCompletionFunc("checks", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return config.ScaffoldChecks(), cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = cmd.MarkFlagDirname("dir")
+
+	return cmd
+}
+
+// runInit scaffolds the configurations
+func runInit(cmd *cobra.Command, opts *initOptions) error {
+	ctx, cancel := logger.NewContextWithLogger(context.Background())
+	defer cancel()
+
+	if opts.interactive {
+		if err := prompt(cmd.InOrStdin(), cmd.OutOrStdout(), opts); err != nil {
+			return err
+		}
+	}
+
+	s := config.Scaffold{
+		Name:        opts.name,
+		Checks:      opts.checks,
+		RuntimePath: filepath.Join(opts.dir, runtimeFile),
+	}
+	startup, rt, err := s.Render(ctx)
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{
+		filepath.Join(opts.dir, startupFile): startup,
+		s.RuntimePath:                        rt,
+	}
+	if !opts.force {
+		for path := range files {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists, use --force to overwrite it", path)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	if err := os.MkdirAll(opts.dir, 0o755); err != nil { //nolint:mnd // Directory permissions
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	for _, path := range []string{filepath.Join(opts.dir, startupFile), s.RuntimePath} {
+		if err := os.WriteFile(path, files[path], 0o600); err != nil { //nolint:mnd // File permissions
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Wrote", path)
+	}
+	return nil
+}
+
+// prompt asks for the options, keeping the current values if the answer is empty
+func prompt(in io.Reader, out io.Writer, opts *initOptions) error {
+	r := bufio.NewReader(in)
+	ask := func(question, current string) (string, error) {
+		_, _ = fmt.Fprintf(out, "%s [%s]: ", question, current)
+		answer, err := r.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		if answer = strings.TrimSpace(answer); answer != "" {
+			return answer, nil
+		}
+		return current, nil
+	}
+
+	var err error
+	if opts.name, err = ask("DNS name of the sparrow", opts.name); err != nil {
+		return err
+	}
+	checks, err := ask(fmt.Sprintf("Checks (%s)", strings.Join(config.ScaffoldChecks(), ", ")), strings.Join(opts.checks, ","))
+	if err != nil {
+		return err
+	}
+	opts.checks = nil
+	for _, c := range strings.Split(checks, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			opts.checks = append(opts.checks, c)
+		}
+	}
+	if opts.dir, err = ask("Directory", opts.dir); err != nil {
+		return err
+	}
+	return nil
+}
@@ -44,6 +44,7 @@ func NewCmdRoot(version string) *cobra.Command {
 	})
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.sparrow.yaml)")
+	_ = rootCmd.MarkPersistentFlagFilename("config", "yaml", "yml")
 
 	return rootCmd
 }
@@ -62,6 +63,10 @@ func Execute(version string) {
 func BuildCmd(version string) *cobra.Command {
 	cmd := NewCmdRoot(version)
 	cmd.AddCommand(NewCmdRun())
+	cmd.AddCommand(NewCmdHub())
+	cmd.AddCommand(NewCmdSimulate())
+	cmd.AddCommand(NewCmdInit())
+	cmd.AddCommand(NewCmdValidate())
 	return cmd
 }
 
@@ -30,6 +30,8 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/internal/tuning"
+	"github.com/caas-team/sparrow/pkg/api"
 	"github.com/caas-team/sparrow/pkg/config"
 	"github.com/caas-team/sparrow/pkg/sparrow"
 )
@@ -39,6 +41,9 @@ const (
 	defaultLoaderInterval    = 300 * time.Second
 	defaultHttpRetryCount    = 3
 	defaultHttpRetryDelay    = 1 * time.Second
+	defaultHttpRetryMaxDelay = 1 * time.Minute
+	defaultSnapshotTimeout   = 30 * time.Second
+	defaultDBMinSize         = 1024
 )
 
 // NewCmdRun creates a new run command
@@ -51,40 +56,98 @@ func NewCmdRun() *cobra.Command {
 	}
 
 	NewFlag("api.address", "apiAddress").String().Bind(cmd, ":8080", "api: The address the server is listening on")
+	NewFlag("api.readTimeout", "apiReadTimeout").Duration().Bind(cmd, api.DefaultReadTimeout, "api: The maximum duration for reading a request, including its body")
+	NewFlag("api.writeTimeout", "apiWriteTimeout").Duration().Bind(cmd, api.DefaultWriteTimeout, "api: The maximum duration before timing out the writes of a response")
+	NewFlag("api.idleTimeout", "apiIdleTimeout").Duration().Bind(cmd, api.DefaultIdleTimeout, "api: The maximum duration to wait for the next request on a keep-alive connection")
+	NewFlag("api.maxHeaderBytes", "apiMaxHeaderBytes").Int().Bind(cmd, api.DefaultMaxHeaderBytes, "api: The maximum size of the request headers in bytes")
+	NewFlag("api.maxBodyBytes", "apiMaxBodyBytes").Int().Bind(cmd, api.DefaultMaxBodyBytes, "api: The maximum size of a request body in bytes")
+	NewFlag("api.runToken", "apiRunToken").String().Bind(cmd, "", "api: The bearer token authorizing checks to be run on demand. Running on demand is disabled if empty")
 	NewFlag("name", "sparrowName").String().Bind(cmd, "", "The DNS name of the sparrow")
-	NewFlag("loader.type", "loaderType").StringP("l").Bind(cmd, "http", "Defines the loader type that will load the checks configuration during the runtime. The fallback is the fileLoader")
-	NewFlag("loader.interval", "loaderInterval").Duration().Bind(cmd, defaultLoaderInterval, "defines the interval the loader reloads the configuration in seconds")
+	NewFlag("identity.env", "identityEnv").String().Bind(cmd, "", "identity: Name of an environment variable overriding the DNS name of the sparrow")
+	NewFlag("identity.autoDetect", "identityAutoDetect").Bool().Bind(cmd, false, "identity: Detect the FQDN of the host if no DNS name is set")
+	NewFlag("loader.type", "loaderType").StringP("l").Bind(cmd, "http", "Defines the loader type that will load the checks configuration during the runtime: http, file, gitlab, s3, gcs or azureblob")
+	NewFlag("loader.interval", "loaderInterval").Duration().Bind(cmd, defaultLoaderInterval, "defines the interval the loader reloads the configuration")
 	NewFlag("loader.http.url", "loaderHttpUrl").String().Bind(cmd, "", "http loader: The url where to get the remote configuration")
 	NewFlag("loader.http.token", "loaderHttpToken").String().Bind(cmd, "", "http loader: Bearer token to authenticate the http endpoint")
-	NewFlag("loader.http.timeout", "loaderHttpTimeout").Duration().Bind(cmd, defaultLoaderHttpTimeout, "http loader: The timeout for the http request in seconds")
+	NewFlag("loader.http.oauth2.tokenUrl", "loaderHttpOAuth2TokenUrl").String().Bind(cmd, "", "http loader: The token endpoint to acquire the bearer token from with the oauth2 client credentials grant")
+	NewFlag("loader.http.oauth2.clientId", "loaderHttpOAuth2ClientId").String().Bind(cmd, "", "http loader: The oauth2 client id")
+	NewFlag("loader.http.oauth2.clientSecret", "loaderHttpOAuth2ClientSecret").String().Bind(cmd, "", "http loader: The oauth2 client secret")
+	NewFlag("loader.http.oauth2.scopes", "loaderHttpOAuth2Scopes").StringSlice().Bind(cmd, nil, "http loader: The scopes requested for the oauth2 token")
+	NewFlag("loader.http.timeout", "loaderHttpTimeout").Duration().Bind(cmd, defaultLoaderHttpTimeout, "http loader: The timeout for the http request")
 	NewFlag("loader.http.retry.count", "loaderHttpRetryCount").Int().Bind(cmd, defaultHttpRetryCount, "http loader: Amount of retries trying to load the configuration")
-	NewFlag("loader.http.retry.delay", "loaderHttpRetryDelay").Duration().Bind(cmd, defaultHttpRetryDelay, "http loader: The initial delay between retries in seconds")
+	NewFlag("loader.http.retry.delay", "loaderHttpRetryDelay").Duration().Bind(cmd, defaultHttpRetryDelay, "http loader: The initial delay between retries")
+	NewFlag("loader.http.retry.maxDelay", "loaderHttpRetryMaxDelay").Duration().Bind(cmd, defaultHttpRetryMaxDelay, "http loader: The maximum delay between retries, including delays requested with Retry-After")
+	NewFlag("loader.http.cache", "loaderHttpCache").String().Bind(cmd, "", "http loader: The path of the file caching the last successfully loaded configuration, applied if loading fails on startup")
+	NewFlag("loader.gitlab.baseUrl", "loaderGitlabBaseUrl").String().Bind(cmd, "", "gitlab loader: The URL of the gitlab instance")
+	NewFlag("loader.gitlab.token", "loaderGitlabToken").String().Bind(cmd, "", "gitlab loader: The access token to authenticate with the gitlab instance")
+	NewFlag("loader.gitlab.projectId", "loaderGitlabProjectId").Int().Bind(cmd, 0, "gitlab loader: The ID of the project containing the runtime config")
+	NewFlag("loader.gitlab.ref", "loaderGitlabRef").String().Bind(cmd, "", "gitlab loader: The branch, tag or commit SHA to read the runtime config from. Defaults to the default branch")
+	NewFlag("loader.gitlab.path", "loaderGitlabPath").String().Bind(cmd, "config.yaml", "gitlab loader: The path of the runtime config file within the repository")
+	NewFlag("loader.gitlab.timeout", "loaderGitlabTimeout").Duration().Bind(cmd, defaultLoaderHttpTimeout, "gitlab loader: The timeout for the request to the gitlab instance")
+	NewFlag("loader.gitlab.retry.count", "loaderGitlabRetryCount").Int().Bind(cmd, defaultHttpRetryCount, "gitlab loader: Amount of retries trying to load the configuration")
+	NewFlag("loader.gitlab.retry.delay", "loaderGitlabRetryDelay").Duration().Bind(cmd, defaultHttpRetryDelay, "gitlab loader: The initial delay between retries")
+	NewFlag("loader.gitlab.retry.maxDelay", "loaderGitlabRetryMaxDelay").Duration().Bind(cmd, defaultHttpRetryMaxDelay, "gitlab loader: The maximum delay between retries, including delays requested with Retry-After")
+	NewFlag("loader.gitlab.cache", "loaderGitlabCache").String().Bind(cmd, "", "gitlab loader: The path of the file caching the last successfully loaded configuration, applied if loading fails on startup")
+	NewFlag("loader.push.token", "loaderPushToken").String().Bind(cmd, "", "push loader: The bearer token authorizing pushes of the runtime config to the API. Pushing is disabled if empty")
 	NewFlag("loader.file.path", "loaderFilePath").String().Bind(cmd, "config.yaml", "file loader: The path to the file to read the runtime config from")
+	NewFlag("once.enabled", "once").Bool().Bind(cmd, false, "once: Run every check once, write the results as JSON and exit with an error if any target failed")
+	NewFlag("once.output", "onceOutput").String().Bind(cmd, "", "once: The file to write the results to. Defaults to stdout")
+	NewFlag("snapshot.import", "snapshotImport").String().Bind(cmd, "", "snapshot: The file or http(s) url of the results exported by a replaced sparrow to restore on startup")
+	NewFlag("snapshot.timeout", "snapshotTimeout").Duration().Bind(cmd, defaultSnapshotTimeout, "snapshot: The timeout for fetching the snapshot from a url")
+	NewFlag("snapshot.token", "snapshotToken").String().Bind(cmd, "", "snapshot: Bearer token to fetch the snapshot from a url with, i.e. the admin token of the replaced sparrow")
+	NewFlag("shutdown.report", "shutdownReport").String().Bind(cmd, "", "shutdown: The file to write the report of the state on shutdown to as JSON. The report is only logged if empty")
+	NewFlag("results.changesOnly", "resultsChangesOnly").Bool().Bind(cmd, false, "results: Export a result only if its data differs from the last exported result of its check")
+	NewFlag("results.fullInterval", "resultsFullInterval").Duration().Bind(cmd, 0, "results: The interval in which a result is exported even if it's unchanged. Disabled if 0")
+	NewFlag("db.compression", "dbCompression").String().Bind(cmd, "", "db: The algorithm the stored results are compressed with: zstd or snappy. Disabled if empty")
+	NewFlag("db.minSize", "dbMinSize").Int().Bind(cmd, defaultDBMinSize, "db: The minimum size in bytes of the data of a result to be compressed")
+	NewFlag("admin.enabled", "adminEnabled").Bool().Bind(cmd, false, "admin: Serve the profiling and runtime debug endpoints on the admin listener")
+	NewFlag("admin.address", "adminAddress").String().Bind(cmd, ":8081", "admin: The address the admin listener is listening on")
+	NewFlag("admin.token", "adminToken").String().Bind(cmd, "", "admin: Bearer token to authorize the requests to the admin listener")
+	NewFlag("tuning.maxProcs", "tuningMaxProcs").Bool().Bind(cmd, false, "tuning: Set GOMAXPROCS to the CPU quota of the container")
+	NewFlag("tuning.memoryLimit", "tuningMemoryLimit").Bool().Bind(cmd, false, "tuning: Set GOMEMLIMIT to a share of the memory limit of the container")
+	NewFlag("tuning.memoryLimitRatio", "tuningMemoryLimitRatio").Float64().Bind(cmd, tuning.DefaultMemoryLimitRatio, "tuning: The share of the memory limit of the container used as GOMEMLIMIT")
 
 	return cmd
 }
 
 // run is the entry point to start the sparrow
 func run() func(cmd *cobra.Command, args []string) error {
-	return func(_ *cobra.Command, _ []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
 		cfg := &config.Config{}
-		err := viper.Unmarshal(cfg)
+		err := viper.Unmarshal(cfg, viper.DecodeHook(config.DecodeHook()))
 		if err != nil {
 			return fmt.Errorf("failed to parse config: %w", err)
 		}
+		cfg.TargetManager.Version = cmd.Root().Version
 
 		ctx, cancel := logger.NewContextWithLogger(context.Background())
 		log := logger.FromContext(ctx)
 		defer cancel()
 
+		if err = cfg.ResolveIdentity(ctx); err != nil {
+			return fmt.Errorf("failed to resolve the identity: %w", err)
+		}
+
 		if err = cfg.Validate(ctx); err != nil {
 			return fmt.Errorf("error while validating the config: %w", err)
 		}
 
+		if cfg.Tuning.Enabled() {
+			tuning.Apply(ctx, cfg.Tuning)
+		}
+
+		if cfg.HasOnce() {
+			log.Info("Running checks once")
+			return sparrow.RunOnce(ctx, cfg)
+		}
+
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-		s := sparrow.New(cfg)
+		s, err := sparrow.New(cfg)
+		if err != nil {
+			return err
+		}
 		cErr := make(chan error, 1)
 		log.Info("Running sparrow")
 		go func() {
@@ -0,0 +1,96 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/simulate"
+)
+
+// NewCmdSimulate creates a new simulate command
+func NewCmdSimulate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Serve simulated targets for the checks",
+		Long: "The simulator serves targets with configurable latencies, error rates and TLS faults,\n" +
+			"so test environments can exercise the checks deterministically without real endpoints.\n" +
+			"The targets are configured in the simulate section of the config file.",
+		RunE: runSimulate(),
+	}
+
+	NewFlag("simulate.address", "simulateAddress").String().Bind(cmd, ":8090", "simulate: The address the simulated targets are served on via http")
+	NewFlag("simulate.tls.address", "simulateTlsAddress").String().Bind(cmd, "", "simulate: The address the simulated targets are served on via https with a faulty certificate")
+	NewFlag("simulate.tls.fault", "simulateTlsFault").String().Bind(cmd, simulate.FaultSelfSigned, "simulate: The fault of the served certificate: expired, wrongHost or selfSigned")
+	NewFlag("simulate.seed", "simulateSeed").Int().Bind(cmd, 0, "simulate: The seed of the random behaviors, runs with the same seed behave the same")
+
+	return cmd
+}
+
+// runSimulate is the entry point to start the simulator
+func runSimulate() func(cmd *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, _ []string) error {
+		// The simulator is configured in its own section, so it can share the config file with the sparrow
+		var file struct {
+			Simulate simulate.Config `mapstructure:"simulate"`
+		}
+		err := viper.Unmarshal(&file, viper.DecodeHook(config.DecodeHook()))
+		if err != nil {
+			return fmt.Errorf("failed to parse config: %w", err)
+		}
+		cfg := &file.Simulate
+
+		ctx, cancel := logger.NewContextWithLogger(context.Background())
+		log := logger.FromContext(ctx)
+		defer cancel()
+
+		if err = cfg.Validate(ctx); err != nil {
+			return fmt.Errorf("error while validating the config: %w", err)
+		}
+
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+		s := simulate.NewServer(cfg)
+		cErr := make(chan error, 1)
+		log.Info("Running sparrow simulator", "targets", len(cfg.Targets))
+		go func() {
+			cErr <- s.Run(ctx)
+		}()
+
+		select {
+		case <-sigChan:
+			log.Info("Signal received, shutting down")
+			cancel()
+			<-cErr
+			return s.Shutdown(ctx)
+		case err := <-cErr:
+			return err
+		}
+	}
+}
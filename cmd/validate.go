@@ -0,0 +1,80 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+)
+
+// NewCmdValidate creates a new validate command
+func NewCmdValidate() *cobra.Command {
+	var name string
+	cmd := &cobra.Command{
+		Use:   "validate <runtime config>...",
+		Short: "Validate runtime configurations",
+		Long: "Validate checks the runtime configurations of the checks the same way the loaders do,\n" +
+			"e.g. that the interval of a check leaves enough time for the timeout and the retries of its probes.\n" +
+			"It exits with an error describing every invalid field if any configuration is invalid.\n" +
+			"The template variables of the configurations are rendered for the sparrow with the given name.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			for _, path := range args {
+				if vErr := validateRuntimeFile(path, name); vErr != nil {
+					err = errors.Join(err, fmt.Errorf("%s: %w", path, vErr))
+					continue
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), path, "is valid")
+			}
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&name, "sparrowName", "", "The DNS name of the sparrow the template variables are rendered for")
+	return cmd
+}
+
+// validateRuntimeFile renders, parses and validates the runtime configuration in the file
+func validateRuntimeFile(path, name string) error {
+	b, err := os.ReadFile(path) // #nosec G304 // The path is chosen by the user
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	b, err = config.RenderRuntimeConfig(b, name)
+	if err != nil {
+		return fmt.Errorf("failed to render config file: %w", err)
+	}
+
+	var cfg runtime.Config
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if cfg.Empty() {
+		return errors.New("no checks are configured")
+	}
+	return cfg.Validate()
+}
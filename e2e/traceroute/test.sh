@@ -1,6 +1,6 @@
 #!/bin/bash
 
-EXIT_CODE=0
+source ../../scripts/e2e_assertions.sh
 
 function cleanup()
 {
@@ -9,25 +9,9 @@ function cleanup()
     exit $EXIT_CODE
 }
 
-function error() {
-    echo "[ ERROR ]: $@"
-    EXIT_CODE=1
-}
-
-function info() {
-    echo "[ INFO ]: $@"
-}
-
-function success() {
-    echo "[ SUCCESS ]: $@"
-}
-
 function check_prometheus_output() {
-  if grep -q 'sparrow_traceroute_minimum_hops{target="200.1.1.7"} 3' ./shared/prometheus.txt; then
-    success "The specific Prometheus output is present."
-  else
-    error "The specific Prometheus output is not present."
-  fi
+    assert_metric ./shared/prometheus.txt 'sparrow_traceroute_minimum_hops{target="200.1.1.7"}' 3 3
+    assert_metric ./shared/prometheus.txt 'sparrow_traceroute_check_duration_ms{target="200.1.1.7"}' 0
 }
 
 function check_api_output() {
@@ -0,0 +1,105 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package clock provides a time source that can be replaced in tests
+// to advance time deterministically instead of sleeping.
+package clock
+
+import "time"
+
+// Clock is a source of time
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// Since returns the time elapsed since t
+	Since(t time.Time) time.Duration
+	// After waits for the duration to elapse and then sends the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a new Timer that sends the current time on its channel after at least duration d
+	NewTimer(d time.Duration) Timer
+	// NewTicker creates a new Ticker that sends the current time on its channel every period d
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a single event timer of a Clock
+type Timer interface {
+	// C returns the channel the time is delivered on
+	C() <-chan time.Time
+	// Reset changes the timer to expire after duration d.
+	// It returns true if the timer had been active.
+	Reset(d time.Duration) bool
+	// Stop prevents the timer from firing.
+	// It returns true if the timer had been active.
+	Stop() bool
+}
+
+// Ticker delivers ticks of a Clock at intervals
+type Ticker interface {
+	// C returns the channel the ticks are delivered on
+	C() <-chan time.Time
+	// Reset stops the ticker and resets its period to the duration d
+	Reset(d time.Duration)
+	// Stop turns off the ticker
+	Stop()
+}
+
+// Real is the system clock
+var Real Clock = system{}
+
+// Source is embedded by types that read the time.
+// Its zero value uses the system clock.
+type Source struct {
+	clock Clock
+}
+
+// Clock returns the clock of the source
+func (s *Source) Clock() Clock {
+	if s.clock == nil {
+		return Real
+	}
+	return s.clock
+}
+
+// SetClock replaces the clock of the source.
+// It must be called before the clock is used.
+func (s *Source) SetClock(c Clock) {
+	s.clock = c
+}
+
+// system implements the Clock interface with the time package
+type system struct{}
+
+func (system) Now() time.Time                         { return time.Now() }
+func (system) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (system) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (system) NewTimer(d time.Duration) Timer         { return &timer{time.NewTimer(d)} }
+func (system) NewTicker(d time.Duration) Ticker       { return &ticker{time.NewTicker(d)} }
+
+// timer wraps a time.Timer to implement the Timer interface
+type timer struct {
+	*time.Timer
+}
+
+func (t *timer) C() <-chan time.Time { return t.Timer.C }
+
+// ticker wraps a time.Ticker to implement the Ticker interface
+type ticker struct {
+	*time.Ticker
+}
+
+func (t *ticker) C() <-chan time.Time { return t.Ticker.C }
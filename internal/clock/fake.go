@@ -0,0 +1,209 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+var _ Clock = (*Fake)(nil)
+
+// Fake is a Clock whose time only moves when it's advanced.
+// Timers and tickers fire while advancing the clock past their deadline.
+type Fake struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	now  time.Time
+	// waiters are the active timers and tickers
+	waiters []*fakeTimer
+}
+
+// NewFake returns a fake clock set to the given time
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now returns the current time of the fake clock
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the time elapsed on the fake clock since t
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After waits for the fake clock to be advanced by the duration
+// and then sends the time on the returned channel
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// NewTimer creates a timer that fires once the fake clock was advanced by the duration
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{fake: f, c: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// NewTicker creates a ticker that fires every time the fake clock was advanced by the period.
+// It panics if the period isn't positive.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for clock.Fake.NewTicker")
+	}
+	t := &fakeTicker{&fakeTimer{fake: f, c: make(chan time.Time, 1), ticker: true}}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the fake clock forward by the duration and fires
+// all timers and tickers that are due, in the order of their deadline
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	end := f.now.Add(d)
+	for {
+		next := f.next(end)
+		if next == nil {
+			break
+		}
+		f.now = next.at
+		select {
+		case next.c <- f.now:
+		default:
+			// like the time package, a tick is dropped if the previous wasn't received yet
+		}
+		if next.ticker {
+			next.at = next.at.Add(next.period)
+		} else {
+			f.remove(next)
+		}
+	}
+	f.now = end
+}
+
+// BlockUntil blocks until the fake clock has at least n active timers and tickers.
+// It allows tests to wait for a goroutine to start waiting on the clock before advancing it.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// next returns the waiter with the earliest deadline not after end.
+// The caller must hold the lock.
+func (f *Fake) next(end time.Time) *fakeTimer {
+	var next *fakeTimer
+	for _, w := range f.waiters {
+		if w.at.After(end) {
+			continue
+		}
+		if next == nil || w.at.Before(next.at) {
+			next = w
+		}
+	}
+	return next
+}
+
+// add activates the waiter. The caller must hold the lock.
+func (f *Fake) add(t *fakeTimer) {
+	f.waiters = append(f.waiters, t)
+	f.cond.Broadcast()
+}
+
+// remove deactivates the waiter and reports whether it was active.
+// The caller must hold the lock.
+func (f *Fake) remove(t *fakeTimer) bool {
+	for i, w := range f.waiters {
+		if w == t {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTimer implements the Timer and Ticker interfaces for the fake clock
+type fakeTimer struct {
+	fake   *Fake
+	c      chan time.Time
+	at     time.Time
+	period time.Duration
+	ticker bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+// Reset changes the timer to fire once the fake clock was advanced by the duration
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.fake.mu.Lock()
+	defer t.fake.mu.Unlock()
+	active := t.fake.remove(t)
+	t.drain()
+	t.at, t.period = t.fake.now.Add(d), d
+	if t.ticker || d > 0 {
+		t.fake.add(t)
+	} else {
+		// a timer that is already due fires immediately
+		select {
+		case t.c <- t.fake.now:
+		default:
+		}
+	}
+	return active
+}
+
+// Stop prevents the timer from firing
+func (t *fakeTimer) Stop() bool {
+	t.fake.mu.Lock()
+	defer t.fake.mu.Unlock()
+	t.drain()
+	return t.fake.remove(t)
+}
+
+// drain discards a pending time, so no stale value is received after a reset or stop
+func (t *fakeTimer) drain() {
+	select {
+	case <-t.c:
+	default:
+	}
+}
+
+// fakeTicker adapts a fakeTimer to the Ticker interface
+type fakeTicker struct {
+	*fakeTimer
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for clock.Fake ticker Reset")
+	}
+	t.fakeTimer.Reset(d)
+}
+
+func (t *fakeTicker) Stop() { t.fakeTimer.Stop() }
@@ -0,0 +1,180 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFake_Timer(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		advance []time.Duration
+		want    bool
+	}{
+		{
+			name:    "not due",
+			timeout: time.Minute,
+			advance: []time.Duration{59 * time.Second},
+			want:    false,
+		},
+		{
+			name:    "due",
+			timeout: time.Minute,
+			advance: []time.Duration{time.Minute},
+			want:    true,
+		},
+		{
+			name:    "due after multiple advances",
+			timeout: time.Minute,
+			advance: []time.Duration{30 * time.Second, 30 * time.Second},
+			want:    true,
+		},
+		{
+			name:    "zero timeout",
+			timeout: 0,
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFake(epoch)
+			timer := f.NewTimer(tt.timeout)
+			for _, d := range tt.advance {
+				f.Advance(d)
+			}
+
+			select {
+			case got := <-timer.C():
+				if !tt.want {
+					t.Fatalf("Timer fired at %v, want not fired", got)
+				}
+				if want := epoch.Add(tt.timeout); !got.Equal(want) {
+					t.Errorf("Timer fired at %v, want %v", got, want)
+				}
+			default:
+				if tt.want {
+					t.Fatal("Timer didn't fire")
+				}
+			}
+		})
+	}
+}
+
+func TestFake_TimerStopAndReset(t *testing.T) {
+	f := NewFake(epoch)
+	timer := f.NewTimer(time.Second)
+
+	if !timer.Stop() {
+		t.Error("Stop() = false, want true for an active timer")
+	}
+	f.Advance(time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("Stopped timer fired")
+	default:
+	}
+
+	if timer.Reset(time.Second) {
+		t.Error("Reset() = true, want false for a stopped timer")
+	}
+	f.Advance(time.Second)
+	select {
+	case <-timer.C():
+	default:
+		t.Fatal("Reset timer didn't fire")
+	}
+}
+
+func TestFake_Ticker(t *testing.T) {
+	f := NewFake(epoch)
+	ticker := f.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for i := 1; i <= 3; i++ {
+		f.Advance(time.Second)
+		select {
+		case got := <-ticker.C():
+			if want := epoch.Add(time.Duration(i) * time.Second); !got.Equal(want) {
+				t.Errorf("Tick %d at %v, want %v", i, got, want)
+			}
+		default:
+			t.Fatalf("Tick %d missing", i)
+		}
+	}
+
+	// ticks that aren't received are dropped
+	f.Advance(5 * time.Second)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Fatal("Ticker delivered dropped ticks")
+	default:
+	}
+}
+
+func TestFake_Advance_order(t *testing.T) {
+	f := NewFake(epoch)
+	late := f.NewTimer(2 * time.Second)
+	early := f.NewTimer(time.Second)
+
+	f.Advance(time.Minute)
+
+	e, l := <-early.C(), <-late.C()
+	if !e.Before(l) {
+		t.Errorf("Early timer fired at %v, not before late timer at %v", e, l)
+	}
+	if got := f.Now(); !got.Equal(epoch.Add(time.Minute)) {
+		t.Errorf("Now() = %v, want %v", got, epoch.Add(time.Minute))
+	}
+	if got := f.Since(epoch); got != time.Minute {
+		t.Errorf("Since() = %v, want %v", got, time.Minute)
+	}
+}
+
+func TestFake_BlockUntil(t *testing.T) {
+	f := NewFake(epoch)
+	done := make(chan time.Time)
+	go func() {
+		done <- <-f.After(time.Hour)
+	}()
+
+	f.BlockUntil(1)
+	f.Advance(time.Hour)
+	if got := <-done; !got.Equal(epoch.Add(time.Hour)) {
+		t.Errorf("After() delivered %v, want %v", got, epoch.Add(time.Hour))
+	}
+}
+
+func TestSource_Clock(t *testing.T) {
+	var s Source
+	if s.Clock() != Real {
+		t.Error("Clock() of the zero value isn't the system clock")
+	}
+
+	f := NewFake(epoch)
+	s.SetClock(f)
+	if s.Clock() != f {
+		t.Error("Clock() isn't the clock that was set")
+	}
+}
@@ -20,8 +20,13 @@ package helper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/caas-team/sparrow/internal/logger"
@@ -30,33 +35,215 @@ import (
 type RetryConfig struct {
 	Count int           `yaml:"count"`
 	Delay time.Duration `yaml:"delay"`
+	// MaxDelay caps the exponentially growing delay between two attempts. 0 means no cap.
+	MaxDelay time.Duration `yaml:"maxDelay,omitempty"`
+	// Jitter is the fraction between 0 and 1 by which the delay is randomly shortened,
+	// so concurrent retries don't hit the same endpoint simultaneously.
+	Jitter float64 `yaml:"jitter,omitempty"`
+	// MaxElapsed is the maximum time spent retrying since the first attempt. 0 means no limit.
+	MaxElapsed time.Duration `yaml:"maxElapsed,omitempty"`
 }
 
 // Effector will be the function called by the Retry function
 type Effector func(context.Context) error
 
+// RetryOption configures the behavior of a single retry function
+type RetryOption func(*retryOptions)
+
+type retryOptions struct {
+	retryIf func(error) bool
+	onRetry []func(attempt int, err error, delay time.Duration)
+	budget  *RetryBudget
+}
+
+// RetryIf only retries errors the predicate returns true for.
+// Errors marked as Permanent are never retried.
+func RetryIf(retryable func(error) bool) RetryOption {
+	return func(o *retryOptions) {
+		o.retryIf = retryable
+	}
+}
+
+// OnRetry calls f after every failed attempt that is retried,
+// e.g. to count the retries in a metric.
+func OnRetry(f func(attempt int, err error, delay time.Duration)) RetryOption {
+	return func(o *retryOptions) {
+		o.onRetry = append(o.onRetry, f)
+	}
+}
+
+// WithBudget takes a token of the budget for every retry and
+// stops retrying if the budget is exhausted
+func WithBudget(b *RetryBudget) RetryOption {
+	return func(o *retryOptions) {
+		o.budget = b
+	}
+}
+
 // Retry will retry the run the effector function in an exponential backoff
-func Retry(effector Effector, rc RetryConfig) func(ctx context.Context) error {
+func Retry(effector Effector, rc RetryConfig, opts ...RetryOption) func(ctx context.Context) error {
+	o := &retryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return func(ctx context.Context) error {
 		log := logger.FromContext(ctx)
+		start := time.Now()
 		for r := 1; ; r++ {
 			err := effector(ctx)
-			if err == nil || r > rc.Count {
-				return err
+			if err == nil || r > rc.Count || !o.retryable(err) {
+				return unwrapPermanent(err)
 			}
 
-			delay := getExpBackoff(rc.Delay, r)
+			delay := rc.backoff(r)
+			if after, ok := retryAfterDelay(err); ok && after > delay {
+				delay = after
+				if rc.MaxDelay > 0 && delay > rc.MaxDelay {
+					delay = rc.MaxDelay
+				}
+			}
+			if rc.MaxElapsed > 0 && time.Since(start)+delay > rc.MaxElapsed {
+				log.WarnContext(ctx, "Effector call failed, maximum retry time exceeded", "maxElapsed", rc.MaxElapsed)
+				return err
+			}
+			if o.budget != nil && !o.budget.take() {
+				log.WarnContext(ctx, "Effector call failed, retry budget exhausted")
+				return err
+			}
+			for _, f := range o.onRetry {
+				f(r, err, delay)
+			}
 			log.WarnContext(ctx, fmt.Sprintf("Effector call failed, retrying in %v", delay))
 
+			timer := time.NewTimer(delay)
 			select {
 			case <-ctx.Done():
+				timer.Stop()
 				return ctx.Err()
-			case <-time.After(delay):
+			case <-timer.C:
 			}
 		}
 	}
 }
 
+// retryable returns true if the error should be retried
+func (o *retryOptions) retryable(err error) bool {
+	var pErr *permanentError
+	if errors.As(err, &pErr) {
+		return false
+	}
+	return o.retryIf == nil || o.retryIf(err)
+}
+
+// permanentError is an error that is never retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error as permanent, so Retry returns it without retrying
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent returns true if the error is marked as permanent
+func IsPermanent(err error) bool {
+	var pErr *permanentError
+	return errors.As(err, &pErr)
+}
+
+// unwrapPermanent returns the error marked as permanent or the given error
+func unwrapPermanent(err error) error {
+	var pErr *permanentError
+	if errors.As(err, &pErr) {
+		return pErr.err
+	}
+	return err
+}
+
+// retryAfterError is an error that requests a minimum delay before the next attempt
+type retryAfterError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *retryAfterError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryAfterError) Unwrap() error {
+	return e.err
+}
+
+// RetryAfter requests Retry to wait at least the given delay before retrying the error,
+// e.g. the delay requested by the Retry-After header of a response.
+// The delay is capped by the maximum delay of the retry configuration.
+func RetryAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &retryAfterError{err: err, delay: delay}
+}
+
+// retryAfterDelay returns the delay requested by the error and true if it requests one
+func retryAfterDelay(err error) (time.Duration, bool) {
+	var raErr *retryAfterError
+	if errors.As(err, &raErr) {
+		return raErr.delay, true
+	}
+	return 0, false
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date, into the delay relative to now.
+// It returns false if the value is missing or malformed.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
+
+// RetryableStatus returns true if a request answered with the given HTTP status code
+// is worth retrying: server errors, request timeouts and rate limits
+func RetryableStatus(code int) bool {
+	return code >= http.StatusInternalServerError || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
+
+// randFloat returns a random number in [0, 1). It is replaced in tests.
+var randFloat = rand.Float64 // #nosec G404 // math.rand is fine here, we're not doing encryption
+
+// backoff returns the delay before the given retry iteration,
+// capped by the maximum delay and shortened by the jitter
+func (rc RetryConfig) backoff(iteration int) time.Duration {
+	delay := getExpBackoff(rc.Delay, iteration)
+	if rc.MaxDelay > 0 && delay > rc.MaxDelay {
+		delay = rc.MaxDelay
+	}
+	if jitter := min(max(rc.Jitter, 0), 1); jitter > 0 {
+		delay -= time.Duration(jitter * randFloat() * float64(delay))
+	}
+	return delay
+}
+
 // calculate the exponential delay for a given iteration
 // first iteration is 1
 func getExpBackoff(initialDelay time.Duration, iteration int) time.Duration {
@@ -65,3 +252,43 @@ func getExpBackoff(initialDelay time.Duration, iteration int) time.Duration {
 	}
 	return time.Duration(math.Pow(2, float64(iteration-1))) * initialDelay
 }
+
+// RetryBudget is a token bucket limiting the retries of all retry functions sharing it,
+// so a failing dependency isn't flooded with retries of many concurrent calls.
+type RetryBudget struct {
+	mu sync.Mutex
+	// rate is the number of tokens added per second
+	rate float64
+	// burst is the maximum number of tokens
+	burst  float64
+	tokens float64
+	last   time.Time
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+}
+
+// NewRetryBudget returns a full budget of burst retries, refilled by rate retries per second
+func NewRetryBudget(rate float64, burst int) *RetryBudget {
+	return &RetryBudget{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// take refills the bucket and takes a token if one is available
+func (b *RetryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
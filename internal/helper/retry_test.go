@@ -185,3 +185,265 @@ func Test_getExpBackoff(t *testing.T) {
 		})
 	}
 }
+
+func TestRetry_options(t *testing.T) {
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+
+	tests := []struct {
+		name        string
+		err         error
+		rc          RetryConfig
+		opts        func(retries *[]int) []RetryOption
+		wantRetries int
+		wantErr     error
+	}{
+		{
+			name:        "permanent error isn't retried",
+			err:         Permanent(errFatal),
+			rc:          RetryConfig{Count: 3, Delay: time.Millisecond},
+			wantRetries: 0,
+			wantErr:     errFatal,
+		},
+		{
+			name: "error not matching the predicate isn't retried",
+			err:  errFatal,
+			rc:   RetryConfig{Count: 3, Delay: time.Millisecond},
+			opts: func(_ *[]int) []RetryOption {
+				return []RetryOption{RetryIf(func(err error) bool { return errors.Is(err, errTransient) })}
+			},
+			wantRetries: 0,
+			wantErr:     errFatal,
+		},
+		{
+			name: "error matching the predicate is retried",
+			err:  errTransient,
+			rc:   RetryConfig{Count: 3, Delay: time.Millisecond},
+			opts: func(_ *[]int) []RetryOption {
+				return []RetryOption{RetryIf(func(err error) bool { return errors.Is(err, errTransient) })}
+			},
+			wantRetries: 3,
+			wantErr:     errTransient,
+		},
+		{
+			name: "callback is called for every retry",
+			err:  errTransient,
+			rc:   RetryConfig{Count: 2, Delay: time.Millisecond},
+			opts: func(retries *[]int) []RetryOption {
+				return []RetryOption{OnRetry(func(attempt int, _ error, _ time.Duration) {
+					*retries = append(*retries, attempt)
+				})}
+			},
+			wantRetries: 2,
+			wantErr:     errTransient,
+		},
+		{
+			name:        "retries stop after the maximum elapsed time",
+			err:         errTransient,
+			rc:          RetryConfig{Count: 5, Delay: 10 * time.Millisecond, MaxElapsed: 50 * time.Millisecond},
+			wantRetries: 2,
+			wantErr:     errTransient,
+		},
+		{
+			name: "retries stop when the budget is exhausted",
+			err:  errTransient,
+			rc:   RetryConfig{Count: 5, Delay: time.Millisecond},
+			opts: func(_ *[]int) []RetryOption {
+				b := NewRetryBudget(0, 2)
+				return []RetryOption{WithBudget(b)}
+			},
+			wantRetries: 2,
+			wantErr:     errTransient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			var retries []int
+			var opts []RetryOption
+			if tt.opts != nil {
+				opts = tt.opts(&retries)
+			}
+
+			err := Retry(func(ctx context.Context) error {
+				calls++
+				return tt.err
+			}, tt.rc, opts...)(context.Background())
+
+			if err != tt.wantErr {
+				t.Errorf("Retry() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls-1 != tt.wantRetries {
+				t.Errorf("Retry() retries = %d, want %d", calls-1, tt.wantRetries)
+			}
+			if retries != nil && len(retries) != tt.wantRetries {
+				t.Errorf("OnRetry() calls = %v, want %d", retries, tt.wantRetries)
+			}
+		})
+	}
+}
+
+func TestIsPermanent(t *testing.T) {
+	errFatal := errors.New("fatal")
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "plain", err: errFatal, want: false},
+		{name: "permanent", err: Permanent(errFatal), want: true},
+		{name: "wrapped permanent", err: fmt.Errorf("wrapped: %w", Permanent(errFatal)), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPermanent(tt.err); got != tt.want {
+				t.Errorf("IsPermanent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetry_retryAfter(t *testing.T) {
+	errLimited := errors.New("rate limited")
+	tests := []struct {
+		name      string
+		rc        RetryConfig
+		after     time.Duration
+		wantDelay time.Duration
+	}{
+		{
+			name:      "longer delay is respected",
+			rc:        RetryConfig{Count: 1, Delay: time.Millisecond},
+			after:     20 * time.Millisecond,
+			wantDelay: 20 * time.Millisecond,
+		},
+		{
+			name:      "shorter delay keeps the backoff",
+			rc:        RetryConfig{Count: 1, Delay: 10 * time.Millisecond},
+			after:     time.Millisecond,
+			wantDelay: 10 * time.Millisecond,
+		},
+		{
+			name:      "delay is capped by the maximum delay",
+			rc:        RetryConfig{Count: 1, Delay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+			after:     time.Hour,
+			wantDelay: 5 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delays []time.Duration
+			err := Retry(func(ctx context.Context) error {
+				return RetryAfter(errLimited, tt.after)
+			}, tt.rc, OnRetry(func(_ int, _ error, delay time.Duration) {
+				delays = append(delays, delay)
+			}))(context.Background())
+
+			if !errors.Is(err, errLimited) {
+				t.Errorf("Retry() error = %v, want %v", err, errLimited)
+			}
+			if len(delays) != 1 || delays[0] != tt.wantDelay {
+				t.Errorf("Retry() delays = %v, want [%v]", delays, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 7, 26, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{name: "empty", value: "", wantOk: false},
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantOk: true},
+		{name: "negative seconds", value: "-5", want: 0, wantOk: true},
+		{name: "http date", value: "Fri, 26 Jul 2024 12:00:30 GMT", want: 30 * time.Second, wantOk: true},
+		{name: "past http date", value: "Fri, 26 Jul 2024 11:00:00 GMT", want: 0, wantOk: true},
+		{name: "malformed", value: "soon", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("ParseRetryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_backoff(t *testing.T) {
+	defer func(f func() float64) { randFloat = f }(randFloat)
+	randFloat = func() float64 { return 0.5 }
+
+	tests := []struct {
+		name      string
+		rc        RetryConfig
+		iteration int
+		want      time.Duration
+	}{
+		{
+			name:      "exponential delay",
+			rc:        RetryConfig{Delay: time.Second},
+			iteration: 3,
+			want:      4 * time.Second,
+		},
+		{
+			name:      "capped by the maximum delay",
+			rc:        RetryConfig{Delay: time.Second, MaxDelay: 3 * time.Second},
+			iteration: 3,
+			want:      3 * time.Second,
+		},
+		{
+			name:      "shortened by the jitter",
+			rc:        RetryConfig{Delay: time.Second, Jitter: 0.5},
+			iteration: 3,
+			want:      3 * time.Second,
+		},
+		{
+			name:      "jitter above 1 is capped",
+			rc:        RetryConfig{Delay: time.Second, Jitter: 4},
+			iteration: 1,
+			want:      500 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rc.backoff(tt.iteration); got != tt.want {
+				t.Errorf("backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Now()
+	b := NewRetryBudget(1, 2)
+	b.last = now
+	b.now = func() time.Time { return now }
+
+	for i, want := range []bool{true, true, false} {
+		if got := b.take(); got != want {
+			t.Fatalf("take() #%d = %v, want %v", i, got, want)
+		}
+	}
+
+	now = now.Add(1500 * time.Millisecond)
+	for i, want := range []bool{true, false} {
+		if got := b.take(); got != want {
+			t.Fatalf("take() after refill #%d = %v, want %v", i, got, want)
+		}
+	}
+
+	now = now.Add(time.Hour)
+	for i, want := range []bool{true, true, false} {
+		if got := b.take(); got != want {
+			t.Fatalf("take() after full refill #%d = %v, want %v", i, got, want)
+		}
+	}
+}
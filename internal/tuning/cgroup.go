@@ -0,0 +1,124 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tuning
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// cgroupRoot is the mount point of the cgroup filesystem. With cgroup namespaces,
+	// which containers use by default, it's the cgroup of the container.
+	cgroupRoot = "/sys/fs/cgroup"
+	// unlimited is the threshold from which a cgroup v1 memory limit is considered as not set,
+	// the kernel reports the maximum value rounded down to the page size in this case
+	unlimited = 1 << 62
+)
+
+// cgroup reads the resource limits of a cgroup from the cgroup filesystem
+type cgroup struct {
+	fsys fs.FS
+}
+
+// newCgroup returns the cgroup mounted at the cgroup root
+func newCgroup() cgroup {
+	return cgroup{fsys: os.DirFS(cgroupRoot)}
+}
+
+// cpuQuota returns the number of CPUs the cgroup may use.
+// It returns false if the CPU usage isn't limited.
+func (c cgroup) cpuQuota() (float64, bool, error) {
+	// cgroup v2: "<quota> <period>" or "max <period>"
+	if b, err := fs.ReadFile(c.fsys, "cpu.max"); err == nil {
+		fields := strings.Fields(string(b))
+		if len(fields) != 2 { //nolint:mnd // quota and period
+			return 0, false, fmt.Errorf("unexpected format of cpu.max: %q", b)
+		}
+		if fields[0] == "max" {
+			return 0, false, nil
+		}
+		return quota(fields[0], fields[1])
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return 0, false, err
+	}
+
+	// cgroup v1: a quota of -1 means unlimited
+	q, err := fs.ReadFile(c.fsys, path.Join("cpu", "cpu.cfs_quota_us"))
+	if err != nil {
+		return 0, false, err
+	}
+	p, err := fs.ReadFile(c.fsys, path.Join("cpu", "cpu.cfs_period_us"))
+	if err != nil {
+		return 0, false, err
+	}
+	if strings.TrimSpace(string(q)) == "-1" {
+		return 0, false, nil
+	}
+	return quota(strings.TrimSpace(string(q)), strings.TrimSpace(string(p)))
+}
+
+// quota returns the number of CPUs of the given quota per period
+func quota(q, p string) (float64, bool, error) {
+	quota, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	period, err := strconv.ParseFloat(p, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	if quota <= 0 || period <= 0 {
+		return 0, false, nil
+	}
+	return quota / period, true, nil
+}
+
+// memoryLimit returns the memory limit of the cgroup in bytes.
+// It returns false if the memory usage isn't limited.
+func (c cgroup) memoryLimit() (int64, bool, error) {
+	// cgroup v2: "<bytes>" or "max"
+	b, err := fs.ReadFile(c.fsys, "memory.max")
+	if errors.Is(err, fs.ErrNotExist) {
+		// cgroup v1
+		b, err = fs.ReadFile(c.fsys, path.Join("memory", "memory.limit_in_bytes"))
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	v := strings.TrimSpace(string(b))
+	if v == "max" {
+		return 0, false, nil
+	}
+	limit, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	if limit == 0 || limit >= unlimited || limit > math.MaxInt64 {
+		return 0, false, nil
+	}
+	return int64(limit), true, nil
+}
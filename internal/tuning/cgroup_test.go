@@ -0,0 +1,134 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tuning
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestCgroup_cpuQuota(t *testing.T) {
+	tests := []struct {
+		name        string
+		fsys        fstest.MapFS
+		want        float64
+		wantLimited bool
+		wantErr     bool
+	}{
+		{
+			name:        "v2 quota",
+			fsys:        fstest.MapFS{"cpu.max": {Data: []byte("150000 100000\n")}},
+			want:        1.5,
+			wantLimited: true,
+		},
+		{
+			name: "v2 unlimited",
+			fsys: fstest.MapFS{"cpu.max": {Data: []byte("max 100000\n")}},
+		},
+		{
+			name:    "v2 malformed",
+			fsys:    fstest.MapFS{"cpu.max": {Data: []byte("max")}},
+			wantErr: true,
+		},
+		{
+			name: "v1 quota",
+			fsys: fstest.MapFS{
+				"cpu/cpu.cfs_quota_us":  {Data: []byte("200000\n")},
+				"cpu/cpu.cfs_period_us": {Data: []byte("100000\n")},
+			},
+			want:        2,
+			wantLimited: true,
+		},
+		{
+			name: "v1 unlimited",
+			fsys: fstest.MapFS{
+				"cpu/cpu.cfs_quota_us":  {Data: []byte("-1\n")},
+				"cpu/cpu.cfs_period_us": {Data: []byte("100000\n")},
+			},
+		},
+		{
+			name:    "no cgroup",
+			fsys:    fstest.MapFS{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, limited, err := cgroup{fsys: tt.fsys}.cpuQuota()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cpuQuota() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || limited != tt.wantLimited {
+				t.Errorf("cpuQuota() = %v, %v, want %v, %v", got, limited, tt.want, tt.wantLimited)
+			}
+		})
+	}
+}
+
+func TestCgroup_memoryLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		fsys        fstest.MapFS
+		want        int64
+		wantLimited bool
+		wantErr     bool
+	}{
+		{
+			name:        "v2 limit",
+			fsys:        fstest.MapFS{"memory.max": {Data: []byte("536870912\n")}},
+			want:        536870912,
+			wantLimited: true,
+		},
+		{
+			name: "v2 unlimited",
+			fsys: fstest.MapFS{"memory.max": {Data: []byte("max\n")}},
+		},
+		{
+			name:        "v1 limit",
+			fsys:        fstest.MapFS{"memory/memory.limit_in_bytes": {Data: []byte("268435456\n")}},
+			want:        268435456,
+			wantLimited: true,
+		},
+		{
+			name: "v1 unlimited",
+			fsys: fstest.MapFS{"memory/memory.limit_in_bytes": {Data: []byte("9223372036854771712\n")}},
+		},
+		{
+			name:    "malformed",
+			fsys:    fstest.MapFS{"memory.max": {Data: []byte("lots")}},
+			wantErr: true,
+		},
+		{
+			name:    "no cgroup",
+			fsys:    fstest.MapFS{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, limited, err := cgroup{fsys: tt.fsys}.memoryLimit()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("memoryLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || limited != tt.wantLimited {
+				t.Errorf("memoryLimit() = %v, %v, want %v, %v", got, limited, tt.want, tt.wantLimited)
+			}
+		})
+	}
+}
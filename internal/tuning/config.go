@@ -0,0 +1,52 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tuning
+
+import (
+	"errors"
+)
+
+// DefaultMemoryLimitRatio is the default share of the memory limit of the cgroup used as the soft memory limit
+const DefaultMemoryLimitRatio = 0.9
+
+// ErrInvalidMemoryLimitRatio is returned when the memory limit ratio isn't within (0, 1]
+var ErrInvalidMemoryLimitRatio = errors.New("invalid memory limit ratio")
+
+// Config is the configuration for tuning the Go runtime to the resource limits of the cgroup the sparrow runs in
+type Config struct {
+	// MaxProcs sets GOMAXPROCS to the CPU quota of the cgroup
+	MaxProcs bool `yaml:"maxProcs" mapstructure:"maxProcs"`
+	// MemoryLimit sets GOMEMLIMIT to a share of the memory limit of the cgroup
+	MemoryLimit bool `yaml:"memoryLimit" mapstructure:"memoryLimit"`
+	// MemoryLimitRatio is the share of the memory limit of the cgroup used as GOMEMLIMIT
+	MemoryLimitRatio float64 `yaml:"memoryLimitRatio" mapstructure:"memoryLimitRatio"`
+}
+
+// Enabled returns true if any tuning is enabled
+func (c *Config) Enabled() bool {
+	return c.MaxProcs || c.MemoryLimit
+}
+
+// Validate validates the tuning configuration
+func (c *Config) Validate() error {
+	if c.MemoryLimit && (c.MemoryLimitRatio <= 0 || c.MemoryLimitRatio > 1) {
+		return ErrInvalidMemoryLimitRatio
+	}
+	return nil
+}
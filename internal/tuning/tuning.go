@@ -0,0 +1,97 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tuning
+
+import (
+	"context"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// Apply tunes GOMAXPROCS and GOMEMLIMIT to the resource limits of the cgroup the sparrow runs in.
+// Settings passed via the GOMAXPROCS and GOMEMLIMIT environment variables take precedence.
+// Limits that can't be read are logged and left untouched.
+func Apply(ctx context.Context, cfg Config) { //nolint:gocritic // no performance concerns yet
+	apply(ctx, cfg, newCgroup())
+}
+
+// apply tunes the Go runtime to the limits of the given cgroup
+func apply(ctx context.Context, cfg Config, cg cgroup) { //nolint:gocritic // no performance concerns yet
+	log := logger.FromContext(ctx)
+
+	if cfg.MaxProcs {
+		if procs, ok := maxProcs(ctx, cg); ok {
+			prev := runtime.GOMAXPROCS(procs)
+			log.Info("Set GOMAXPROCS to the CPU quota", "maxProcs", procs, "previous", prev)
+		}
+	}
+
+	if cfg.MemoryLimit {
+		if limit, ok := memoryLimit(ctx, cg, cfg.MemoryLimitRatio); ok {
+			debug.SetMemoryLimit(limit)
+			log.Info("Set GOMEMLIMIT to the share of the memory limit", "memoryLimit", limit, "ratio", cfg.MemoryLimitRatio)
+		}
+	}
+}
+
+// maxProcs returns the GOMAXPROCS matching the CPU quota of the cgroup, rounded down
+// but at least 1 and at most the number of CPUs. It returns false if it shouldn't be changed.
+func maxProcs(ctx context.Context, cg cgroup) (int, bool) {
+	log := logger.FromContext(ctx)
+	if v, ok := os.LookupEnv("GOMAXPROCS"); ok {
+		log.Info("Keeping GOMAXPROCS set via the environment", "maxProcs", v)
+		return 0, false
+	}
+
+	cpus, limited, err := cg.cpuQuota()
+	if err != nil {
+		log.Warn("Failed to read the CPU quota, keeping GOMAXPROCS", "error", err)
+		return 0, false
+	}
+	if !limited {
+		log.Debug("The CPU usage isn't limited, keeping GOMAXPROCS")
+		return 0, false
+	}
+	return min(max(int(math.Floor(cpus)), 1), runtime.NumCPU()), true
+}
+
+// memoryLimit returns the share of the memory limit of the cgroup used as GOMEMLIMIT.
+// It returns false if it shouldn't be changed.
+func memoryLimit(ctx context.Context, cg cgroup, ratio float64) (int64, bool) {
+	log := logger.FromContext(ctx)
+	if v, ok := os.LookupEnv("GOMEMLIMIT"); ok {
+		log.Info("Keeping GOMEMLIMIT set via the environment", "memoryLimit", v)
+		return 0, false
+	}
+
+	limit, limited, err := cg.memoryLimit()
+	if err != nil {
+		log.Warn("Failed to read the memory limit, keeping GOMEMLIMIT", "error", err)
+		return 0, false
+	}
+	if !limited {
+		log.Debug("The memory usage isn't limited, keeping GOMEMLIMIT")
+		return 0, false
+	}
+	return int64(float64(limit) * ratio), true
+}
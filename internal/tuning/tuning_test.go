@@ -0,0 +1,83 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tuning
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMaxProcs(t *testing.T) {
+	ctx := context.Background()
+	quota := func(q string) cgroup {
+		return cgroup{fsys: fstest.MapFS{"cpu.max": {Data: []byte(q + " 100000")}}}
+	}
+
+	if got, ok := maxProcs(ctx, quota("50000")); !ok || got != 1 {
+		t.Errorf("maxProcs() of half a CPU = %d, %v, want 1, true", got, ok)
+	}
+	if got, ok := maxProcs(ctx, quota("100000000")); !ok || got != runtime.NumCPU() {
+		t.Errorf("maxProcs() of more CPUs than available = %d, %v, want %d, true", got, ok, runtime.NumCPU())
+	}
+	if _, ok := maxProcs(ctx, quota("max")); ok {
+		t.Error("maxProcs() changed GOMAXPROCS without a quota")
+	}
+
+	t.Setenv("GOMAXPROCS", "3")
+	if _, ok := maxProcs(ctx, quota("50000")); ok {
+		t.Error("maxProcs() overrode GOMAXPROCS set via the environment")
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	ctx := context.Background()
+	cg := cgroup{fsys: fstest.MapFS{"memory.max": {Data: []byte("1000")}}}
+
+	if got, ok := memoryLimit(ctx, cg, 0.9); !ok || got != 900 {
+		t.Errorf("memoryLimit() = %d, %v, want 900, true", got, ok)
+	}
+
+	t.Setenv("GOMEMLIMIT", "1GiB")
+	if _, ok := memoryLimit(ctx, cg, 0.9); ok {
+		t.Error("memoryLimit() overrode GOMEMLIMIT set via the environment")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "disabled", cfg: Config{}},
+		{name: "valid ratio", cfg: Config{MemoryLimit: true, MemoryLimitRatio: DefaultMemoryLimitRatio}},
+		{name: "full limit", cfg: Config{MemoryLimit: true, MemoryLimitRatio: 1}},
+		{name: "zero ratio", cfg: Config{MemoryLimit: true}, wantErr: true},
+		{name: "ratio above 1", cfg: Config{MemoryLimit: true, MemoryLimitRatio: 1.5}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -20,6 +20,11 @@ package main
 
 import (
 	"github.com/caas-team/sparrow/cmd"
+
+	// Registers the loader types implemented outside of the config package
+	_ "github.com/caas-team/sparrow/pkg/config/azureblob"
+	_ "github.com/caas-team/sparrow/pkg/config/gcs"
+	_ "github.com/caas-team/sparrow/pkg/config/s3"
 )
 
 // Version is the current version of sparrow
@@ -0,0 +1,60 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/api"
+)
+
+// Config is the configuration for the admin listener serving the debug endpoints
+type Config struct {
+	// Enabled is a flag to enable or disable the admin listener
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Address is the address the admin listener is listening on.
+	// It should differ from the address of the API, so it isn't exposed along with it.
+	Address string `yaml:"address" mapstructure:"address"`
+	// Token is the bearer token the requests to the admin listener are authorized with
+	Token string `yaml:"token" mapstructure:"token"`
+	// Tls is the TLS configuration of the admin listener
+	Tls api.TLSConfig `yaml:"tls" mapstructure:"tls"`
+}
+
+// Validate validates the admin configuration
+func (c *Config) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if c.Address == "" {
+		log.Error("The admin listening address cannot be empty")
+		return ErrMissingAddress
+	}
+	if c.Token == "" {
+		log.Error("The admin token cannot be empty")
+		return ErrMissingToken
+	}
+	cfg := c.api()
+	return cfg.Validate()
+}
+
+// api returns the configuration of the API server of the admin listener
+func (c *Config) api() api.Config {
+	return api.Config{ListeningAddress: c.Address, Tls: c.Tls}
+}
@@ -0,0 +1,53 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/api"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{name: "valid", cfg: Config{Enabled: true, Address: ":8081", Token: "secret"}},
+		{name: "missing address", cfg: Config{Enabled: true, Token: "secret"}, wantErr: ErrMissingAddress},
+		{name: "missing token", cfg: Config{Enabled: true, Address: ":8081"}, wantErr: ErrMissingToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("tls without certificate", func(t *testing.T) {
+		cfg := Config{Enabled: true, Address: ":8081", Token: "secret", Tls: api.TLSConfig{Enabled: true}}
+		if err := cfg.Validate(context.Background()); err == nil {
+			t.Error("Validate() expected an error")
+		}
+	})
+}
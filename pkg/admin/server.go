@@ -0,0 +1,169 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/api"
+)
+
+// Server is the admin listener serving the profiling, runtime debug and snapshot export endpoints.
+// Every request except the liveness probe at "/" requires the configured bearer token.
+type Server struct {
+	api   api.API
+	token string
+	// routes are the routes added by other components, e.g. the snapshot export
+	routes []api.Route
+}
+
+// NewServer creates a new admin server
+func NewServer(cfg *Config) *Server {
+	return &Server{
+		api:   api.New(cfg.api()),
+		token: cfg.Token,
+	}
+}
+
+// Routes returns the routes of the admin server
+func (s *Server) Routes() []api.Route {
+	routes := []api.Route{
+		{Path: "/debug/pprof/*", Method: "*", Handler: s.authorize(pprof.Index)},
+		{Path: "/debug/pprof/cmdline", Method: "*", Handler: s.authorize(pprof.Cmdline)},
+		{Path: "/debug/pprof/profile", Method: "*", Handler: s.authorize(pprof.Profile)},
+		{Path: "/debug/pprof/symbol", Method: "*", Handler: s.authorize(pprof.Symbol)},
+		{Path: "/debug/pprof/trace", Method: "*", Handler: s.authorize(pprof.Trace)},
+		{Path: "/debug/runtime", Method: http.MethodGet, Handler: s.authorize(handleRuntime)},
+	}
+	for _, route := range s.routes {
+		route.Handler = s.authorize(route.Handler)
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// Handle adds routes to be served by the admin server. Like the debug endpoints,
+// they require the bearer token. Must be called before the server runs.
+func (s *Server) Handle(routes ...api.Route) {
+	s.routes = append(s.routes, routes...)
+}
+
+// Run registers the routes of the admin server and serves them.
+// Blocks until the context is done.
+func (s *Server) Run(ctx context.Context) error {
+	if err := s.api.RegisterRoutes(ctx, s.Routes()...); err != nil {
+		logger.FromContext(ctx).Error("Error while registering admin routes", "error", err)
+		return err
+	}
+	return s.api.Run(ctx)
+}
+
+// Shutdown shuts down the admin server gracefully
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.api.Shutdown(ctx)
+}
+
+// authorize rejects requests without the configured bearer token
+func (s *Server) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			log := logger.FromContext(r.Context())
+			log.Warn("Rejected unauthorized admin request", "path", r.URL.Path)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			if _, err := w.Write([]byte(http.StatusText(http.StatusUnauthorized))); err != nil {
+				log.Error("Failed to write response", "error", err)
+			}
+			return
+		}
+		next(w, r)
+	}
+}
+
+// RuntimeSnapshot is a snapshot of the state of the Go runtime
+type RuntimeSnapshot struct {
+	// Timestamp is the time the snapshot was taken
+	Timestamp time.Time `json:"timestamp"`
+	// Goroutines is the number of existing goroutines
+	Goroutines int `json:"goroutines"`
+	// MaxProcs is the value of GOMAXPROCS
+	MaxProcs int `json:"maxProcs"`
+	// MemoryLimit is the value of GOMEMLIMIT in bytes
+	MemoryLimit int64 `json:"memoryLimit"`
+	// Heap is the state of the heap
+	Heap HeapSnapshot `json:"heap"`
+}
+
+// HeapSnapshot is a snapshot of the state of the heap
+type HeapSnapshot struct {
+	// Alloc is the number of bytes of allocated heap objects
+	Alloc uint64 `json:"alloc"`
+	// Sys is the number of bytes of heap memory obtained from the OS
+	Sys uint64 `json:"sys"`
+	// Objects is the number of allocated heap objects
+	Objects uint64 `json:"objects"`
+	// NextGC is the target heap size of the next GC cycle
+	NextGC uint64 `json:"nextGC"`
+	// NumGC is the number of completed GC cycles
+	NumGC uint32 `json:"numGC"`
+	// LastGC is the time the last GC cycle finished
+	LastGC time.Time `json:"lastGC"`
+}
+
+// handleRuntime returns a snapshot of the state of the Go runtime
+func handleRuntime(w http.ResponseWriter, r *http.Request) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	snap := RuntimeSnapshot{
+		Timestamp:   time.Now().UTC(),
+		Goroutines:  runtime.NumGoroutine(),
+		MaxProcs:    runtime.GOMAXPROCS(0),
+		MemoryLimit: debug.SetMemoryLimit(-1),
+		Heap: HeapSnapshot{
+			Alloc:   m.HeapAlloc,
+			Sys:     m.HeapSys,
+			Objects: m.HeapObjects,
+			NextGC:  m.NextGC,
+			NumGC:   m.NumGC,
+			LastGC:  time.Unix(0, int64(m.LastGC)).UTC(), // #nosec G115 // Nanoseconds since the epoch fit into int64
+		},
+	}
+
+	log := logger.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(snap); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/api"
+)
+
+func TestServer_authorize(t *testing.T) {
+	s := NewServer(&Config{Address: ":8081", Token: "secret"})
+	tests := []struct {
+		name     string
+		header   string
+		wantCode int
+	}{
+		{name: "valid token", header: "Bearer secret", wantCode: http.StatusOK},
+		{name: "invalid token", header: "Bearer other", wantCode: http.StatusUnauthorized},
+		{name: "basic auth", header: "Basic c2VjcmV0", wantCode: http.StatusUnauthorized},
+		{name: "missing header", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/debug/runtime", http.NoBody)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			s.authorize(handleRuntime)(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("authorize() = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Error("Expected the WWW-Authenticate header to be set")
+			}
+		})
+	}
+}
+
+func TestHandleRuntime(t *testing.T) {
+	w := httptest.NewRecorder()
+	handleRuntime(w, httptest.NewRequest(http.MethodGet, "/debug/runtime", http.NoBody))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("handleRuntime() = %d, want %d", w.Code, http.StatusOK)
+	}
+	var snap RuntimeSnapshot
+	if err := json.Unmarshal(w.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if snap.Goroutines < 1 || snap.MaxProcs < 1 || snap.Heap.Alloc == 0 {
+		t.Errorf("handleRuntime() returned an incomplete snapshot: %+v", snap)
+	}
+}
+
+func TestServer_Routes(t *testing.T) {
+	s := NewServer(&Config{Address: ":8081", Token: "secret"})
+	s.Handle(api.Route{Path: "/v1/admin/export", Method: http.MethodGet, Handler: func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}})
+	handled := false
+	for _, route := range s.Routes() {
+		handled = handled || route.Path == "/v1/admin/export"
+		r := httptest.NewRequest(http.MethodGet, route.Path, http.NoBody)
+		w := httptest.NewRecorder()
+
+		route.Handler(w, r)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Route %s served an unauthorized request with %d", route.Path, w.Code)
+		}
+	}
+	if !handled {
+		t.Error("Expected the handled route to be served")
+	}
+}
@@ -46,12 +46,37 @@ type api struct {
 type Config struct {
 	ListeningAddress string    `yaml:"address" mapstructure:"address"`
 	Tls              TLSConfig `yaml:"tls" mapstructure:"tls"`
+	// ReadTimeout is the maximum duration for reading a request, including its body
+	ReadTimeout time.Duration `yaml:"readTimeout" mapstructure:"readTimeout"`
+	// WriteTimeout is the maximum duration before timing out the writes of a response
+	WriteTimeout time.Duration `yaml:"writeTimeout" mapstructure:"writeTimeout"`
+	// IdleTimeout is the maximum duration to wait for the next request on a keep-alive connection
+	IdleTimeout time.Duration `yaml:"idleTimeout" mapstructure:"idleTimeout"`
+	// MaxHeaderBytes is the maximum size of the request headers in bytes
+	MaxHeaderBytes int `yaml:"maxHeaderBytes" mapstructure:"maxHeaderBytes"`
+	// MaxBodyBytes is the maximum size of a request body in bytes
+	MaxBodyBytes int `yaml:"maxBodyBytes" mapstructure:"maxBodyBytes"`
+	// RunToken is the bearer token authorizing checks to be run on demand. Running on demand is disabled if empty.
+	RunToken string `yaml:"runToken" mapstructure:"runToken"`
 }
 
 type TLSConfig struct {
 	Enabled  bool   `yaml:"enabled" mapstructure:"enabled"`
 	CertPath string `yaml:"certPath" mapstructure:"certPath"`
 	KeyPath  string `yaml:"keyPath" mapstructure:"keyPath"`
+	// Hosts are additional hostnames the API is served under with their own certificates.
+	// The certificate is selected by the server name (SNI) the client requests, the certificate
+	// of the CertPath and KeyPath is served to clients requesting any other or no server name.
+	Hosts []TLSHost `yaml:"hosts,omitempty" mapstructure:"hosts"`
+}
+
+// TLSHost is a set of hostnames the API is served under with a distinct certificate
+type TLSHost struct {
+	// Names are the server names the certificate is served for.
+	// A wildcard name like *.example.com matches a single label.
+	Names    []string `yaml:"names" mapstructure:"names"`
+	CertPath string   `yaml:"certPath" mapstructure:"certPath"`
+	KeyPath  string   `yaml:"keyPath" mapstructure:"keyPath"`
 }
 
 const (
@@ -59,6 +84,17 @@ const (
 	shutdownTimeout   = 30 * time.Second
 )
 
+// The defaults of the server limits, applied if a limit isn't configured
+const (
+	DefaultReadTimeout  = 30 * time.Second
+	DefaultWriteTimeout = 2 * time.Minute
+	DefaultIdleTimeout  = 2 * time.Minute
+	// DefaultMaxHeaderBytes is 1 MiB
+	DefaultMaxHeaderBytes = 1 << 20
+	// DefaultMaxBodyBytes is 10 MiB, which fits the submissions pushed to a hub
+	DefaultMaxBodyBytes = 10 << 20
+)
+
 func (a *Config) Validate() error {
 	if a.ListeningAddress == "" {
 		return fmt.Errorf("listening address cannot be empty")
@@ -70,16 +106,54 @@ func (a *Config) Validate() error {
 		if a.Tls.KeyPath == "" {
 			return fmt.Errorf("tls key path cannot be empty")
 		}
+		if err := a.Tls.validateHosts(); err != nil {
+			return err
+		}
+	}
+	if a.ReadTimeout < 0 || a.WriteTimeout < 0 || a.IdleTimeout < 0 {
+		return fmt.Errorf("timeouts cannot be negative")
+	}
+	if a.MaxHeaderBytes < 0 || a.MaxBodyBytes < 0 {
+		return fmt.Errorf("size limits cannot be negative")
 	}
 	return nil
 }
 
+// applyDefaults sets the unset limits to their defaults
+func (a *Config) applyDefaults() {
+	if a.ReadTimeout == 0 {
+		a.ReadTimeout = DefaultReadTimeout
+	}
+	if a.WriteTimeout == 0 {
+		a.WriteTimeout = DefaultWriteTimeout
+	}
+	if a.IdleTimeout == 0 {
+		a.IdleTimeout = DefaultIdleTimeout
+	}
+	if a.MaxHeaderBytes == 0 {
+		a.MaxHeaderBytes = DefaultMaxHeaderBytes
+	}
+	if a.MaxBodyBytes == 0 {
+		a.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+}
+
 // New creates a new api
-func New(cfg Config) API {
+func New(cfg Config) API { //nolint:gocritic // no performance concerns yet
+	cfg.applyDefaults()
 	r := chi.NewRouter()
+	r.Use(limitBody(int64(cfg.MaxBodyBytes)))
 
 	return &api{
-		server:    &http.Server{Addr: cfg.ListeningAddress, Handler: r, ReadHeaderTimeout: readHeaderTimeout},
+		server: &http.Server{
+			Addr:              cfg.ListeningAddress,
+			Handler:           r,
+			ReadHeaderTimeout: readHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		},
 		router:    r,
 		tlsConfig: cfg.Tls,
 	}
@@ -100,7 +174,7 @@ func (a *api) Run(ctx context.Context) error {
 		defer close(cErr)
 		log.Info("Serving Api", "addr", a.server.Addr)
 		if a.tlsConfig.Enabled {
-			if err := a.server.ListenAndServeTLS(a.tlsConfig.CertPath, a.tlsConfig.KeyPath); err != nil {
+			if err := a.serveTLS(); err != nil {
 				log.Error("Failed to serve api", "error", err, "scheme", "https")
 				cErr <- err
 			}
@@ -180,6 +254,22 @@ func (a *api) registerDefaultRoute(route Route) (err error) {
 	return nil
 }
 
+// limitBody limits the size of the request bodies to n bytes.
+// Requests announcing a larger body are rejected with 413 Request Entity Too Large,
+// reading beyond the limit of other requests fails.
+func limitBody(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // OkHandler returns a handler that will serve status ok
 func OkHandler(ctx context.Context) http.Handler {
 	log := logger.FromContext(ctx)
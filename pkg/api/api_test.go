@@ -21,8 +21,11 @@ package api
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -247,9 +250,28 @@ func TestConfig_Validate(t *testing.T) {
 		{"Empty certpath", Config{Tls: TLSConfig{Enabled: true}}, true},
 		{"Empty keypath", Config{Tls: TLSConfig{Enabled: true}}, true},
 
+		{"Negative timeout", Config{ListeningAddress: ":8080", WriteTimeout: -time.Second}, true},
+		{"Negative body limit", Config{ListeningAddress: ":8080", MaxBodyBytes: -1}, true},
+
 		{"Valid config", Config{ListeningAddress: ":8080"}, false},
 		{"Valid tls config", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: true, CertPath: "./mycert.pem", KeyPath: "mykey.key"}}, false},
 		{"Valid tls config without tls", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: false}}, false},
+		{"Valid tls hosts", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: true, CertPath: "./mycert.pem", KeyPath: "mykey.key", Hosts: []TLSHost{
+			{Names: []string{"sparrow.partner.example.com", "*.partner.example.com"}, CertPath: "partner.pem", KeyPath: "partner.key"},
+		}}}, false},
+		{"Tls host without names", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: true, CertPath: "./mycert.pem", KeyPath: "mykey.key", Hosts: []TLSHost{
+			{CertPath: "partner.pem", KeyPath: "partner.key"},
+		}}}, true},
+		{"Tls host without key", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: true, CertPath: "./mycert.pem", KeyPath: "mykey.key", Hosts: []TLSHost{
+			{Names: []string{"sparrow.partner.example.com"}, CertPath: "partner.pem"},
+		}}}, true},
+		{"Invalid tls host name", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: true, CertPath: "./mycert.pem", KeyPath: "mykey.key", Hosts: []TLSHost{
+			{Names: []string{"*.*.example.com"}, CertPath: "partner.pem", KeyPath: "partner.key"},
+		}}}, true},
+		{"Duplicate tls host name", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: true, CertPath: "./mycert.pem", KeyPath: "mykey.key", Hosts: []TLSHost{
+			{Names: []string{"sparrow.example.com"}, CertPath: "a.pem", KeyPath: "a.key"},
+			{Names: []string{"Sparrow.example.com"}, CertPath: "b.pem", KeyPath: "b.key"},
+		}}}, true},
 	}
 
 	for _, c := range cases {
@@ -261,3 +283,108 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_limits(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want Config
+	}{
+		{
+			name: "defaults",
+			cfg:  Config{ListeningAddress: ":8080"},
+			want: Config{
+				ReadTimeout:    DefaultReadTimeout,
+				WriteTimeout:   DefaultWriteTimeout,
+				IdleTimeout:    DefaultIdleTimeout,
+				MaxHeaderBytes: DefaultMaxHeaderBytes,
+			},
+		},
+		{
+			name: "configured",
+			cfg: Config{
+				ListeningAddress: ":8080",
+				ReadTimeout:      time.Second,
+				WriteTimeout:     2 * time.Second,
+				IdleTimeout:      3 * time.Second,
+				MaxHeaderBytes:   1024,
+			},
+			want: Config{
+				ReadTimeout:    time.Second,
+				WriteTimeout:   2 * time.Second,
+				IdleTimeout:    3 * time.Second,
+				MaxHeaderBytes: 1024,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := New(tt.cfg).(*api).server
+			got := Config{
+				ReadTimeout:    srv.ReadTimeout,
+				WriteTimeout:   srv.WriteTimeout,
+				IdleTimeout:    srv.IdleTimeout,
+				MaxHeaderBytes: srv.MaxHeaderBytes,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() server limits = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPI_bodyLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		// chunked hides the length of the body, so the limit applies while reading it
+		chunked    bool
+		wantStatus int
+	}{
+		{
+			name:       "within limit",
+			body:       "0123456789",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "announced body too large",
+			body:       "0123456789a",
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "chunked body too large",
+			body:       "0123456789a",
+			chunked:    true,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(Config{ListeningAddress: ":8080", MaxBodyBytes: 10}).(*api)
+			err := a.RegisterRoutes(context.Background(), Route{
+				Path: "/body", Method: http.MethodPost,
+				Handler: func(w http.ResponseWriter, r *http.Request) {
+					if _, err := io.ReadAll(r.Body); err != nil {
+						http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to register routes: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/body", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			a.router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
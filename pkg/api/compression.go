@@ -0,0 +1,181 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// EncodingZstd is the content coding of zstd (RFC 8878) compressed responses
+	EncodingZstd = "zstd"
+	// EncodingGzip is the content coding of gzip compressed responses
+	EncodingGzip = "gzip"
+)
+
+// encodings are the supported content codings in the order of preference if their quality is equal
+var encodings = []string{EncodingZstd, EncodingGzip}
+
+var (
+	// zstdPool holds the zstd encoders, which are expensive to create
+	zstdPool = sync.Pool{
+		New: func() any {
+			enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+			return enc
+		},
+	}
+	// gzipPool holds the gzip writers
+	gzipPool = sync.Pool{
+		New: func() any { return gzip.NewWriter(nil) },
+	}
+)
+
+// Compress wraps the handler, so its responses are compressed with the content coding
+// negotiated via the Accept-Encoding header of the request. Responses aren't compressed
+// if the request doesn't accept any of the supported codings.
+func Compress(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := NegotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next(w, r)
+			return
+		}
+
+		cw := newCompressWriter(w, encoding)
+		defer func() {
+			_ = cw.Close()
+		}()
+		next(cw, r)
+	}
+}
+
+// NegotiateEncoding returns the supported content coding with the highest quality
+// in the given Accept-Encoding header or an empty string if none is accepted
+func NegotiateEncoding(accept string) string {
+	// qualities are the accepted codings with their quality, "*" applies to all codings not listed
+	qualities := map[string]float64{}
+	for _, part := range strings.Split(accept, ",") {
+		coding, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		qualities[coding] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, e := range encodings {
+		q, ok := qualities[e]
+		if !ok {
+			q = qualities["*"]
+		}
+		if q > bestQ {
+			best, bestQ = e, q
+		}
+	}
+	return best
+}
+
+// compressWriter compresses everything written to the response
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	// writer is the compressing writer, which is created with the first write
+	writer io.WriteCloser
+}
+
+// newCompressWriter returns a response writer compressing with the given content coding
+func newCompressWriter(w http.ResponseWriter, encoding string) *compressWriter {
+	return &compressWriter{ResponseWriter: w, encoding: encoding}
+}
+
+// WriteHeader sets the Content-Encoding header, unless the response has no body
+func (c *compressWriter) WriteHeader(status int) {
+	if c.writer == nil && status != http.StatusNoContent && status != http.StatusNotModified {
+		c.init()
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+// Write compresses the data and writes it to the response
+func (c *compressWriter) Write(b []byte) (int, error) {
+	if c.writer == nil {
+		c.init()
+	}
+	return c.writer.Write(b)
+}
+
+// Flush flushes the compressed data written so far to the client
+func (c *compressWriter) Flush() {
+	if f, ok := c.writer.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close finishes the compressed stream and returns the compressing writer to its pool
+func (c *compressWriter) Close() error {
+	if c.writer == nil {
+		return nil
+	}
+	err := c.writer.Close()
+	switch w := c.writer.(type) {
+	case *zstd.Encoder:
+		w.Reset(nil)
+		zstdPool.Put(w)
+	case *gzip.Writer:
+		w.Reset(nil)
+		gzipPool.Put(w)
+	}
+	return err
+}
+
+// init creates the compressing writer and sets the headers of the compressed response
+func (c *compressWriter) init() {
+	h := c.Header()
+	h.Set("Content-Encoding", c.encoding)
+	h.Del("Content-Length")
+
+	switch c.encoding {
+	case EncodingZstd:
+		enc := zstdPool.Get().(*zstd.Encoder)
+		enc.Reset(c.ResponseWriter)
+		c.writer = enc
+	default:
+		gz := gzipPool.Get().(*gzip.Writer)
+		gz.Reset(c.ResponseWriter)
+		c.writer = gz
+	}
+}
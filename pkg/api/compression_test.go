@@ -0,0 +1,131 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "no accept encoding header", accept: "", want: ""},
+		{name: "gzip", accept: "gzip", want: EncodingGzip},
+		{name: "zstd", accept: "zstd", want: EncodingZstd},
+		{name: "zstd preferred", accept: "gzip, zstd", want: EncodingZstd},
+		{name: "any", accept: "*", want: EncodingZstd},
+		{name: "any but zstd", accept: "*, zstd;q=0", want: EncodingGzip},
+		{name: "highest quality", accept: "zstd;q=0.5, gzip;q=1", want: EncodingGzip},
+		{name: "not acceptable", accept: "gzip;q=0", want: ""},
+		{name: "unsupported", accept: "br, identity", want: ""},
+		{name: "invalid quality", accept: "zstd;q=abc, gzip;q=0.1", want: EncodingGzip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NegotiateEncoding(tt.accept); got != tt.want {
+				t.Errorf("NegotiateEncoding(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	body := strings.Repeat(`{"https://example.com":{"code":200}}`, 100)
+	handler := Compress(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	})
+
+	tests := []struct {
+		name     string
+		accept   string
+		encoding string
+		decode   func(r io.Reader) (io.Reader, error)
+	}{
+		{
+			name:     "uncompressed",
+			accept:   "",
+			encoding: "",
+			decode:   func(r io.Reader) (io.Reader, error) { return r, nil },
+		},
+		{
+			name:     "gzip",
+			accept:   "gzip",
+			encoding: EncodingGzip,
+			decode:   func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		},
+		{
+			name:     "zstd",
+			accept:   "zstd, gzip",
+			encoding: EncodingZstd,
+			decode: func(r io.Reader) (io.Reader, error) {
+				d, err := zstd.NewReader(r)
+				if err != nil {
+					return nil, err
+				}
+				return d.IOReadCloser(), nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/metrics/health", http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept-Encoding", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			handler(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+			if got := resp.Header.Get("Content-Encoding"); got != tt.encoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.encoding)
+			}
+			if got := resp.Header.Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want %q", got, "Accept-Encoding")
+			}
+
+			r, err := tt.decode(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to create decoder: %v", err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("Failed to decode body: %v", err)
+			}
+			if string(got) != body {
+				t.Errorf("Decoded body = %q, want %q", got, body)
+			}
+		})
+	}
+}
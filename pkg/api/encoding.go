@@ -0,0 +1,187 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	// ContentTypeJSON is the media type of JSON encoded responses
+	ContentTypeJSON = "application/json"
+	// ContentTypeCBOR is the media type of CBOR (RFC 8949) encoded responses
+	ContentTypeCBOR = "application/cbor"
+	// ContentTypeMsgpack is the media type of MessagePack encoded responses
+	ContentTypeMsgpack = "application/msgpack"
+)
+
+// ResultContentTypes are the media types the results can be encoded in
+var ResultContentTypes = []string{ContentTypeJSON, ContentTypeCBOR, ContentTypeMsgpack}
+
+// contentTypeAliases maps the accepted media types to the ones used in the response
+var contentTypeAliases = map[string]string{
+	ContentTypeJSON:           ContentTypeJSON,
+	ContentTypeCBOR:           ContentTypeCBOR,
+	ContentTypeMsgpack:        ContentTypeMsgpack,
+	"application/x-msgpack":   ContentTypeMsgpack,
+	"application/vnd.msgpack": ContentTypeMsgpack,
+}
+
+var (
+	cborHandle    = &codec.CborHandle{}
+	msgpackHandle = &codec.MsgpackHandle{WriteExt: true}
+)
+
+const (
+	// batchSize is the number of entries of a map that are encoded
+	// and written to the response at once when streaming the map
+	batchSize = 256
+	// maxPooledBufferSize is the size up to which the buffers are returned to the pool,
+	// so a batch of large entries doesn't keep its buffer allocated
+	maxPooledBufferSize = 1 << 20
+)
+
+// bufferPool holds the buffers the batches of map entries are encoded in
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// Encoder encodes values to the response
+type Encoder interface {
+	Encode(v any) error
+}
+
+// NewEncoder returns an encoder writing in the format requested by the Accept header
+// of the request and sets the Content-Type header of the response accordingly.
+// JSON is used if the request doesn't accept any of the supported formats.
+func NewEncoder(w http.ResponseWriter, r *http.Request) Encoder {
+	contentType := Negotiate(r.Header.Get("Accept"))
+	w.Header().Set("Content-Type", contentType)
+	return newEncoder(w, contentType)
+}
+
+// EncodeMap writes the map to the response in the format requested by the Accept header of the
+// request like NewEncoder. JSON is streamed: the entries are encoded in batches in the order of
+// their keys and every batch is written and flushed on its own, so large maps aren't held in
+// memory as a whole. The output is the same as encoding the map at once. If a batch fails to
+// encode, previous batches have been written already.
+func EncodeMap[V any](w http.ResponseWriter, r *http.Request, m map[string]V) error {
+	contentType := Negotiate(r.Header.Get("Accept"))
+	w.Header().Set("Content-Type", contentType)
+	if contentType != ContentTypeJSON || len(m) <= batchSize {
+		// The binary encoders already write while encoding
+		return newEncoder(w, contentType).Encode(m)
+	}
+	return streamJSON(w, m)
+}
+
+// streamJSON writes the map as indented JSON in batches of entries
+func streamJSON[V any](w io.Writer, m map[string]V) error {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buf)
+		}
+	}()
+	enc := json.NewEncoder(buf)
+	enc.SetIndent("", "  ")
+
+	keys := slices.Sorted(maps.Keys(m))
+	batch := make(map[string]V, batchSize)
+	for i := 0; i < len(keys); i += batchSize {
+		clear(batch)
+		for _, k := range keys[i:min(i+batchSize, len(keys))] {
+			batch[k] = m[k]
+		}
+
+		buf.Reset()
+		if err := enc.Encode(batch); err != nil {
+			return err
+		}
+		sep := ",\n"
+		if i == 0 {
+			sep = "{\n"
+		}
+		if _, err := io.WriteString(w, sep); err != nil {
+			return err
+		}
+		// The entries of every batch are enclosed in "{\n" and "\n}\n"
+		if _, err := w.Write(buf.Bytes()[2 : buf.Len()-3]); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	_, err := io.WriteString(w, "\n}\n")
+	return err
+}
+
+// newEncoder returns an encoder writing in the format of the given media type
+func newEncoder(w io.Writer, contentType string) Encoder {
+	switch contentType {
+	case ContentTypeCBOR:
+		return codec.NewEncoder(w, cborHandle)
+	case ContentTypeMsgpack:
+		return codec.NewEncoder(w, msgpackHandle)
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc
+	}
+}
+
+// Negotiate returns the supported media type with the highest quality
+// in the given Accept header or JSON if none is supported
+func Negotiate(accept string) string {
+	best, bestQ := ContentTypeJSON, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		contentType, ok := contentTypeAliases[mediaType]
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > bestQ {
+			best, bestQ = contentType, q
+		}
+	}
+	return best
+}
@@ -0,0 +1,216 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ugorji/go/codec"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "no accept header", accept: "", want: ContentTypeJSON},
+		{name: "any", accept: "*/*", want: ContentTypeJSON},
+		{name: "json", accept: "application/json", want: ContentTypeJSON},
+		{name: "cbor", accept: "application/cbor", want: ContentTypeCBOR},
+		{name: "msgpack", accept: "application/msgpack", want: ContentTypeMsgpack},
+		{name: "msgpack alias", accept: "application/x-msgpack", want: ContentTypeMsgpack},
+		{name: "unsupported", accept: "text/html", want: ContentTypeJSON},
+		{name: "first supported", accept: "text/html, application/cbor, application/json", want: ContentTypeCBOR},
+		{name: "highest quality", accept: "application/json;q=0.5, application/msgpack;q=0.9", want: ContentTypeMsgpack},
+		{name: "invalid quality", accept: "application/cbor;q=abc, application/json;q=0.1", want: ContentTypeJSON},
+		{name: "malformed", accept: ";;, application/cbor", want: ContentTypeCBOR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.accept); got != tt.want {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEncoder(t *testing.T) {
+	type data struct {
+		Total float64 `json:"total"`
+		Error *string `json:"error,omitempty"`
+	}
+	type result struct {
+		Data      map[string]data `json:"data"`
+		Timestamp time.Time       `json:"timestamp"`
+	}
+	ts := time.Date(2024, 7, 26, 15, 0, 0, 0, time.UTC)
+	in := result{Data: map[string]data{"example.com": {Total: 1.5}}, Timestamp: ts}
+
+	tests := []struct {
+		name   string
+		accept string
+		decode func(b []byte, v any) error
+	}{
+		{
+			name:   "json",
+			accept: ContentTypeJSON,
+			decode: json.Unmarshal,
+		},
+		{
+			name:   "cbor",
+			accept: ContentTypeCBOR,
+			decode: func(b []byte, v any) error {
+				return codec.NewDecoderBytes(b, &codec.CborHandle{}).Decode(v)
+			},
+		},
+		{
+			name:   "msgpack",
+			accept: ContentTypeMsgpack,
+			decode: func(b []byte, v any) error {
+				return codec.NewDecoderBytes(b, &codec.MsgpackHandle{WriteExt: true}).Decode(v)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			r.Header.Set("Accept", tt.accept)
+
+			if err := NewEncoder(w, r).Encode(in); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.accept {
+				t.Errorf("Content-Type = %q, want %q", got, tt.accept)
+			}
+
+			var out result
+			if err := tt.decode(w.Body.Bytes(), &out); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !out.Timestamp.Equal(ts) || out.Data["example.com"].Total != 1.5 {
+				t.Errorf("Decoded response = %+v, want %+v", out, in)
+			}
+		})
+	}
+}
+
+func TestEncodeMap(t *testing.T) {
+	tests := []struct {
+		name string
+		in   map[string]benchResult
+	}{
+		{name: "empty", in: map[string]benchResult{}},
+		{name: "single entry", in: newResults(1)},
+		{name: "escaped keys", in: map[string]benchResult{"<a&b>": {Total: 1}, "\"quoted\"": {Total: 2}}},
+		{name: "multiple batches", in: newResults(1000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := httptest.NewRecorder()
+			if err := NewEncoder(want, httptest.NewRequest(http.MethodGet, "/", http.NoBody)).Encode(tt.in); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			if err := EncodeMap(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody), tt.in); err != nil {
+				t.Fatalf("EncodeMap() error = %v", err)
+			}
+			if got := w.Header().Get("Content-Type"); got != ContentTypeJSON {
+				t.Errorf("Content-Type = %q, want %q", got, ContentTypeJSON)
+			}
+			if !bytes.Equal(w.Body.Bytes(), want.Body.Bytes()) {
+				t.Errorf("EncodeMap() = %s, want %s", w.Body.String(), want.Body.String())
+			}
+		})
+	}
+}
+
+func TestEncodeMap_binary(t *testing.T) {
+	in := newResults(1000)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	r.Header.Set("Accept", ContentTypeCBOR)
+
+	if err := EncodeMap(w, r, in); err != nil {
+		t.Fatalf("EncodeMap() error = %v", err)
+	}
+	var out map[string]benchResult
+	if err := codec.NewDecoderBytes(w.Body.Bytes(), &codec.CborHandle{}).Decode(&out); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(out) != len(in) {
+		t.Errorf("Decoded %d entries, want %d", len(out), len(in))
+	}
+}
+
+// BenchmarkEncodeMap compares encoding a large result map at once with streaming it
+func BenchmarkEncodeMap(b *testing.B) {
+	in := newResults(5000)
+	r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+
+	b.Run("encoder", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if err := NewEncoder(discardWriter{}, r).Encode(in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("stream", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if err := EncodeMap(discardWriter{}, r, in); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+type benchResult struct {
+	Code  int     `json:"code"`
+	Error *string `json:"error"`
+	Total float64 `json:"total"`
+}
+
+// newResults returns a map of n results keyed by target
+func newResults(n int) map[string]benchResult {
+	results := make(map[string]benchResult, n)
+	for i := range n {
+		results[fmt.Sprintf("https://target-%d.example.com", i)] = benchResult{Code: http.StatusOK, Total: float64(i) / 1000}
+	}
+	return results
+}
+
+// discardWriter is a response writer discarding the response
+type discardWriter struct{}
+
+func (discardWriter) Header() http.Header         { return http.Header{} }
+func (discardWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (discardWriter) WriteHeader(int)             {}
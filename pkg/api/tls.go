@@ -0,0 +1,120 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// validateHosts validates the hostnames served with their own certificates
+func (t *TLSConfig) validateHosts() error {
+	seen := map[string]struct{}{}
+	for i, h := range t.Hosts {
+		if len(h.Names) == 0 {
+			return fmt.Errorf("tls host %d needs at least one name", i)
+		}
+		if h.CertPath == "" || h.KeyPath == "" {
+			return fmt.Errorf("tls host %d needs a cert and key path", i)
+		}
+		for _, n := range h.Names {
+			name := strings.ToLower(n)
+			if !validServerName(name) {
+				return fmt.Errorf("tls host name %q is invalid", n)
+			}
+			if _, ok := seen[name]; ok {
+				return fmt.Errorf("tls host name %q is configured multiple times", n)
+			}
+			seen[name] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// validServerName returns whether the name is a hostname or a wildcard of a single leftmost label
+func validServerName(name string) bool {
+	name = strings.TrimPrefix(name, "*.")
+	if name == "" || strings.ContainsAny(name, "*:/ ") {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// serveTLS serves the API via https. The certificates of the hosts are selected by the server name the client requests.
+func (a *api) serveTLS() error {
+	if len(a.tlsConfig.Hosts) == 0 {
+		return a.server.ListenAndServeTLS(a.tlsConfig.CertPath, a.tlsConfig.KeyPath)
+	}
+
+	cfg, err := newSNIConfig(a.tlsConfig)
+	if err != nil {
+		return err
+	}
+	a.server.TLSConfig = cfg
+	return a.server.ListenAndServeTLS("", "")
+}
+
+// newSNIConfig loads the certificates of the hosts and returns the TLS configuration selecting them by server name
+func newSNIConfig(c TLSConfig) (*tls.Config, error) {
+	fallback, err := tls.LoadX509KeyPair(c.CertPath, c.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+
+	certs := map[string]*tls.Certificate{}
+	for _, h := range c.Hosts {
+		cert, err := tls.LoadX509KeyPair(h.CertPath, h.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls certificate of %s: %w", strings.Join(h.Names, ", "), err)
+		}
+		for _, n := range h.Names {
+			certs[strings.ToLower(n)] = &cert
+		}
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Served to clients requesting no server name
+		Certificates: []tls.Certificate{fallback},
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return certificateFor(certs, hello.ServerName, &fallback), nil
+		},
+	}, nil
+}
+
+// certificateFor returns the certificate of the server name. An exact name is preferred over a wildcard
+// matching its leftmost label. The fallback is returned for unknown or missing server names.
+func certificateFor(certs map[string]*tls.Certificate, serverName string, fallback *tls.Certificate) *tls.Certificate {
+	name := strings.ToLower(strings.TrimSuffix(serverName, "."))
+	if cert, ok := certs[name]; ok {
+		return cert
+	}
+	if _, parent, ok := strings.Cut(name, "."); ok {
+		if cert, ok := certs["*."+parent]; ok {
+			return cert
+		}
+	}
+	return fallback
+}
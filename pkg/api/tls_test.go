@@ -0,0 +1,140 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes a self-signed certificate for the common name and its key to the directory
+func writeCertificate(t *testing.T, dir, commonName string) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	certPath = filepath.Join(dir, commonName+".pem")
+	keyPath = filepath.Join(dir, commonName+".key")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return certPath, keyPath
+}
+
+func TestNewSNIConfig(t *testing.T) {
+	dir := t.TempDir()
+	defaultCert, defaultKey := writeCertificate(t, dir, "sparrow.internal")
+	partnerCert, partnerKey := writeCertificate(t, dir, "sparrow.partner.example.com")
+	wildcardCert, wildcardKey := writeCertificate(t, dir, "wildcard.example.com")
+
+	cfg, err := newSNIConfig(TLSConfig{
+		Enabled:  true,
+		CertPath: defaultCert,
+		KeyPath:  defaultKey,
+		Hosts: []TLSHost{
+			{Names: []string{"sparrow.partner.example.com"}, CertPath: partnerCert, KeyPath: partnerKey},
+			{Names: []string{"*.example.com"}, CertPath: wildcardCert, KeyPath: wildcardKey},
+		},
+	})
+	if err != nil {
+		t.Fatalf("newSNIConfig() error = %v", err)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = cfg
+	srv.StartTLS()
+	defer srv.Close()
+
+	tests := []struct {
+		serverName string
+		want       string
+	}{
+		{serverName: "sparrow.partner.example.com", want: "sparrow.partner.example.com"},
+		{serverName: "SPARROW.Partner.example.com", want: "sparrow.partner.example.com"},
+		{serverName: "sparrow.example.com", want: "wildcard.example.com"},
+		{serverName: "a.b.example.com", want: "sparrow.internal"},
+		{serverName: "sparrow.internal", want: "sparrow.internal"},
+		{serverName: "", want: "sparrow.internal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.serverName, func(t *testing.T) {
+			conn, err := tls.Dial("tcp", srv.Listener.Addr().String(), &tls.Config{
+				ServerName:         tt.serverName,
+				InsecureSkipVerify: true, //nolint:gosec // the certificates are self-signed
+			})
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer func() { _ = conn.Close() }()
+
+			if got := conn.ConnectionState().PeerCertificates[0].Subject.CommonName; got != tt.want {
+				t.Errorf("Served certificate of %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSNIConfig_missingCertificate(t *testing.T) {
+	dir := t.TempDir()
+	cert, key := writeCertificate(t, dir, "sparrow.internal")
+
+	_, err := newSNIConfig(TLSConfig{
+		Enabled:  true,
+		CertPath: cert,
+		KeyPath:  key,
+		Hosts:    []TLSHost{{Names: []string{"sparrow.example.com"}, CertPath: filepath.Join(dir, "missing.pem"), KeyPath: key}},
+	})
+	if err == nil {
+		t.Error("newSNIConfig() should fail for a missing certificate")
+	}
+}
@@ -0,0 +1,160 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// AdaptiveInterval configures a shortened probing interval for failing targets.
+// A target is re-checked in the shortened interval after a failure
+// until it succeeded the configured amount of consecutive times.
+type AdaptiveInterval struct {
+	// Interval is the shortened interval in which failing targets are probed.
+	// A duration of 0 disables the adaptive interval.
+	Interval time.Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+	// Successes is the amount of consecutive successes after which
+	// a target is probed in the normal interval again
+	Successes int `json:"successes" yaml:"successes" mapstructure:"successes"`
+}
+
+// Enabled returns true if the adaptive interval is enabled
+func (a AdaptiveInterval) Enabled() bool {
+	return a.Interval > 0
+}
+
+// Next returns the time to wait until the next check run.
+// The check runs in the shortened interval if the adaptive interval is enabled,
+// so failing targets can be re-checked in time.
+func (a AdaptiveInterval) Next(interval time.Duration) time.Duration {
+	if a.Enabled() {
+		return a.Interval
+	}
+	return interval
+}
+
+// Validate checks if the adaptive interval is valid for a check with the given interval
+func (a AdaptiveInterval) Validate(checkName string, interval time.Duration) error {
+	if !a.Enabled() {
+		if a.Interval < 0 {
+			return ErrInvalidConfig{CheckName: checkName, Field: "adaptive.interval", Reason: "must be equal or greater than 0"}
+		}
+		return nil
+	}
+	if a.Interval >= interval {
+		return ErrInvalidConfig{CheckName: checkName, Field: "adaptive.interval", Reason: "must be less than the check interval"}
+	}
+	if a.Successes < 1 {
+		return ErrInvalidConfig{CheckName: checkName, Field: "adaptive.successes", Reason: "must be at least 1"}
+	}
+	return nil
+}
+
+// Scheduler decides which targets of a check are due for a probe
+// based on the outcome of their previous probes.
+// The zero value is ready to use.
+type Scheduler struct {
+	mu      sync.Mutex
+	targets map[string]*targetSchedule
+}
+
+// targetSchedule is the probing state of a single target
+type targetSchedule struct {
+	// last is the time of the last probe
+	last time.Time
+	// failing is true while the target is probed in the shortened interval
+	failing bool
+	// successes is the amount of consecutive successes while failing
+	successes int
+}
+
+// Due returns the targets that are due for a probe at the given time.
+// All targets are due if the adaptive interval is disabled.
+// Targets without a previous probe and failing targets are always due,
+// all other targets are due once the normal interval elapsed.
+func (s *Scheduler) Due(targets []string, now time.Time, interval time.Duration, cfg AdaptiveInterval) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for t := range s.targets {
+		if !slices.Contains(targets, t) {
+			delete(s.targets, t)
+		}
+	}
+	if !cfg.Enabled() {
+		return targets
+	}
+
+	// The check runs in the shortened interval, so a target is due
+	// if the next run would be closer to its normal interval than this one
+	tolerance := cfg.Interval / 2 //nolint:mnd // half of the shortened interval
+	var due []string
+	for _, t := range targets {
+		st, ok := s.targets[t]
+		if !ok || st.failing || now.Sub(st.last) >= interval-tolerance {
+			due = append(due, t)
+		}
+	}
+	return due
+}
+
+// Report records the outcome of a probe of the target at the given time
+func (s *Scheduler) Report(target string, success bool, now time.Time, cfg AdaptiveInterval) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.targets == nil {
+		s.targets = map[string]*targetSchedule{}
+	}
+	st, ok := s.targets[target]
+	if !ok {
+		st = &targetSchedule{}
+		s.targets[target] = st
+	}
+	st.last = now
+
+	if !success {
+		st.failing = true
+		st.successes = 0
+		return
+	}
+	if st.failing {
+		st.successes++
+		if st.successes >= cfg.Successes {
+			st.failing = false
+			st.successes = 0
+		}
+	}
+}
+
+// MergeResults merges the results of the probed targets into the previous results.
+// Results of targets that are no longer configured are dropped.
+func MergeResults[T any](prev, cur map[string]T, targets []string) map[string]T {
+	merged := make(map[string]T, len(targets))
+	for _, t := range targets {
+		if r, ok := cur[t]; ok {
+			merged[t] = r
+		} else if r, ok := prev[t]; ok {
+			merged[t] = r
+		}
+	}
+	return merged
+}
@@ -0,0 +1,126 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	interval := time.Minute
+	cfg := AdaptiveInterval{Interval: 10 * time.Second, Successes: 2}
+	targets := []string{"a", "b"}
+	start := time.Now()
+
+	type step struct {
+		// after is the time elapsed since the start
+		after time.Duration
+		// report are the outcomes of the probes of the due targets
+		report  map[string]bool
+		wantDue []string
+	}
+	tests := []struct {
+		name  string
+		cfg   AdaptiveInterval
+		steps []step
+	}{
+		{
+			name: "disabled adaptive interval",
+			cfg:  AdaptiveInterval{},
+			steps: []step{
+				{after: 0, report: map[string]bool{"a": false, "b": true}, wantDue: targets},
+				{after: time.Second, wantDue: targets},
+			},
+		},
+		{
+			name: "failing target is re-checked until it recovered",
+			cfg:  cfg,
+			steps: []step{
+				{after: 0, report: map[string]bool{"a": false, "b": true}, wantDue: targets},
+				{after: 10 * time.Second, report: map[string]bool{"a": true}, wantDue: []string{"a"}},
+				{after: 20 * time.Second, report: map[string]bool{"a": true}, wantDue: []string{"a"}},
+				{after: 30 * time.Second, wantDue: nil},
+				{after: 60 * time.Second, report: map[string]bool{"b": true}, wantDue: []string{"b"}},
+				{after: 80 * time.Second, wantDue: []string{"a"}},
+			},
+		},
+		{
+			name: "failure resets the consecutive successes",
+			cfg:  cfg,
+			steps: []step{
+				{after: 0, report: map[string]bool{"a": false, "b": true}, wantDue: targets},
+				{after: 10 * time.Second, report: map[string]bool{"a": true}, wantDue: []string{"a"}},
+				{after: 20 * time.Second, report: map[string]bool{"a": false}, wantDue: []string{"a"}},
+				{after: 30 * time.Second, report: map[string]bool{"a": true}, wantDue: []string{"a"}},
+				{after: 40 * time.Second, wantDue: []string{"a"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Scheduler
+			for i, st := range tt.steps {
+				now := start.Add(st.after)
+				due := s.Due(targets, now, interval, tt.cfg)
+				if !reflect.DeepEqual(due, st.wantDue) {
+					t.Fatalf("step %d: Scheduler.Due() = %v, want %v", i, due, st.wantDue)
+				}
+				for target, ok := range st.report {
+					s.Report(target, ok, now, tt.cfg)
+				}
+			}
+		})
+	}
+}
+
+func TestAdaptiveInterval_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     AdaptiveInterval
+		wantErr bool
+	}{
+		{name: "disabled", cfg: AdaptiveInterval{}},
+		{name: "valid", cfg: AdaptiveInterval{Interval: time.Second, Successes: 3}},
+		{name: "negative interval", cfg: AdaptiveInterval{Interval: -time.Second}, wantErr: true},
+		{name: "interval not shorter", cfg: AdaptiveInterval{Interval: time.Minute, Successes: 3}, wantErr: true},
+		{name: "no successes", cfg: AdaptiveInterval{Interval: time.Second}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate("health", time.Minute); (err != nil) != tt.wantErr {
+				t.Errorf("AdaptiveInterval.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeResults(t *testing.T) {
+	prev := map[string]int{"a": 1, "b": 1, "c": 1}
+	cur := map[string]int{"a": 2}
+
+	got := MergeResults(prev, cur, []string{"a", "b"})
+	want := map[string]int{"a": 2, "b": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeResults() = %v, want %v", got, want)
+	}
+}
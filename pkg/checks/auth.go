@@ -0,0 +1,105 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/caas-team/sparrow/pkg/oauth"
+)
+
+// TargetAuth configures how the probes to targets are authenticated
+type TargetAuth struct {
+	// Targets are the targets authenticated this way. They must be targets of the check.
+	Targets []string `json:"targets" yaml:"targets"`
+	// OAuth2 acquires a bearer token with the client credentials grant
+	OAuth2 oauth.Config `json:"oauth2" yaml:"oauth2"`
+}
+
+// ValidateAuth checks if the auth configurations are valid and every target is authenticated at most one way
+func ValidateAuth(checkName string, auth []TargetAuth, targets []string) error {
+	authenticated := map[string]struct{}{}
+	for i, a := range auth {
+		field := fmt.Sprintf("auth[%d]", i)
+		if len(a.Targets) == 0 {
+			return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: "must not be empty"}
+		}
+		for _, t := range a.Targets {
+			if !slices.Contains(targets, t) {
+				return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: fmt.Sprintf("%q is not a target of the check", t)}
+			}
+			if _, ok := authenticated[t]; ok {
+				return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: fmt.Sprintf("%q is already configured by another auth", t)}
+			}
+			authenticated[t] = struct{}{}
+		}
+		if err := a.OAuth2.Validate(); err != nil {
+			return ErrInvalidConfig{CheckName: checkName, Field: field + ".oauth2", Reason: err.Error()}
+		}
+	}
+	return nil
+}
+
+// redacted replaces the secrets of redacted configurations
+const redacted = "REDACTED"
+
+// RedactAuth returns a copy of the auth configurations with the client secrets replaced
+func RedactAuth(auth []TargetAuth) []TargetAuth {
+	if auth == nil {
+		return nil
+	}
+	res := make([]TargetAuth, len(auth))
+	for i, a := range auth {
+		if a.OAuth2.ClientSecret != "" {
+			a.OAuth2.ClientSecret = redacted
+		}
+		res[i] = a
+	}
+	return res
+}
+
+// Authorizers are the token sources of the targets with an auth configuration
+type Authorizers map[string]*oauth.TokenSource
+
+// NewAuthorizers returns the token sources of the targets requesting the tokens with the given client.
+// The targets of an auth configuration share a token source, so they share the cached token.
+func NewAuthorizers(auth []TargetAuth, client *http.Client) Authorizers {
+	a := Authorizers{}
+	for _, ta := range auth {
+		ts := oauth.NewTokenSource(ta.OAuth2, client)
+		for _, t := range ta.Targets {
+			a[t] = ts
+		}
+	}
+	return a
+}
+
+// Authorize sets the bearer token of the request to the target if the target has an auth configuration
+func (a Authorizers) Authorize(ctx context.Context, req *http.Request, target string) error {
+	ts, ok := a[target]
+	if !ok {
+		return nil
+	}
+	if err := ts.Authorize(ctx, req); err != nil {
+		return fmt.Errorf("failed to authorize request: %w", err)
+	}
+	return nil
+}
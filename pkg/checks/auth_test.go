@@ -0,0 +1,91 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package checks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/oauth"
+)
+
+func TestValidateAuth(t *testing.T) {
+	targets := []string{"https://a.example.com", "https://b.example.com"}
+	valid := oauth.Config{TokenURL: "https://idp.example.com/token", ClientID: "sparrow", ClientSecret: "secret"}
+
+	tests := []struct {
+		name    string
+		auth    []TargetAuth
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "valid", auth: []TargetAuth{{Targets: targets, OAuth2: valid}}},
+		{name: "no targets", auth: []TargetAuth{{OAuth2: valid}}, wantErr: true},
+		{name: "unknown target", auth: []TargetAuth{{Targets: []string{"https://c.example.com"}, OAuth2: valid}}, wantErr: true},
+		{
+			name: "target authenticated twice",
+			auth: []TargetAuth{
+				{Targets: []string{"https://a.example.com"}, OAuth2: valid},
+				{Targets: []string{"https://a.example.com"}, OAuth2: valid},
+			},
+			wantErr: true,
+		},
+		{name: "invalid oauth2", auth: []TargetAuth{{Targets: targets, OAuth2: oauth.Config{TokenURL: "https://idp.example.com/token"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateAuth("health", tt.auth, targets); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAuth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorizers_Authorize(t *testing.T) {
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer idp.Close()
+
+	a := NewAuthorizers([]TargetAuth{{
+		Targets: []string{"https://a.example.com"},
+		OAuth2:  oauth.Config{TokenURL: idp.URL, ClientID: "sparrow"},
+	}}, idp.Client())
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "https://a.example.com", want: "Bearer token"},
+		{target: "https://b.example.com", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, http.NoBody)
+			if err := a.Authorize(context.Background(), req, tt.target); err != nil {
+				t.Fatalf("Authorizers.Authorize() error = %v", err)
+			}
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
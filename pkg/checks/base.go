@@ -23,6 +23,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/caas-team/sparrow/internal/clock"
 	"github.com/caas-team/sparrow/internal/helper"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/prometheus/client_golang/prometheus"
@@ -59,6 +60,15 @@ type Check interface {
 	// RemoveLabelledMetrics allows the check to remove the prometheus metrics
 	// of the check whose `target` label matches the passed value
 	RemoveLabelledMetrics(target string) error
+	// OnRegister is called once when the check is registered, before it's run.
+	// It allows the check to prepare itself, e.g. to warm caches or to pre-resolve names.
+	// An error is logged, but doesn't prevent the check from running.
+	OnRegister(ctx context.Context) error
+	// OnConfigChange is called after the configuration of the running check was updated
+	OnConfigChange(ctx context.Context, config Runtime) error
+	// OnShutdown is called once when the check is unregistered, before it's shut down.
+	// It allows the check to flush its state.
+	OnShutdown(ctx context.Context)
 }
 
 // CheckBase is a struct providing common fields used by implementations of the Check interface.
@@ -68,8 +78,25 @@ type CheckBase struct {
 	Mu sync.Mutex
 	// Signal channel used to notify about shutdown of a check
 	DoneChan chan struct{}
+	// SeriesLimit caps the number of targets the check registers labelled metric series for
+	SeriesLimit
+	// Source is the clock the check schedules its runs and timestamps its results with
+	clock.Source
 }
 
+// OnRegister does nothing. Checks override it to prepare themselves before they're run.
+func (b *CheckBase) OnRegister(context.Context) error {
+	return nil
+}
+
+// OnConfigChange does nothing. Checks override it to react on configuration changes.
+func (b *CheckBase) OnConfigChange(context.Context, Runtime) error {
+	return nil
+}
+
+// OnShutdown does nothing. Checks override it to flush their state before they're shut down.
+func (b *CheckBase) OnShutdown(context.Context) {}
+
 // Runtime is the interface that all check configurations must implement
 type Runtime interface {
 	// For returns the name of the check being configured
@@ -84,6 +111,13 @@ type Result struct {
 	Data any `json:"data"`
 	// Timestamp is the UTC time the check was run
 	Timestamp time.Time `json:"timestamp"`
+	// Instance is the identity of the sparrow that ran the check
+	Instance string `json:"instance,omitempty"`
+	// SchemaVersion is the version of the layout of Data.
+	// Results without a version have the layout of LegacySchemaVersion.
+	SchemaVersion int `json:"schemaVersion,omitempty"`
+	// Provenance describes where the runtime configuration of the check run was loaded from
+	Provenance *Provenance `json:"provenance,omitempty"`
 }
 
 // ResultDTO is a data transfer object used to associate a check's name with its result.
@@ -92,9 +126,11 @@ type ResultDTO struct {
 	Result *Result
 }
 
-// GlobalTarget includes the basic information regarding
-// other Sparrow instances, which this Sparrow can communicate with.
-type GlobalTarget struct {
-	Url      string    `json:"url"`
-	LastSeen time.Time `json:"lastSeen"`
+// TargetState is the state of a single target as reported by a check's result.
+// It is used to evaluate the dependencies between checks.
+type TargetState struct {
+	// Healthy is true if the target was reachable and responded successfully
+	Healthy bool
+	// Latency is the time it took the target to respond
+	Latency time.Duration
 }
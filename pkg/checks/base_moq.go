@@ -29,6 +29,15 @@ var _ Check = &CheckMock{}
 //			NameFunc: func() string {
 //				panic("mock out the Name method")
 //			},
+//			OnConfigChangeFunc: func(ctx context.Context, config Runtime) error {
+//				panic("mock out the OnConfigChange method")
+//			},
+//			OnRegisterFunc: func(ctx context.Context) error {
+//				panic("mock out the OnRegister method")
+//			},
+//			OnShutdownFunc: func(ctx context.Context)  {
+//				panic("mock out the OnShutdown method")
+//			},
 //			RemoveLabelledMetricsFunc: func(target string) error {
 //				panic("mock out the RemoveLabelledMetrics method")
 //			},
@@ -60,6 +69,15 @@ type CheckMock struct {
 	// NameFunc mocks the Name method.
 	NameFunc func() string
 
+	// OnConfigChangeFunc mocks the OnConfigChange method.
+	OnConfigChangeFunc func(ctx context.Context, config Runtime) error
+
+	// OnRegisterFunc mocks the OnRegister method.
+	OnRegisterFunc func(ctx context.Context) error
+
+	// OnShutdownFunc mocks the OnShutdown method.
+	OnShutdownFunc func(ctx context.Context)
+
 	// RemoveLabelledMetricsFunc mocks the RemoveLabelledMetrics method.
 	RemoveLabelledMetricsFunc func(target string) error
 
@@ -86,6 +104,23 @@ type CheckMock struct {
 		// Name holds details about calls to the Name method.
 		Name []struct {
 		}
+		// OnConfigChange holds details about calls to the OnConfigChange method.
+		OnConfigChange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Config is the config argument value.
+			Config Runtime
+		}
+		// OnRegister holds details about calls to the OnRegister method.
+		OnRegister []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// OnShutdown holds details about calls to the OnShutdown method.
+		OnShutdown []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// RemoveLabelledMetrics holds details about calls to the RemoveLabelledMetrics method.
 		RemoveLabelledMetrics []struct {
 			// Target is the target argument value.
@@ -113,6 +148,9 @@ type CheckMock struct {
 	lockGetConfig             sync.RWMutex
 	lockGetMetricCollectors   sync.RWMutex
 	lockName                  sync.RWMutex
+	lockOnConfigChange        sync.RWMutex
+	lockOnRegister            sync.RWMutex
+	lockOnShutdown            sync.RWMutex
 	lockRemoveLabelledMetrics sync.RWMutex
 	lockRun                   sync.RWMutex
 	lockSchema                sync.RWMutex
@@ -201,6 +239,106 @@ func (mock *CheckMock) NameCalls() []struct {
 	return calls
 }
 
+// OnConfigChange calls OnConfigChangeFunc.
+func (mock *CheckMock) OnConfigChange(ctx context.Context, config Runtime) error {
+	if mock.OnConfigChangeFunc == nil {
+		panic("CheckMock.OnConfigChangeFunc: method is nil but Check.OnConfigChange was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Config Runtime
+	}{
+		Ctx:    ctx,
+		Config: config,
+	}
+	mock.lockOnConfigChange.Lock()
+	mock.calls.OnConfigChange = append(mock.calls.OnConfigChange, callInfo)
+	mock.lockOnConfigChange.Unlock()
+	return mock.OnConfigChangeFunc(ctx, config)
+}
+
+// OnConfigChangeCalls gets all the calls that were made to OnConfigChange.
+// Check the length with:
+//
+//	len(mockedCheck.OnConfigChangeCalls())
+func (mock *CheckMock) OnConfigChangeCalls() []struct {
+	Ctx    context.Context
+	Config Runtime
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Config Runtime
+	}
+	mock.lockOnConfigChange.RLock()
+	calls = mock.calls.OnConfigChange
+	mock.lockOnConfigChange.RUnlock()
+	return calls
+}
+
+// OnRegister calls OnRegisterFunc.
+func (mock *CheckMock) OnRegister(ctx context.Context) error {
+	if mock.OnRegisterFunc == nil {
+		panic("CheckMock.OnRegisterFunc: method is nil but Check.OnRegister was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockOnRegister.Lock()
+	mock.calls.OnRegister = append(mock.calls.OnRegister, callInfo)
+	mock.lockOnRegister.Unlock()
+	return mock.OnRegisterFunc(ctx)
+}
+
+// OnRegisterCalls gets all the calls that were made to OnRegister.
+// Check the length with:
+//
+//	len(mockedCheck.OnRegisterCalls())
+func (mock *CheckMock) OnRegisterCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockOnRegister.RLock()
+	calls = mock.calls.OnRegister
+	mock.lockOnRegister.RUnlock()
+	return calls
+}
+
+// OnShutdown calls OnShutdownFunc.
+func (mock *CheckMock) OnShutdown(ctx context.Context) {
+	if mock.OnShutdownFunc == nil {
+		panic("CheckMock.OnShutdownFunc: method is nil but Check.OnShutdown was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockOnShutdown.Lock()
+	mock.calls.OnShutdown = append(mock.calls.OnShutdown, callInfo)
+	mock.lockOnShutdown.Unlock()
+	mock.OnShutdownFunc(ctx)
+}
+
+// OnShutdownCalls gets all the calls that were made to OnShutdown.
+// Check the length with:
+//
+//	len(mockedCheck.OnShutdownCalls())
+func (mock *CheckMock) OnShutdownCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockOnShutdown.RLock()
+	calls = mock.calls.OnShutdown
+	mock.lockOnShutdown.RUnlock()
+	return calls
+}
+
 // RemoveLabelledMetrics calls RemoveLabelledMetricsFunc.
 func (mock *CheckMock) RemoveLabelledMetrics(target string) error {
 	if mock.RemoveLabelledMetricsFunc == nil {
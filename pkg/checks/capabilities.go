@@ -0,0 +1,61 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"errors"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/sys/unix"
+)
+
+// Capabilities are the privileges of the process some checks depend on
+type Capabilities struct {
+	// RawICMP is true if raw ICMP sockets can be opened, which requires CAP_NET_RAW
+	RawICMP bool `json:"rawIcmp"`
+	// UnprivilegedICMP is true if ICMP datagram sockets can be opened without privileges,
+	// which the net.ipv4.ping_group_range sysctl allows on linux
+	UnprivilegedICMP bool `json:"unprivilegedIcmp"`
+}
+
+// ProbeCapabilities detects the capabilities of the process by opening the sockets the checks depend on
+func ProbeCapabilities() (Capabilities, error) {
+	raw, err := canListen("ip4:icmp")
+	if err != nil {
+		return Capabilities{}, err
+	}
+	unprivileged, err := canListen("udp4")
+	if err != nil {
+		return Capabilities{}, err
+	}
+	return Capabilities{RawICMP: raw, UnprivilegedICMP: unprivileged}, nil
+}
+
+// canListen returns true if an ICMP socket of the network can be opened.
+// It returns false if the process lacks the permission and an error if the socket can't be opened for another reason.
+func canListen(network string) (bool, error) {
+	conn, err := icmp.ListenPacket(network, "0.0.0.0")
+	if err != nil {
+		if errors.Is(err, unix.EPERM) || errors.Is(err, unix.EACCES) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, conn.Close()
+}
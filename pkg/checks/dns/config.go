@@ -38,6 +38,11 @@ type Config struct {
 	Interval time.Duration      `json:"interval" yaml:"interval"`
 	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
 	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+	Schedule checks.Schedule    `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	// TargetRetries override the retry configuration for some targets
+	TargetRetries []checks.TargetRetry `json:"targetRetries,omitempty" yaml:"targetRetries,omitempty"`
+	// Buckets are the upper bounds of the buckets of the duration histogram in seconds
+	Buckets checks.Buckets `json:"buckets,omitempty" yaml:"buckets,omitempty"`
 }
 
 // For returns the name of the check
@@ -51,9 +56,12 @@ func (c *Config) Validate() error {
 		if strings.HasPrefix(t, "https://") || strings.HasPrefix(t, "http://") {
 			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: "target URLs must not start with 'https://' or 'http://'"}
 		}
+		if _, _, err := checks.HostForms(t); err != nil {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: fmt.Sprintf("invalid internationalized domain name %q: %v", t, err)}
+		}
 	}
 
-	if c.Interval < minInterval {
+	if !c.Schedule.Enabled() && c.Interval < minInterval {
 		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
 	}
 
@@ -61,5 +69,18 @@ func (c *Config) Validate() error {
 		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
 	}
 
-	return nil
+	if err := checks.ValidateRetries(c.For(), c.TargetRetries, c.Targets); err != nil {
+		return err
+	}
+
+	if err := c.Buckets.Validate(c.For()); err != nil {
+		return err
+	}
+
+	timing := checks.Timing{Interval: c.Interval, Timeout: c.Timeout, Schedule: c.Schedule, Retry: c.Retry, TargetRetries: c.TargetRetries}
+	if err := timing.Validate(c.For()); err != nil {
+		return err
+	}
+
+	return c.Schedule.Validate(c.For())
 }
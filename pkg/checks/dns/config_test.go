@@ -33,7 +33,7 @@ func TestConfig_Validate(t *testing.T) {
 			name: "valid config",
 			config: Config{
 				Targets:  []string{"example.com"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			},
 			wantErr: false,
@@ -42,7 +42,25 @@ func TestConfig_Validate(t *testing.T) {
 			name: "invalid targets",
 			config: Config{
 				Targets:  []string{"http://example.com", "https://google.com"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "internationalized domain name",
+			config: Config{
+				Targets:  []string{"bücher.example", "xn--bcher-kva.example"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid internationalized domain name",
+			config: Config{
+				Targets:  []string{"xn--a.example"},
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			},
 			wantErr: true,
@@ -60,7 +78,7 @@ func TestConfig_Validate(t *testing.T) {
 			name: "invalid timeout",
 			config: Config{
 				Targets:  []string{"example.com"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  100 * time.Millisecond,
 			},
 			wantErr: true,
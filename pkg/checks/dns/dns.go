@@ -20,8 +20,8 @@ package dns
 
 import (
 	"context"
+	"errors"
 	"net"
-	"slices"
 	"sync"
 	"time"
 
@@ -39,6 +39,10 @@ var (
 
 const CheckName = "dns"
 
+// SchemaVersion is the version of the layout of the check's result data.
+// It is incremented with every breaking change of the layout.
+const SchemaVersion = 1
+
 // DNS is a check that resolves the names and addresses
 type DNS struct {
 	checks.CheckBase
@@ -50,7 +54,8 @@ type DNS struct {
 func (d *DNS) GetConfig() checks.Runtime {
 	d.Mu.Lock()
 	defer d.Mu.Unlock()
-	return &d.config
+	cfg := d.config
+	return &cfg
 }
 
 func (d *DNS) Name() string {
@@ -77,6 +82,9 @@ type result struct {
 	Resolved []string
 	Error    *string
 	Total    float64
+	// Unicode and ASCII are the forms of the target if it's an internationalized domain name
+	Unicode string `json:",omitempty"`
+	ASCII   string `json:",omitempty"`
 }
 
 // Run starts the dns check
@@ -85,22 +93,30 @@ func (d *DNS) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 	defer cancel()
 	log := logger.FromContext(ctx)
 
+	d.Mu.Lock()
 	log.Info("Starting dns check", "interval", d.config.Interval.String())
+	d.Mu.Unlock()
 	for {
+		// The configuration is updated concurrently, so every run reads it from a copy
+		d.Mu.Lock()
+		cfg := d.config
+		d.Mu.Unlock()
+
 		select {
 		case <-ctx.Done():
 			log.Error("Context canceled", "err", ctx.Err())
 			return ctx.Err()
 		case <-d.DoneChan:
 			return nil
-		case <-time.After(d.config.Interval):
-			res := d.check(ctx)
+		case <-d.Clock().After(cfg.Schedule.Next(d.Clock().Now(), cfg.Interval)):
+			res := d.check(ctx, cfg)
 
 			cResult <- checks.ResultDTO{
 				Name: d.Name(),
 				Result: &checks.Result{
-					Data:      res,
-					Timestamp: time.Now(),
+					Data:          res,
+					Timestamp:     d.Clock().Now(),
+					SchemaVersion: SchemaVersion,
 				},
 			}
 			log.Debug("Successfully finished dns check run")
@@ -108,6 +124,28 @@ func (d *DNS) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 	}
 }
 
+// RunOnce runs the dns check once against all targets
+func (d *DNS) RunOnce(ctx context.Context) (*checks.Result, []string) {
+	d.Mu.Lock()
+	cfg := d.config
+	d.Mu.Unlock()
+
+	res := d.check(ctx, cfg)
+	failed := checks.FailedTargets(res, result.failed)
+	return &checks.Result{Data: res, Timestamp: d.Clock().Now(), SchemaVersion: SchemaVersion}, failed
+}
+
+// failed returns true if the target failed
+func (r result) failed() bool {
+	return r.Error != nil
+}
+
+// Failures reports for every target in the data of a dns check result whether it failed
+func Failures(data any) map[string]bool {
+	res, _ := checks.ResultData[result](data)
+	return checks.TargetFailures(res, result.failed)
+}
+
 func (d *DNS) Shutdown() {
 	d.DoneChan <- struct{}{}
 	close(d.DoneChan)
@@ -118,16 +156,12 @@ func (d *DNS) UpdateConfig(cfg checks.Runtime) error {
 		d.Mu.Lock()
 		defer d.Mu.Unlock()
 
-		for _, target := range d.config.Targets {
-			if !slices.Contains(c.Targets, target) {
-				err := d.metrics.Remove(target)
-				if err != nil {
-					return err
-				}
-			}
+		if err := d.SetTargets(c.Targets, d.metrics.Remove); err != nil {
+			return err
 		}
 
 		d.config = *c
+		d.metrics.histogram.SetBuckets(c.Buckets)
 		return nil
 	}
 
@@ -151,15 +185,15 @@ func (d *DNS) GetMetricCollectors() []prometheus.Collector {
 // RemoveLabelledMetrics removes the metrics which have the passed
 // target as a label
 func (d *DNS) RemoveLabelledMetrics(target string) error {
-	return d.metrics.Remove(target)
+	return d.RemoveSeries(target, d.metrics.Remove)
 }
 
-// check performs DNS checks for all configured targets using a custom net.Resolver.
+// check performs DNS checks for all targets of the given configuration using a custom net.Resolver.
 // Returns a map where each target is associated with its DNS check result.
-func (d *DNS) check(ctx context.Context) map[string]result {
+func (d *DNS) check(ctx context.Context, cfg Config) map[string]result {
 	log := logger.FromContext(ctx)
 	log.Debug("Checking dns")
-	if len(d.config.Targets) == 0 {
+	if len(cfg.Targets) == 0 {
 		log.Debug("No targets defined")
 		return map[string]result{}
 	}
@@ -169,14 +203,16 @@ func (d *DNS) check(ctx context.Context) map[string]result {
 	results := map[string]result{}
 
 	d.client.SetDialer(&net.Dialer{
-		Timeout: d.config.Timeout,
+		Timeout: cfg.Timeout,
 	})
 
-	log.Debug("Getting dns status for each target in separate routine", "amount", len(d.config.Targets))
-	for _, t := range d.config.Targets {
+	retries := checks.NewRetries(cfg.Retry, cfg.TargetRetries)
+	log.Debug("Getting dns status for each target in separate routine", "amount", len(cfg.Targets))
+	for _, t := range cfg.Targets {
 		target := t
 		wg.Add(1)
 		lo := log.With("target", target)
+		retry, opts := retries.For(target)
 
 		getDNSRetry := helper.Retry(func(ctx context.Context) error {
 			res, err := getDNS(ctx, d.client, target)
@@ -187,7 +223,7 @@ func (d *DNS) check(ctx context.Context) map[string]result {
 				return err
 			}
 			return nil
-		}, d.config.Retry)
+		}, retry, append(opts, helper.RetryIf(retryable))...)
 
 		go func() {
 			defer wg.Done()
@@ -202,7 +238,9 @@ func (d *DNS) check(ctx context.Context) map[string]result {
 
 			mu.Lock()
 			defer mu.Unlock()
-			d.metrics.Set(target, results, float64(status))
+			d.WithSeries(target, func(label string) {
+				d.metrics.Set(label, results[target], float64(status))
+			})
 		}()
 	}
 	wg.Wait()
@@ -211,9 +249,17 @@ func (d *DNS) check(ctx context.Context) map[string]result {
 	return results
 }
 
+// retryable returns false for lookups of names that don't exist,
+// because retrying won't change the answer
+func retryable(err error) bool {
+	var dnsErr *net.DNSError
+	return !errors.As(err, &dnsErr) || !dnsErr.IsNotFound
+}
+
 // getDNS performs a DNS resolution for the given address using the specified net.Resolver.
 // If the address is an IP address, LookupAddr is used to perform a reverse DNS lookup.
 // If the address is a hostname, LookupHost is used to find its IP addresses.
+// Internationalized domain names are looked up in their ASCII form.
 // Returns a result struct containing the outcome of the DNS query.
 func getDNS(ctx context.Context, c Resolver, address string) (result, error) {
 	log := logger.FromContext(ctx).With("address", address)
@@ -227,8 +273,19 @@ func getDNS(ctx context.Context, c Resolver, address string) (result, error) {
 		lookupFunc = c.LookupHost
 	}
 
+	name, unicodeName, err := checks.HostForms(address)
+	if err != nil {
+		log.Error("Invalid internationalized domain name", "error", err)
+		errval := err.Error()
+		res.Error = &errval
+		return res, helper.Permanent(err)
+	}
+	if name != unicodeName || name != address {
+		res.Unicode, res.ASCII = unicodeName, name
+	}
+
 	start := time.Now()
-	resp, err := lookupFunc(ctx, address)
+	resp, err := lookupFunc(ctx, name)
 	if err != nil {
 		log.Error("Error while looking up address", "error", err)
 		errval := err.Error()
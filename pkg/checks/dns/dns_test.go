@@ -55,6 +55,7 @@ func TestDNS_Run(t *testing.T) {
 						Mu:       sync.Mutex{},
 						DoneChan: make(chan struct{}, 1),
 					},
+					metrics: newMetrics(),
 				}
 			},
 			targets: []string{},
@@ -121,6 +122,28 @@ func TestDNS_Run(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "success with internationalized domain name",
+			mockSetup: func() *DNS {
+				c := newCommonDNS()
+				c.client = &ResolverMock{
+					LookupHostFunc: func(ctx context.Context, addr string) ([]string, error) {
+						if addr != "xn--bcher-kva.example" {
+							return nil, fmt.Errorf("lookup of %s failed", addr)
+						}
+						return []string{exampleIP}, nil
+					},
+					SetDialerFunc: func(d *net.Dialer) {},
+				}
+				return c
+			},
+			targets: []string{"bücher.example"},
+			want: checks.Result{
+				Data: map[string]result{
+					"bücher.example": {Resolved: []string{exampleIP}, Unicode: "bücher.example", ASCII: "xn--bcher-kva.example"},
+				},
+			},
+		},
 		{
 			name: "error - lookup failure for a target",
 			mockSetup: func() *DNS {
@@ -299,7 +322,7 @@ func TestDNS_UpdateConfig(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &DNS{}
+			c := &DNS{metrics: newMetrics()}
 
 			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
 				t.Errorf("DNS.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
@@ -329,3 +352,21 @@ func newCommonDNS() *DNS {
 		metrics: newMetrics(),
 	}
 }
+
+func Test_retryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "timeout", err: &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}, want: true},
+		{name: "not found", err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}, want: false},
+		{name: "wrapped not found", err: fmt.Errorf("lookup failed: %w", &net.DNSError{IsNotFound: true}), want: false},
+		{name: "other error", err: fmt.Errorf("connection refused"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryable(tt.err))
+		})
+	}
+}
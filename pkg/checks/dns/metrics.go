@@ -28,7 +28,7 @@ type metrics struct {
 	status    *prometheus.GaugeVec
 	duration  *prometheus.GaugeVec
 	count     *prometheus.CounterVec
-	histogram *prometheus.HistogramVec
+	histogram *checks.HistogramVec
 }
 
 // newMetrics initializes metric collectors of the dns check
@@ -55,7 +55,7 @@ func newMetrics() metrics {
 			},
 			[]string{"target"},
 		),
-		histogram: prometheus.NewHistogramVec(
+		histogram: checks.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name: "sparrow_dns_duration",
 				Help: "Histogram of response times for DNS checks in seconds.",
@@ -76,9 +76,9 @@ func (m *metrics) GetCollectors() []prometheus.Collector {
 }
 
 // Set sets the metrics of one lookup target result
-func (m *metrics) Set(target string, results map[string]result, status float64) {
-	m.duration.WithLabelValues(target).Set(results[target].Total)
-	m.histogram.WithLabelValues(target).Observe(results[target].Total)
+func (m *metrics) Set(target string, res result, status float64) {
+	m.duration.WithLabelValues(target).Set(res.Total)
+	m.histogram.WithLabelValues(target).Observe(res.Total)
 	m.status.WithLabelValues(target).Set(status)
 	m.count.WithLabelValues(target).Inc()
 }
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/caas-team/sparrow/pkg/checks"
 )
 
 func TestMetrics_GetCollectors(t *testing.T) {
@@ -57,7 +59,7 @@ func TestMetrics_GetCollectors(t *testing.T) {
 					},
 					[]string{"target"},
 				),
-				histogram: prometheus.NewHistogramVec(
+				histogram: checks.NewHistogramVec(
 					prometheus.HistogramOpts{
 						Name: "sparrow_dns_duration",
 						Help: "Histogram of response times for DNS checks in seconds.",
@@ -68,7 +70,7 @@ func TestMetrics_GetCollectors(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		tt.metrics.Set("test", make(map[string]result, 1), float64(1))
+		tt.metrics.Set("test", result{}, float64(1))
 
 		if tt.metrics.GetCollectors() == nil {
 			t.Errorf("metrics.GetCollectors() = %v", tt.metrics.GetCollectors())
@@ -20,6 +20,7 @@ package health
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 
@@ -34,10 +35,29 @@ const (
 
 // Config defines the configuration parameters for a health check
 type Config struct {
-	Targets  []string           `json:"targets,omitempty" yaml:"targets,omitempty"`
-	Interval time.Duration      `json:"interval" yaml:"interval"`
-	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
-	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+	Targets  []string                `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Interval time.Duration           `json:"interval" yaml:"interval"`
+	Timeout  time.Duration           `json:"timeout" yaml:"timeout"`
+	Retry    helper.RetryConfig      `json:"retry" yaml:"retry"`
+	Adaptive checks.AdaptiveInterval `json:"adaptive,omitempty" yaml:"adaptive,omitempty"`
+	Schedule checks.Schedule         `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	Network  checks.NetworkConfig    `json:"network,omitempty" yaml:"network,omitempty"`
+	// Tunnel tunnels the probes through an SSH jump host
+	Tunnel checks.TunnelConfig `json:"tunnel,omitempty" yaml:"tunnel,omitempty"`
+	// Protocol forces the HTTP protocol version of the probes. A target is unhealthy if another version is negotiated.
+	Protocol checks.Protocol `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	// Auth authenticates the probes to targets, e.g. with an OAuth2 bearer token
+	Auth []checks.TargetAuth `json:"auth,omitempty" yaml:"auth,omitempty"`
+	// Proxies send the probes to some targets through a proxy, e.g. a region-specific egress point
+	Proxies []checks.TargetProxy `json:"proxies,omitempty" yaml:"proxies,omitempty"`
+	// TargetRetries override the retry configuration for some targets
+	TargetRetries []checks.TargetRetry `json:"targetRetries,omitempty" yaml:"targetRetries,omitempty"`
+	// Groups are groups of targets with an aggregated status
+	Groups []TargetGroup `json:"groups,omitempty" yaml:"groups,omitempty"`
+	// Probes configure the HTTP method and the success status codes per target
+	Probes []Probe `json:"probes,omitempty" yaml:"probes,omitempty"`
+	// Content configures the tracking of the response bodies per target
+	Content []Content `json:"content,omitempty" yaml:"content,omitempty"`
 }
 
 // For returns the name of the check
@@ -56,9 +76,13 @@ func (c *Config) Validate() error {
 		if u.Scheme != "https" && u.Scheme != "http" {
 			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: "target URLs must start with 'https://' or 'http://'"}
 		}
+
+		if _, _, err := checks.HostForms(u.Hostname()); err != nil {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: fmt.Sprintf("invalid internationalized domain name %q: %v", u.Hostname(), err)}
+		}
 	}
 
-	if c.Interval < minInterval {
+	if !c.Schedule.Enabled() && c.Interval < minInterval {
 		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
 	}
 
@@ -66,5 +90,73 @@ func (c *Config) Validate() error {
 		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
 	}
 
+	if err := c.Schedule.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if c.Schedule.Enabled() && c.Adaptive.Enabled() {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "adaptive", Reason: "can't be combined with a schedule"}
+	}
+
+	if err := c.Adaptive.Validate(c.For(), c.Interval); err != nil {
+		return err
+	}
+
+	if err := c.Network.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if err := c.Tunnel.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if err := c.Protocol.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if err := checks.ValidateAuth(c.For(), c.Auth, c.Targets); err != nil {
+		return err
+	}
+
+	if err := checks.ValidateProxies(c.For(), c.Proxies, c.Targets); err != nil {
+		return err
+	}
+
+	if err := checks.ValidateRetries(c.For(), c.TargetRetries, c.Targets); err != nil {
+		return err
+	}
+
+	timing := checks.Timing{Interval: c.Interval, Timeout: c.Timeout, Schedule: c.Schedule, Retry: c.Retry, TargetRetries: c.TargetRetries}
+	if err := timing.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if err := validateGroups(c.Groups, c.Targets); err != nil {
+		return err
+	}
+
+	if err := validateProbes(c.Probes, c.Targets); err != nil {
+		return err
+	}
+
+	if err := validateContent(c.Content, c.Targets); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// transport returns the transport of the probes dialing through the tunnel if one is given
+// and sending the probes to the proxied targets through their proxy
+// or nil if the default transport is used
+func (c *Config) transport(tunnel *checks.Tunnel) http.RoundTripper {
+	return c.Protocol.Transport(checks.ProxyTransport(c.Proxies, tunnel.Transport(c.Network.Transport(c.Timeout))))
+}
+
+// authorizers returns the token sources authenticating the probes to the targets
+func (c *Config) authorizers() checks.Authorizers {
+	return checks.NewAuthorizers(c.Auth, &http.Client{
+		Timeout:   c.Timeout,
+		Transport: c.Network.Transport(c.Timeout),
+	})
+}
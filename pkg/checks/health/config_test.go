@@ -19,8 +19,12 @@
 package health
 
 import (
+	"net/http"
 	"testing"
 	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/pkg/checks"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -33,7 +37,7 @@ func TestConfig_Validate(t *testing.T) {
 			name: "valid config",
 			config: Config{
 				Targets:  []string{"http://localhost:8080"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			},
 			wantErr: false,
@@ -42,7 +46,7 @@ func TestConfig_Validate(t *testing.T) {
 			name: "invalid targets - invalid url",
 			config: Config{
 				Targets:  []string{"://localhost:8080"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			},
 			wantErr: true,
@@ -51,7 +55,25 @@ func TestConfig_Validate(t *testing.T) {
 			name: "invalid targets - invalid scheme",
 			config: Config{
 				Targets:  []string{"localhost:8080"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid internationalized domain name",
+			config: Config{
+				Targets:  []string{"https://bücher.example/health"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid targets - invalid internationalized domain name",
+			config: Config{
+				Targets:  []string{"https://xn--a.example/health"},
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			},
 			wantErr: true,
@@ -65,15 +87,279 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "unsupported protocol",
+			config: Config{
+				Targets:  []string{"https://localhost:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Protocol: checks.ProtocolHTTP3,
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid timeout",
 			config: Config{
 				Targets:  []string{"http://localhost:8080"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  100 * time.Millisecond,
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid tunnel",
+			config: Config{
+				Targets:  []string{"http://10.0.0.1:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Tunnel: checks.TunnelConfig{
+					Host:           "bastion.example.com",
+					User:           "sparrow",
+					KeyFile:        "/etc/sparrow/id_ed25519",
+					KnownHostsFile: "/etc/sparrow/known_hosts",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid proxies",
+			config: Config{
+				Targets:  []string{"https://eu.example.com", "https://us.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Proxies: []checks.TargetProxy{
+					{Targets: []string{"https://eu.example.com"}, URL: "socks5://egress-eu.example.com:1080"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid proxies - unknown target",
+			config: Config{
+				Targets:  []string{"https://eu.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Proxies: []checks.TargetProxy{
+					{Targets: []string{"https://us.example.com"}, URL: "socks5://egress-us.example.com:1080"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid tunnel - unverified jump host",
+			config: Config{
+				Targets:  []string{"http://10.0.0.1:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Tunnel: checks.TunnelConfig{
+					Host:    "bastion.example.com",
+					User:    "sparrow",
+					KeyFile: "/etc/sparrow/id_ed25519",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid schedule without interval",
+			config: Config{
+				Targets:  []string{"http://localhost:8080"},
+				Timeout:  1 * time.Second,
+				Schedule: "0 2 * * *",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid schedule",
+			config: Config{
+				Targets:  []string{"http://localhost:8080"},
+				Timeout:  1 * time.Second,
+				Schedule: "0 2 * *",
+			},
+			wantErr: true,
+		},
+		{
+			name: "schedule combined with adaptive interval",
+			config: Config{
+				Targets:  []string{"http://localhost:8080"},
+				Interval: 1 * time.Minute,
+				Timeout:  1 * time.Second,
+				Schedule: "0 2 * * *",
+				Adaptive: checks.AdaptiveInterval{Interval: 10 * time.Second, Successes: 2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid groups",
+			config: Config{
+				Targets:  []string{"http://a:8080", "http://b:8080", "http://c:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Groups: []TargetGroup{
+					{Name: "svc", Targets: []string{"http://a:8080", "http://b:8080"}, Quorum: 1},
+					{Name: "all", Targets: []string{"http://a:8080", "http://b:8080", "http://c:8080"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid groups - missing name",
+			config: Config{
+				Targets:  []string{"http://a:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Groups:   []TargetGroup{{Targets: []string{"http://a:8080"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid groups - duplicate name",
+			config: Config{
+				Targets:  []string{"http://a:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Groups: []TargetGroup{
+					{Name: "svc", Targets: []string{"http://a:8080"}},
+					{Name: "svc", Targets: []string{"http://a:8080"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid groups - unknown target",
+			config: Config{
+				Targets:  []string{"http://a:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Groups:   []TargetGroup{{Name: "svc", Targets: []string{"http://b:8080"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid groups - quorum exceeds targets",
+			config: Config{
+				Targets:  []string{"http://a:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Groups:   []TargetGroup{{Name: "svc", Targets: []string{"http://a:8080"}, Quorum: 2}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid probes",
+			config: Config{
+				Targets:  []string{"http://a:8080", "http://b:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Probes: []Probe{
+					{Targets: []string{"http://a:8080"}, Method: http.MethodHead},
+					{Targets: []string{"http://b:8080"}, SuccessCodes: []int{http.StatusOK, http.StatusUnauthorized}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid content",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Content:  []Content{{Targets: []string{"https://example.com"}, Hash: "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid content - unknown target",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Content:  []Content{{Targets: []string{"https://example.org"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid content - target tracked twice",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Content:  []Content{{Targets: []string{"https://example.com"}}, {Targets: []string{"https://example.com"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid content - invalid hash",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Content:  []Content{{Targets: []string{"https://example.com"}, Hash: "md5:d41d8cd98f00b204e9800998ecf8427e"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid target retries",
+			config: Config{
+				Targets:       []string{"https://example.com"},
+				Interval:      10 * time.Second,
+				Timeout:       1 * time.Second,
+				TargetRetries: []checks.TargetRetry{{Targets: []string{"https://example.com"}, Retry: helper.RetryConfig{Count: 5}, Budget: 3}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid target retries - unknown target",
+			config: Config{
+				Targets:       []string{"https://example.com"},
+				Interval:      10 * time.Second,
+				Timeout:       1 * time.Second,
+				TargetRetries: []checks.TargetRetry{{Targets: []string{"https://example.org"}, Retry: helper.RetryConfig{Count: 5}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid probes - unknown target",
+			config: Config{
+				Targets:  []string{"http://a:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Probes:   []Probe{{Targets: []string{"http://b:8080"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid probes - target probed twice",
+			config: Config{
+				Targets:  []string{"http://a:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Probes: []Probe{
+					{Targets: []string{"http://a:8080"}, Method: http.MethodHead},
+					{Targets: []string{"http://a:8080"}, Method: http.MethodOptions},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid probes - unsupported method",
+			config: Config{
+				Targets:  []string{"http://a:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Probes:   []Probe{{Targets: []string{"http://a:8080"}, Method: http.MethodPost}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid probes - invalid success code",
+			config: Config{
+				Targets:  []string{"http://a:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Probes:   []Probe{{Targets: []string{"http://a:8080"}, SuccessCodes: []int{42}}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
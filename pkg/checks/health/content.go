@@ -0,0 +1,112 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// Content configures the tracking of the response bodies of targets,
+// e.g. to detect changes of static status pages or defacements
+type Content struct {
+	// Targets are the targets whose response bodies are hashed. They must be targets of the check.
+	Targets []string `json:"targets" yaml:"targets"`
+	// Hash is the expected hex encoded SHA-256 hash of the response bodies.
+	// The content is only hashed and not compared if empty.
+	Hash string `json:"hash,omitempty" yaml:"hash,omitempty"`
+}
+
+// content is the tracked content of a response body
+type content struct {
+	// size is the size of the response body in bytes
+	size int64
+	// hash is the hex encoded SHA-256 hash of the response body. Empty if the content isn't tracked.
+	hash string
+}
+
+// contentFor returns the content tracking configuration of the target or nil if its content isn't tracked
+func (c *Config) contentFor(target string) *Content {
+	for i := range c.Content {
+		if slices.Contains(c.Content[i].Targets, target) {
+			return &c.Content[i]
+		}
+	}
+	return nil
+}
+
+// changed returns true if the content deviates from the expected hash
+func (c *Content) changed(hash string) bool {
+	return c != nil && c.Hash != "" && !strings.EqualFold(c.Hash, hash)
+}
+
+// readContent reads the response body and returns its size
+// and its hash if the content of the target is tracked
+func readContent(body io.Reader, track *Content) (content, error) {
+	var h hash.Hash
+	w := io.Discard
+	if track != nil {
+		h = sha256.New()
+		w = h
+	}
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return content{size: n}, err
+	}
+	res := content{size: n}
+	if h != nil {
+		res.hash = hex.EncodeToString(h.Sum(nil))
+	}
+	return res, nil
+}
+
+// validateContent checks if the content tracking configurations are valid
+// and the content of every target is tracked at most once
+func validateContent(contents []Content, targets []string) error {
+	tracked := map[string]struct{}{}
+	for i, c := range contents {
+		field := fmt.Sprintf("content[%d]", i)
+		if len(c.Targets) == 0 {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".targets", Reason: "must not be empty"}
+		}
+		for _, t := range c.Targets {
+			if !slices.Contains(targets, t) {
+				return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".targets", Reason: fmt.Sprintf("%q is not a target of the check", t)}
+			}
+			if _, ok := tracked[t]; ok {
+				return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".targets", Reason: fmt.Sprintf("%q is already configured by another content", t)}
+			}
+			tracked[t] = struct{}{}
+		}
+		if c.Hash != "" {
+			if b, err := hex.DecodeString(c.Hash); err != nil || len(b) != sha256.Size {
+				return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".hash", Reason: "must be a hex encoded SHA-256 hash"}
+			}
+		}
+	}
+	return nil
+}
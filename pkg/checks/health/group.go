@@ -0,0 +1,110 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// groupPrefix prefixes the keys of the group statuses in the results,
+// so they can't collide with the target URLs
+const groupPrefix = "group:"
+
+// TargetGroup is a group of targets, e.g. all replicas of a service, with an aggregated status
+type TargetGroup struct {
+	// Name is the name of the group
+	Name string `json:"name" yaml:"name"`
+	// Targets are the targets belonging to the group. They must be targets of the check.
+	Targets []string `json:"targets" yaml:"targets"`
+	// Quorum is the minimum number of healthy targets for the group to be healthy.
+	// Defaults to the majority of the targets.
+	Quorum int `json:"quorum,omitempty" yaml:"quorum,omitempty"`
+}
+
+// quorum returns the minimum number of healthy targets for the group to be healthy
+func (g *TargetGroup) quorum() int {
+	if g.Quorum == 0 {
+		return len(g.Targets)/2 + 1
+	}
+	return g.Quorum
+}
+
+// healthy returns the number of healthy targets of the group in the results.
+// Targets without a result, e.g. because they were skipped due to a dependency, count as unhealthy.
+func (g *TargetGroup) healthy(results map[string]result) int {
+	n := 0
+	for _, t := range g.Targets {
+		if results[t].Status == stateMapping[1] {
+			n++
+		}
+	}
+	return n
+}
+
+// validateGroups checks if the groups are valid and only contain configured targets
+func validateGroups(groups []TargetGroup, targets []string) error {
+	names := map[string]struct{}{}
+	for i, g := range groups {
+		field := fmt.Sprintf("groups[%d]", i)
+		if g.Name == "" {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".name", Reason: "must not be empty"}
+		}
+		if _, ok := names[g.Name]; ok {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".name", Reason: fmt.Sprintf("duplicate group %q", g.Name)}
+		}
+		names[g.Name] = struct{}{}
+
+		if len(g.Targets) == 0 {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".targets", Reason: "must not be empty"}
+		}
+		for _, t := range g.Targets {
+			if !slices.Contains(targets, t) {
+				return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".targets", Reason: fmt.Sprintf("%q is not a target of the check", t)}
+			}
+		}
+		if g.Quorum < 0 || g.Quorum > len(g.Targets) {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".quorum", Reason: "must be between 0 and the number of targets"}
+		}
+	}
+	return nil
+}
+
+// aggregateGroups adds the aggregated status of every group to the results
+// and updates the group metrics
+func (h *Health) aggregateGroups(results map[string]result, groups []TargetGroup) {
+	for i := range groups {
+		g := &groups[i]
+		healthy := g.healthy(results)
+		state := 0
+		if healthy >= g.quorum() {
+			state = 1
+		}
+		results[groupPrefix+g.Name] = result{Status: stateMapping[state]}
+		h.metrics.SetGroup(g.Name, healthy, state)
+	}
+}
+
+// isGroup returns true if the key of a result is the status of a group
+func isGroup(key string) bool {
+	return strings.HasPrefix(key, groupPrefix)
+}
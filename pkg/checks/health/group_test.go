@@ -0,0 +1,104 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealth_aggregateGroups(t *testing.T) {
+	results := map[string]result{
+		"https://a.com": {Status: "healthy"},
+		"https://b.com": {Status: "healthy"},
+		"https://c.com": {Status: "unhealthy"},
+	}
+
+	tests := []struct {
+		name        string
+		group       TargetGroup
+		want        string
+		wantHealthy float64
+	}{
+		{
+			name:        "majority healthy",
+			group:       TargetGroup{Name: "svc", Targets: []string{"https://a.com", "https://b.com", "https://c.com"}},
+			want:        "healthy",
+			wantHealthy: 2,
+		},
+		{
+			name:        "majority unhealthy",
+			group:       TargetGroup{Name: "svc", Targets: []string{"https://a.com", "https://c.com", "https://d.com"}},
+			want:        "unhealthy",
+			wantHealthy: 1,
+		},
+		{
+			name:        "quorum reached",
+			group:       TargetGroup{Name: "svc", Targets: []string{"https://a.com", "https://c.com"}, Quorum: 1},
+			want:        "healthy",
+			wantHealthy: 1,
+		},
+		{
+			name:        "quorum not reached",
+			group:       TargetGroup{Name: "svc", Targets: []string{"https://a.com", "https://b.com", "https://c.com"}, Quorum: 3},
+			want:        "unhealthy",
+			wantHealthy: 2,
+		},
+		{
+			name:        "missing targets are unhealthy",
+			group:       TargetGroup{Name: "svc", Targets: []string{"https://d.com"}},
+			want:        "unhealthy",
+			wantHealthy: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Health{metrics: newMetrics()}
+			res := map[string]result{}
+			for k, v := range results {
+				res[k] = v
+			}
+
+			h.aggregateGroups(res, []TargetGroup{tt.group})
+
+			assert.Equal(t, tt.want, res[groupPrefix+tt.group.Name].Status)
+			assert.Len(t, res, len(results)+1)
+			want := 0.0
+			if tt.want == "healthy" {
+				want = 1
+			}
+			assert.Equal(t, want, gaugeValue(t, h.metrics.groups.WithLabelValues(tt.group.Name)))
+			assert.Equal(t, tt.wantHealthy, gaugeValue(t, h.metrics.groupTargets.WithLabelValues(tt.group.Name)))
+		})
+	}
+}
+
+// gaugeValue returns the current value of a gauge
+func gaugeValue(t *testing.T, g prometheus.Gauge) float64 {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := g.Write(m); err != nil {
+		t.Fatalf("failed to read gauge: %v", err)
+	}
+	return m.GetGauge().GetValue()
+}
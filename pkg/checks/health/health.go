@@ -25,7 +25,6 @@ import (
 	"net/http"
 	"slices"
 	"sync"
-	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -46,11 +45,24 @@ var (
 
 const CheckName = "health"
 
+// SchemaVersion is the version of the layout of the check's result data.
+// It is incremented with every breaking change of the layout.
+// Version 2 reports an object per target instead of its status only.
+const SchemaVersion = 2
+
 // Health is a check that measures the availability of an endpoint
 type Health struct {
 	checks.CheckBase
 	config  Config
 	metrics metrics
+	// scheduler decides which targets are due if the adaptive interval is enabled
+	scheduler checks.Scheduler
+	// results are the latest results of all targets if the adaptive interval is enabled
+	results map[string]result
+	// authorizers are kept across the runs, so the acquired tokens are reused until they expire
+	authorizers checks.Authorizers
+	// tunnel is kept across the runs, so the connection to the jump host is reused
+	tunnel *checks.Tunnel
 }
 
 // NewCheck creates a new instance of the health check
@@ -67,14 +79,38 @@ func NewCheck() checks.Check {
 	}
 }
 
+// result is the result of the health check of a single target or the aggregated status of a group
+type result struct {
+	// Status is either healthy or unhealthy
+	Status string `json:"status" yaml:"status"`
+	// Size is the size of the response body in bytes
+	Size int64 `json:"size,omitempty" yaml:"size,omitempty"`
+	// Hash is the hex encoded SHA-256 hash of the response body if the content of the target is tracked
+	Hash string `json:"hash,omitempty" yaml:"hash,omitempty"`
+	// ContentChanged is true if the hash deviates from the expected hash
+	ContentChanged bool `json:"contentChanged,omitempty" yaml:"contentChanged,omitempty"`
+}
+
+// failed returns true if the target is unhealthy or its content changed
+func (r result) failed() bool {
+	return r.Status != stateMapping[1] || r.ContentChanged
+}
+
 // Run starts the health check
 func (h *Health) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 	ctx, cancel := logger.NewContextWithLogger(ctx)
 	defer cancel()
 	log := logger.FromContext(ctx)
 
+	h.Mu.Lock()
 	log.Info("Starting healthcheck", "interval", h.config.Interval.String())
+	h.Mu.Unlock()
 	for {
+		// The configuration is updated concurrently, so every run reads it from a copy
+		h.Mu.Lock()
+		cfg := h.config
+		h.Mu.Unlock()
+
 		select {
 		case <-ctx.Done():
 			log.Error("Context canceled", "err", ctx.Err())
@@ -82,14 +118,24 @@ func (h *Health) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 		case <-h.DoneChan:
 			log.Debug("Soft shut down")
 			return nil
-		case <-time.After(h.config.Interval):
-			res := h.check(ctx)
+		case <-h.Clock().After(cfg.Schedule.Next(h.Clock().Now(), cfg.Adaptive.Next(cfg.Interval))):
+			res := h.check(ctx, cfg)
+			if cfg.Adaptive.Enabled() {
+				if len(res) == 0 && len(cfg.Targets) > 0 {
+					log.Debug("No target is due for a health check")
+					continue
+				}
+				res = checks.MergeResults(h.results, res, cfg.Targets)
+				h.results = res
+			}
+			h.aggregateGroups(res, cfg.Groups)
 
 			cResult <- checks.ResultDTO{
 				Name: h.Name(),
 				Result: &checks.Result{
-					Data:      res,
-					Timestamp: time.Now(),
+					Data:          res,
+					Timestamp:     h.Clock().Now(),
+					SchemaVersion: SchemaVersion,
 				},
 			}
 			log.Debug("Successfully finished health check run")
@@ -97,10 +143,25 @@ func (h *Health) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 	}
 }
 
+// RunOnce runs the health check once against all targets
+func (h *Health) RunOnce(ctx context.Context) (*checks.Result, []string) {
+	h.Mu.Lock()
+	cfg := h.config
+	h.Mu.Unlock()
+
+	res := h.check(ctx, cfg)
+	h.aggregateGroups(res, cfg.Groups)
+	failed := checks.FailedTargets(targetResults(res), result.failed)
+	return &checks.Result{Data: res, Timestamp: h.Clock().Now(), SchemaVersion: SchemaVersion}, failed
+}
+
 // Shutdown is called once when the check is unregistered or sparrow shuts down
 func (h *Health) Shutdown() {
 	h.DoneChan <- struct{}{}
 	close(h.DoneChan)
+	h.Mu.Lock()
+	defer h.Mu.Unlock()
+	_ = h.tunnel.Close()
 }
 
 // UpdateConfig sets the configuration for the health check
@@ -109,16 +170,19 @@ func (h *Health) UpdateConfig(cfg checks.Runtime) error {
 		h.Mu.Lock()
 		defer h.Mu.Unlock()
 
-		for _, target := range h.config.Targets {
-			if !slices.Contains(c.Targets, target) {
-				err := h.metrics.Remove(target)
-				if err != nil {
-					return err
-				}
+		if err := h.SetTargets(c.Targets, h.metrics.Remove); err != nil {
+			return err
+		}
+
+		for _, g := range h.config.Groups {
+			if !slices.ContainsFunc(c.Groups, func(n TargetGroup) bool { return n.Name == g.Name }) {
+				h.metrics.RemoveGroup(g.Name)
 			}
 		}
 
 		h.config = *c
+		h.authorizers = c.authorizers()
+		h.tunnel = h.tunnel.Update(c.Tunnel, c.Network, c.Timeout)
 		return nil
 	}
 
@@ -132,7 +196,8 @@ func (h *Health) UpdateConfig(cfg checks.Runtime) error {
 func (h *Health) GetConfig() checks.Runtime {
 	h.Mu.Lock()
 	defer h.Mu.Unlock()
-	return &h.config
+	cfg := h.config
+	return &cfg
 }
 
 // Name returns the name of the check
@@ -141,50 +206,60 @@ func (h *Health) Name() string {
 }
 
 // Schema provides the schema of the data that will be provided
-// by the health check
+// by the health check. The status of the target groups is reported
+// with the group name prefixed by "group:" as key.
 func (h *Health) Schema() (*openapi3.SchemaRef, error) {
-	return checks.OpenapiFromPerfData[map[string]string](map[string]string{})
+	return checks.OpenapiFromPerfData[map[string]result](map[string]result{})
 }
 
 // GetMetricCollectors returns all metric collectors of check
 func (h *Health) GetMetricCollectors() []prometheus.Collector {
-	return []prometheus.Collector{
-		h.metrics,
-	}
+	return h.metrics.GetCollectors()
 }
 
 // RemoveLabelledMetrics removes the metrics which have the passed
 // target as a label
 func (h *Health) RemoveLabelledMetrics(target string) error {
-	return h.metrics.Remove(target)
+	return h.RemoveSeries(target, h.metrics.Remove)
 }
 
 // check performs a health check using a retry function
-// to get the health status for all targets
-func (h *Health) check(ctx context.Context) map[string]string {
+// to get the health status for all targets of the given configuration
+func (h *Health) check(ctx context.Context, cfg Config) map[string]result {
 	log := logger.FromContext(ctx)
 	log.Debug("Checking health")
-	if len(h.config.Targets) == 0 {
+	if len(cfg.Targets) == 0 {
 		log.Debug("No targets defined")
-		return map[string]string{}
+		return map[string]result{}
 	}
-	log.Debug("Getting health status for each target in separate routine", "amount", len(h.config.Targets))
+	targets := h.scheduler.Due(cfg.Targets, h.Clock().Now(), cfg.Interval, cfg.Adaptive)
+	log.Debug("Getting health status for each target in separate routine", "amount", len(targets))
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	results := map[string]string{}
+	results := map[string]result{}
 
+	h.Mu.Lock()
 	client := &http.Client{
-		Timeout: h.config.Timeout,
+		Timeout:   cfg.Timeout,
+		Transport: cfg.transport(h.tunnel),
 	}
-	for _, t := range h.config.Targets {
+	auth := h.authorizers
+	retries := checks.NewRetries(cfg.Retry, cfg.TargetRetries)
+	h.Mu.Unlock()
+	for _, t := range targets {
 		target := t
 		wg.Add(1)
 		l := log.With("target", target)
 
-		getHealthRetry := helper.Retry(func(ctx context.Context) error {
-			return getHealth(ctx, client, target)
-		}, h.config.Retry)
+		probe := cfg.probeFor(target)
+		track := cfg.contentFor(target)
+		var body content
+		retry, opts := retries.For(target)
+		getHealthRetry := helper.Retry(func(ctx context.Context) (err error) {
+			body, err = getHealth(ctx, client, target, probe, cfg.Protocol, auth, track)
+			return err
+		}, retry, opts...)
 
 		go func() {
 			defer wg.Done()
@@ -193,15 +268,23 @@ func (h *Health) check(ctx context.Context) map[string]string {
 			l.Debug("Starting retry routine to get health status")
 			if err := getHealthRetry(ctx); err != nil {
 				state = 0
-				l.Warn(fmt.Sprintf("Health check failed after %d retries", h.config.Retry.Count), "error", err)
+				l.Warn(fmt.Sprintf("Health check failed after %d retries", retry.Count), "error", err)
+			}
+
+			res := result{Status: stateMapping[state], Size: body.size, Hash: body.hash, ContentChanged: track.changed(body.hash)}
+			if res.ContentChanged {
+				l.Warn("Content of target changed", "hash", res.Hash)
 			}
 
-			l.Debug("Successfully got health status of target", "status", stateMapping[state])
+			l.Debug("Successfully got health status of target", "status", res.Status)
+			h.scheduler.Report(target, state == 1, h.Clock().Now(), cfg.Adaptive)
 			mu.Lock()
 			defer mu.Unlock()
-			results[target] = stateMapping[state]
+			results[target] = res
 
-			h.metrics.WithLabelValues(target).Set(float64(state))
+			h.WithSeries(target, func(label string) {
+				h.metrics.Set(label, res, track != nil)
+			})
 		}()
 	}
 
@@ -212,20 +295,37 @@ func (h *Health) check(ctx context.Context) map[string]string {
 	return results
 }
 
-// getHealth performs an HTTP get request and returns ok if status code is 200
-func getHealth(ctx context.Context, client *http.Client, url string) error {
+// getHealth performs an HTTP request with the method of the probe
+// and returns ok if the status code is one of the probe's success codes
+// and the response was received with the forced protocol, if any.
+// The request is authorized if the target has an auth configuration
+// and sent through the proxy of the target, if any.
+// Internationalized domain names are requested in their ASCII form.
+// It returns the size of the response body and its hash if the content is tracked.
+func getHealth(ctx context.Context, client *http.Client, url string, probe Probe, protocol checks.Protocol, auth checks.Authorizers, track *Content) (content, error) {
 	log := logger.FromContext(ctx).With("url", url)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	target, err := checks.ASCIIURL(url)
+	if err != nil {
+		log.Error("Invalid internationalized domain name", "error", err)
+		return content{}, helper.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, probe.method(), target, http.NoBody)
 	if err != nil {
 		log.Error("Error while creating request", "error", err)
-		return err
+		return content{}, helper.Permanent(err)
+	}
+	req = req.WithContext(checks.WithTarget(req.Context(), url))
+	if err := auth.Authorize(ctx, req, url); err != nil {
+		log.Error("Error while authorizing request", "error", err)
+		return content{}, err
 	}
 
 	resp, err := client.Do(req) //nolint:bodyclose // Closed in defer below
 	if err != nil {
 		log.Error("Error while requesting health", "error", err)
-		return err
+		return content{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -234,10 +334,72 @@ func getHealth(ctx context.Context, client *http.Client, url string) error {
 		}
 	}(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		log.Warn("Health request was not ok (HTTP Status 200)", "status", resp.Status)
-		return fmt.Errorf("request failed, status is %s", resp.Status)
+	body, err := readContent(resp.Body, track)
+	if err != nil {
+		log.Error("Error while reading response body", "error", err)
+		return body, err
+	}
+
+	if !probe.success(resp.StatusCode) {
+		log.Warn("Health request was not ok", "status", resp.Status)
+		return body, fmt.Errorf("request failed, status is %s", resp.Status)
 	}
 
-	return nil
+	if err := protocol.Verify(resp); err != nil {
+		log.Warn("Health request was downgraded", "protocol", resp.Proto, "error", err)
+		return body, err
+	}
+
+	return body, nil
+}
+
+// TargetStates returns the state of every target reported in the data of a health check result
+func TargetStates(data any) map[string]checks.TargetState {
+	res, ok := resultsOf(data)
+	if !ok {
+		return nil
+	}
+
+	states := make(map[string]checks.TargetState, len(res))
+	for target, r := range targetResults(res) {
+		states[target] = checks.TargetState{Healthy: r.Status == stateMapping[1]}
+	}
+	return states
+}
+
+// Failures reports for every target in the data of a health check result whether it's unhealthy or its content changed
+func Failures(data any) map[string]bool {
+	res, ok := resultsOf(data)
+	if !ok {
+		return nil
+	}
+	return checks.TargetFailures(targetResults(res), result.failed)
+}
+
+// resultsOf returns the results in the data of a health check result.
+// The data of schema version 1, which maps every target to its status only, is converted.
+func resultsOf(data any) (map[string]result, bool) {
+	if res, ok := checks.ResultData[result](data); ok {
+		return res, true
+	}
+	statuses, ok := checks.ResultData[string](data)
+	if !ok {
+		return nil, false
+	}
+	res := make(map[string]result, len(statuses))
+	for key, status := range statuses {
+		res[key] = result{Status: status}
+	}
+	return res, true
+}
+
+// targetResults returns the results of the targets without the aggregated statuses of the groups
+func targetResults(res map[string]result) map[string]result {
+	targets := make(map[string]result, len(res))
+	for key, r := range res {
+		if !isGroup(key) {
+			targets[key] = r
+		}
+	}
+	return targets
 }
@@ -20,6 +20,8 @@ package health
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"testing"
 	"time"
@@ -87,14 +89,17 @@ func Test_getHealth(t *testing.T) {
 	endpoint := "https://api.test.com/test"
 
 	type args struct {
-		ctx    context.Context
-		client *http.Client
-		url    string
+		ctx      context.Context
+		client   *http.Client
+		url      string
+		probe    Probe
+		protocol checks.Protocol
 	}
 	tests := []struct {
 		name string
 		args args
 
+		method        string
 		httpResponder httpmock.Responder
 		wantErr       bool
 	}{
@@ -118,6 +123,52 @@ func Test_getHealth(t *testing.T) {
 			httpResponder: httpmock.NewStringResponder(400, ""),
 			wantErr:       true,
 		},
+		{
+			name: "head method",
+			args: args{
+				ctx:    context.Background(),
+				client: &http.Client{},
+				url:    endpoint,
+				probe:  Probe{Method: http.MethodHead},
+			},
+			method:        http.MethodHead,
+			httpResponder: httpmock.NewStringResponder(200, ""),
+			wantErr:       false,
+		},
+		{
+			name: "custom success code",
+			args: args{
+				ctx:    context.Background(),
+				client: &http.Client{},
+				url:    endpoint,
+				probe:  Probe{Method: http.MethodOptions, SuccessCodes: []int{http.StatusNoContent, http.StatusUnauthorized}},
+			},
+			method:        http.MethodOptions,
+			httpResponder: httpmock.NewStringResponder(401, ""),
+			wantErr:       false,
+		},
+		{
+			name: "status 200 not a custom success code",
+			args: args{
+				ctx:    context.Background(),
+				client: &http.Client{},
+				url:    endpoint,
+				probe:  Probe{SuccessCodes: []int{http.StatusUnauthorized}},
+			},
+			httpResponder: httpmock.NewStringResponder(200, ""),
+			wantErr:       true,
+		},
+		{
+			name: "protocol downgraded",
+			args: args{
+				ctx:      context.Background(),
+				client:   &http.Client{},
+				url:      endpoint,
+				protocol: checks.ProtocolHTTP2,
+			},
+			httpResponder: httpmock.NewStringResponder(200, ""),
+			wantErr:       true,
+		},
 		{
 			name: "ctx is nil",
 			args: args{
@@ -128,6 +179,16 @@ func Test_getHealth(t *testing.T) {
 			httpResponder: httpmock.NewStringResponder(200, ""),
 			wantErr:       true,
 		},
+		{
+			name: "invalid internationalized domain name",
+			args: args{
+				ctx:    context.Background(),
+				client: &http.Client{},
+				url:    "https://xn--a.test.com/test",
+			},
+			httpResponder: httpmock.NewStringResponder(200, ""),
+			wantErr:       true,
+		},
 		{
 			name: "unknown url",
 			args: args{
@@ -140,9 +201,14 @@ func Test_getHealth(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		httpmock.RegisterResponder(http.MethodGet, endpoint, tt.httpResponder)
+		httpmock.Reset()
+		method := tt.method
+		if method == "" {
+			method = http.MethodGet
+		}
+		httpmock.RegisterResponder(method, endpoint, tt.httpResponder)
 		t.Run(tt.name, func(t *testing.T) {
-			if err := getHealth(tt.args.ctx, tt.args.client, tt.args.url); (err != nil) != tt.wantErr {
+			if _, err := getHealth(tt.args.ctx, tt.args.client, tt.args.url, tt.args.probe, tt.args.protocol, nil, nil); (err != nil) != tt.wantErr {
 				t.Errorf("getHealth() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -158,14 +224,14 @@ func TestHealth_Check(t *testing.T) {
 		registeredEndpoints map[string]int
 		targets             []string
 		ctx                 context.Context
-		want                map[string]string
+		want                map[string]result
 	}{
 		{
 			name:                "no target",
 			registeredEndpoints: nil,
 			targets:             []string{},
 			ctx:                 context.Background(),
-			want:                map[string]string{},
+			want:                map[string]result{},
 		},
 		{
 			name: "one target healthy",
@@ -176,8 +242,8 @@ func TestHealth_Check(t *testing.T) {
 				"https://api.test.com",
 			},
 			ctx: context.Background(),
-			want: map[string]string{
-				"https://api.test.com": "healthy",
+			want: map[string]result{
+				"https://api.test.com": {Status: "healthy"},
 			},
 		},
 		{
@@ -189,8 +255,8 @@ func TestHealth_Check(t *testing.T) {
 				"https://api.test.com",
 			},
 			ctx: context.Background(),
-			want: map[string]string{
-				"https://api.test.com": "unhealthy",
+			want: map[string]result{
+				"https://api.test.com": {Status: "unhealthy"},
 			},
 		},
 		{
@@ -210,12 +276,12 @@ func TestHealth_Check(t *testing.T) {
 				"https://api5.test.com",
 			},
 			ctx: context.Background(),
-			want: map[string]string{
-				"https://api1.test.com": "healthy",
-				"https://api2.test.com": "unhealthy",
-				"https://api3.test.com": "healthy",
-				"https://api4.test.com": "unhealthy",
-				"https://api5.test.com": "healthy",
+			want: map[string]result{
+				"https://api1.test.com": {Status: "healthy"},
+				"https://api2.test.com": {Status: "unhealthy"},
+				"https://api3.test.com": {Status: "healthy"},
+				"https://api4.test.com": {Status: "unhealthy"},
+				"https://api5.test.com": {Status: "healthy"},
 			},
 		},
 	}
@@ -235,14 +301,14 @@ func TestHealth_Check(t *testing.T) {
 				},
 				metrics: newMetrics(),
 			}
-			got := h.check(tt.ctx)
+			got := h.check(tt.ctx, h.config)
 			assert.Equal(t, len(got), len(tt.want), "Amount of targets is not equal")
 			for target, status := range tt.want {
 				helperStatus := "unhealthy"
 				if tt.registeredEndpoints[target] == 200 {
 					helperStatus = "healthy"
 				}
-				assert.Equal(t, helperStatus, status, "Target does not map with expected target")
+				assert.Equal(t, helperStatus, status.Status, "Target does not map with expected target")
 			}
 		})
 	}
@@ -277,3 +343,91 @@ func TestHealth_Shutdown(t *testing.T) {
 		hc.(*Health).DoneChan <- struct{}{}
 	}, "Channel is closed, should panic")
 }
+
+func TestTargetStates(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		want map[string]checks.TargetState
+	}{
+		{
+			name: "healthy and unhealthy targets",
+			data: map[string]result{
+				"https://healthy.com":   {Status: "healthy"},
+				"https://unhealthy.com": {Status: "unhealthy"},
+			},
+			want: map[string]checks.TargetState{
+				"https://healthy.com":   {Healthy: true},
+				"https://unhealthy.com": {Healthy: false},
+			},
+		},
+		{
+			name: "group statuses are ignored",
+			data: map[string]result{
+				"https://healthy.com": {Status: "healthy"},
+				"group:svc":           {Status: "healthy"},
+			},
+			want: map[string]checks.TargetState{
+				"https://healthy.com": {Healthy: true},
+			},
+		},
+		{
+			name: "schema version 1",
+			data: map[string]string{
+				"https://healthy.com":   "healthy",
+				"https://unhealthy.com": "unhealthy",
+			},
+			want: map[string]checks.TargetState{
+				"https://healthy.com":   {Healthy: true},
+				"https://unhealthy.com": {Healthy: false},
+			},
+		},
+		{
+			name: "unexpected data",
+			data: 42,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TargetStates(tt.data))
+		})
+	}
+}
+
+func TestHealth_check_content(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(http.MethodGet, "https://status.test.com", httpmock.NewStringResponder(200, "all systems operational"))
+	httpmock.RegisterResponder(http.MethodGet, "https://defaced.test.com", httpmock.NewStringResponder(200, "hacked"))
+	httpmock.RegisterResponder(http.MethodGet, "https://api.test.com", httpmock.NewStringResponder(200, "ok"))
+
+	sum := sha256.Sum256([]byte("all systems operational"))
+	expected := hex.EncodeToString(sum[:])
+	defaced := sha256.Sum256([]byte("hacked"))
+
+	h := &Health{
+		config: Config{
+			Targets: []string{"https://status.test.com", "https://defaced.test.com", "https://api.test.com"},
+			Timeout: time.Second,
+			Content: []Content{{Targets: []string{"https://status.test.com", "https://defaced.test.com"}, Hash: expected}},
+		},
+		metrics: newMetrics(),
+	}
+
+	got := h.check(context.Background(), h.config)
+	want := map[string]result{
+		"https://status.test.com":  {Status: "healthy", Size: 23, Hash: expected},
+		"https://defaced.test.com": {Status: "healthy", Size: 6, Hash: hex.EncodeToString(defaced[:]), ContentChanged: true},
+		"https://api.test.com":     {Status: "healthy", Size: 2},
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, map[string]bool{
+		"https://status.test.com":  false,
+		"https://defaced.test.com": true,
+		"https://api.test.com":     false,
+	}, Failures(got))
+	assert.Equal(t, 1.0, gaugeValue(t, h.metrics.contentChanged.WithLabelValues("https://defaced.test.com")))
+	assert.Equal(t, 6.0, gaugeValue(t, h.metrics.size.WithLabelValues("https://defaced.test.com")))
+}
@@ -26,6 +26,14 @@ import (
 // metrics contains the metric collectors for the Health check
 type metrics struct {
 	*prometheus.GaugeVec
+	// groups is the aggregated health of the target groups
+	groups *prometheus.GaugeVec
+	// groupTargets is the number of healthy targets of the target groups
+	groupTargets *prometheus.GaugeVec
+	// size is the size of the response bodies of the targets
+	size *prometheus.GaugeVec
+	// contentChanged reports the targets whose content deviates from the expected hash
+	contentChanged *prometheus.GaugeVec
 }
 
 // newMetrics initializes metric collectors of the health check
@@ -40,13 +48,94 @@ func newMetrics() metrics {
 				"target",
 			},
 		),
+		groups: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_health_group_up",
+				Help: "Aggregated health of target groups. Healthy if the quorum of targets is healthy.",
+			},
+			[]string{
+				"group",
+			},
+		),
+		groupTargets: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_health_group_healthy_targets",
+				Help: "Number of healthy targets of target groups",
+			},
+			[]string{
+				"group",
+			},
+		),
+		size: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_health_response_size_bytes",
+				Help: "Size of the response body of targets",
+			},
+			[]string{
+				"target",
+			},
+		),
+		contentChanged: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_health_content_changed",
+				Help: "Specifies if the content of targets deviates from the expected hash",
+			},
+			[]string{
+				"target",
+			},
+		),
 	}
 }
 
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.GaugeVec,
+		m.groups,
+		m.groupTargets,
+		m.size,
+		m.contentChanged,
+	}
+}
+
+// Set sets the metrics of a target. The content changed metric
+// is only set if the content of the target is tracked.
+func (m *metrics) Set(target string, res result, tracked bool) {
+	state := 0.0
+	if res.Status == stateMapping[1] {
+		state = 1
+	}
+	m.WithLabelValues(target).Set(state)
+	m.size.WithLabelValues(target).Set(float64(res.Size))
+	if !tracked {
+		m.contentChanged.DeleteLabelValues(target)
+		return
+	}
+	changed := 0.0
+	if res.ContentChanged {
+		changed = 1
+	}
+	m.contentChanged.WithLabelValues(target).Set(changed)
+}
+
 // Remove removes a metric with a specific label
 func (m *metrics) Remove(label string) error {
 	if !m.DeleteLabelValues(label) {
 		return checks.ErrMetricNotFound{Label: label}
 	}
+	m.size.DeleteLabelValues(label)
+	m.contentChanged.DeleteLabelValues(label)
 	return nil
 }
+
+// SetGroup sets the metrics of a target group
+func (m *metrics) SetGroup(group string, healthy, state int) {
+	m.groups.WithLabelValues(group).Set(float64(state))
+	m.groupTargets.WithLabelValues(group).Set(float64(healthy))
+}
+
+// RemoveGroup removes the metrics of a target group
+func (m *metrics) RemoveGroup(group string) {
+	m.groups.DeleteLabelValues(group)
+	m.groupTargets.DeleteLabelValues(group)
+}
@@ -0,0 +1,98 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// methods are the HTTP methods targets can be probed with
+var methods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+
+// defaultProbe is how targets without a probe configuration are probed
+var defaultProbe = Probe{Method: http.MethodGet, SuccessCodes: []int{http.StatusOK}}
+
+// Probe configures how targets are probed, e.g. for endpoints that intentionally answer with a non-2xx status
+type Probe struct {
+	// Targets are the targets probed this way. They must be targets of the check.
+	Targets []string `json:"targets" yaml:"targets"`
+	// Method is the HTTP method of the probes: GET, HEAD or OPTIONS. Defaults to GET.
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	// SuccessCodes are the HTTP status codes the targets are healthy with. Defaults to 200.
+	SuccessCodes []int `json:"successCodes,omitempty" yaml:"successCodes,omitempty"`
+}
+
+// method returns the HTTP method of the probes
+func (p *Probe) method() string {
+	if p.Method == "" {
+		return defaultProbe.Method
+	}
+	return p.Method
+}
+
+// success returns true if the target is healthy with the given status code
+func (p *Probe) success(code int) bool {
+	if len(p.SuccessCodes) == 0 {
+		return slices.Contains(defaultProbe.SuccessCodes, code)
+	}
+	return slices.Contains(p.SuccessCodes, code)
+}
+
+// probeFor returns the probe configuration of the target
+func (c *Config) probeFor(target string) Probe {
+	for _, p := range c.Probes {
+		if slices.Contains(p.Targets, target) {
+			return p
+		}
+	}
+	return defaultProbe
+}
+
+// validateProbes checks if the probes are valid and every target is probed at most one way
+func validateProbes(probes []Probe, targets []string) error {
+	probed := map[string]struct{}{}
+	for i, p := range probes {
+		field := fmt.Sprintf("probes[%d]", i)
+		if len(p.Targets) == 0 {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".targets", Reason: "must not be empty"}
+		}
+		for _, t := range p.Targets {
+			if !slices.Contains(targets, t) {
+				return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".targets", Reason: fmt.Sprintf("%q is not a target of the check", t)}
+			}
+			if _, ok := probed[t]; ok {
+				return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".targets", Reason: fmt.Sprintf("%q is already configured by another probe", t)}
+			}
+			probed[t] = struct{}{}
+		}
+		if p.Method != "" && !slices.Contains(methods, p.Method) {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".method", Reason: "must be GET, HEAD or OPTIONS"}
+		}
+		for _, code := range p.SuccessCodes {
+			if code < 100 || code > 599 {
+				return checks.ErrInvalidConfig{CheckName: CheckName, Field: field + ".successCodes", Reason: fmt.Sprintf("%d is not a valid HTTP status code", code)}
+			}
+		}
+	}
+	return nil
+}
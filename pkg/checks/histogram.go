@@ -0,0 +1,116 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ prometheus.Collector = (*HistogramVec)(nil)
+
+// Buckets are the upper bounds of the buckets of a histogram in seconds.
+// The default buckets of the Prometheus client are used if none are configured.
+type Buckets []float64
+
+// Validate checks if the buckets are positive and strictly increasing
+func (b Buckets) Validate(checkName string) error {
+	for i, v := range b {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v <= 0 {
+			return ErrInvalidConfig{CheckName: checkName, Field: "buckets", Reason: "must be positive numbers"}
+		}
+		if i > 0 && v <= b[i-1] {
+			return ErrInvalidConfig{CheckName: checkName, Field: "buckets", Reason: "must be in strictly increasing order"}
+		}
+	}
+	return nil
+}
+
+// values returns the configured buckets or the default buckets if none are configured
+func (b Buckets) values() []float64 {
+	if len(b) == 0 {
+		return prometheus.DefBuckets
+	}
+	return b
+}
+
+// HistogramVec is a histogram vector whose buckets can be reconfigured while it's registered.
+// The buckets aren't part of the description of a histogram, so the collector stays registered
+// when they change. Changing the buckets resets the observations.
+type HistogramVec struct {
+	mu     sync.RWMutex
+	opts   prometheus.HistogramOpts
+	labels []string
+	vec    *prometheus.HistogramVec
+}
+
+// NewHistogramVec creates a histogram vector with the default buckets
+func NewHistogramVec(opts prometheus.HistogramOpts, labels []string) *HistogramVec {
+	opts.Buckets = prometheus.DefBuckets
+	return &HistogramVec{opts: opts, labels: labels, vec: prometheus.NewHistogramVec(opts, labels)}
+}
+
+// SetBuckets replaces the buckets of the histograms if they changed
+func (h *HistogramVec) SetBuckets(buckets Buckets) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if slices.Equal(h.opts.Buckets, buckets.values()) {
+		return
+	}
+	h.opts.Buckets = buckets.values()
+	h.vec = prometheus.NewHistogramVec(h.opts, h.labels)
+}
+
+// WithLabelValues returns the histogram for the given label values
+func (h *HistogramVec) WithLabelValues(lvs ...string) prometheus.Observer {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.vec.WithLabelValues(lvs...)
+}
+
+// Delete deletes the histogram with the given labels and returns true if it existed
+func (h *HistogramVec) Delete(labels prometheus.Labels) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.vec.Delete(labels)
+}
+
+// DeleteLabelValues deletes the histogram with the given label values and returns true if it existed
+func (h *HistogramVec) DeleteLabelValues(lvs ...string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.vec.DeleteLabelValues(lvs...)
+}
+
+// Describe sends the description of the histograms, which doesn't depend on the buckets
+func (h *HistogramVec) Describe(ch chan<- *prometheus.Desc) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	h.vec.Describe(ch)
+}
+
+// Collect sends the histograms with their current buckets
+func (h *HistogramVec) Collect(ch chan<- prometheus.Metric) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	h.vec.Collect(ch)
+}
@@ -0,0 +1,91 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestBuckets_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets Buckets
+		wantErr bool
+	}{
+		{name: "default"},
+		{name: "sub-10ms resolution", buckets: Buckets{0.001, 0.0025, 0.005, 0.01, 0.1, 1}},
+		{name: "not increasing", buckets: Buckets{0.01, 0.005}, wantErr: true},
+		{name: "duplicate", buckets: Buckets{0.01, 0.01}, wantErr: true},
+		{name: "zero", buckets: Buckets{0, 0.01}, wantErr: true},
+		{name: "infinite", buckets: Buckets{0.01, math.Inf(1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.buckets.Validate("latency"); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHistogramVec_SetBuckets(t *testing.T) {
+	h := NewHistogramVec(prometheus.HistogramOpts{Name: "test_duration", Help: "test"}, []string{"target"})
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(h); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	h.WithLabelValues("a").Observe(0.003)
+	if got := bucketsOf(t, reg); len(got) != len(prometheus.DefBuckets) {
+		t.Errorf("default buckets = %v, want %v", got, prometheus.DefBuckets)
+	}
+
+	h.SetBuckets(Buckets{0.001, 0.005, 0.01})
+	h.WithLabelValues("a").Observe(0.003)
+	got := bucketsOf(t, reg)
+	if len(got) != 3 || got[1].GetUpperBound() != 0.005 || got[1].GetCumulativeCount() != 1 {
+		t.Errorf("configured buckets = %v", got)
+	}
+
+	if n := testutil.CollectAndCount(h); n != 1 {
+		t.Errorf("CollectAndCount() = %d, want 1", n)
+	}
+	if !h.DeleteLabelValues("a") {
+		t.Error("DeleteLabelValues() = false, want true")
+	}
+}
+
+// bucketsOf returns the buckets of the only histogram gathered from the registry
+func bucketsOf(t *testing.T, reg *prometheus.Registry) []*dto.Bucket {
+	t.Helper()
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	if len(mfs) != 1 || len(mfs[0].GetMetric()) != 1 {
+		t.Fatalf("Gather() = %v, want a single histogram", mfs)
+	}
+	return mfs[0].GetMetric()[0].GetHistogram().GetBucket()
+}
@@ -0,0 +1,138 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpheaders
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+const (
+	minInterval = 100 * time.Millisecond
+	minTimeout  = 1 * time.Second
+)
+
+// DefaultPolicy is the policy the targets are audited against if none is configured.
+// It follows the recommendations of the OWASP Secure Headers Project.
+var DefaultPolicy = []Rule{
+	{Header: "Strict-Transport-Security", Pattern: `max-age=[1-9][0-9]*`},
+	{Header: "Content-Security-Policy"},
+	{Header: "X-Frame-Options", Pattern: `(?i)^(deny|sameorigin)$`},
+	{Header: "X-Content-Type-Options", Pattern: `(?i)^nosniff$`},
+	{Header: "Referrer-Policy"},
+}
+
+// Config defines the configuration parameters for a security headers audit check
+type Config struct {
+	// Targets is a list of URLs whose response headers are audited
+	Targets []string `json:"targets" yaml:"targets" mapstructure:"targets"`
+	// Policy are the rules the response headers of every target are audited against.
+	// The DefaultPolicy is applied if empty.
+	Policy []Rule `json:"policy,omitempty" yaml:"policy,omitempty" mapstructure:"policy"`
+	// Interval is the time to wait between check iterations
+	Interval time.Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+	// Schedule is a cron expression defining when the check runs. It replaces the interval if set.
+	Schedule checks.Schedule `json:"schedule,omitempty" yaml:"schedule,omitempty" mapstructure:"schedule"`
+	// Timeout is the maximum time to wait for the response of a target
+	Timeout time.Duration `json:"timeout" yaml:"timeout" mapstructure:"timeout"`
+	// Retry defines if and how to retry a target
+	Retry helper.RetryConfig `json:"retry" yaml:"retry" mapstructure:"retry"`
+	// Network configures the DSCP marking and the source binding of the requests
+	Network checks.NetworkConfig `json:"network,omitempty" yaml:"network,omitempty" mapstructure:"network"`
+	// TargetRetries override the retry configuration for some targets
+	TargetRetries []checks.TargetRetry `json:"targetRetries,omitempty" yaml:"targetRetries,omitempty" mapstructure:"targetRetries"`
+}
+
+// Rule is the rule of the policy for a single response header
+type Rule struct {
+	// Header is the name of the header, e.g. Strict-Transport-Security
+	Header string `json:"header" yaml:"header" mapstructure:"header"`
+	// Pattern is a regular expression the value of the header must match.
+	// The header only has to be present if empty.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty" mapstructure:"pattern"`
+	// Absent requires the header not to be sent, e.g. X-Powered-By disclosing the server software
+	Absent bool `json:"absent,omitempty" yaml:"absent,omitempty" mapstructure:"absent"`
+}
+
+func (c *Config) For() string {
+	return CheckName
+}
+
+func (c *Config) Validate() error {
+	for i, t := range c.Targets {
+		u, err := url.Parse(t)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "target URLs must start with 'https://' or 'http://'"}
+		}
+	}
+
+	seen := map[string]struct{}{}
+	for i, r := range c.Policy {
+		if r.Header == "" || strings.ContainsAny(r.Header, " \t\r\n:") {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("policy[%d].header", i), Reason: "must be a valid header name"}
+		}
+		name := http.CanonicalHeaderKey(r.Header)
+		if _, ok := seen[name]; ok {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("policy[%d].header", i), Reason: fmt.Sprintf("header %q is configured more than once", name)}
+		}
+		seen[name] = struct{}{}
+
+		if r.Absent && r.Pattern != "" {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("policy[%d].pattern", i), Reason: "can't be combined with absent"}
+		}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("policy[%d].pattern", i), Reason: err.Error()}
+		}
+	}
+
+	if !c.Schedule.Enabled() && c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+	if err := c.Schedule.Validate(c.For()); err != nil {
+		return err
+	}
+	if err := checks.ValidateRetries(CheckName, c.TargetRetries, c.Targets); err != nil {
+		return err
+	}
+
+	timing := checks.Timing{Interval: c.Interval, Timeout: c.Timeout, Schedule: c.Schedule, Retry: c.Retry, TargetRetries: c.TargetRetries}
+	if err := timing.Validate(CheckName); err != nil {
+		return err
+	}
+
+	return c.Network.Validate(CheckName)
+}
+
+// policy returns the configured policy or the DefaultPolicy if none is configured
+func (c *Config) policy() []Rule {
+	if len(c.Policy) == 0 {
+		return DefaultPolicy
+	}
+	return c.Policy
+}
@@ -0,0 +1,127 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpheaders
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				Targets:  []string{"https://example.com", "http://example.com:8080/login"},
+				Policy:   []Rule{{Header: "X-Frame-Options", Pattern: "(?i)^deny$"}, {Header: "X-Powered-By", Absent: true}},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "default policy",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid target",
+			config: Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid header name",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Policy:   []Rule{{Header: "X-Frame-Options:"}},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "header configured twice",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Policy:   []Rule{{Header: "X-Frame-Options"}, {Header: "x-frame-options", Pattern: "DENY"}},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid pattern",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Policy:   []Rule{{Header: "X-Frame-Options", Pattern: "(deny"}},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "pattern of absent header",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Policy:   []Rule{{Header: "Server", Pattern: "nginx", Absent: true}},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  10 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
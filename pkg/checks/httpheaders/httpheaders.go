@@ -0,0 +1,324 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpheaders
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_ checks.Check   = (*HTTPHeaders)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "httpheaders"
+
+// SchemaVersion is the version of the layout of the check's result data.
+// It is incremented with every breaking change of the layout.
+const SchemaVersion = 1
+
+// HTTPHeaders is a check that audits the security headers
+// of the responses of the targets against a policy
+type HTTPHeaders struct {
+	checks.CheckBase
+	config  Config
+	metrics metrics
+}
+
+// NewCheck creates a new instance of the security headers audit check
+func NewCheck() checks.Check {
+	return &HTTPHeaders{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics: newMetrics(),
+	}
+}
+
+// result represents the result of the audit of a single target
+type result struct {
+	// Headers are the findings of the headers of the policy, keyed by the canonical header name
+	Headers map[string]finding `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Passed is true if all headers comply with the policy
+	Passed bool `json:"passed" yaml:"passed"`
+	// Error is the error that occurred while requesting the target
+	Error *string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// finding is the outcome of the audit of a single header
+type finding struct {
+	// Passed is true if the header complies with its rule
+	Passed bool `json:"passed" yaml:"passed"`
+	// Value is the value of the header sent by the target
+	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	// Reason explains why the header doesn't comply with its rule
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Run starts the security headers audit check
+func (h *HTTPHeaders) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	log.Info("Starting httpheaders check", "interval", h.config.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-h.DoneChan:
+			return nil
+		case <-h.Clock().After(h.config.Schedule.Next(h.Clock().Now(), h.config.Interval)):
+			res := h.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: h.Name(),
+				Result: &checks.Result{
+					Data:          res,
+					Timestamp:     h.Clock().Now(),
+					SchemaVersion: SchemaVersion,
+				},
+			}
+			log.Debug("Successfully finished httpheaders check run")
+		}
+	}
+}
+
+// RunOnce runs the security headers audit check once against all targets
+func (h *HTTPHeaders) RunOnce(ctx context.Context) (*checks.Result, []string) {
+	res := h.check(ctx)
+	failed := checks.FailedTargets(res, result.failed)
+	return &checks.Result{Data: res, Timestamp: h.Clock().Now(), SchemaVersion: SchemaVersion}, failed
+}
+
+// failed returns true if the target failed
+func (r result) failed() bool {
+	return r.Error != nil || !r.Passed
+}
+
+// Failures reports for every target in the data of a security headers audit check result whether it failed
+func Failures(data any) map[string]bool {
+	res, _ := checks.ResultData[result](data)
+	return checks.TargetFailures(res, result.failed)
+}
+
+func (h *HTTPHeaders) Shutdown() {
+	h.DoneChan <- struct{}{}
+	close(h.DoneChan)
+}
+
+func (h *HTTPHeaders) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		h.Mu.Lock()
+		defer h.Mu.Unlock()
+
+		if err := h.SetTargets(c.Targets, h.metrics.Remove); err != nil {
+			return err
+		}
+
+		h.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+func (h *HTTPHeaders) GetConfig() checks.Runtime {
+	h.Mu.Lock()
+	defer h.Mu.Unlock()
+	return &h.config
+}
+
+func (h *HTTPHeaders) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the security headers audit check
+func (h *HTTPHeaders) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (h *HTTPHeaders) GetMetricCollectors() []prometheus.Collector {
+	return h.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (h *HTTPHeaders) RemoveLabelledMetrics(target string) error {
+	return h.RemoveSeries(target, h.metrics.Remove)
+}
+
+// check requests all targets and audits their response headers against the policy
+func (h *HTTPHeaders) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Checking security headers")
+	h.Mu.Lock()
+	cfg := h.config
+	h.Mu.Unlock()
+
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+
+	rules := compile(cfg.policy())
+	client := &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: cfg.Network.Transport(cfg.Timeout),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	log.Debug("Auditing each target in separate routine", "amount", len(cfg.Targets))
+	retries := checks.NewRetries(cfg.Retry, cfg.TargetRetries)
+	for _, target := range cfg.Targets {
+		wg.Add(1)
+		lo := log.With("target", target)
+		retry, opts := retries.For(target)
+
+		auditRetry := helper.Retry(func(ctx context.Context) error {
+			header, err := getHeaders(ctx, client, target)
+			res := result{}
+			if err != nil {
+				errval := err.Error()
+				res.Error = &errval
+			} else {
+				res = audit(header, rules)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[target] = res
+			return err
+		}, retry, opts...)
+
+		go func() {
+			defer wg.Done()
+			lo.Debug("Starting retry routine to audit the security headers")
+			if err := auditRetry(ctx); err != nil {
+				lo.Warn("Error while requesting target", "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for target, res := range results {
+		if !res.Passed && res.Error == nil {
+			log.Warn("Security headers don't comply with the policy", "target", target)
+		}
+		h.WithSeries(target, func(label string) {
+			h.metrics.Set(label, res)
+		})
+	}
+
+	log.Debug("Successfully audited all targets")
+	return results
+}
+
+// getHeaders performs an HTTP GET request and returns the headers of the response.
+// Redirects are followed, so the headers of the final response are returned.
+func getHeaders(ctx context.Context, client *http.Client, target string) (http.Header, error) {
+	u, err := checks.ASCIIURL(target)
+	if err != nil {
+		return nil, helper.Permanent(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, helper.Permanent(err)
+	}
+
+	resp, err := client.Do(req) //nolint:bodyclose // Closed in defer below
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+	return resp.Header, nil
+}
+
+// rule is a rule of the policy with its compiled pattern
+type rule struct {
+	Rule
+	pattern *regexp.Regexp
+}
+
+// compile compiles the patterns of the rules, which are validated before
+func compile(policy []Rule) []rule {
+	rules := make([]rule, 0, len(policy))
+	for _, r := range policy {
+		cr := rule{Rule: r}
+		if r.Pattern != "" {
+			cr.pattern = regexp.MustCompile(r.Pattern)
+		}
+		rules = append(rules, cr)
+	}
+	return rules
+}
+
+// audit audits the response headers against the rules of the policy
+func audit(header http.Header, rules []rule) result {
+	res := result{Headers: make(map[string]finding, len(rules)), Passed: true}
+	for _, r := range rules {
+		name := http.CanonicalHeaderKey(r.Header)
+		values := header.Values(name)
+		f := finding{Value: strings.Join(values, ", ")}
+
+		switch {
+		case r.Absent && len(values) > 0:
+			f.Reason = "header must not be sent"
+		case r.Absent:
+			f.Passed = true
+		case len(values) == 0:
+			f.Reason = "header is missing"
+		case r.pattern != nil && !r.pattern.MatchString(f.Value):
+			f.Reason = fmt.Sprintf("value doesn't match %q", r.Pattern)
+		default:
+			f.Passed = true
+		}
+
+		res.Headers[name] = f
+		res.Passed = res.Passed && f.Passed
+	}
+	return res
+}
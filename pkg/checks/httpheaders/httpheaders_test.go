@@ -0,0 +1,209 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpheaders
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHeaders_Run(t *testing.T) {
+	secure := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Frame-Options", "DENY")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	}))
+	defer secure.Close()
+	insecure := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Frame-Options", "ALLOW-FROM https://example.com")
+		w.Header().Set("X-Powered-By", "PHP/5.6")
+	}))
+	defer insecure.Close()
+
+	policy := []Rule{
+		{Header: "x-frame-options", Pattern: `(?i)^(deny|sameorigin)$`},
+		{Header: "X-Content-Type-Options"},
+		{Header: "X-Powered-By", Absent: true},
+	}
+
+	tests := []struct {
+		name    string
+		targets []string
+		want    map[string]result
+	}{
+		{
+			name:    "success with no targets",
+			targets: []string{},
+			want:    map[string]result{},
+		},
+		{
+			name:    "compliant target",
+			targets: []string{secure.URL},
+			want: map[string]result{
+				secure.URL: {
+					Headers: map[string]finding{
+						"X-Frame-Options":        {Passed: true, Value: "DENY"},
+						"X-Content-Type-Options": {Passed: true, Value: "nosniff"},
+						"X-Powered-By":           {Passed: true},
+					},
+					Passed: true,
+				},
+			},
+		},
+		{
+			name:    "non-compliant target",
+			targets: []string{insecure.URL},
+			want: map[string]result{
+				insecure.URL: {
+					Headers: map[string]finding{
+						"X-Frame-Options":        {Value: "ALLOW-FROM https://example.com", Reason: `value doesn't match "(?i)^(deny|sameorigin)$"`},
+						"X-Content-Type-Options": {Reason: "header is missing"},
+						"X-Powered-By":           {Value: "PHP/5.6", Reason: "header must not be sent"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCommonHTTPHeaders()
+			cResult := make(chan checks.ResultDTO, 1)
+			defer close(cResult)
+
+			err := c.UpdateConfig(&Config{
+				Targets:  tt.targets,
+				Policy:   policy,
+				Interval: 10 * time.Millisecond,
+				Timeout:  time.Second,
+			})
+			if err != nil {
+				t.Fatalf("HTTPHeaders.UpdateConfig() error = %v", err)
+			}
+
+			go func() {
+				err := c.Run(context.Background(), cResult)
+				if err != nil {
+					t.Errorf("HTTPHeaders.Run() error = %v", err)
+					return
+				}
+			}()
+			defer c.Shutdown()
+
+			r := <-cResult
+			got, ok := r.Result.Data.(map[string]result)
+			if !ok {
+				t.Fatalf("HTTPHeaders.Run() result data has type %T", r.Result.Data)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHTTPHeaders_RunOnce_unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	target := srv.URL
+	srv.Close()
+
+	c := newCommonHTTPHeaders()
+	if err := c.UpdateConfig(&Config{Targets: []string{target}, Interval: time.Second, Timeout: time.Second}); err != nil {
+		t.Fatalf("HTTPHeaders.UpdateConfig() error = %v", err)
+	}
+
+	res, failed := c.RunOnce(context.Background())
+	data := res.Data.(map[string]result)
+	if data[target].Error == nil {
+		t.Errorf("HTTPHeaders.RunOnce() expected an error for the unreachable target")
+	}
+	assert.Equal(t, []string{target}, failed)
+}
+
+func TestHTTPHeaders_UpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   checks.Runtime
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "simple config",
+			input: &Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+			},
+			want: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+			},
+		},
+		{
+			name:    "wrong type",
+			input:   &health.Config{Targets: []string{"https://example.com"}},
+			want:    Config{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &HTTPHeaders{}
+
+			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("HTTPHeaders.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, c.config, "Config is not equal")
+		})
+	}
+}
+
+func TestAudit_defaultPolicy(t *testing.T) {
+	header := http.Header{}
+	header.Set("Strict-Transport-Security", "max-age=0")
+	header.Set("Content-Security-Policy", "default-src 'self'")
+	header.Set("X-Frame-Options", "sameorigin")
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Referrer-Policy", "no-referrer")
+
+	res := audit(header, compile(DefaultPolicy))
+	if res.Passed {
+		t.Errorf("audit() passed, want the disabled HSTS to fail")
+	}
+	for name, f := range res.Headers {
+		if wantPassed := name != "Strict-Transport-Security"; f.Passed != wantPassed {
+			t.Errorf("audit() header %s passed = %v, want %v", name, f.Passed, wantPassed)
+		}
+	}
+}
+
+func newCommonHTTPHeaders() *HTTPHeaders {
+	return &HTTPHeaders{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		metrics: newMetrics(),
+	}
+}
@@ -0,0 +1,77 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpheaders
+
+import (
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics defines the metric collectors of the security headers audit check
+type metrics struct {
+	passed *prometheus.GaugeVec
+	header *prometheus.GaugeVec
+}
+
+// newMetrics initializes metric collectors of the security headers audit check
+func newMetrics() metrics {
+	return metrics{
+		passed: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_httpheaders_passed",
+				Help: "Specifies if all security headers of the target comply with the policy.",
+			},
+			[]string{"target"},
+		),
+		header: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_httpheaders_header_passed",
+				Help: "Specifies if the security header of the target complies with its rule of the policy.",
+			},
+			[]string{"target", "header"},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.passed,
+		m.header,
+	}
+}
+
+// Set sets the metrics of a target. The series of headers
+// no longer contained in the result are removed.
+func (m *metrics) Set(target string, res result) {
+	m.passed.WithLabelValues(target).Set(checks.BoolToFloat(res.Error == nil && res.Passed))
+	m.header.DeletePartialMatch(prometheus.Labels{"target": target})
+	for name, f := range res.Headers {
+		m.header.WithLabelValues(target, name).Set(checks.BoolToFloat(f.Passed))
+	}
+}
+
+// Remove removes the metrics of a target
+func (m *metrics) Remove(target string) error {
+	if !m.passed.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+	m.header.DeletePartialMatch(prometheus.Labels{"target": target})
+	return nil
+}
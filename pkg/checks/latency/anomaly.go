@@ -0,0 +1,125 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package latency
+
+import (
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+const (
+	// defaultAnomalyWindow is the amount of probes the baseline is averaged over if none is configured
+	defaultAnomalyWindow = 30
+	// minDeviation is the lower bound of the standard deviation of a baseline in seconds,
+	// so sub-millisecond jitter of very stable targets isn't flagged as an anomaly
+	minDeviation = 0.001
+)
+
+// Anomaly flags the targets whose latency deviates from their own baseline.
+// The baseline is an exponentially weighted moving average of the latencies of the target.
+type Anomaly struct {
+	// Threshold is the z-score, the deviation from the baseline in standard deviations,
+	// above which a latency is anomalous, e.g. 3. Disabled if 0.
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	// Window is the amount of probes the baseline is averaged over.
+	// Targets aren't flagged before their baseline covers a full window. Defaults to 30.
+	Window int `json:"window,omitempty" yaml:"window,omitempty"`
+}
+
+// Enabled returns true if anomalies are detected
+func (a Anomaly) Enabled() bool {
+	return a.Threshold > 0
+}
+
+// Validate validates the anomaly detection configuration
+func (a Anomaly) Validate(checkName string) error {
+	if a.Threshold < 0 {
+		return checks.ErrInvalidConfig{CheckName: checkName, Field: "anomaly.threshold", Reason: "must be equal or greater than 0"}
+	}
+	if a.Window < 0 || a.Window == 1 {
+		return checks.ErrInvalidConfig{CheckName: checkName, Field: "anomaly.window", Reason: "must be at least 2"}
+	}
+	return nil
+}
+
+// window returns the configured window or the default one
+func (a Anomaly) window() int {
+	if a.Window == 0 {
+		return defaultAnomalyWindow
+	}
+	return a.Window
+}
+
+// baseline is the exponentially weighted mean and variance of the latencies of a target
+type baseline struct {
+	mean     float64
+	variance float64
+	samples  int
+}
+
+// detector keeps the baselines of the targets across the runs
+type detector struct {
+	mu        sync.Mutex
+	baselines map[string]*baseline
+}
+
+// observe reports whether the latency of the result deviates from the baseline of the target
+// by more than the threshold and adds it to the baseline afterwards, so a lasting shift
+// becomes the new baseline. Failed probes are neither anomalous nor added to the baseline.
+func (d *detector) observe(cfg Anomaly, target string, r result) bool {
+	if !cfg.Enabled() || r.Error != nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.baselines == nil {
+		d.baselines = map[string]*baseline{}
+	}
+	b, ok := d.baselines[target]
+	if !ok {
+		d.baselines[target] = &baseline{mean: r.Total, samples: 1}
+		return false
+	}
+
+	window := cfg.window()
+	deviation := math.Max(math.Sqrt(b.variance), minDeviation)
+	anomalous := b.samples >= window && math.Abs(r.Total-b.mean)/deviation > cfg.Threshold
+
+	alpha := 2 / float64(window+1)
+	diff := r.Total - b.mean
+	b.mean += alpha * diff
+	b.variance = (1 - alpha) * (b.variance + alpha*diff*diff)
+	b.samples++
+	return anomalous
+}
+
+// retain drops the baselines of the targets not in the given targets
+func (d *detector) retain(targets []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for target := range d.baselines {
+		if !slices.Contains(targets, target) {
+			delete(d.baselines, target)
+		}
+	}
+}
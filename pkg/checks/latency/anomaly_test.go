@@ -0,0 +1,113 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package latency
+
+import (
+	"testing"
+)
+
+func TestDetector_observe(t *testing.T) {
+	errval := "connection refused"
+	cfg := Anomaly{Threshold: 3, Window: 5}
+	tests := []struct {
+		name          string
+		cfg           Anomaly
+		baseline      []float64
+		result        result
+		wantAnomalous bool
+	}{
+		{
+			name:          "within the baseline",
+			cfg:           cfg,
+			baseline:      []float64{0.1, 0.12, 0.09, 0.11, 0.1, 0.1},
+			result:        result{Code: 200, Total: 0.11},
+			wantAnomalous: false,
+		},
+		{
+			name:          "deviates from the baseline",
+			cfg:           cfg,
+			baseline:      []float64{0.1, 0.12, 0.09, 0.11, 0.1, 0.1},
+			result:        result{Code: 200, Total: 0.5},
+			wantAnomalous: true,
+		},
+		{
+			name:          "faster than the baseline",
+			cfg:           cfg,
+			baseline:      []float64{1, 1.1, 0.9, 1, 1.05, 1},
+			result:        result{Code: 200, Total: 0.1},
+			wantAnomalous: true,
+		},
+		{
+			name:          "jitter of a stable target",
+			cfg:           cfg,
+			baseline:      []float64{0.01, 0.01, 0.01, 0.01, 0.01, 0.01},
+			result:        result{Code: 200, Total: 0.0102},
+			wantAnomalous: false,
+		},
+		{
+			name:          "baseline shorter than the window",
+			cfg:           cfg,
+			baseline:      []float64{0.1, 0.1, 0.1},
+			result:        result{Code: 200, Total: 5},
+			wantAnomalous: false,
+		},
+		{
+			name:          "failed probe",
+			cfg:           cfg,
+			baseline:      []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1},
+			result:        result{Error: &errval, Total: 5},
+			wantAnomalous: false,
+		},
+		{
+			name:          "disabled",
+			cfg:           Anomaly{},
+			baseline:      []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1},
+			result:        result{Code: 200, Total: 5},
+			wantAnomalous: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d detector
+			for _, l := range tt.baseline {
+				if d.observe(tt.cfg, "https://example.com", result{Code: 200, Total: l}) {
+					t.Fatalf("observe() flagged the baseline latency %v", l)
+				}
+			}
+			if got := d.observe(tt.cfg, "https://example.com", tt.result); got != tt.wantAnomalous {
+				t.Errorf("observe() = %v, want %v", got, tt.wantAnomalous)
+			}
+		})
+	}
+}
+
+func TestDetector_retain(t *testing.T) {
+	var d detector
+	cfg := Anomaly{Threshold: 3}
+	d.observe(cfg, "https://a.com", result{Code: 200, Total: 0.1})
+	d.observe(cfg, "https://b.com", result{Code: 200, Total: 0.1})
+
+	d.retain([]string{"https://a.com"})
+	if _, ok := d.baselines["https://a.com"]; !ok {
+		t.Error("retain() dropped the baseline of a retained target")
+	}
+	if _, ok := d.baselines["https://b.com"]; ok {
+		t.Error("retain() kept the baseline of a removed target")
+	}
+}
@@ -19,7 +19,9 @@
 package latency
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 
@@ -34,10 +36,30 @@ const (
 
 // Config defines the configuration parameters for a latency check
 type Config struct {
-	Targets  []string           `json:"targets,omitempty" yaml:"targets,omitempty"`
-	Interval time.Duration      `json:"interval" yaml:"interval"`
-	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
-	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+	Targets  []string                `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Interval time.Duration           `json:"interval" yaml:"interval"`
+	Timeout  time.Duration           `json:"timeout" yaml:"timeout"`
+	Retry    helper.RetryConfig      `json:"retry" yaml:"retry"`
+	Adaptive checks.AdaptiveInterval `json:"adaptive,omitempty" yaml:"adaptive,omitempty"`
+	Schedule checks.Schedule         `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	Network  checks.NetworkConfig    `json:"network,omitempty" yaml:"network,omitempty"`
+	// Tunnel tunnels the probes through an SSH jump host
+	Tunnel checks.TunnelConfig `json:"tunnel,omitempty" yaml:"tunnel,omitempty"`
+	// FreshConnections opens a new connection without resuming a TLS session for every probe to measure the
+	// cold path latency. By default connections are kept alive and reused across probes to measure the warm path.
+	FreshConnections bool `json:"freshConnections,omitempty" yaml:"freshConnections,omitempty"`
+	// Protocol forces the HTTP protocol version of the probes. A probe fails if another version is negotiated.
+	Protocol checks.Protocol `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	// Auth authenticates the probes to targets, e.g. with an OAuth2 bearer token
+	Auth []checks.TargetAuth `json:"auth,omitempty" yaml:"auth,omitempty"`
+	// Proxies send the probes to some targets through a proxy, e.g. a region-specific egress point
+	Proxies []checks.TargetProxy `json:"proxies,omitempty" yaml:"proxies,omitempty"`
+	// TargetRetries override the retry configuration for some targets
+	TargetRetries []checks.TargetRetry `json:"targetRetries,omitempty" yaml:"targetRetries,omitempty"`
+	// Buckets are the upper bounds of the buckets of the latency histogram in seconds
+	Buckets checks.Buckets `json:"buckets,omitempty" yaml:"buckets,omitempty"`
+	// Anomaly flags the targets whose latency deviates from their own baseline
+	Anomaly Anomaly `json:"anomaly,omitempty" yaml:"anomaly,omitempty"`
 }
 
 // For returns the name of the check
@@ -58,7 +80,7 @@ func (c *Config) Validate() error {
 		}
 	}
 
-	if c.Interval < minInterval {
+	if !c.Schedule.Enabled() && c.Interval < minInterval {
 		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
 	}
 
@@ -66,5 +88,93 @@ func (c *Config) Validate() error {
 		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
 	}
 
+	if err := c.Schedule.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if c.Schedule.Enabled() && c.Adaptive.Enabled() {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "adaptive", Reason: "can't be combined with a schedule"}
+	}
+
+	if err := c.Adaptive.Validate(c.For(), c.Interval); err != nil {
+		return err
+	}
+
+	if err := c.Network.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if err := c.Tunnel.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if err := c.Protocol.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if err := checks.ValidateAuth(c.For(), c.Auth, c.Targets); err != nil {
+		return err
+	}
+
+	if err := checks.ValidateProxies(c.For(), c.Proxies, c.Targets); err != nil {
+		return err
+	}
+
+	if err := checks.ValidateRetries(c.For(), c.TargetRetries, c.Targets); err != nil {
+		return err
+	}
+
+	if err := c.Buckets.Validate(c.For()); err != nil {
+		return err
+	}
+
+	if err := c.Anomaly.Validate(c.For()); err != nil {
+		return err
+	}
+
+	timing := checks.Timing{Interval: c.Interval, Timeout: c.Timeout, Schedule: c.Schedule, Retry: c.Retry, TargetRetries: c.TargetRetries}
+	if err := timing.Validate(c.For()); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// transport returns the transport of the probes dialing through the tunnel if one is given
+// and sending the probes to the proxied targets through their proxy
+// or nil if the default transport is used
+func (c *Config) transport(tunnel *checks.Tunnel) http.RoundTripper {
+	return c.Protocol.Transport(checks.ProxyTransport(c.Proxies, tunnel.Transport(c.connTransport())))
+}
+
+// connTransport returns the transport of the probes without a forced protocol
+func (c *Config) connTransport() http.RoundTripper {
+	rt := c.Network.Transport(c.Timeout)
+	if !c.FreshConnections {
+		if t, ok := rt.(*http.Transport); ok {
+			t.TLSClientConfig = &tls.Config{
+				MinVersion:         tls.VersionTLS12,
+				ClientSessionCache: tls.NewLRUClientSessionCache(0),
+			}
+		}
+		return rt
+	}
+
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		t = &http.Transport{Proxy: http.ProxyFromEnvironment}
+		if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+			t = dt.Clone()
+		}
+	}
+	t.DisableKeepAlives = true
+	return t
+}
+
+// authorizers returns the token sources authenticating the probes to the targets
+func (c *Config) authorizers() checks.Authorizers {
+	return checks.NewAuthorizers(c.Auth, &http.Client{
+		Timeout:   c.Timeout,
+		Transport: c.Network.Transport(c.Timeout),
+	})
+}
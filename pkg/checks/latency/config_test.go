@@ -21,6 +21,8 @@ package latency
 import (
 	"testing"
 	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -33,7 +35,7 @@ func TestConfig_Validate(t *testing.T) {
 			name: "valid config",
 			config: Config{
 				Targets:  []string{"http://localhost:8080"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			},
 			wantErr: false,
@@ -42,7 +44,7 @@ func TestConfig_Validate(t *testing.T) {
 			name: "invalid targets - invalid url",
 			config: Config{
 				Targets:  []string{"://localhost:8080"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			},
 			wantErr: true,
@@ -51,7 +53,7 @@ func TestConfig_Validate(t *testing.T) {
 			name: "invalid targets - invalid scheme",
 			config: Config{
 				Targets:  []string{"localhost:8080"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			},
 			wantErr: true,
@@ -65,15 +67,98 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "unsupported protocol",
+			config: Config{
+				Targets:  []string{"https://localhost:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Protocol: checks.ProtocolHTTP3,
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid timeout",
 			config: Config{
 				Targets:  []string{"http://localhost:8080"},
-				Interval: 100 * time.Millisecond,
+				Interval: 10 * time.Second,
 				Timeout:  100 * time.Millisecond,
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid tunnel",
+			config: Config{
+				Targets:  []string{"http://10.0.0.1:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Tunnel: checks.TunnelConfig{
+					Host:           "bastion.example.com",
+					User:           "sparrow",
+					KeyFile:        "/etc/sparrow/id_ed25519",
+					KnownHostsFile: "/etc/sparrow/known_hosts",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid proxies",
+			config: Config{
+				Targets:  []string{"https://eu.example.com", "https://us.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Proxies: []checks.TargetProxy{
+					{Targets: []string{"https://eu.example.com"}, URL: "socks5://egress-eu.example.com:1080"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid proxies - unknown target",
+			config: Config{
+				Targets:  []string{"https://eu.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Proxies: []checks.TargetProxy{
+					{Targets: []string{"https://us.example.com"}, URL: "socks5://egress-us.example.com:1080"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid tunnel - unverified jump host",
+			config: Config{
+				Targets:  []string{"http://10.0.0.1:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Tunnel: checks.TunnelConfig{
+					Host:    "bastion.example.com",
+					User:    "sparrow",
+					KeyFile: "/etc/sparrow/id_ed25519",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid anomaly detection",
+			config: Config{
+				Targets:  []string{"https://localhost:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Anomaly:  Anomaly{Threshold: 3, Window: 20},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid anomaly detection - single probe window",
+			config: Config{
+				Targets:  []string{"https://localhost:8080"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Anomaly:  Anomaly{Threshold: 3, Window: 1},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
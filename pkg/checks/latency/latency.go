@@ -22,7 +22,6 @@ import (
 	"context"
 	"io"
 	"net/http"
-	"slices"
 	"sync"
 	"time"
 
@@ -41,11 +40,27 @@ var (
 
 const CheckName = "latency"
 
+// SchemaVersion is the version of the layout of the check's result data.
+// It is incremented with every breaking change of the layout.
+const SchemaVersion = 1
+
 // Latency is a check that measures the latency to an endpoint
 type Latency struct {
 	checks.CheckBase
 	config  Config
 	metrics metrics
+	// scheduler decides which targets are due if the adaptive interval is enabled
+	scheduler checks.Scheduler
+	// results are the latest results of all targets if the adaptive interval is enabled
+	results map[string]result
+	// transport is kept across the runs, so connections are reused unless fresh connections are configured
+	transport http.RoundTripper
+	// authorizers are kept across the runs, so the acquired tokens are reused until they expire
+	authorizers checks.Authorizers
+	// tunnel is kept across the runs, so the connection to the jump host is reused
+	tunnel *checks.Tunnel
+	// detector keeps the latency baselines of the targets across the runs
+	detector detector
 }
 
 // NewCheck creates a new instance of the latency check
@@ -67,6 +82,10 @@ type result struct {
 	Code  int     `json:"code"`
 	Error *string `json:"error"`
 	Total float64 `json:"total"`
+	// Protocol is the negotiated HTTP protocol, e.g. HTTP/2.0
+	Protocol string `json:"protocol,omitempty"`
+	// Anomalous is true if the latency deviates significantly from the baseline of the target
+	Anomalous bool `json:"anomalous,omitempty"`
 }
 
 // Run starts the latency check
@@ -75,22 +94,38 @@ func (l *Latency) Run(ctx context.Context, cResult chan checks.ResultDTO) error
 	defer cancel()
 	log := logger.FromContext(ctx)
 
+	l.Mu.Lock()
 	log.Info("Starting latency check", "interval", l.config.Interval.String())
+	l.Mu.Unlock()
 	for {
+		// The configuration is updated concurrently, so every run reads it from a copy
+		l.Mu.Lock()
+		cfg := l.config
+		l.Mu.Unlock()
+
 		select {
 		case <-ctx.Done():
 			log.Error("Context canceled", "err", ctx.Err())
 			return ctx.Err()
 		case <-l.DoneChan:
 			return nil
-		case <-time.After(l.config.Interval):
-			res := l.check(ctx)
+		case <-l.Clock().After(cfg.Schedule.Next(l.Clock().Now(), cfg.Adaptive.Next(cfg.Interval))):
+			res := l.check(ctx, cfg)
+			if cfg.Adaptive.Enabled() {
+				if len(res) == 0 && len(cfg.Targets) > 0 {
+					log.Debug("No target is due for a latency check")
+					continue
+				}
+				res = checks.MergeResults(l.results, res, cfg.Targets)
+				l.results = res
+			}
 
 			cResult <- checks.ResultDTO{
 				Name: l.Name(),
 				Result: &checks.Result{
-					Data:      res,
-					Timestamp: time.Now(),
+					Data:          res,
+					Timestamp:     l.Clock().Now(),
+					SchemaVersion: SchemaVersion,
 				},
 			}
 			log.Debug("Successfully finished latency check run")
@@ -98,9 +133,31 @@ func (l *Latency) Run(ctx context.Context, cResult chan checks.ResultDTO) error
 	}
 }
 
+// RunOnce runs the latency check once against all targets
+func (l *Latency) RunOnce(ctx context.Context) (*checks.Result, []string) {
+	l.Mu.Lock()
+	cfg := l.config
+	l.Mu.Unlock()
+
+	res := l.check(ctx, cfg)
+	failed := checks.FailedTargets(TargetStates(res), unhealthy)
+	return &checks.Result{Data: res, Timestamp: l.Clock().Now(), SchemaVersion: SchemaVersion}, failed
+}
+
 func (l *Latency) Shutdown() {
 	l.DoneChan <- struct{}{}
 	close(l.DoneChan)
+	l.Mu.Lock()
+	defer l.Mu.Unlock()
+	l.closeIdleConnections()
+	_ = l.tunnel.Close()
+}
+
+// closeIdleConnections closes the idle connections kept alive by the check's own transport
+func (l *Latency) closeIdleConnections() {
+	if t, ok := l.transport.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
 }
 
 // UpdateConfig sets the configuration for the latency check
@@ -109,16 +166,20 @@ func (l *Latency) UpdateConfig(cfg checks.Runtime) error {
 		l.Mu.Lock()
 		defer l.Mu.Unlock()
 
-		for _, target := range l.config.Targets {
-			if !slices.Contains(c.Targets, target) {
-				err := l.metrics.Remove(target)
-				if err != nil {
-					return err
-				}
-			}
+		if err := l.SetTargets(c.Targets, l.metrics.Remove); err != nil {
+			return err
 		}
 
 		l.config = *c
+		l.metrics.histogram.SetBuckets(c.Buckets)
+		l.detector.retain(c.Targets)
+		if !c.Anomaly.Enabled() {
+			l.metrics.anomalous.Reset()
+		}
+		l.closeIdleConnections()
+		l.tunnel = l.tunnel.Update(c.Tunnel, c.Network, c.Timeout)
+		l.transport = c.transport(l.tunnel)
+		l.authorizers = c.authorizers()
 		return nil
 	}
 
@@ -132,7 +193,8 @@ func (l *Latency) UpdateConfig(cfg checks.Runtime) error {
 func (l *Latency) GetConfig() checks.Runtime {
 	l.Mu.Lock()
 	defer l.Mu.Unlock()
-	return &l.config
+	cfg := l.config
+	return &cfg
 }
 
 // Name returns the name of the check
@@ -152,39 +214,48 @@ func (l *Latency) GetMetricCollectors() []prometheus.Collector {
 		l.metrics.totalDuration,
 		l.metrics.count,
 		l.metrics.histogram,
+		l.metrics.anomalous,
 	}
 }
 
 // RemoveLabelledMetrics removes the metrics which have the passed target as a label
 func (l *Latency) RemoveLabelledMetrics(target string) error {
-	return l.metrics.Remove(target)
+	return l.RemoveSeries(target, l.metrics.Remove)
 }
 
 // check performs a latency check using a retry function
-// to get the latency to all targets
-func (l *Latency) check(ctx context.Context) map[string]result {
+// to get the latency to all targets of the given configuration
+func (l *Latency) check(ctx context.Context, cfg Config) map[string]result {
 	log := logger.FromContext(ctx)
 	log.Debug("Checking latency")
-	if len(l.config.Targets) == 0 {
+	if len(cfg.Targets) == 0 {
 		log.Debug("No targets defined")
 		return map[string]result{}
 	}
-	log.Debug("Getting latency status for each target in separate routine", "amount", len(l.config.Targets))
+	targets := l.scheduler.Due(cfg.Targets, l.Clock().Now(), cfg.Interval, cfg.Adaptive)
+	log.Debug("Getting latency status for each target in separate routine", "amount", len(targets))
 
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	results := map[string]result{}
 
+	l.Mu.Lock()
 	client := &http.Client{
-		Timeout: l.config.Timeout,
+		Timeout:   cfg.Timeout,
+		Transport: l.transport,
 	}
-	for _, t := range l.config.Targets {
+	auth := l.authorizers
+	anomaly := cfg.Anomaly
+	retries := checks.NewRetries(cfg.Retry, cfg.TargetRetries)
+	l.Mu.Unlock()
+	for _, t := range targets {
 		target := t
 		wg.Add(1)
 		lo := log.With("target", target)
+		retry, opts := retries.For(target)
 
 		getLatencyRetry := helper.Retry(func(ctx context.Context) error {
-			res, err := getLatency(ctx, client, target)
+			res, err := getLatency(ctx, client, target, cfg.Protocol, auth)
 			mu.Lock()
 			defer mu.Unlock()
 			results[target] = res
@@ -192,23 +263,37 @@ func (l *Latency) check(ctx context.Context) map[string]result {
 				return err
 			}
 			return nil
-		}, l.config.Retry)
+		}, retry, opts...)
 
 		go func() {
 			defer wg.Done()
 
 			lo.Debug("Starting retry routine to get latency status")
-			if err := getLatencyRetry(ctx); err != nil {
+			err := getLatencyRetry(ctx)
+			if err != nil {
 				lo.Error("Error while checking latency", "error", err)
 			}
+			l.scheduler.Report(target, err == nil, l.Clock().Now(), cfg.Adaptive)
 
 			lo.Debug("Successfully got latency status of target")
 			mu.Lock()
 			defer mu.Unlock()
 
-			l.metrics.totalDuration.WithLabelValues(target).Set(results[target].Total)
-			l.metrics.count.WithLabelValues(target).Inc()
-			l.metrics.histogram.WithLabelValues(target).Observe(results[target].Total)
+			res := results[target]
+			res.Anomalous = l.detector.observe(anomaly, target, res)
+			results[target] = res
+			if res.Anomalous {
+				lo.Warn("Latency deviates from the baseline of the target", "latency", res.Total)
+			}
+
+			l.WithSeries(target, func(label string) {
+				l.metrics.totalDuration.WithLabelValues(label).Set(res.Total)
+				l.metrics.count.WithLabelValues(label).Inc()
+				l.metrics.histogram.WithLabelValues(label).Observe(res.Total)
+				if anomaly.Enabled() {
+					l.metrics.anomalous.WithLabelValues(label).Set(checks.BoolToFloat(res.Anomalous))
+				}
+			})
 		}()
 	}
 
@@ -220,7 +305,10 @@ func (l *Latency) check(ctx context.Context) map[string]result {
 }
 
 // getLatency performs an HTTP get request and returns ok if request succeeds
-func getLatency(ctx context.Context, c *http.Client, url string) (result, error) {
+// and the response was received with the forced protocol, if any.
+// The request is authorized if the target has an auth configuration
+// and sent through the proxy of the target, if any.
+func getLatency(ctx context.Context, c *http.Client, url string, protocol checks.Protocol, auth checks.Authorizers) (result, error) {
 	log := logger.FromContext(ctx).With("url", url)
 	var res result
 
@@ -229,6 +317,13 @@ func getLatency(ctx context.Context, c *http.Client, url string) (result, error)
 		log.Error("Error while creating request", "error", err)
 		errval := err.Error()
 		res.Error = &errval
+		return res, helper.Permanent(err)
+	}
+	req = req.WithContext(checks.WithTarget(req.Context(), url))
+	if err := auth.Authorize(ctx, req, url); err != nil {
+		log.Error("Error while authorizing request", "error", err)
+		errval := err.Error()
+		res.Error = &errval
 		return res, err
 	}
 
@@ -243,10 +338,44 @@ func getLatency(ctx context.Context, c *http.Client, url string) (result, error)
 	end := time.Now()
 
 	res.Code = resp.StatusCode
+	res.Protocol = resp.Proto
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
 	}(resp.Body)
 
 	res.Total = end.Sub(start).Seconds()
+	if err := protocol.Verify(resp); err != nil {
+		log.Warn("Latency request was downgraded", "protocol", resp.Proto, "error", err)
+		errval := err.Error()
+		res.Error = &errval
+		return res, err
+	}
 	return res, nil
 }
+
+// TargetStates returns the state of every target reported in the data of a latency check result
+func TargetStates(data any) map[string]checks.TargetState {
+	res, ok := checks.ResultData[result](data)
+	if !ok {
+		return nil
+	}
+
+	states := make(map[string]checks.TargetState, len(res))
+	for target, r := range res {
+		states[target] = checks.TargetState{
+			Healthy: r.Error == nil && r.Code >= http.StatusOK && r.Code < http.StatusBadRequest,
+			Latency: time.Duration(r.Total * float64(time.Second)),
+		}
+	}
+	return states
+}
+
+// unhealthy returns true if the target isn't healthy
+func unhealthy(s checks.TargetState) bool {
+	return !s.Healthy
+}
+
+// Failures reports for every target in the data of a latency check result whether it's unhealthy
+func Failures(data any) map[string]bool {
+	return checks.TargetFailures(TargetStates(data), unhealthy)
+}
@@ -21,8 +21,11 @@ package latency
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -279,7 +282,7 @@ func TestLatency_check(t *testing.T) {
 				metrics: newMetrics(),
 			}
 
-			got := l.check(tt.ctx)
+			got := l.check(tt.ctx, l.config)
 
 			if len(got) != len(tt.want) {
 				t.Errorf("check() got %v results, want %v results", len(got), len(tt.want))
@@ -305,6 +308,57 @@ func TestLatency_check(t *testing.T) {
 	}
 }
 
+func TestLatency_check_connections(t *testing.T) {
+	tests := []struct {
+		name             string
+		freshConnections bool
+		network          checks.NetworkConfig
+		wantConns        int
+	}{
+		{name: "reused connections", wantConns: 1},
+		{name: "reused connections with network config", network: checks.NetworkConfig{SourceIP: "127.0.0.1"}, wantConns: 1},
+		{name: "fresh connections", freshConnections: true, wantConns: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conns atomic.Int32
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					conns.Add(1)
+				}
+			}
+			srv.Start()
+			defer srv.Close()
+
+			l := NewCheck().(*Latency)
+			err := l.UpdateConfig(&Config{
+				Targets:          []string{srv.URL},
+				Interval:         time.Second,
+				Timeout:          time.Second,
+				Network:          tt.network,
+				FreshConnections: tt.freshConnections,
+			})
+			if err != nil {
+				t.Fatalf("UpdateConfig() error = %v", err)
+			}
+			defer l.closeIdleConnections()
+
+			for range 3 {
+				res := l.check(context.Background(), l.config)
+				if res[srv.URL].Code != http.StatusOK {
+					t.Fatalf("check() = %+v, want status %d", res[srv.URL], http.StatusOK)
+				}
+			}
+			if got := int(conns.Load()); got != tt.wantConns {
+				t.Errorf("check() opened %d connections, want %d", got, tt.wantConns)
+			}
+		})
+	}
+}
+
 func TestLatency_Shutdown(t *testing.T) {
 	cDone := make(chan struct{}, 1)
 	c := Latency{
@@ -321,7 +375,7 @@ func TestLatency_Shutdown(t *testing.T) {
 }
 
 func TestLatency_UpdateConfig(t *testing.T) {
-	c := Latency{}
+	c := Latency{metrics: newMetrics()}
 	wantCfg := Config{
 		Targets: []string{"http://localhost:9090"},
 	}
@@ -341,3 +395,35 @@ func TestNewLatencyCheck(t *testing.T) {
 		t.Error("NewLatencyCheck() should not be nil")
 	}
 }
+
+func TestTargetStates(t *testing.T) {
+	errMsg := "connection refused"
+	tests := []struct {
+		name string
+		data any
+		want map[string]checks.TargetState
+	}{
+		{
+			name: "successful and failed targets",
+			data: map[string]result{
+				successURL: {Code: http.StatusOK, Total: 0.5},
+				failURL:    {Code: 0, Error: &errMsg},
+			},
+			want: map[string]checks.TargetState{
+				successURL: {Healthy: true, Latency: 500 * time.Millisecond},
+				failURL:    {Healthy: false},
+			},
+		},
+		{
+			name: "unexpected data",
+			data: "unexpected",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TargetStates(tt.data))
+		})
+	}
+}
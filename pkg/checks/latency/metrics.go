@@ -27,7 +27,8 @@ import (
 type metrics struct {
 	totalDuration *prometheus.GaugeVec
 	count         *prometheus.CounterVec
-	histogram     *prometheus.HistogramVec
+	histogram     *checks.HistogramVec
+	anomalous     *prometheus.GaugeVec
 }
 
 // newMetrics initializes metric collectors of the latency check
@@ -51,7 +52,7 @@ func newMetrics() metrics {
 				"target",
 			},
 		),
-		histogram: prometheus.NewHistogramVec(
+		histogram: checks.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name: "sparrow_latency_duration",
 				Help: "Latency of targets in seconds",
@@ -60,6 +61,15 @@ func newMetrics() metrics {
 				"target",
 			},
 		),
+		anomalous: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_latency_anomalous",
+				Help: "1 if the latency of the target deviates significantly from its baseline, 0 otherwise",
+			},
+			[]string{
+				"target",
+			},
+		),
 	}
 }
 
@@ -77,5 +87,8 @@ func (m metrics) Remove(label string) error {
 		return checks.ErrMetricNotFound{Label: label}
 	}
 
+	// the anomaly metric only exists if anomalies are detected
+	m.anomalous.Delete(map[string]string{"target": label})
+
 	return nil
 }
@@ -0,0 +1,125 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// maxDSCP is the maximum value of the 6 bit Differentiated Services Code Point
+const maxDSCP = 63
+
+// NetworkConfig configures the network properties of the probes sent by a check
+type NetworkConfig struct {
+	// DSCP is the Differentiated Services Code Point set in the IP header of the probes
+	DSCP int `json:"dscp,omitempty" yaml:"dscp,omitempty" mapstructure:"dscp"`
+	// SourceIP is the local IP address the probes are sent from
+	SourceIP string `json:"sourceIp,omitempty" yaml:"sourceIp,omitempty" mapstructure:"sourceIp"`
+	// Interface is the name of the network interface the probes are bound to.
+	// This is only supported on Linux.
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty" mapstructure:"interface"`
+}
+
+// IsZero returns true if no network properties are configured
+func (n NetworkConfig) IsZero() bool {
+	return n == NetworkConfig{}
+}
+
+// Validate checks if the network configuration is valid
+func (n NetworkConfig) Validate(checkName string) error {
+	if n.DSCP < 0 || n.DSCP > maxDSCP {
+		return ErrInvalidConfig{CheckName: checkName, Field: "network.dscp", Reason: "must be between 0 and 63"}
+	}
+	if n.SourceIP != "" && net.ParseIP(n.SourceIP) == nil {
+		return ErrInvalidConfig{CheckName: checkName, Field: "network.sourceIp", Reason: "invalid ip"}
+	}
+	return nil
+}
+
+// LocalIP returns the configured source IP or nil if none is configured
+func (n NetworkConfig) LocalIP() net.IP {
+	return net.ParseIP(n.SourceIP)
+}
+
+// Control applies the network configuration to a socket.
+// It is meant to be used as or within the control function of a [net.Dialer].
+func (n NetworkConfig) Control(network string, c syscall.RawConn) error {
+	if n.DSCP == 0 && n.Interface == "" {
+		return nil
+	}
+
+	var opErr error
+	err := c.Control(func(fd uintptr) {
+		if n.DSCP != 0 {
+			// The DSCP occupies the upper 6 bits of the ToS / traffic class field
+			tos := n.DSCP << 2
+			if strings.HasSuffix(network, "6") {
+				opErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IPV6, unix.IPV6_TCLASS, tos) // #nosec G115 // The net package is safe to use
+			} else {
+				opErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TOS, tos) // #nosec G115 // The net package is safe to use
+			}
+			if opErr != nil {
+				return
+			}
+		}
+		if n.Interface != "" {
+			opErr = bindToDevice(int(fd), n.Interface) // #nosec G115 // The net package is safe to use
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return opErr
+}
+
+// Dialer returns a [net.Dialer] sending from the configured source IP
+// and applying the network configuration to its sockets
+func (n NetworkConfig) Dialer(timeout time.Duration) *net.Dialer {
+	d := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, _ string, c syscall.RawConn) error {
+			return n.Control(network, c)
+		},
+	}
+	if ip := n.LocalIP(); ip != nil {
+		d.LocalAddr = &net.TCPAddr{IP: ip}
+	}
+	return d
+}
+
+// Transport returns an [http.RoundTripper] applying the network configuration
+// or nil if no network properties are configured, so the default transport is used
+func (n NetworkConfig) Transport(timeout time.Duration) http.RoundTripper {
+	if n.IsZero() {
+		return nil
+	}
+
+	t := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+		t = dt.Clone()
+	}
+	t.DialContext = n.Dialer(timeout).DialContext
+	return t
+}
@@ -0,0 +1,94 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestNetworkConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     NetworkConfig
+		wantErr bool
+	}{
+		{name: "empty", cfg: NetworkConfig{}},
+		{name: "valid", cfg: NetworkConfig{DSCP: 46, SourceIP: "10.0.0.1", Interface: "eth0"}},
+		{name: "valid ipv6 source", cfg: NetworkConfig{SourceIP: "fd00::1"}},
+		{name: "dscp too large", cfg: NetworkConfig{DSCP: 64}, wantErr: true},
+		{name: "negative dscp", cfg: NetworkConfig{DSCP: -1}, wantErr: true},
+		{name: "invalid source ip", cfg: NetworkConfig{SourceIP: "not-an-ip"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate("latency"); (err != nil) != tt.wantErr {
+				t.Errorf("NetworkConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNetworkConfig_Transport(t *testing.T) {
+	if tr := (NetworkConfig{}).Transport(time.Second); tr != nil {
+		t.Errorf("NetworkConfig.Transport() = %v, want nil for an empty config", tr)
+	}
+	if tr := (NetworkConfig{DSCP: 46}).Transport(time.Second); tr == nil {
+		t.Error("NetworkConfig.Transport() = nil, want a transport")
+	}
+}
+
+func TestNetworkConfig_Dialer(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	cfg := NetworkConfig{DSCP: 46, SourceIP: "127.0.0.1"}
+	conn, err := cfg.Dialer(time.Second).Dial("tcp4", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if ip := conn.LocalAddr().(*net.TCPAddr).IP; !ip.Equal(net.ParseIP("127.0.0.1")) {
+		t.Errorf("Local address = %v, want 127.0.0.1", ip)
+	}
+
+	raw, err := conn.(*net.TCPConn).SyscallConn()
+	if err != nil {
+		t.Fatalf("Failed to get raw connection: %v", err)
+	}
+	var tos int
+	var opErr error
+	err = raw.Control(func(fd uintptr) {
+		tos, opErr = unix.GetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TOS)
+	})
+	if err != nil || opErr != nil {
+		t.Fatalf("Failed to get ToS: %v, %v", err, opErr)
+	}
+	if want := 46 << 2; tos != want {
+		t.Errorf("ToS = %d, want %d", tos, want)
+	}
+}
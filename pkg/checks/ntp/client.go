@@ -0,0 +1,146 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+const (
+	// packetSize is the size of a NTP packet without extensions
+	packetSize = 48
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the unix epoch (1970)
+	ntpEpochOffset = 2208988800
+
+	// versionClient is the first byte of a request: no leap indicator, version 4 and client mode
+	versionClient = 4<<3 | modeClient
+	modeClient    = 3
+	modeServer    = 4
+	// maxStratum is the highest stratum of a synchronized server. A stratum of 16 means unsynchronized.
+	maxStratum = 15
+	// leapNotSynchronized is the leap indicator of a server with an unsynchronized clock
+	leapNotSynchronized = 3
+)
+
+// response is the answer of a NTP server
+type response struct {
+	// Offset is the offset of the local clock to the server
+	Offset time.Duration
+	// Delay is the round trip delay of the query
+	Delay time.Duration
+	// Stratum is the distance of the server to a reference clock
+	Stratum int
+}
+
+// queryFunc queries a single NTP server
+type queryFunc func(ctx context.Context, addr string, timeout time.Duration, netCfg checks.NetworkConfig) (response, error)
+
+// query sends a SNTP (RFC 4330) request to the server and calculates
+// the offset of the local clock and the round trip delay from its answer
+func query(ctx context.Context, addr string, timeout time.Duration, netCfg checks.NetworkConfig) (response, error) {
+	d := netCfg.Dialer(timeout)
+	if ip := netCfg.LocalIP(); ip != nil {
+		d.LocalAddr = &net.UDPAddr{IP: ip}
+	}
+	conn, err := d.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return response{}, fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer conn.Close() // #nosec G307
+
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return response{}, fmt.Errorf("failed to set deadline: %w", err)
+	}
+
+	req := make([]byte, packetSize)
+	req[0] = versionClient
+	sent := time.Now()
+	putTimestamp(req[40:48], sent)
+	if _, err = conn.Write(req); err != nil {
+		return response{}, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	res := make([]byte, packetSize)
+	for {
+		n, err := conn.Read(res)
+		if err != nil {
+			return response{}, fmt.Errorf("failed to read response: %w", err)
+		}
+		received := time.Now()
+		// Answers not matching the request are ignored as they might be spoofed or duplicates
+		if n < packetSize || res[0]&0x07 != modeServer || !equalTimestamps(res[24:32], req[40:48]) {
+			continue
+		}
+		return parseResponse(res, sent, received)
+	}
+}
+
+// parseResponse parses the answer of a server to a request sent
+// and received at the given local times
+func parseResponse(b []byte, sent, received time.Time) (response, error) {
+	stratum := int(b[1])
+	if stratum == 0 {
+		// Kiss-o'-Death packets carry their code in the reference id
+		return response{}, fmt.Errorf("server sent kiss code %q", string(b[12:16]))
+	}
+	if stratum > maxStratum || b[0]>>6 == leapNotSynchronized {
+		return response{}, errors.New("server is not synchronized")
+	}
+
+	serverReceived := timestamp(b[32:40])
+	serverSent := timestamp(b[40:48])
+
+	return response{
+		Offset:  (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2,
+		Delay:   received.Sub(sent) - serverSent.Sub(serverReceived),
+		Stratum: stratum,
+	}, nil
+}
+
+// timestamp converts a 64 bit NTP timestamp to a [time.Time].
+// Timestamps with the most significant bit unset belong to the era starting in 2036 (RFC 4330).
+func timestamp(b []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b[0:4]))
+	if seconds&(1<<31) == 0 {
+		seconds += 1 << 32
+	}
+	seconds -= ntpEpochOffset
+	fraction := int64(binary.BigEndian.Uint32(b[4:8]))
+	return time.Unix(seconds, fraction*int64(time.Second)>>32)
+}
+
+// putTimestamp writes a [time.Time] as 64 bit NTP timestamp
+func putTimestamp(b []byte, t time.Time) {
+	seconds := uint64(t.Unix() + ntpEpochOffset)                   // #nosec G115 // Times before 1900 are not supported
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second) // #nosec G115 // Nanoseconds are never negative
+	binary.BigEndian.PutUint32(b[0:4], uint32(seconds))            // #nosec G115 // The era is dropped by design
+	binary.BigEndian.PutUint32(b[4:8], uint32(fraction))           // #nosec G115 // The fraction is less than 2^32
+}
+
+// equalTimestamps returns true if both timestamps are equal
+func equalTimestamps(a, b []byte) bool {
+	return binary.BigEndian.Uint64(a) == binary.BigEndian.Uint64(b)
+}
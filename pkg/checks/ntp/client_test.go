@@ -0,0 +1,140 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ntp
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// newTestServer starts a NTP server on the loopback interface answering
+// with the given stratum and a clock shifted by the given offset
+func newTestServer(t *testing.T, stratum byte, offset time.Duration) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start test server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		req := make([]byte, packetSize)
+		for {
+			n, addr, err := conn.ReadFrom(req)
+			if err != nil {
+				return
+			}
+			if n < packetSize {
+				continue
+			}
+			res := make([]byte, packetSize)
+			res[0] = 4<<3 | modeServer
+			res[1] = stratum
+			copy(res[12:16], "RATE")
+			copy(res[24:32], req[40:48])
+			putTimestamp(res[32:40], time.Now().Add(offset))
+			putTimestamp(res[40:48], time.Now().Add(offset))
+			_, _ = conn.WriteTo(res, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		stratum byte
+		offset  time.Duration
+		wantErr string
+	}{
+		{name: "synchronized server", stratum: 2, offset: 0},
+		{name: "server ahead", stratum: 1, offset: 5 * time.Second},
+		{name: "server behind", stratum: 3, offset: -5 * time.Second},
+		{name: "kiss-o'-death", stratum: 0, wantErr: `server sent kiss code "RATE"`},
+		{name: "unsynchronized server", stratum: 16, wantErr: "server is not synchronized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := newTestServer(t, tt.stratum, tt.offset)
+
+			got, err := query(context.Background(), addr, time.Second, checks.NetworkConfig{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("query() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("query() error = %v", err)
+			}
+
+			if got.Stratum != int(tt.stratum) {
+				t.Errorf("query() stratum = %d, want %d", got.Stratum, tt.stratum)
+			}
+			if diff := got.Offset - tt.offset; diff < -100*time.Millisecond || diff > 100*time.Millisecond {
+				t.Errorf("query() offset = %v, want %v", got.Offset, tt.offset)
+			}
+			if got.Delay < 0 || got.Delay > 100*time.Millisecond {
+				t.Errorf("query() delay = %v, want a small positive delay", got.Delay)
+			}
+		})
+	}
+}
+
+func TestQuery_Timeout(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	_, err = query(context.Background(), conn.LocalAddr().String(), 50*time.Millisecond, checks.NetworkConfig{})
+	if err == nil {
+		t.Fatal("query() should fail if the server does not answer")
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+	}{
+		{name: "now", time: time.Now()},
+		{name: "first era", time: time.Date(2030, 1, 1, 12, 0, 0, 500000000, time.UTC)},
+		{name: "second era", time: time.Date(2040, 6, 1, 0, 0, 0, 250000000, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := make([]byte, 8)
+			putTimestamp(b, tt.time)
+			got := timestamp(b)
+			if diff := got.Sub(tt.time); diff < -time.Microsecond || diff > time.Microsecond {
+				t.Errorf("timestamp() = %v, want %v", got, tt.time)
+			}
+		})
+	}
+}
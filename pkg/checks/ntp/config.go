@@ -0,0 +1,106 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ntp
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+const (
+	minInterval = 100 * time.Millisecond
+	minTimeout  = 200 * time.Millisecond
+
+	// defaultPort is the port NTP servers listen on
+	defaultPort = "123"
+)
+
+// Config defines the configuration parameters for a NTP check
+type Config struct {
+	// Targets is a list of NTP servers to query. The port defaults to 123.
+	Targets []string `json:"targets" yaml:"targets" mapstructure:"targets"`
+	// Interval is the time to wait between check iterations
+	Interval time.Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+	// Schedule is a cron expression defining when the check runs. It replaces the interval if set.
+	Schedule checks.Schedule `json:"schedule,omitempty" yaml:"schedule,omitempty" mapstructure:"schedule"`
+	// Timeout is the maximum time to wait for the answer of a server
+	Timeout time.Duration `json:"timeout" yaml:"timeout" mapstructure:"timeout"`
+	// Retry defines if and how to retry a target
+	Retry helper.RetryConfig `json:"retry" yaml:"retry" mapstructure:"retry"`
+	// MaxOffset is the maximum acceptable absolute offset of the local clock to a server.
+	// An offset of 0 disables the threshold.
+	MaxOffset time.Duration `json:"maxOffset,omitempty" yaml:"maxOffset,omitempty" mapstructure:"maxOffset"`
+	// Network configures the DSCP marking and the source binding of the queries
+	Network checks.NetworkConfig `json:"network,omitempty" yaml:"network,omitempty" mapstructure:"network"`
+	// TargetRetries override the retry configuration for some targets
+	TargetRetries []checks.TargetRetry `json:"targetRetries,omitempty" yaml:"targetRetries,omitempty" mapstructure:"targetRetries"`
+}
+
+// For returns the name of the check
+func (c *Config) For() string {
+	return CheckName
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	for i, t := range c.Targets {
+		if t == "" || strings.Contains(t, "://") {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "must be a host name or an ip with an optional port"}
+		}
+	}
+
+	if !c.Schedule.Enabled() && c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+
+	if c.MaxOffset < 0 {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "maxOffset", Reason: "must not be negative"}
+	}
+
+	if err := c.Schedule.Validate(c.For()); err != nil {
+		return err
+	}
+	if err := checks.ValidateRetries(CheckName, c.TargetRetries, c.Targets); err != nil {
+		return err
+	}
+
+	timing := checks.Timing{Interval: c.Interval, Timeout: c.Timeout, Schedule: c.Schedule, Retry: c.Retry, TargetRetries: c.TargetRetries}
+	if err := timing.Validate(CheckName); err != nil {
+		return err
+	}
+
+	return c.Network.Validate(CheckName)
+}
+
+// address returns the address of the target including the port
+func address(target string) string {
+	if _, _, err := net.SplitHostPort(target); err == nil {
+		return target
+	}
+	return net.JoinHostPort(strings.Trim(target, "[]"), defaultPort)
+}
@@ -0,0 +1,123 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ntp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				Targets:   []string{"pool.ntp.org", "10.0.0.1:123"},
+				Interval:  10 * time.Second,
+				Timeout:   1 * time.Second,
+				MaxOffset: 100 * time.Millisecond,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid target",
+			config: Config{
+				Targets:  []string{"udp://pool.ntp.org"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			config: Config{
+				Targets:  []string{"pool.ntp.org"},
+				Interval: 10 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Targets:  []string{"pool.ntp.org"},
+				Interval: 10 * time.Second,
+				Timeout:  100 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative max offset",
+			config: Config{
+				Targets:   []string{"pool.ntp.org"},
+				Interval:  10 * time.Second,
+				Timeout:   1 * time.Second,
+				MaxOffset: -time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid network",
+			config: Config{
+				Targets:  []string{"pool.ntp.org"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Network:  checks.NetworkConfig{SourceIP: "invalid"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddress(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "pool.ntp.org", want: "pool.ntp.org:123"},
+		{target: "pool.ntp.org:1123", want: "pool.ntp.org:1123"},
+		{target: "10.0.0.1", want: "10.0.0.1:123"},
+		{target: "::1", want: "[::1]:123"},
+		{target: "[::1]", want: "[::1]:123"},
+		{target: "[::1]:1123", want: "[::1]:1123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			if got := address(tt.target); got != tt.want {
+				t.Errorf("address() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ntp
+
+import (
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics defines the metric collectors of the NTP check
+type metrics struct {
+	status  *prometheus.GaugeVec
+	offset  *prometheus.GaugeVec
+	delay   *prometheus.GaugeVec
+	stratum *prometheus.GaugeVec
+}
+
+// newMetrics initializes metric collectors of the ntp check
+func newMetrics() metrics {
+	return metrics{
+		status: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_ntp_status",
+				Help: "Specifies if the server answered and the offset is within the acceptable drift.",
+			},
+			[]string{"target"},
+		),
+		offset: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_ntp_offset_seconds",
+				Help: "Offset of the local clock to the server in seconds.",
+			},
+			[]string{"target"},
+		),
+		delay: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_ntp_delay_seconds",
+				Help: "Round trip delay of the query to the server in seconds.",
+			},
+			[]string{"target"},
+		),
+		stratum: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_ntp_stratum",
+				Help: "Stratum of the server.",
+			},
+			[]string{"target"},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.status,
+		m.offset,
+		m.delay,
+		m.stratum,
+	}
+}
+
+// Set sets the metrics of one target result
+func (m *metrics) Set(target string, res result, status float64) {
+	m.status.WithLabelValues(target).Set(status)
+	m.offset.WithLabelValues(target).Set(res.Offset)
+	m.delay.WithLabelValues(target).Set(res.Delay)
+	m.stratum.WithLabelValues(target).Set(float64(res.Stratum))
+}
+
+// Remove removes the metrics of one target
+func (m *metrics) Remove(target string) error {
+	if !m.status.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.offset.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.delay.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.stratum.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ntp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_ checks.Check   = (*NTP)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "ntp"
+
+// SchemaVersion is the version of the layout of the check's result data.
+// It is incremented with every breaking change of the layout.
+const SchemaVersion = 1
+
+// NTP is a check that queries NTP servers to detect the drift of the local clock
+type NTP struct {
+	checks.CheckBase
+	config  Config
+	metrics metrics
+	query   queryFunc
+}
+
+// NewCheck creates a new instance of the ntp check
+func NewCheck() checks.Check {
+	return &NTP{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics: newMetrics(),
+		query:   query,
+	}
+}
+
+// result represents the result of a single NTP query for a specific target
+type result struct {
+	// Offset is the offset of the local clock to the server in seconds
+	Offset float64 `json:"offset" yaml:"offset"`
+	// Delay is the round trip delay of the query in seconds
+	Delay float64 `json:"delay" yaml:"delay"`
+	// Stratum is the distance of the server to a reference clock
+	Stratum int `json:"stratum" yaml:"stratum"`
+	// Exceeded is true if the absolute offset exceeds the maximum offset
+	Exceeded bool `json:"exceeded" yaml:"exceeded"`
+	// Error is the error that occurred during the query
+	Error *string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Run starts the ntp check
+func (n *NTP) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	log.Info("Starting ntp check", "interval", n.config.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-n.DoneChan:
+			return nil
+		case <-n.Clock().After(n.config.Schedule.Next(n.Clock().Now(), n.config.Interval)):
+			res := n.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: n.Name(),
+				Result: &checks.Result{
+					Data:          res,
+					Timestamp:     n.Clock().Now(),
+					SchemaVersion: SchemaVersion,
+				},
+			}
+			log.Debug("Successfully finished ntp check run")
+		}
+	}
+}
+
+// RunOnce runs the ntp check once against all targets
+func (n *NTP) RunOnce(ctx context.Context) (*checks.Result, []string) {
+	res := n.check(ctx)
+	failed := checks.FailedTargets(res, result.failed)
+	return &checks.Result{Data: res, Timestamp: n.Clock().Now(), SchemaVersion: SchemaVersion}, failed
+}
+
+// failed returns true if the target failed
+func (r result) failed() bool {
+	return r.Error != nil || r.Exceeded
+}
+
+// Failures reports for every target in the data of a ntp check result whether it failed
+func Failures(data any) map[string]bool {
+	res, _ := checks.ResultData[result](data)
+	return checks.TargetFailures(res, result.failed)
+}
+
+// Shutdown is called once when the check is unregistered or sparrow shuts down
+func (n *NTP) Shutdown() {
+	n.DoneChan <- struct{}{}
+	close(n.DoneChan)
+}
+
+// UpdateConfig sets the configuration of the check
+func (n *NTP) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		n.Mu.Lock()
+		defer n.Mu.Unlock()
+
+		if err := n.SetTargets(c.Targets, n.metrics.Remove); err != nil {
+			return err
+		}
+
+		n.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+// GetConfig returns the current configuration of the check
+func (n *NTP) GetConfig() checks.Runtime {
+	n.Mu.Lock()
+	defer n.Mu.Unlock()
+	return &n.config
+}
+
+// Name returns the name of the check
+func (n *NTP) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the ntp check
+func (n *NTP) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (n *NTP) GetMetricCollectors() []prometheus.Collector {
+	return n.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (n *NTP) RemoveLabelledMetrics(target string) error {
+	return n.RemoveSeries(target, n.metrics.Remove)
+}
+
+// check queries all configured NTP servers concurrently.
+// Returns a map where each target is associated with its query result.
+func (n *NTP) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Checking ntp")
+
+	n.Mu.Lock()
+	cfg := n.config
+	n.Mu.Unlock()
+
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	log.Debug("Querying each ntp server in separate routine", "amount", len(cfg.Targets))
+	retries := checks.NewRetries(cfg.Retry, cfg.TargetRetries)
+	for _, target := range cfg.Targets {
+		wg.Add(1)
+		lo := log.With("target", target)
+		retry, opts := retries.For(target)
+
+		queryRetry := helper.Retry(func(ctx context.Context) error {
+			resp, err := n.query(ctx, address(target), cfg.Timeout, cfg.Network)
+			res := result{
+				Offset:  resp.Offset.Seconds(),
+				Delay:   resp.Delay.Seconds(),
+				Stratum: resp.Stratum,
+			}
+			if err != nil {
+				errval := err.Error()
+				res.Error = &errval
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[target] = res
+			return err
+		}, retry, opts...)
+
+		go func() {
+			defer wg.Done()
+			status := 1
+
+			lo.Debug("Starting retry routine to query ntp server")
+			if err := queryRetry(ctx); err != nil {
+				status = 0
+				lo.Warn("Error while querying ntp server", "error", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			res := results[target]
+			if status == 1 && exceeds(res.Offset, cfg.MaxOffset) {
+				status = 0
+				res.Exceeded = true
+				results[target] = res
+				lo.Warn("Clock offset exceeds the maximum offset", "offset", res.Offset, "maxOffset", cfg.MaxOffset.String())
+			}
+			lo.Debug("NTP check completed for target")
+			n.WithSeries(target, func(label string) {
+				n.metrics.Set(label, res, float64(status))
+			})
+		}()
+	}
+	wg.Wait()
+
+	log.Debug("Successfully queried all ntp servers")
+	return results
+}
+
+// exceeds returns true if the absolute offset in seconds exceeds the maximum offset.
+// A maximum offset of 0 is never exceeded.
+func exceeds(offset float64, maxOffset time.Duration) bool {
+	if maxOffset == 0 {
+		return false
+	}
+	if offset < 0 {
+		offset = -offset
+	}
+	return offset > maxOffset.Seconds()
+}
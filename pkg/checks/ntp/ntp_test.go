@@ -0,0 +1,183 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ntp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNTP_Run(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     queryFunc
+		targets   []string
+		maxOffset time.Duration
+		want      map[string]result
+	}{
+		{
+			name:    "success with no targets",
+			targets: []string{},
+			want:    map[string]result{},
+		},
+		{
+			name: "success with multiple targets",
+			query: func(_ context.Context, addr string, _ time.Duration, _ checks.NetworkConfig) (response, error) {
+				if addr == "10.0.0.1:123" {
+					return response{Offset: -20 * time.Millisecond, Delay: 10 * time.Millisecond, Stratum: 1}, nil
+				}
+				return response{Offset: 5 * time.Millisecond, Delay: 30 * time.Millisecond, Stratum: 2}, nil
+			},
+			targets:   []string{"pool.ntp.org", "10.0.0.1"},
+			maxOffset: 100 * time.Millisecond,
+			want: map[string]result{
+				"pool.ntp.org": {Offset: 0.005, Delay: 0.03, Stratum: 2},
+				"10.0.0.1":     {Offset: -0.02, Delay: 0.01, Stratum: 1},
+			},
+		},
+		{
+			name: "offset exceeds the maximum offset",
+			query: func(_ context.Context, _ string, _ time.Duration, _ checks.NetworkConfig) (response, error) {
+				return response{Offset: -2 * time.Second, Delay: 10 * time.Millisecond, Stratum: 2}, nil
+			},
+			targets:   []string{"pool.ntp.org"},
+			maxOffset: time.Second,
+			want: map[string]result{
+				"pool.ntp.org": {Offset: -2, Delay: 0.01, Stratum: 2, Exceeded: true},
+			},
+		},
+		{
+			name: "offset without maximum offset",
+			query: func(_ context.Context, _ string, _ time.Duration, _ checks.NetworkConfig) (response, error) {
+				return response{Offset: time.Minute, Stratum: 2}, nil
+			},
+			targets: []string{"pool.ntp.org"},
+			want: map[string]result{
+				"pool.ntp.org": {Offset: 60, Stratum: 2},
+			},
+		},
+		{
+			name: "error during query",
+			query: func(_ context.Context, _ string, _ time.Duration, _ checks.NetworkConfig) (response, error) {
+				return response{}, errors.New("server is not synchronized")
+			},
+			targets: []string{"pool.ntp.org"},
+			want: map[string]result{
+				"pool.ntp.org": {Error: stringPointer("server is not synchronized")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCommonNTP()
+			c.query = tt.query
+
+			cResult := make(chan checks.ResultDTO, 1)
+			defer close(cResult)
+
+			err := c.UpdateConfig(&Config{
+				Targets:   tt.targets,
+				Interval:  10 * time.Millisecond,
+				Timeout:   5 * time.Millisecond,
+				MaxOffset: tt.maxOffset,
+			})
+			if err != nil {
+				t.Fatalf("NTP.UpdateConfig() error = %v", err)
+			}
+
+			go func() {
+				err := c.Run(context.Background(), cResult)
+				if err != nil {
+					t.Errorf("NTP.Run() error = %v", err)
+					return
+				}
+			}()
+			defer c.Shutdown()
+
+			r := <-cResult
+			got, ok := r.Result.Data.(map[string]result)
+			if !ok {
+				t.Fatalf("NTP.Run() result data has type %T", r.Result.Data)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNTP_UpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   checks.Runtime
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "simple config",
+			input: &Config{
+				Targets:   []string{"pool.ntp.org"},
+				Interval:  10 * time.Second,
+				Timeout:   time.Second,
+				MaxOffset: 100 * time.Millisecond,
+			},
+			want: Config{
+				Targets:   []string{"pool.ntp.org"},
+				Interval:  10 * time.Second,
+				Timeout:   time.Second,
+				MaxOffset: 100 * time.Millisecond,
+			},
+		},
+		{
+			name:    "wrong type",
+			input:   &health.Config{Targets: []string{"https://example.com"}},
+			want:    Config{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NTP{}
+
+			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("NTP.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, c.config, "Config is not equal")
+		})
+	}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func newCommonNTP() *NTP {
+	return &NTP{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		metrics: newMetrics(),
+	}
+}
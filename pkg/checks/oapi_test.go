@@ -41,11 +41,23 @@ func TestOpenapiFromPerfData(t *testing.T) {
 			args: args[string]{perfData: "hello world"},
 			want: &openapi3.SchemaRef{
 				Value: openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
-					"data": {Type: openapi3.NewStringSchema().Type},
+					"data":          {Type: openapi3.NewStringSchema().Type},
+					"instance":      {Type: openapi3.NewStringSchema().Type},
+					"schemaVersion": {Type: openapi3.NewIntegerSchema().Type},
 					"timestamp": {
 						Type:   openapi3.NewStringSchema().Type,
 						Format: "date-time",
 					},
+					"provenance": openapi3.NewObjectSchema().WithNullable().WithProperties(map[string]*openapi3.Schema{
+						"loader": {Type: openapi3.NewStringSchema().Type},
+						"source": {Type: openapi3.NewStringSchema().Type},
+						"digest": {Type: openapi3.NewStringSchema().Type},
+						"commit": {Type: openapi3.NewStringSchema().Type},
+						"loadedAt": {
+							Type:   openapi3.NewStringSchema().Type,
+							Format: "date-time",
+						},
+					}),
 				}),
 			},
 			wantErr: false,
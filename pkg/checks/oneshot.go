@@ -0,0 +1,79 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+)
+
+// Oneshot is implemented by checks that can run a single iteration on demand,
+// e.g. to gate a CI pipeline on the results of the checks
+type Oneshot interface {
+	// RunOnce runs the check once against all of its targets.
+	// It returns the result and the sorted targets that failed.
+	RunOnce(ctx context.Context) (result *Result, failed []string)
+}
+
+// FailedTargets returns the sorted targets of the result data for which failed returns true
+func FailedTargets[T any](data map[string]T, failed func(T) bool) []string {
+	var targets []string
+	for target, res := range data {
+		if failed(res) {
+			targets = append(targets, target)
+		}
+	}
+	slices.Sort(targets)
+	return targets
+}
+
+// TargetFailures reports for every target of the result data whether failed returns true for it.
+// It returns nil if there's no result data.
+func TargetFailures[T any](data map[string]T, failed func(T) bool) map[string]bool {
+	if data == nil {
+		return nil
+	}
+	failures := make(map[string]bool, len(data))
+	for target, res := range data {
+		failures[target] = failed(res)
+	}
+	return failures
+}
+
+// ResultData returns the result data of a check, which maps every target to its result.
+// Data decoded from JSON, e.g. the results received by a hub, is converted to the result type of the check.
+func ResultData[T any](data any) (map[string]T, bool) {
+	if res, ok := data.(map[string]T); ok {
+		return res, true
+	}
+	if _, ok := data.(map[string]any); !ok {
+		return nil, false
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, false
+	}
+	var res map[string]T
+	if err = json.Unmarshal(b, &res); err != nil {
+		return nil, false
+	}
+	return res, true
+}
@@ -0,0 +1,94 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFailedTargets(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]bool
+		want []string
+	}{
+		{name: "no targets", data: nil, want: nil},
+		{name: "no failures", data: map[string]bool{"a": true, "b": true}, want: nil},
+		{name: "sorted failures", data: map[string]bool{"c": false, "a": false, "b": true}, want: []string{"a", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FailedTargets(tt.data, func(ok bool) bool { return !ok })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FailedTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]bool
+		want map[string]bool
+	}{
+		{name: "no data", data: nil, want: nil},
+		{name: "no targets", data: map[string]bool{}, want: map[string]bool{}},
+		{name: "failures", data: map[string]bool{"a": true, "b": false}, want: map[string]bool{"a": false, "b": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TargetFailures(tt.data, func(ok bool) bool { return !ok })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TargetFailures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultData(t *testing.T) {
+	type result struct {
+		Code int `json:"code"`
+	}
+
+	tests := []struct {
+		name   string
+		data   any
+		want   map[string]result
+		wantOk bool
+	}{
+		{name: "typed data", data: map[string]result{"a": {Code: 200}}, want: map[string]result{"a": {Code: 200}}, wantOk: true},
+		{name: "decoded data", data: map[string]any{"a": map[string]any{"code": 500.0}}, want: map[string]result{"a": {Code: 500}}, wantOk: true},
+		{name: "mismatching decoded data", data: map[string]any{"a": "healthy"}, want: nil, wantOk: false},
+		{name: "other data", data: []string{"a"}, want: nil, wantOk: false},
+		{name: "no data", data: nil, want: nil, wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ResultData[result](tt.data)
+			if ok != tt.wantOk || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResultData() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
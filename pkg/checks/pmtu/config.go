@@ -0,0 +1,122 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pmtu
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+const (
+	minInterval = 100 * time.Millisecond
+	minTimeout  = 200 * time.Millisecond
+
+	// defaultMaxMTU is the size of the first probe if no maximum is configured
+	defaultMaxMTU = 1500
+	// defaultMinMTU is the minimum MTU every IPv4 link must support (RFC 791)
+	defaultMinMTU = 68
+	// maxIPv4PacketSize is the largest size an IPv4 packet can have
+	maxIPv4PacketSize = 65535
+)
+
+// Config defines the configuration parameters for a path MTU check
+type Config struct {
+	// Targets is a list of host names or IPv4 addresses to discover the path MTU to
+	Targets []string `json:"targets" yaml:"targets" mapstructure:"targets"`
+	// Interval is the time to wait between check iterations
+	Interval time.Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+	// Schedule is a cron expression defining when the check runs. It replaces the interval if set.
+	Schedule checks.Schedule `json:"schedule,omitempty" yaml:"schedule,omitempty" mapstructure:"schedule"`
+	// Timeout is the maximum time to wait for the answer to a single probe
+	Timeout time.Duration `json:"timeout" yaml:"timeout" mapstructure:"timeout"`
+	// Retry defines if and how to retry a target
+	Retry helper.RetryConfig `json:"retry" yaml:"retry" mapstructure:"retry"`
+	// MaxMTU is the size of the first probe in bytes. Defaults to 1500.
+	MaxMTU int `json:"maxMtu,omitempty" yaml:"maxMtu,omitempty" mapstructure:"maxMtu"`
+	// MinMTU is the size of the smallest probe in bytes. Defaults to 68.
+	MinMTU int `json:"minMtu,omitempty" yaml:"minMtu,omitempty" mapstructure:"minMtu"`
+	// Network configures the DSCP marking and the source binding of the probes
+	Network checks.NetworkConfig `json:"network,omitempty" yaml:"network,omitempty" mapstructure:"network"`
+	// TargetRetries override the retry configuration for some targets
+	TargetRetries []checks.TargetRetry `json:"targetRetries,omitempty" yaml:"targetRetries,omitempty" mapstructure:"targetRetries"`
+}
+
+// For returns the name of the check
+func (c *Config) For() string {
+	return CheckName
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	for i, t := range c.Targets {
+		if t == "" || strings.Contains(t, "://") {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "must be a host name or an ip"}
+		}
+	}
+
+	if !c.Schedule.Enabled() && c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+
+	if c.MaxMTU != 0 && (c.MaxMTU < defaultMinMTU || c.MaxMTU > maxIPv4PacketSize) {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "maxMtu", Reason: fmt.Sprintf("must be between %d and %d", defaultMinMTU, maxIPv4PacketSize)}
+	}
+
+	if c.MinMTU != 0 && (c.MinMTU < defaultMinMTU || c.MinMTU > c.maxMTU()) {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "minMtu", Reason: fmt.Sprintf("must be between %d and the maximum mtu", defaultMinMTU)}
+	}
+
+	if err := c.Schedule.Validate(c.For()); err != nil {
+		return err
+	}
+	if err := checks.ValidateRetries(CheckName, c.TargetRetries, c.Targets); err != nil {
+		return err
+	}
+
+	timing := checks.Timing{Interval: c.Interval, Timeout: c.Timeout, Schedule: c.Schedule, Retry: c.Retry, TargetRetries: c.TargetRetries}
+	if err := timing.Validate(CheckName); err != nil {
+		return err
+	}
+
+	return c.Network.Validate(CheckName)
+}
+
+// maxMTU returns the configured maximum MTU or the default
+func (c *Config) maxMTU() int {
+	if c.MaxMTU == 0 {
+		return defaultMaxMTU
+	}
+	return c.MaxMTU
+}
+
+// minMTU returns the configured minimum MTU or the default
+func (c *Config) minMTU() int {
+	if c.MinMTU == 0 {
+		return defaultMinMTU
+	}
+	return c.MinMTU
+}
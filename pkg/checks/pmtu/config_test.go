@@ -0,0 +1,121 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pmtu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				Targets:  []string{"example.com", "10.0.0.1"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid mtu range",
+			config: Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				MaxMTU:   9000,
+				MinMTU:   1280,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid target",
+			config: Config{
+				Targets:  []string{"https://example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			config: Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  100 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+		{
+			name: "max mtu too small",
+			config: Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				MaxMTU:   60,
+			},
+			wantErr: true,
+		},
+		{
+			name: "min mtu above default max mtu",
+			config: Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				MinMTU:   2000,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid network",
+			config: Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Network:  checks.NetworkConfig{DSCP: 64},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pmtu
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ipv4HeaderSize is the size of an IPv4 header without options in bytes
+	ipv4HeaderSize = 20
+	// udpHeaderSize is the size of an UDP header in bytes
+	udpHeaderSize = 8
+	// probePort is the destination port of the probes. It is expected to be closed,
+	// so the target answers with an ICMP 'Port Unreachable' once a probe arrives.
+	probePort = 33434
+
+	// codePortUnreachable is the ICMP 'Destination Unreachable' code sent by the target
+	codePortUnreachable = 3
+	// codeFragmentationNeeded is the ICMP 'Destination Unreachable' code sent by a router
+	// which had to drop a probe because it was too big and the don't fragment flag was set
+	codeFragmentationNeeded = 4
+)
+
+// plateaus are the common MTU values (RFC 1191) used as probe sizes
+// if a router does not report the MTU of its next hop
+var plateaus = []int{65535, 32000, 17914, 9000, 8166, 4352, 2002, 1500, 1492, 1480, 1460, 1400, 1280, 1006, 576, 508, 296, 68}
+
+// ErrPermissionDenied is returned if the ICMP listener cannot be created due to missing privileges
+var ErrPermissionDenied = errors.New("listening for icmp messages requires the CAP_NET_RAW capability")
+
+// discoverConfig is the configuration of a single path MTU discovery
+type discoverConfig struct {
+	Target  string
+	MaxMTU  int
+	MinMTU  int
+	Timeout time.Duration
+	Network checks.NetworkConfig
+}
+
+// discoverFunc discovers the path MTU to a single target
+type discoverFunc func(ctx context.Context, cfg discoverConfig) (result, error)
+
+// replyKind is the meaning of an ICMP message answering a probe
+type replyKind int
+
+const (
+	// replyReached means the probe reached the target
+	replyReached replyKind = iota + 1
+	// replyFragmentationNeeded means a router dropped the probe because it was too big
+	replyFragmentationNeeded
+)
+
+// reply is an ICMP message answering a probe
+type reply struct {
+	kind replyKind
+	// mtu is the MTU of the next hop reported by the router or 0 if none was reported
+	mtu int
+	// from is the address of the host that sent the message
+	from string
+}
+
+// discover discovers the path MTU to the target by sending UDP probes with the
+// don't fragment flag set, starting at the maximum MTU and decreasing the size until
+// a probe reaches the target. The hop of the result is the router which last reported
+// that fragmentation was needed.
+func discover(ctx context.Context, cfg discoverConfig) (result, error) {
+	log := logger.FromContext(ctx).With("target", cfg.Target)
+	var res result
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip4", cfg.Target)
+	if err != nil {
+		return res, fmt.Errorf("failed to resolve target: %w", err)
+	}
+	dst := ips[0]
+
+	listener, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		if errors.Is(err, unix.EPERM) {
+			return res, ErrPermissionDenied
+		}
+		return res, fmt.Errorf("failed to create icmp listener: %w", err)
+	}
+	defer listener.Close() // #nosec G307
+
+	conn, err := listenUDP(ctx, cfg.Network)
+	if err != nil {
+		return res, fmt.Errorf("failed to create udp socket: %w", err)
+	}
+	defer conn.Close() // #nosec G307
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+
+	for size := cfg.MaxMTU; size >= cfg.MinMTU; {
+		log.DebugContext(ctx, "Sending probe", "size", size)
+		_, err = conn.WriteTo(make([]byte, size-ipv4HeaderSize-udpHeaderSize), &net.UDPAddr{IP: dst, Port: probePort})
+		if err != nil {
+			if !errors.Is(err, syscall.EMSGSIZE) {
+				return res, fmt.Errorf("failed to send probe: %w", err)
+			}
+			// The probe exceeds the MTU of the local interface
+			log.DebugContext(ctx, "Probe exceeds the local mtu", "size", size)
+			size = nextSize(size, 0)
+			continue
+		}
+
+		r, ok := readReply(ctx, listener, dst, port, cfg.Timeout)
+		switch {
+		case !ok:
+			log.DebugContext(ctx, "Probe was not answered", "size", size)
+			size = nextSize(size, 0)
+		case r.kind == replyReached:
+			res.MTU = size
+			return res, nil
+		case r.kind == replyFragmentationNeeded:
+			log.DebugContext(ctx, "Fragmentation needed", "size", size, "hop", r.from, "mtu", r.mtu)
+			res.Hop = r.from
+			size = nextSize(size, r.mtu)
+		}
+	}
+
+	return res, fmt.Errorf("no probe of at least %d bytes reached the target", cfg.MinMTU)
+}
+
+// listenUDP creates an unconnected UDP socket sending with the don't fragment flag set.
+// The socket is unconnected, so ICMP errors are not reported on it.
+func listenUDP(ctx context.Context, netCfg checks.NetworkConfig) (net.PacketConn, error) {
+	lc := net.ListenConfig{
+		Control: func(network, _ string, c syscall.RawConn) error {
+			if err := netCfg.Control(network, c); err != nil {
+				return err
+			}
+			var opErr error
+			err := c.Control(func(fd uintptr) {
+				opErr = setDontFragment(int(fd)) // #nosec G115 // The net package is safe to use
+			})
+			if err != nil {
+				return err
+			}
+			return opErr
+		},
+	}
+
+	addr := "0.0.0.0:0"
+	if ip := netCfg.LocalIP(); ip != nil {
+		addr = net.JoinHostPort(ip.String(), "0")
+	}
+	return lc.ListenPacket(ctx, "udp4", addr)
+}
+
+// readReply reads ICMP messages until one answers the probe sent from the given port
+// to dst or the timeout is reached. Returns false if no answer was received.
+func readReply(ctx context.Context, listener *icmp.PacketConn, dst net.IP, port int, timeout time.Duration) (reply, bool) {
+	log := logger.FromContext(ctx)
+	if err := listener.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		log.DebugContext(ctx, "Failed to set icmp read deadline", "error", err)
+		return reply{}, false
+	}
+
+	buffer := make([]byte, maxIPv4PacketSize)
+	for {
+		n, from, err := listener.ReadFrom(buffer)
+		if err != nil {
+			// This is probably a timeout
+			log.DebugContext(ctx, "Failed to read icmp message", "error", err)
+			return reply{}, false
+		}
+
+		r, ok := parseReply(buffer[:n], dst, port)
+		if ok {
+			r.from = from.String()
+			return r, true
+		}
+	}
+}
+
+// parseReply parses an ICMP message and returns true if it is a 'Destination Unreachable'
+// answering the probe sent from the given port to dst
+func parseReply(b []byte, dst net.IP, port int) (reply, bool) {
+	msg, err := icmp.ParseMessage(ipv4.ICMPTypeDestinationUnreachable.Protocol(), b)
+	if err != nil || msg.Type != ipv4.ICMPTypeDestinationUnreachable {
+		return reply{}, false
+	}
+
+	// The message contains the IP header and the first 8 bytes of the probe
+	data := msg.Body.(*icmp.DstUnreach).Data
+	if len(data) < ipv4HeaderSize {
+		return reply{}, false
+	}
+	headerSize := int(data[0]&0x0f) * 4
+	if len(data) < headerSize+udpHeaderSize || data[9] != unix.IPPROTO_UDP || !net.IP(data[16:20]).Equal(dst) {
+		return reply{}, false
+	}
+	if int(binary.BigEndian.Uint16(data[headerSize:headerSize+2])) != port {
+		return reply{}, false
+	}
+
+	switch msg.Code {
+	case codePortUnreachable:
+		return reply{kind: replyReached}, true
+	case codeFragmentationNeeded:
+		// The next hop MTU is stored in the last two bytes of the ICMP header (RFC 1191)
+		return reply{kind: replyFragmentationNeeded, mtu: int(binary.BigEndian.Uint16(b[6:8]))}, true
+	default:
+		return reply{}, false
+	}
+}
+
+// nextSize returns the size of the next probe after a probe of the given size did not reach
+// the target. The MTU reported by a router is used if it is smaller, otherwise the next
+// smaller plateau. Returns 0 if there is no smaller size.
+func nextSize(size, reported int) int {
+	if reported > 0 && reported < size {
+		return reported
+	}
+	i := slices.IndexFunc(plateaus, func(p int) bool { return p < size })
+	if i < 0 {
+		return 0
+	}
+	return plateaus[i]
+}
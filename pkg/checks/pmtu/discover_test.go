@@ -0,0 +1,125 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pmtu
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+)
+
+// newDstUnreach builds an ICMP 'Destination Unreachable' message
+// answering a probe sent from srcPort to dst
+func newDstUnreach(code, mtu int, dst net.IP, srcPort int) []byte {
+	b := make([]byte, 8+ipv4HeaderSize+udpHeaderSize)
+	b[0] = 3
+	b[1] = byte(code)
+	binary.BigEndian.PutUint16(b[6:8], uint16(mtu)) // #nosec G115 // Test values are small
+
+	ip := b[8:]
+	ip[0] = 0x45
+	ip[9] = 17
+	copy(ip[16:20], dst.To4())
+	binary.BigEndian.PutUint16(ip[ipv4HeaderSize:], uint16(srcPort)) // #nosec G115 // Test values are small
+	binary.BigEndian.PutUint16(ip[ipv4HeaderSize+2:], probePort)
+	return b
+}
+
+func TestParseReply(t *testing.T) {
+	dst := net.ParseIP("10.0.0.1")
+	tests := []struct {
+		name   string
+		msg    []byte
+		want   reply
+		wantOk bool
+	}{
+		{
+			name:   "port unreachable",
+			msg:    newDstUnreach(codePortUnreachable, 0, dst, 40000),
+			want:   reply{kind: replyReached},
+			wantOk: true,
+		},
+		{
+			name:   "fragmentation needed",
+			msg:    newDstUnreach(codeFragmentationNeeded, 1400, dst, 40000),
+			want:   reply{kind: replyFragmentationNeeded, mtu: 1400},
+			wantOk: true,
+		},
+		{
+			name:   "other source port",
+			msg:    newDstUnreach(codePortUnreachable, 0, dst, 40001),
+			wantOk: false,
+		},
+		{
+			name:   "other destination",
+			msg:    newDstUnreach(codePortUnreachable, 0, net.ParseIP("10.0.0.2"), 40000),
+			wantOk: false,
+		},
+		{
+			name:   "host unreachable",
+			msg:    newDstUnreach(1, 0, dst, 40000),
+			wantOk: false,
+		},
+		{
+			name:   "truncated message",
+			msg:    newDstUnreach(codePortUnreachable, 0, dst, 40000)[:12],
+			wantOk: false,
+		},
+		{
+			name:   "echo reply",
+			msg:    []byte{0, 0, 0, 0, 0, 1, 0, 1},
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseReply(tt.msg, dst, 40000)
+			if ok != tt.wantOk {
+				t.Fatalf("parseReply() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("parseReply() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		reported int
+		want     int
+	}{
+		{name: "reported mtu", size: 1500, reported: 1400, want: 1400},
+		{name: "no reported mtu", size: 1500, reported: 0, want: 1492},
+		{name: "reported mtu not smaller", size: 1400, reported: 1500, want: 1280},
+		{name: "between plateaus", size: 1450, reported: 0, want: 1400},
+		{name: "smallest plateau", size: 68, reported: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextSize(tt.size, tt.reported); got != tt.want {
+				t.Errorf("nextSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pmtu
+
+import (
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics defines the metric collectors of the path MTU check
+type metrics struct {
+	status   *prometheus.GaugeVec
+	mtu      *prometheus.GaugeVec
+	duration *prometheus.GaugeVec
+}
+
+// newMetrics initializes metric collectors of the path MTU check
+func newMetrics() metrics {
+	return metrics{
+		status: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_pmtu_status",
+				Help: "Specifies if the path MTU to the target could be discovered.",
+			},
+			[]string{"target"},
+		),
+		mtu: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_pmtu_mtu_bytes",
+				Help: "Discovered path MTU to the target in bytes.",
+			},
+			[]string{"target"},
+		),
+		duration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_pmtu_duration_seconds",
+				Help: "Duration of the path MTU discovery in seconds.",
+			},
+			[]string{"target"},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.status,
+		m.mtu,
+		m.duration,
+	}
+}
+
+// Set sets the metrics of one target result
+func (m *metrics) Set(target string, res result, status float64) {
+	m.status.WithLabelValues(target).Set(status)
+	m.mtu.WithLabelValues(target).Set(float64(res.MTU))
+	m.duration.WithLabelValues(target).Set(res.Total)
+}
+
+// Remove removes the metrics of one target
+func (m *metrics) Remove(target string) error {
+	if !m.status.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.mtu.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.duration.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	return nil
+}
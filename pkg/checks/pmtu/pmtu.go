@@ -0,0 +1,251 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pmtu
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_ checks.Check   = (*PMTU)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "pmtu"
+
+// SchemaVersion is the version of the layout of the check's result data.
+// It is incremented with every breaking change of the layout.
+const SchemaVersion = 1
+
+// PMTU is a check that discovers the path MTU to the targets
+type PMTU struct {
+	checks.CheckBase
+	config   Config
+	metrics  metrics
+	discover discoverFunc
+}
+
+// NewCheck creates a new instance of the pmtu check
+func NewCheck() checks.Check {
+	return &PMTU{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics:  newMetrics(),
+		discover: discover,
+	}
+}
+
+// result represents the result of a path MTU discovery for a specific target
+type result struct {
+	// MTU is the effective path MTU to the target in bytes
+	MTU int `json:"mtu" yaml:"mtu"`
+	// Hop is the address of the router which reported that fragmentation was needed
+	Hop string `json:"hop,omitempty" yaml:"hop,omitempty"`
+	// Error is the error that occurred during the discovery
+	Error *string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Total is the duration of the discovery in seconds
+	Total float64 `json:"total" yaml:"total"`
+}
+
+// Run starts the pmtu check
+func (p *PMTU) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	log.Info("Starting pmtu check", "interval", p.config.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-p.DoneChan:
+			return nil
+		case <-p.Clock().After(p.config.Schedule.Next(p.Clock().Now(), p.config.Interval)):
+			res := p.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: p.Name(),
+				Result: &checks.Result{
+					Data:          res,
+					Timestamp:     p.Clock().Now(),
+					SchemaVersion: SchemaVersion,
+				},
+			}
+			log.Debug("Successfully finished pmtu check run")
+		}
+	}
+}
+
+// RunOnce runs the pmtu check once against all targets
+func (p *PMTU) RunOnce(ctx context.Context) (*checks.Result, []string) {
+	res := p.check(ctx)
+	failed := checks.FailedTargets(res, result.failed)
+	return &checks.Result{Data: res, Timestamp: p.Clock().Now(), SchemaVersion: SchemaVersion}, failed
+}
+
+// failed returns true if the target failed
+func (r result) failed() bool {
+	return r.Error != nil
+}
+
+// Failures reports for every target in the data of a path MTU check result whether it failed
+func Failures(data any) map[string]bool {
+	res, _ := checks.ResultData[result](data)
+	return checks.TargetFailures(res, result.failed)
+}
+
+// Shutdown is called once when the check is unregistered or sparrow shuts down
+func (p *PMTU) Shutdown() {
+	p.DoneChan <- struct{}{}
+	close(p.DoneChan)
+}
+
+// UpdateConfig sets the configuration of the check
+func (p *PMTU) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		p.Mu.Lock()
+		defer p.Mu.Unlock()
+
+		if err := p.SetTargets(c.Targets, p.metrics.Remove); err != nil {
+			return err
+		}
+
+		p.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+// GetConfig returns the current configuration of the check
+func (p *PMTU) GetConfig() checks.Runtime {
+	p.Mu.Lock()
+	defer p.Mu.Unlock()
+	return &p.config
+}
+
+// Name returns the name of the check
+func (p *PMTU) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the pmtu check
+func (p *PMTU) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (p *PMTU) GetMetricCollectors() []prometheus.Collector {
+	return p.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (p *PMTU) RemoveLabelledMetrics(target string) error {
+	return p.RemoveSeries(target, p.metrics.Remove)
+}
+
+// check discovers the path MTU to all configured targets concurrently.
+// Returns a map where each target is associated with its discovery result.
+func (p *PMTU) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Checking path mtu")
+
+	p.Mu.Lock()
+	cfg := p.config
+	p.Mu.Unlock()
+
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	log.Debug("Discovering path mtu for each target in separate routine", "amount", len(cfg.Targets))
+	retries := checks.NewRetries(cfg.Retry, cfg.TargetRetries)
+	for _, target := range cfg.Targets {
+		wg.Add(1)
+		lo := log.With("target", target)
+		retry, opts := retries.For(target)
+
+		discoverRetry := helper.Retry(func(ctx context.Context) error {
+			start := time.Now()
+			res, err := p.discover(ctx, discoverConfig{
+				Target:  target,
+				MaxMTU:  cfg.maxMTU(),
+				MinMTU:  cfg.minMTU(),
+				Timeout: cfg.Timeout,
+				Network: cfg.Network,
+			})
+			res.Total = time.Since(start).Seconds()
+			if err != nil {
+				errval := err.Error()
+				res.Error = &errval
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[target] = res
+			return err
+		}, retry, opts...)
+
+		go func() {
+			defer wg.Done()
+			status := 1
+
+			lo.Debug("Starting retry routine to discover path mtu")
+			if err := discoverRetry(ctx); err != nil {
+				status = 0
+				lo.Warn("Error while discovering path mtu", "error", err)
+			}
+			lo.Debug("Path mtu discovery completed for target")
+
+			mu.Lock()
+			defer mu.Unlock()
+			p.WithSeries(target, func(label string) {
+				p.metrics.Set(label, results[target], float64(status))
+			})
+		}()
+	}
+	wg.Wait()
+
+	log.Debug("Successfully discovered path mtu of all targets")
+	return results
+}
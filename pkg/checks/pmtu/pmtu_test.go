@@ -0,0 +1,167 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pmtu
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPMTU_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		discover discoverFunc
+		targets  []string
+		want     map[string]result
+	}{
+		{
+			name:    "success with no targets",
+			targets: []string{},
+			want:    map[string]result{},
+		},
+		{
+			name: "success with multiple targets",
+			discover: func(_ context.Context, cfg discoverConfig) (result, error) {
+				if cfg.Target == "10.0.0.1" {
+					return result{MTU: 1400, Hop: "192.168.0.1"}, nil
+				}
+				return result{MTU: cfg.MaxMTU}, nil
+			},
+			targets: []string{"example.com", "10.0.0.1"},
+			want: map[string]result{
+				"example.com": {MTU: defaultMaxMTU},
+				"10.0.0.1":    {MTU: 1400, Hop: "192.168.0.1"},
+			},
+		},
+		{
+			name: "error during discovery",
+			discover: func(_ context.Context, _ discoverConfig) (result, error) {
+				return result{Hop: "192.168.0.1"}, errors.New("no probe reached the target")
+			},
+			targets: []string{"example.com"},
+			want: map[string]result{
+				"example.com": {Hop: "192.168.0.1", Error: stringPointer("no probe reached the target")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCommonPMTU()
+			c.discover = tt.discover
+
+			cResult := make(chan checks.ResultDTO, 1)
+			defer close(cResult)
+
+			err := c.UpdateConfig(&Config{
+				Targets:  tt.targets,
+				Interval: 10 * time.Millisecond,
+				Timeout:  5 * time.Millisecond,
+			})
+			if err != nil {
+				t.Fatalf("PMTU.UpdateConfig() error = %v", err)
+			}
+
+			go func() {
+				err := c.Run(context.Background(), cResult)
+				if err != nil {
+					t.Errorf("PMTU.Run() error = %v", err)
+					return
+				}
+			}()
+			defer c.Shutdown()
+
+			r := <-cResult
+			got, ok := r.Result.Data.(map[string]result)
+			if !ok {
+				t.Fatalf("PMTU.Run() result data has type %T", r.Result.Data)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("PMTU.Run() returned %d results, want %d", len(got), len(tt.want))
+			}
+			for target, want := range tt.want {
+				res := got[target]
+				assert.Equal(t, want.MTU, res.MTU, "MTU of %s", target)
+				assert.Equal(t, want.Hop, res.Hop, "Hop of %s", target)
+				assert.Equal(t, want.Error, res.Error, "Error of %s", target)
+			}
+		})
+	}
+}
+
+func TestPMTU_UpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   checks.Runtime
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "simple config",
+			input: &Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+				MaxMTU:   9000,
+			},
+			want: Config{
+				Targets:  []string{"example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+				MaxMTU:   9000,
+			},
+		},
+		{
+			name:    "wrong type",
+			input:   &health.Config{Targets: []string{"https://example.com"}},
+			want:    Config{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &PMTU{}
+
+			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("PMTU.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, c.config, "Config is not equal")
+		})
+	}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func newCommonPMTU() *PMTU {
+	return &PMTU{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		metrics: newMetrics(),
+	}
+}
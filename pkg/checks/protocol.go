@@ -0,0 +1,99 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package checks
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// Protocol is the HTTP protocol version probes are forced to use
+type Protocol string
+
+const (
+	// ProtocolHTTP1 forces HTTP/1.1
+	ProtocolHTTP1 Protocol = "http1.1"
+	// ProtocolHTTP2 forces HTTP/2. It is negotiated via TLS, so the targets must be HTTPS endpoints.
+	ProtocolHTTP2 Protocol = "http2"
+	// ProtocolHTTP3 is HTTP/3 over QUIC. It is not supported yet, as there is no QUIC transport available.
+	ProtocolHTTP3 Protocol = "http3"
+)
+
+// Validate checks if the protocol is valid
+func (p Protocol) Validate(checkName string) error {
+	switch p {
+	case "", ProtocolHTTP1, ProtocolHTTP2:
+		return nil
+	case ProtocolHTTP3:
+		return ErrInvalidConfig{CheckName: checkName, Field: "protocol", Reason: "HTTP/3 is not supported yet"}
+	default:
+		return ErrInvalidConfig{CheckName: checkName, Field: "protocol", Reason: "must be http1.1 or http2"}
+	}
+}
+
+// Transport returns an [http.RoundTripper] forcing the protocol on top of the given round tripper.
+// The round tripper is returned as is if no protocol is configured.
+// If it's nil, a clone of the default transport is used.
+func (p Protocol) Transport(rt http.RoundTripper) http.RoundTripper {
+	if p == "" {
+		return rt
+	}
+
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		t = &http.Transport{Proxy: http.ProxyFromEnvironment}
+		if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+			t = dt.Clone()
+		}
+	}
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	switch p {
+	case ProtocolHTTP1:
+		// A non-nil empty map disables the HTTP/2 upgrade of the transport
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		t.TLSClientConfig.NextProtos = []string{"http/1.1"}
+	case ProtocolHTTP2:
+		t.ForceAttemptHTTP2 = true
+		t.TLSNextProto = nil
+	}
+	return t
+}
+
+// Verify returns an error if the response wasn't received with the protocol,
+// e.g. because the server or a proxy in between downgraded the connection
+func (p Protocol) Verify(resp *http.Response) error {
+	var major int
+	switch p {
+	case ProtocolHTTP1:
+		major = 1
+	case ProtocolHTTP2:
+		major = 2
+	default:
+		return nil
+	}
+
+	if resp.ProtoMajor != major {
+		return fmt.Errorf("negotiated protocol is %s instead of %s", resp.Proto, p)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package checks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProtocol_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol Protocol
+		wantErr  bool
+	}{
+		{name: "empty", protocol: ""},
+		{name: "http1.1", protocol: ProtocolHTTP1},
+		{name: "http2", protocol: ProtocolHTTP2},
+		{name: "http3 not supported", protocol: ProtocolHTTP3, wantErr: true},
+		{name: "unknown", protocol: "spdy", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.protocol.Validate("health"); (err != nil) != tt.wantErr {
+				t.Errorf("Protocol.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProtocol_Transport(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		protocol  Protocol
+		wantMajor int
+	}{
+		{name: "http1.1", protocol: ProtocolHTTP1, wantMajor: 1},
+		{name: "http2", protocol: ProtocolHTTP2, wantMajor: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := srv.Client().Transport.(*http.Transport).Clone()
+			client := &http.Client{Transport: tt.protocol.Transport(rt)}
+
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.ProtoMajor != tt.wantMajor {
+				t.Errorf("Protocol = %s, want HTTP/%d", resp.Proto, tt.wantMajor)
+			}
+			if err := tt.protocol.Verify(resp); err != nil {
+				t.Errorf("Protocol.Verify() error = %v", err)
+			}
+		})
+	}
+
+	if rt := Protocol("").Transport(nil); rt != nil {
+		t.Errorf("Protocol.Transport() = %v, want nil if no protocol is configured", rt)
+	}
+}
+
+func TestProtocol_Verify(t *testing.T) {
+	resp := &http.Response{Proto: "HTTP/1.1", ProtoMajor: 1, ProtoMinor: 1}
+	if err := ProtocolHTTP2.Verify(resp); err == nil {
+		t.Error("Protocol.Verify() = nil, want an error for a downgraded response")
+	}
+	if err := Protocol("").Verify(resp); err != nil {
+		t.Errorf("Protocol.Verify() error = %v, want nil if no protocol is configured", err)
+	}
+}
@@ -0,0 +1,50 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package checks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Provenance describes where a runtime configuration was loaded from,
+// so the results and the targets of the checks can be traced back to the revision of their configuration
+type Provenance struct {
+	// Loader is the type of the loader that loaded the configuration, e.g. file or http
+	Loader string `json:"loader" yaml:"loader"`
+	// Source is the path of the file or the URL the configuration was loaded from
+	Source string `json:"source" yaml:"source"`
+	// Digest is the SHA-256 digest of the loaded configuration, which identifies its revision
+	Digest string `json:"digest" yaml:"digest"`
+	// Commit is the git commit SHA of the configuration if the source is backed by a git repository
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	// LoadedAt is the time the configuration was loaded
+	LoadedAt time.Time `json:"loadedAt" yaml:"loadedAt"`
+}
+
+// NewProvenance returns the provenance of the configuration loaded by the loader from the source
+func NewProvenance(loader, source string, content []byte) *Provenance {
+	sum := sha256.Sum256(content)
+	return &Provenance{
+		Loader:   loader,
+		Source:   source,
+		Digest:   "sha256:" + hex.EncodeToString(sum[:]),
+		LoadedAt: time.Now(),
+	}
+}
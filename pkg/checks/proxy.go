@@ -0,0 +1,138 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// proxySchemes are the supported schemes of the proxy URLs
+var proxySchemes = []string{"socks5", "socks5h", "http", "https"}
+
+// TargetProxy configures a proxy the probes to targets are sent through
+type TargetProxy struct {
+	// Targets are the targets probed through the proxy. They must be targets of the check.
+	Targets []string `json:"targets" yaml:"targets"`
+	// URL is the URL of the proxy, e.g. socks5://egress-eu.example.com:1080
+	URL string `json:"url" yaml:"url"`
+}
+
+// ValidateProxies checks if the proxy configurations are valid and every target is probed through at most one proxy
+func ValidateProxies(checkName string, proxies []TargetProxy, targets []string) error {
+	proxied := map[string]struct{}{}
+	for i, p := range proxies {
+		field := fmt.Sprintf("proxies[%d]", i)
+		if len(p.Targets) == 0 {
+			return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: "must not be empty"}
+		}
+		for _, t := range p.Targets {
+			if !slices.Contains(targets, t) {
+				return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: fmt.Sprintf("%q is not a target of the check", t)}
+			}
+			if _, ok := proxied[t]; ok {
+				return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: fmt.Sprintf("%q is already configured by another proxy", t)}
+			}
+			proxied[t] = struct{}{}
+		}
+		u, err := url.Parse(p.URL)
+		if err != nil || u.Host == "" {
+			return ErrInvalidConfig{CheckName: checkName, Field: field + ".url", Reason: "invalid url"}
+		}
+		if !slices.Contains(proxySchemes, u.Scheme) {
+			return ErrInvalidConfig{CheckName: checkName, Field: field + ".url", Reason: "scheme must be socks5, socks5h, http or https"}
+		}
+	}
+	return nil
+}
+
+// RedactProxies returns a copy of the proxy configurations with the passwords of the URLs replaced
+func RedactProxies(proxies []TargetProxy) []TargetProxy {
+	if proxies == nil {
+		return nil
+	}
+	res := make([]TargetProxy, len(proxies))
+	for i, p := range proxies {
+		if u, err := url.Parse(p.URL); err == nil {
+			if _, ok := u.User.Password(); ok {
+				u.User = url.UserPassword(u.User.Username(), redacted)
+				p.URL = u.String()
+			}
+		}
+		res[i] = p
+	}
+	return res
+}
+
+// targetKey is the context key of the target a request probes
+type targetKey struct{}
+
+// WithTarget returns a copy of the context carrying the target the requests made with it probe.
+// The transports returned by [ProxyTransport] use it to select the proxy of the target.
+func WithTarget(ctx context.Context, target string) context.Context {
+	return context.WithValue(ctx, targetKey{}, target)
+}
+
+// ProxyTransport returns a clone of the transport, or the default transport if it's nil,
+// sending the requests to the configured targets through their proxy.
+// The requests to other targets keep using the proxy of the transport, if any.
+// The transport is returned as is if no proxies are configured.
+func ProxyTransport(proxies []TargetProxy, rt http.RoundTripper) http.RoundTripper {
+	if len(proxies) == 0 {
+		return rt
+	}
+
+	byTarget := map[string]*url.URL{}
+	for _, p := range proxies {
+		u, err := url.Parse(p.URL)
+		if err != nil {
+			// The configuration is validated beforehand
+			continue
+		}
+		for _, t := range p.Targets {
+			byTarget[t] = u
+		}
+	}
+
+	tr, ok := rt.(*http.Transport)
+	if ok {
+		tr = tr.Clone()
+	} else {
+		tr = &http.Transport{Proxy: http.ProxyFromEnvironment}
+		if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+			tr = dt.Clone()
+		}
+	}
+	fallback := tr.Proxy
+	tr.Proxy = func(req *http.Request) (*url.URL, error) {
+		if target, ok := req.Context().Value(targetKey{}).(string); ok {
+			if u, ok := byTarget[target]; ok {
+				return u, nil
+			}
+		}
+		if fallback == nil {
+			return nil, nil
+		}
+		return fallback(req)
+	}
+	return tr
+}
@@ -0,0 +1,106 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/caas-team/sparrow/internal/helper"
+)
+
+// TargetRetry overrides the retry configuration of a check for some of its targets,
+// e.g. to retry flaky but important endpoints more often
+type TargetRetry struct {
+	// Targets are the targets retried this way. They must be targets of the check.
+	Targets []string `json:"targets" yaml:"targets"`
+	// Retry replaces the retry configuration of the check for the targets
+	Retry helper.RetryConfig `json:"retry" yaml:"retry"`
+	// Budget is the maximum total number of retries of the targets in a single run of the check,
+	// so the retries can't extend the run indefinitely. 0 means no limit.
+	Budget int `json:"budget,omitempty" yaml:"budget,omitempty"`
+}
+
+// ValidateRetries checks if the retry overrides are valid and every target is overridden at most once
+func ValidateRetries(checkName string, retries []TargetRetry, targets []string) error {
+	overridden := map[string]struct{}{}
+	for i, r := range retries {
+		field := fmt.Sprintf("targetRetries[%d]", i)
+		if len(r.Targets) == 0 {
+			return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: "must not be empty"}
+		}
+		for _, t := range r.Targets {
+			if !slices.Contains(targets, t) {
+				return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: fmt.Sprintf("%q is not a target of the check", t)}
+			}
+			if _, ok := overridden[t]; ok {
+				return ErrInvalidConfig{CheckName: checkName, Field: field + ".targets", Reason: fmt.Sprintf("%q is already configured by another retry override", t)}
+			}
+			overridden[t] = struct{}{}
+		}
+		if err := validateRetry(checkName, field+".retry", r.Retry); err != nil {
+			return err
+		}
+		if r.Budget < 0 {
+			return ErrInvalidConfig{CheckName: checkName, Field: field + ".budget", Reason: "must not be negative"}
+		}
+	}
+	return nil
+}
+
+// Retries are the retry configurations of the targets of a single run of a check
+type Retries struct {
+	retry   helper.RetryConfig
+	targets map[string]targetRetry
+}
+
+// targetRetry is the retry configuration of an overridden target
+type targetRetry struct {
+	retry  helper.RetryConfig
+	budget *helper.RetryBudget
+}
+
+// NewRetries returns the retry configurations of the targets for a single run of a check.
+// The targets without an override are retried with the given retry configuration of the check.
+// The targets of an override share its budget, which is full at the start of every run.
+func NewRetries(retry helper.RetryConfig, overrides []TargetRetry) Retries {
+	r := Retries{retry: retry, targets: map[string]targetRetry{}}
+	for _, o := range overrides {
+		var budget *helper.RetryBudget
+		if o.Budget > 0 {
+			budget = helper.NewRetryBudget(0, o.Budget)
+		}
+		for _, t := range o.Targets {
+			r.targets[t] = targetRetry{retry: o.Retry, budget: budget}
+		}
+	}
+	return r
+}
+
+// For returns the retry configuration of the target and the options applying its budget, if any
+func (r Retries) For(target string) (helper.RetryConfig, []helper.RetryOption) {
+	t, ok := r.targets[target]
+	if !ok {
+		return r.retry, nil
+	}
+	if t.budget == nil {
+		return t.retry, nil
+	}
+	return t.retry, []helper.RetryOption{helper.WithBudget(t.budget)}
+}
@@ -0,0 +1,102 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+)
+
+func TestValidateRetries(t *testing.T) {
+	targets := []string{"https://a.example.com", "https://b.example.com"}
+	valid := helper.RetryConfig{Count: 5, Delay: time.Second}
+
+	tests := []struct {
+		name    string
+		retries []TargetRetry
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "valid", retries: []TargetRetry{{Targets: targets, Retry: valid, Budget: 6}}},
+		{name: "no targets", retries: []TargetRetry{{Retry: valid}}, wantErr: true},
+		{name: "unknown target", retries: []TargetRetry{{Targets: []string{"https://c.example.com"}, Retry: valid}}, wantErr: true},
+		{
+			name: "target overridden twice",
+			retries: []TargetRetry{
+				{Targets: []string{"https://a.example.com"}, Retry: valid},
+				{Targets: []string{"https://a.example.com"}, Retry: valid},
+			},
+			wantErr: true,
+		},
+		{name: "negative count", retries: []TargetRetry{{Targets: targets, Retry: helper.RetryConfig{Count: -1}}}, wantErr: true},
+		{name: "negative budget", retries: []TargetRetry{{Targets: targets, Retry: valid, Budget: -1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRetries("health", tt.retries, targets); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRetries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetries_For(t *testing.T) {
+	retry := helper.RetryConfig{Count: 1}
+	override := helper.RetryConfig{Count: 5}
+	r := NewRetries(retry, []TargetRetry{
+		{Targets: []string{"https://a.example.com", "https://b.example.com"}, Retry: override, Budget: 3},
+		{Targets: []string{"https://c.example.com"}, Retry: override},
+	})
+
+	// attempts runs a retry function failing every attempt for the target and returns the number of attempts
+	attempts := func(target string) int {
+		cfg, opts := r.For(target)
+		n := 0
+		_ = helper.Retry(func(context.Context) error {
+			n++
+			return errors.New("failed")
+		}, cfg, opts...)(context.Background())
+		return n
+	}
+
+	if got := attempts("https://d.example.com"); got != 2 {
+		t.Errorf("Target without override attempted %d times, want 2", got)
+	}
+	if got := attempts("https://c.example.com"); got != 6 {
+		t.Errorf("Target with override attempted %d times, want 6", got)
+	}
+	// The targets share the budget of 3 retries
+	if got := attempts("https://a.example.com"); got != 4 {
+		t.Errorf("First target of the budget attempted %d times, want 4", got)
+	}
+	if got := attempts("https://b.example.com"); got != 1 {
+		t.Errorf("Second target of the budget attempted %d times, want 1", got)
+	}
+
+	// The budget is full again in the next run
+	r = NewRetries(retry, []TargetRetry{{Targets: []string{"https://a.example.com"}, Retry: override, Budget: 3}})
+	if got := attempts("https://a.example.com"); got != 4 {
+		t.Errorf("Target attempted %d times in the next run, want 4", got)
+	}
+}
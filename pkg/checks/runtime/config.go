@@ -24,18 +24,35 @@ import (
 	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/checks/dns"
 	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/httpheaders"
 	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/ntp"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
 	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/caas-team/sparrow/pkg/checks/zone"
 )
 
 // Config holds the runtime configuration
 // for the various checks
 // the sparrow supports
 type Config struct {
-	Health     *health.Config     `yaml:"health" json:"health"`
-	Latency    *latency.Config    `yaml:"latency" json:"latency"`
-	Dns        *dns.Config        `yaml:"dns" json:"dns"`
-	Traceroute *traceroute.Config `yaml:"traceroute" json:"traceroute"`
+	Health      *health.Config      `yaml:"health" json:"health"`
+	Latency     *latency.Config     `yaml:"latency" json:"latency"`
+	Dns         *dns.Config         `yaml:"dns" json:"dns"`
+	Traceroute  *traceroute.Config  `yaml:"traceroute" json:"traceroute"`
+	Pmtu        *pmtu.Config        `yaml:"pmtu" json:"pmtu"`
+	Ntp         *ntp.Config         `yaml:"ntp" json:"ntp"`
+	Zone        *zone.Config        `yaml:"zone" json:"zone"`
+	HttpHeaders *httpheaders.Config `yaml:"httpheaders" json:"httpheaders"`
+	// Dependencies declare which targets of a check are probed based on the results of other checks
+	Dependencies []Dependency `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+	// MaxSeries is the maximum number of targets every check registers labelled metric series for.
+	// The series of further targets are aggregated into a single series. 0 means no limit.
+	MaxSeries int `yaml:"maxSeries,omitempty" json:"maxSeries,omitempty"`
+	// Watchdog cancels check iterations exceeding a multiple of the timeout of their check
+	Watchdog Watchdog `yaml:"watchdog,omitempty" json:"watchdog,omitempty"`
+	// Provenance describes where the configuration was loaded from. It's set by the loader.
+	Provenance *checks.Provenance `yaml:"-" json:"provenance,omitempty"`
 }
 
 // Empty returns true if no checks are configured
@@ -50,9 +67,40 @@ func (c Config) Validate() (err error) {
 		}
 	}
 
+	for i := range c.Dependencies {
+		if vErr := c.Dependencies[i].Validate(c); vErr != nil {
+			err = errors.Join(err, vErr)
+		}
+	}
+
+	if c.MaxSeries < 0 {
+		err = errors.Join(err, errors.New(`invalid configuration field "maxSeries": must not be negative`))
+	}
+
+	if vErr := c.Watchdog.Validate(); vErr != nil {
+		err = errors.Join(err, vErr)
+	}
+
 	return err
 }
 
+// Redacted returns a copy of the configuration with the secrets of the checks replaced
+func (c Config) Redacted() Config {
+	if c.Health != nil {
+		h := *c.Health
+		h.Auth = checks.RedactAuth(h.Auth)
+		h.Proxies = checks.RedactProxies(h.Proxies)
+		c.Health = &h
+	}
+	if c.Latency != nil {
+		l := *c.Latency
+		l.Auth = checks.RedactAuth(l.Auth)
+		l.Proxies = checks.RedactProxies(l.Proxies)
+		c.Latency = &l
+	}
+	return c
+}
+
 // Iter returns configured checks in an iterable format
 func (c Config) Iter() []checks.Runtime {
 	var configs []checks.Runtime
@@ -68,6 +116,18 @@ func (c Config) Iter() []checks.Runtime {
 	if c.Traceroute != nil {
 		configs = append(configs, c.Traceroute)
 	}
+	if c.Pmtu != nil {
+		configs = append(configs, c.Pmtu)
+	}
+	if c.Ntp != nil {
+		configs = append(configs, c.Ntp)
+	}
+	if c.Zone != nil {
+		configs = append(configs, c.Zone)
+	}
+	if c.HttpHeaders != nil {
+		configs = append(configs, c.HttpHeaders)
+	}
 	return configs
 }
 
@@ -86,6 +146,18 @@ func (c Config) size() int {
 	if c.HasTracerouteCheck() {
 		size++
 	}
+	if c.HasPmtuCheck() {
+		size++
+	}
+	if c.HasNtpCheck() {
+		size++
+	}
+	if c.HasZoneCheck() {
+		size++
+	}
+	if c.HasHTTPHeadersCheck() {
+		size++
+	}
 	return size
 }
 
@@ -109,6 +181,26 @@ func (c Config) HasTracerouteCheck() bool {
 	return c.Traceroute != nil
 }
 
+// HasPmtuCheck returns true if the check has a path mtu check configured
+func (c Config) HasPmtuCheck() bool {
+	return c.Pmtu != nil
+}
+
+// HasNtpCheck returns true if the check has a ntp check configured
+func (c Config) HasNtpCheck() bool {
+	return c.Ntp != nil
+}
+
+// HasZoneCheck returns true if the check has a zone propagation check configured
+func (c Config) HasZoneCheck() bool {
+	return c.Zone != nil
+}
+
+// HasHTTPHeadersCheck returns true if the check has a security headers audit check configured
+func (c Config) HasHTTPHeadersCheck() bool {
+	return c.HttpHeaders != nil
+}
+
 // HasCheck returns true if the check has a check with the given name configured
 func (c Config) HasCheck(name string) bool {
 	switch name {
@@ -120,6 +212,14 @@ func (c Config) HasCheck(name string) bool {
 		return c.HasDNSCheck()
 	case traceroute.CheckName:
 		return c.HasTracerouteCheck()
+	case pmtu.CheckName:
+		return c.HasPmtuCheck()
+	case ntp.CheckName:
+		return c.HasNtpCheck()
+	case zone.CheckName:
+		return c.HasZoneCheck()
+	case httpheaders.CheckName:
+		return c.HasHTTPHeadersCheck()
 	default:
 		return false
 	}
@@ -144,6 +244,22 @@ func (c Config) For(name string) checks.Runtime {
 		if c.HasTracerouteCheck() {
 			return c.Traceroute
 		}
+	case pmtu.CheckName:
+		if c.HasPmtuCheck() {
+			return c.Pmtu
+		}
+	case ntp.CheckName:
+		if c.HasNtpCheck() {
+			return c.Ntp
+		}
+	case zone.CheckName:
+		if c.HasZoneCheck() {
+			return c.Zone
+		}
+	case httpheaders.CheckName:
+		if c.HasHTTPHeadersCheck() {
+			return c.HttpHeaders
+		}
 	}
 	return nil
 }
@@ -0,0 +1,95 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// seedConfigs is the directory of the real configurations seeding the fuzz tests
+const seedConfigs = "testdata/configs"
+
+// readSeeds returns the real configurations of the seed directory
+func readSeeds(tb testing.TB) map[string][]byte {
+	tb.Helper()
+	paths, err := filepath.Glob(filepath.Join(seedConfigs, "*.yaml"))
+	if err != nil || len(paths) == 0 {
+		tb.Fatalf("Failed to find seed configurations: %v", err)
+	}
+
+	seeds := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path) //#nosec G304 // test data
+		if err != nil {
+			tb.Fatalf("Failed to read seed configuration: %v", err)
+		}
+		seeds[filepath.Base(path)] = b
+	}
+	return seeds
+}
+
+func TestConfig_seeds(t *testing.T) {
+	for name, b := range readSeeds(t) {
+		t.Run(name, func(t *testing.T) {
+			var cfg Config
+			if err := yaml.Unmarshal(b, &cfg); err != nil {
+				t.Fatalf("Failed to parse seed configuration: %v", err)
+			}
+			if cfg.Empty() {
+				t.Error("Seed configuration configures no checks")
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+// FuzzConfig verifies malformed runtime configurations are rejected without panicking
+// while parsing, validating and comparing them
+func FuzzConfig(f *testing.F) {
+	for _, b := range readSeeds(f) {
+		f.Add(b)
+	}
+	f.Add([]byte("health:\n  targets: [\"\"]\n  interval: -1s\n"))
+	f.Add([]byte("dependencies:\n  - check: latency\n    dependsOn: latency\n"))
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var cfg Config
+		if err := yaml.Unmarshal(b, &cfg); err != nil {
+			return
+		}
+
+		_ = cfg.Validate()
+		for _, c := range cfg.Iter() {
+			if cfg.For(c.For()) == nil {
+				t.Errorf("For(%q) = nil, want the configured check", c.For())
+			}
+		}
+		_ = cfg.Diff(Config{})
+		_ = Config{}.Diff(cfg)
+		if d := cfg.Diff(cfg); !d.Empty() {
+			t.Errorf("Diff() of the same configuration = %+v, want empty", d)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package runtime
+
+import (
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/httpheaders"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/ntp"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/caas-team/sparrow/pkg/checks/zone"
+)
+
+// Dedup returns a copy of the configuration in which the duplicate targets of every check are collapsed,
+// e.g. a static target and a global target spelling the same endpoint differently.
+// Targets are duplicates if their normalized forms are equal, the first spelling is kept.
+// The number of collapsed targets is returned per check name.
+func (c Config) Dedup() (Config, map[string]int) {
+	collapsed := map[string]int{}
+	for _, name := range []string{health.CheckName, latency.CheckName, dns.CheckName, pmtu.CheckName, ntp.CheckName, zone.CheckName, httpheaders.CheckName, traceroute.CheckName} {
+		seen := map[string]struct{}{}
+		dups := 0
+		deduped := c.filterTargets(name, func(target string) bool {
+			n := checks.NormalizeTarget(target)
+			if _, ok := seen[n]; ok {
+				dups++
+				return false
+			}
+			seen[n] = struct{}{}
+			return true
+		})
+		if dups > 0 {
+			c = deduped
+			collapsed[name] = dups
+		}
+	}
+	return c, collapsed
+}
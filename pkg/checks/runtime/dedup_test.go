@@ -0,0 +1,81 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package runtime
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+)
+
+func TestConfig_Dedup(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           Config
+		want          Config
+		wantCollapsed map[string]int
+	}{
+		{
+			name: "no duplicates",
+			cfg: Config{
+				Health: &health.Config{Targets: []string{"https://a.example.com", "https://b.example.com"}},
+			},
+			want: Config{
+				Health: &health.Config{Targets: []string{"https://a.example.com", "https://b.example.com"}},
+			},
+			wantCollapsed: map[string]int{},
+		},
+		{
+			name: "different spellings are collapsed",
+			cfg: Config{
+				Health: &health.Config{Targets: []string{"https://a.example.com", "HTTPS://A.example.com/", "https://a.example.com:443"}},
+				Dns:    &dns.Config{Targets: []string{"bücher.example", "xn--bcher-kva.example", "b.example.com."}},
+			},
+			want: Config{
+				Health: &health.Config{Targets: []string{"https://a.example.com"}},
+				Dns:    &dns.Config{Targets: []string{"bücher.example", "b.example.com."}},
+			},
+			wantCollapsed: map[string]int{health.CheckName: 2, dns.CheckName: 1},
+		},
+		{
+			name: "traceroute targets are collapsed by address",
+			cfg: Config{
+				Traceroute: &traceroute.Config{Targets: []traceroute.Target{{Addr: "a.example.com", Port: 443}, {Addr: "A.example.com", Port: 443}}},
+			},
+			want: Config{
+				Traceroute: &traceroute.Config{Targets: []traceroute.Target{{Addr: "a.example.com", Port: 443}}},
+			},
+			wantCollapsed: map[string]int{traceroute.CheckName: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, collapsed := tt.cfg.Dedup()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Config.Dedup() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(collapsed, tt.wantCollapsed) {
+				t.Errorf("Config.Dedup() collapsed = %v, want %v", collapsed, tt.wantCollapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/httpheaders"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/ntp"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/caas-team/sparrow/pkg/checks/zone"
+)
+
+// Condition is the condition a target has to fulfill in the
+// result of a dependency for the dependent check to probe it
+type Condition string
+
+const (
+	// ConditionHealthy only probes the targets the dependency reports as healthy
+	ConditionHealthy Condition = "healthy"
+	// ConditionLatencyAbove only probes the targets whose latency reported by the
+	// dependency exceeds the threshold or that the dependency reports as unhealthy
+	ConditionLatencyAbove Condition = "latencyAbove"
+)
+
+// targetStates maps the names of the checks that can be depended on
+// to the function extracting the target states from their results
+var targetStates = map[string]func(data any) map[string]checks.TargetState{
+	health.CheckName:  health.TargetStates,
+	latency.CheckName: latency.TargetStates,
+}
+
+// Dependency declares that a check only probes the targets
+// fulfilling a condition in the latest result of another check
+type Dependency struct {
+	// Check is the name of the dependent check
+	Check string `yaml:"check" json:"check"`
+	// DependsOn is the name of the check the dependent check depends on
+	DependsOn string `yaml:"dependsOn" json:"dependsOn"`
+	// Condition is the condition a target has to fulfill
+	Condition Condition `yaml:"condition" json:"condition"`
+	// Threshold is the latency threshold of the latencyAbove condition
+	Threshold time.Duration `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+}
+
+// Validate checks if the dependency is valid for the given runtime configuration
+func (d *Dependency) Validate(cfg Config) error {
+	if !cfg.HasCheck(d.Check) {
+		return checks.ErrInvalidConfig{CheckName: d.Check, Field: "dependencies.check", Reason: "check is not configured"}
+	}
+	if !cfg.HasCheck(d.DependsOn) {
+		return checks.ErrInvalidConfig{CheckName: d.Check, Field: "dependencies.dependsOn", Reason: fmt.Sprintf("check %q is not configured", d.DependsOn)}
+	}
+	if d.Check == d.DependsOn {
+		return checks.ErrInvalidConfig{CheckName: d.Check, Field: "dependencies.dependsOn", Reason: "check cannot depend on itself"}
+	}
+	if _, ok := targetStates[d.DependsOn]; !ok {
+		return checks.ErrInvalidConfig{CheckName: d.Check, Field: "dependencies.dependsOn", Reason: fmt.Sprintf("check %q cannot be depended on", d.DependsOn)}
+	}
+
+	switch d.Condition {
+	case ConditionHealthy:
+	case ConditionLatencyAbove:
+		if d.Threshold <= 0 {
+			return checks.ErrInvalidConfig{CheckName: d.Check, Field: "dependencies.threshold", Reason: "must be greater than 0"}
+		}
+	default:
+		return checks.ErrInvalidConfig{CheckName: d.Check, Field: "dependencies.condition", Reason: fmt.Sprintf("unknown condition %q", d.Condition)}
+	}
+	return nil
+}
+
+// keep returns true if the dependent check should probe the target with the given state.
+// Targets unknown to the dependency are probed by the healthy condition,
+// so the dependent check isn't suppressed until the dependency reported its first result.
+func (d *Dependency) keep(state checks.TargetState, known bool) bool {
+	switch d.Condition {
+	case ConditionHealthy:
+		return !known || state.Healthy
+	case ConditionLatencyAbove:
+		return known && (!state.Healthy || state.Latency > d.Threshold)
+	default:
+		return true
+	}
+}
+
+// HasDependency returns true if any check depends on the check with the given name
+func (c Config) HasDependency(name string) bool {
+	for _, d := range c.Dependencies {
+		if d.DependsOn == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Gate returns a copy of the configuration in which the targets of all dependent checks
+// are reduced to the targets fulfilling the conditions of their dependencies.
+// The results are the latest results of the checks mapped by their name.
+func (c Config) Gate(results map[string]checks.Result) Config {
+	for i := range c.Dependencies {
+		d := &c.Dependencies[i]
+		var states map[string]checks.TargetState
+		if res, ok := results[d.DependsOn]; ok {
+			states = hostStates(targetStates[d.DependsOn](res.Data))
+		}
+
+		c = c.filterTargets(d.Check, func(target string) bool {
+			state, known := states[targetHost(target)]
+			return d.keep(state, known)
+		})
+	}
+	return c
+}
+
+// SelectTargets returns a copy of the configuration in which the targets of the given check
+// are reduced to the given targets. It also returns the given targets the check doesn't have.
+func (c Config) SelectTargets(name string, targets []string) (Config, []string) {
+	found := map[string]bool{}
+	c = c.filterTargets(name, func(target string) bool {
+		if slices.Contains(targets, target) {
+			found[target] = true
+			return true
+		}
+		return false
+	})
+
+	var unknown []string
+	for _, t := range targets {
+		if !found[t] {
+			unknown = append(unknown, t)
+		}
+	}
+	return c, unknown
+}
+
+// filterTargets returns a copy of the configuration in which the targets
+// of the given check are reduced to the targets for which keep returns true
+func (c Config) filterTargets(name string, keep func(target string) bool) Config {
+	filter := func(targets []string) []string {
+		var kept []string
+		for _, t := range targets {
+			if keep(t) {
+				kept = append(kept, t)
+			}
+		}
+		return kept
+	}
+
+	switch name {
+	case health.CheckName:
+		if c.HasHealthCheck() {
+			cfg := *c.Health
+			cfg.Targets = filter(cfg.Targets)
+			c.Health = &cfg
+		}
+	case latency.CheckName:
+		if c.HasLatencyCheck() {
+			cfg := *c.Latency
+			cfg.Targets = filter(cfg.Targets)
+			c.Latency = &cfg
+		}
+	case dns.CheckName:
+		if c.HasDNSCheck() {
+			cfg := *c.Dns
+			cfg.Targets = filter(cfg.Targets)
+			c.Dns = &cfg
+		}
+	case pmtu.CheckName:
+		if c.HasPmtuCheck() {
+			cfg := *c.Pmtu
+			cfg.Targets = filter(cfg.Targets)
+			c.Pmtu = &cfg
+		}
+	case ntp.CheckName:
+		if c.HasNtpCheck() {
+			cfg := *c.Ntp
+			cfg.Targets = filter(cfg.Targets)
+			c.Ntp = &cfg
+		}
+	case zone.CheckName:
+		if c.HasZoneCheck() {
+			cfg := *c.Zone
+			cfg.Targets = filter(cfg.Targets)
+			c.Zone = &cfg
+		}
+	case httpheaders.CheckName:
+		if c.HasHTTPHeadersCheck() {
+			cfg := *c.HttpHeaders
+			cfg.Targets = filter(cfg.Targets)
+			c.HttpHeaders = &cfg
+		}
+	case traceroute.CheckName:
+		if c.HasTracerouteCheck() {
+			cfg := *c.Traceroute
+			var kept []traceroute.Target
+			for _, t := range cfg.Targets {
+				if keep(t.Addr) {
+					kept = append(kept, t)
+				}
+			}
+			cfg.Targets = kept
+			c.Traceroute = &cfg
+		}
+	}
+	return c
+}
+
+// hostStates maps the target states by the host of their targets,
+// so the targets of checks with different target formats can be matched
+func hostStates(states map[string]checks.TargetState) map[string]checks.TargetState {
+	res := make(map[string]checks.TargetState, len(states))
+	for target, state := range states {
+		res[targetHost(target)] = state
+	}
+	return res
+}
+
+// targetHost returns the host of a target, which is either a URL, a host with a port or a plain host
+func targetHost(target string) string {
+	if u, err := url.Parse(target); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	if host, _, err := net.SplitHostPort(target); err == nil {
+		return host
+	}
+	return target
+}
@@ -0,0 +1,262 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+)
+
+func TestConfig_Gate(t *testing.T) {
+	cfg := Config{
+		Health: &health.Config{
+			Targets:  []string{"https://a.example.com", "https://b.example.com"},
+			Interval: time.Second,
+			Timeout:  time.Second,
+		},
+		Latency: &latency.Config{
+			Targets:  []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"},
+			Interval: time.Second,
+			Timeout:  time.Second,
+		},
+		Traceroute: &traceroute.Config{
+			Targets:  []traceroute.Target{{Addr: "a.example.com", Port: 443}, {Addr: "b.example.com", Port: 443}},
+			Interval: time.Second,
+			Timeout:  time.Second,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		dependencies []Dependency
+		results      map[string]checks.Result
+		wantLatency  []string
+		wantTrace    []traceroute.Target
+	}{
+		{
+			name:        "no dependencies",
+			wantLatency: cfg.Latency.Targets,
+			wantTrace:   cfg.Traceroute.Targets,
+		},
+		{
+			name: "healthy condition without results",
+			dependencies: []Dependency{
+				{Check: latency.CheckName, DependsOn: health.CheckName, Condition: ConditionHealthy},
+			},
+			wantLatency: cfg.Latency.Targets,
+			wantTrace:   cfg.Traceroute.Targets,
+		},
+		{
+			name: "healthy condition skips unhealthy targets",
+			dependencies: []Dependency{
+				{Check: latency.CheckName, DependsOn: health.CheckName, Condition: ConditionHealthy},
+			},
+			results: map[string]checks.Result{
+				health.CheckName: {Data: map[string]string{
+					"https://a.example.com": "healthy",
+					"https://b.example.com": "unhealthy",
+				}},
+			},
+			wantLatency: []string{"https://a.example.com", "https://c.example.com"},
+			wantTrace:   cfg.Traceroute.Targets,
+		},
+		{
+			name: "latencyAbove condition without results",
+			dependencies: []Dependency{
+				{Check: traceroute.CheckName, DependsOn: latency.CheckName, Condition: ConditionLatencyAbove, Threshold: time.Second},
+			},
+			wantLatency: cfg.Latency.Targets,
+			wantTrace:   nil,
+		},
+		{
+			name: "latencyAbove condition only keeps slow targets",
+			dependencies: []Dependency{
+				{Check: traceroute.CheckName, DependsOn: latency.CheckName, Condition: ConditionLatencyAbove, Threshold: time.Second},
+			},
+			results: map[string]checks.Result{
+				latency.CheckName: {Data: map[string]checks.TargetState{
+					"https://a.example.com": {Healthy: true, Latency: 100 * time.Millisecond},
+					"https://b.example.com": {Healthy: true, Latency: 2 * time.Second},
+				}},
+			},
+			wantLatency: cfg.Latency.Targets,
+			wantTrace:   []traceroute.Target{{Addr: "b.example.com", Port: 443}},
+		},
+	}
+
+	// The result data of the latency check is unexported, so the target states are passed directly
+	orig := targetStates[latency.CheckName]
+	targetStates[latency.CheckName] = func(data any) map[string]checks.TargetState {
+		return data.(map[string]checks.TargetState)
+	}
+	t.Cleanup(func() { targetStates[latency.CheckName] = orig })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cfg
+			c.Dependencies = tt.dependencies
+
+			got := c.Gate(tt.results)
+			if !reflect.DeepEqual(got.Latency.Targets, tt.wantLatency) {
+				t.Errorf("Config.Gate() latency targets = %v, want %v", got.Latency.Targets, tt.wantLatency)
+			}
+			if !reflect.DeepEqual(got.Traceroute.Targets, tt.wantTrace) {
+				t.Errorf("Config.Gate() traceroute targets = %v, want %v", got.Traceroute.Targets, tt.wantTrace)
+			}
+			if len(cfg.Latency.Targets) != 3 || len(cfg.Traceroute.Targets) != 2 {
+				t.Error("Config.Gate() modified the original configuration")
+			}
+		})
+	}
+}
+
+func TestConfig_SelectTargets(t *testing.T) {
+	cfg := Config{
+		Latency: &latency.Config{
+			Targets:  []string{"https://a.example.com", "https://b.example.com"},
+			Interval: time.Second,
+			Timeout:  time.Second,
+		},
+		Traceroute: &traceroute.Config{
+			Targets:  []traceroute.Target{{Addr: "a.example.com", Port: 443}, {Addr: "b.example.com", Port: 443}},
+			Interval: time.Second,
+			Timeout:  time.Second,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		check       string
+		targets     []string
+		wantLatency []string
+		wantTrace   []traceroute.Target
+		wantUnknown []string
+	}{
+		{
+			name:        "selects the targets of the check",
+			check:       latency.CheckName,
+			targets:     []string{"https://b.example.com"},
+			wantLatency: []string{"https://b.example.com"},
+			wantTrace:   cfg.Traceroute.Targets,
+		},
+		{
+			name:        "selects traceroute targets by address",
+			check:       traceroute.CheckName,
+			targets:     []string{"a.example.com"},
+			wantLatency: cfg.Latency.Targets,
+			wantTrace:   []traceroute.Target{{Addr: "a.example.com", Port: 443}},
+		},
+		{
+			name:        "reports unknown targets",
+			check:       latency.CheckName,
+			targets:     []string{"https://a.example.com", "https://c.example.com"},
+			wantLatency: []string{"https://a.example.com"},
+			wantTrace:   cfg.Traceroute.Targets,
+			wantUnknown: []string{"https://c.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unknown := cfg.SelectTargets(tt.check, tt.targets)
+			if !reflect.DeepEqual(got.Latency.Targets, tt.wantLatency) {
+				t.Errorf("Config.SelectTargets() latency targets = %v, want %v", got.Latency.Targets, tt.wantLatency)
+			}
+			if !reflect.DeepEqual(got.Traceroute.Targets, tt.wantTrace) {
+				t.Errorf("Config.SelectTargets() traceroute targets = %v, want %v", got.Traceroute.Targets, tt.wantTrace)
+			}
+			if !reflect.DeepEqual(unknown, tt.wantUnknown) {
+				t.Errorf("Config.SelectTargets() unknown = %v, want %v", unknown, tt.wantUnknown)
+			}
+			if len(cfg.Latency.Targets) != 2 || len(cfg.Traceroute.Targets) != 2 {
+				t.Error("Config.SelectTargets() modified the original configuration")
+			}
+		})
+	}
+}
+
+func TestDependency_Validate(t *testing.T) {
+	cfg := Config{
+		Health:  &health.Config{},
+		Latency: &latency.Config{},
+		Traceroute: &traceroute.Config{
+			Interval: time.Second,
+			Timeout:  time.Second,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		dep     Dependency
+		wantErr bool
+	}{
+		{
+			name: "valid healthy condition",
+			dep:  Dependency{Check: latency.CheckName, DependsOn: health.CheckName, Condition: ConditionHealthy},
+		},
+		{
+			name: "valid latencyAbove condition",
+			dep:  Dependency{Check: traceroute.CheckName, DependsOn: latency.CheckName, Condition: ConditionLatencyAbove, Threshold: time.Second},
+		},
+		{
+			name:    "check not configured",
+			dep:     Dependency{Check: "dns", DependsOn: health.CheckName, Condition: ConditionHealthy},
+			wantErr: true,
+		},
+		{
+			name:    "dependency not configured",
+			dep:     Dependency{Check: latency.CheckName, DependsOn: "dns", Condition: ConditionHealthy},
+			wantErr: true,
+		},
+		{
+			name:    "self dependency",
+			dep:     Dependency{Check: health.CheckName, DependsOn: health.CheckName, Condition: ConditionHealthy},
+			wantErr: true,
+		},
+		{
+			name:    "dependency without target states",
+			dep:     Dependency{Check: latency.CheckName, DependsOn: traceroute.CheckName, Condition: ConditionHealthy},
+			wantErr: true,
+		},
+		{
+			name:    "missing threshold",
+			dep:     Dependency{Check: traceroute.CheckName, DependsOn: latency.CheckName, Condition: ConditionLatencyAbove},
+			wantErr: true,
+		},
+		{
+			name:    "unknown condition",
+			dep:     Dependency{Check: latency.CheckName, DependsOn: health.CheckName, Condition: "sometimes"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.dep.Validate(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("Dependency.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// ConfigDiff describes the changes between two runtime configurations
+type ConfigDiff struct {
+	// Added are the names of the checks added to the configuration
+	Added []string `json:"added,omitempty"`
+	// Removed are the names of the checks removed from the configuration
+	Removed []string `json:"removed,omitempty"`
+	// Changed are the changes of the checks configured in both configurations
+	Changed []CheckDiff `json:"changed,omitempty"`
+	// Settings are the changed settings applying to all checks, e.g. the dependencies
+	Settings []string `json:"settings,omitempty"`
+}
+
+// CheckDiff describes the changes of the configuration of a single check
+type CheckDiff struct {
+	// Check is the name of the check
+	Check string `json:"check"`
+	// AddedTargets are the targets added to the check
+	AddedTargets []string `json:"addedTargets,omitempty"`
+	// RemovedTargets are the targets removed from the check
+	RemovedTargets []string `json:"removedTargets,omitempty"`
+	// Interval is the change of the interval of the check
+	Interval *IntervalChange `json:"interval,omitempty"`
+	// Fields are the names of the other changed fields
+	Fields []string `json:"fields,omitempty"`
+}
+
+// IntervalChange describes the change of the interval of a check
+type IntervalChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// Empty returns true if the configurations are equal
+func (d ConfigDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 && len(d.Settings) == 0
+}
+
+// Diff returns the changes from the given previous configuration to this configuration
+func (c Config) Diff(prev Config) ConfigDiff {
+	var diff ConfigDiff
+	for _, cfg := range c.Iter() {
+		old := prev.For(cfg.For())
+		if old == nil {
+			diff.Added = append(diff.Added, cfg.For())
+			continue
+		}
+		if cd := diffCheck(old, cfg); cd != nil {
+			diff.Changed = append(diff.Changed, *cd)
+		}
+	}
+	for _, cfg := range prev.Iter() {
+		if c.For(cfg.For()) == nil {
+			diff.Removed = append(diff.Removed, cfg.For())
+		}
+	}
+
+	if !reflect.DeepEqual(c.Dependencies, prev.Dependencies) && (len(c.Dependencies) > 0 || len(prev.Dependencies) > 0) {
+		diff.Settings = append(diff.Settings, "dependencies")
+	}
+	if c.MaxSeries != prev.MaxSeries {
+		diff.Settings = append(diff.Settings, "maxSeries")
+	}
+	if c.Watchdog != prev.Watchdog {
+		diff.Settings = append(diff.Settings, "watchdog")
+	}
+	return diff
+}
+
+// diffCheck returns the changes of the configuration of a check
+// based on its JSON representation or nil if it didn't change
+func diffCheck(old, cur checks.Runtime) *CheckDiff {
+	oldFields, err := fields(old)
+	if err != nil {
+		return &CheckDiff{Check: cur.For(), Fields: []string{"*"}}
+	}
+	curFields, err := fields(cur)
+	if err != nil {
+		return &CheckDiff{Check: cur.For(), Fields: []string{"*"}}
+	}
+
+	cd := CheckDiff{Check: cur.For()}
+	for name := range union(oldFields, curFields) {
+		if bytes.Equal(oldFields[name], curFields[name]) {
+			continue
+		}
+		switch name {
+		case "targets":
+			oldTargets, curTargets := targets(oldFields[name]), targets(curFields[name])
+			for _, t := range curTargets {
+				if !slices.Contains(oldTargets, t) {
+					cd.AddedTargets = append(cd.AddedTargets, t)
+				}
+			}
+			for _, t := range oldTargets {
+				if !slices.Contains(curTargets, t) {
+					cd.RemovedTargets = append(cd.RemovedTargets, t)
+				}
+			}
+		case "interval":
+			cd.Interval = &IntervalChange{
+				Old: duration(oldFields[name]).String(),
+				New: duration(curFields[name]).String(),
+			}
+		default:
+			cd.Fields = append(cd.Fields, name)
+		}
+	}
+	slices.Sort(cd.Fields)
+
+	if len(cd.AddedTargets) == 0 && len(cd.RemovedTargets) == 0 && cd.Interval == nil && len(cd.Fields) == 0 {
+		return nil
+	}
+	return &cd
+}
+
+// fields returns the JSON representation of every field of the check configuration
+func fields(cfg checks.Runtime) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var f map[string]json.RawMessage
+	err = json.Unmarshal(b, &f)
+	return f, err
+}
+
+// union returns the set of the keys of both maps
+func union(a, b map[string]json.RawMessage) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
+
+// targets returns the targets of a check as strings.
+// Targets that aren't strings, e.g. the traceroute targets, are returned as JSON.
+func targets(raw json.RawMessage) []string {
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+	targets := make([]string, 0, len(list))
+	for _, t := range list {
+		var s string
+		if err := json.Unmarshal(t, &s); err == nil {
+			targets = append(targets, s)
+			continue
+		}
+		targets = append(targets, string(t))
+	}
+	return targets
+}
+
+// duration returns the duration encoded in the JSON representation of a check
+func duration(raw json.RawMessage) time.Duration {
+	var d time.Duration
+	_ = json.Unmarshal(raw, &d)
+	return d
+}
@@ -0,0 +1,109 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+)
+
+func TestConfig_Diff(t *testing.T) {
+	healthCfg := func(interval time.Duration, targets ...string) *health.Config {
+		return &health.Config{Targets: targets, Interval: interval, Timeout: time.Second}
+	}
+
+	tests := []struct {
+		name string
+		prev Config
+		cur  Config
+		want ConfigDiff
+	}{
+		{
+			name: "unchanged",
+			prev: Config{Health: healthCfg(time.Second, "https://a.com")},
+			cur:  Config{Health: healthCfg(time.Second, "https://a.com")},
+			want: ConfigDiff{},
+		},
+		{
+			name: "added and removed checks",
+			prev: Config{Health: healthCfg(time.Second, "https://a.com")},
+			cur: Config{
+				Latency: &latency.Config{Targets: []string{"https://a.com"}, Interval: time.Second},
+				Dns:     &dns.Config{Targets: []string{"a.com"}, Interval: time.Second},
+			},
+			want: ConfigDiff{Added: []string{latency.CheckName, dns.CheckName}, Removed: []string{health.CheckName}},
+		},
+		{
+			name: "changed targets and interval",
+			prev: Config{Health: healthCfg(time.Second, "https://a.com", "https://b.com")},
+			cur:  Config{Health: healthCfg(time.Minute, "https://b.com", "https://c.com")},
+			want: ConfigDiff{Changed: []CheckDiff{{
+				Check:          health.CheckName,
+				AddedTargets:   []string{"https://c.com"},
+				RemovedTargets: []string{"https://a.com"},
+				Interval:       &IntervalChange{Old: "1s", New: "1m0s"},
+			}}},
+		},
+		{
+			name: "changed other fields",
+			prev: Config{Health: healthCfg(time.Second, "https://a.com")},
+			cur:  Config{Health: &health.Config{Targets: []string{"https://a.com"}, Interval: time.Second, Timeout: time.Minute, Schedule: "0 * * * *"}},
+			want: ConfigDiff{Changed: []CheckDiff{{Check: health.CheckName, Fields: []string{"schedule", "timeout"}}}},
+		},
+		{
+			name: "changed structured targets",
+			prev: Config{Traceroute: &traceroute.Config{Targets: []traceroute.Target{{Addr: "a.com", Port: 80}}, Interval: time.Second}},
+			cur:  Config{Traceroute: &traceroute.Config{Targets: []traceroute.Target{{Addr: "a.com", Port: 443}}, Interval: time.Second}},
+			want: ConfigDiff{Changed: []CheckDiff{{
+				Check:          traceroute.CheckName,
+				AddedTargets:   []string{`{"addr":"a.com","port":443}`},
+				RemovedTargets: []string{`{"addr":"a.com","port":80}`},
+			}}},
+		},
+		{
+			name: "changed settings",
+			prev: Config{Health: healthCfg(time.Second, "https://a.com")},
+			cur: Config{
+				Health:       healthCfg(time.Second, "https://a.com"),
+				Latency:      &latency.Config{Targets: []string{"https://a.com"}, Interval: time.Second},
+				Dependencies: []Dependency{{Check: latency.CheckName, DependsOn: health.CheckName, Condition: ConditionHealthy}},
+				MaxSeries:    10,
+			},
+			want: ConfigDiff{Added: []string{latency.CheckName}, Settings: []string{"dependencies", "maxSeries"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cur.Diff(tt.prev)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Config.Diff() = %+v, want %+v", got, tt.want)
+			}
+			if got.Empty() != reflect.DeepEqual(tt.want, ConfigDiff{}) {
+				t.Errorf("ConfigDiff.Empty() = %v", got.Empty())
+			}
+		})
+	}
+}
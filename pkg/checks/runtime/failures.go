@@ -0,0 +1,53 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/httpheaders"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/ntp"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/caas-team/sparrow/pkg/checks/zone"
+)
+
+// targetFailures maps the names of the checks to the function
+// reporting the failed targets of their results
+var targetFailures = map[string]func(data any) map[string]bool{
+	health.CheckName:      health.Failures,
+	latency.CheckName:     latency.Failures,
+	dns.CheckName:         dns.Failures,
+	traceroute.CheckName:  traceroute.Failures,
+	pmtu.CheckName:        pmtu.Failures,
+	ntp.CheckName:         ntp.Failures,
+	zone.CheckName:        zone.Failures,
+	httpheaders.CheckName: httpheaders.Failures,
+}
+
+// TargetFailures reports for every target in the result data of the check with the given name whether it failed.
+// It returns nil if the check is unknown or the data isn't a result of the check.
+func TargetFailures(check string, data any) map[string]bool {
+	failures, ok := targetFailures[check]
+	if !ok {
+		return nil
+	}
+	return failures(data)
+}
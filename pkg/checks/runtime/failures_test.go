@@ -0,0 +1,62 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks/health"
+)
+
+func TestTargetFailures(t *testing.T) {
+	tests := []struct {
+		name  string
+		check string
+		data  any
+		want  map[string]bool
+	}{
+		{
+			name:  "health result",
+			check: health.CheckName,
+			data:  map[string]string{"https://a.com": "healthy", "https://b.com": "unhealthy"},
+			want:  map[string]bool{"https://a.com": false, "https://b.com": true},
+		},
+		{
+			name:  "unknown check",
+			check: "unknown",
+			data:  map[string]string{"https://a.com": "healthy"},
+			want:  nil,
+		},
+		{
+			name:  "data of another check",
+			check: health.CheckName,
+			data:  map[string]int{"https://a.com": 1},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TargetFailures(tt.check, tt.data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TargetFailures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
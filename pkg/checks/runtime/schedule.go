@@ -0,0 +1,48 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// NextRun returns the time the check with the given configuration runs next after
+// the run at the given time. It's derived from the check's schedule or its interval,
+// which is shortened if the adaptive interval is enabled. It returns false if the
+// configuration has neither a schedule nor an interval.
+func NextRun(cfg checks.Runtime, last time.Time) (time.Time, bool) {
+	f, err := fields(cfg)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var schedule checks.Schedule
+	_ = json.Unmarshal(f["schedule"], &schedule)
+	var adaptive checks.AdaptiveInterval
+	_ = json.Unmarshal(f["adaptive"], &adaptive)
+
+	interval := adaptive.Next(duration(f["interval"]))
+	if !schedule.Enabled() && interval <= 0 {
+		return time.Time{}, false
+	}
+	return last.Add(schedule.Next(last, interval)), true
+}
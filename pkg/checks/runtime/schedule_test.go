@@ -0,0 +1,74 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+)
+
+func TestNextRun(t *testing.T) {
+	last := time.Date(2024, 7, 26, 15, 0, 30, 0, time.UTC)
+	tests := []struct {
+		name   string
+		cfg    checks.Runtime
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "interval",
+			cfg:    &health.Config{Interval: time.Minute},
+			want:   last.Add(time.Minute),
+			wantOk: true,
+		},
+		{
+			name:   "adaptive interval",
+			cfg:    &health.Config{Interval: time.Minute, Adaptive: checks.AdaptiveInterval{Interval: 10 * time.Second, Successes: 3}},
+			want:   last.Add(10 * time.Second),
+			wantOk: true,
+		},
+		{
+			name:   "schedule",
+			cfg:    &dns.Config{Schedule: "CRON_TZ=UTC 0 * * * *"},
+			want:   time.Date(2024, 7, 26, 16, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "neither interval nor schedule",
+			cfg:    &dns.Config{},
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NextRun(tt.cfg, last)
+			if ok != tt.wantOk {
+				t.Fatalf("NextRun() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
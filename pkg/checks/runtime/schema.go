@@ -0,0 +1,49 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/httpheaders"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/ntp"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/caas-team/sparrow/pkg/checks/zone"
+)
+
+// schemaVersions maps the names of the checks to the version of the layout of their result data
+var schemaVersions = map[string]int{
+	health.CheckName:      health.SchemaVersion,
+	latency.CheckName:     latency.SchemaVersion,
+	dns.CheckName:         dns.SchemaVersion,
+	traceroute.CheckName:  traceroute.SchemaVersion,
+	pmtu.CheckName:        pmtu.SchemaVersion,
+	ntp.CheckName:         ntp.SchemaVersion,
+	zone.CheckName:        zone.SchemaVersion,
+	httpheaders.CheckName: httpheaders.SchemaVersion,
+}
+
+// SchemaVersion returns the version of the layout of the result data of the check with the given name.
+// It returns false if the check is unknown.
+func SchemaVersion(check string) (int, bool) {
+	v, ok := schemaVersions[check]
+	return v, ok
+}
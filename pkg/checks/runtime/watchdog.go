@@ -0,0 +1,91 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// WatchdogPolicy is what the watchdog does with a check whose iteration hangs
+type WatchdogPolicy string
+
+const (
+	// WatchdogRestart cancels the hanging iteration and restarts the check
+	WatchdogRestart WatchdogPolicy = "restart"
+	// WatchdogStop cancels the hanging iteration and stops the check until its configuration changes
+	WatchdogStop WatchdogPolicy = "stop"
+)
+
+// Watchdog configures the cancellation of check iterations
+// that exceed a multiple of the timeout of their check
+type Watchdog struct {
+	// Factor is the multiple of a check's timeout an iteration may take
+	// after its scheduled start before it's canceled. 0 disables the watchdog.
+	Factor int `yaml:"factor,omitempty" json:"factor,omitempty"`
+	// Policy is what happens to the check after its iteration was canceled. Defaults to restart.
+	Policy WatchdogPolicy `yaml:"policy,omitempty" json:"policy,omitempty"`
+}
+
+// Enabled returns true if the iterations of the checks are watched
+func (w Watchdog) Enabled() bool {
+	return w.Factor > 0
+}
+
+// Validate checks if the watchdog configuration is valid
+func (w Watchdog) Validate() error {
+	if w.Factor < 0 {
+		return errors.New(`invalid configuration field "watchdog.factor": must not be negative`)
+	}
+	switch w.Policy {
+	case "", WatchdogRestart, WatchdogStop:
+		return nil
+	default:
+		return fmt.Errorf(`invalid configuration field "watchdog.policy": unknown policy %q`, w.Policy)
+	}
+}
+
+// Stops returns true if a check with a canceled iteration is stopped instead of restarted
+func (w Watchdog) Stops() bool {
+	return w.Policy == WatchdogStop
+}
+
+// Deadline returns the time by which the iteration of the check with the given configuration
+// following the run at the given time has to finish. It returns false if the configuration
+// has no timeout or no next run can be derived from it.
+func (w Watchdog) Deadline(cfg checks.Runtime, last time.Time) (time.Time, bool) {
+	next, ok := NextRun(cfg, last)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	f, err := fields(cfg)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var timeout time.Duration
+	if err = json.Unmarshal(f["timeout"], &timeout); err != nil || timeout <= 0 {
+		return time.Time{}, false
+	}
+	return next.Add(time.Duration(w.Factor) * timeout), true
+}
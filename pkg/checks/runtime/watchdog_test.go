@@ -0,0 +1,85 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+)
+
+func TestWatchdog_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		watchdog Watchdog
+		wantErr  bool
+	}{
+		{name: "disabled", watchdog: Watchdog{}},
+		{name: "default policy", watchdog: Watchdog{Factor: 3}},
+		{name: "restart policy", watchdog: Watchdog{Factor: 3, Policy: WatchdogRestart}},
+		{name: "stop policy", watchdog: Watchdog{Factor: 3, Policy: WatchdogStop}},
+		{name: "negative factor", watchdog: Watchdog{Factor: -1}, wantErr: true},
+		{name: "unknown policy", watchdog: Watchdog{Factor: 3, Policy: "ignore"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.watchdog.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Watchdog.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWatchdog_Deadline(t *testing.T) {
+	last := time.Date(2024, 7, 26, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		cfg    checks.Runtime
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "interval and timeout",
+			cfg:    &health.Config{Interval: time.Minute, Timeout: 10 * time.Second},
+			want:   last.Add(time.Minute + 30*time.Second),
+			wantOk: true,
+		},
+		{
+			name: "no timeout",
+			cfg:  &health.Config{Interval: time.Minute},
+		},
+		{
+			name: "no interval",
+			cfg:  &health.Config{Timeout: 10 * time.Second},
+		},
+	}
+
+	w := Watchdog{Factor: 3}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := w.Deadline(tt.cfg, last)
+			if ok != tt.wantOk || !got.Equal(tt.want) {
+				t.Errorf("Watchdog.Deadline() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedule is a cron expression defining when a check runs instead of its fixed interval,
+// e.g. "0 2 * * *" to run the check at 2am every night. It supports the standard five fields
+// (minute, hour, day of month, month, day of week), descriptors like "@hourly" and a
+// "CRON_TZ=Europe/Berlin" prefix to use a time zone other than the local one.
+type Schedule string
+
+// Enabled returns true if a schedule is configured
+func (s Schedule) Enabled() bool {
+	return s != ""
+}
+
+// Validate checks if the schedule is a valid cron expression
+func (s Schedule) Validate(checkName string) error {
+	if !s.Enabled() {
+		return nil
+	}
+	if _, err := cron.ParseStandard(string(s)); err != nil {
+		return ErrInvalidConfig{CheckName: checkName, Field: "schedule", Reason: err.Error()}
+	}
+	return nil
+}
+
+// Next returns the time to wait from now until the next check run.
+// Without a schedule the check runs after the given interval.
+func (s Schedule) Next(now time.Time, interval time.Duration) time.Duration {
+	if !s.Enabled() {
+		return interval
+	}
+	sched, err := cron.ParseStandard(string(s))
+	if err != nil {
+		// The schedule is validated before, so this is a fallback only
+		return interval
+	}
+	return sched.Next(now).Sub(now)
+}
@@ -0,0 +1,76 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		wantErr  bool
+	}{
+		{name: "no schedule", schedule: ""},
+		{name: "standard expression", schedule: "0 2 * * *"},
+		{name: "descriptor", schedule: "@hourly"},
+		{name: "time zone", schedule: "CRON_TZ=Europe/Berlin 0 2 * * 1-5"},
+		{name: "too few fields", schedule: "0 2 * *", wantErr: true},
+		{name: "invalid hour", schedule: "0 25 * * *", wantErr: true},
+		{name: "unknown descriptor", schedule: "@sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate("test")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if e, ok := err.(ErrInvalidConfig); !ok || e.Field != "schedule" {
+					t.Errorf("Validate() error = %#v, want invalid schedule", err)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 30, 0, 0, time.Local)
+	tests := []struct {
+		name     string
+		schedule Schedule
+		want     time.Duration
+	}{
+		{name: "no schedule", schedule: "", want: time.Minute},
+		{name: "hourly", schedule: "@hourly", want: 30 * time.Minute},
+		{name: "nightly", schedule: "0 2 * * *", want: 13*time.Hour + 30*time.Minute},
+		{name: "invalid schedule falls back to the interval", schedule: "invalid", want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.Next(now, time.Minute); got != tt.want {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
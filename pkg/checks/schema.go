@@ -0,0 +1,38 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+// LegacySchemaVersion is the version of the layout of result data
+// reported before the results were versioned
+const LegacySchemaVersion = 1
+
+// Version returns the version of the layout of the result's data.
+// Results without a version are reported as LegacySchemaVersion.
+func (r *Result) Version() int {
+	if r.SchemaVersion <= 0 {
+		return LegacySchemaVersion
+	}
+	return r.SchemaVersion
+}
+
+// Compatible returns true if the result's data has the layout of the given version.
+// Versions are only incremented on breaking changes, so a result is compatible with its own version only.
+func (r *Result) Compatible(version int) bool {
+	return r.Version() == version
+}
@@ -0,0 +1,43 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import "testing"
+
+func TestResult_Compatible(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  Result
+		version int
+		want    bool
+	}{
+		{name: "same version", result: Result{SchemaVersion: 2}, version: 2, want: true},
+		{name: "older version", result: Result{SchemaVersion: 1}, version: 2, want: false},
+		{name: "newer version", result: Result{SchemaVersion: 3}, version: 2, want: false},
+		{name: "unversioned result is legacy", result: Result{}, version: LegacySchemaVersion, want: true},
+		{name: "unversioned result is not current", result: Result{}, version: 2, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.Compatible(tt.version); got != tt.want {
+				t.Errorf("Compatible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// OverflowLabel is the target label of the metric series
+// aggregating the targets exceeding the series limit of a check
+const OverflowLabel = "other"
+
+// SeriesLimited is implemented by checks capping the number of targets
+// they register labelled metric series for
+type SeriesLimited interface {
+	// SetSeriesLimit sets the maximum number of targets with their own metric series.
+	// The overflow gauge is set to the number of targets aggregated into the overflow series.
+	SetSeriesLimit(limit int, overflow prometheus.Gauge)
+}
+
+// SeriesPruner is implemented by checks removing the metric series
+// of targets that are no longer configured
+type SeriesPruner interface {
+	// PruneSeries removes the metric series of all targets that are no longer configured
+	PruneSeries() error
+}
+
+var (
+	_ SeriesLimited = (*SeriesLimit)(nil)
+	_ SeriesPruner  = (*SeriesLimit)(nil)
+)
+
+// SeriesLimit caps the number of targets a check registers labelled metric series for.
+// Targets exceeding the limit share the series labelled with [OverflowLabel], so gauges
+// hold the value of the last written target and counters and histograms aggregate them.
+// Targets keep their label until they are released, so lowering the limit only
+// affects targets seen afterwards. The zero value doesn't limit the series.
+//
+// The metric series of the targets are written with [SeriesLimit.WithSeries] and released with
+// [SeriesLimit.SetTargets] when the configuration changes. Both are serialized, so a probe finishing
+// after its target was removed doesn't recreate the released series.
+type SeriesLimit struct {
+	mu sync.Mutex
+	// limit is the maximum number of targets with their own series. 0 means no limit.
+	limit int
+	// targets are the targets with their own series
+	targets map[string]struct{}
+	// overflow are the targets aggregated into the overflow series
+	overflow map[string]struct{}
+	// gauge reports the number of targets aggregated into the overflow series
+	gauge prometheus.Gauge
+	// configured are the targets of the current configuration.
+	// Every target is accepted as long as SetTargets wasn't called.
+	configured map[string]struct{}
+	// remove removes the metric series with the given label
+	remove func(label string) error
+}
+
+// SetSeriesLimit sets the maximum number of targets with their own metric series
+func (s *SeriesLimit) SetSeriesLimit(limit int, overflow prometheus.Gauge) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = limit
+	s.gauge = overflow
+	s.report()
+}
+
+// SeriesLabel returns the target label of the metric series of the target
+func (s *SeriesLimit) SeriesLabel(target string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.label(target)
+}
+
+// WithSeries calls set with the target label of the metric series of the target.
+// It's skipped if the target is no longer configured, e.g. because the configuration
+// changed while the target was probed.
+func (s *SeriesLimit) WithSeries(target string, set func(label string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.configured != nil {
+		if _, ok := s.configured[target]; !ok {
+			return
+		}
+	}
+	set(s.label(target))
+}
+
+// SetTargets sets the targets of the current configuration and releases the metric series
+// of all targets that are no longer configured. Targets removed before they were probed
+// have no series to release. Remove is kept to prune the series later on.
+func (s *SeriesLimit) SetTargets(targets []string, remove func(label string) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configured = make(map[string]struct{}, len(targets))
+	for _, t := range targets {
+		s.configured[t] = struct{}{}
+	}
+	s.remove = remove
+	return s.prune()
+}
+
+// PruneSeries removes the metric series of all targets that are no longer configured.
+// It's a no-op as long as the targets weren't set.
+func (s *SeriesLimit) PruneSeries() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prune()
+}
+
+// prune releases the metric series of all targets with a series that are no longer configured
+func (s *SeriesLimit) prune() error {
+	if s.configured == nil || s.remove == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, set := range []map[string]struct{}{s.targets, s.overflow} {
+		for t := range set {
+			if _, ok := s.configured[t]; !ok {
+				errs = append(errs, s.release(t, s.remove))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// label returns the target label of the metric series of the target
+func (s *SeriesLimit) label(target string) string {
+	if s.targets == nil {
+		s.targets = map[string]struct{}{}
+		s.overflow = map[string]struct{}{}
+	}
+
+	if _, ok := s.targets[target]; ok {
+		return target
+	}
+	if _, ok := s.overflow[target]; ok {
+		return OverflowLabel
+	}
+	if s.limit <= 0 || len(s.targets) < s.limit {
+		s.targets[target] = struct{}{}
+		return target
+	}
+	s.overflow[target] = struct{}{}
+	s.report()
+	return OverflowLabel
+}
+
+// RemoveSeries releases the metric series of the target and calls remove with
+// the label of the series if no other target uses it anymore
+func (s *SeriesLimit) RemoveSeries(target string, remove func(label string) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.release(target, remove)
+}
+
+// release releases the metric series of the target and calls remove with
+// the label of the series if no other target uses it anymore
+func (s *SeriesLimit) release(target string, remove func(label string) error) error {
+	label := target
+	if _, ok := s.overflow[target]; ok {
+		delete(s.overflow, target)
+		s.report()
+		label = OverflowLabel
+		if len(s.overflow) > 0 {
+			return nil
+		}
+	}
+	delete(s.targets, target)
+	return remove(label)
+}
+
+// report sets the overflow gauge to the number of targets aggregated into the overflow series
+func (s *SeriesLimit) report() {
+	if s.gauge != nil {
+		s.gauge.Set(float64(len(s.overflow)))
+	}
+}
+
+// BoolToFloat returns 1 if b is true and 0 otherwise, e.g. to set a gauge from a state
+func BoolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
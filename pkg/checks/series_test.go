@@ -0,0 +1,147 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeriesLimit(t *testing.T) {
+	t.Run("no limit", func(t *testing.T) {
+		var s SeriesLimit
+		for _, target := range []string{"a", "b", "c"} {
+			assert.Equal(t, target, s.SeriesLabel(target))
+		}
+	})
+
+	t.Run("targets exceeding the limit share the overflow series", func(t *testing.T) {
+		var s SeriesLimit
+		gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "overflow"})
+		s.SetSeriesLimit(2, gauge)
+
+		assert.Equal(t, "a", s.SeriesLabel("a"))
+		assert.Equal(t, "b", s.SeriesLabel("b"))
+		assert.Equal(t, OverflowLabel, s.SeriesLabel("c"))
+		assert.Equal(t, OverflowLabel, s.SeriesLabel("d"))
+		assert.Equal(t, "a", s.SeriesLabel("a"))
+		assert.Equal(t, 2.0, gaugeValue(t, gauge))
+
+		// The overflow series is kept as long as other targets use it
+		var removed []string
+		remove := func(label string) error {
+			removed = append(removed, label)
+			return nil
+		}
+		assert.NoError(t, s.RemoveSeries("c", remove))
+		assert.Empty(t, removed)
+		assert.Equal(t, 1.0, gaugeValue(t, gauge))
+
+		assert.NoError(t, s.RemoveSeries("d", remove))
+		assert.Equal(t, []string{OverflowLabel}, removed)
+		assert.Equal(t, 0.0, gaugeValue(t, gauge))
+
+		// A released slot is used by the next target
+		assert.NoError(t, s.RemoveSeries("a", remove))
+		assert.Equal(t, []string{OverflowLabel, "a"}, removed)
+		assert.Equal(t, "e", s.SeriesLabel("e"))
+		assert.Equal(t, OverflowLabel, s.SeriesLabel("f"))
+	})
+
+	t.Run("lowering the limit keeps the series of known targets", func(t *testing.T) {
+		var s SeriesLimit
+		assert.Equal(t, "a", s.SeriesLabel("a"))
+		assert.Equal(t, "b", s.SeriesLabel("b"))
+
+		s.SetSeriesLimit(1, nil)
+		assert.Equal(t, "a", s.SeriesLabel("a"))
+		assert.Equal(t, "b", s.SeriesLabel("b"))
+		assert.Equal(t, OverflowLabel, s.SeriesLabel("c"))
+	})
+
+	t.Run("targets no longer configured aren't written", func(t *testing.T) {
+		var s SeriesLimit
+		var removed, written []string
+		remove := func(label string) error {
+			removed = append(removed, label)
+			return nil
+		}
+		set := func(label string) {
+			written = append(written, label)
+		}
+
+		assert.NoError(t, s.SetTargets([]string{"a", "b"}, remove))
+		s.WithSeries("a", set)
+		s.WithSeries("b", set)
+		assert.Equal(t, []string{"a", "b"}, written)
+
+		// The probe of b finishes after b was removed
+		assert.NoError(t, s.SetTargets([]string{"a"}, remove))
+		assert.Equal(t, []string{"b"}, removed)
+		s.WithSeries("b", set)
+		assert.Equal(t, []string{"a", "b"}, written)
+	})
+
+	t.Run("series of targets no longer configured are pruned", func(t *testing.T) {
+		var s SeriesLimit
+		var removed []string
+		remove := func(label string) error {
+			removed = append(removed, label)
+			return nil
+		}
+
+		// Nothing is pruned as long as the targets weren't set
+		assert.Equal(t, "a", s.SeriesLabel("a"))
+		assert.Equal(t, "b", s.SeriesLabel("b"))
+		assert.NoError(t, s.PruneSeries())
+		assert.Empty(t, removed)
+
+		assert.NoError(t, s.SetTargets([]string{"a"}, remove))
+		assert.Equal(t, []string{"b"}, removed)
+
+		// A series written without checking the configuration is orphaned until it's pruned
+		assert.Equal(t, "c", s.SeriesLabel("c"))
+		assert.NoError(t, s.PruneSeries())
+		assert.Equal(t, []string{"b", "c"}, removed)
+		assert.Equal(t, "a", s.SeriesLabel("a"))
+	})
+
+	t.Run("targets removed before they were probed have no series", func(t *testing.T) {
+		var s SeriesLimit
+		remove := func(label string) error {
+			return ErrMetricNotFound{Label: label}
+		}
+
+		assert.NoError(t, s.SetTargets([]string{"a"}, remove))
+		assert.NoError(t, s.SetTargets([]string{"b"}, remove))
+	})
+}
+
+// gaugeValue returns the current value of a gauge
+func gaugeValue(t *testing.T, g prometheus.Gauge) float64 {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := g.Write(m); err != nil {
+		t.Fatalf("failed to read gauge: %v", err)
+	}
+	return m.GetGauge().GetValue()
+}
@@ -0,0 +1,170 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultPorts are the default ports of the URL schemes, which are omitted in normalized targets
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// GlobalTarget includes the basic information regarding
+// other Sparrow instances, which this Sparrow can communicate with.
+type GlobalTarget struct {
+	Url      string    `json:"url"`
+	LastSeen time.Time `json:"lastSeen"`
+	// Port is the port of the instance's API if it isn't the default port of the URL's scheme
+	Port int `json:"port,omitempty"`
+	// Checks are the names of the checks the instance runs. Empty if the instance doesn't advertise them.
+	Checks []string `json:"checks,omitempty"`
+	// Version is the sparrow version of the instance
+	Version string `json:"version,omitempty"`
+	// Unregistered is the time the instance unregistered gracefully on shutdown.
+	// The registration is a tombstone if it's set, the instance isn't a target anymore.
+	Unregistered *time.Time `json:"unregistered,omitempty"`
+	// Region is the region the instance declared it runs in, e.g. eu-de-1
+	Region string `json:"region,omitempty"`
+	// Labels are additional labels the instance declared, e.g. its provider or zone
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Tombstone returns true if the instance unregistered gracefully and only left a tombstone behind
+func (g *GlobalTarget) Tombstone() bool {
+	return g.Unregistered != nil
+}
+
+// URL returns the parsed URL of the instance's API including the advertised port
+func (g *GlobalTarget) URL() (*url.URL, error) {
+	u, err := url.Parse(g.Url)
+	if err != nil {
+		return nil, err
+	}
+	if g.Port > 0 && u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(g.Port))
+	}
+	return u, nil
+}
+
+// Runs returns true if the instance runs the given check.
+// Instances not advertising their checks are assumed to run every check.
+func (g *GlobalTarget) Runs(check string) bool {
+	return len(g.Checks) == 0 || slices.Contains(g.Checks, check)
+}
+
+// NormalizeTarget returns the normalized form of a target, which is either a URL, a host with a port or a plain host.
+// Different spellings of the same endpoint have the same normalized form: the scheme and host are case folded,
+// internationalized host names are converted to their ASCII form, and default ports and trailing slashes or dots are removed.
+func NormalizeTarget(target string) string {
+	if u, err := url.Parse(target); err == nil && u.Host != "" {
+		u.Scheme = strings.ToLower(u.Scheme)
+		host, port := normalizeHost(u.Hostname()), u.Port()
+		if port == defaultPorts[u.Scheme] {
+			port = ""
+		}
+		u.Host = host
+		if port != "" {
+			u.Host = net.JoinHostPort(host, port)
+		}
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		u.RawPath = ""
+		return u.String()
+	}
+
+	if host, port, err := net.SplitHostPort(target); err == nil {
+		return net.JoinHostPort(normalizeHost(host), port)
+	}
+	return normalizeHost(target)
+}
+
+// normalizeHost returns the case folded ASCII form of a host without a trailing dot
+func normalizeHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+		return ascii
+	}
+	return host
+}
+
+// HostForms returns the ASCII (punycode) and the unicode form of a host name, so an internationalized
+// domain name can be resolved in its ASCII form and reported in both forms. It returns an error if the
+// host isn't a valid internationalized domain name. IP addresses and host names without internationalized
+// labels are returned unchanged in both forms.
+func HostForms(host string) (ascii, unicodeName string, err error) {
+	if net.ParseIP(host) != nil || !isIDN(host) {
+		return host, host, nil
+	}
+	ascii, err = idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", "", err
+	}
+	unicodeName, err = idna.Lookup.ToUnicode(ascii)
+	if err != nil {
+		return "", "", err
+	}
+	return ascii, unicodeName, nil
+}
+
+// ASCIIURL returns the target URL with the ASCII form of its host name
+func ASCIIURL(target string) (string, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+	host := u.Hostname()
+	ascii, _, err := HostForms(host)
+	if err != nil {
+		return "", err
+	}
+	if ascii == host {
+		return target, nil
+	}
+	port := u.Port()
+	u.Host = ascii
+	if port != "" {
+		u.Host = net.JoinHostPort(ascii, port)
+	}
+	return u.String(), nil
+}
+
+// isIDN returns true if the host contains non-ASCII characters or punycode encoded labels
+func isIDN(host string) bool {
+	for _, r := range host {
+		if r > unicode.MaxASCII {
+			return true
+		}
+	}
+	for _, label := range strings.Split(host, ".") {
+		if strings.HasPrefix(strings.ToLower(label), "xn--") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package checks
+
+import "testing"
+
+func TestGlobalTarget_URL(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  GlobalTarget
+		want    string
+		wantErr bool
+	}{
+		{name: "no port", target: GlobalTarget{Url: "https://sparrow.com"}, want: "https://sparrow.com"},
+		{name: "advertised port", target: GlobalTarget{Url: "https://sparrow.com", Port: 8080}, want: "https://sparrow.com:8080"},
+		{name: "port in url", target: GlobalTarget{Url: "http://sparrow.com:9090", Port: 8080}, want: "http://sparrow.com:9090"},
+		{name: "invalid url", target: GlobalTarget{Url: "://sparrow.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.target.URL()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GlobalTarget.URL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("GlobalTarget.URL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobalTarget_Runs(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []string
+		want   bool
+	}{
+		{name: "checks not advertised", checks: nil, want: true},
+		{name: "check advertised", checks: []string{"health", "traceroute"}, want: true},
+		{name: "check not advertised", checks: []string{"health"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := GlobalTarget{Url: "https://sparrow.com", Checks: tt.checks}
+			if got := g.Runs("traceroute"); got != tt.want {
+				t.Errorf("GlobalTarget.Runs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{name: "url", target: "https://sparrow.com", want: "https://sparrow.com"},
+		{name: "case folded url", target: "HTTPS://Sparrow.COM/Health", want: "https://sparrow.com/Health"},
+		{name: "trailing slash", target: "https://sparrow.com/", want: "https://sparrow.com"},
+		{name: "trailing slash of path", target: "https://sparrow.com/health/", want: "https://sparrow.com/health"},
+		{name: "default port", target: "https://sparrow.com:443", want: "https://sparrow.com"},
+		{name: "non default port", target: "http://sparrow.com:443", want: "http://sparrow.com:443"},
+		{name: "internationalized url", target: "https://bücher.example", want: "https://xn--bcher-kva.example"},
+		{name: "host", target: "Sparrow.com.", want: "sparrow.com"},
+		{name: "internationalized host", target: "Bücher.example", want: "xn--bcher-kva.example"},
+		{name: "host with port", target: "Sparrow.com:123", want: "sparrow.com:123"},
+		{name: "ip address", target: "10.0.0.1", want: "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTarget(tt.target); got != tt.want {
+				t.Errorf("NormalizeTarget() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostForms(t *testing.T) {
+	tests := []struct {
+		name        string
+		host        string
+		wantASCII   string
+		wantUnicode string
+		wantErr     bool
+	}{
+		{name: "ascii", host: "example.com", wantASCII: "example.com", wantUnicode: "example.com"},
+		{name: "ip address", host: "192.0.2.1", wantASCII: "192.0.2.1", wantUnicode: "192.0.2.1"},
+		{name: "unicode", host: "bücher.example", wantASCII: "xn--bcher-kva.example", wantUnicode: "bücher.example"},
+		{name: "punycode", host: "xn--bcher-kva.example", wantASCII: "xn--bcher-kva.example", wantUnicode: "bücher.example"},
+		{name: "mixed case unicode", host: "Bücher.example", wantASCII: "xn--bcher-kva.example", wantUnicode: "bücher.example"},
+		{name: "invalid punycode", host: "xn--a.example", wantErr: true},
+		{name: "disallowed character", host: "bü cher.example", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ascii, unicodeName, err := HostForms(tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HostForms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ascii != tt.wantASCII || unicodeName != tt.wantUnicode {
+				t.Errorf("HostForms() = (%s, %s), want (%s, %s)", ascii, unicodeName, tt.wantASCII, tt.wantUnicode)
+			}
+		})
+	}
+}
+
+func TestASCIIURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{name: "ascii", target: "https://example.com/health", want: "https://example.com/health"},
+		{name: "unicode", target: "https://bücher.example/health", want: "https://xn--bcher-kva.example/health"},
+		{name: "unicode with port", target: "http://bücher.example:8080", want: "http://xn--bcher-kva.example:8080"},
+		{name: "invalid host", target: "https://xn--a.example", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ASCIIURL(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ASCIIURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ASCIIURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+)
+
+// Timing are the durations of a check deciding how long a probe of a target takes at most
+type Timing struct {
+	// Interval is the interval of the check. It isn't compared to the probe duration if the check is scheduled.
+	Interval time.Duration
+	// Timeout is the timeout of a single attempt of a probe
+	Timeout time.Duration
+	// Schedule is the schedule of the check replacing its interval if enabled
+	Schedule Schedule
+	// Retry is the retry configuration of the check
+	Retry helper.RetryConfig
+	// TargetRetries are the retry overrides of the check's targets
+	TargetRetries []TargetRetry
+}
+
+// Validate checks if the durations aren't negative and every probe
+// including its retries finishes within the interval of the check.
+// Otherwise, the iterations of the check would silently take longer than its interval.
+func (t Timing) Validate(checkName string) error {
+	if t.Interval < 0 {
+		return ErrInvalidConfig{CheckName: checkName, Field: "interval", Reason: "must not be negative"}
+	}
+	if t.Timeout < 0 {
+		return ErrInvalidConfig{CheckName: checkName, Field: "timeout", Reason: "must not be negative"}
+	}
+	if err := validateRetry(checkName, "retry", t.Retry); err != nil {
+		return err
+	}
+
+	if t.Schedule.Enabled() {
+		return nil
+	}
+	if err := t.validateProbe(checkName, "retry", t.Retry, 0); err != nil {
+		return err
+	}
+	for i, o := range t.TargetRetries {
+		if err := t.validateProbe(checkName, fmt.Sprintf("targetRetries[%d].retry", i), o.Retry, o.Budget); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateProbe checks if a probe retried with the given configuration and budget finishes within the interval
+func (t Timing) validateProbe(checkName, field string, retry helper.RetryConfig, budget int) error {
+	retries := retry.Count
+	if budget > 0 {
+		retries = min(retries, budget)
+	}
+	d := t.Timeout * time.Duration(retries+1)
+	if retry.MaxElapsed > 0 {
+		d = min(d, retry.MaxElapsed+t.Timeout)
+	}
+	if d < t.Interval {
+		return nil
+	}
+	return ErrInvalidConfig{
+		CheckName: checkName,
+		Field:     field,
+		Reason: fmt.Sprintf(
+			"a probe with a timeout of %v and %d retries can take %v, which must be less than the interval of %v; "+
+				"lower the timeout or the retries, or limit the retries with maxElapsed",
+			t.Timeout, retries, d, t.Interval,
+		),
+	}
+}
+
+// validateRetry checks if the durations and counts of the retry configuration aren't negative
+func validateRetry(checkName, field string, retry helper.RetryConfig) error {
+	switch {
+	case retry.Count < 0:
+		return ErrInvalidConfig{CheckName: checkName, Field: field + ".count", Reason: "must not be negative"}
+	case retry.Delay < 0:
+		return ErrInvalidConfig{CheckName: checkName, Field: field + ".delay", Reason: "must not be negative"}
+	case retry.MaxDelay < 0:
+		return ErrInvalidConfig{CheckName: checkName, Field: field + ".maxDelay", Reason: "must not be negative"}
+	case retry.MaxElapsed < 0:
+		return ErrInvalidConfig{CheckName: checkName, Field: field + ".maxElapsed", Reason: "must not be negative"}
+	case retry.Jitter < 0 || retry.Jitter > 1:
+		return ErrInvalidConfig{CheckName: checkName, Field: field + ".jitter", Reason: "must be between 0 and 1"}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+)
+
+func TestTiming_Validate(t *testing.T) {
+	retry := helper.RetryConfig{Count: 3, Delay: time.Second}
+
+	tests := []struct {
+		name    string
+		timing  Timing
+		wantErr bool
+	}{
+		{name: "valid", timing: Timing{Interval: time.Minute, Timeout: 10 * time.Second, Retry: retry}},
+		{name: "no retries", timing: Timing{Interval: 20 * time.Second, Timeout: 10 * time.Second}},
+		{name: "retries exceed interval", timing: Timing{Interval: 30 * time.Second, Timeout: 10 * time.Second, Retry: retry}, wantErr: true},
+		{name: "timeout equals interval", timing: Timing{Interval: 10 * time.Second, Timeout: 10 * time.Second}, wantErr: true},
+		{
+			name:   "retries limited by max elapsed",
+			timing: Timing{Interval: 30 * time.Second, Timeout: 10 * time.Second, Retry: helper.RetryConfig{Count: 3, Delay: time.Second, MaxElapsed: 15 * time.Second}},
+		},
+		{name: "scheduled", timing: Timing{Timeout: 10 * time.Second, Retry: retry, Schedule: "*/5 * * * *"}},
+		{
+			name: "target retries exceed interval",
+			timing: Timing{
+				Interval: time.Minute, Timeout: 10 * time.Second, Retry: retry,
+				TargetRetries: []TargetRetry{{Targets: []string{"a"}, Retry: helper.RetryConfig{Count: 8}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "target retries limited by budget",
+			timing: Timing{
+				Interval: time.Minute, Timeout: 10 * time.Second, Retry: retry,
+				TargetRetries: []TargetRetry{{Targets: []string{"a"}, Retry: helper.RetryConfig{Count: 8}, Budget: 4}},
+			},
+		},
+		{name: "negative interval", timing: Timing{Interval: -time.Second, Schedule: "*/5 * * * *"}, wantErr: true},
+		{name: "negative timeout", timing: Timing{Interval: time.Minute, Timeout: -time.Second}, wantErr: true},
+		{name: "negative retry count", timing: Timing{Interval: time.Minute, Timeout: time.Second, Retry: helper.RetryConfig{Count: -1}}, wantErr: true},
+		{name: "negative max delay", timing: Timing{Interval: time.Minute, Timeout: time.Second, Retry: helper.RetryConfig{MaxDelay: -time.Second}}, wantErr: true},
+		{name: "jitter above 1", timing: Timing{Interval: time.Minute, Timeout: time.Second, Retry: helper.RetryConfig{Jitter: 1.5}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.timing.Validate("test"); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -40,11 +40,22 @@ var _ checks.Check = (*Traceroute)(nil)
 
 const CheckName = "traceroute"
 
+// SchemaVersion is the version of the layout of the check's result data.
+// It is incremented with every breaking change of the layout.
+const SchemaVersion = 1
+
 type Target struct {
 	// The address of the target to traceroute to. Can be a DNS name or an IP address
 	Addr string `json:"addr" yaml:"addr" mapstructure:"addr"`
 	// The port to traceroute to
 	Port int `json:"port" yaml:"port" mapstructure:"port"`
+	// Peer is the URL of the API of a paired sparrow running on the target.
+	// The path it traces back is added to the result if reverse path detection is enabled.
+	Peer string `json:"peer,omitempty" yaml:"peer,omitempty" mapstructure:"peer"`
+	// FirstTtl overrides the first time to live probed for this target
+	FirstTtl int `json:"firstTtl,omitempty" yaml:"firstTtl,omitempty" mapstructure:"firstTtl"`
+	// MaxHops overrides the maximum number of hops for this target
+	MaxHops int `json:"maxHops,omitempty" yaml:"maxHops,omitempty" mapstructure:"maxHops"`
 }
 
 func (t Target) String() string {
@@ -59,6 +70,7 @@ func NewCheck() checks.Check {
 		},
 		config:     Config{},
 		traceroute: TraceRoute,
+		fetch:      fetch,
 		metrics:    newMetrics(),
 	}
 	c.tracer = otel.Tracer(c.Name())
@@ -69,6 +81,7 @@ type Traceroute struct {
 	checks.CheckBase
 	config     Config
 	traceroute tracerouteFactory
+	fetch      fetchFunc
 	metrics    metrics
 	tracer     trace.Tracer
 }
@@ -77,8 +90,13 @@ type tracerouteConfig struct {
 	Dest    string
 	Port    int
 	Timeout time.Duration
-	MaxHops int
-	Rc      helper.RetryConfig
+	// FirstTtl is the first time to live probed, the hops before are skipped
+	FirstTtl int
+	MaxHops  int
+	Rc       helper.RetryConfig
+	// RetryOpts apply the retry budget of the target, if any
+	RetryOpts []helper.RetryOption
+	Network   checks.NetworkConfig
 }
 
 type tracerouteFactory func(ctx context.Context, cfg tracerouteConfig) (map[int][]Hop, error)
@@ -86,8 +104,16 @@ type tracerouteFactory func(ctx context.Context, cfg tracerouteConfig) (map[int]
 type result struct {
 	// The minimum number of hops required to reach the target
 	MinHops int `json:"min_hops" yaml:"min_hops" mapstructure:"min_hops"`
-	// The path taken to the destination
+	// The path taken to the destination. Omitted if the results are compact.
 	Hops map[int][]Hop `json:"hops" yaml:"hops" mapstructure:"hops"`
+	// Summary are the attempts of every hop collapsed into a single entry, sorted by the time to live
+	Summary []HopSummary `json:"summary,omitempty" yaml:"summary,omitempty" mapstructure:"summary"`
+	// Path is the canonical form of the addresses of the hops up to the destination
+	Path string `json:"path,omitempty" yaml:"path,omitempty" mapstructure:"path"`
+	// PathHash is the SHA-256 hash of the canonical path
+	PathHash string `json:"path_hash,omitempty" yaml:"path_hash,omitempty" mapstructure:"path_hash"`
+	// The path taken from the paired sparrow back to this sparrow
+	Reverse *reversePath `json:"reverse,omitempty" yaml:"reverse,omitempty" mapstructure:"reverse"`
 }
 
 // Run runs the check in a loop sending results to the provided channel
@@ -96,22 +122,30 @@ func (tr *Traceroute) Run(ctx context.Context, cResult chan checks.ResultDTO) er
 	defer cancel()
 	log := logger.FromContext(ctx)
 
+	tr.Mu.Lock()
 	log.InfoContext(ctx, "Starting traceroute check", "interval", tr.config.Interval.String())
+	tr.Mu.Unlock()
 	for {
+		// The configuration is updated concurrently, so every run reads it from a copy
+		tr.Mu.Lock()
+		cfg := tr.config
+		tr.Mu.Unlock()
+
 		select {
 		case <-ctx.Done():
 			log.ErrorContext(ctx, "Context canceled", "error", ctx.Err())
 			return ctx.Err()
 		case <-tr.DoneChan:
 			return nil
-		case <-time.After(tr.config.Interval):
-			res := tr.check(ctx)
-			tr.metrics.MinHops(res)
+		case <-tr.Clock().After(cfg.nextRun(tr.Clock().Now())):
+			res := tr.check(ctx, cfg)
+			tr.setMinHops(res)
 			cResult <- checks.ResultDTO{
 				Name: tr.Name(),
 				Result: &checks.Result{
-					Data:      res,
-					Timestamp: time.Now(),
+					Data:          res,
+					Timestamp:     tr.Clock().Now(),
+					SchemaVersion: SchemaVersion,
 				},
 			}
 			log.DebugContext(ctx, "Successfully finished traceroute check run")
@@ -119,14 +153,61 @@ func (tr *Traceroute) Run(ctx context.Context, cResult chan checks.ResultDTO) er
 	}
 }
 
+// RunOnce runs the traceroute check once against all targets.
+// A target fails if none of the hops reached it.
+func (tr *Traceroute) RunOnce(ctx context.Context) (*checks.Result, []string) {
+	tr.Mu.Lock()
+	cfg := tr.config
+	tr.Mu.Unlock()
+
+	res := tr.check(ctx, cfg)
+	tr.setMinHops(res)
+	failed := checks.FailedTargets(res, result.failed)
+	return &checks.Result{Data: res, Timestamp: tr.Clock().Now(), SchemaVersion: SchemaVersion}, failed
+}
+
+// setMinHops sets the minimum number of hops of every target
+func (tr *Traceroute) setMinHops(res map[string]result) {
+	for target, r := range res {
+		tr.WithSeries(target, func(label string) {
+			tr.metrics.MinHops(label, r.MinHops)
+		})
+	}
+}
+
+// failed returns true if the target wasn't reached
+func (r result) failed() bool {
+	return !r.reached()
+}
+
+// Failures reports for every target in the data of a traceroute check result whether it wasn't reached
+func Failures(data any) map[string]bool {
+	res, _ := checks.ResultData[result](data)
+	return checks.TargetFailures(res, result.failed)
+}
+
+// reached returns true if any hop reached the target
+func (r result) reached() bool {
+	for _, hops := range r.Hops {
+		for _, h := range hops {
+			if h.Reached {
+				return true
+			}
+		}
+	}
+	return slices.ContainsFunc(r.Summary, func(s HopSummary) bool { return s.Reached })
+}
+
 // GetConfig returns the current configuration of the check
 func (tr *Traceroute) GetConfig() checks.Runtime {
 	tr.Mu.Lock()
 	defer tr.Mu.Unlock()
-	return &tr.config
+	cfg := tr.config
+	return &cfg
 }
 
-func (tr *Traceroute) check(ctx context.Context) map[string]result {
+// check traces the routes to all targets of the given configuration
+func (tr *Traceroute) check(ctx context.Context, cfg Config) map[string]result {
 	res := make(map[string]result)
 	log := logger.FromContext(ctx)
 
@@ -135,35 +216,42 @@ func (tr *Traceroute) check(ctx context.Context) map[string]result {
 		res  result
 	}
 
-	cResult := make(chan internalResult, len(tr.config.Targets))
+	cResult := make(chan internalResult, len(cfg.Targets))
 	var wg sync.WaitGroup
 	start := time.Now()
-	wg.Add(len(tr.config.Targets))
+	wg.Add(len(cfg.Targets))
 
-	for _, t := range tr.config.Targets {
+	retries := checks.NewRetries(cfg.Retry, cfg.TargetRetries)
+	for _, t := range cfg.Targets {
 		go func(t Target) {
 			defer wg.Done()
 			l := log.With("target", t.String())
 			l.DebugContext(ctx, "Running traceroute")
+			retry, opts := retries.For(t.Addr)
+			firstTtl, maxHops := cfg.ttlWindow(t)
 
 			c, span := tr.tracer.Start(ctx, t.String(), trace.WithAttributes(
 				attribute.String("target.addr", t.Addr),
 				attribute.Int("target.port", t.Port),
-				attribute.Stringer("config.interval", tr.config.Interval),
-				attribute.Stringer("config.timeout", tr.config.Timeout),
-				attribute.Int("config.max_hops", tr.config.MaxHops),
-				attribute.Int("config.retry.count", tr.config.Retry.Count),
-				attribute.Stringer("config.retry.delay", tr.config.Retry.Delay),
+				attribute.Stringer("config.interval", cfg.Interval),
+				attribute.Stringer("config.timeout", cfg.Timeout),
+				attribute.Int("config.first_ttl", firstTtl),
+				attribute.Int("config.max_hops", maxHops),
+				attribute.Int("config.retry.count", retry.Count),
+				attribute.Stringer("config.retry.delay", retry.Delay),
 			))
 			defer span.End()
 
 			s := time.Now()
 			hops, err := tr.traceroute(c, tracerouteConfig{
-				Dest:    t.Addr,
-				Port:    t.Port,
-				Timeout: tr.config.Timeout,
-				MaxHops: tr.config.MaxHops,
-				Rc:      tr.config.Retry,
+				Dest:      t.Addr,
+				Port:      t.Port,
+				Timeout:   cfg.Timeout,
+				FirstTtl:  firstTtl,
+				MaxHops:   maxHops,
+				Rc:        retry,
+				RetryOpts: opts,
+				Network:   cfg.Network,
 			})
 			elapsed := time.Since(s)
 
@@ -175,12 +263,14 @@ func (tr *Traceroute) check(ctx context.Context) map[string]result {
 				span.SetStatus(codes.Ok, "success")
 			}
 
-			tr.metrics.CheckDuration(t.Addr, elapsed)
+			tr.WithSeries(t.Addr, func(label string) {
+				tr.metrics.CheckDuration(label, elapsed)
+			})
 			l.DebugContext(ctx, "Ran traceroute", "result", hops, "duration", elapsed)
 
 			res := result{
 				Hops:    hops,
-				MinHops: tr.config.MaxHops,
+				MinHops: maxHops,
 			}
 			for ttl, hop := range hops {
 				for _, attempt := range hop {
@@ -189,6 +279,11 @@ func (tr *Traceroute) check(ctx context.Context) map[string]result {
 					}
 				}
 			}
+			res.Summary = summarize(hops)
+			res.Path, res.PathHash = canonicalPath(res.Summary)
+			if cfg.Compact {
+				res.Hops = nil
+			}
 
 			span.AddEvent("Traceroute completed", trace.WithAttributes(
 				attribute.Int("result.min_hops", res.MinHops),
@@ -207,6 +302,10 @@ func (tr *Traceroute) check(ctx context.Context) map[string]result {
 		res[r.addr] = r.res
 	}
 
+	if cfg.Reverse.Enabled {
+		tr.addReversePaths(ctx, cfg, res, start)
+	}
+
 	elapsed := time.Since(start)
 	log.InfoContext(ctx, "Finished traceroute check", "duration", elapsed)
 	return res
@@ -226,16 +325,16 @@ func (tr *Traceroute) UpdateConfig(cfg checks.Runtime) error {
 		tr.Mu.Lock()
 		defer tr.Mu.Unlock()
 
-		for _, target := range tr.config.Targets {
-			if !slices.Contains(c.Targets, target) {
-				err := tr.metrics.Remove(target.Addr)
-				if err != nil {
-					return err
-				}
-			}
+		addrs := make([]string, 0, len(c.Targets))
+		for _, t := range c.Targets {
+			addrs = append(addrs, t.Addr)
+		}
+		if err := tr.SetTargets(addrs, tr.metrics.Remove); err != nil {
+			return err
 		}
 
 		tr.config = *c
+		tr.metrics.histogram.SetBuckets(c.Buckets)
 		return nil
 	}
 
@@ -263,5 +362,5 @@ func (tr *Traceroute) Name() string {
 // RemoveLabelledMetrics removes the metrics which have the passed
 // target as a label
 func (tr *Traceroute) RemoveLabelledMetrics(target string) error {
-	return tr.metrics.Remove(target)
+	return tr.RemoveSeries(target, tr.metrics.Remove)
 }
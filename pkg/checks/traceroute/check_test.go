@@ -49,6 +49,21 @@ func TestCheck(t *testing.T) {
 						4: {{Addr: HopAddress{IP: "0.0.0.4"}, Latency: 4 * time.Second, Reached: false, Ttl: 4}},
 						5: {{Addr: HopAddress{IP: "123.0.0.123", Port: 53}, Name: "google-public-dns-a.google.com", Latency: 69 * time.Second, Reached: true, Ttl: 5}},
 					},
+					Summary:  fiveHopsSummary,
+					Path:     "0.0.0.1>0.0.0.2>0.0.0.3>0.0.0.4>123.0.0.123",
+					PathHash: "sha256:a4c37af2fef5525c4528153d365b9faaee590fc6efbdac3f47fc94a6fb1611a4",
+				},
+			},
+		},
+		{
+			name: "Compact results omit the hops",
+			c:    newCompactForTest(success(5), 10, []string{"8.8.8.8"}),
+			want: map[string]result{
+				"8.8.8.8": {
+					MinHops:  5,
+					Summary:  fiveHopsSummary,
+					Path:     "0.0.0.1>0.0.0.2>0.0.0.3>0.0.0.4>123.0.0.123",
+					PathHash: "sha256:a4c37af2fef5525c4528153d365b9faaee590fc6efbdac3f47fc94a6fb1611a4",
 				},
 			},
 		},
@@ -63,7 +78,7 @@ func TestCheck(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			res := c.c.check(context.Background())
+			res := c.c.check(context.Background(), c.c.config)
 
 			if !cmp.Equal(res, c.want) {
 				diff := cmp.Diff(res, c.want)
@@ -73,6 +88,81 @@ func TestCheck(t *testing.T) {
 	}
 }
 
+func TestCheck_ttlWindow(t *testing.T) {
+	var mu sync.Mutex
+	windows := map[string][2]int{}
+	tr := newForTest(func(_ context.Context, cfg tracerouteConfig) (map[int][]Hop, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		windows[cfg.Dest] = [2]int{cfg.FirstTtl, cfg.MaxHops}
+		return map[int][]Hop{}, nil
+	}, 30, nil)
+	tr.config.FirstTtl = 3
+	tr.config.Targets = []Target{
+		{Addr: "8.8.8.8"},
+		{Addr: "1.1.1.1", FirstTtl: 5, MaxHops: 12},
+	}
+
+	res := tr.check(context.Background(), tr.config)
+
+	want := map[string][2]int{"8.8.8.8": {3, 30}, "1.1.1.1": {5, 12}}
+	if !cmp.Equal(windows, want) {
+		t.Errorf("unexpected ttl windows: +want -got\n%s", cmp.Diff(windows, want))
+	}
+	if res["1.1.1.1"].MinHops != 12 {
+		t.Errorf("MinHops = %d, want 12", res["1.1.1.1"].MinHops)
+	}
+}
+
+func TestConfig_Validate_ttlWindow(t *testing.T) {
+	cases := []struct {
+		name     string
+		firstTtl int
+		target   Target
+		wantErr  bool
+	}{
+		{name: "default window", target: Target{Addr: "8.8.8.8"}},
+		{name: "first ttl", firstTtl: 5, target: Target{Addr: "8.8.8.8"}},
+		{name: "first ttl equals max hops", firstTtl: 30, target: Target{Addr: "8.8.8.8"}},
+		{name: "target window", target: Target{Addr: "8.8.8.8", FirstTtl: 10, MaxHops: 40}},
+		{name: "negative first ttl", firstTtl: -1, target: Target{Addr: "8.8.8.8"}, wantErr: true},
+		{name: "first ttl exceeds max hops", firstTtl: 31, target: Target{Addr: "8.8.8.8"}, wantErr: true},
+		{name: "target first ttl exceeds max hops", target: Target{Addr: "8.8.8.8", FirstTtl: 31}, wantErr: true},
+		{name: "target max hops below first ttl", firstTtl: 10, target: Target{Addr: "8.8.8.8", MaxHops: 5}, wantErr: true},
+		{name: "target max hops exceeds max ttl", target: Target{Addr: "8.8.8.8", MaxHops: 256}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := Config{
+				Targets:  []Target{c.target},
+				MaxHops:  30,
+				FirstTtl: c.firstTtl,
+				Interval: time.Minute,
+				Timeout:  time.Second,
+			}
+			if err := cfg.Validate(); (err != nil) != c.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
+
+// fiveHopsSummary is the summary of the hops produced by success(5)
+var fiveHopsSummary = []HopSummary{
+	{Ttl: 1, Addrs: []string{"0.0.0.1"}, Best: 1 * time.Second, Worst: 1 * time.Second},
+	{Ttl: 2, Addrs: []string{"0.0.0.2"}, Best: 2 * time.Second, Worst: 2 * time.Second},
+	{Ttl: 3, Addrs: []string{"0.0.0.3"}, Best: 3 * time.Second, Worst: 3 * time.Second},
+	{Ttl: 4, Addrs: []string{"0.0.0.4"}, Best: 4 * time.Second, Worst: 4 * time.Second},
+	{Ttl: 5, Addrs: []string{"123.0.0.123"}, Names: []string{"google-public-dns-a.google.com"}, Best: 69 * time.Second, Worst: 69 * time.Second, Reached: true},
+}
+
+func newCompactForTest(f tracerouteFactory, maxHops int, targets []string) *Traceroute {
+	tr := newForTest(f, maxHops, targets)
+	tr.config.Compact = true
+	return tr
+}
+
 func newForTest(f tracerouteFactory, maxHops int, targets []string) *Traceroute {
 	t := make([]Target, len(targets))
 	for i, target := range targets {
@@ -28,6 +28,9 @@ import (
 	"github.com/caas-team/sparrow/pkg/checks"
 )
 
+// maxTTL is the maximum time to live of an ip packet, which limits the number of hops
+const maxTTL = 255
+
 // Config is the configuration for the traceroute check
 type Config struct {
 	// Targets is a list of targets to traceroute to
@@ -36,10 +39,24 @@ type Config struct {
 	Retry helper.RetryConfig `json:"retry" yaml:"retry" mapstructure:"retry"`
 	// MaxHops is the maximum number of hops to try before giving up
 	MaxHops int `json:"maxHops" yaml:"maxHops" mapstructure:"maxHops"`
+	// FirstTtl is the first time to live probed, so well-known first hops can be skipped. Defaults to 1.
+	FirstTtl int `json:"firstTtl,omitempty" yaml:"firstTtl,omitempty" mapstructure:"firstTtl"`
 	// Interval is the time to wait between check iterations
 	Interval time.Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+	// Schedule is a cron expression defining when the check runs. It replaces the interval if set.
+	Schedule checks.Schedule `json:"schedule,omitempty" yaml:"schedule,omitempty" mapstructure:"schedule"`
 	// Timeout is the maximum time to wait for a response from a hop
 	Timeout time.Duration `json:"timeout" yaml:"timeout" mapstructure:"timeout"`
+	// Network configures the DSCP marking and the source binding of the probes
+	Network checks.NetworkConfig `json:"network,omitempty" yaml:"network,omitempty" mapstructure:"network"`
+	// Reverse configures the detection of the reverse path with paired sparrows
+	Reverse Reverse `json:"reverse,omitempty" yaml:"reverse,omitempty" mapstructure:"reverse"`
+	// TargetRetries override the retry configuration for some targets, which are referred to by their address
+	TargetRetries []checks.TargetRetry `json:"targetRetries,omitempty" yaml:"targetRetries,omitempty" mapstructure:"targetRetries"`
+	// Buckets are the upper bounds of the buckets of the duration histogram in seconds
+	Buckets checks.Buckets `json:"buckets,omitempty" yaml:"buckets,omitempty" mapstructure:"buckets"`
+	// Compact omits the raw attempts of every hop from the results, leaving the summarized hops and the path
+	Compact bool `json:"compact,omitempty" yaml:"compact,omitempty" mapstructure:"compact"`
 }
 
 func (c *Config) For() string {
@@ -50,9 +67,22 @@ func (c *Config) Validate() error {
 	if c.Timeout <= 0 {
 		return checks.ErrInvalidConfig{CheckName: CheckName, Field: "traceroute.timeout", Reason: "must be greater than 0"}
 	}
-	if c.Interval <= 0 {
+	if !c.Schedule.Enabled() && c.Interval <= 0 {
 		return checks.ErrInvalidConfig{CheckName: CheckName, Field: "traceroute.interval", Reason: "must be greater than 0"}
 	}
+	if err := c.Schedule.Validate(CheckName); err != nil {
+		return err
+	}
+	if c.MaxHops < 1 || c.MaxHops > maxTTL {
+		return checks.ErrInvalidConfig{CheckName: CheckName, Field: "traceroute.maxHops", Reason: fmt.Sprintf("must be between 1 and %d", maxTTL)}
+	}
+	if c.FirstTtl < 0 || c.FirstTtl > c.MaxHops {
+		return checks.ErrInvalidConfig{CheckName: CheckName, Field: "traceroute.firstTtl", Reason: "must be between 1 and maxHops"}
+	}
+
+	if err := c.Network.Validate(CheckName); err != nil {
+		return err
+	}
 
 	for i, t := range c.Targets {
 		ip := net.ParseIP(t.Addr)
@@ -65,5 +95,52 @@ func (c *Config) Validate() error {
 			return checks.ErrInvalidConfig{CheckName: CheckName, Field: fmt.Sprintf("traceroute.targets[%d].addr", i), Reason: "invalid url or ip"}
 		}
 	}
-	return nil
+
+	for i, t := range c.Targets {
+		if t.MaxHops < 0 || t.MaxHops > maxTTL {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: fmt.Sprintf("traceroute.targets[%d].maxHops", i), Reason: fmt.Sprintf("must be between 1 and %d", maxTTL)}
+		}
+		first, last := c.ttlWindow(t)
+		if t.FirstTtl < 0 || first > last {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: fmt.Sprintf("traceroute.targets[%d].firstTtl", i), Reason: "must be between 1 and maxHops"}
+		}
+	}
+
+	for i, t := range c.Targets {
+		if t.Peer == "" {
+			continue
+		}
+		u, err := url.Parse(t.Peer)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return checks.ErrInvalidConfig{CheckName: CheckName, Field: fmt.Sprintf("traceroute.targets[%d].peer", i), Reason: "must be a http or https url"}
+		}
+	}
+
+	addrs := make([]string, 0, len(c.Targets))
+	for _, t := range c.Targets {
+		addrs = append(addrs, t.Addr)
+	}
+	if err := checks.ValidateRetries(CheckName, c.TargetRetries, addrs); err != nil {
+		return err
+	}
+
+	if err := c.Buckets.Validate(CheckName); err != nil {
+		return err
+	}
+
+	timing := checks.Timing{Interval: c.Interval, Timeout: c.Timeout, Schedule: c.Schedule, Retry: c.Retry, TargetRetries: c.TargetRetries}
+	return timing.Validate(CheckName)
+}
+
+// ttlWindow returns the first and the last time to live probed for the target.
+// The settings of the target take precedence over the ones of the check.
+func (c *Config) ttlWindow(t Target) (first, last int) {
+	first, last = c.FirstTtl, c.MaxHops
+	if t.FirstTtl > 0 {
+		first = t.FirstTtl
+	}
+	if t.MaxHops > 0 {
+		last = t.MaxHops
+	}
+	return max(first, 1), last
 }
@@ -31,25 +31,32 @@ const (
 )
 
 type metrics struct {
-	minHops       *prometheus.GaugeVec
-	checkDuration *prometheus.GaugeVec
+	minHops        *prometheus.GaugeVec
+	checkDuration  *prometheus.GaugeVec
+	reverseMinHops *prometheus.GaugeVec
+	histogram      *checks.HistogramVec
 }
 
 func (m metrics) List() []prometheus.Collector {
 	return []prometheus.Collector{
 		m.minHops,
 		m.checkDuration,
+		m.reverseMinHops,
+		m.histogram,
 	}
 }
 
-func (m metrics) MinHops(data map[string]result) {
-	for target, hops := range data {
-		m.minHops.With(prometheus.Labels{labelTarget: target}).Set(float64(hops.MinHops))
-	}
+func (m metrics) MinHops(target string, hops int) {
+	m.minHops.With(prometheus.Labels{labelTarget: target}).Set(float64(hops))
 }
 
 func (m metrics) CheckDuration(target string, n time.Duration) {
 	m.checkDuration.With(prometheus.Labels{labelTarget: target}).Set(float64(n.Milliseconds()))
+	m.histogram.WithLabelValues(target).Observe(n.Seconds())
+}
+
+func (m metrics) ReverseMinHops(target string, hops int) {
+	m.reverseMinHops.With(prometheus.Labels{labelTarget: target}).Set(float64(hops))
 }
 
 func newMetrics() metrics {
@@ -62,6 +69,15 @@ func newMetrics() metrics {
 			Namespace: "sparrow_traceroute",
 			Name:      "check_duration_ms",
 		}, []string{labelTarget}),
+		reverseMinHops: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "sparrow_traceroute",
+			Name:      "reverse_minimum_hops",
+		}, []string{labelTarget}),
+		histogram: checks.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "sparrow_traceroute",
+			Name:      "check_duration",
+			Help:      "Histogram of the durations of the traceroutes in seconds",
+		}, []string{labelTarget}),
 	}
 }
 
@@ -73,5 +89,10 @@ func (m metrics) Remove(label string) error {
 	if !m.checkDuration.DeleteLabelValues(label) {
 		return checks.ErrMetricNotFound{Label: label}
 	}
+	if !m.histogram.DeleteLabelValues(label) {
+		return checks.ErrMetricNotFound{Label: label}
+	}
+	// The reverse path is only known for targets with a paired sparrow
+	m.reverseMinHops.DeleteLabelValues(label)
 	return nil
 }
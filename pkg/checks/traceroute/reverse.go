@@ -0,0 +1,183 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceroute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/client"
+)
+
+// Reverse configures the detection of the reverse path with paired sparrows.
+// Paired sparrows trace each other simultaneously and every sparrow publishes
+// the path the paired sparrow traced back to it next to its own path.
+type Reverse struct {
+	// Enabled aligns the runs to multiples of the interval and fetches the
+	// reverse path from the targets with a paired sparrow
+	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+	// Name is the address the paired sparrows trace this sparrow with.
+	// Defaults to the name of the sparrow if the target manager is enabled.
+	Name string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name"`
+}
+
+// reversePath is the path a paired sparrow traced back to this sparrow
+type reversePath struct {
+	// The minimum number of hops required to reach this sparrow
+	MinHops int `json:"min_hops" yaml:"min_hops" mapstructure:"min_hops"`
+	// The path taken from the paired sparrow
+	Hops map[int][]Hop `json:"hops" yaml:"hops" mapstructure:"hops"`
+	// Summary are the summarized hops of the path taken from the paired sparrow
+	Summary []HopSummary `json:"summary,omitempty" yaml:"summary,omitempty" mapstructure:"summary"`
+	// Path is the canonical form of the path taken from the paired sparrow
+	Path string `json:"path,omitempty" yaml:"path,omitempty" mapstructure:"path"`
+	// PathHash is the SHA-256 hash of the canonical path
+	PathHash string `json:"path_hash,omitempty" yaml:"path_hash,omitempty" mapstructure:"path_hash"`
+	// Timestamp is the time the paired sparrow finished its traceroute run
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp" mapstructure:"timestamp"`
+	// Error is the error that occurred while fetching the path from the paired sparrow
+	Error *string `json:"error,omitempty" yaml:"error,omitempty" mapstructure:"error"`
+}
+
+// errStaleResult is returned if the paired sparrow hasn't finished the current run yet
+var errStaleResult = errors.New("paired sparrow has no result of the current run yet")
+
+// fetchFunc fetches the latest traceroute result of a paired sparrow
+type fetchFunc func(ctx context.Context, peer string, timeout time.Duration, netCfg checks.NetworkConfig) (checks.Result, error)
+
+// fetch fetches the latest traceroute result from the API of the paired sparrow
+func fetch(ctx context.Context, peer string, timeout time.Duration, netCfg checks.NetworkConfig) (checks.Result, error) {
+	c, err := client.NewClientWithResponses(peer, client.WithHTTPClient(&http.Client{
+		Timeout:   timeout,
+		Transport: netCfg.Transport(timeout),
+	}))
+	if err != nil {
+		return checks.Result{}, err
+	}
+	resp, err := c.GetCheckMetricsTracerouteWithResponse(ctx, &client.GetCheckMetricsTracerouteParams{})
+	if err != nil {
+		return checks.Result{}, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return checks.Result{}, fmt.Errorf("unexpected status: %s", resp.Status())
+	}
+
+	// The result is decoded into the shared envelope, its data is converted by the caller
+	var res checks.Result
+	if err = json.Unmarshal(resp.Body, &res); err != nil {
+		return checks.Result{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return res, nil
+}
+
+// nextRun returns the time to wait for the next run. With reverse path detection the
+// runs are aligned to multiples of the interval, so paired sparrows with the same
+// interval and synchronized clocks trace each other simultaneously. A schedule
+// aligns the runs by itself.
+func (c *Config) nextRun(now time.Time) time.Duration {
+	if c.Schedule.Enabled() || !c.Reverse.Enabled {
+		return c.Schedule.Next(now, c.Interval)
+	}
+	return now.Truncate(c.Interval).Add(c.Interval).Sub(now)
+}
+
+// addReversePaths adds the reverse path of every target with a paired
+// sparrow to the results of the run started at the given time
+func (tr *Traceroute) addReversePaths(ctx context.Context, cfg Config, results map[string]result, started time.Time) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, t := range cfg.Targets {
+		if t.Peer == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(t Target) {
+			defer wg.Done()
+			rev := tr.reversePath(ctx, &cfg, t, started)
+
+			mu.Lock()
+			defer mu.Unlock()
+			res := results[t.Addr]
+			res.Reverse = rev
+			results[t.Addr] = res
+		}(t)
+	}
+	wg.Wait()
+}
+
+// reversePath fetches the path the paired sparrow of the target traced
+// back to this sparrow in the run started at the given time
+func (tr *Traceroute) reversePath(ctx context.Context, cfg *Config, t Target, started time.Time) *reversePath {
+	log := logger.FromContext(ctx).With("target", t.String(), "peer", t.Peer)
+	rev := &reversePath{}
+
+	err := helper.Retry(func(ctx context.Context) error {
+		if cfg.Reverse.Name == "" {
+			return errors.New("name of this sparrow is unknown")
+		}
+		res, err := tr.fetch(ctx, t.Peer, cfg.Timeout, cfg.Network)
+		if err != nil {
+			return fmt.Errorf("failed to fetch result of paired sparrow: %w", err)
+		}
+		if res.Timestamp.Before(started) {
+			return errStaleResult
+		}
+
+		// The result is decoded from JSON, so it's converted to the result type by a round trip
+		b, err := json.Marshal(res.Data)
+		if err != nil {
+			return fmt.Errorf("failed to convert result of paired sparrow: %w", err)
+		}
+		var data map[string]result
+		if err = json.Unmarshal(b, &data); err != nil {
+			return fmt.Errorf("failed to convert result of paired sparrow: %w", err)
+		}
+		path, ok := data[cfg.Reverse.Name]
+		if !ok {
+			return fmt.Errorf("paired sparrow has no path to %q", cfg.Reverse.Name)
+		}
+
+		rev.MinHops = path.MinHops
+		rev.Hops = path.Hops
+		rev.Summary = path.Summary
+		rev.Path = path.Path
+		rev.PathHash = path.PathHash
+		rev.Timestamp = res.Timestamp
+		return nil
+	}, cfg.Retry)(ctx)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to get reverse path", "error", err)
+		errval := err.Error()
+		rev.Error = &errval
+		return rev
+	}
+
+	tr.WithSeries(t.Addr, func(label string) {
+		tr.metrics.ReverseMinHops(label, rev.MinHops)
+	})
+	return rev
+}
@@ -0,0 +1,180 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceroute
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheck_reversePath(t *testing.T) {
+	reverseHops := map[string]any{
+		"sparrow-a.com": map[string]any{
+			"min_hops": 3,
+			"hops": map[string]any{
+				"3": []any{map[string]any{"addr": map[string]any{"ip": "10.0.0.1"}, "ttl": 3, "reached": true}},
+			},
+		},
+	}
+	later := time.Now().Add(time.Hour)
+
+	cases := []struct {
+		name    string
+		rName   string
+		fetch   fetchFunc
+		want    *reversePath
+		wantErr string
+	}{
+		{
+			name:  "reverse path of the current run",
+			rName: "sparrow-a.com",
+			fetch: func(_ context.Context, _ string, _ time.Duration, _ checks.NetworkConfig) (checks.Result, error) {
+				return checks.Result{Data: reverseHops, Timestamp: later}, nil
+			},
+			want: &reversePath{
+				MinHops:   3,
+				Hops:      map[int][]Hop{3: {{Addr: HopAddress{IP: "10.0.0.1"}, Ttl: 3, Reached: true}}},
+				Timestamp: later,
+			},
+		},
+		{
+			name:  "result of the previous run",
+			rName: "sparrow-a.com",
+			fetch: func(_ context.Context, _ string, _ time.Duration, _ checks.NetworkConfig) (checks.Result, error) {
+				return checks.Result{Data: reverseHops, Timestamp: time.Now().Add(-time.Hour)}, nil
+			},
+			wantErr: errStaleResult.Error(),
+		},
+		{
+			name:  "paired sparrow has no path back",
+			rName: "sparrow-b.com",
+			fetch: func(_ context.Context, _ string, _ time.Duration, _ checks.NetworkConfig) (checks.Result, error) {
+				return checks.Result{Data: reverseHops, Timestamp: later}, nil
+			},
+			wantErr: `paired sparrow has no path to "sparrow-b.com"`,
+		},
+		{
+			name:  "paired sparrow not reachable",
+			rName: "sparrow-a.com",
+			fetch: func(_ context.Context, _ string, _ time.Duration, _ checks.NetworkConfig) (checks.Result, error) {
+				return checks.Result{}, errors.New("connection refused")
+			},
+			wantErr: "failed to fetch result of paired sparrow: connection refused",
+		},
+		{
+			name: "unknown name",
+			fetch: func(_ context.Context, _ string, _ time.Duration, _ checks.NetworkConfig) (checks.Result, error) {
+				return checks.Result{Data: reverseHops, Timestamp: later}, nil
+			},
+			wantErr: "name of this sparrow is unknown",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tr := newForTest(success(5), 10, []string{"8.8.8.8", "sparrow-b.com"})
+			tr.config.Targets[1].Peer = "https://sparrow-b.com"
+			tr.config.Reverse = Reverse{Enabled: true, Name: c.rName}
+			tr.config.Retry = helper.RetryConfig{}
+			tr.fetch = c.fetch
+
+			res := tr.check(context.Background(), tr.config)
+
+			if res["8.8.8.8"].Reverse != nil {
+				t.Errorf("Target without paired sparrow has a reverse path")
+			}
+			got := res["sparrow-b.com"].Reverse
+			if c.wantErr != "" {
+				if got == nil || got.Error == nil || *got.Error != c.wantErr {
+					t.Fatalf("unexpected reverse path %+v, want error %q", got, c.wantErr)
+				}
+				return
+			}
+			if !cmp.Equal(got, c.want) {
+				t.Errorf("unexpected reverse path: +want -got\n%s", cmp.Diff(got, c.want))
+			}
+		})
+	}
+}
+
+func TestConfig_nextRun(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 25, 0, time.UTC)
+	cases := []struct {
+		name string
+		cfg  Config
+		want time.Duration
+	}{
+		{
+			name: "interval",
+			cfg:  Config{Interval: time.Minute},
+			want: time.Minute,
+		},
+		{
+			name: "aligned to the interval with reverse path detection",
+			cfg:  Config{Interval: time.Minute, Reverse: Reverse{Enabled: true}},
+			want: 35 * time.Second,
+		},
+		{
+			name: "schedule",
+			cfg:  Config{Interval: time.Minute, Schedule: "*/5 * * * *", Reverse: Reverse{Enabled: true}},
+			want: 4*time.Minute + 35*time.Second,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.cfg.nextRun(now); got != c.want {
+				t.Errorf("nextRun() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_peer(t *testing.T) {
+	cases := []struct {
+		name    string
+		peer    string
+		wantErr bool
+	}{
+		{name: "no peer", peer: ""},
+		{name: "https peer", peer: "https://sparrow-b.com"},
+		{name: "http peer with port", peer: "http://sparrow-b.com:8080"},
+		{name: "peer without scheme", peer: "sparrow-b.com", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := Config{
+				Targets:  []Target{{Addr: "sparrow-b.com", Port: 443, Peer: c.peer}},
+				MaxHops:  30,
+				Interval: time.Minute,
+				Timeout:  time.Second,
+			}
+			if err := cfg.Validate(); (err != nil) != c.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceroute
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+)
+
+// noAnswer is the placeholder of a hop without any answering address in the canonical path
+const noAnswer = "*"
+
+// HopSummary summarizes the attempts of a single hop
+type HopSummary struct {
+	// Ttl is the time to live of the probes of the hop
+	Ttl int `json:"ttl" yaml:"ttl" mapstructure:"ttl"`
+	// Addrs are the sorted distinct IP addresses that answered the probes
+	Addrs []string `json:"addrs,omitempty" yaml:"addrs,omitempty" mapstructure:"addrs"`
+	// Names are the sorted distinct names of the answering addresses
+	Names []string `json:"names,omitempty" yaml:"names,omitempty" mapstructure:"names"`
+	// Best is the lowest latency of the answered probes
+	Best time.Duration `json:"best" yaml:"best" mapstructure:"best"`
+	// Worst is the highest latency of the answered probes
+	Worst time.Duration `json:"worst" yaml:"worst" mapstructure:"worst"`
+	// Loss is the percentage of the probes without an answer
+	Loss float64 `json:"loss" yaml:"loss" mapstructure:"loss"`
+	// Reached is true if any probe reached the target
+	Reached bool `json:"reached" yaml:"reached" mapstructure:"reached"`
+}
+
+// summarize collapses the attempts of every hop into a summary sorted by the time to live
+func summarize(hops map[int][]Hop) []HopSummary {
+	ttls := make([]int, 0, len(hops))
+	for ttl := range hops {
+		ttls = append(ttls, ttl)
+	}
+	slices.Sort(ttls)
+
+	var summaries []HopSummary
+	for _, ttl := range ttls {
+		s := HopSummary{Ttl: ttl}
+		answered := 0
+		for _, h := range hops[ttl] {
+			if h.Addr.IP == "" {
+				continue
+			}
+			if !slices.Contains(s.Addrs, h.Addr.IP) {
+				s.Addrs = append(s.Addrs, h.Addr.IP)
+			}
+			if h.Name != "" && !slices.Contains(s.Names, h.Name) {
+				s.Names = append(s.Names, h.Name)
+			}
+			if answered == 0 || h.Latency < s.Best {
+				s.Best = h.Latency
+			}
+			s.Worst = max(s.Worst, h.Latency)
+			s.Reached = s.Reached || h.Reached
+			answered++
+		}
+		if n := len(hops[ttl]); n > 0 {
+			s.Loss = float64(n-answered) / float64(n) * 100 //nolint:mnd // percentage
+		}
+		slices.Sort(s.Addrs)
+		slices.Sort(s.Names)
+		summaries = append(summaries, s)
+	}
+	return summaries
+}
+
+// canonicalPath returns the path of the summarized hops up to the first hop that reached the target
+// and its hash. The hops are separated by ">", the addresses of a hop by "|" and a hop without
+// any answer is written as "*". Paths with the same routers result in the same string
+// regardless of the latencies and the order of the answers.
+func canonicalPath(summaries []HopSummary) (path, hash string) {
+	if len(summaries) == 0 {
+		return "", ""
+	}
+
+	hops := make([]string, 0, len(summaries))
+	for _, s := range summaries {
+		if len(s.Addrs) == 0 {
+			hops = append(hops, noAnswer)
+		} else {
+			hops = append(hops, strings.Join(s.Addrs, "|"))
+		}
+		if s.Reached {
+			break
+		}
+	}
+	path = strings.Join(hops, ">")
+	sum := sha256.Sum256([]byte(path))
+	return path, "sha256:" + hex.EncodeToString(sum[:])
+}
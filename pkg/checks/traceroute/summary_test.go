@@ -0,0 +1,98 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traceroute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name     string
+		hops     map[int][]Hop
+		want     []HopSummary
+		wantPath string
+	}{
+		{
+			name: "no hops",
+			hops: map[int][]Hop{},
+		},
+		{
+			name: "retries collapsed per hop",
+			hops: map[int][]Hop{
+				1: {
+					{Ttl: 1, Addr: HopAddress{IP: "10.0.0.1"}, Name: "gw", Latency: 3 * time.Millisecond},
+					{Ttl: 1, Addr: HopAddress{IP: "10.0.0.1"}, Name: "gw", Latency: 1 * time.Millisecond},
+					{Ttl: 1, Addr: HopAddress{IP: "10.0.0.1"}, Name: "gw", Latency: 2 * time.Millisecond},
+				},
+				2: {
+					{Ttl: 2},
+					{Ttl: 2, Addr: HopAddress{IP: "10.0.1.2"}, Latency: 5 * time.Millisecond},
+					{Ttl: 2, Addr: HopAddress{IP: "10.0.1.1"}, Latency: 4 * time.Millisecond},
+					{Ttl: 2},
+				},
+				3: {{Ttl: 3}, {Ttl: 3}},
+				4: {{Ttl: 4, Addr: HopAddress{IP: "1.2.3.4", Port: 443}, Latency: 9 * time.Millisecond, Reached: true}},
+				5: {{Ttl: 5, Addr: HopAddress{IP: "1.2.3.4", Port: 443}, Latency: 8 * time.Millisecond, Reached: true}},
+			},
+			want: []HopSummary{
+				{Ttl: 1, Addrs: []string{"10.0.0.1"}, Names: []string{"gw"}, Best: 1 * time.Millisecond, Worst: 3 * time.Millisecond},
+				{Ttl: 2, Addrs: []string{"10.0.1.1", "10.0.1.2"}, Best: 4 * time.Millisecond, Worst: 5 * time.Millisecond, Loss: 50},
+				{Ttl: 3, Loss: 100},
+				{Ttl: 4, Addrs: []string{"1.2.3.4"}, Best: 9 * time.Millisecond, Worst: 9 * time.Millisecond, Reached: true},
+				{Ttl: 5, Addrs: []string{"1.2.3.4"}, Best: 8 * time.Millisecond, Worst: 8 * time.Millisecond, Reached: true},
+			},
+			wantPath: "10.0.0.1>10.0.1.1|10.0.1.2>*>1.2.3.4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarize(tt.hops)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("summarize() mismatch (-want +got):\n%s", diff)
+			}
+
+			path, hash := canonicalPath(got)
+			if path != tt.wantPath {
+				t.Errorf("canonicalPath() = %q, want %q", path, tt.wantPath)
+			}
+			if (hash == "") != (tt.wantPath == "") {
+				t.Errorf("canonicalPath() hash = %q for path %q", hash, path)
+			}
+		})
+	}
+}
+
+func TestCanonicalPath_order(t *testing.T) {
+	a := summarize(map[int][]Hop{
+		1: {{Ttl: 1, Addr: HopAddress{IP: "10.0.0.2"}, Latency: time.Millisecond}, {Ttl: 1, Addr: HopAddress{IP: "10.0.0.1"}, Latency: time.Second}},
+	})
+	b := summarize(map[int][]Hop{
+		1: {{Ttl: 1, Addr: HopAddress{IP: "10.0.0.1"}, Latency: 2 * time.Millisecond}, {Ttl: 1, Addr: HopAddress{IP: "10.0.0.2"}, Latency: 3 * time.Millisecond}},
+	})
+
+	pathA, hashA := canonicalPath(a)
+	pathB, hashB := canonicalPath(b)
+	if pathA != pathB || hashA != hashB {
+		t.Errorf("canonicalPath() differs for the same routers: %q %q, %q %q", pathA, hashA, pathB, hashB)
+	}
+}
@@ -36,6 +36,7 @@ import (
 
 	"github.com/caas-team/sparrow/internal/helper"
 	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
 	"golang.org/x/net/icmp"
 	"golang.org/x/net/ipv4"
 	"golang.org/x/net/ipv6"
@@ -60,8 +61,9 @@ func randomPort() int {
 }
 
 // tcpHop attempts to connect to the target host using TCP with the specified TTL and timeout.
+// The network configuration is applied to the socket in addition to the TTL.
 // It returns a [net.Conn], the port used for the connection, and an error if the connection failed.
-func tcpHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration) (net.Conn, int, error) {
+func tcpHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration, netCfg checks.NetworkConfig) (net.Conn, int, error) {
 	span := trace.SpanFromContext(ctx)
 
 	for {
@@ -70,17 +72,21 @@ func tcpHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration)
 		// Dialer with control function to set IP_TTL
 		dialer := net.Dialer{
 			LocalAddr: &net.TCPAddr{
+				IP:   netCfg.LocalIP(),
 				Port: port,
 			},
 			Timeout: timeout,
-			Control: func(_, _ string, c syscall.RawConn) error {
+			Control: func(network, _ string, c syscall.RawConn) error {
 				var opErr error
 				if err := c.Control(func(fd uintptr) {
 					opErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TTL, ttl) // #nosec G115 // The net package is safe to use
 				}); err != nil {
 					return err
 				}
-				return opErr
+				if opErr != nil {
+					return opErr
+				}
+				return netCfg.Control(network, c)
 			},
 		}
 
@@ -171,6 +177,7 @@ func TraceRoute(ctx context.Context, cfg tracerouteConfig) (map[int][]Hop, error
 	ctx, sp := tracer.Start(ctx, "TraceRoute", trace.WithAttributes(
 		attribute.String("target", cfg.Dest),
 		attribute.Int("port", cfg.Port),
+		attribute.Int("first_ttl", cfg.FirstTtl),
 		attribute.Int("max_hops", cfg.MaxHops),
 		attribute.Stringer("timeout", cfg.Timeout),
 	))
@@ -190,11 +197,12 @@ func TraceRoute(ctx context.Context, cfg tracerouteConfig) (map[int][]Hop, error
 
 	// if we don't add the +1, this causes issues, when the user does not want to retry,
 	// since the channel's size would be zero, blocking all threads from sending
-	queueSize := cfg.MaxHops * (1 + cfg.Rc.Count)
+	first := max(cfg.FirstTtl, 1)
+	queueSize := max(cfg.MaxHops-first+1, 0) * (1 + cfg.Rc.Count)
 	results := make(chan Hop, queueSize)
 	var wg sync.WaitGroup
 
-	for ttl := 1; ttl <= cfg.MaxHops; ttl++ {
+	for ttl := first; ttl <= cfg.MaxHops; ttl++ {
 		wg.Add(1)
 		go func(ttl int) {
 			c, hopSpan := tracer.Start(ctx, addr.String(), trace.WithAttributes(
@@ -216,7 +224,7 @@ func TraceRoute(ctx context.Context, cfg tracerouteConfig) (map[int][]Hop, error
 					attribute.Int("retry", retry),
 				))
 
-				hop, hErr := doHop(ctx, addr, ttl, cfg.Timeout)
+				hop, hErr := doHop(ctx, addr, ttl, cfg.Timeout, cfg.Network)
 				if hop != nil {
 					results <- *hop
 				}
@@ -233,7 +241,7 @@ func TraceRoute(ctx context.Context, cfg tracerouteConfig) (map[int][]Hop, error
 					return errors.New("failed to reach target")
 				}
 				return nil
-			}, cfg.Rc)(logctx)
+			}, cfg.Rc, cfg.RetryOpts...)(logctx)
 			if retryErr != nil {
 				l.DebugContext(ctx, "Traceroute could not reach target")
 				if !errors.Is(err, syscall.EHOSTUNREACH) {
@@ -263,7 +271,7 @@ func TraceRoute(ctx context.Context, cfg tracerouteConfig) (map[int][]Hop, error
 
 // doHop performs a hop to the given address with the specified TTL and timeout.
 // It returns a Hop struct containing the latency, TTL, address, and other details of the hop.
-func doHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration) (*Hop, error) {
+func doHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration, netCfg checks.NetworkConfig) (*Hop, error) {
 	span := trace.SpanFromContext(ctx)
 	log := logger.FromContext(ctx)
 	canIcmp, icmpListener, err := newIcmpListener()
@@ -276,7 +284,7 @@ func doHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration) (
 	defer closeIcmpListener(canIcmp, icmpListener)
 
 	start := time.Now()
-	conn, clientPort, err := tcpHop(ctx, addr, ttl, timeout)
+	conn, clientPort, err := tcpHop(ctx, addr, ttl, timeout, netCfg)
 	latency := time.Since(start)
 
 	span.SetAttributes(attribute.Int("ttl", ttl), attribute.Stringer("addr", addr))
@@ -0,0 +1,243 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// defaultSSHPort is the port of the jump host if none is configured
+const defaultSSHPort = "22"
+
+// TunnelConfig configures an SSH jump host the probes of a check are tunneled through.
+// It allows to probe targets only reachable via a bastion host.
+type TunnelConfig struct {
+	// Host is the address of the jump host. The port defaults to 22.
+	Host string `json:"host,omitempty" yaml:"host,omitempty" mapstructure:"host"`
+	// User is the user logging in to the jump host
+	User string `json:"user,omitempty" yaml:"user,omitempty" mapstructure:"user"`
+	// KeyFile is the path of the private key authenticating the user
+	KeyFile string `json:"keyFile,omitempty" yaml:"keyFile,omitempty" mapstructure:"keyFile"`
+	// KnownHostsFile is the path of the known_hosts file verifying the host key of the jump host
+	KnownHostsFile string `json:"knownHostsFile,omitempty" yaml:"knownHostsFile,omitempty" mapstructure:"knownHostsFile"`
+}
+
+// Enabled returns true if a jump host is configured
+func (c TunnelConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// Validate checks if the tunnel configuration is valid
+func (c TunnelConfig) Validate(checkName string) error {
+	if !c.Enabled() {
+		return nil
+	}
+	if !c.validAddress() {
+		return ErrInvalidConfig{CheckName: checkName, Field: "tunnel.host", Reason: "invalid address"}
+	}
+	if c.User == "" {
+		return ErrInvalidConfig{CheckName: checkName, Field: "tunnel.user", Reason: "must be set"}
+	}
+	if c.KeyFile == "" {
+		return ErrInvalidConfig{CheckName: checkName, Field: "tunnel.keyFile", Reason: "must be set"}
+	}
+	if c.KnownHostsFile == "" {
+		return ErrInvalidConfig{CheckName: checkName, Field: "tunnel.knownHostsFile", Reason: "must be set to verify the jump host"}
+	}
+	return nil
+}
+
+// address returns the address of the jump host including the port
+func (c TunnelConfig) address() string {
+	if _, _, err := net.SplitHostPort(c.Host); err == nil {
+		return c.Host
+	}
+	return net.JoinHostPort(c.Host, defaultSSHPort)
+}
+
+// validAddress returns true if the address of the jump host consists of a host name or IP and a numeric port
+func (c TunnelConfig) validAddress() bool {
+	host, port, err := net.SplitHostPort(c.address())
+	if err != nil || host == "" {
+		return false
+	}
+	if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+		return false
+	}
+	// Only IPv6 addresses contain colons
+	return !strings.Contains(host, ":") || net.ParseIP(host) != nil
+}
+
+// clientConfig returns the configuration of the SSH client logging in to the jump host
+func (c TunnelConfig) clientConfig(timeout time.Duration) (*ssh.ClientConfig, error) {
+	key, err := os.ReadFile(c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the private key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key: %w", err)
+	}
+	hostKeys, err := knownhosts.New(c.KnownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the known hosts: %w", err)
+	}
+
+	return &ssh.ClientConfig{
+		User:            c.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeys,
+		Timeout:         timeout,
+	}, nil
+}
+
+// Tunnel tunnels the connections of the probes of a check through an SSH jump host.
+// The SSH connection is established on the first dial and reused by the following ones.
+// It's established again on the next dial once it broke.
+// A nil Tunnel doesn't tunnel the connections.
+type Tunnel struct {
+	cfg     TunnelConfig
+	network NetworkConfig
+	dialer  *net.Dialer
+	// mu guards the client
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+// NewTunnel returns a tunnel through the configured jump host, which is dialed with the network configuration.
+// It returns nil if no jump host is configured.
+func NewTunnel(cfg TunnelConfig, network NetworkConfig, timeout time.Duration) *Tunnel {
+	if !cfg.Enabled() {
+		return nil
+	}
+	return &Tunnel{cfg: cfg, network: network, dialer: network.Dialer(timeout)}
+}
+
+// Update returns the tunnel for the new configuration. The tunnel is kept if the configuration
+// is unchanged, otherwise it's closed and replaced, so the next dial uses the new configuration.
+func (t *Tunnel) Update(cfg TunnelConfig, network NetworkConfig, timeout time.Duration) *Tunnel {
+	if t != nil && t.cfg == cfg && t.network == network && t.dialer.Timeout == timeout {
+		return t
+	}
+	_ = t.Close()
+	return NewTunnel(cfg, network, timeout)
+}
+
+// DialContext connects to the address through the jump host
+func (t *Tunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, err := t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := client.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s through jump host %s: %w", addr, t.cfg.Host, err)
+	}
+	return conn, nil
+}
+
+// Transport returns a clone of the transport, or the default transport if it's nil,
+// dialing its connections through the jump host
+func (t *Tunnel) Transport(rt http.RoundTripper) http.RoundTripper {
+	if t == nil {
+		return rt
+	}
+
+	tr, ok := rt.(*http.Transport)
+	if ok {
+		tr = tr.Clone()
+	} else {
+		tr = &http.Transport{}
+		if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+			tr = dt.Clone()
+		}
+	}
+	// The targets are resolved and reached by the jump host, so an environment proxy doesn't apply
+	tr.Proxy = nil
+	tr.DialContext = t.DialContext
+	return tr
+}
+
+// Close closes the SSH connection to the jump host
+func (t *Tunnel) Close() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.client == nil {
+		return nil
+	}
+	err := t.client.Close()
+	t.client = nil
+	return err
+}
+
+// connect returns the SSH connection to the jump host and establishes it if there is none
+func (t *Tunnel) connect(ctx context.Context) (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.client != nil {
+		return t.client, nil
+	}
+
+	cfg, err := t.cfg.clientConfig(t.dialer.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the ssh client of jump host %s: %w", t.cfg.Host, err)
+	}
+	addr := t.cfg.address()
+	conn, err := t.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial jump host %s: %w", t.cfg.Host, err)
+	}
+	// The handshake is bounded by the context, the established connection isn't
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, cfg)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to log in to jump host %s: %w", t.cfg.Host, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	client := ssh.NewClient(c, chans, reqs)
+	t.client = client
+	go func() {
+		// Once the connection broke, the next dial establishes a new one
+		_ = client.Wait()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.client == client {
+			t.client = nil
+		}
+	}()
+	return client, nil
+}
@@ -0,0 +1,268 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestTunnelConfig_Validate(t *testing.T) {
+	valid := TunnelConfig{Host: "bastion.example.com", User: "sparrow", KeyFile: "/id_ed25519", KnownHostsFile: "/known_hosts"}
+	tests := []struct {
+		name    string
+		cfg     func(c TunnelConfig) TunnelConfig
+		wantErr bool
+	}{
+		{name: "disabled", cfg: func(_ TunnelConfig) TunnelConfig { return TunnelConfig{} }},
+		{name: "valid", cfg: func(c TunnelConfig) TunnelConfig { return c }},
+		{name: "valid with port", cfg: func(c TunnelConfig) TunnelConfig { c.Host = "bastion.example.com:2222"; return c }},
+		{name: "valid ipv6", cfg: func(c TunnelConfig) TunnelConfig { c.Host = "[fd00::1]:2222"; return c }},
+		{name: "valid ipv6 without port", cfg: func(c TunnelConfig) TunnelConfig { c.Host = "fd00::1"; return c }},
+		{name: "invalid host", cfg: func(c TunnelConfig) TunnelConfig { c.Host = "bastion:22:22"; return c }, wantErr: true},
+		{name: "invalid port", cfg: func(c TunnelConfig) TunnelConfig { c.Host = "bastion.example.com:ssh"; return c }, wantErr: true},
+		{name: "missing user", cfg: func(c TunnelConfig) TunnelConfig { c.User = ""; return c }, wantErr: true},
+		{name: "missing key file", cfg: func(c TunnelConfig) TunnelConfig { c.KeyFile = ""; return c }, wantErr: true},
+		{name: "missing known hosts", cfg: func(c TunnelConfig) TunnelConfig { c.KnownHostsFile = ""; return c }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg(valid).Validate("health"); (err != nil) != tt.wantErr {
+				t.Errorf("TunnelConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTunnel_Disabled(t *testing.T) {
+	tun := NewTunnel(TunnelConfig{}, NetworkConfig{}, time.Second)
+	if tun != nil {
+		t.Fatalf("NewTunnel() = %v, want nil for a disabled tunnel", tun)
+	}
+	if rt := tun.Transport(nil); rt != nil {
+		t.Errorf("Tunnel.Transport() = %v, want the given transport", rt)
+	}
+	if err := tun.Close(); err != nil {
+		t.Errorf("Tunnel.Close() error = %v", err)
+	}
+}
+
+func TestTunnel_Update(t *testing.T) {
+	cfg := TunnelConfig{Host: "bastion.example.com", User: "sparrow", KeyFile: "/id_ed25519", KnownHostsFile: "/known_hosts"}
+	tun := NewTunnel(cfg, NetworkConfig{}, time.Second)
+
+	if got := tun.Update(cfg, NetworkConfig{}, time.Second); got != tun {
+		t.Error("Tunnel.Update() replaced the tunnel of an unchanged configuration")
+	}
+	if got := tun.Update(cfg, NetworkConfig{}, 2*time.Second); got == tun || got == nil {
+		t.Error("Tunnel.Update() kept the tunnel of a changed timeout")
+	}
+	if got := tun.Update(TunnelConfig{}, NetworkConfig{}, time.Second); got != nil {
+		t.Errorf("Tunnel.Update() = %v, want nil for a disabled tunnel", got)
+	}
+}
+
+func TestTunnel_Transport(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	cfg, forwarded := newJumpHost(t)
+	tun := NewTunnel(cfg, NetworkConfig{}, time.Second)
+	defer func() { _ = tun.Close() }()
+
+	client := &http.Client{Transport: tun.Transport(nil), Timeout: 5 * time.Second}
+	for range 2 {
+		resp, err := client.Get(target.URL)
+		if err != nil {
+			t.Fatalf("Failed to probe through the tunnel: %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Status = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+	}
+
+	select {
+	case addr := <-forwarded:
+		if addr != target.Listener.Addr().String() {
+			t.Errorf("Forwarded address = %q, want %q", addr, target.Listener.Addr().String())
+		}
+	default:
+		t.Error("The connection wasn't forwarded by the jump host")
+	}
+}
+
+func TestTunnel_UnknownHost(t *testing.T) {
+	cfg, _ := newJumpHost(t)
+	// Replace the known hosts with an unrelated key, so the jump host can't be verified
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(other)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	line := knownhosts.Line([]string{knownhosts.Normalize(cfg.Host)}, signer.PublicKey())
+	if err = os.WriteFile(cfg.KnownHostsFile, []byte(line+"\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write known hosts: %v", err)
+	}
+
+	tun := NewTunnel(cfg, NetworkConfig{}, time.Second)
+	defer func() { _ = tun.Close() }()
+	if _, err = tun.DialContext(context.Background(), "tcp", "127.0.0.1:80"); err == nil {
+		t.Error("Tunnel.DialContext() error = nil, want an error for an unknown host key")
+	}
+}
+
+// newJumpHost starts an SSH server forwarding direct-tcpip channels and returns
+// the tunnel configuration logging in to it and a channel receiving the forwarded addresses
+func newJumpHost(t *testing.T) (TunnelConfig, <-chan string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate host key: %v", err)
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatalf("Failed to create host signer: %v", err)
+	}
+	_, userKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate user key: %v", err)
+	}
+	userSigner, err := ssh.NewSignerFromKey(userKey)
+	if err != nil {
+		t.Fatalf("Failed to create user signer: %v", err)
+	}
+	block, err := ssh.MarshalPrivateKey(userKey, "")
+	if err != nil {
+		t.Fatalf("Failed to marshal user key: %v", err)
+	}
+	keyFile := filepath.Join(dir, "id_ed25519")
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("Failed to write user key: %v", err)
+	}
+
+	server := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if meta.User() == "sparrow" && string(key.Marshal()) == string(userSigner.PublicKey().Marshal()) {
+				return &ssh.Permissions{}, nil
+			}
+			return nil, io.ErrUnexpectedEOF
+		},
+	}
+	server.AddHostKey(hostSigner)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(ln.Addr().String())}, hostSigner.PublicKey())
+	if err = os.WriteFile(knownHostsFile, []byte(line+"\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write known hosts: %v", err)
+	}
+
+	forwarded := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveJumpHost(conn, server, forwarded)
+		}
+	}()
+
+	return TunnelConfig{
+		Host:           ln.Addr().String(),
+		User:           "sparrow",
+		KeyFile:        keyFile,
+		KnownHostsFile: knownHostsFile,
+	}, forwarded
+}
+
+// serveJumpHost forwards the direct-tcpip channels of the SSH connection to their destination
+func serveJumpHost(conn net.Conn, cfg *ssh.ServerConfig, forwarded chan<- string) {
+	sc, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	defer func() { _ = sc.Close() }()
+	go ssh.DiscardRequests(reqs)
+
+	for nc := range chans {
+		if nc.ChannelType() != "direct-tcpip" {
+			_ = nc.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		var payload struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(nc.ExtraData(), &payload); err != nil {
+			_ = nc.Reject(ssh.ConnectionFailed, "invalid payload")
+			continue
+		}
+		addr := net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port)))
+		target, err := net.Dial("tcp", addr)
+		if err != nil {
+			_ = nc.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		ch, creqs, err := nc.Accept()
+		if err != nil {
+			_ = target.Close()
+			continue
+		}
+		go ssh.DiscardRequests(creqs)
+		forwarded <- addr
+		go func() {
+			defer func() { _ = ch.Close() }()
+			defer func() { _ = target.Close() }()
+			go func() { _, _ = io.Copy(target, ch) }()
+			_, _ = io.Copy(ch, target)
+		}()
+	}
+}
@@ -0,0 +1,252 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package zone
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// udpSize is the EDNS0 UDP payload size advertised to the nameservers
+	udpSize = 1232
+	// maxMessageSize is the maximum size of a DNS message
+	maxMessageSize = 65535
+)
+
+// errTruncated is returned if the answer didn't fit into a UDP message
+var errTruncated = errors.New("answer is truncated")
+
+// queryFunc queries the records of a name with the given type from a single nameserver.
+// The answer of a SOA query is the serial of the zone.
+type queryFunc func(ctx context.Context, addr, name string, typ dnsmessage.Type, timeout time.Duration, netCfg checks.NetworkConfig) ([]string, error)
+
+// query sends a non-recursive query to the nameserver via UDP and retries it via TCP
+// if the answer is truncated. The answers are returned sorted, so they can be compared.
+// A non-existent name has no answers.
+func query(ctx context.Context, addr, name string, typ dnsmessage.Type, timeout time.Duration, netCfg checks.NetworkConfig) ([]string, error) {
+	q, err := dnsmessage.NewName(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %q: %w", name, err)
+	}
+	id, req, err := newRequest(q, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := exchange(ctx, "udp", addr, id, req, typ, timeout, netCfg)
+	if errors.Is(err, errTruncated) {
+		answers, err = exchange(ctx, "tcp", addr, id, req, typ, timeout, netCfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(answers)
+	return answers, nil
+}
+
+// newRequest builds a query for the name and type with a random id
+func newRequest(name dnsmessage.Name, typ dnsmessage.Type) (uint16, []byte, error) {
+	var buf [2]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, nil, fmt.Errorf("failed to generate query id: %w", err)
+	}
+	id := binary.BigEndian.Uint16(buf[:])
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return 0, nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: name, Type: typ, Class: dnsmessage.ClassINET}); err != nil {
+		return 0, nil, err
+	}
+	if err := b.StartAdditionals(); err != nil {
+		return 0, nil, err
+	}
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(udpSize, dnsmessage.RCodeSuccess, false); err != nil {
+		return 0, nil, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return 0, nil, err
+	}
+	req, err := b.Finish()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to build query: %w", err)
+	}
+	return id, req, nil
+}
+
+// exchange sends the request to the nameserver and parses its answer
+func exchange(ctx context.Context, network, addr string, id uint16, req []byte, typ dnsmessage.Type, timeout time.Duration, netCfg checks.NetworkConfig) ([]string, error) {
+	d := netCfg.Dialer(timeout)
+	if ip := netCfg.LocalIP(); ip != nil && network == "udp" {
+		d.LocalAddr = &net.UDPAddr{IP: ip}
+	}
+	conn, err := d.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nameserver: %w", err)
+	}
+	defer conn.Close() // #nosec G307
+
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, fmt.Errorf("failed to set deadline: %w", err)
+	}
+
+	if network == "tcp" {
+		return exchangeStream(conn, id, req, typ)
+	}
+
+	if _, err = conn.Write(req); err != nil {
+		return nil, fmt.Errorf("failed to send query: %w", err)
+	}
+	res := make([]byte, maxMessageSize)
+	for {
+		n, err := conn.Read(res)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read answer: %w", err)
+		}
+		answers, err := parseResponse(res[:n], id, typ)
+		// Answers not matching the query are ignored as they might be spoofed or duplicates
+		if errors.Is(err, errMismatch) {
+			continue
+		}
+		return answers, err
+	}
+}
+
+// exchangeStream sends the request over a stream connection, where
+// every message is prefixed with its length (RFC 1035 4.2.2)
+func exchangeStream(conn net.Conn, id uint16, req []byte, typ dnsmessage.Type) ([]string, error) {
+	msg := make([]byte, 2, 2+len(req))
+	binary.BigEndian.PutUint16(msg, uint16(len(req))) // #nosec G115 // Queries are far smaller than 64KiB
+	if _, err := conn.Write(append(msg, req...)); err != nil {
+		return nil, fmt.Errorf("failed to send query: %w", err)
+	}
+
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, fmt.Errorf("failed to read answer: %w", err)
+	}
+	res := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, res); err != nil {
+		return nil, fmt.Errorf("failed to read answer: %w", err)
+	}
+	return parseResponse(res, id, typ)
+}
+
+// errMismatch is returned if a message isn't the answer to the query
+var errMismatch = errors.New("message doesn't match the query")
+
+// parseResponse parses the answer to the query with the given id
+// and returns the formatted records of the queried type
+func parseResponse(b []byte, id uint16, typ dnsmessage.Type) ([]string, error) {
+	var p dnsmessage.Parser
+	h, err := p.Start(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse answer: %w", err)
+	}
+	if h.ID != id || !h.Response {
+		return nil, errMismatch
+	}
+	if h.Truncated {
+		return nil, errTruncated
+	}
+	if !h.Authoritative {
+		return nil, errors.New("nameserver is not authoritative for the zone")
+	}
+	switch h.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return []string{}, nil
+	default:
+		return nil, fmt.Errorf("nameserver answered with %s", h.RCode)
+	}
+
+	if err = p.SkipAllQuestions(); err != nil {
+		return nil, fmt.Errorf("failed to parse answer: %w", err)
+	}
+	answers := []string{}
+	for {
+		rh, err := p.AnswerHeader()
+		if errors.Is(err, dnsmessage.ErrSectionDone) {
+			return answers, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse answer: %w", err)
+		}
+		if rh.Type != typ {
+			if err = p.SkipAnswer(); err != nil {
+				return nil, fmt.Errorf("failed to parse answer: %w", err)
+			}
+			continue
+		}
+		answer, err := parseAnswer(&p, typ)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s record: %w", typ, err)
+		}
+		answers = append(answers, answer)
+	}
+}
+
+// parseAnswer formats the next answer of the parser
+func parseAnswer(p *dnsmessage.Parser, typ dnsmessage.Type) (string, error) {
+	switch typ {
+	case dnsmessage.TypeSOA:
+		r, err := p.SOAResource()
+		return strconv.FormatUint(uint64(r.Serial), 10), err
+	case dnsmessage.TypeA:
+		r, err := p.AResource()
+		return net.IP(r.A[:]).String(), err
+	case dnsmessage.TypeAAAA:
+		r, err := p.AAAAResource()
+		return net.IP(r.AAAA[:]).String(), err
+	case dnsmessage.TypeCNAME:
+		r, err := p.CNAMEResource()
+		return strings.ToLower(r.CNAME.String()), err
+	case dnsmessage.TypeNS:
+		r, err := p.NSResource()
+		return strings.ToLower(r.NS.String()), err
+	case dnsmessage.TypeMX:
+		r, err := p.MXResource()
+		return fmt.Sprintf("%d %s", r.Pref, strings.ToLower(r.MX.String())), err
+	case dnsmessage.TypeTXT:
+		r, err := p.TXTResource()
+		txt := make([]string, len(r.TXT))
+		for i, s := range r.TXT {
+			txt[i] = strconv.Quote(s)
+		}
+		return strings.Join(txt, " "), err
+	default:
+		return "", fmt.Errorf("unsupported type %s", typ)
+	}
+}
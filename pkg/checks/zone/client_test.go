@@ -0,0 +1,240 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package zone
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// handler answers a query of the test server
+type handler func(q dnsmessage.Question, tcp bool) (dnsmessage.Header, []dnsmessage.Resource)
+
+// newTestServer starts a nameserver on the loopback interface listening
+// on UDP and TCP on the same port and answering with the given handler
+func newTestServer(t *testing.T, h handler) string {
+	t.Helper()
+	var conn net.PacketConn
+	var ln net.Listener
+	for i := 0; ln == nil; i++ {
+		c, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to start test server: %v", err)
+		}
+		ln, err = net.Listen("tcp", c.LocalAddr().String())
+		if err != nil {
+			_ = c.Close()
+			if i == 10 {
+				t.Fatalf("Failed to start test server: %v", err)
+			}
+			continue
+		}
+		conn = c
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		_ = ln.Close()
+	})
+
+	go func() {
+		buf := make([]byte, maxMessageSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo(answer(t, buf[:n], h, false), addr)
+		}
+	}()
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			var length [2]byte
+			if _, err = io.ReadFull(c, length[:]); err != nil {
+				_ = c.Close()
+				continue
+			}
+			req := make([]byte, binary.BigEndian.Uint16(length[:]))
+			if _, err = io.ReadFull(c, req); err != nil {
+				_ = c.Close()
+				continue
+			}
+			res := answer(t, req, h, true)
+			binary.BigEndian.PutUint16(length[:], uint16(len(res))) // #nosec G115 // Test answers are small
+			_, _ = c.Write(append(length[:], res...))
+			_ = c.Close()
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// answer builds the answer of the handler to the request
+func answer(t *testing.T, req []byte, h handler, tcp bool) []byte {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(req); err != nil {
+		t.Errorf("Failed to parse query: %v", err)
+		return nil
+	}
+	header, answers := h(msg.Questions[0], tcp)
+	header.ID = msg.Header.ID
+	header.Response = true
+	res := dnsmessage.Message{Header: header, Questions: msg.Questions, Answers: answers}
+	b, err := res.Pack()
+	if err != nil {
+		t.Errorf("Failed to build answer: %v", err)
+	}
+	return b
+}
+
+func TestQuery(t *testing.T) {
+	name := dnsmessage.MustNewName("example.com.")
+	tests := []struct {
+		name    string
+		typ     dnsmessage.Type
+		handler handler
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "serial",
+			typ:  dnsmessage.TypeSOA,
+			handler: func(q dnsmessage.Question, _ bool) (dnsmessage.Header, []dnsmessage.Resource) {
+				return dnsmessage.Header{Authoritative: true}, []dnsmessage.Resource{
+					{Header: header(q), Body: &dnsmessage.SOAResource{NS: name, MBox: name, Serial: 2024010101}},
+				}
+			},
+			want: []string{"2024010101"},
+		},
+		{
+			name: "sorted records of the queried type",
+			typ:  dnsmessage.TypeA,
+			handler: func(q dnsmessage.Question, _ bool) (dnsmessage.Header, []dnsmessage.Resource) {
+				cname := header(q)
+				cname.Type = dnsmessage.TypeCNAME
+				return dnsmessage.Header{Authoritative: true}, []dnsmessage.Resource{
+					{Header: cname, Body: &dnsmessage.CNAMEResource{CNAME: name}},
+					{Header: header(q), Body: &dnsmessage.AResource{A: [4]byte{10, 0, 0, 2}}},
+					{Header: header(q), Body: &dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}}},
+				}
+			},
+			want: []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name: "mx records",
+			typ:  dnsmessage.TypeMX,
+			handler: func(q dnsmessage.Question, _ bool) (dnsmessage.Header, []dnsmessage.Resource) {
+				return dnsmessage.Header{Authoritative: true}, []dnsmessage.Resource{
+					{Header: header(q), Body: &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("Mail.Example.com.")}},
+				}
+			},
+			want: []string{"10 mail.example.com."},
+		},
+		{
+			name: "txt records",
+			typ:  dnsmessage.TypeTXT,
+			handler: func(q dnsmessage.Question, _ bool) (dnsmessage.Header, []dnsmessage.Resource) {
+				return dnsmessage.Header{Authoritative: true}, []dnsmessage.Resource{
+					{Header: header(q), Body: &dnsmessage.TXTResource{TXT: []string{"v=spf1", "-all"}}},
+				}
+			},
+			want: []string{`"v=spf1" "-all"`},
+		},
+		{
+			name: "non-existent name",
+			typ:  dnsmessage.TypeA,
+			handler: func(_ dnsmessage.Question, _ bool) (dnsmessage.Header, []dnsmessage.Resource) {
+				return dnsmessage.Header{Authoritative: true, RCode: dnsmessage.RCodeNameError}, nil
+			},
+			want: []string{},
+		},
+		{
+			name: "truncated answer is retried via tcp",
+			typ:  dnsmessage.TypeA,
+			handler: func(q dnsmessage.Question, tcp bool) (dnsmessage.Header, []dnsmessage.Resource) {
+				if !tcp {
+					return dnsmessage.Header{Authoritative: true, Truncated: true}, nil
+				}
+				return dnsmessage.Header{Authoritative: true}, []dnsmessage.Resource{
+					{Header: header(q), Body: &dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}}},
+				}
+			},
+			want: []string{"10.0.0.1"},
+		},
+		{
+			name: "not authoritative",
+			typ:  dnsmessage.TypeSOA,
+			handler: func(_ dnsmessage.Question, _ bool) (dnsmessage.Header, []dnsmessage.Resource) {
+				return dnsmessage.Header{}, nil
+			},
+			wantErr: "nameserver is not authoritative for the zone",
+		},
+		{
+			name: "server failure",
+			typ:  dnsmessage.TypeSOA,
+			handler: func(_ dnsmessage.Question, _ bool) (dnsmessage.Header, []dnsmessage.Resource) {
+				return dnsmessage.Header{Authoritative: true, RCode: dnsmessage.RCodeServerFailure}, nil
+			},
+			wantErr: "nameserver answered with RCodeServerFailure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := newTestServer(t, tt.handler)
+
+			got, err := query(context.Background(), addr, name.String(), tt.typ, time.Second, checks.NetworkConfig{})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseResponse_mismatch(t *testing.T) {
+	res, err := (&dnsmessage.Message{Header: dnsmessage.Header{ID: 1, Response: true}}).Pack()
+	if err != nil {
+		t.Fatalf("Failed to build answer: %v", err)
+	}
+
+	_, err = parseResponse(res, 2, dnsmessage.TypeA)
+	if !errors.Is(err, errMismatch) {
+		t.Errorf("parseResponse() error = %v, want %v", err, errMismatch)
+	}
+}
+
+// header returns the resource header of an answer to the question
+func header(q dnsmessage.Question) dnsmessage.ResourceHeader {
+	return dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 300}
+}
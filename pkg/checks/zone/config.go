@@ -0,0 +1,149 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package zone
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	minInterval = 100 * time.Millisecond
+	minTimeout  = 200 * time.Millisecond
+	// defaultPort is the port nameservers listen on
+	defaultPort = "53"
+)
+
+// recordTypes are the supported types of the compared records
+var recordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"NS":    dnsmessage.TypeNS,
+	"TXT":   dnsmessage.TypeTXT,
+}
+
+// Config defines the configuration parameters for a zone propagation check
+type Config struct {
+	// Zone is the zone to check, e.g. example.com
+	Zone string `json:"zone" yaml:"zone" mapstructure:"zone"`
+	// Targets is a list of authoritative nameservers of the zone. The port defaults to 53.
+	Targets []string `json:"targets" yaml:"targets" mapstructure:"targets"`
+	// Records are the records compared between the nameservers in addition to the serial
+	Records []Record `json:"records,omitempty" yaml:"records,omitempty" mapstructure:"records"`
+	// Interval is the time to wait between check iterations
+	Interval time.Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+	// Schedule is a cron expression defining when the check runs. It replaces the interval if set.
+	Schedule checks.Schedule `json:"schedule,omitempty" yaml:"schedule,omitempty" mapstructure:"schedule"`
+	// Timeout is the maximum time to wait for the answer of a nameserver
+	Timeout time.Duration `json:"timeout" yaml:"timeout" mapstructure:"timeout"`
+	// Retry defines if and how to retry a target
+	Retry helper.RetryConfig `json:"retry" yaml:"retry" mapstructure:"retry"`
+	// Network configures the DSCP marking and the source binding of the queries
+	Network checks.NetworkConfig `json:"network,omitempty" yaml:"network,omitempty" mapstructure:"network"`
+	// TargetRetries override the retry configuration for some targets
+	TargetRetries []checks.TargetRetry `json:"targetRetries,omitempty" yaml:"targetRetries,omitempty" mapstructure:"targetRetries"`
+}
+
+// Record is a record of the zone compared between the nameservers
+type Record struct {
+	// Name is the name of the record. Names without a trailing dot are relative to the zone, @ is the zone itself.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+	// Type is the type of the record
+	Type string `json:"type" yaml:"type" mapstructure:"type"`
+}
+
+// fqdn returns the fully qualified name of the record in the zone
+func (r Record) fqdn(zone string) string {
+	switch {
+	case r.Name == "@":
+		return fqdn(zone)
+	case strings.HasSuffix(r.Name, "."):
+		return strings.ToLower(r.Name)
+	default:
+		return fqdn(r.Name + "." + zone)
+	}
+}
+
+// key returns the key of the record in the results
+func (r Record) key(zone string) string {
+	return r.fqdn(zone) + " " + strings.ToUpper(r.Type)
+}
+
+func (c *Config) For() string {
+	return CheckName
+}
+
+func (c *Config) Validate() error {
+	if _, err := dnsmessage.NewName(fqdn(c.Zone)); err != nil || strings.Trim(c.Zone, ".") == "" {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "zone", Reason: "must be a valid domain name"}
+	}
+	for i, t := range c.Targets {
+		if t == "" || strings.Contains(t, "://") {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "must be a host name or an ip with an optional port"}
+		}
+	}
+	for i, r := range c.Records {
+		if _, err := dnsmessage.NewName(r.fqdn(c.Zone)); err != nil || r.Name == "" {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("records[%d].name", i), Reason: "must be a valid domain name"}
+		}
+		if _, ok := recordTypes[strings.ToUpper(r.Type)]; !ok {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("records[%d].type", i), Reason: "must be one of A, AAAA, CNAME, MX, NS or TXT"}
+		}
+	}
+	if !c.Schedule.Enabled() && c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+	if err := c.Schedule.Validate(c.For()); err != nil {
+		return err
+	}
+	if err := checks.ValidateRetries(CheckName, c.TargetRetries, c.Targets); err != nil {
+		return err
+	}
+
+	timing := checks.Timing{Interval: c.Interval, Timeout: c.Timeout, Schedule: c.Schedule, Retry: c.Retry, TargetRetries: c.TargetRetries}
+	if err := timing.Validate(CheckName); err != nil {
+		return err
+	}
+
+	return c.Network.Validate(CheckName)
+}
+
+// fqdn returns the lower case fully qualified form of a domain name
+func fqdn(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
+
+// address returns the address of a nameserver with the default port if none is given
+func address(target string) string {
+	if _, _, err := net.SplitHostPort(target); err == nil {
+		return target
+	}
+	return net.JoinHostPort(strings.Trim(target, "[]"), defaultPort)
+}
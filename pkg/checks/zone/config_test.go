@@ -0,0 +1,146 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package zone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				Zone:     "example.com",
+				Targets:  []string{"ns1.example.com", "10.0.0.1:5353"},
+				Records:  []Record{{Name: "@", Type: "NS"}, {Name: "www", Type: "a"}, {Name: "mail.example.com.", Type: "MX"}},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing zone",
+			config: Config{
+				Targets:  []string{"ns1.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid target",
+			config: Config{
+				Zone:     "example.com",
+				Targets:  []string{"udp://ns1.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing record name",
+			config: Config{
+				Zone:     "example.com",
+				Targets:  []string{"ns1.example.com"},
+				Records:  []Record{{Type: "A"}},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported record type",
+			config: Config{
+				Zone:     "example.com",
+				Targets:  []string{"ns1.example.com"},
+				Records:  []Record{{Name: "www", Type: "SRV"}},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			config: Config{
+				Zone:     "example.com",
+				Targets:  []string{"ns1.example.com"},
+				Interval: 10 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Zone:     "example.com",
+				Targets:  []string{"ns1.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  100 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid network",
+			config: Config{
+				Zone:     "example.com",
+				Targets:  []string{"ns1.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  1 * time.Second,
+				Network:  checks.NetworkConfig{SourceIP: "invalid"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecord_key(t *testing.T) {
+	tests := []struct {
+		record Record
+		want   string
+	}{
+		{record: Record{Name: "@", Type: "NS"}, want: "example.com. NS"},
+		{record: Record{Name: "www", Type: "a"}, want: "www.example.com. A"},
+		{record: Record{Name: "WWW.Example.org.", Type: "AAAA"}, want: "www.example.org. AAAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.record.key("Example.com."); got != tt.want {
+				t.Errorf("Record.key() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
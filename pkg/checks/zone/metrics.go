@@ -0,0 +1,105 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package zone
+
+import (
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics defines the metric collectors of the zone propagation check
+type metrics struct {
+	inSync    *prometheus.GaugeVec
+	serial    *prometheus.GaugeVec
+	serialLag *prometheus.GaugeVec
+	divergent *prometheus.GaugeVec
+}
+
+// newMetrics initializes metric collectors of the zone propagation check
+func newMetrics() metrics {
+	return metrics{
+		inSync: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_zone_in_sync",
+				Help: "Specifies if the nameserver answered with the newest serial and the same records as the other nameservers.",
+			},
+			[]string{"target"},
+		),
+		serial: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_zone_serial",
+				Help: "Serial of the zone served by the nameserver.",
+			},
+			[]string{"target"},
+		),
+		serialLag: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_zone_serial_lag",
+				Help: "Difference between the newest serial of all nameservers and the serial of the nameserver.",
+			},
+			[]string{"target"},
+		),
+		divergent: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_zone_divergent_records",
+				Help: "Number of records the nameserver answers differently than the majority of the nameservers.",
+			},
+			[]string{"target"},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.inSync,
+		m.serial,
+		m.serialLag,
+		m.divergent,
+	}
+}
+
+// Set sets the metrics of a target
+func (m *metrics) Set(target string, res result) {
+	inSync := 0.0
+	if res.InSync {
+		inSync = 1
+	}
+	m.inSync.WithLabelValues(target).Set(inSync)
+	m.serial.WithLabelValues(target).Set(float64(res.Serial))
+	m.serialLag.WithLabelValues(target).Set(float64(res.SerialLag))
+	m.divergent.WithLabelValues(target).Set(float64(len(res.DivergentRecords)))
+}
+
+// Remove removes the metrics of a target
+func (m *metrics) Remove(target string) error {
+	if !m.inSync.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+	if !m.serial.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+	if !m.serialLag.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+	if !m.divergent.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+	return nil
+}
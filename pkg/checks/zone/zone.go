@@ -0,0 +1,335 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package zone
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+var (
+	_ checks.Check   = (*Zone)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "zone"
+
+// SchemaVersion is the version of the layout of the check's result data.
+// It is incremented with every breaking change of the layout.
+const SchemaVersion = 1
+
+// Zone is a check that compares the serial and records of a zone
+// between its authoritative nameservers to detect stuck zone transfers
+type Zone struct {
+	checks.CheckBase
+	config  Config
+	metrics metrics
+	query   queryFunc
+}
+
+// NewCheck creates a new instance of the zone propagation check
+func NewCheck() checks.Check {
+	return &Zone{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics: newMetrics(),
+		query:   query,
+	}
+}
+
+// result represents the result of a single nameserver
+type result struct {
+	// Serial is the serial of the zone served by the nameserver
+	Serial uint32 `json:"serial" yaml:"serial"`
+	// SerialLag is the difference between the newest serial of all nameservers and the serial of the nameserver
+	SerialLag uint32 `json:"serialLag" yaml:"serialLag"`
+	// Records are the answers of the nameserver to the compared records
+	Records map[string][]string `json:"records,omitempty" yaml:"records,omitempty"`
+	// DivergentRecords are the records the nameserver answers differently than the majority of the nameservers
+	DivergentRecords []string `json:"divergentRecords,omitempty" yaml:"divergentRecords,omitempty"`
+	// InSync is true if the nameserver serves the newest serial and no divergent records
+	InSync bool `json:"inSync" yaml:"inSync"`
+	// Error is the error that occurred during the queries
+	Error *string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Run starts the zone propagation check
+func (z *Zone) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	log.Info("Starting zone check", "interval", z.config.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-z.DoneChan:
+			return nil
+		case <-z.Clock().After(z.config.Schedule.Next(z.Clock().Now(), z.config.Interval)):
+			res := z.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: z.Name(),
+				Result: &checks.Result{
+					Data:          res,
+					Timestamp:     z.Clock().Now(),
+					SchemaVersion: SchemaVersion,
+				},
+			}
+			log.Debug("Successfully finished zone check run")
+		}
+	}
+}
+
+// RunOnce runs the zone propagation check once against all targets
+func (z *Zone) RunOnce(ctx context.Context) (*checks.Result, []string) {
+	res := z.check(ctx)
+	failed := checks.FailedTargets(res, result.failed)
+	return &checks.Result{Data: res, Timestamp: z.Clock().Now(), SchemaVersion: SchemaVersion}, failed
+}
+
+// failed returns true if the target failed
+func (r result) failed() bool {
+	return r.Error != nil || !r.InSync
+}
+
+// Failures reports for every target in the data of a zone propagation check result whether it failed
+func Failures(data any) map[string]bool {
+	res, _ := checks.ResultData[result](data)
+	return checks.TargetFailures(res, result.failed)
+}
+
+func (z *Zone) Shutdown() {
+	z.DoneChan <- struct{}{}
+	close(z.DoneChan)
+}
+
+func (z *Zone) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		z.Mu.Lock()
+		defer z.Mu.Unlock()
+
+		if err := z.SetTargets(c.Targets, z.metrics.Remove); err != nil {
+			return err
+		}
+
+		z.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+func (z *Zone) GetConfig() checks.Runtime {
+	z.Mu.Lock()
+	defer z.Mu.Unlock()
+	return &z.config
+}
+
+func (z *Zone) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the zone propagation check
+func (z *Zone) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (z *Zone) GetMetricCollectors() []prometheus.Collector {
+	return z.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (z *Zone) RemoveLabelledMetrics(target string) error {
+	return z.RemoveSeries(target, z.metrics.Remove)
+}
+
+// check queries the serial and records of the zone from all nameservers
+// and compares their answers
+func (z *Zone) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Checking zone")
+	z.Mu.Lock()
+	cfg := z.config
+	z.Mu.Unlock()
+
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	log.Debug("Querying each nameserver in separate routine", "amount", len(cfg.Targets))
+	retries := checks.NewRetries(cfg.Retry, cfg.TargetRetries)
+	for _, target := range cfg.Targets {
+		wg.Add(1)
+		lo := log.With("target", target)
+		retry, opts := retries.For(target)
+
+		queryRetry := helper.Retry(func(ctx context.Context) error {
+			res, err := z.queryZone(ctx, address(target), &cfg)
+			if err != nil {
+				errval := err.Error()
+				res.Error = &errval
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[target] = res
+			return err
+		}, retry, opts...)
+
+		go func() {
+			defer wg.Done()
+			lo.Debug("Starting retry routine to query nameserver")
+			if err := queryRetry(ctx); err != nil {
+				lo.Warn("Error while querying nameserver", "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	compare(results)
+	for target, res := range results {
+		if !res.InSync && res.Error == nil {
+			log.Warn("Nameserver is out of sync", "target", target, "serialLag", res.SerialLag, "divergentRecords", res.DivergentRecords)
+		}
+		z.WithSeries(target, func(label string) {
+			z.metrics.Set(label, res)
+		})
+	}
+
+	log.Debug("Successfully queried all nameservers")
+	return results
+}
+
+// queryZone queries the serial of the zone and the configured records from a nameserver
+func (z *Zone) queryZone(ctx context.Context, addr string, cfg *Config) (result, error) {
+	soa, err := z.query(ctx, addr, fqdn(cfg.Zone), dnsmessage.TypeSOA, cfg.Timeout, cfg.Network)
+	if err != nil {
+		return result{}, err
+	}
+	if len(soa) != 1 {
+		return result{}, fmt.Errorf("expected one SOA record for zone %q, got %d", cfg.Zone, len(soa))
+	}
+	serial, err := strconv.ParseUint(soa[0], 10, 32)
+	if err != nil {
+		return result{}, fmt.Errorf("invalid serial %q: %w", soa[0], err)
+	}
+
+	res := result{Serial: uint32(serial), Records: map[string][]string{}}
+	for _, r := range cfg.Records {
+		answers, err := z.query(ctx, addr, r.fqdn(cfg.Zone), recordTypes[strings.ToUpper(r.Type)], cfg.Timeout, cfg.Network)
+		if err != nil {
+			return res, fmt.Errorf("failed to query %s: %w", r.key(cfg.Zone), err)
+		}
+		res.Records[r.key(cfg.Zone)] = answers
+	}
+	return res, nil
+}
+
+// compare compares the answers of the nameservers without errors. The newest serial is
+// determined with serial number arithmetic (RFC 1982) and every record is compared to
+// the answer of the majority of the nameservers.
+func compare(results map[string]result) {
+	var newest uint32
+	first := true
+	answers := map[string]map[string]int{}
+	for _, res := range results {
+		if res.Error != nil {
+			continue
+		}
+		if first || newer(res.Serial, newest) {
+			newest = res.Serial
+			first = false
+		}
+		for key, a := range res.Records {
+			if answers[key] == nil {
+				answers[key] = map[string]int{}
+			}
+			answers[key][strings.Join(a, "\n")]++
+		}
+	}
+
+	majority := make(map[string]string, len(answers))
+	for key, counts := range answers {
+		majority[key] = mostCommon(counts)
+	}
+
+	for target, res := range results {
+		if res.Error != nil {
+			continue
+		}
+		res.SerialLag = newest - res.Serial
+		res.DivergentRecords = nil
+		for key, a := range res.Records {
+			if strings.Join(a, "\n") != majority[key] {
+				res.DivergentRecords = append(res.DivergentRecords, key)
+			}
+		}
+		slices.Sort(res.DivergentRecords)
+		res.InSync = res.SerialLag == 0 && len(res.DivergentRecords) == 0
+		results[target] = res
+	}
+}
+
+// newer returns true if serial a is newer than serial b (RFC 1982)
+func newer(a, b uint32) bool {
+	return a != b && int32(a-b) > 0 // #nosec G115 // The wrap around is intended
+}
+
+// mostCommon returns the most common answer. Ties are broken by
+// choosing the lexically smallest answer to be deterministic.
+func mostCommon(counts map[string]int) string {
+	var best string
+	n := -1
+	for answer, c := range counts {
+		if c > n || (c == n && answer < best) {
+			best, n = answer, c
+		}
+	}
+	return best
+}
@@ -0,0 +1,224 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package zone
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestZone_Run(t *testing.T) {
+	// answers are the answers of the nameservers by address and queried name
+	type answers map[string]map[string][]string
+	tests := []struct {
+		name    string
+		answers answers
+		targets []string
+		records []Record
+		want    map[string]result
+	}{
+		{
+			name:    "success with no targets",
+			targets: []string{},
+			want:    map[string]result{},
+		},
+		{
+			name: "nameservers in sync",
+			answers: answers{
+				"ns1.example.com:53": {"example.com.": {"10"}, "www.example.com.": {"10.0.0.1"}},
+				"ns2.example.com:53": {"example.com.": {"10"}, "www.example.com.": {"10.0.0.1"}},
+			},
+			targets: []string{"ns1.example.com", "ns2.example.com"},
+			records: []Record{{Name: "www", Type: "A"}},
+			want: map[string]result{
+				"ns1.example.com": {Serial: 10, Records: map[string][]string{"www.example.com. A": {"10.0.0.1"}}, InSync: true},
+				"ns2.example.com": {Serial: 10, Records: map[string][]string{"www.example.com. A": {"10.0.0.1"}}, InSync: true},
+			},
+		},
+		{
+			name: "stuck zone transfer",
+			answers: answers{
+				"ns1.example.com:53": {"example.com.": {"12"}, "www.example.com.": {"10.0.0.2"}},
+				"ns2.example.com:53": {"example.com.": {"12"}, "www.example.com.": {"10.0.0.2"}},
+				"ns3.example.com:53": {"example.com.": {"10"}, "www.example.com.": {"10.0.0.1"}},
+			},
+			targets: []string{"ns1.example.com", "ns2.example.com", "ns3.example.com"},
+			records: []Record{{Name: "www", Type: "A"}},
+			want: map[string]result{
+				"ns1.example.com": {Serial: 12, Records: map[string][]string{"www.example.com. A": {"10.0.0.2"}}, InSync: true},
+				"ns2.example.com": {Serial: 12, Records: map[string][]string{"www.example.com. A": {"10.0.0.2"}}, InSync: true},
+				"ns3.example.com": {
+					Serial:           10,
+					SerialLag:        2,
+					Records:          map[string][]string{"www.example.com. A": {"10.0.0.1"}},
+					DivergentRecords: []string{"www.example.com. A"},
+				},
+			},
+		},
+		{
+			name: "serial wraps around",
+			answers: answers{
+				"ns1.example.com:53": {"example.com.": {"1"}},
+				"ns2.example.com:53": {"example.com.": {"4294967295"}},
+			},
+			targets: []string{"ns1.example.com", "ns2.example.com"},
+			want: map[string]result{
+				"ns1.example.com": {Serial: 1, Records: map[string][]string{}, InSync: true},
+				"ns2.example.com": {Serial: 4294967295, SerialLag: 2, Records: map[string][]string{}},
+			},
+		},
+		{
+			name: "error during query",
+			answers: answers{
+				"ns1.example.com:53": {"example.com.": {"10"}},
+			},
+			targets: []string{"ns1.example.com", "ns2.example.com"},
+			want: map[string]result{
+				"ns1.example.com": {Serial: 10, Records: map[string][]string{}, InSync: true},
+				"ns2.example.com": {Error: stringPointer("nameserver is not authoritative for the zone")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCommonZone()
+			c.query = func(_ context.Context, addr, name string, _ dnsmessage.Type, _ time.Duration, _ checks.NetworkConfig) ([]string, error) {
+				ns, ok := tt.answers[addr]
+				if !ok {
+					return nil, errors.New("nameserver is not authoritative for the zone")
+				}
+				return ns[name], nil
+			}
+
+			cResult := make(chan checks.ResultDTO, 1)
+			defer close(cResult)
+
+			err := c.UpdateConfig(&Config{
+				Zone:     "example.com",
+				Targets:  tt.targets,
+				Records:  tt.records,
+				Interval: 10 * time.Millisecond,
+				Timeout:  5 * time.Millisecond,
+			})
+			if err != nil {
+				t.Fatalf("Zone.UpdateConfig() error = %v", err)
+			}
+
+			go func() {
+				err := c.Run(context.Background(), cResult)
+				if err != nil {
+					t.Errorf("Zone.Run() error = %v", err)
+					return
+				}
+			}()
+			defer c.Shutdown()
+
+			r := <-cResult
+			got, ok := r.Result.Data.(map[string]result)
+			if !ok {
+				t.Fatalf("Zone.Run() result data has type %T", r.Result.Data)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestZone_UpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   checks.Runtime
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "simple config",
+			input: &Config{
+				Zone:     "example.com",
+				Targets:  []string{"ns1.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+			},
+			want: Config{
+				Zone:     "example.com",
+				Targets:  []string{"ns1.example.com"},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+			},
+		},
+		{
+			name:    "wrong type",
+			input:   &health.Config{Targets: []string{"https://example.com"}},
+			want:    Config{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Zone{}
+
+			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("Zone.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, c.config, "Config is not equal")
+		})
+	}
+}
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b uint32
+		want bool
+	}{
+		{name: "greater", a: 2, b: 1, want: true},
+		{name: "smaller", a: 1, b: 2, want: false},
+		{name: "equal", a: 1, b: 1, want: false},
+		{name: "wrap around", a: 1, b: 4294967295, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newer(tt.a, tt.b); got != tt.want {
+				t.Errorf("newer(%d, %d) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func newCommonZone() *Zone {
+	return &Zone{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		metrics: newMetrics(),
+	}
+}
@@ -0,0 +1,27 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package client provides a typed client for the API of a sparrow.
+//
+// The client, its models and the TypeScript types in sparrow.d.ts are generated from the OpenAPI specification
+// in openapi.yaml. The specification describes the API of a sparrow running every known check without tenants,
+// the same way it's served at /openapi. Run go generate after changing the API, a test fails if the files are outdated.
+package client
+
+//go:generate go run ../../scripts/gen-openapi gen-openapi --spec openapi.yaml --ts sparrow.d.ts
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.4.1 -config oapi-codegen.yaml openapi.yaml
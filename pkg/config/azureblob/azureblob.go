@@ -0,0 +1,181 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package azureblob implements a loader reading the runtime configuration from a blob in an Azure Blob Storage
+// container. It's registered as loader type "azureblob" and authenticates with the identity of the workload,
+// either through AKS workload identity or a managed identity.
+package azureblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/oauth"
+)
+
+// LoaderType is the name the loader is registered as
+const LoaderType = "azureblob"
+
+// apiVersion is the version of the Blob Storage REST API. Bearer tokens require 2017-11-09 or later.
+const apiVersion = "2021-08-06"
+
+func init() {
+	config.RegisterLoader(LoaderType, config.LoaderType{
+		New: func(cfg *config.Config, cRuntime chan<- runtime.Config) config.Loader {
+			return NewLoader(cfg, cRuntime)
+		},
+		Validate: validate,
+	})
+}
+
+var (
+	// ErrInvalidConfig is returned when the options of the azureblob loader are invalid
+	ErrInvalidConfig = errors.New("invalid azureblob loader configuration")
+)
+
+// Config is the configuration of the azureblob loader, set in the options of the loader configuration
+type Config struct {
+	// Account is the name of the storage account
+	Account string `mapstructure:"account"`
+	// Endpoint is the URL of the blob service. Defaults to https://<account>.blob.core.windows.net.
+	Endpoint string `mapstructure:"endpoint"`
+	// Container is the name of the container containing the runtime configuration
+	Container string `mapstructure:"container"`
+	// Blob is the name of the blob containing the runtime configuration
+	Blob string `mapstructure:"blob"`
+	// ClientID is the client ID of the workload identity or the user-assigned managed identity.
+	// Defaults to the AZURE_CLIENT_ID environment variable. The system-assigned managed identity is used if empty.
+	ClientID string `mapstructure:"clientId"`
+	// Timeout is the timeout of a request
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Retry configures the retries of a failed request
+	Retry helper.RetryConfig `mapstructure:"retry"`
+}
+
+// newConfig decodes the configuration of the azureblob loader from the loader configuration and applies the defaults
+func newConfig(lc *config.LoaderConfig) (Config, error) {
+	var c Config
+	if err := lc.DecodeOptions(&c); err != nil {
+		return c, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if c.Endpoint == "" && c.Account != "" {
+		c.Endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", c.Account)
+	}
+	if c.ClientID == "" {
+		c.ClientID = os.Getenv(clientIDEnv)
+	}
+	return c, nil
+}
+
+// validate validates the options of the azureblob loader
+func validate(ctx context.Context, lc *config.LoaderConfig) error {
+	log := logger.FromContext(ctx)
+
+	c, err := newConfig(lc)
+	if err != nil {
+		log.Error("The options of the azureblob loader cannot be decoded", "error", err)
+		return err
+	}
+	if c.Endpoint == "" || c.Container == "" || c.Blob == "" {
+		log.Error("The account or endpoint, container and blob of the azureblob loader are required")
+		return fmt.Errorf("%w: account or endpoint, container and blob are required", ErrInvalidConfig)
+	}
+	u, err := url.ParseRequestURI(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		log.Error("The endpoint of the azureblob loader is not a valid http(s) url", "endpoint", c.Endpoint)
+		return fmt.Errorf("%w: invalid endpoint %q", ErrInvalidConfig, c.Endpoint)
+	}
+	if c.Retry.Count < 0 || c.Retry.Count >= 5 {
+		log.Error("The amount of azureblob loader retries should be equal or above 0 and below 5", "retryCount", c.Retry.Count)
+		return fmt.Errorf("%w: invalid retry count", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// NewLoader creates a loader reading the runtime configuration from the blob configured in the options
+// of the loader configuration. The options are validated before, so decoding errors are ignored.
+func NewLoader(cfg *config.Config, cRuntime chan<- runtime.Config) *config.FetchLoader {
+	c, _ := newConfig(&cfg.Loader)
+	client := &http.Client{Timeout: c.Timeout}
+	b := &blob{cfg: c, client: client, tokens: newIdentity(client, c.ClientID)}
+	return config.NewFetchLoader(cfg, cRuntime, config.Fetcher{
+		Name:   LoaderType,
+		Source: b.url(),
+		Retry:  c.Retry,
+		Fetch:  b.fetch,
+	})
+}
+
+// blob is the blob in a container containing the runtime configuration
+type blob struct {
+	cfg    Config
+	client *http.Client
+	// tokens acquires the access tokens of the workload
+	tokens *oauth.TokenSource
+}
+
+// fetch downloads the blob from the container
+func (b *blob) fetch(ctx context.Context) (body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.url(), http.NoBody)
+	if err != nil {
+		return nil, helper.Permanent(err)
+	}
+	req.Header.Set("x-ms-version", apiVersion)
+	if err = b.tokens.Authorize(ctx, req); err != nil {
+		return nil, fmt.Errorf("could not acquire access token: %w", err)
+	}
+
+	res, err := b.client.Do(req) //nolint:bodyclose // closed in the deferred function
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		if cErr := Body.Close(); cErr != nil {
+			err = errors.Join(err, cErr)
+		}
+	}(res.Body)
+
+	if res.StatusCode != http.StatusOK {
+		err = fmt.Errorf("request failed, status is %s", res.Status)
+		if !helper.RetryableStatus(res.StatusCode) {
+			return nil, helper.Permanent(err)
+		}
+		return nil, err
+	}
+	return io.ReadAll(res.Body)
+}
+
+// url returns the URL of the blob
+func (b *blob) url() string {
+	segments := strings.Split(b.cfg.Blob, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(b.cfg.Endpoint, "/"), url.PathEscape(b.cfg.Container), strings.Join(segments, "/"))
+}
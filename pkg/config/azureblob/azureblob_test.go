@@ -0,0 +1,162 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureblob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+)
+
+func TestNewLoader(t *testing.T) {
+	tests := []struct {
+		name             string
+		workloadIdentity bool
+		blobCode         int
+		wantErr          bool
+	}{
+		{name: "workload identity", workloadIdentity: true, blobCode: http.StatusOK},
+		{name: "managed identity", blobCode: http.StatusOK},
+		{name: "missing blob", blobCode: http.StatusNotFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /tenant/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("Failed to parse token request: %v", err)
+				}
+				if r.PostForm.Get("client_assertion") != "federated-token" || r.PostForm.Get("client_id") != "client" ||
+					r.PostForm.Get("scope") != "https://storage.azure.com/.default" {
+					t.Errorf("Token request = %v, want the exchange of the federated token", r.PostForm)
+				}
+				_, _ = w.Write([]byte(`{"access_token":"workload-token","expires_in":3599,"token_type":"Bearer"}`))
+			})
+			mux.HandleFunc("GET /metadata/identity/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("resource") != storageResource ||
+					r.URL.Query().Get("client_id") != "client" {
+					t.Errorf("Token request = %v, want a managed identity token for storage", r.URL.Query())
+				}
+				_, _ = w.Write([]byte(`{"access_token":"managed-token","expires_in":"3599","token_type":"Bearer"}`))
+			})
+			mux.HandleFunc("GET /configs/sparrow/config%20v1.yaml", func(w http.ResponseWriter, r *http.Request) {
+				want := "Bearer managed-token"
+				if tt.workloadIdentity {
+					want = "Bearer workload-token"
+				}
+				if r.Header.Get("Authorization") != want {
+					t.Errorf("Authorization = %q, want %q", r.Header.Get("Authorization"), want)
+				}
+				if r.Header.Get("x-ms-version") != apiVersion {
+					t.Error("Request is missing the API version")
+				}
+				w.WriteHeader(tt.blobCode)
+				_, _ = w.Write([]byte("health:\n  interval: 20s\n  timeout: 5s\n  targets:\n    - https://example.com\n"))
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			t.Setenv(clientIDEnv, "client")
+			t.Setenv(federatedTokenFileEnv, "")
+			if tt.workloadIdentity {
+				file := filepath.Join(t.TempDir(), "token")
+				if err := os.WriteFile(file, []byte("federated-token\n"), 0o600); err != nil {
+					t.Fatalf("Failed to write federated token: %v", err)
+				}
+				t.Setenv(federatedTokenFileEnv, file)
+				t.Setenv(tenantIDEnv, "tenant")
+				t.Setenv(authorityHostEnv, srv.URL)
+			}
+			endpoint := imdsEndpoint
+			imdsEndpoint = srv.URL + "/metadata/identity/oauth2/token"
+			t.Cleanup(func() { imdsEndpoint = endpoint })
+
+			cfg := &config.Config{Loader: config.LoaderConfig{
+				Type:    LoaderType,
+				Options: map[string]any{"endpoint": srv.URL, "container": "configs", "blob": "sparrow/config v1.yaml"},
+			}}
+			if err := cfg.Loader.Validate(context.Background()); err != nil {
+				t.Fatalf("LoaderConfig.Validate() error = %v", err)
+			}
+
+			cRuntime := make(chan runtime.Config, 1)
+			err := config.NewLoader(cfg, cRuntime).Run(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchLoader.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := <-cRuntime
+			if tt.wantErr {
+				return
+			}
+			if got.Health == nil || got.Provenance == nil || got.Provenance.Source != srv.URL+"/configs/sparrow/config%20v1.yaml" {
+				t.Errorf("FetchLoader.Run() config = %+v, want the health check of the blob", got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "account",
+			options: map[string]any{"account": "sparrow", "container": "configs", "blob": "config.yaml"},
+			want:    "https://sparrow.blob.core.windows.net",
+		},
+		{
+			name:    "endpoint",
+			options: map[string]any{"endpoint": "https://blob.example.com", "container": "configs", "blob": "config.yaml"},
+			want:    "https://blob.example.com",
+		},
+		{name: "missing account", options: map[string]any{"container": "configs", "blob": "config.yaml"}, wantErr: true},
+		{name: "missing blob", options: map[string]any{"account": "sparrow", "container": "configs"}, wantErr: true},
+		{name: "unknown option", options: map[string]any{"account": "sparrow", "container": "configs", "blob": "config.yaml", "key": "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &config.LoaderConfig{Type: LoaderType, Options: tt.options}
+			err := lc.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoaderConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidConfig) {
+					t.Errorf("LoaderConfig.Validate() error = %v, want %v", err, ErrInvalidConfig)
+				}
+				return
+			}
+			if c, _ := newConfig(lc); c.Endpoint != tt.want {
+				t.Errorf("newConfig() endpoint = %q, want %q", c.Endpoint, tt.want)
+			}
+		})
+	}
+}
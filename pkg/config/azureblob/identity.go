@@ -0,0 +1,134 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package azureblob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/oauth"
+)
+
+const (
+	// storageResource is the resource the access tokens for Azure Storage are requested for
+	storageResource = "https://storage.azure.com/"
+
+	// The environment variables AKS workload identity injects into the pods
+	clientIDEnv           = "AZURE_CLIENT_ID"
+	tenantIDEnv           = "AZURE_TENANT_ID"
+	federatedTokenFileEnv = "AZURE_FEDERATED_TOKEN_FILE"
+	authorityHostEnv      = "AZURE_AUTHORITY_HOST"
+
+	// defaultAuthorityHost is the host of Microsoft Entra ID in the public cloud
+	defaultAuthorityHost = "https://login.microsoftonline.com/"
+)
+
+// imdsEndpoint is the token endpoint of the managed identity of the Azure Instance Metadata Service
+var imdsEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// tokenResponse is the response of the token endpoints.
+// The managed identity endpoint returns the lifetime of the token as string.
+type tokenResponse struct {
+	AccessToken string      `json:"access_token"`
+	ExpiresIn   json.Number `json:"expires_in"`
+}
+
+// newIdentity returns a token source acquiring the access tokens for Azure Storage.
+// The federated token of AKS workload identity is exchanged if it's injected into the pod,
+// otherwise the token of the managed identity with the client ID is requested.
+func newIdentity(client *http.Client, clientID string) *oauth.TokenSource {
+	return oauth.NewTokenSourceFunc(func(ctx context.Context) (string, time.Duration, error) {
+		if file := os.Getenv(federatedTokenFileEnv); file != "" {
+			return workloadIdentityToken(ctx, client, clientID, file)
+		}
+		return managedIdentityToken(ctx, client, clientID)
+	})
+}
+
+// workloadIdentityToken exchanges the federated service account token in the file for an access token
+func workloadIdentityToken(ctx context.Context, client *http.Client, clientID, file string) (string, time.Duration, error) {
+	// The token is rotated by the kubelet, so it's read for every exchange
+	assertion, err := os.ReadFile(file) //nolint:gosec // the path is injected by the workload identity webhook
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read federated token: %w", err)
+	}
+	authority := os.Getenv(authorityHostEnv)
+	if authority == "" {
+		authority = defaultAuthorityHost
+	}
+
+	form := url.Values{
+		"client_id":             {clientID},
+		"scope":                 {storageResource + ".default"},
+		"grant_type":            {"client_credentials"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {strings.TrimSpace(string(assertion))},
+	}
+	u := fmt.Sprintf("%s/%s/oauth2/v2.0/token", strings.TrimSuffix(authority, "/"), url.PathEscape(os.Getenv(tenantIDEnv)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return requestToken(client, req)
+}
+
+// managedIdentityToken requests an access token of the managed identity from the Instance Metadata Service
+func managedIdentityToken(ctx context.Context, client *http.Client, clientID string) (string, time.Duration, error) {
+	query := url.Values{"api-version": {"2018-02-01"}, "resource": {storageResource}}
+	if clientID != "" {
+		query.Set("client_id", clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imdsEndpoint+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Metadata", "true")
+	return requestToken(client, req)
+}
+
+// requestToken sends the token request and decodes the access token and its lifetime
+func requestToken(client *http.Client, req *http.Request) (string, time.Duration, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer func(Body io.ReadCloser) { _ = Body.Close() }(res.Body)
+
+	if res.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("token request failed, status is %s", res.Status)
+	}
+	var tr tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&tr); err != nil {
+		return "", 0, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tr.AccessToken == "" {
+		return "", 0, errors.New("token response contains no access token")
+	}
+	expiresIn, _ := tr.ExpiresIn.Int64()
+	return tr.AccessToken, time.Duration(expiresIn) * time.Second, nil
+}
@@ -21,16 +21,31 @@ package config
 import (
 	"time"
 
+	"github.com/caas-team/sparrow/pkg/admin"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/email"
+	"github.com/caas-team/sparrow/pkg/heartbeat"
+	"github.com/caas-team/sparrow/pkg/hub"
+	"github.com/caas-team/sparrow/pkg/incident"
+	"github.com/caas-team/sparrow/pkg/kafka"
+	"github.com/caas-team/sparrow/pkg/mqtt"
+	"github.com/caas-team/sparrow/pkg/oauth"
+	"github.com/caas-team/sparrow/pkg/pushgateway"
+	"github.com/caas-team/sparrow/pkg/slo"
 	"github.com/caas-team/sparrow/pkg/sparrow/metrics"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets"
+	"github.com/caas-team/sparrow/pkg/webhook"
 
 	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/tuning"
 	"github.com/caas-team/sparrow/pkg/api"
 )
 
 type Config struct {
 	// SparrowName is the DNS name of the sparrow
 	SparrowName string `yaml:"name" mapstructure:"name"`
+	// Identity is the configuration for resolving the identity of the sparrow
+	Identity IdentityConfig `yaml:"identity" mapstructure:"identity"`
 	// Loader is the configuration for the loader
 	Loader LoaderConfig `yaml:"loader" mapstructure:"loader"`
 	// Api is the configuration for the api server
@@ -39,6 +54,40 @@ type Config struct {
 	TargetManager targets.TargetManagerConfig `yaml:"targetManager" mapstructure:"targetManager"`
 	// Telemetry is the configuration for the telemetry
 	Telemetry metrics.Config `yaml:"telemetry" mapstructure:"telemetry"`
+	// Hub is the configuration for pushing results to and receiving results from other sparrows
+	Hub hub.Config `yaml:"hub" mapstructure:"hub"`
+	// Webhooks is the configuration of the webhooks the results are sent to
+	Webhooks webhook.Config `yaml:"webhooks" mapstructure:"webhooks"`
+	// Email is the configuration of the email receivers the results are sent to
+	Email email.Config `yaml:"email" mapstructure:"email"`
+	// Mqtt is the configuration of the MQTT brokers the results are published to
+	Mqtt mqtt.Config `yaml:"mqtt" mapstructure:"mqtt"`
+	// Kafka is the configuration of the Kafka topics the results are published to
+	Kafka kafka.Config `yaml:"kafka" mapstructure:"kafka"`
+	// Incidents is the configuration of the incident management systems the failed targets are reported to
+	Incidents incident.Config `yaml:"incidents" mapstructure:"incidents"`
+	// SLO is the configuration of the service level objectives evaluated from the results
+	SLO slo.Config `yaml:"slo" mapstructure:"slo"`
+	// Tenants are additional logical groups of checks with their own runtime configuration
+	Tenants []TenantConfig `yaml:"tenants" mapstructure:"tenants"`
+	// Once is the configuration for running the checks once instead of continuously
+	Once OnceConfig `yaml:"once" mapstructure:"once"`
+	// Snapshot is the configuration for restoring the results of a replaced sparrow
+	Snapshot SnapshotConfig `yaml:"snapshot" mapstructure:"snapshot"`
+	// Results is the configuration for exporting the results to the hub, webhooks, brokers and incident systems
+	Results ResultsConfig `yaml:"results" mapstructure:"results"`
+	// DB is the configuration of the database storing the latest results of the checks
+	DB db.Config `yaml:"db" mapstructure:"db"`
+	// Admin is the configuration for the admin listener serving the debug endpoints
+	Admin admin.Config `yaml:"admin" mapstructure:"admin"`
+	// Tuning is the configuration for tuning the Go runtime to the resource limits of the container
+	Tuning tuning.Config `yaml:"tuning" mapstructure:"tuning"`
+	// Pushgateway is the configuration for pushing the metrics to a Prometheus Pushgateway
+	Pushgateway pushgateway.Config `yaml:"pushgateway" mapstructure:"pushgateway"`
+	// Heartbeat is the configuration for sending heartbeats to a dead man's switch
+	Heartbeat heartbeat.Config `yaml:"heartbeat" mapstructure:"heartbeat"`
+	// Shutdown is the configuration for the report of the state of the sparrow on shutdown
+	Shutdown ShutdownConfig `yaml:"shutdown" mapstructure:"shutdown"`
 }
 
 // LoaderConfig is the configuration for loader
@@ -47,6 +96,20 @@ type LoaderConfig struct {
 	Interval time.Duration    `yaml:"interval" mapstructure:"interval"`
 	Http     HttpLoaderConfig `yaml:"http" mapstructure:"http"`
 	File     FileLoaderConfig `yaml:"file" mapstructure:"file"`
+	// Gitlab is the configuration for the loader reading the runtime configuration from a gitlab repository
+	Gitlab GitlabLoaderConfig `yaml:"gitlab" mapstructure:"gitlab"`
+	// Push is the configuration for pushing the runtime configuration through the API
+	Push PushLoaderConfig `yaml:"push" mapstructure:"push"`
+	// Options is the configuration of a loader type registered in addition to the built-in loaders.
+	// It's decoded by the loader type with DecodeOptions.
+	Options map[string]any `yaml:"options,omitempty" mapstructure:"options"`
+}
+
+// PushLoaderConfig is the configuration for pushing the runtime configuration through the API
+// in addition to the configured loader
+type PushLoaderConfig struct {
+	// Token is the bearer token the pushes are authorized with. Pushing is disabled if empty.
+	Token string `yaml:"token" mapstructure:"token"`
 }
 
 // HttpLoaderConfig is the configuration for the http loader
@@ -55,6 +118,45 @@ type HttpLoaderConfig struct {
 	Token    string             `yaml:"token" mapstructure:"token"`
 	Timeout  time.Duration      `yaml:"timeout" mapstructure:"timeout"`
 	RetryCfg helper.RetryConfig `yaml:"retry" mapstructure:"retry"`
+	// Cache is the path of the file the last successfully loaded configuration is stored in.
+	// It's applied if the configuration can't be loaded on startup. Disabled if empty.
+	Cache string `yaml:"cache" mapstructure:"cache"`
+	// OAuth2 acquires the bearer token with the client credentials grant instead of using a static token
+	OAuth2 oauth.Config `yaml:"oauth2" mapstructure:"oauth2"`
+}
+
+// OnceConfig is the configuration for running every check once, e.g. as a batch job
+type OnceConfig struct {
+	// Enabled runs every configured check once, writes the results and exits
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Output is the file the results are written to as JSON. Defaults to stdout.
+	Output string `yaml:"output" mapstructure:"output"`
+}
+
+// ShutdownConfig is the configuration for the report summarizing the state of the sparrow on shutdown
+type ShutdownConfig struct {
+	// Report is the file the shutdown report is written to as JSON. The report is only logged if empty.
+	Report string `yaml:"report" mapstructure:"report"`
+}
+
+// SnapshotConfig is the configuration for restoring the results exported by another sparrow on startup
+type SnapshotConfig struct {
+	// Import is the file or http(s) URL of the snapshot, e.g. the export endpoint of the replaced sparrow.
+	// Disabled if empty.
+	Import string `yaml:"import" mapstructure:"import"`
+	// Timeout is the timeout for fetching the snapshot from a URL
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+	// Token is the bearer token the snapshot is fetched with, i.e. the admin token of the replaced sparrow
+	Token string `yaml:"token" mapstructure:"token"`
+}
+
+// ResultsConfig is the configuration for exporting the results of the checks
+type ResultsConfig struct {
+	// ChangesOnly exports a result only if its data differs from the last exported result of its check
+	ChangesOnly bool `yaml:"changesOnly" mapstructure:"changesOnly"`
+	// FullInterval is the interval in which a result is exported even if it's unchanged.
+	// Only applies if ChangesOnly is set. Disabled if 0.
+	FullInterval time.Duration `yaml:"fullInterval" mapstructure:"fullInterval"`
 }
 
 // FileLoaderConfig is the configuration for the file loader
@@ -62,11 +164,116 @@ type FileLoaderConfig struct {
 	Path string `yaml:"path" mapstructure:"path"`
 }
 
+// GitlabLoaderConfig is the configuration for the gitlab loader
+type GitlabLoaderConfig struct {
+	// BaseURL is the URL of the gitlab instance
+	BaseURL string `yaml:"baseUrl" mapstructure:"baseUrl"`
+	// Token is the access token used to authenticate with the gitlab instance
+	Token string `yaml:"token" mapstructure:"token"`
+	// ProjectID is the ID of the project in the gitlab instance that contains the runtime configuration
+	ProjectID int `yaml:"projectId" mapstructure:"projectId"`
+	// Ref is the branch, tag or commit SHA the runtime configuration is read from.
+	// Defaults to the default branch of the project if empty.
+	Ref string `yaml:"ref" mapstructure:"ref"`
+	// Path is the path of the runtime configuration file within the repository
+	Path     string             `yaml:"path" mapstructure:"path"`
+	Timeout  time.Duration      `yaml:"timeout" mapstructure:"timeout"`
+	RetryCfg helper.RetryConfig `yaml:"retry" mapstructure:"retry"`
+	// Cache is the path of the file the last successfully loaded configuration is stored in.
+	// It's applied if the configuration can't be loaded on startup. Disabled if empty.
+	Cache string `yaml:"cache" mapstructure:"cache"`
+}
+
 // HasTargetManager returns true if the config has a target manager
 func (c *Config) HasTargetManager() bool {
 	return c.TargetManager.Enabled
 }
 
+// HasConfigPush returns true if the runtime configuration can be pushed through the API
+func (c *Config) HasConfigPush() bool {
+	return c.Loader.Push.Token != ""
+}
+
+// HasCheckRun returns true if the checks can be run on demand through the API
+func (c *Config) HasCheckRun() bool {
+	return c.Api.RunToken != ""
+}
+
+// HasOnce returns true if the checks should run once instead of continuously
+func (c *Config) HasOnce() bool {
+	return c.Once.Enabled
+}
+
+// HasAdmin returns true if the admin listener is enabled
+func (c *Config) HasAdmin() bool {
+	return c.Admin.Enabled
+}
+
+// HasPushgateway returns true if the metrics are pushed to a Pushgateway
+func (c *Config) HasPushgateway() bool {
+	return c.Pushgateway.Enabled
+}
+
+// HasHeartbeat returns true if heartbeats are sent to a dead man's switch
+func (c *Config) HasHeartbeat() bool {
+	return c.Heartbeat.Enabled
+}
+
+// HasSnapshotImport returns true if a snapshot is imported on startup
+func (c *Config) HasSnapshotImport() bool {
+	return c.Snapshot.Import != ""
+}
+
+// HasChangesOnly returns true if only changed results are exported
+func (c *Config) HasChangesOnly() bool {
+	return c.Results.ChangesOnly
+}
+
+// HasTenants returns true if the config has tenants configured
+func (c *Config) HasTenants() bool {
+	return len(c.Tenants) > 0
+}
+
+// HasHubPush returns true if the config has the push of results to a hub enabled
+func (c *Config) HasHubPush() bool {
+	return c.Hub.Push.Enabled
+}
+
+// HasHubReceiver returns true if the config has the reception of results from other sparrows enabled
+func (c *Config) HasHubReceiver() bool {
+	return c.Hub.Receiver.Enabled
+}
+
+// HasWebhooks returns true if the config has webhook receivers configured
+func (c *Config) HasWebhooks() bool {
+	return c.Webhooks.Enabled()
+}
+
+// HasEmail returns true if the config has email receivers configured
+func (c *Config) HasEmail() bool {
+	return c.Email.Enabled()
+}
+
+// HasMqtt returns true if the config has mqtt receivers configured
+func (c *Config) HasMqtt() bool {
+	return c.Mqtt.Enabled()
+}
+
+// HasKafka returns true if the config has kafka receivers configured
+func (c *Config) HasKafka() bool {
+	return c.Kafka.Enabled()
+}
+
+// HasIncidents returns true if the config has incident receivers configured
+func (c *Config) HasIncidents() bool {
+	return c.Incidents.Enabled()
+}
+
+// HasSLOs returns true if the config has service level objectives configured
+func (c *Config) HasSLOs() bool {
+	return c.SLO.Enabled()
+}
+
 // HasTelemetry returns true if the config has telemetry enabled
 func (c *Config) HasTelemetry() bool {
 	return c.Telemetry.Enabled
@@ -0,0 +1,68 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// DecodeHook returns the hook decoding the values of the startup configuration into their fields.
+// Durations are only accepted as strings with a unit, e.g. "500ms" or "2m".
+// Bare numbers are rejected, as it's ambiguous whether they mean seconds or nanoseconds.
+func DecodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		decodeDuration,
+		mapstructure.StringToSliceHookFunc(","),
+	)
+}
+
+// decodeDuration decodes a duration with a unit into a time.Duration field.
+// A bare 0 is accepted, as it's unambiguous.
+func decodeDuration(from, to reflect.Type, data any) (any, error) {
+	if to != durationType || from == durationType {
+		return data, nil
+	}
+
+	switch from.Kind() {
+	case reflect.String:
+		s := data.(string)
+		if _, err := strconv.ParseFloat(s, 64); err == nil && s != "0" {
+			return nil, fmt.Errorf("%w: %q, add a unit, e.g. \"%ss\"", ErrAmbiguousDuration, s, s)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		return d, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if reflect.ValueOf(data).IsZero() {
+			return time.Duration(0), nil
+		}
+		return nil, fmt.Errorf("%w: %v, add a unit, e.g. \"%vs\"", ErrAmbiguousDuration, data, data)
+	default:
+		return data, nil
+	}
+}
@@ -0,0 +1,78 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeHook(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     map[string]any
+		want      time.Duration
+		wantErr   bool
+		ambiguous bool
+	}{
+		{name: "duration with unit", input: map[string]any{"interval": "500ms"}, want: 500 * time.Millisecond},
+		{name: "composed duration", input: map[string]any{"interval": "1m30s"}, want: 90 * time.Second},
+		{name: "decoded duration", input: map[string]any{"interval": 2 * time.Minute}, want: 2 * time.Minute},
+		{name: "bare zero", input: map[string]any{"interval": 0}, want: 0},
+		{name: "zero string", input: map[string]any{"interval": "0"}, want: 0},
+		{name: "bare integer", input: map[string]any{"interval": 300}, wantErr: true, ambiguous: true},
+		{name: "bare float", input: map[string]any{"interval": 1.5}, wantErr: true, ambiguous: true},
+		{name: "number as string", input: map[string]any{"interval": "300"}, wantErr: true, ambiguous: true},
+		{name: "invalid duration", input: map[string]any{"interval": "five minutes"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out struct {
+				Interval time.Duration `mapstructure:"interval"`
+			}
+			dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{DecodeHook: DecodeHook(), Result: &out})
+			require.NoError(t, err)
+
+			err = dec.Decode(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.ambiguous, errors.Is(err, ErrAmbiguousDuration))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.Interval)
+		})
+	}
+
+	t.Run("comma separated slice", func(t *testing.T) {
+		var out struct {
+			Scopes []string `mapstructure:"scopes"`
+		}
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{DecodeHook: DecodeHook(), Result: &out})
+		require.NoError(t, err)
+		require.NoError(t, dec.Decode(map[string]any{"scopes": "read,write"}))
+		assert.Equal(t, []string{"read", "write"}, out.Scopes)
+	})
+}
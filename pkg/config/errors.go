@@ -23,12 +23,36 @@ import "errors"
 var (
 	// ErrInvalidSparrowName is returned when the sparrow name is invalid
 	ErrInvalidSparrowName = errors.New("invalid sparrow name")
+	// ErrIdentityDetection is returned when the identity of the sparrow cannot be detected
+	ErrIdentityDetection = errors.New("failed to detect identity")
+	// ErrInvalidLoaderType is returned when the loader type isn't registered
+	ErrInvalidLoaderType = errors.New("invalid loader type")
 	// ErrInvalidLoaderInterval is returned when the loader interval is invalid
 	ErrInvalidLoaderInterval = errors.New("invalid loader interval")
 	// ErrInvalidLoaderHttpURL is returned when the loader http url is invalid
 	ErrInvalidLoaderHttpURL = errors.New("invalid loader http url")
 	// ErrInvalidLoaderHttpRetryCount is returned when the loader http retry count is invalid
 	ErrInvalidLoaderHttpRetryCount = errors.New("invalid loader http retry count")
+	// ErrInvalidLoaderHttpAuth is returned when the loader http authentication is invalid
+	ErrInvalidLoaderHttpAuth = errors.New("invalid loader http auth")
 	// ErrInvalidLoaderFilePath is returned when the loader file path is invalid
 	ErrInvalidLoaderFilePath = errors.New("invalid loader file path")
+	// ErrInvalidLoaderGitlabURL is returned when the loader gitlab base url is invalid
+	ErrInvalidLoaderGitlabURL = errors.New("invalid loader gitlab base url")
+	// ErrInvalidLoaderGitlabProject is returned when the loader gitlab project id is invalid
+	ErrInvalidLoaderGitlabProject = errors.New("invalid loader gitlab project id")
+	// ErrInvalidLoaderGitlabPath is returned when the loader gitlab file path is invalid
+	ErrInvalidLoaderGitlabPath = errors.New("invalid loader gitlab file path")
+	// ErrInvalidLoaderGitlabRetryCount is returned when the loader gitlab retry count is invalid
+	ErrInvalidLoaderGitlabRetryCount = errors.New("invalid loader gitlab retry count")
+	// ErrInvalidTenantName is returned when the tenant name is invalid
+	ErrInvalidTenantName = errors.New("invalid tenant name")
+	// ErrDuplicateTenant is returned when a tenant is configured multiple times
+	ErrDuplicateTenant = errors.New("duplicate tenant")
+	// ErrInvalidSnapshotImport is returned when the snapshot to import is neither a file nor a http(s) url
+	ErrInvalidSnapshotImport = errors.New("invalid snapshot import")
+	// ErrInvalidResultsFullInterval is returned when the interval of the forced export of unchanged results is invalid
+	ErrInvalidResultsFullInterval = errors.New("invalid results full interval")
+	// ErrAmbiguousDuration is returned when a duration is configured as a bare number without a unit
+	ErrAmbiguousDuration = errors.New("ambiguous duration without a unit")
 )
@@ -0,0 +1,159 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/clock"
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+)
+
+// Fetcher describes how a FetchLoader fetches the raw runtime configuration from a remote source
+type Fetcher struct {
+	// Name is the loader reported in the provenance of the loaded configuration
+	Name string
+	// Source is the location reported in the provenance of the loaded configuration. It must not contain credentials.
+	Source string
+	// Retry configures the retries of a failed fetch
+	Retry helper.RetryConfig
+	// Fetch returns the raw runtime configuration.
+	// Errors marked with helper.Permanent aren't retried.
+	Fetch func(ctx context.Context) ([]byte, error)
+}
+
+var _ Loader = (*FetchLoader)(nil)
+
+// FetchLoader periodically fetches the runtime configuration with a Fetcher.
+// The fetched configuration is rendered and validated. It's meant as base of the registered loader types
+// reading the configuration from a remote source, so they only need to implement the fetch.
+type FetchLoader struct {
+	fetcher  Fetcher
+	interval time.Duration
+	cRuntime chan<- runtime.Config
+	done     chan struct{}
+	// instanceName is the name of the sparrow the templates of the configuration are rendered with
+	instanceName string
+	loaderMetrics
+	// Source is the clock the loader schedules its reloads with
+	clock.Source
+}
+
+// NewFetchLoader creates a loader fetching the runtime configuration with the fetcher in the loader interval
+func NewFetchLoader(cfg *Config, cRuntime chan<- runtime.Config, f Fetcher) *FetchLoader {
+	return &FetchLoader{
+		fetcher:       f,
+		interval:      cfg.Loader.Interval,
+		cRuntime:      cRuntime,
+		done:          make(chan struct{}, 1),
+		instanceName:  cfg.SparrowName,
+		loaderMetrics: newLoaderMetrics(),
+	}
+}
+
+// Run fetches the runtime configuration.
+// The config will be loaded periodically defined by the loader interval configuration.
+// If the interval is 0, the configuration is only fetched once and the loader is disabled.
+func (l *FetchLoader) Run(ctx context.Context) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx).With("loader", l.fetcher.Name)
+
+	var cfg runtime.Config
+	retry := helper.Retry(func(ctx context.Context) (err error) {
+		cfg, err = l.getRuntimeConfig(ctx)
+		return err
+	}, l.fetcher.Retry)
+	getConfigRetry := func(ctx context.Context) error {
+		start := l.Clock().Now()
+		err := retry(ctx)
+		l.observe(l.Clock().Since(start), &cfg, err)
+		return err
+	}
+
+	// Get the runtime configuration once on startup
+	err := getConfigRetry(ctx)
+	if err != nil {
+		log.Warn("Could not fetch runtime configuration", "error", err)
+		err = fmt.Errorf("could not fetch runtime configuration: %w", err)
+	} else {
+		l.lastSuccess.SetToCurrentTime()
+	}
+	l.cRuntime <- cfg
+
+	if l.interval == 0 {
+		log.Info("Fetch Loader disabled")
+		return err
+	}
+
+	tick := l.Clock().NewTicker(l.interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			log.Info("Fetch Loader terminated")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick.C():
+			if err := getConfigRetry(ctx); err != nil {
+				log.Warn("Could not fetch runtime configuration", "error", err)
+				tick.Reset(l.interval)
+				continue
+			}
+
+			log.Info("Successfully fetched runtime configuration")
+			l.lastSuccess.SetToCurrentTime()
+			l.cRuntime <- cfg
+			tick.Reset(l.interval)
+		}
+	}
+}
+
+// getRuntimeConfig fetches, renders and validates the runtime configuration
+func (l *FetchLoader) getRuntimeConfig(ctx context.Context) (runtime.Config, error) {
+	b, err := l.fetcher.Fetch(ctx)
+	if err != nil {
+		return runtime.Config{}, err
+	}
+
+	// An invalid configuration won't become valid on a retry
+	cfg, err := ParseRuntimeConfig(b, l.instanceName)
+	if err != nil {
+		return runtime.Config{}, helper.Permanent(err)
+	}
+	cfg.Provenance = checks.NewProvenance(l.fetcher.Name, l.fetcher.Source, b)
+	return cfg, nil
+}
+
+// Shutdown stops the loader routine
+func (l *FetchLoader) Shutdown(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	select {
+	case l.done <- struct{}{}:
+		log.Debug("Sending signal to shut down fetch loader")
+	default:
+	}
+}
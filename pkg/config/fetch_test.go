@@ -0,0 +1,119 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+)
+
+func TestFetchLoader_Run(t *testing.T) {
+	const valid = "health:\n  interval: 20s\n  timeout: 5s\n  targets:\n    - https://{{ .instanceName }}\n"
+	errFetch := errors.New("fetch failed")
+
+	tests := []struct {
+		name      string
+		responses []error
+		body      string
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "valid configuration", responses: []error{nil}, body: valid, wantCalls: 1},
+		{name: "transient error", responses: []error{errFetch, nil}, body: valid, wantCalls: 2},
+		{name: "permanent error", responses: []error{helper.Permanent(errFetch)}, wantCalls: 1, wantErr: true},
+		{name: "invalid configuration", responses: []error{nil}, body: "health:\n  targets:\n    - not a url\n", wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			cRuntime := make(chan runtime.Config, 1)
+			l := NewFetchLoader(&Config{SparrowName: "sparrow.example.com"}, cRuntime, Fetcher{
+				Name:   "test",
+				Source: "test://configs/config.yaml",
+				Retry:  helper.RetryConfig{Count: 2, Delay: time.Millisecond},
+				Fetch: func(context.Context) ([]byte, error) {
+					err := tt.responses[min(calls, len(tt.responses)-1)]
+					calls++
+					if err != nil {
+						return nil, err
+					}
+					return []byte(tt.body), nil
+				},
+			})
+
+			var results []LoadResult
+			l.OnLoad(func(r LoadResult) { results = append(results, r) })
+
+			err := l.Run(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchLoader.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("FetchLoader.Run() fetched %d times, want %d", calls, tt.wantCalls)
+			}
+			if len(results) != 1 || (results[0].Result == LoadError) != tt.wantErr {
+				t.Errorf("FetchLoader.Run() load results = %+v", results)
+			}
+
+			cfg := <-cRuntime
+			if tt.wantErr {
+				if !cfg.Empty() {
+					t.Errorf("FetchLoader.Run() applied a configuration after a failed fetch: %+v", cfg)
+				}
+				return
+			}
+			if cfg.Health == nil || cfg.Health.Targets[0] != "https://sparrow.example.com" {
+				t.Fatalf("FetchLoader.Run() config = %+v, want the rendered health check", cfg)
+			}
+			if cfg.Provenance == nil || cfg.Provenance.Loader != "test" || cfg.Provenance.Source != "test://configs/config.yaml" {
+				t.Errorf("FetchLoader.Run() provenance = %+v", cfg.Provenance)
+			}
+		})
+	}
+}
+
+func TestFetchLoader_Shutdown(t *testing.T) {
+	cRuntime := make(chan runtime.Config, 1)
+	l := NewFetchLoader(&Config{Loader: LoaderConfig{Interval: time.Hour}}, cRuntime, Fetcher{
+		Name: "test",
+		Fetch: func(context.Context) ([]byte, error) {
+			return []byte("health:\n  interval: 20s\n  timeout: 5s\n  targets:\n    - https://example.com\n"), nil
+		},
+	})
+
+	errC := make(chan error, 1)
+	go func() { errC <- l.Run(context.Background()) }()
+	<-cRuntime
+	l.Shutdown(context.Background())
+
+	select {
+	case err := <-errC:
+		if err != nil {
+			t.Errorf("FetchLoader.Run() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("FetchLoader.Run() didn't terminate after the shutdown")
+	}
+}
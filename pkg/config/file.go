@@ -26,9 +26,10 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"time"
 
+	"github.com/caas-team/sparrow/internal/clock"
 	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
 	"gopkg.in/yaml.v3"
 )
@@ -40,6 +41,11 @@ type FileLoader struct {
 	cRuntime chan<- runtime.Config
 	done     chan struct{}
 	fsys     fs.FS
+	// instanceName is the name of the sparrow the templates of the configuration are rendered with
+	instanceName string
+	loaderMetrics
+	// Source is the clock the loader schedules its reloads with
+	clock.Source
 }
 
 func NewFileLoader(cfg *Config, cRuntime chan<- runtime.Config) *FileLoader {
@@ -48,6 +54,9 @@ func NewFileLoader(cfg *Config, cRuntime chan<- runtime.Config) *FileLoader {
 		cRuntime: cRuntime,
 		done:     make(chan struct{}, 1),
 		fsys:     os.DirFS(filepath.Dir(cfg.Loader.File.Path)),
+
+		instanceName:  cfg.SparrowName,
+		loaderMetrics: newLoaderMetrics(),
 	}
 }
 
@@ -60,10 +69,14 @@ func (f *FileLoader) Run(ctx context.Context) error {
 	log := logger.FromContext(ctx)
 
 	// Get the runtime configuration once on startup
+	start := f.Clock().Now()
 	cfg, err := f.getRuntimeConfig(ctx)
+	f.observe(f.Clock().Since(start), &cfg, err)
 	if err != nil {
 		log.Warn("Could not get local runtime configuration", "error", err)
 		err = fmt.Errorf("could not get local runtime configuration: %w", err)
+	} else {
+		f.lastSuccess.SetToCurrentTime()
 	}
 	f.cRuntime <- cfg
 
@@ -72,7 +85,7 @@ func (f *FileLoader) Run(ctx context.Context) error {
 		return err
 	}
 
-	tick := time.NewTicker(f.config.Interval)
+	tick := f.Clock().NewTicker(f.config.Interval)
 	defer tick.Stop()
 
 	for {
@@ -82,8 +95,10 @@ func (f *FileLoader) Run(ctx context.Context) error {
 			return nil
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-tick.C:
+		case <-tick.C():
+			start := f.Clock().Now()
 			runtimeCfg, err := f.getRuntimeConfig(ctx)
+			f.observe(f.Clock().Since(start), &runtimeCfg, err)
 			if err != nil {
 				log.Warn("Could not get local runtime configuration", "error", err)
 				tick.Reset(f.config.Interval)
@@ -91,6 +106,7 @@ func (f *FileLoader) Run(ctx context.Context) error {
 			}
 
 			log.Info("Successfully got local runtime configuration")
+			f.lastSuccess.SetToCurrentTime()
 			f.cRuntime <- runtimeCfg
 			tick.Reset(f.config.Interval)
 		}
@@ -120,11 +136,24 @@ func (f *FileLoader) getRuntimeConfig(ctx context.Context) (cfg runtime.Config,
 		return cfg, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if err := yaml.Unmarshal(b, &cfg); err != nil {
+	rendered, err := RenderRuntimeConfig(b, f.instanceName)
+	if err != nil {
+		log.Error("Failed to render config file", "error", err)
+		return cfg, fmt.Errorf("failed to render config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(rendered, &cfg); err != nil {
 		log.Error("Failed to parse config file", "error", err)
 		return cfg, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if err := cfg.Validate(); err != nil {
+		log.Error("Invalid runtime configuration", "error", err)
+		return cfg, fmt.Errorf("invalid runtime configuration: %w", err)
+	}
+
+	cfg.Provenance = checks.NewProvenance("file", f.config.File.Path, b)
+	cfg.Provenance.Commit = gitCommit(filepath.Dir(f.config.File.Path))
 	return cfg, nil
 }
 
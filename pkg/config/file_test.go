@@ -22,10 +22,14 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"testing/fstest"
 	"time"
 
+	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/checks/health"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
 	"github.com/caas-team/sparrow/pkg/config/test"
@@ -65,7 +69,7 @@ func TestFileLoader_Run(t *testing.T) {
 			want: runtime.Config{
 				Health: &health.Config{
 					Targets:  []string{"http://localhost:8080/health"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 			},
@@ -83,7 +87,7 @@ func TestFileLoader_Run(t *testing.T) {
 			want: runtime.Config{
 				Health: &health.Config{
 					Targets:  []string{"http://localhost:8080/health"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 			},
@@ -110,6 +114,10 @@ func TestFileLoader_Run(t *testing.T) {
 
 			if !tt.wantErr {
 				config := <-result
+				if p := config.Provenance; p == nil || p.Loader != "file" || p.Source != tt.config.File.Path || p.Digest == "" {
+					t.Errorf("Expected the provenance of the file, got %+v", p)
+				}
+				config.Provenance = nil
 				if !reflect.DeepEqual(config, tt.want) {
 					t.Errorf("Expected config to be %v, got %v", tt.want, config)
 				}
@@ -156,6 +164,25 @@ func TestFileLoader_getRuntimeConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Invalid Config File",
+			config: LoaderConfig{
+				Type:     "file",
+				Interval: 1 * time.Second,
+				File: FileLoaderConfig{
+					Path: "test/data/invalid.yaml",
+				},
+			},
+			mockFS: func(_ *testing.T) fs.FS {
+				return &test.MockFS{
+					OpenFunc: func(name string) (fs.File, error) {
+						content := []byte("health:\n  targets: [\"https://example.com\"]\n  interval: 10s\n  timeout: 5s\n  retry:\n    count: 3\n")
+						return &test.MockFile{Content: content}, nil
+					},
+				}
+			},
+			wantErr: true,
+		},
 		{
 			name: "Failed to close file",
 			config: LoaderConfig{
@@ -239,3 +266,62 @@ func TestFileLoader_getRuntimeConfig(t *testing.T) {
 		})
 	}
 }
+
+// addSeeds adds the real runtime configurations as the seed corpus of the fuzz test
+func TestFileLoader_getRuntimeConfig_template(t *testing.T) {
+	t.Setenv("SPARROW_TEST_REGION", "eu-west")
+	b := []byte("health:\n" +
+		"  targets:\n" +
+		"    - https://{{ env \"SPARROW_TEST_REGION\" }}.example.com/health?from={{ .instanceName }}\n" +
+		"  interval: 10s\n" +
+		"  timeout: 1s\n")
+
+	l := NewFileLoader(&Config{SparrowName: "sparrow.example.com", Loader: LoaderConfig{File: FileLoaderConfig{Path: "config.yaml"}}}, nil)
+	l.fsys = fstest.MapFS{"config.yaml": &fstest.MapFile{Data: b}}
+
+	cfg, err := l.getRuntimeConfig(context.Background())
+	if err != nil {
+		t.Fatalf("getRuntimeConfig() error = %v", err)
+	}
+	want := []string{"https://eu-west.example.com/health?from=sparrow.example.com"}
+	if !reflect.DeepEqual(cfg.Health.Targets, want) {
+		t.Errorf("getRuntimeConfig() targets = %v, want %v", cfg.Health.Targets, want)
+	}
+	if cfg.Provenance == nil || cfg.Provenance.Digest != checks.NewProvenance("file", "", b).Digest {
+		t.Errorf("getRuntimeConfig() provenance = %+v, want the digest of the unrendered config", cfg.Provenance)
+	}
+}
+
+func addSeeds(f *testing.F) {
+	f.Helper()
+	paths, err := filepath.Glob(filepath.Join("..", "checks", "runtime", "testdata", "configs", "*.yaml"))
+	if err != nil {
+		f.Fatalf("Failed to find seed configurations: %v", err)
+	}
+	for _, path := range append(paths, filepath.Join("test", "data", "config.yaml")) {
+		b, err := os.ReadFile(path) //#nosec G304 // test data
+		if err != nil {
+			f.Fatalf("Failed to read seed configuration: %v", err)
+		}
+		f.Add(b)
+	}
+	f.Add([]byte("this is not a valid yaml content"))
+	f.Add([]byte("health: [\"https://example.com\"]\n"))
+}
+
+// FuzzFileLoader_getRuntimeConfig verifies malformed configuration files are rejected without panicking
+func FuzzFileLoader_getRuntimeConfig(f *testing.F) {
+	addSeeds(f)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		l := NewFileLoader(&Config{Loader: LoaderConfig{File: FileLoaderConfig{Path: "config.yaml"}}}, nil)
+		l.fsys = fstest.MapFS{"config.yaml": &fstest.MapFile{Data: b}}
+
+		var want runtime.Config
+		rendered, rErr := RenderRuntimeConfig(b, "")
+		wantErr := rErr != nil || yaml.Unmarshal(rendered, &want) != nil || want.Validate() != nil
+		_, err := l.getRuntimeConfig(context.Background())
+		if (err != nil) != wantErr {
+			t.Errorf("getRuntimeConfig() error = %v, wantErr %v", err, wantErr)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package gcs implements a loader reading the runtime configuration from an object in a Google Cloud Storage bucket.
+// It's registered as loader type "gcs" and authenticates with the service account of the workload,
+// e.g. through GKE workload identity.
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/oauth"
+)
+
+// LoaderType is the name the loader is registered as
+const LoaderType = "gcs"
+
+// defaultEndpoint is the endpoint of the Cloud Storage JSON API
+const defaultEndpoint = "https://storage.googleapis.com"
+
+func init() {
+	config.RegisterLoader(LoaderType, config.LoaderType{
+		New: func(cfg *config.Config, cRuntime chan<- runtime.Config) config.Loader {
+			return NewLoader(cfg, cRuntime)
+		},
+		Validate: validate,
+	})
+}
+
+var (
+	// ErrInvalidConfig is returned when the options of the gcs loader are invalid
+	ErrInvalidConfig = errors.New("invalid gcs loader configuration")
+)
+
+// Config is the configuration of the gcs loader, set in the options of the loader configuration
+type Config struct {
+	// Endpoint is the URL of the Cloud Storage JSON API. Defaults to https://storage.googleapis.com.
+	Endpoint string `mapstructure:"endpoint"`
+	// Bucket is the name of the bucket containing the runtime configuration
+	Bucket string `mapstructure:"bucket"`
+	// Object is the name of the object containing the runtime configuration
+	Object string `mapstructure:"object"`
+	// ServiceAccount is the service account of the workload the access tokens are requested for.
+	// Defaults to the default service account.
+	ServiceAccount string `mapstructure:"serviceAccount"`
+	// Timeout is the timeout of a request
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Retry configures the retries of a failed request
+	Retry helper.RetryConfig `mapstructure:"retry"`
+}
+
+// newConfig decodes the configuration of the gcs loader from the loader configuration and applies the defaults
+func newConfig(lc *config.LoaderConfig) (Config, error) {
+	var c Config
+	if err := lc.DecodeOptions(&c); err != nil {
+		return c, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if c.Endpoint == "" {
+		c.Endpoint = defaultEndpoint
+	}
+	if c.ServiceAccount == "" {
+		c.ServiceAccount = "default"
+	}
+	return c, nil
+}
+
+// validate validates the options of the gcs loader
+func validate(ctx context.Context, lc *config.LoaderConfig) error {
+	log := logger.FromContext(ctx)
+
+	c, err := newConfig(lc)
+	if err != nil {
+		log.Error("The options of the gcs loader cannot be decoded", "error", err)
+		return err
+	}
+	if c.Bucket == "" || c.Object == "" {
+		log.Error("The bucket and object of the gcs loader are required")
+		return fmt.Errorf("%w: bucket and object are required", ErrInvalidConfig)
+	}
+	u, err := url.ParseRequestURI(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		log.Error("The endpoint of the gcs loader is not a valid http(s) url", "endpoint", c.Endpoint)
+		return fmt.Errorf("%w: invalid endpoint %q", ErrInvalidConfig, c.Endpoint)
+	}
+	if c.Retry.Count < 0 || c.Retry.Count >= 5 {
+		log.Error("The amount of gcs loader retries should be equal or above 0 and below 5", "retryCount", c.Retry.Count)
+		return fmt.Errorf("%w: invalid retry count", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// NewLoader creates a loader reading the runtime configuration from the object configured in the options
+// of the loader configuration. The options are validated before, so decoding errors are ignored.
+func NewLoader(cfg *config.Config, cRuntime chan<- runtime.Config) *config.FetchLoader {
+	c, _ := newConfig(&cfg.Loader)
+	client := &http.Client{Timeout: c.Timeout}
+	o := &object{cfg: c, client: client, tokens: newWorkloadIdentity(client, c.ServiceAccount)}
+	return config.NewFetchLoader(cfg, cRuntime, config.Fetcher{
+		Name:   LoaderType,
+		Source: fmt.Sprintf("gs://%s/%s", c.Bucket, c.Object),
+		Retry:  c.Retry,
+		Fetch:  o.fetch,
+	})
+}
+
+// object is the object in a Cloud Storage bucket containing the runtime configuration
+type object struct {
+	cfg    Config
+	client *http.Client
+	// tokens acquires the access tokens of the workload
+	tokens *oauth.TokenSource
+}
+
+// fetch downloads the object from the bucket
+func (o *object) fetch(ctx context.Context) (b []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.url(), http.NoBody)
+	if err != nil {
+		return nil, helper.Permanent(err)
+	}
+	if err = o.tokens.Authorize(ctx, req); err != nil {
+		return nil, fmt.Errorf("could not acquire access token: %w", err)
+	}
+
+	res, err := o.client.Do(req) //nolint:bodyclose // closed in the deferred function
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		if cErr := Body.Close(); cErr != nil {
+			err = errors.Join(err, cErr)
+		}
+	}(res.Body)
+
+	if res.StatusCode != http.StatusOK {
+		err = fmt.Errorf("request failed, status is %s", res.Status)
+		if !helper.RetryableStatus(res.StatusCode) {
+			return nil, helper.Permanent(err)
+		}
+		return nil, err
+	}
+	return io.ReadAll(res.Body)
+}
+
+// url returns the media download URL of the object
+func (o *object) url() string {
+	return fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media",
+		strings.TrimSuffix(o.cfg.Endpoint, "/"), url.PathEscape(o.cfg.Bucket), url.PathEscape(o.cfg.Object))
+}
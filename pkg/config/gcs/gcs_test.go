@@ -0,0 +1,124 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+)
+
+func TestNewLoader(t *testing.T) {
+	tests := []struct {
+		name       string
+		tokenCode  int
+		objectCode int
+		wantErr    bool
+	}{
+		{name: "object downloaded", tokenCode: http.StatusOK, objectCode: http.StatusOK},
+		{name: "missing object", tokenCode: http.StatusOK, objectCode: http.StatusNotFound, wantErr: true},
+		{name: "no workload identity", tokenCode: http.StatusNotFound, objectCode: http.StatusOK, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tokens atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("/computeMetadata/v1/instance/service-accounts/default/token", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Metadata-Flavor") != "Google" {
+					t.Error("Token request is missing the metadata flavor header")
+				}
+				tokens.Add(1)
+				w.WriteHeader(tt.tokenCode)
+				_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600,"token_type":"Bearer"}`))
+			})
+			mux.HandleFunc("/storage/v1/b/configs/o/", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.EscapedPath() != "/storage/v1/b/configs/o/sparrow%2Fconfig.yaml" || r.URL.Query().Get("alt") != "media" {
+					t.Errorf("Requested %q, want the media download of the object", r.URL.String())
+				}
+				if r.Header.Get("Authorization") != "Bearer token" {
+					t.Errorf("Authorization = %q, want the workload identity token", r.Header.Get("Authorization"))
+				}
+				w.WriteHeader(tt.objectCode)
+				_, _ = w.Write([]byte("health:\n  interval: 20s\n  timeout: 5s\n  targets:\n    - https://example.com\n"))
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+			t.Setenv(metadataHostEnv, strings.TrimPrefix(srv.URL, "http://"))
+
+			cfg := &config.Config{Loader: config.LoaderConfig{
+				Type:    LoaderType,
+				Options: map[string]any{"endpoint": srv.URL, "bucket": "configs", "object": "sparrow/config.yaml"},
+			}}
+			if err := cfg.Loader.Validate(context.Background()); err != nil {
+				t.Fatalf("LoaderConfig.Validate() error = %v", err)
+			}
+
+			cRuntime := make(chan runtime.Config, 1)
+			err := config.NewLoader(cfg, cRuntime).Run(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchLoader.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := <-cRuntime
+			if tt.wantErr {
+				return
+			}
+			if got.Health == nil || got.Provenance == nil || got.Provenance.Source != "gs://configs/sparrow/config.yaml" {
+				t.Errorf("FetchLoader.Run() config = %+v, want the health check of the object", got)
+			}
+			if tokens.Load() != 1 {
+				t.Errorf("Acquired %d tokens, want 1", tokens.Load())
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		wantErr bool
+	}{
+		{name: "valid options", options: map[string]any{"bucket": "configs", "object": "config.yaml", "timeout": "30s"}},
+		{name: "missing object", options: map[string]any{"bucket": "configs"}, wantErr: true},
+		{name: "invalid endpoint", options: map[string]any{"bucket": "configs", "object": "config.yaml", "endpoint": "storage"}, wantErr: true},
+		{name: "unknown option", options: map[string]any{"bucket": "configs", "object": "config.yaml", "key": "config.yaml"}, wantErr: true},
+		{name: "too many retries", options: map[string]any{"bucket": "configs", "object": "config.yaml", "retry": map[string]any{"count": 5}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &config.LoaderConfig{Type: LoaderType, Options: tt.options}
+			err := lc.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoaderConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("LoaderConfig.Validate() error = %v, want %v", err, ErrInvalidConfig)
+			}
+		})
+	}
+}
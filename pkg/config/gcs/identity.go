@@ -0,0 +1,83 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/oauth"
+)
+
+const (
+	// metadataHostEnv is the environment variable overriding the host of the metadata server
+	metadataHostEnv = "GCE_METADATA_HOST"
+	// defaultMetadataHost is the host of the metadata server of Compute Engine and GKE
+	defaultMetadataHost = "metadata.google.internal"
+)
+
+// tokenResponse is the response of the token endpoint of the metadata server
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// newWorkloadIdentity returns a token source acquiring the access tokens of the service account from the
+// metadata server. With GKE workload identity the metadata server issues the tokens of the service account
+// bound to the Kubernetes service account of the pod.
+func newWorkloadIdentity(client *http.Client, serviceAccount string) *oauth.TokenSource {
+	return oauth.NewTokenSourceFunc(func(ctx context.Context) (string, time.Duration, error) {
+		host := os.Getenv(metadataHostEnv)
+		if host == "" {
+			host = defaultMetadataHost
+		}
+		u := fmt.Sprintf("http://%s/computeMetadata/v1/instance/service-accounts/%s/token", host, url.PathEscape(serviceAccount))
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+		if err != nil {
+			return "", 0, err
+		}
+		req.Header.Set("Metadata-Flavor", "Google")
+
+		res, err := client.Do(req)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to request token from the metadata server: %w", err)
+		}
+		defer func(Body io.ReadCloser) { _ = Body.Close() }(res.Body)
+
+		if res.StatusCode != http.StatusOK {
+			return "", 0, fmt.Errorf("token request failed, status is %s", res.Status)
+		}
+		var tr tokenResponse
+		if err := json.NewDecoder(res.Body).Decode(&tr); err != nil {
+			return "", 0, fmt.Errorf("failed to decode token response: %w", err)
+		}
+		if tr.AccessToken == "" {
+			return "", 0, errors.New("token response contains no access token")
+		}
+		return tr.AccessToken, time.Duration(tr.ExpiresIn) * time.Second, nil
+	})
+}
@@ -0,0 +1,98 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitCommit(t *testing.T) {
+	const sha = "8a1f2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c"
+	write := func(t *testing.T, path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, root string)
+		want  string
+	}{
+		{
+			name:  "no work tree",
+			setup: func(t *testing.T, root string) {},
+			want:  "",
+		},
+		{
+			name: "branch",
+			setup: func(t *testing.T, root string) {
+				write(t, filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/main\n")
+				write(t, filepath.Join(root, ".git", "refs", "heads", "main"), sha+"\n")
+			},
+			want: sha,
+		},
+		{
+			name: "packed branch",
+			setup: func(t *testing.T, root string) {
+				write(t, filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/main\n")
+				write(t, filepath.Join(root, ".git", "packed-refs"), "# pack-refs with: peeled fully-peeled sorted\n"+sha+" refs/heads/main\n")
+			},
+			want: sha,
+		},
+		{
+			name: "detached head",
+			setup: func(t *testing.T, root string) {
+				write(t, filepath.Join(root, ".git", "HEAD"), sha+"\n")
+			},
+			want: sha,
+		},
+		{
+			name: "linked work tree",
+			setup: func(t *testing.T, root string) {
+				common := filepath.Join(root, "repo.git")
+				write(t, filepath.Join(common, "refs", "heads", "main"), sha+"\n")
+				write(t, filepath.Join(common, "worktrees", "config", "HEAD"), "ref: refs/heads/main\n")
+				write(t, filepath.Join(common, "worktrees", "config", "commondir"), "../..\n")
+				write(t, filepath.Join(root, ".git"), "gitdir: "+filepath.Join(common, "worktrees", "config")+"\n")
+			},
+			want: sha,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			tt.setup(t, root)
+			dir := filepath.Join(root, "config")
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := gitCommit(dir); got != tt.want {
+				t.Errorf("gitCommit() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
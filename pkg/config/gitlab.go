@@ -0,0 +1,58 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+)
+
+// NewGitlabLoader creates a loader reading the runtime configuration from a file in a gitlab repository.
+// The file is fetched with the repository files API at the configured ref, so the configuration can be
+// pinned to a branch, tag or commit. The commit SHA of the ref is exposed in the provenance of the configuration.
+func NewGitlabLoader(cfg *Config, cRuntime chan<- runtime.Config) *HttpLoader {
+	gl := cfg.Loader.Gitlab
+	c := *cfg
+	c.Loader.Http = HttpLoaderConfig{
+		Url:      gl.fileURL(),
+		Token:    gl.Token,
+		Timeout:  gl.Timeout,
+		RetryCfg: gl.RetryCfg,
+		Cache:    gl.Cache,
+	}
+
+	hl := NewHttpLoader(&c, cRuntime)
+	hl.name = "gitlab"
+	// The URL contains neither credentials nor secrets, so the ref is kept in the source
+	hl.source = c.Loader.Http.Url
+	return hl
+}
+
+// fileURL returns the URL of the raw runtime configuration file in the repository files API
+func (c *GitlabLoaderConfig) fileURL() string {
+	u := fmt.Sprintf("%s/api/v4/projects/%d/repository/files/%s/raw",
+		strings.TrimSuffix(c.BaseURL, "/"), c.ProjectID, url.PathEscape(c.Path))
+	if c.Ref != "" {
+		u += "?" + url.Values{"ref": {c.Ref}}.Encode()
+	}
+	return u
+}
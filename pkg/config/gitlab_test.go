@@ -0,0 +1,120 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGitlabLoaderConfig_fileURL(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  GitlabLoaderConfig
+		want string
+	}{
+		{
+			name: "default branch",
+			cfg:  GitlabLoaderConfig{BaseURL: "https://gitlab.com", ProjectID: 1, Path: "config.yaml"},
+			want: "https://gitlab.com/api/v4/projects/1/repository/files/config.yaml/raw",
+		},
+		{
+			name: "pinned tag and nested path",
+			cfg:  GitlabLoaderConfig{BaseURL: "https://gitlab.com/", ProjectID: 42, Ref: "v1.0.0", Path: "sparrow/config.yaml"},
+			want: "https://gitlab.com/api/v4/projects/42/repository/files/sparrow%2Fconfig.yaml/raw?ref=v1.0.0",
+		},
+		{
+			name: "branch with slash",
+			cfg:  GitlabLoaderConfig{BaseURL: "https://gitlab.com", ProjectID: 1, Ref: "release/1.x", Path: "config.yaml"},
+			want: "https://gitlab.com/api/v4/projects/1/repository/files/config.yaml/raw?ref=release%2F1.x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.fileURL(); got != tt.want {
+				t.Errorf("fileURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitlabLoader_getRuntimeConfig(t *testing.T) {
+	expected := runtime.Config{
+		Health: &health.Config{
+			Targets:  []string{"http://localhost:8080/health"},
+			Interval: 10 * time.Second,
+			Timeout:  1 * time.Second,
+		},
+	}
+	body, err := yaml.Marshal(expected)
+	if err != nil {
+		t.Fatalf("Failed marshaling yaml: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/42/repository/files/sparrow%2Fconfig.yaml/raw" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("ref") != "v1.0.0" {
+			t.Errorf("Request has ref %q, want %q", r.URL.Query().Get("ref"), "v1.0.0")
+		}
+		if r.Header.Get("Authorization") != "Bearer SECRET" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set(gitlabCommitHeader, "8a1f2b3c")
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	gl := NewGitlabLoader(&Config{Loader: LoaderConfig{
+		Type: "gitlab",
+		Gitlab: GitlabLoaderConfig{
+			BaseURL:   srv.URL,
+			Token:     "SECRET",
+			ProjectID: 42,
+			Ref:       "v1.0.0",
+			Path:      "sparrow/config.yaml",
+			Timeout:   time.Second,
+		},
+	}}, make(chan runtime.Config, 1))
+
+	got, err := gl.getRuntimeConfig(context.Background())
+	if err != nil {
+		t.Fatalf("getRuntimeConfig() error = %v", err)
+	}
+
+	want := srv.URL + "/api/v4/projects/42/repository/files/sparrow%2Fconfig.yaml/raw?ref=v1.0.0"
+	if p := got.Provenance; p == nil || p.Loader != "gitlab" || p.Source != want || p.Commit != "8a1f2b3c" {
+		t.Errorf("getRuntimeConfig() provenance = %+v, want the gitlab loader, source %q and commit %q", p, want, "8a1f2b3c")
+	}
+	got.Provenance = nil
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("getRuntimeConfig() = %v, want %v", got, expected)
+	}
+}
@@ -24,30 +24,58 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
 	"time"
 
+	"github.com/caas-team/sparrow/internal/clock"
 	"github.com/caas-team/sparrow/internal/helper"
 	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/oauth"
 	"gopkg.in/yaml.v3"
 )
 
+// gitlabCommitHeader is the response header of the GitLab repository files API
+// containing the commit SHA of the requested file
+const gitlabCommitHeader = "X-Gitlab-Commit-Id"
+
 type HttpLoader struct {
 	cfg      LoaderConfig
 	cRuntime chan<- runtime.Config
 	done     chan struct{}
 	client   *http.Client
+	// tokens acquires the bearer tokens if oauth2 is configured
+	tokens *oauth.TokenSource
+	// name is the loader reported in the provenance of the loaded configuration
+	name string
+	// source is the source reported in the provenance of the loaded configuration
+	source string
+	// instanceName is the name of the sparrow the templates of the configuration are rendered with
+	instanceName string
+	loaderMetrics
+	// Source is the clock the loader schedules its reloads with
+	clock.Source
 }
 
 func NewHttpLoader(cfg *Config, cRuntime chan<- runtime.Config) *HttpLoader {
-	return &HttpLoader{
+	hl := &HttpLoader{
 		cfg:      cfg.Loader,
 		cRuntime: cRuntime,
 		done:     make(chan struct{}, 1),
 		client: &http.Client{
 			Timeout: cfg.Loader.Http.Timeout,
 		},
+		name:          "http",
+		source:        provenanceSource(cfg.Loader.Http.Url),
+		instanceName:  cfg.SparrowName,
+		loaderMetrics: newLoaderMetrics(),
+	}
+	if cfg.Loader.Http.OAuth2.Enabled() {
+		hl.tokens = oauth.NewTokenSource(cfg.Loader.Http.OAuth2, hl.client)
 	}
+	return hl
 }
 
 // Run gets the runtime configuration from the remote file of the configured http endpoint.
@@ -59,16 +87,28 @@ func (h *HttpLoader) Run(ctx context.Context) error {
 	log := logger.FromContext(ctx)
 
 	var cfg runtime.Config
-	getConfigRetry := helper.Retry(func(ctx context.Context) (err error) {
+	retry := helper.Retry(func(ctx context.Context) (err error) {
 		cfg, err = h.getRuntimeConfig(ctx)
 		return err
 	}, h.cfg.Http.RetryCfg)
+	getConfigRetry := func(ctx context.Context) error {
+		start := h.Clock().Now()
+		err := retry(ctx)
+		h.observe(h.Clock().Since(start), &cfg, err)
+		return err
+	}
 
 	// Get the runtime configuration once on startup
 	err := getConfigRetry(ctx)
 	if err != nil {
 		log.Warn("Could not get remote runtime configuration", "error", err)
 		err = fmt.Errorf("could not get remote runtime configuration: %w", err)
+		if cached, ok := h.loadCache(ctx); ok {
+			log.Warn("Applying the last successfully loaded runtime configuration", "cache", h.cfg.Http.Cache)
+			cfg, err = cached, nil
+		}
+	} else {
+		h.succeeded(ctx, cfg)
 	}
 	h.cRuntime <- cfg
 
@@ -77,7 +117,7 @@ func (h *HttpLoader) Run(ctx context.Context) error {
 		return err
 	}
 
-	tick := time.NewTicker(h.cfg.Interval)
+	tick := h.Clock().NewTicker(h.cfg.Interval)
 	defer tick.Stop()
 
 	for {
@@ -87,7 +127,7 @@ func (h *HttpLoader) Run(ctx context.Context) error {
 			return nil
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-tick.C:
+		case <-tick.C():
 			if err := getConfigRetry(ctx); err != nil {
 				log.Warn("Could not get remote runtime configuration", "error", err)
 				tick.Reset(h.cfg.Interval)
@@ -95,6 +135,7 @@ func (h *HttpLoader) Run(ctx context.Context) error {
 			}
 
 			log.Info("Successfully got remote runtime configuration")
+			h.succeeded(ctx, cfg)
 			h.cRuntime <- cfg
 			tick.Reset(h.cfg.Interval)
 		}
@@ -112,12 +153,20 @@ func (hl *HttpLoader) getRuntimeConfig(ctx context.Context) (cfg runtime.Config,
 	if hl.cfg.Http.Token != "" {
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", hl.cfg.Http.Token))
 	}
+	if hl.tokens != nil {
+		if err := hl.tokens.Authorize(ctx, req); err != nil {
+			log.Error("Could not acquire oauth2 token", "error", err.Error())
+			return cfg, err
+		}
+	}
 
 	res, err := hl.client.Do(req) //nolint:bodyclose
 	if err != nil {
 		log.Error("Http get request failed", "error", err.Error())
+		hl.responses.WithLabelValues("error").Inc()
 		return cfg, err
 	}
+	hl.responses.WithLabelValues(statusClass(res.StatusCode)).Inc()
 	defer func(Body io.ReadCloser) {
 		cErr := Body.Close()
 		if cErr != nil {
@@ -128,7 +177,18 @@ func (hl *HttpLoader) getRuntimeConfig(ctx context.Context) (cfg runtime.Config,
 
 	if res.StatusCode != http.StatusOK {
 		log.Error("Http get request failed", "status", res.Status)
-		return cfg, fmt.Errorf("request failed, status is %s", res.Status)
+		err = fmt.Errorf("request failed, status is %s", res.Status)
+		if !helper.RetryableStatus(res.StatusCode) {
+			return cfg, helper.Permanent(err)
+		}
+		// Rate limited or unavailable endpoints may tell when to retry
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable {
+			if delay, ok := helper.ParseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+				log.Debug("Endpoint requested a delay before retrying", "retryAfter", delay)
+				return cfg, helper.RetryAfter(err, delay)
+			}
+		}
+		return cfg, err
 	}
 
 	b, err := io.ReadAll(res.Body)
@@ -138,14 +198,98 @@ func (hl *HttpLoader) getRuntimeConfig(ctx context.Context) (cfg runtime.Config,
 	}
 	log.Debug("Successfully got response")
 
-	if err := yaml.Unmarshal(b, &cfg); err != nil {
+	// A template that fails to render won't render on a retry
+	rendered, err := RenderRuntimeConfig(b, hl.instanceName)
+	if err != nil {
+		log.Error("Could not render response", "error", err.Error())
+		return cfg, helper.Permanent(err)
+	}
+
+	if err := yaml.Unmarshal(rendered, &cfg); err != nil {
 		log.Error("Could not unmarshal response", "error", err.Error())
 		return cfg, err
 	}
 
+	// An invalid configuration won't become valid on a retry
+	if err := cfg.Validate(); err != nil {
+		log.Error("Invalid runtime configuration", "error", err.Error())
+		return cfg, helper.Permanent(fmt.Errorf("invalid runtime configuration: %w", err))
+	}
+
+	cfg.Provenance = checks.NewProvenance(hl.name, hl.source, b)
+	cfg.Provenance.Commit = res.Header.Get(gitlabCommitHeader)
 	return cfg, nil
 }
 
+// succeeded records the successfully loaded runtime configuration
+// and stores it in the cache if configured
+func (hl *HttpLoader) succeeded(ctx context.Context, cfg runtime.Config) {
+	hl.lastSuccess.SetToCurrentTime()
+	if hl.cfg.Http.Cache == "" {
+		return
+	}
+
+	if err := hl.saveCache(cfg); err != nil {
+		logger.FromContext(ctx).Error("Could not cache runtime configuration", "cache", hl.cfg.Http.Cache, "error", err)
+	}
+}
+
+// saveCache writes the runtime configuration to the cache file.
+// The file is replaced atomically, so a crash doesn't leave a partially written cache behind.
+func (hl *HttpLoader) saveCache(cfg runtime.Config) error {
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal runtime configuration: %w", err)
+	}
+
+	tmp := hl.cfg.Http.Cache + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp, hl.cfg.Http.Cache); err != nil {
+		return fmt.Errorf("failed to replace cache file: %w", err)
+	}
+	return nil
+}
+
+// loadCache returns the cached runtime configuration
+// and false if there is no cache or it can't be read
+func (hl *HttpLoader) loadCache(ctx context.Context) (cfg runtime.Config, ok bool) {
+	if hl.cfg.Http.Cache == "" {
+		return cfg, false
+	}
+	log := logger.FromContext(ctx).With("cache", hl.cfg.Http.Cache)
+
+	b, err := os.ReadFile(hl.cfg.Http.Cache)
+	if err != nil {
+		log.Warn("Could not read cached runtime configuration", "error", err)
+		return cfg, false
+	}
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		log.Error("Could not parse cached runtime configuration", "error", err)
+		return cfg, false
+	}
+	cfg.Provenance = checks.NewProvenance("cache", hl.cfg.Http.Cache, b)
+	return cfg, true
+}
+
+// statusClass returns the class of the HTTP status code, e.g. 4xx
+func statusClass(code int) string {
+	return fmt.Sprintf("%dxx", code/100) //nolint:mnd // status classes are the hundreds
+}
+
+// provenanceSource returns the URL without its credentials and query,
+// which may contain tokens, to be exposed as the source of the configuration
+func provenanceSource(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
+
 // Shutdown stops the loader
 func (hl *HttpLoader) Shutdown(ctx context.Context) {
 	log := logger.FromContext(ctx)
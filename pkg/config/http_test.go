@@ -23,7 +23,9 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sync"
 	"testing"
@@ -34,6 +36,8 @@ import (
 	"github.com/caas-team/sparrow/pkg/checks/health"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
 	"github.com/jarcoal/httpmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
@@ -68,7 +72,7 @@ func TestHttpLoader_GetRuntimeConfig(t *testing.T) {
 			want: runtime.Config{
 				Health: &health.Config{
 					Targets:  []string{"http://localhost:8080/health"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 			},
@@ -91,7 +95,7 @@ func TestHttpLoader_GetRuntimeConfig(t *testing.T) {
 			want: runtime.Config{
 				Health: &health.Config{
 					Targets:  []string{"http://localhost:8080/health"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 			},
@@ -121,6 +125,7 @@ func TestHttpLoader_GetRuntimeConfig(t *testing.T) {
 						fmt.Println("TOKEN tested")
 					}
 					resp, _ := httpmock.NewStringResponder(tt.httpResponder.statusCode, tt.httpResponder.response)(req)
+					resp.Header.Set(gitlabCommitHeader, "8a1f2b3c")
 					return resp, nil
 				},
 			)
@@ -136,21 +141,87 @@ func TestHttpLoader_GetRuntimeConfig(t *testing.T) {
 				client: &http.Client{
 					Timeout: tt.cfg.Loader.Http.Timeout,
 				},
+				loaderMetrics: newLoaderMetrics(),
 			}
-			gl.cfg.Http.Url = endpoint
+			gl.cfg.Http.Url = endpoint + "?private_token=SECRET"
+			gl.name, gl.source = "http", provenanceSource(gl.cfg.Http.Url)
 
 			got, err := gl.getRuntimeConfig(ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("HttpLoader.GetRuntimeConfig() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if err == nil && !reflect.DeepEqual(got, tt.want) {
+			if err != nil {
+				return
+			}
+			if p := got.Provenance; p == nil || p.Loader != "http" || p.Source != endpoint || p.Commit != "8a1f2b3c" || p.Digest == "" {
+				t.Errorf("HttpLoader.GetRuntimeConfig() provenance = %+v, want the endpoint without the query and the commit", p)
+			}
+			got.Provenance = nil
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("HttpLoader.GetRuntimeConfig() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+// TestHttpLoader_getRuntimeConfig_status tests that failed requests are retried depending on the status code,
+// the delay requested by the endpoint is respected and the responses are counted by their status class
+func TestHttpLoader_getRuntimeConfig_status(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		wantCalls  int
+		wantDelay  time.Duration
+		wantClass  string
+	}{
+		{name: "client error isn't retried", status: http.StatusNotFound, wantCalls: 1, wantClass: "4xx"},
+		{name: "server error is retried with backoff", status: http.StatusBadGateway, wantCalls: 2, wantDelay: time.Millisecond, wantClass: "5xx"},
+		{name: "rate limit respects retry after", status: http.StatusTooManyRequests, retryAfter: "0", wantCalls: 2, wantDelay: time.Millisecond, wantClass: "4xx"},
+		{name: "retry after is capped", status: http.StatusServiceUnavailable, retryAfter: "3600", wantCalls: 2, wantDelay: 50 * time.Millisecond, wantClass: "5xx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls++
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			hl := NewHttpLoader(&Config{Loader: LoaderConfig{Http: HttpLoaderConfig{
+				Url:     srv.URL,
+				Timeout: time.Second,
+			}}}, nil)
+
+			var delays []time.Duration
+			err := helper.Retry(func(ctx context.Context) error {
+				_, err := hl.getRuntimeConfig(ctx)
+				return err
+			}, helper.RetryConfig{Count: 1, Delay: time.Millisecond, MaxDelay: 50 * time.Millisecond}, helper.OnRetry(func(_ int, _ error, delay time.Duration) {
+				delays = append(delays, delay)
+			}))(context.Background())
+
+			if err == nil {
+				t.Fatal("getRuntimeConfig() should have failed")
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("getRuntimeConfig() requests = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantCalls > 1 && (len(delays) != 1 || delays[0] != tt.wantDelay) {
+				t.Errorf("getRuntimeConfig() retry delays = %v, want [%v]", delays, tt.wantDelay)
+			}
+			if got := testutil.ToFloat64(hl.responses.WithLabelValues(tt.wantClass)); got != float64(tt.wantCalls) {
+				t.Errorf("sparrow_loader_responses_total{class=%q} = %v, want %d", tt.wantClass, got, tt.wantCalls)
+			}
+		})
+	}
+}
+
 // TestHttpLoader_Run tests the Run method of the HttpLoader
 // The test runs the Run method for a while
 // and then shuts it down via a goroutine
@@ -184,7 +255,8 @@ func TestHttpLoader_Run(t *testing.T) {
 			response: runtime.Config{
 				Health: &health.Config{
 					Targets:  []string{"http://localhost:8080/health"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
+					Timeout:  1 * time.Second,
 				},
 			},
 			code: http.StatusOK,
@@ -195,7 +267,8 @@ func TestHttpLoader_Run(t *testing.T) {
 			response: runtime.Config{
 				Health: &health.Config{
 					Targets:  []string{"http://localhost:8080/health"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
+					Timeout:  1 * time.Second,
 				},
 			},
 			code:    http.StatusOK,
@@ -228,7 +301,8 @@ func TestHttpLoader_Run(t *testing.T) {
 				client: &http.Client{
 					Transport: http.DefaultTransport,
 				},
-				done: make(chan struct{}, 1),
+				done:          make(chan struct{}, 1),
+				loaderMetrics: newLoaderMetrics(),
 			}
 
 			// shutdown routine
@@ -289,7 +363,8 @@ func TestHttpLoader_Run_config_sent_to_channel(t *testing.T) {
 	expected := runtime.Config{
 		Health: &health.Config{
 			Targets:  []string{"http://localhost:8080/health"},
-			Interval: 1 * time.Second,
+			Interval: 10 * time.Second,
+			Timeout:  1 * time.Second,
 		},
 	}
 	body, err := yaml.Marshal(expected)
@@ -317,7 +392,8 @@ func TestHttpLoader_Run_config_sent_to_channel(t *testing.T) {
 		client: &http.Client{
 			Transport: http.DefaultTransport,
 		},
-		done: make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		loaderMetrics: newLoaderMetrics(),
 	}
 
 	ctx := context.Background()
@@ -333,6 +409,7 @@ func TestHttpLoader_Run_config_sent_to_channel(t *testing.T) {
 	case <-time.After(time.Second):
 		t.Error("Config not sent to channel")
 	case c := <-cRuntime:
+		c.Provenance = nil
 		if !reflect.DeepEqual(c, expected) {
 			t.Errorf("Config sent to channel is not equal to expected config: got %v, want %v", c, expected)
 		}
@@ -373,7 +450,8 @@ func TestHttpLoader_Run_empty_config_sent_to_channel_500(t *testing.T) {
 		client: &http.Client{
 			Transport: http.DefaultTransport,
 		},
-		done: make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		loaderMetrics: newLoaderMetrics(),
 	}
 
 	ctx := context.Background()
@@ -385,7 +463,7 @@ func TestHttpLoader_Run_empty_config_sent_to_channel_500(t *testing.T) {
 	}()
 
 	cfg := <-cRuntime
-	if cfg != (runtime.Config{}) {
+	if !reflect.DeepEqual(cfg, runtime.Config{}) {
 		t.Errorf("Config sent to channel: %v", cfg)
 	}
 
@@ -420,7 +498,8 @@ func TestHttpLoader_Run_empty_config_sent_to_channel_client_error(t *testing.T)
 		client: &http.Client{
 			Transport: http.DefaultTransport,
 		},
-		done: make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		loaderMetrics: newLoaderMetrics(),
 	}
 
 	ctx := context.Background()
@@ -432,9 +511,136 @@ func TestHttpLoader_Run_empty_config_sent_to_channel_client_error(t *testing.T)
 	}()
 
 	cfg := <-cRuntime
-	if cfg != (runtime.Config{}) {
+	if !reflect.DeepEqual(cfg, runtime.Config{}) {
 		t.Errorf("Config sent to channel: %v", cfg)
 	}
 
 	hl.Shutdown(ctx)
 }
+
+// TestHttpLoader_Run_cache tests that the last successfully loaded config is cached
+// and applied if the remote endpoint is unavailable on startup
+func TestHttpLoader_Run_cache(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	expected := runtime.Config{
+		Health: &health.Config{
+			Targets:  []string{"http://localhost:8080/health"},
+			Interval: 10 * time.Second,
+			Timeout:  1 * time.Second,
+		},
+	}
+	body, err := yaml.Marshal(expected)
+	if err != nil {
+		t.Fatalf("Failed marshaling yaml: %v", err)
+	}
+
+	cache := filepath.Join(t.TempDir(), "config.yaml")
+	newLoader := func(cRuntime chan runtime.Config) *HttpLoader {
+		return &HttpLoader{
+			cfg: LoaderConfig{
+				Type: "http",
+				Http: HttpLoaderConfig{
+					Url:   "https://api.test.com/test",
+					Cache: cache,
+				},
+			},
+			cRuntime: cRuntime,
+			client: &http.Client{
+				Transport: http.DefaultTransport,
+			},
+			name:          "http",
+			done:          make(chan struct{}, 1),
+			loaderMetrics: newLoaderMetrics(),
+		}
+	}
+
+	// The first run loads the config from the endpoint and caches it
+	httpmock.RegisterResponder(http.MethodGet, "https://api.test.com/test", httpmock.NewBytesResponder(http.StatusOK, body))
+	cRuntime := make(chan runtime.Config, 1)
+	hl := newLoader(cRuntime)
+	if err := hl.Run(context.Background()); err != nil {
+		t.Fatalf("HttpLoader.Run() error = %v", err)
+	}
+	c := <-cRuntime
+	if c.Provenance == nil || c.Provenance.Loader != "http" {
+		t.Errorf("Config sent to channel has provenance %+v, want the http loader", c.Provenance)
+	}
+	if c.Provenance = nil; !reflect.DeepEqual(c, expected) {
+		t.Errorf("Config sent to channel = %v, want %v", c, expected)
+	}
+	var m dto.Metric
+	if err := hl.lastSuccess.Write(&m); err != nil {
+		t.Fatalf("Failed reading metric: %v", err)
+	}
+	if m.GetGauge().GetValue() == 0 {
+		t.Error("Last success timestamp wasn't set")
+	}
+
+	// The second run falls back to the cached config
+	httpmock.RegisterResponder(http.MethodGet, "https://api.test.com/test", httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+	hl = newLoader(cRuntime)
+	if err := hl.Run(context.Background()); err != nil {
+		t.Fatalf("HttpLoader.Run() error = %v", err)
+	}
+	c = <-cRuntime
+	if c.Provenance == nil || c.Provenance.Loader != "cache" || c.Provenance.Source != cache {
+		t.Errorf("Config sent to channel has provenance %+v, want the cache", c.Provenance)
+	}
+	if c.Provenance = nil; !reflect.DeepEqual(c, expected) {
+		t.Errorf("Config sent to channel = %v, want cached %v", c, expected)
+	}
+	m.Reset()
+	if err := hl.lastSuccess.Write(&m); err != nil {
+		t.Fatalf("Failed reading metric: %v", err)
+	}
+	if m.GetGauge().GetValue() != 0 {
+		t.Error("Last success timestamp was set for the cached config")
+	}
+
+	// Without a cache the run fails
+	if err := os.Remove(cache); err != nil {
+		t.Fatalf("Failed removing cache: %v", err)
+	}
+	hl = newLoader(cRuntime)
+	if err := hl.Run(context.Background()); err == nil {
+		t.Error("HttpLoader.Run() should have failed without a cache")
+	}
+	if c := <-cRuntime; !reflect.DeepEqual(c, runtime.Config{}) {
+		t.Errorf("Config sent to channel = %v, want empty config", c)
+	}
+}
+
+// FuzzHttpLoader_getRuntimeConfig verifies malformed remote configurations are rejected without panicking
+// and every accepted configuration can be cached and restored
+func FuzzHttpLoader_getRuntimeConfig(f *testing.F) {
+	addSeeds(f)
+
+	var payload []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(payload)
+	}))
+	defer srv.Close()
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		payload = b
+		hl := NewHttpLoader(&Config{Loader: LoaderConfig{Http: HttpLoaderConfig{
+			Url:     srv.URL,
+			Timeout: time.Second,
+			Cache:   filepath.Join(t.TempDir(), "config.yaml"),
+		}}}, nil)
+
+		ctx := context.Background()
+		cfg, err := hl.getRuntimeConfig(ctx)
+		if err != nil {
+			return
+		}
+		_ = cfg.Validate()
+
+		hl.succeeded(ctx, cfg)
+		if _, ok := hl.loadCache(ctx); !ok {
+			t.Error("loadCache() failed to restore the accepted configuration")
+		}
+	})
+}
@@ -0,0 +1,95 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// IdentityConfig configures how the identity of the sparrow is resolved.
+// The resulting identity is stored in [Config.SparrowName] and used for
+// the target manager registration, the result metadata and the telemetry.
+type IdentityConfig struct {
+	// Env is the name of an environment variable containing the identity.
+	// If the variable is set, it overrides the configured name.
+	// This allows injecting the identity through the Kubernetes downward API.
+	Env string `yaml:"env" mapstructure:"env"`
+	// AutoDetect enables the detection of the host's FQDN
+	// if neither a name nor an identity environment variable is set.
+	AutoDetect bool `yaml:"autoDetect" mapstructure:"autoDetect"`
+}
+
+// fqdnDetector is the function used to detect the FQDN of the host
+type fqdnDetector func(ctx context.Context) (string, error)
+
+// detectFQDN is the function used to detect the FQDN of the host
+var detectFQDN fqdnDetector = func(ctx context.Context) (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname: %w", err)
+	}
+	if strings.Contains(hostname, ".") {
+		return hostname, nil
+	}
+
+	cname, err := net.DefaultResolver.LookupCNAME(ctx, hostname)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve fqdn of %q: %w", hostname, err)
+	}
+	return cname, nil
+}
+
+// ResolveIdentity resolves the identity of the sparrow and stores it as the sparrow name.
+//
+// The identity is resolved in the following order:
+//  1. The environment variable configured by identity.env
+//  2. The configured name
+//  3. The FQDN of the host, if identity.autoDetect is enabled
+//
+// The resolved identity is normalized to lowercase without a trailing dot.
+// Its DNS compliance is checked by [Config.Validate].
+func (c *Config) ResolveIdentity(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if c.Identity.Env != "" {
+		if v, ok := os.LookupEnv(c.Identity.Env); ok && v != "" {
+			log.Debug("Using identity from environment", "env", c.Identity.Env)
+			c.SparrowName = v
+		}
+	}
+
+	if c.SparrowName == "" && c.Identity.AutoDetect {
+		fqdn, err := detectFQDN(ctx)
+		if err != nil {
+			log.Error("Failed to auto-detect the identity", "error", err)
+			return fmt.Errorf("%w: %w", ErrIdentityDetection, err)
+		}
+		log.Info("Auto-detected identity", "name", fqdn)
+		c.SparrowName = fqdn
+	}
+
+	c.SparrowName = strings.TrimSuffix(strings.ToLower(c.SparrowName), ".")
+	return nil
+}
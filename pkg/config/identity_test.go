@@ -0,0 +1,115 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestConfig_ResolveIdentity(t *testing.T) {
+	const envName = "SPARROW_TEST_IDENTITY"
+
+	tests := []struct {
+		name     string
+		config   Config
+		env      string
+		detector fqdnDetector
+		want     string
+		wantErr  error
+	}{
+		{
+			name:   "configured name is kept",
+			config: Config{SparrowName: "sparrow.com"},
+			want:   "sparrow.com",
+		},
+		{
+			name:   "configured name is normalized",
+			config: Config{SparrowName: "Sparrow.COM."},
+			want:   "sparrow.com",
+		},
+		{
+			name: "env overrides configured name",
+			config: Config{
+				SparrowName: "sparrow.com",
+				Identity:    IdentityConfig{Env: envName},
+			},
+			env:  "pod.sparrow.com",
+			want: "pod.sparrow.com",
+		},
+		{
+			name: "empty env does not override configured name",
+			config: Config{
+				SparrowName: "sparrow.com",
+				Identity:    IdentityConfig{Env: envName},
+			},
+			want: "sparrow.com",
+		},
+		{
+			name:   "auto-detection",
+			config: Config{Identity: IdentityConfig{AutoDetect: true}},
+			detector: func(_ context.Context) (string, error) {
+				return "host.sparrow.com.", nil
+			},
+			want: "host.sparrow.com",
+		},
+		{
+			name:   "auto-detection not used if name is configured",
+			config: Config{SparrowName: "sparrow.com", Identity: IdentityConfig{AutoDetect: true}},
+			detector: func(_ context.Context) (string, error) {
+				return "host.sparrow.com", nil
+			},
+			want: "sparrow.com",
+		},
+		{
+			name:   "auto-detection fails",
+			config: Config{Identity: IdentityConfig{AutoDetect: true}},
+			detector: func(_ context.Context) (string, error) {
+				return "", errors.New("no fqdn")
+			},
+			wantErr: ErrIdentityDetection,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv(envName, tt.env)
+			}
+			if tt.detector != nil {
+				orig := detectFQDN
+				detectFQDN = tt.detector
+				t.Cleanup(func() { detectFQDN = orig })
+			}
+
+			err := tt.config.ResolveIdentity(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveIdentity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if tt.config.SparrowName != tt.want {
+				t.Errorf("ResolveIdentity() name = %q, want %q", tt.config.SparrowName, tt.want)
+			}
+		})
+	}
+}
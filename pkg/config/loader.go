@@ -20,8 +20,16 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
 )
 
 //go:generate moq -out loader_moq.go . Loader
@@ -36,12 +44,217 @@ type Loader interface {
 	Shutdown(context.Context)
 }
 
-// NewLoader Get a new typed runtime configuration loader
+// LoaderType is a type of runtime configuration loader selected by the loader type of the startup configuration
+type LoaderType struct {
+	// New creates the loader sending the loaded runtime configurations to the channel
+	New func(cfg *Config, cRuntime chan<- runtime.Config) Loader
+	// Validate validates the loader configuration of the type. Optional.
+	Validate func(ctx context.Context, cfg *LoaderConfig) error
+}
+
+var (
+	loaderTypesMu sync.RWMutex
+	// loaderTypes contains the registered loader types by name
+	loaderTypes = map[string]LoaderType{
+		"http": {
+			New:      func(cfg *Config, cRuntime chan<- runtime.Config) Loader { return NewHttpLoader(cfg, cRuntime) },
+			Validate: validateHttpLoader,
+		},
+		"file": {
+			New:      func(cfg *Config, cRuntime chan<- runtime.Config) Loader { return NewFileLoader(cfg, cRuntime) },
+			Validate: validateFileLoader,
+		},
+		"gitlab": {
+			New:      func(cfg *Config, cRuntime chan<- runtime.Config) Loader { return NewGitlabLoader(cfg, cRuntime) },
+			Validate: validateGitlabLoader,
+		},
+	}
+)
+
+// RegisterLoader registers a loader type under the name, so it can be selected as loader type.
+// It's meant to be called in the init function of the package implementing the loader.
+// It panics if the name is already registered or the loader type can't create loaders.
+func RegisterLoader(name string, t LoaderType) {
+	loaderTypesMu.Lock()
+	defer loaderTypesMu.Unlock()
+
+	if name == "" || t.New == nil {
+		panic("config: RegisterLoader requires a name and a constructor")
+	}
+	if _, ok := loaderTypes[name]; ok {
+		panic(fmt.Sprintf("config: loader type %q is already registered", name))
+	}
+	loaderTypes[name] = t
+}
+
+// LoaderTypes returns the sorted names of the registered loader types
+func LoaderTypes() []string {
+	loaderTypesMu.RLock()
+	defer loaderTypesMu.RUnlock()
+
+	names := make([]string, 0, len(loaderTypes))
+	for name := range loaderTypes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// lookupLoaderType returns the registered loader type with the name
+func lookupLoaderType(name string) (LoaderType, bool) {
+	loaderTypesMu.RLock()
+	defer loaderTypesMu.RUnlock()
+
+	t, ok := loaderTypes[name]
+	return t, ok
+}
+
+// NewLoader Get a new typed runtime configuration loader.
+// It falls back to the file loader if the loader type isn't registered.
 func NewLoader(cfg *Config, cRuntime chan<- runtime.Config) Loader {
-	switch cfg.Loader.Type {
-	case "http":
-		return NewHttpLoader(cfg, cRuntime)
-	default:
-		return NewFileLoader(cfg, cRuntime)
+	if t, ok := lookupLoaderType(cfg.Loader.Type); ok {
+		return t.New(cfg, cRuntime)
+	}
+	return NewFileLoader(cfg, cRuntime)
+}
+
+// DecodeOptions decodes the options of the loader configuration into v,
+// e.g. the configuration of a registered loader type. Unknown options are rejected.
+func (c *LoaderConfig) DecodeOptions(v any) error {
+	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:  DecodeHook(),
+		ErrorUnused: true,
+		Result:      v,
+	})
+	if err != nil {
+		return err
+	}
+	return d.Decode(c.Options)
+}
+
+// ParseRuntimeConfig renders the templates of the raw runtime configuration, decodes and validates it.
+// It's meant for loaders that don't need to distinguish the cause of an invalid configuration.
+func ParseRuntimeConfig(b []byte, instanceName string) (runtime.Config, error) {
+	var cfg runtime.Config
+	rendered, err := RenderRuntimeConfig(b, instanceName)
+	if err != nil {
+		return cfg, err
+	}
+	if err = yaml.Unmarshal(rendered, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid runtime configuration: %w", err)
 	}
+	if err = cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid runtime configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// The results of loading the runtime configuration
+const (
+	// LoadSuccess is the result of a load returning a changed configuration
+	LoadSuccess = "success"
+	// LoadUnchanged is the result of a load returning the same configuration as the previous load
+	LoadUnchanged = "unchanged"
+	// LoadError is the result of a failed load
+	LoadError = "error"
+)
+
+// LoadResult is the outcome of loading the runtime configuration once, including its retries
+type LoadResult struct {
+	// Result is either LoadSuccess, LoadUnchanged or LoadError
+	Result string
+	// Duration is the time it took to load the configuration
+	Duration time.Duration
+	// Provenance describes where the configuration was loaded from. It is nil if the load failed.
+	Provenance *checks.Provenance
+	// Err is the error of the failed load
+	Err error
+}
+
+// Observable is implemented by the loaders reporting the outcome of every load
+type Observable interface {
+	// OnLoad sets the function called with the outcome of every load.
+	// It must be set before the loader is started.
+	OnLoad(func(LoadResult))
+}
+
+// loaderMetrics contains the metrics of a loader
+type loaderMetrics struct {
+	// lastSuccess is the time of the last successfully loaded runtime configuration
+	lastSuccess prometheus.Gauge
+	// responses counts the responses of the remote endpoint by their status class
+	responses *prometheus.CounterVec
+	// duration is the time it took to load the runtime configuration
+	duration prometheus.Histogram
+	// loads counts the loads of the runtime configuration by their result
+	loads *prometheus.CounterVec
+	// digest is the digest of the last successfully loaded runtime configuration
+	digest string
+	// onLoad is called with the outcome of every load if set
+	onLoad func(LoadResult)
+}
+
+// newLoaderMetrics creates the metrics of a loader
+func newLoaderMetrics() loaderMetrics {
+	return loaderMetrics{
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sparrow_loader_last_success_timestamp",
+			Help: "Unix timestamp of the last successfully loaded runtime configuration",
+		}),
+		responses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sparrow_loader_responses_total",
+			Help: "Total number of responses of the remote runtime configuration endpoint by status class",
+		}, []string{"class"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "sparrow_loader_duration_seconds",
+			Help:    "Time it took to load the runtime configuration including its retries",
+			Buckets: prometheus.DefBuckets,
+		}),
+		loads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sparrow_loader_loads_total",
+			Help: "Total number of loads of the runtime configuration by result: success, unchanged or error",
+		}, []string{"result"}),
+	}
+}
+
+// OnLoad sets the function called with the outcome of every load
+func (m *loaderMetrics) OnLoad(f func(LoadResult)) {
+	m.onLoad = f
+}
+
+// observe records the outcome of the load of the runtime configuration that took the elapsed time.
+// A configuration with the same digest as the previously loaded one is recorded as unchanged.
+func (m *loaderMetrics) observe(elapsed time.Duration, cfg *runtime.Config, err error) {
+	r := LoadResult{Result: LoadError, Duration: elapsed, Err: err}
+	if err == nil {
+		r.Result, r.Provenance = LoadSuccess, cfg.Provenance
+		if cfg.Provenance != nil {
+			if cfg.Provenance.Digest == m.digest {
+				r.Result = LoadUnchanged
+			}
+			m.digest = cfg.Provenance.Digest
+		}
+	}
+
+	m.duration.Observe(r.Duration.Seconds())
+	m.loads.WithLabelValues(r.Result).Inc()
+	if m.onLoad != nil {
+		m.onLoad(r)
+	}
+}
+
+// Describe sends the descriptors of the loader metrics to the channel
+func (m loaderMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.lastSuccess.Describe(ch)
+	m.responses.Describe(ch)
+	m.duration.Describe(ch)
+	m.loads.Describe(ch)
+}
+
+// Collect sends the loader metrics to the channel
+func (m loaderMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.lastSuccess.Collect(ch)
+	m.responses.Collect(ch)
+	m.duration.Collect(ch)
+	m.loads.Collect(ch)
 }
@@ -0,0 +1,140 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLoaderMetrics_observe(t *testing.T) {
+	errLoad := errors.New("load failed")
+	first := runtime.Config{Provenance: &checks.Provenance{Digest: "sha256:first"}}
+	second := runtime.Config{Provenance: &checks.Provenance{Digest: "sha256:second"}}
+
+	m := newLoaderMetrics()
+	var got []LoadResult
+	m.OnLoad(func(r LoadResult) { got = append(got, r) })
+
+	loads := []struct {
+		cfg  runtime.Config
+		err  error
+		want string
+	}{
+		{cfg: first, want: LoadSuccess},
+		{cfg: first, want: LoadUnchanged},
+		{err: errLoad, want: LoadError},
+		{cfg: first, want: LoadUnchanged},
+		{cfg: second, want: LoadSuccess},
+	}
+	for _, l := range loads {
+		m.observe(time.Millisecond, &l.cfg, l.err)
+	}
+
+	if len(got) != len(loads) {
+		t.Fatalf("OnLoad() calls = %d, want %d", len(got), len(loads))
+	}
+	for i, l := range loads {
+		if got[i].Result != l.want {
+			t.Errorf("load %d result = %q, want %q", i, got[i].Result, l.want)
+		}
+		if !errors.Is(got[i].Err, l.err) {
+			t.Errorf("load %d error = %v, want %v", i, got[i].Err, l.err)
+		}
+	}
+
+	for result, want := range map[string]float64{LoadSuccess: 2, LoadUnchanged: 2, LoadError: 1} {
+		if v := testutil.ToFloat64(m.loads.WithLabelValues(result)); v != want {
+			t.Errorf("sparrow_loader_loads_total{result=%q} = %v, want %v", result, v, want)
+		}
+	}
+	if n := testutil.CollectAndCount(m.duration); n != 1 {
+		t.Errorf("sparrow_loader_duration_seconds series = %d, want 1", n)
+	}
+}
+
+func TestRegisterLoader(t *testing.T) {
+	errOptions := errors.New("missing bucket")
+	RegisterLoader("test", LoaderType{
+		New: func(*Config, chan<- runtime.Config) Loader { return &LoaderMock{} },
+		Validate: func(_ context.Context, cfg *LoaderConfig) error {
+			var opts struct {
+				Bucket  string        `mapstructure:"bucket"`
+				Timeout time.Duration `mapstructure:"timeout"`
+			}
+			if err := cfg.DecodeOptions(&opts); err != nil {
+				return err
+			}
+			if opts.Bucket == "" {
+				return errOptions
+			}
+			return nil
+		},
+	})
+	t.Cleanup(func() {
+		loaderTypesMu.Lock()
+		defer loaderTypesMu.Unlock()
+		delete(loaderTypes, "test")
+	})
+
+	if got := LoaderTypes(); !slices.Equal(got, []string{"file", "gitlab", "http", "test"}) {
+		t.Errorf("LoaderTypes() = %v, want the built-in and the registered loader types", got)
+	}
+	if _, ok := NewLoader(&Config{Loader: LoaderConfig{Type: "test"}}, nil).(*LoaderMock); !ok {
+		t.Error("NewLoader() didn't create the registered loader type")
+	}
+	if _, ok := NewLoader(&Config{Loader: LoaderConfig{Type: "ftp"}}, nil).(*FileLoader); !ok {
+		t.Error("NewLoader() didn't fall back to the file loader")
+	}
+
+	tests := []struct {
+		name    string
+		options map[string]any
+		wantErr error
+	}{
+		{name: "valid options", options: map[string]any{"bucket": "configs", "timeout": "5s"}},
+		{name: "invalid options", options: map[string]any{"timeout": "5s"}, wantErr: errOptions},
+		{name: "ambiguous duration", options: map[string]any{"bucket": "configs", "timeout": 5}, wantErr: ErrAmbiguousDuration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := LoaderConfig{Type: "test", Options: tt.options}
+			if err := cfg.Validate(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("LoaderConfig.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := (&LoaderConfig{Type: "test", Options: map[string]any{"bucket": "configs", "region": "eu"}}).Validate(context.Background()); err == nil {
+		t.Error("LoaderConfig.Validate() accepted an unknown option")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterLoader() didn't panic on a duplicate loader type")
+		}
+	}()
+	RegisterLoader("http", LoaderType{New: func(*Config, chan<- runtime.Config) Loader { return &LoaderMock{} }})
+}
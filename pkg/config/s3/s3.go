@@ -0,0 +1,188 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package s3 implements a loader reading the runtime configuration from an object in an S3 bucket.
+// It's registered as loader type "s3" and serves as an example for loader types implemented outside of
+// the config package. Any S3 compatible object storage is supported, e.g. MinIO.
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+)
+
+// LoaderType is the name the loader is registered as
+const LoaderType = "s3"
+
+func init() {
+	config.RegisterLoader(LoaderType, config.LoaderType{
+		New: func(cfg *config.Config, cRuntime chan<- runtime.Config) config.Loader {
+			return NewLoader(cfg, cRuntime)
+		},
+		Validate: validate,
+	})
+}
+
+var (
+	// ErrInvalidConfig is returned when the options of the s3 loader are invalid
+	ErrInvalidConfig = errors.New("invalid s3 loader configuration")
+)
+
+// Config is the configuration of the s3 loader, set in the options of the loader configuration
+type Config struct {
+	// Endpoint is the URL of an S3 compatible object storage the object is addressed on path-style.
+	// The virtual-hosted-style URL of AWS S3 in the region is used if empty.
+	Endpoint string `mapstructure:"endpoint"`
+	// Region is the region of the bucket the requests are signed for
+	Region string `mapstructure:"region"`
+	// Bucket is the name of the bucket containing the runtime configuration
+	Bucket string `mapstructure:"bucket"`
+	// Key is the key of the object containing the runtime configuration
+	Key string `mapstructure:"key"`
+	// AccessKeyID is the access key the requests are signed with.
+	// Defaults to the AWS_ACCESS_KEY_ID environment variable.
+	AccessKeyID string `mapstructure:"accessKeyId"`
+	// SecretAccessKey is the secret of the access key.
+	// Defaults to the AWS_SECRET_ACCESS_KEY environment variable.
+	SecretAccessKey string `mapstructure:"secretAccessKey"`
+	// SessionToken is the token of temporary credentials.
+	// Defaults to the AWS_SESSION_TOKEN environment variable.
+	SessionToken string `mapstructure:"sessionToken"`
+	// Timeout is the timeout of a request
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Retry configures the retries of a failed request
+	Retry helper.RetryConfig `mapstructure:"retry"`
+}
+
+// newConfig decodes the configuration of the s3 loader from the loader configuration.
+// Missing credentials are read from the environment.
+func newConfig(lc *config.LoaderConfig) (Config, error) {
+	var c Config
+	if err := lc.DecodeOptions(&c); err != nil {
+		return c, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if c.AccessKeyID == "" && c.SecretAccessKey == "" {
+		c.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		c.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if c.SessionToken == "" {
+			c.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+		}
+	}
+	return c, nil
+}
+
+// validate validates the options of the s3 loader
+func validate(ctx context.Context, lc *config.LoaderConfig) error {
+	log := logger.FromContext(ctx)
+
+	c, err := newConfig(lc)
+	if err != nil {
+		log.Error("The options of the s3 loader cannot be decoded", "error", err)
+		return err
+	}
+	if c.Region == "" || c.Bucket == "" || c.Key == "" {
+		log.Error("The region, bucket and key of the s3 loader are required")
+		return fmt.Errorf("%w: region, bucket and key are required", ErrInvalidConfig)
+	}
+	if c.Endpoint != "" {
+		u, err := url.ParseRequestURI(c.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			log.Error("The endpoint of the s3 loader is not a valid http(s) url", "endpoint", c.Endpoint)
+			return fmt.Errorf("%w: invalid endpoint %q", ErrInvalidConfig, c.Endpoint)
+		}
+	}
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		log.Error("The credentials of the s3 loader are missing")
+		return fmt.Errorf("%w: access key id and secret access key are required", ErrInvalidConfig)
+	}
+	if c.Retry.Count < 0 || c.Retry.Count >= 5 {
+		log.Error("The amount of s3 loader retries should be equal or above 0 and below 5", "retryCount", c.Retry.Count)
+		return fmt.Errorf("%w: invalid retry count", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// NewLoader creates a loader reading the runtime configuration from the object configured in the options
+// of the loader configuration. The options are validated before, so decoding errors are ignored.
+func NewLoader(cfg *config.Config, cRuntime chan<- runtime.Config) *config.FetchLoader {
+	c, _ := newConfig(&cfg.Loader)
+	o := &object{cfg: c, client: &http.Client{Timeout: c.Timeout}, now: time.Now}
+	return config.NewFetchLoader(cfg, cRuntime, config.Fetcher{
+		Name:   LoaderType,
+		Source: fmt.Sprintf("s3://%s/%s", c.Bucket, c.Key),
+		Retry:  c.Retry,
+		Fetch:  o.fetch,
+	})
+}
+
+// object is the object in an S3 bucket containing the runtime configuration
+type object struct {
+	cfg    Config
+	client *http.Client
+	// now returns the time the requests are signed at
+	now func() time.Time
+}
+
+// fetch gets the object from the bucket
+func (o *object) fetch(ctx context.Context) (b []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.url(), http.NoBody)
+	if err != nil {
+		return nil, helper.Permanent(err)
+	}
+	sign(req, o.cfg, o.now())
+
+	res, err := o.client.Do(req) //nolint:bodyclose // closed in the deferred function
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		if cErr := Body.Close(); cErr != nil {
+			err = errors.Join(err, cErr)
+		}
+	}(res.Body)
+
+	if res.StatusCode != http.StatusOK {
+		err = fmt.Errorf("request failed, status is %s", res.Status)
+		if !helper.RetryableStatus(res.StatusCode) {
+			return nil, helper.Permanent(err)
+		}
+		return nil, err
+	}
+	return io.ReadAll(res.Body)
+}
+
+// url returns the URL of the object. Custom endpoints address the object on path-style,
+// AWS S3 on virtual-hosted-style.
+func (o *object) url() string {
+	if o.cfg.Endpoint != "" {
+		return strings.TrimSuffix(o.cfg.Endpoint, "/") + "/" + escapePath(o.cfg.Bucket+"/"+o.cfg.Key)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", o.cfg.Bucket, o.cfg.Region, escapePath(o.cfg.Key))
+}
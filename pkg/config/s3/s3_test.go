@@ -0,0 +1,170 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+)
+
+func TestNewLoader(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantErr   bool
+		wantCheck bool
+	}{
+		{
+			name:      "valid configuration",
+			status:    http.StatusOK,
+			body:      "health:\n  interval: 20s\n  timeout: 5s\n  targets:\n    - https://{{ .instanceName }}\n",
+			wantCheck: true,
+		},
+		{name: "missing object", status: http.StatusNotFound, wantErr: true},
+		{name: "invalid configuration", status: http.StatusOK, body: "health:\n  interval: 20s\n  timeout: 5s\n  targets:\n    - not a url\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.EscapedPath() != "/configs/sparrow/config%2Bv1.yaml" {
+					t.Errorf("Requested path = %q, want the object path", r.URL.EscapedPath())
+				}
+				if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+					t.Errorf("Request not signed: %q", r.Header.Get("Authorization"))
+				}
+				if r.Header.Get("X-Amz-Security-Token") != "session" {
+					t.Error("Request is missing the session token")
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			cfg := &config.Config{
+				SparrowName: "sparrow.example.com",
+				Loader: config.LoaderConfig{
+					Type: LoaderType,
+					Options: map[string]any{
+						"endpoint":        srv.URL,
+						"region":          "eu-central-1",
+						"bucket":          "configs",
+						"key":             "sparrow/config+v1.yaml",
+						"accessKeyId":     "key",
+						"secretAccessKey": "secret",
+						"sessionToken":    "session",
+						"timeout":         "5s",
+					},
+				},
+			}
+			if err := cfg.Loader.Validate(context.Background()); err != nil {
+				t.Fatalf("LoaderConfig.Validate() error = %v", err)
+			}
+
+			cRuntime := make(chan runtime.Config, 1)
+			l, ok := config.NewLoader(cfg, cRuntime).(*config.FetchLoader)
+			if !ok {
+				t.Fatalf("config.NewLoader() didn't create the s3 loader")
+			}
+			err := l.Run(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchLoader.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := <-cRuntime
+			if (got.Health != nil) != tt.wantCheck {
+				t.Fatalf("FetchLoader.Run() config = %+v, want health check %v", got, tt.wantCheck)
+			}
+			if !tt.wantCheck {
+				return
+			}
+			if got.Health.Targets[0] != "https://sparrow.example.com" {
+				t.Errorf("FetchLoader.Run() target = %q, want the rendered target", got.Health.Targets[0])
+			}
+			if got.Provenance == nil || got.Provenance.Loader != LoaderType || got.Provenance.Source != "s3://configs/sparrow/config+v1.yaml" {
+				t.Errorf("FetchLoader.Run() provenance = %+v, want the s3 object", got.Provenance)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() map[string]any {
+		return map[string]any{
+			"region": "eu-central-1", "bucket": "configs", "key": "config.yaml",
+			"accessKeyId": "key", "secretAccessKey": "secret",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(map[string]any)
+		wantErr bool
+	}{
+		{name: "valid options", modify: func(map[string]any) {}},
+		{name: "missing bucket", modify: func(o map[string]any) { delete(o, "bucket") }, wantErr: true},
+		{name: "invalid endpoint", modify: func(o map[string]any) { o["endpoint"] = "minio:9000" }, wantErr: true},
+		{name: "missing credentials", modify: func(o map[string]any) { delete(o, "secretAccessKey") }, wantErr: true},
+		{name: "unknown option", modify: func(o map[string]any) { o["buckett"] = "configs" }, wantErr: true},
+		{name: "ambiguous timeout", modify: func(o map[string]any) { o["timeout"] = 30 }, wantErr: true},
+		{name: "maximum retries", modify: func(o map[string]any) { o["retry"] = map[string]any{"count": 4} }},
+		{name: "too many retries", modify: func(o map[string]any) { o["retry"] = map[string]any{"count": 5} }, wantErr: true},
+		{name: "negative retries", modify: func(o map[string]any) { o["retry"] = map[string]any{"count": -1} }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AWS_ACCESS_KEY_ID", "")
+			t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+			opts := valid()
+			tt.modify(opts)
+
+			lc := &config.LoaderConfig{Type: LoaderType, Options: opts}
+			err := lc.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoaderConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("LoaderConfig.Validate() error = %v, want %v", err, ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestNewConfig_environment(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "env-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env-secret")
+	t.Setenv("AWS_SESSION_TOKEN", "env-session")
+
+	c, err := newConfig(&config.LoaderConfig{Options: map[string]any{"bucket": "configs"}})
+	if err != nil {
+		t.Fatalf("newConfig() error = %v", err)
+	}
+	if c.AccessKeyID != "env-key" || c.SecretAccessKey != "env-secret" || c.SessionToken != "env-session" {
+		t.Errorf("newConfig() credentials = %q, %q, %q, want the environment", c.AccessKeyID, c.SecretAccessKey, c.SessionToken)
+	}
+}
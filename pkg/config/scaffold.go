@@ -0,0 +1,179 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/httpheaders"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/ntp"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/caas-team/sparrow/pkg/checks/zone"
+)
+
+// DefaultScaffoldChecks are the checks scaffolded if none are chosen
+var DefaultScaffoldChecks = []string{health.CheckName, latency.CheckName}
+
+// scaffoldExamples returns the example configuration of every check that can be scaffolded
+func scaffoldExamples() map[string]checks.Runtime {
+	return map[string]checks.Runtime{
+		health.CheckName: &health.Config{
+			Targets:  []string{"https://example.com/"},
+			Interval: 30 * time.Second,
+			Timeout:  5 * time.Second,
+			Retry:    checks.DefaultRetry,
+		},
+		latency.CheckName: &latency.Config{
+			Targets:  []string{"https://example.com/"},
+			Interval: 30 * time.Second,
+			Timeout:  5 * time.Second,
+			Retry:    checks.DefaultRetry,
+		},
+		dns.CheckName: &dns.Config{
+			Targets:  []string{"example.com"},
+			Interval: 30 * time.Second,
+			Timeout:  5 * time.Second,
+			Retry:    checks.DefaultRetry,
+		},
+		traceroute.CheckName: &traceroute.Config{
+			Targets:  []traceroute.Target{{Addr: "example.com", Port: 443}},
+			Interval: 5 * time.Minute,
+			Timeout:  3 * time.Second,
+			Retry:    checks.DefaultRetry,
+			MaxHops:  30,
+		},
+		pmtu.CheckName: &pmtu.Config{
+			Targets:  []string{"example.com"},
+			Interval: 5 * time.Minute,
+			Timeout:  time.Second,
+			Retry:    checks.DefaultRetry,
+		},
+		ntp.CheckName: &ntp.Config{
+			Targets:   []string{"pool.ntp.org"},
+			Interval:  time.Minute,
+			Timeout:   2 * time.Second,
+			Retry:     checks.DefaultRetry,
+			MaxOffset: 100 * time.Millisecond,
+		},
+		zone.CheckName: &zone.Config{
+			Zone:     "example.com",
+			Targets:  []string{"a.iana-servers.net", "b.iana-servers.net"},
+			Records:  []zone.Record{{Name: "@", Type: "NS"}},
+			Interval: 5 * time.Minute,
+			Timeout:  2 * time.Second,
+			Retry:    checks.DefaultRetry,
+		},
+		httpheaders.CheckName: &httpheaders.Config{
+			Targets:  []string{"https://example.com/"},
+			Interval: time.Hour,
+			Timeout:  10 * time.Second,
+			Retry:    checks.DefaultRetry,
+		},
+	}
+}
+
+// ScaffoldChecks returns the names of the checks that can be scaffolded
+func ScaffoldChecks() []string {
+	return slices.Sorted(maps.Keys(scaffoldExamples()))
+}
+
+// Scaffold describes the startup configuration and the example runtime configuration
+// scaffolded for a new sparrow
+type Scaffold struct {
+	// Name is the DNS name of the sparrow
+	Name string
+	// Checks are the checks the runtime configuration contains an example of
+	Checks []string
+	// RuntimePath is the path of the runtime configuration read by the file loader
+	RuntimePath string
+}
+
+// startupTemplate is the template of the scaffolded startup configuration
+var startupTemplate = template.Must(template.New("startup").Parse(`# DNS name of the sparrow
+name: {{ .Name }}
+
+# The API serving the results of the checks
+api:
+  address: ":8080"
+
+# The loader continuously reading the runtime configuration of the checks
+loader:
+  type: file
+  # The interval in which the runtime configuration is reloaded. 0 reads it once.
+  interval: 30s
+  file:
+    path: {{ printf "%q" .RuntimePath }}
+`))
+
+// Render returns the scaffolded startup and runtime configuration.
+// Both configurations are validated, so they can be used right away.
+func (s Scaffold) Render(ctx context.Context) (startup, rt []byte, err error) {
+	examples := scaffoldExamples()
+	selected := map[string]checks.Runtime{}
+	for _, name := range s.Checks {
+		example, ok := examples[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown check %q, must be one of %v", name, ScaffoldChecks())
+		}
+		selected[name] = example
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2) //nolint:mnd // Indentation of the examples in the documentation
+	if err = enc.Encode(selected); err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal runtime configuration: %w", err)
+	}
+	rt = bytes.Clone(buf.Bytes())
+	var rc runtime.Config
+	if err = yaml.Unmarshal(rt, &rc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse runtime configuration: %w", err)
+	}
+	if err = rc.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("scaffolded runtime configuration is invalid: %w", err)
+	}
+
+	buf.Reset()
+	if err = startupTemplate.Execute(&buf, s); err != nil {
+		return nil, nil, fmt.Errorf("failed to render startup configuration: %w", err)
+	}
+	var sc Config
+	if err = yaml.Unmarshal(buf.Bytes(), &sc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse startup configuration: %w", err)
+	}
+	if err = sc.Validate(ctx); err != nil {
+		return nil, nil, fmt.Errorf("scaffolded startup configuration is invalid: %w", err)
+	}
+
+	return buf.Bytes(), rt, nil
+}
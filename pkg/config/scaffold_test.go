@@ -0,0 +1,84 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestScaffold_Render(t *testing.T) {
+	tests := []struct {
+		name     string
+		scaffold Scaffold
+		wantErr  bool
+	}{
+		{
+			name:     "default checks",
+			scaffold: Scaffold{Name: "sparrow.example.com", Checks: DefaultScaffoldChecks, RuntimePath: "checks.yaml"},
+		},
+		{
+			name:     "all checks",
+			scaffold: Scaffold{Name: "sparrow.example.com", Checks: ScaffoldChecks(), RuntimePath: "config/checks.yaml"},
+		},
+		{
+			name:     "unknown check",
+			scaffold: Scaffold{Name: "sparrow.example.com", Checks: []string{"unknown"}, RuntimePath: "checks.yaml"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid name",
+			scaffold: Scaffold{Name: "not a dns name", Checks: DefaultScaffoldChecks, RuntimePath: "checks.yaml"},
+			wantErr:  true,
+		},
+	}
+
+	startupSchema, err := Schema(SchemaStartup)
+	require.NoError(t, err)
+	runtimeSchema, err := Schema(SchemaRuntime)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startup, rt, err := tt.scaffold.Render(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var sc map[string]any
+			require.NoError(t, yaml.Unmarshal(startup, &sc))
+			assert.NoError(t, conforms(startupSchema, sc, ""))
+			assert.Equal(t, tt.scaffold.RuntimePath, sc["loader"].(map[string]any)["file"].(map[string]any)["path"])
+
+			var rc map[string]any
+			require.NoError(t, yaml.Unmarshal(rt, &rc))
+			assert.NoError(t, conforms(runtimeSchema, rc, ""))
+			assert.Len(t, rc, len(tt.scaffold.Checks))
+			for _, c := range tt.scaffold.Checks {
+				assert.Contains(t, rc, c)
+			}
+		})
+	}
+}
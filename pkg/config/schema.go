@@ -0,0 +1,182 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+)
+
+const (
+	// SchemaStartup is the kind of the JSON Schema of the startup configuration
+	SchemaStartup = "startup"
+	// SchemaRuntime is the kind of the JSON Schema of the runtime configuration of the checks
+	SchemaRuntime = "runtime"
+)
+
+// schemaDialect is the JSON Schema dialect of the generated schemas
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches the durations parsed by time.ParseDuration, e.g. 1m30s
+const durationPattern = `^-?([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// ErrUnknownSchema is returned if a JSON Schema of an unknown kind is requested
+type ErrUnknownSchema struct {
+	Kind string
+}
+
+func (e ErrUnknownSchema) Error() string {
+	return fmt.Sprintf("unknown configuration schema %q, must be %q or %q", e.Kind, SchemaStartup, SchemaRuntime)
+}
+
+// Schema returns the JSON Schema of the startup or the runtime configuration, which is generated
+// from the configuration structs. Without a kind, the schema accepts both configurations.
+// Unknown fields are rejected, so misspelled fields are found before the configuration is loaded.
+func Schema(kind string) (map[string]any, error) {
+	var schema map[string]any
+	switch kind {
+	case SchemaStartup:
+		schema = schemaFor(reflect.TypeOf(Config{}), nil)
+		schema["title"] = "sparrow startup configuration"
+	case SchemaRuntime:
+		schema = schemaFor(reflect.TypeOf(runtime.Config{}), nil)
+		schema["title"] = "sparrow runtime configuration"
+	case "":
+		schema = map[string]any{
+			"title": "sparrow configuration",
+			"anyOf": []any{
+				map[string]any{"$ref": "#/$defs/" + SchemaStartup},
+				map[string]any{"$ref": "#/$defs/" + SchemaRuntime},
+			},
+			"$defs": map[string]any{
+				SchemaStartup: schemaFor(reflect.TypeOf(Config{}), nil),
+				SchemaRuntime: schemaFor(reflect.TypeOf(runtime.Config{}), nil),
+			},
+		}
+	default:
+		return nil, ErrUnknownSchema{Kind: kind}
+	}
+	schema["$schema"] = schemaDialect
+	return schema, nil
+}
+
+// schemaFor returns the JSON Schema of the YAML representation of the type.
+// The parents are the struct types the type is nested in, which end a recursion.
+func schemaFor(t reflect.Type, parents []reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case durationType:
+		// Bare numbers are ambiguous, so the only duration accepted without a unit is 0
+		return map[string]any{"type": []string{"string", "integer"}, "pattern": durationPattern, "minimum": 0, "maximum": 0}
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), parents)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), parents)}
+	case reflect.Struct:
+		for _, p := range parents {
+			if p == t {
+				return map[string]any{}
+			}
+		}
+		properties := map[string]any{}
+		addProperties(properties, t, append(parents, t))
+		return map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	default:
+		return map[string]any{}
+	}
+}
+
+// addProperties adds the schemas of the exported fields of the struct type to the properties.
+// The fields of inlined structs are added as properties of the struct itself.
+func addProperties(properties map[string]any, t reflect.Type, parents []reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, inline := fieldName(f)
+		if name == "-" {
+			continue
+		}
+		if inline {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addProperties(properties, ft, parents)
+				continue
+			}
+		}
+		properties[name] = schemaFor(f.Type, parents)
+	}
+}
+
+// fieldName returns the name of the field in the YAML representation and whether it's inlined.
+// Fields without a YAML tag fall back to their JSON tag or their lowercased name.
+func fieldName(f reflect.StructField) (name string, inline bool) {
+	tag, ok := f.Tag.Lookup("yaml")
+	if !ok {
+		tag, ok = f.Tag.Lookup("json")
+	}
+	if !ok {
+		return strings.ToLower(f.Name), f.Anonymous
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	inline = f.Anonymous && name == ""
+	for _, o := range strings.Split(opts, ",") {
+		inline = inline || o == "inline"
+	}
+	if name == "" {
+		name = strings.ToLower(f.Name)
+	}
+	return name, inline
+}
@@ -0,0 +1,146 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema(t *testing.T) {
+	t.Run("unknown kind", func(t *testing.T) {
+		_, err := Schema("unknown")
+		assert.True(t, errors.As(err, &ErrUnknownSchema{}))
+	})
+
+	t.Run("combined schema references both configurations", func(t *testing.T) {
+		s, err := Schema("")
+		require.NoError(t, err)
+		assert.Equal(t, schemaDialect, s["$schema"])
+		defs := s["$defs"].(map[string]any)
+		assert.Contains(t, defs, SchemaStartup)
+		assert.Contains(t, defs, SchemaRuntime)
+	})
+
+	t.Run("fields are named like in the YAML representation", func(t *testing.T) {
+		s, err := Schema(SchemaStartup)
+		require.NoError(t, err)
+		loader := property(t, s, "loader")
+		assert.Equal(t, []string{"string", "integer"}, property(t, loader, "interval")["type"])
+		http := property(t, loader, "http")
+		assert.Contains(t, http["properties"], "retry")
+		assert.NotContains(t, http["properties"], "RetryCfg")
+		assert.Equal(t, false, s["additionalProperties"])
+	})
+
+	t.Run("example configurations conform", func(t *testing.T) {
+		runtimeSchema, err := Schema(SchemaRuntime)
+		require.NoError(t, err)
+		paths, err := filepath.Glob(filepath.Join("..", "checks", "runtime", "testdata", "configs", "*.yaml"))
+		require.NoError(t, err)
+		require.NotEmpty(t, paths)
+
+		for _, path := range append(paths, filepath.Join("test", "data", "config.yaml")) {
+			b, err := os.ReadFile(path) //#nosec G304 // Test data
+			require.NoError(t, err)
+			var doc any
+			require.NoError(t, yaml.Unmarshal(b, &doc))
+			assert.NoError(t, conforms(runtimeSchema, doc, ""), path)
+		}
+
+		startupSchema, err := Schema(SchemaStartup)
+		require.NoError(t, err)
+		var doc any
+		require.NoError(t, yaml.Unmarshal([]byte(startupConfig), &doc))
+		assert.NoError(t, conforms(startupSchema, doc, ""))
+		assert.Error(t, conforms(startupSchema, map[string]any{"loader": map[string]any{"intervall": "1m"}}, ""))
+	})
+}
+
+// startupConfig is an example of a startup configuration
+const startupConfig = `
+name: sparrow.example.com
+api:
+  address: :8080
+loader:
+  type: http
+  interval: 30s
+  http:
+    url: https://config.example.com/sparrow.yaml
+    token: secret
+    timeout: 30s
+    retry:
+      count: 3
+      delay: 1s
+targetManager:
+  enabled: true
+  type: gitlab
+  checkInterval: 1m
+  gitlab:
+    baseUrl: https://gitlab.example.com
+    projectId: 1
+`
+
+// property returns the schema of the property of an object schema
+func property(t *testing.T, schema map[string]any, name string) map[string]any {
+	t.Helper()
+	props, ok := schema["properties"].(map[string]any)
+	require.True(t, ok, "schema has no properties")
+	p, ok := props[name].(map[string]any)
+	require.True(t, ok, "schema has no property %q", name)
+	return p
+}
+
+// conforms returns an error if the document contains fields unknown to the schema
+func conforms(schema map[string]any, doc any, path string) error {
+	switch d := doc.(type) {
+	case map[string]any:
+		props, _ := schema["properties"].(map[string]any)
+		additional, _ := schema["additionalProperties"].(map[string]any)
+		for k, v := range d {
+			switch {
+			case props[k] != nil:
+				if err := conforms(props[k].(map[string]any), v, path+"."+k); err != nil {
+					return err
+				}
+			case additional != nil:
+				if err := conforms(additional, v, path+"."+k); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unknown field %s.%s", path, k)
+			}
+		}
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		for i, v := range d {
+			if err := conforms(items, v, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/template"
+)
+
+// RenderRuntimeConfig evaluates the template variables of the raw runtime configuration,
+// so one shared configuration can serve many sparrows with per-instance differences.
+// The name of the sparrow is available as {{ .instanceName }} and environment variables
+// as {{ env "NAME" }}. A configuration without template actions is returned unchanged.
+func RenderRuntimeConfig(b []byte, instanceName string) ([]byte, error) {
+	if !bytes.Contains(b, []byte("{{")) {
+		return b, nil
+	}
+
+	tmpl, err := template.New("runtime").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"env": os.Getenv}).
+		Parse(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{"instanceName": instanceName}); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
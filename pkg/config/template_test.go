@@ -0,0 +1,74 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import "testing"
+
+func TestRenderRuntimeConfig(t *testing.T) {
+	t.Setenv("SPARROW_TEST_REGION", "eu-west")
+
+	tests := []struct {
+		name    string
+		config  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "without template actions",
+			config: "health:\n  targets:\n    - https://example.com\n",
+			want:   "health:\n  targets:\n    - https://example.com\n",
+		},
+		{
+			name:   "instance name",
+			config: "target: https://example.com/?from={{ .instanceName }}",
+			want:   "target: https://example.com/?from=sparrow.example.com",
+		},
+		{
+			name:   "environment variable",
+			config: `target: https://{{ env "SPARROW_TEST_REGION" }}.example.com`,
+			want:   "target: https://eu-west.example.com",
+		},
+		{
+			name:   "unset environment variable",
+			config: `target: https://{{ env "SPARROW_TEST_UNSET" }}example.com`,
+			want:   "target: https://example.com",
+		},
+		{
+			name:    "unknown variable",
+			config:  "target: {{ .region }}",
+			wantErr: true,
+		},
+		{
+			name:    "malformed template",
+			config:  "target: {{ .instanceName",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderRuntimeConfig([]byte(tt.config), "sparrow.example.com")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderRuntimeConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("RenderRuntimeConfig() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
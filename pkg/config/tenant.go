@@ -0,0 +1,89 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// reservedTenantNames are names that cannot be used for tenants
+// because they collide with the API paths
+var reservedTenantNames = []string{"metrics"}
+
+// TenantConfig is the configuration of a tenant.
+// A tenant is a logical group of checks with its own runtime configuration,
+// isolated results and a dedicated value of the tenant metrics label.
+type TenantConfig struct {
+	// Name is the name of the tenant used in the API paths and metric labels
+	Name string `yaml:"name" mapstructure:"name"`
+	// Loader is the configuration for the loader of the tenant's runtime configuration
+	Loader LoaderConfig `yaml:"loader" mapstructure:"loader"`
+}
+
+// Validate validates the tenant configuration
+func (t *TenantConfig) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx).With("tenant", t.Name)
+
+	if !isTenantName(t.Name) {
+		log.Error("The name of the tenant must be a lowercase DNS label")
+		return ErrInvalidTenantName
+	}
+	for _, r := range reservedTenantNames {
+		if t.Name == r {
+			log.Error("The name of the tenant is reserved")
+			return ErrInvalidTenantName
+		}
+	}
+
+	if err := t.Loader.Validate(ctx); err != nil {
+		log.Error("The loader configuration of the tenant is invalid")
+		return err
+	}
+	return nil
+}
+
+// validateTenants validates all tenants and ensures their names are unique
+func (c *Config) validateTenants(ctx context.Context) (err error) {
+	seen := map[string]struct{}{}
+	for i := range c.Tenants {
+		t := &c.Tenants[i]
+		if vErr := t.Validate(ctx); vErr != nil {
+			err = errors.Join(err, fmt.Errorf("tenant %q: %w", t.Name, vErr))
+			continue
+		}
+		if _, ok := seen[t.Name]; ok {
+			logger.FromContext(ctx).Error("The tenant is configured multiple times", "tenant", t.Name)
+			err = errors.Join(err, fmt.Errorf("tenant %q: %w", t.Name, ErrDuplicateTenant))
+			continue
+		}
+		seen[t.Name] = struct{}{}
+	}
+	return err
+}
+
+// isTenantName checks if the given string is a valid tenant name
+func isTenantName(s string) bool {
+	re := regexp.MustCompile(`^[a-z0-9]([a-z0-9\-]{0,61}[a-z0-9])?$`)
+	return re.MatchString(s)
+}
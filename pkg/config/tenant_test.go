@@ -0,0 +1,96 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+)
+
+func TestConfig_validateTenants(t *testing.T) {
+	loader := LoaderConfig{
+		Type: "http",
+		Http: HttpLoaderConfig{
+			Url:     "https://test.de/config",
+			Timeout: time.Second,
+			RetryCfg: helper.RetryConfig{
+				Count: 1,
+				Delay: time.Second,
+			},
+		},
+		Interval: time.Second,
+	}
+
+	tests := []struct {
+		name    string
+		tenants []TenantConfig
+		wantErr error
+	}{
+		{
+			name: "valid tenants",
+			tenants: []TenantConfig{
+				{Name: "team-a", Loader: loader},
+				{Name: "team-b", Loader: loader},
+			},
+		},
+		{
+			name:    "invalid name",
+			tenants: []TenantConfig{{Name: "Team_A", Loader: loader}},
+			wantErr: ErrInvalidTenantName,
+		},
+		{
+			name:    "reserved name",
+			tenants: []TenantConfig{{Name: "metrics", Loader: loader}},
+			wantErr: ErrInvalidTenantName,
+		},
+		{
+			name: "duplicate name",
+			tenants: []TenantConfig{
+				{Name: "team-a", Loader: loader},
+				{Name: "team-a", Loader: loader},
+			},
+			wantErr: ErrDuplicateTenant,
+		},
+		{
+			name:    "invalid loader",
+			tenants: []TenantConfig{{Name: "team-a", Loader: LoaderConfig{Type: "http"}}},
+			wantErr: ErrInvalidLoaderHttpURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{Tenants: tt.tenants}
+			err := c.validateTenants(context.Background())
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("validateTenants() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateTenants() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/caas-team/sparrow/internal/logger"
 )
@@ -41,6 +42,13 @@ func (c *Config) Validate(ctx context.Context) (err error) {
 		err = errors.Join(err, vErr)
 	}
 
+	if c.HasTenants() {
+		if vErr := c.validateTenants(ctx); vErr != nil {
+			log.Error("The tenant configuration is invalid")
+			err = errors.Join(err, vErr)
+		}
+	}
+
 	if c.HasTargetManager() {
 		if vErr := c.TargetManager.Validate(ctx); vErr != nil {
 			log.Error("The target manager configuration is invalid")
@@ -48,6 +56,84 @@ func (c *Config) Validate(ctx context.Context) (err error) {
 		}
 	}
 
+	if vErr := c.Hub.Validate(ctx); vErr != nil {
+		log.Error("The hub configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if vErr := c.Webhooks.Validate(ctx); vErr != nil {
+		log.Error("The webhook configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if vErr := c.Email.Validate(ctx); vErr != nil {
+		log.Error("The email configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if vErr := c.Mqtt.Validate(ctx); vErr != nil {
+		log.Error("The mqtt configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if vErr := c.Kafka.Validate(ctx); vErr != nil {
+		log.Error("The kafka configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if vErr := c.Incidents.Validate(ctx); vErr != nil {
+		log.Error("The incident configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if vErr := c.SLO.Validate(ctx); vErr != nil {
+		log.Error("The slo configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if c.HasSnapshotImport() {
+		if vErr := c.Snapshot.Validate(ctx); vErr != nil {
+			log.Error("The snapshot configuration is invalid")
+			err = errors.Join(err, vErr)
+		}
+	}
+
+	if vErr := c.Results.Validate(ctx); vErr != nil {
+		log.Error("The results configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if vErr := c.DB.Validate(ctx); vErr != nil {
+		log.Error("The database configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if c.HasAdmin() {
+		if vErr := c.Admin.Validate(ctx); vErr != nil {
+			log.Error("The admin configuration is invalid")
+			err = errors.Join(err, vErr)
+		}
+	}
+
+	if c.HasPushgateway() {
+		if vErr := c.Pushgateway.Validate(ctx); vErr != nil {
+			log.Error("The pushgateway configuration is invalid")
+			err = errors.Join(err, vErr)
+		}
+	}
+
+	if c.HasHeartbeat() {
+		if vErr := c.Heartbeat.Validate(ctx); vErr != nil {
+			log.Error("The heartbeat configuration is invalid")
+			err = errors.Join(err, vErr)
+		}
+	}
+
+	if vErr := c.Tuning.Validate(); vErr != nil {
+		log.Error("The tuning configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
 	if c.HasTelemetry() {
 		if vErr := c.Telemetry.Validate(ctx); vErr != nil {
 			log.Error("The telemetry configuration is invalid")
@@ -75,26 +161,106 @@ func (c *LoaderConfig) Validate(ctx context.Context) error {
 		return ErrInvalidLoaderInterval
 	}
 
-	switch c.Type {
-	case "http":
-		if _, err := url.ParseRequestURI(c.Http.Url); err != nil {
-			log.Error("The loader http url is not a valid url")
-			return ErrInvalidLoaderHttpURL
+	// The file loader is used as fallback if no loader type is set
+	if c.Type == "" {
+		return nil
+	}
+	t, ok := lookupLoaderType(c.Type)
+	if !ok {
+		log.Error("The loader type is not registered", "type", c.Type, "types", LoaderTypes())
+		return ErrInvalidLoaderType
+	}
+	if t.Validate != nil {
+		return t.Validate(ctx, c)
+	}
+	return nil
+}
+
+// validateHttpLoader validates the configuration of the http loader
+func validateHttpLoader(ctx context.Context, c *LoaderConfig) error {
+	log := logger.FromContext(ctx)
+
+	if _, err := url.ParseRequestURI(c.Http.Url); err != nil {
+		log.Error("The loader http url is not a valid url")
+		return ErrInvalidLoaderHttpURL
+	}
+	if c.Http.RetryCfg.Count < 0 || c.Http.RetryCfg.Count >= 5 {
+		log.Error("The amount of loader http retries should be equal or above 0 and below 5", "retryCount", c.Http.RetryCfg.Count)
+		return ErrInvalidLoaderHttpRetryCount
+	}
+	if c.Http.OAuth2.Enabled() {
+		if c.Http.Token != "" {
+			log.Error("The loader http token and oauth2 cannot be configured both")
+			return ErrInvalidLoaderHttpAuth
 		}
-		if c.Http.RetryCfg.Count < 0 || c.Http.RetryCfg.Count >= 5 {
-			log.Error("The amount of loader http retries should be above 0 and below 6", "retryCount", c.Http.RetryCfg.Count)
-			return ErrInvalidLoaderHttpRetryCount
+		if err := c.Http.OAuth2.Validate(); err != nil {
+			log.Error("The loader http oauth2 configuration is invalid", "error", err)
+			return errors.Join(ErrInvalidLoaderHttpAuth, err)
 		}
-	case "file":
-		if c.File.Path == "" {
-			log.Error("The loader file path cannot be empty")
-			return ErrInvalidLoaderFilePath
+	}
+	return nil
+}
+
+// validateFileLoader validates the configuration of the file loader
+func validateFileLoader(ctx context.Context, c *LoaderConfig) error {
+	if c.File.Path == "" {
+		logger.FromContext(ctx).Error("The loader file path cannot be empty")
+		return ErrInvalidLoaderFilePath
+	}
+	return nil
+}
+
+// validateGitlabLoader validates the configuration of the gitlab loader
+func validateGitlabLoader(ctx context.Context, c *LoaderConfig) error {
+	log := logger.FromContext(ctx)
+
+	if _, err := url.ParseRequestURI(c.Gitlab.BaseURL); err != nil {
+		log.Error("The loader gitlab base url is not a valid url")
+		return ErrInvalidLoaderGitlabURL
+	}
+	if c.Gitlab.ProjectID <= 0 {
+		log.Error("The loader gitlab project id should be above 0", "projectId", c.Gitlab.ProjectID)
+		return ErrInvalidLoaderGitlabProject
+	}
+	if c.Gitlab.Path == "" {
+		log.Error("The loader gitlab file path cannot be empty")
+		return ErrInvalidLoaderGitlabPath
+	}
+	if c.Gitlab.RetryCfg.Count < 0 || c.Gitlab.RetryCfg.Count >= 5 {
+		log.Error("The amount of loader gitlab retries should be equal or above 0 and below 5", "retryCount", c.Gitlab.RetryCfg.Count)
+		return ErrInvalidLoaderGitlabRetryCount
+	}
+	return nil
+}
+
+// Validate validates the snapshot configuration
+func (c *SnapshotConfig) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if strings.Contains(c.Import, "://") {
+		u, err := url.ParseRequestURI(c.Import)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			log.Error("The snapshot import url is not a valid http(s) url", "import", c.Import)
+			return ErrInvalidSnapshotImport
 		}
 	}
+	if c.Timeout < 0 {
+		log.Error("The snapshot timeout should be equal or above 0", "timeout", c.Timeout)
+		return ErrInvalidSnapshotImport
+	}
 
 	return nil
 }
 
+// Validate validates the results configuration
+func (c *ResultsConfig) Validate(ctx context.Context) error {
+	if c.FullInterval < 0 {
+		logger.FromContext(ctx).Error("The full interval of the results should be equal or above 0", "fullInterval", c.FullInterval)
+		return ErrInvalidResultsFullInterval
+	}
+	return nil
+}
+
 // isDNSName checks if the given string is a valid DNS name
 func isDNSName(s string) bool {
 	re := regexp.MustCompile(`^([a-z0-9]([a-z0-9\-]{0,61}[a-z0-9])?\.)+[a-z]{2,}$`)
@@ -25,6 +25,9 @@ import (
 
 	"github.com/caas-team/sparrow/internal/helper"
 	"github.com/caas-team/sparrow/pkg/api"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/oauth"
+	"github.com/caas-team/sparrow/pkg/webhook"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -58,6 +61,47 @@ func TestConfig_Validate(t *testing.T) {
 
 			wantErr: false,
 		},
+		{
+			name: "results - negative full interval",
+			config: Config{
+				SparrowName: "sparrow.com",
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				Loader: LoaderConfig{
+					Type: "file",
+					File: FileLoaderConfig{
+						Path: "config.yaml",
+					},
+					Interval: time.Second,
+				},
+				Results: ResultsConfig{
+					ChangesOnly:  true,
+					FullInterval: -time.Minute,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "db - unknown compression",
+			config: Config{
+				SparrowName: "sparrow.com",
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				Loader: LoaderConfig{
+					Type: "file",
+					File: FileLoaderConfig{
+						Path: "config.yaml",
+					},
+					Interval: time.Second,
+				},
+				DB: db.Config{
+					Compression: "lz4",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "loader - url missing",
 			config: Config{
@@ -103,6 +147,58 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "loader - token and oauth2",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "http",
+					Http: HttpLoaderConfig{
+						Url:     "https://test.de/config",
+						Token:   "SECRET",
+						Timeout: time.Second,
+						RetryCfg: helper.RetryConfig{
+							Count: 1,
+							Delay: time.Second,
+						},
+						OAuth2: oauth.Config{
+							TokenURL: "https://idp.test.de/token",
+							ClientID: "sparrow",
+						},
+					},
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - oauth2 client id missing",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "http",
+					Http: HttpLoaderConfig{
+						Url:     "https://test.de/config",
+						Timeout: time.Second,
+						RetryCfg: helper.RetryConfig{
+							Count: 1,
+							Delay: time.Second,
+						},
+						OAuth2: oauth.Config{
+							TokenURL: "https://idp.test.de/token",
+						},
+					},
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "loader - retry count to high",
 			config: Config{
@@ -142,6 +238,80 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "loader - gitlab ok",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "gitlab",
+					Gitlab: GitlabLoaderConfig{
+						BaseURL:   "https://gitlab.com",
+						ProjectID: 1,
+						Ref:       "v1.0.0",
+						Path:      "sparrow/config.yaml",
+					},
+					Interval: time.Second,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "loader - gitlab base url missing",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "gitlab",
+					Gitlab: GitlabLoaderConfig{
+						ProjectID: 1,
+						Path:      "config.yaml",
+					},
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - gitlab project id missing",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "gitlab",
+					Gitlab: GitlabLoaderConfig{
+						BaseURL: "https://gitlab.com",
+						Path:    "config.yaml",
+					},
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - gitlab path missing",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "gitlab",
+					Gitlab: GitlabLoaderConfig{
+						BaseURL:   "https://gitlab.com",
+						ProjectID: 1,
+					},
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "targetManager - Wrong Scheme",
 			config: Config{
@@ -159,6 +329,94 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "webhooks - invalid template",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "file",
+					File: FileLoaderConfig{
+						Path: "/config.yaml",
+					},
+					Interval: time.Second,
+				},
+				Webhooks: webhook.Config{
+					Receivers: []webhook.Receiver{{Name: "slack", Url: "https://hooks.example.com", Template: `{{ .Check `}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "snapshot - url",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "file",
+					File: FileLoaderConfig{
+						Path: "/config.yaml",
+					},
+					Interval: time.Second,
+				},
+				Snapshot: SnapshotConfig{Import: "https://sparrow-old.com/v1/admin/export"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "snapshot - file",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "file",
+					File: FileLoaderConfig{
+						Path: "/config.yaml",
+					},
+					Interval: time.Second,
+				},
+				Snapshot: SnapshotConfig{Import: "/var/lib/sparrow/snapshot.json"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "snapshot - unsupported scheme",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: "file",
+					File: FileLoaderConfig{
+						Path: "/config.yaml",
+					},
+					Interval: time.Second,
+				},
+				Snapshot: SnapshotConfig{Import: "ftp://sparrow-old.com/snapshot.json"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - unregistered type",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type:     "ftp",
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
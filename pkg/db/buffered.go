@@ -0,0 +1,182 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package db
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ DB = (*Buffered)(nil)
+
+// Buffered is a database buffering the results its backend fails to save.
+// The buffered results are saved in their original order before the next result,
+// so the backend catches up once it recovers. If the buffer is full, the oldest result is dropped.
+// Until the buffer is drained, the database is degraded and serves the buffered results
+// in addition to the results of the backend.
+type Buffered struct {
+	backend DB
+	// limit is the maximum number of buffered results
+	limit int
+	mu    sync.Mutex
+	// pending are the results the backend failed to save, oldest first
+	pending []checks.ResultDTO
+	// err is the last error of the backend
+	err error
+	// metrics report the degradation of the database
+	metrics bufferedMetrics
+}
+
+// bufferedMetrics contains the metrics of a buffered database
+type bufferedMetrics struct {
+	// failures counts the results the backend failed to save
+	failures prometheus.Counter
+	// dropped counts the results dropped because the buffer was full
+	dropped prometheus.Counter
+	// buffered is the number of results currently buffered
+	buffered prometheus.Gauge
+}
+
+// NewBuffered wraps the backend with a buffer of up to limit results
+func NewBuffered(backend DB, limit int) *Buffered {
+	return &Buffered{
+		backend: backend,
+		limit:   max(limit, 1),
+		metrics: bufferedMetrics{
+			failures: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "sparrow_db_write_failures_total",
+				Help: "Total number of results the database failed to save",
+			}),
+			dropped: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "sparrow_db_dropped_results_total",
+				Help: "Total number of results dropped because the buffer of the database was full",
+			}),
+			buffered: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "sparrow_db_buffered_results",
+				Help: "Number of results buffered in memory until the database accepts them again",
+			}),
+		},
+	}
+}
+
+// Save saves the buffered results and the given result in the backend.
+// A result the backend fails to save is buffered instead, so Save never fails.
+func (b *Buffered) Save(result checks.ResultDTO) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = append(b.pending, result)
+	for len(b.pending) > 0 {
+		if err := b.backend.Save(b.pending[0]); err != nil {
+			b.err = err
+			b.metrics.failures.Inc()
+			break
+		}
+		b.pending = b.pending[1:]
+	}
+
+	if dropped := len(b.pending) - b.limit; dropped > 0 {
+		b.pending = b.pending[dropped:]
+		b.metrics.dropped.Add(float64(dropped))
+	}
+	if len(b.pending) == 0 {
+		b.pending, b.err = nil, nil
+	}
+	b.metrics.buffered.Set(float64(len(b.pending)))
+	return nil
+}
+
+// Get returns the latest buffered result of the check or the result of the backend
+func (b *Buffered) Get(check string) (checks.Result, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := len(b.pending) - 1; i >= 0; i-- {
+		if r := b.pending[i]; r.Name == check && r.Result != nil {
+			return *r.Result, true
+		}
+	}
+	return b.backend.Get(check)
+}
+
+// List returns the results of the backend overlaid with the latest buffered results
+func (b *Buffered) List() map[string]checks.Result {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	results := b.backend.List()
+	for _, r := range b.pending {
+		if r.Result != nil {
+			results[r.Name] = *r.Result
+		}
+	}
+	return results
+}
+
+// Healthy returns an error if the database is degraded,
+// because results are buffered until the backend accepts them again
+func (b *Buffered) Healthy() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.pending) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d results buffered: %w", len(b.pending), b.err)
+}
+
+// Pending returns the amount of buffered results the backend didn't save yet
+func (b *Buffered) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Healthy returns an error if the database reports to be degraded
+func Healthy(d DB) error {
+	if h, ok := d.(interface{ Healthy() error }); ok {
+		return h.Healthy()
+	}
+	return nil
+}
+
+// Pending returns the amount of results the database buffers, because its backend didn't save them yet
+func Pending(d DB) int {
+	if p, ok := d.(interface{ Pending() int }); ok {
+		return p.Pending()
+	}
+	return 0
+}
+
+// Describe sends the descriptors of the database metrics to the channel
+func (b *Buffered) Describe(ch chan<- *prometheus.Desc) {
+	b.metrics.failures.Describe(ch)
+	b.metrics.dropped.Describe(ch)
+	b.metrics.buffered.Describe(ch)
+}
+
+// Collect sends the database metrics to the channel
+func (b *Buffered) Collect(ch chan<- prometheus.Metric) {
+	b.metrics.failures.Collect(ch)
+	b.metrics.dropped.Collect(ch)
+	b.metrics.buffered.Collect(ch)
+}
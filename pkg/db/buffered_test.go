@@ -0,0 +1,110 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package db
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// failingDB is a database recording the saved results, which fails to save while err is set
+type failingDB struct {
+	*InMemory
+	err   error
+	saved []string
+}
+
+func (f *failingDB) Save(result checks.ResultDTO) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.saved = append(f.saved, result.Name)
+	return f.InMemory.Save(result)
+}
+
+func TestBuffered(t *testing.T) {
+	errDiskFull := errors.New("disk full")
+	backend := &failingDB{InMemory: NewInMemory()}
+	b := NewBuffered(backend, 2)
+	result := func(name string, data int) checks.ResultDTO {
+		return checks.ResultDTO{Name: name, Result: &checks.Result{Data: data}}
+	}
+
+	_ = b.Save(result("health", 1))
+	if err := b.Healthy(); err != nil {
+		t.Fatalf("Healthy() = %v, want nil", err)
+	}
+
+	// Failed results are buffered and served
+	backend.err = errDiskFull
+	for _, r := range []checks.ResultDTO{result("health", 2), result("latency", 3), result("dns", 4)} {
+		if err := b.Save(r); err != nil {
+			t.Fatalf("Save() = %v, want nil", err)
+		}
+	}
+	if err := b.Healthy(); !errors.Is(err, errDiskFull) {
+		t.Errorf("Healthy() = %v, want %v", err, errDiskFull)
+	}
+	if err := Healthy(b); !errors.Is(err, errDiskFull) {
+		t.Errorf("Healthy(b) = %v, want %v", err, errDiskFull)
+	}
+	if got, ok := b.Get("latency"); !ok || got.Data != 3 {
+		t.Errorf("Get() = %v, %v, want the buffered result", got, ok)
+	}
+	// The oldest buffered result was dropped, so the stored result is served
+	want := map[string]checks.Result{"health": {Data: 1}, "latency": {Data: 3}, "dns": {Data: 4}}
+	if got := b.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+	if v := testutil.ToFloat64(b.metrics.failures); v != 3 {
+		t.Errorf("sparrow_db_write_failures_total = %v, want 3", v)
+	}
+	if v := testutil.ToFloat64(b.metrics.dropped); v != 1 {
+		t.Errorf("sparrow_db_dropped_results_total = %v, want 1", v)
+	}
+	if v := testutil.ToFloat64(b.metrics.buffered); v != 2 {
+		t.Errorf("sparrow_db_buffered_results = %v, want 2", v)
+	}
+
+	// The buffer is drained in order once the backend recovers
+	backend.err = nil
+	_ = b.Save(result("health", 5))
+	if err := b.Healthy(); err != nil {
+		t.Errorf("Healthy() = %v, want nil", err)
+	}
+	if want := []string{"health", "latency", "dns", "health"}; !reflect.DeepEqual(backend.saved, want) {
+		t.Errorf("saved = %v, want %v", backend.saved, want)
+	}
+	if v := testutil.ToFloat64(b.metrics.buffered); v != 0 {
+		t.Errorf("sparrow_db_buffered_results = %v, want 0", v)
+	}
+	if got, _ := b.Get("health"); got.Data != 5 {
+		t.Errorf("Get() = %v, want the latest result", got)
+	}
+}
+
+func TestHealthy(t *testing.T) {
+	if err := Healthy(NewInMemory()); err != nil {
+		t.Errorf("Healthy() = %v, want nil for the in-memory database", err)
+	}
+}
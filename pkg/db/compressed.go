@@ -0,0 +1,220 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionZstd compresses the results with zstd, which achieves the higher compression ratio
+	CompressionZstd = "zstd"
+	// CompressionSnappy compresses the results with snappy, which is faster but compresses less
+	CompressionSnappy = "snappy"
+)
+
+// Compressions returns the supported compression algorithms
+func Compressions() []string {
+	return []string{CompressionZstd, CompressionSnappy}
+}
+
+// Compressor compresses the data of the results stored in a database.
+// Its methods must be safe for concurrent use.
+type Compressor interface {
+	// Compress returns the compressed src
+	Compress(src []byte) ([]byte, error)
+	// Decompress returns the decompressed src
+	Decompress(src []byte) ([]byte, error)
+}
+
+// NewCompressor returns the compressor of the given algorithm
+func NewCompressor(algorithm string) (Compressor, error) {
+	switch algorithm {
+	case CompressionZstd:
+		return newZstdCompressor()
+	case CompressionSnappy:
+		return snappyCompressor{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCompression, algorithm)
+	}
+}
+
+// zstdCompressor compresses with zstd
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// newZstdCompressor creates a zstd compressor. Its encoder and decoder are
+// created once, because they are expensive to create and safe for concurrent use.
+func newZstdCompressor() (*zstdCompressor, error) {
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+	dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+	return &zstdCompressor{encoder: enc, decoder: dec}, nil
+}
+
+func (z *zstdCompressor) Compress(src []byte) ([]byte, error) {
+	return z.encoder.EncodeAll(src, nil), nil
+}
+
+func (z *zstdCompressor) Decompress(src []byte) ([]byte, error) {
+	return z.decoder.DecodeAll(src, nil)
+}
+
+// snappyCompressor compresses with snappy
+type snappyCompressor struct{}
+
+func (snappyCompressor) Compress(src []byte) ([]byte, error) {
+	return snappy.Encode(nil, src), nil
+}
+
+func (snappyCompressor) Decompress(src []byte) ([]byte, error) {
+	return snappy.Decode(nil, src)
+}
+
+var _ DB = (*Compressed)(nil)
+
+// Compressed is an in-memory database storing the data of the results compressed.
+// The data is JSON encoded before it's compressed and decoded into the type of the original data
+// when the result is read the first time. The decoded result is kept until the next result of the check
+// is saved, so repeated reads, e.g. by the controller or the metrics collectors, don't decompress it again.
+type Compressed struct {
+	compressor Compressor
+	// minSize is the minimum size of the JSON encoded data of a result to be compressed
+	minSize int
+	data    sync.Map
+}
+
+// compressedResult is a result stored in the compressed database
+type compressedResult struct {
+	// result is the stored result. Its data is nil if the data is compressed.
+	result checks.Result
+	// data is the compressed JSON encoded data of the result, nil if it isn't compressed
+	data []byte
+	// decoded returns the result with its decompressed data. It decompresses the data only once.
+	decoded func() (checks.Result, error)
+}
+
+// NewCompressed creates a new in-memory database compressing the data of the results
+// whose JSON encoded data is at least minSize bytes large
+func NewCompressed(c Compressor, minSize int) *Compressed {
+	return &Compressed{compressor: c, minSize: minSize}
+}
+
+// Save compresses the data of the result if it's large enough and stores it
+func (c *Compressed) Save(result checks.ResultDTO) error {
+	if result.Result == nil {
+		c.data.Store(result.Name, &compressedResult{})
+		return nil
+	}
+
+	b, err := json.Marshal(result.Result.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode the data of the result: %w", err)
+	}
+	if len(b) < c.minSize {
+		c.data.Store(result.Name, &compressedResult{result: *result.Result})
+		return nil
+	}
+
+	compressed, err := c.compressor.Compress(b)
+	if err != nil {
+		return fmt.Errorf("failed to compress the data of the result: %w", err)
+	}
+	stored := &compressedResult{result: *result.Result, data: compressed}
+	stored.result.Data = nil
+	typ := reflect.TypeOf(result.Result.Data)
+	stored.decoded = sync.OnceValues(func() (checks.Result, error) {
+		return c.decompress(stored, typ)
+	})
+	c.data.Store(result.Name, stored)
+	return nil
+}
+
+// Get returns the result of the check with its decompressed data.
+// A result that can't be decompressed isn't returned.
+func (c *Compressed) Get(check string) (checks.Result, bool) {
+	tmp, ok := c.data.Load(check)
+	if !ok {
+		return checks.Result{}, false
+	}
+	// this should not fail, otherwise this will panic
+	res, err := tmp.(*compressedResult).get()
+	if err != nil {
+		return checks.Result{}, false
+	}
+	return res, true
+}
+
+// List returns the results of all checks with their decompressed data
+func (c *Compressed) List() map[string]checks.Result {
+	results := make(map[string]checks.Result)
+	c.data.Range(func(key, value any) bool {
+		// these assertions should not fail, unless we have a bug somewhere
+		check := key.(string)
+		res, err := value.(*compressedResult).get()
+		if err == nil {
+			results[check] = res
+		}
+		return true
+	})
+	return results
+}
+
+// get returns the stored result with its decompressed data
+func (r *compressedResult) get() (checks.Result, error) {
+	if r.data == nil {
+		return r.result, nil
+	}
+	return r.decoded()
+}
+
+// decompress returns the stored result with its data decompressed and decoded into the given type
+func (c *Compressed) decompress(stored *compressedResult, typ reflect.Type) (checks.Result, error) {
+	res := stored.result
+	b, err := c.compressor.Decompress(stored.data)
+	if err != nil {
+		return res, fmt.Errorf("failed to decompress the data of the result: %w", err)
+	}
+	if typ == nil {
+		if err := json.Unmarshal(b, &res.Data); err != nil {
+			return res, fmt.Errorf("failed to decode the data of the result: %w", err)
+		}
+		return res, nil
+	}
+
+	data := reflect.New(typ)
+	if err := json.Unmarshal(b, data.Interface()); err != nil {
+		return res, fmt.Errorf("failed to decode the data of the result: %w", err)
+	}
+	res.Data = data.Elem().Interface()
+	return res, nil
+}
@@ -0,0 +1,121 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package db
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestCompressed(t *testing.T) {
+	now := time.Now().UTC()
+	large := map[string]string{"https://example.com": strings.Repeat("a", 2048), "https://example.org": "healthy"}
+
+	for _, algorithm := range Compressions() {
+		t.Run(algorithm, func(t *testing.T) {
+			c, err := NewCompressor(algorithm)
+			if err != nil {
+				t.Fatalf("NewCompressor() error = %v", err)
+			}
+			d := NewCompressed(c, 1024)
+
+			if err := d.Save(checks.ResultDTO{Name: "health", Result: &checks.Result{Data: large, Timestamp: now, SchemaVersion: 2}}); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			stored, _ := d.data.Load("health")
+			if cr := stored.(*compressedResult); cr.data == nil || len(cr.data) >= 2048 {
+				t.Errorf("Save() stored %d bytes, want the data compressed", len(cr.data))
+			}
+
+			// Small results are stored as they are
+			small := map[string]int{"https://example.com": 1}
+			if err := d.Save(checks.ResultDTO{Name: "latency", Result: &checks.Result{Data: small, Timestamp: now}}); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			want := map[string]checks.Result{
+				"health":  {Data: large, Timestamp: now, SchemaVersion: 2},
+				"latency": {Data: small, Timestamp: now},
+			}
+			if got := d.List(); !reflect.DeepEqual(got, want) {
+				t.Errorf("List() = %v, want %v", got, want)
+			}
+			if got, ok := d.Get("health"); !ok || !reflect.DeepEqual(got, want["health"]) {
+				t.Errorf("Get() = %v, %v, want %v", got, ok, want["health"])
+			}
+			if _, ok := d.Get("dns"); ok {
+				t.Error("Get() found a result of an unknown check")
+			}
+		})
+	}
+}
+
+// countingCompressor counts the decompressions of its compressor
+type countingCompressor struct {
+	Compressor
+	decompressions atomic.Int32
+}
+
+func (c *countingCompressor) Decompress(src []byte) ([]byte, error) {
+	c.decompressions.Add(1)
+	return c.Compressor.Decompress(src)
+}
+
+func TestCompressed_decodesOnce(t *testing.T) {
+	type latency struct {
+		Total float64 `json:"total"`
+	}
+	c := &countingCompressor{Compressor: snappyCompressor{}}
+	d := NewCompressed(c, 0)
+
+	data := map[string]latency{"https://example.com": {Total: 0.5}}
+	if err := d.Save(checks.ResultDTO{Name: "latency", Result: &checks.Result{Data: data}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	for range 3 {
+		got, ok := d.Get("latency")
+		if !ok || !reflect.DeepEqual(got.Data, data) {
+			t.Fatalf("Get() = %v, %v, want the data as %T", got.Data, ok, data)
+		}
+		_ = d.List()
+	}
+	if n := c.decompressions.Load(); n != 1 {
+		t.Errorf("Compressed decompressed the result %d times, want 1", n)
+	}
+
+	// A new result of the check is decoded again
+	if err := d.Save(checks.ResultDTO{Name: "latency", Result: &checks.Result{Data: data}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	_, _ = d.Get("latency")
+	if n := c.decompressions.Load(); n != 2 {
+		t.Errorf("Compressed decompressed the results %d times, want 2", n)
+	}
+}
+
+func TestNewCompressor_unknown(t *testing.T) {
+	if _, err := NewCompressor("lz4"); !errors.Is(err, ErrUnknownCompression) {
+		t.Errorf("NewCompressor() error = %v, want %v", err, ErrUnknownCompression)
+	}
+}
@@ -0,0 +1,62 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package db
+
+import (
+	"context"
+	"slices"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// Config is the configuration of the database storing the latest results of the checks
+type Config struct {
+	// Compression is the algorithm the data of the results is compressed with: zstd or snappy.
+	// The results are stored uncompressed if empty.
+	Compression string `yaml:"compression" mapstructure:"compression"`
+	// MinSize is the minimum size in bytes of the JSON encoded data of a result to be compressed.
+	// Smaller results are stored uncompressed, because compressing them isn't worth the effort.
+	MinSize int `yaml:"minSize" mapstructure:"minSize"`
+}
+
+// Validate validates the database configuration
+func (c *Config) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if c.Compression != "" && !slices.Contains(Compressions(), c.Compression) {
+		log.Error("The compression algorithm of the database is not supported", "compression", c.Compression, "supported", Compressions())
+		return ErrUnknownCompression
+	}
+	if c.MinSize < 0 {
+		log.Error("The minimum size of the compressed results should be equal or above 0", "minSize", c.MinSize)
+		return ErrInvalidMinSize
+	}
+	return nil
+}
+
+// New creates the database configured by the given configuration
+func New(cfg Config) (DB, error) {
+	if cfg.Compression == "" {
+		return NewInMemory(), nil
+	}
+	c, err := NewCompressor(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+	return NewCompressed(c, cfg.MinSize), nil
+}
@@ -24,9 +24,18 @@ import (
 	"github.com/caas-team/sparrow/pkg/checks"
 )
 
+// DB stores the latest result of every check.
+//
+// Implementations backed by persistent storage may fail to write, e.g. if the disk is full or locked.
+// Save returns the error in that case without blocking, so the checks keep running. Such a database
+// should be wrapped with NewBuffered, which keeps the failed results in memory up to a bound until the
+// backend accepts them again and reports the degradation.
 type DB interface {
-	Save(result checks.ResultDTO)
+	// Save stores the result of a check. It returns an error if the result couldn't be stored.
+	Save(result checks.ResultDTO) error
+	// Get returns the latest result of the check
 	Get(check string) (result checks.Result, ok bool)
+	// List returns the latest results of all checks mapped by their name
 	List() map[string]checks.Result
 }
 
@@ -47,8 +56,10 @@ func NewInMemory() *InMemory {
 	}
 }
 
-func (i *InMemory) Save(result checks.ResultDTO) {
+// Save stores the result of a check. It never fails.
+func (i *InMemory) Save(result checks.ResultDTO) error {
 	i.data.Store(result.Name, result.Result)
+	return nil
 }
 
 func (i *InMemory) Get(check string) (checks.Result, bool) {
@@ -76,3 +87,20 @@ func (i *InMemory) List() map[string]checks.Result {
 
 	return results
 }
+
+// Restore saves the given results mapped by check name in the database.
+// Results older than the stored result of their check and results failing to be saved are skipped.
+// It returns the number of restored results.
+func Restore(d DB, results map[string]checks.Result) int {
+	restored := 0
+	for name, res := range results {
+		if cur, ok := d.Get(name); ok && cur.Timestamp.After(res.Timestamp) {
+			continue
+		}
+		if err := d.Save(checks.ResultDTO{Name: name, Result: &res}); err != nil {
+			continue
+		}
+		restored++
+	}
+	return restored
+}
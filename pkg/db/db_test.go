@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/caas-team/sparrow/pkg/checks"
 )
@@ -188,3 +189,50 @@ func TestInMemory_ListThreadsafe(t *testing.T) {
 		t.Errorf("Expected alpha to be 0 but got %d", newGot["alpha"].Data)
 	}
 }
+
+func TestRestore(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		stored       map[string]checks.Result
+		results      map[string]checks.Result
+		want         map[string]checks.Result
+		wantRestored int
+	}{
+		{
+			name:         "empty database",
+			results:      map[string]checks.Result{"health": {Data: 1, Timestamp: now}},
+			want:         map[string]checks.Result{"health": {Data: 1, Timestamp: now}},
+			wantRestored: 1,
+		},
+		{
+			name:         "older stored result is replaced",
+			stored:       map[string]checks.Result{"health": {Data: 0, Timestamp: now.Add(-time.Minute)}},
+			results:      map[string]checks.Result{"health": {Data: 1, Timestamp: now}},
+			want:         map[string]checks.Result{"health": {Data: 1, Timestamp: now}},
+			wantRestored: 1,
+		},
+		{
+			name:         "newer stored result is kept",
+			stored:       map[string]checks.Result{"health": {Data: 0, Timestamp: now}},
+			results:      map[string]checks.Result{"health": {Data: 1, Timestamp: now.Add(-time.Minute)}, "latency": {Data: 2, Timestamp: now}},
+			want:         map[string]checks.Result{"health": {Data: 0, Timestamp: now}, "latency": {Data: 2, Timestamp: now}},
+			wantRestored: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewInMemory()
+			for name, res := range tt.stored {
+				d.Save(checks.ResultDTO{Name: name, Result: &res})
+			}
+
+			if got := Restore(d, tt.results); got != tt.wantRestored {
+				t.Errorf("Restore() = %d, want %d", got, tt.wantRestored)
+			}
+			if got := d.List(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
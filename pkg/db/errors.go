@@ -0,0 +1,27 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package db
+
+import "errors"
+
+var (
+	// ErrUnknownCompression is returned when the configured compression algorithm isn't supported
+	ErrUnknownCompression = errors.New("unknown compression algorithm")
+	// ErrInvalidMinSize is returned when the minimum size of the compressed results is negative
+	ErrInvalidMinSize = errors.New("invalid minimum size of compressed results")
+)
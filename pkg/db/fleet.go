@@ -0,0 +1,85 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package db
+
+import (
+	"sync"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// Fleet stores the check results of multiple sparrow instances
+type Fleet interface {
+	// Save stores the result for the given instance
+	Save(instance string, result checks.ResultDTO)
+	// Get returns the database of the given instance
+	Get(instance string) (db DB, ok bool)
+	// List returns the databases of all instances mapped by their name
+	List() map[string]DB
+}
+
+var _ Fleet = (*InMemoryFleet)(nil)
+
+// InMemoryFleet is a fleet database storing the results of every instance in an in-memory database
+type InMemoryFleet struct {
+	mu        sync.RWMutex
+	instances map[string]*InMemory
+}
+
+// NewInMemoryFleet creates a new in-memory fleet database
+func NewInMemoryFleet() *InMemoryFleet {
+	return &InMemoryFleet{
+		instances: map[string]*InMemory{},
+	}
+}
+
+func (f *InMemoryFleet) Save(instance string, result checks.ResultDTO) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	idb, ok := f.instances[instance]
+	if !ok {
+		idb = NewInMemory()
+		f.instances[instance] = idb
+	}
+	_ = idb.Save(result) // The in-memory database never fails
+}
+
+func (f *InMemoryFleet) Get(instance string) (DB, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	idb, ok := f.instances[instance]
+	if !ok {
+		return nil, false
+	}
+	return idb, true
+}
+
+// List returns a copy of the map of instances
+func (f *InMemoryFleet) List() map[string]DB {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	instances := make(map[string]DB, len(f.instances))
+	for name, idb := range f.instances {
+		instances[name] = idb
+	}
+	return instances
+}
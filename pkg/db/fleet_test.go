@@ -0,0 +1,84 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package db
+
+import (
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestInMemoryFleet_Save(t *testing.T) {
+	tests := []struct {
+		name      string
+		saves     map[string][]checks.ResultDTO
+		wantCount map[string]int
+	}{
+		{
+			name: "Saves per instance",
+			saves: map[string][]checks.ResultDTO{
+				"sparrow-a.com": {
+					{Name: "health", Result: &checks.Result{Data: 0}},
+					{Name: "latency", Result: &checks.Result{Data: 1}},
+				},
+				"sparrow-b.com": {
+					{Name: "health", Result: &checks.Result{Data: 2}},
+				},
+			},
+			wantCount: map[string]int{"sparrow-a.com": 2, "sparrow-b.com": 1},
+		},
+		{
+			name: "Overwrites the result of a check",
+			saves: map[string][]checks.ResultDTO{
+				"sparrow-a.com": {
+					{Name: "health", Result: &checks.Result{Data: 0}},
+					{Name: "health", Result: &checks.Result{Data: 1}},
+				},
+			},
+			wantCount: map[string]int{"sparrow-a.com": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewInMemoryFleet()
+			for instance, results := range tt.saves {
+				for _, r := range results {
+					f.Save(instance, r)
+				}
+			}
+
+			if got := len(f.List()); got != len(tt.wantCount) {
+				t.Fatalf("Expected %d instances but got: %d", len(tt.wantCount), got)
+			}
+			for instance, count := range tt.wantCount {
+				idb, ok := f.Get(instance)
+				if !ok {
+					t.Fatalf("Expected to find instance %s", instance)
+				}
+				if got := len(idb.List()); got != count {
+					t.Errorf("Expected %d results for instance %s but got: %d", count, instance, got)
+				}
+			}
+
+			if _, ok := f.Get("unknown"); ok {
+				t.Error("Expected not to find an unknown instance")
+			}
+		})
+	}
+}
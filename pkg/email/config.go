@@ -0,0 +1,207 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"text/template"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/notifier"
+	"github.com/caas-team/sparrow/pkg/webhook"
+)
+
+var (
+	// ErrMissingHost is returned when a receiver has no smtp host
+	ErrMissingHost = errors.New("missing email receiver smtp host")
+	// ErrInvalidPort is returned when the smtp port of a receiver is invalid
+	ErrInvalidPort = errors.New("invalid email receiver smtp port")
+	// ErrInvalidTLS is returned when the tls mode of a receiver is unknown
+	ErrInvalidTLS = errors.New("invalid email receiver tls mode")
+	// ErrInvalidAddress is returned when the sender or a recipient of a receiver is invalid
+	ErrInvalidAddress = errors.New("invalid email receiver address")
+	// ErrInvalidTemplate is returned when the subject or body template of a receiver is invalid
+	ErrInvalidTemplate = errors.New("invalid email receiver template")
+	// ErrInvalidTimeout is returned when the timeout of a receiver is invalid
+	ErrInvalidTimeout = errors.New("invalid email receiver timeout")
+	// ErrInvalidMaxPerHour is returned when the throttling of a receiver is invalid
+	ErrInvalidMaxPerHour = errors.New("invalid email receiver max mails per hour")
+)
+
+const (
+	// TLSStartTLS upgrades the connection to the smtp server with STARTTLS
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects to the smtp server with TLS, usually on port 465
+	TLSImplicit = "tls"
+	// TLSNone sends the mails unencrypted
+	TLSNone = "none"
+)
+
+const (
+	// defaultSubject is the subject template used if none is configured
+	defaultSubject = `[sparrow] {{ .Check }} result of {{ .Instance }}`
+	// defaultBody is the body template used if none is configured
+	defaultBody = `{{ json . }}`
+)
+
+// Config is the configuration of the email receivers the results are sent to
+type Config struct {
+	// Receivers are the email receivers every result is sent to
+	Receivers []Receiver `yaml:"receivers" mapstructure:"receivers"`
+}
+
+// Receiver sends the results of the checks as mails through a smtp server
+type Receiver struct {
+	// Name is the unique name of the receiver
+	Name string `yaml:"name" mapstructure:"name"`
+	// Host is the host of the smtp server
+	Host string `yaml:"host" mapstructure:"host"`
+	// Port is the port of the smtp server
+	Port int `yaml:"port" mapstructure:"port"`
+	// TLS is the encryption of the connection: starttls, tls or none. Defaults to starttls.
+	TLS string `yaml:"tls" mapstructure:"tls"`
+	// Username is the username to authenticate with. No authentication is used if empty.
+	Username string `yaml:"username" mapstructure:"username"`
+	// Password is the password to authenticate with
+	Password string `yaml:"password" mapstructure:"password"`
+	// From is the sender address of the mails
+	From string `yaml:"from" mapstructure:"from"`
+	// To are the recipient addresses of the mails
+	To []string `yaml:"to" mapstructure:"to"`
+	// Checks limits the results sent to the receiver to the given checks.
+	// The results of all checks are sent if empty.
+	Checks []string `yaml:"checks" mapstructure:"checks"`
+	// Subject is the Go template rendering the subject of a mail
+	Subject string `yaml:"subject" mapstructure:"subject"`
+	// Template is the Go template rendering the body of a mail.
+	// Defaults to the result as JSON.
+	Template string `yaml:"template" mapstructure:"template"`
+	// MaxPerHour is the maximum amount of mails sent by the receiver within an hour.
+	// Further results are dropped. A value of 0 means no limit.
+	MaxPerHour int `yaml:"maxPerHour" mapstructure:"maxPerHour"`
+	// Timeout is the timeout of sending a single mail
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// Enabled returns true if any receiver is configured
+func (c *Config) Enabled() bool {
+	return len(c.Receivers) > 0
+}
+
+// Validate validates the email configuration
+func (c *Config) Validate(ctx context.Context) error {
+	names := make([]string, 0, len(c.Receivers))
+	for _, r := range c.Receivers {
+		names = append(names, r.Name)
+	}
+	if err := notifier.ValidateNames(ctx, "email", names); err != nil {
+		return err
+	}
+
+	for i := range c.Receivers {
+		if err := c.Receivers[i].Validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the receiver configuration
+func (r *Receiver) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx).With("name", r.Name)
+
+	if r.Host == "" {
+		log.Error("The email receiver smtp host cannot be empty")
+		return ErrMissingHost
+	}
+	if r.Port <= 0 || r.Port > 65535 {
+		log.Error("The email receiver smtp port is not a valid port", "port", r.Port)
+		return ErrInvalidPort
+	}
+	switch r.TLS {
+	case "", TLSStartTLS, TLSImplicit, TLSNone:
+	default:
+		log.Error("The email receiver tls mode is unknown", "tls", r.TLS)
+		return ErrInvalidTLS
+	}
+	if _, err := mail.ParseAddress(r.From); err != nil {
+		log.Error("The email receiver sender is not a valid address", "from", r.From)
+		return ErrInvalidAddress
+	}
+	if len(r.To) == 0 {
+		log.Error("The email receiver needs at least one recipient")
+		return ErrInvalidAddress
+	}
+	for _, to := range r.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			log.Error("The email receiver recipient is not a valid address", "to", to)
+			return ErrInvalidAddress
+		}
+	}
+	if r.MaxPerHour < 0 {
+		log.Error("The email receiver max mails per hour should be equal or above 0", "maxPerHour", r.MaxPerHour)
+		return ErrInvalidMaxPerHour
+	}
+	if r.Timeout < 0 {
+		log.Error("The email receiver timeout should be equal or above 0", "timeout", r.Timeout)
+		return ErrInvalidTimeout
+	}
+
+	subject, body, err := r.templates()
+	if err != nil {
+		log.Error("The email receiver template cannot be parsed", "error", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	// The templates are rendered once with an empty payload to detect
+	// references to fields the payload doesn't have
+	for _, tmpl := range []*template.Template{subject, body} {
+		if err = tmpl.Execute(io.Discard, webhook.Payload{Data: map[string]any{}}); err != nil {
+			log.Error("The email receiver template cannot be rendered", "error", err)
+			return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+		}
+	}
+	return nil
+}
+
+// templates parses the subject and body templates of the receiver
+func (r *Receiver) templates() (subject, body *template.Template, err error) {
+	text := r.Subject
+	if text == "" {
+		text = defaultSubject
+	}
+	subject, err = template.New(r.Name + "-subject").Funcs(webhook.Funcs).Parse(text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	text = r.Template
+	if text == "" {
+		text = defaultBody
+	}
+	body, err = template.New(r.Name).Funcs(webhook.Funcs).Parse(text)
+	if err != nil {
+		return nil, nil, err
+	}
+	return subject, body, nil
+}
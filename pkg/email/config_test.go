@@ -0,0 +1,136 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func(mod func(r *Receiver)) Config {
+		r := Receiver{
+			Name: "oncall",
+			Host: "smtp.example.com",
+			Port: 587,
+			From: "sparrow@example.com",
+			To:   []string{"oncall@example.com"},
+		}
+		mod(&r)
+		return Config{Receivers: []Receiver{r}}
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name:    "no receivers",
+			config:  Config{},
+			wantErr: nil,
+		},
+		{
+			name: "valid receiver",
+			config: valid(func(r *Receiver) {
+				r.TLS = TLSImplicit
+				r.Subject = `{{ .Check }} failed`
+				r.Template = `{{ range $t, $s := .Data }}{{ $t }}: {{ $s }}{{ end }}`
+				r.MaxPerHour = 10
+			}),
+			wantErr: nil,
+		},
+		{
+			name:    "missing name",
+			config:  valid(func(r *Receiver) { r.Name = "" }),
+			wantErr: notifier.ErrMissingName,
+		},
+		{
+			name: "duplicate name",
+			config: Config{Receivers: []Receiver{
+				valid(func(*Receiver) {}).Receivers[0],
+				valid(func(*Receiver) {}).Receivers[0],
+			}},
+			wantErr: notifier.ErrDuplicateName,
+		},
+		{
+			name:    "missing host",
+			config:  valid(func(r *Receiver) { r.Host = "" }),
+			wantErr: ErrMissingHost,
+		},
+		{
+			name:    "invalid port",
+			config:  valid(func(r *Receiver) { r.Port = 0 }),
+			wantErr: ErrInvalidPort,
+		},
+		{
+			name:    "unknown tls mode",
+			config:  valid(func(r *Receiver) { r.TLS = "ssl" }),
+			wantErr: ErrInvalidTLS,
+		},
+		{
+			name:    "invalid sender",
+			config:  valid(func(r *Receiver) { r.From = "sparrow" }),
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name:    "missing recipients",
+			config:  valid(func(r *Receiver) { r.To = nil }),
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name:    "invalid recipient",
+			config:  valid(func(r *Receiver) { r.To = []string{"oncall"} }),
+			wantErr: ErrInvalidAddress,
+		},
+		{
+			name:    "negative max per hour",
+			config:  valid(func(r *Receiver) { r.MaxPerHour = -1 }),
+			wantErr: ErrInvalidMaxPerHour,
+		},
+		{
+			name:    "negative timeout",
+			config:  valid(func(r *Receiver) { r.Timeout = -time.Second }),
+			wantErr: ErrInvalidTimeout,
+		},
+		{
+			name:    "invalid subject template",
+			config:  valid(func(r *Receiver) { r.Subject = `{{ .Target }}` }),
+			wantErr: ErrInvalidTemplate,
+		},
+		{
+			name:    "invalid body template",
+			config:  valid(func(r *Receiver) { r.Template = `{{ .Check ` }),
+			wantErr: ErrInvalidTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/notifier"
+	"github.com/caas-team/sparrow/pkg/webhook"
+)
+
+// throttleWindow is the window the mails per receiver are limited in
+const throttleWindow = time.Hour
+
+// receiver is a configured email receiver with its parsed templates
+type receiver struct {
+	Receiver
+	subject *template.Template
+	body    *template.Template
+	// mu guards sent
+	mu sync.Mutex
+	// sent are the times of the mails sent within the throttle window
+	sent []time.Time
+}
+
+// sendFunc delivers a mail to the smtp server of the receiver
+type sendFunc func(ctx context.Context, r *receiver, msg []byte) error
+
+// Notifier sends the results of the checks as mails to the configured receivers
+type Notifier struct {
+	*notifier.Queue
+	receivers []*receiver
+	deliver   sendFunc
+	now       func() time.Time
+}
+
+// NewNotifier creates a new Notifier for the given configuration.
+// It returns an error if a template of a receiver is invalid.
+func NewNotifier(cfg Config) (*Notifier, error) {
+	n := &Notifier{
+		deliver: deliver,
+		now:     time.Now,
+	}
+	for _, r := range cfg.Receivers {
+		subject, body, err := r.templates()
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidTemplate, r.Name, err)
+		}
+		n.receivers = append(n.receivers, &receiver{
+			Receiver: r,
+			subject:  subject,
+			body:     body,
+		})
+	}
+	n.Queue = notifier.NewQueue(n.send)
+	return n, nil
+}
+
+// send mails the result to the receivers accepting the results of its check
+// unless they exceeded their max mails per hour
+func (n *Notifier) send(ctx context.Context, result checks.ResultDTO) {
+	log := logger.FromContext(ctx)
+	for _, r := range n.receivers {
+		if !notifier.Accepts(r.Checks, result.Name) {
+			continue
+		}
+		if !r.allow(n.now()) {
+			log.DebugContext(ctx, "Email receiver exceeded its max mails per hour, dropping result", "receiver", r.Name, "check", result.Name)
+			continue
+		}
+		if err := n.notify(ctx, r, result); err != nil {
+			log.WarnContext(ctx, "Failed to send result to email receiver", "receiver", r.Name, "check", result.Name, "error", err)
+		}
+	}
+}
+
+// allow returns true and counts the mail if the receiver didn't exceed
+// its max mails within the throttle window before the given time
+func (r *receiver) allow(now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sent = slices.DeleteFunc(r.sent, func(t time.Time) bool {
+		return now.Sub(t) >= throttleWindow
+	})
+	if r.MaxPerHour > 0 && len(r.sent) >= r.MaxPerHour {
+		return false
+	}
+	r.sent = append(r.sent, now)
+	return true
+}
+
+// notify renders the mail of the result and sends it to the receiver
+func (n *Notifier) notify(ctx context.Context, r *receiver, result checks.ResultDTO) error {
+	msg, err := r.message(webhook.NewPayload(result), n.now())
+	if err != nil {
+		return err
+	}
+	return n.deliver(ctx, r, msg)
+}
+
+// message renders the mail of the given payload including its headers
+func (r *receiver) message(p webhook.Payload, date time.Time) ([]byte, error) {
+	var subject, body bytes.Buffer
+	if err := r.subject.Execute(&subject, p); err != nil {
+		return nil, fmt.Errorf("failed to render subject: %w", err)
+	}
+	if err := r.body.Execute(&body, p); err != nil {
+		return nil, fmt.Errorf("failed to render body: %w", err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", r.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(r.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject.String())))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(body.String(), "\r\n", "\n"), "\n", "\r\n"))
+	return msg.Bytes(), nil
+}
+
+// deliver sends the mail through the smtp server of the receiver
+func deliver(ctx context.Context, r *receiver, msg []byte) (err error) {
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	addr := net.JoinHostPort(r.Host, strconv.Itoa(r.Port))
+	tlsCfg := &tls.Config{ServerName: r.Host, MinVersion: tls.VersionTLS12}
+	var conn net.Conn
+	if r.TLS == TLSImplicit {
+		conn, err = (&tls.Dialer{Config: tlsCfg}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, r.Host)
+	if err != nil {
+		return errors.Join(err, conn.Close())
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	if r.TLS == "" || r.TLS == TLSStartTLS {
+		if err = c.StartTLS(tlsCfg); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if r.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", r.Username, r.Password, r.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+	if err = c.Mail(r.From); err != nil {
+		return err
+	}
+	for _, to := range r.To {
+		if err = c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
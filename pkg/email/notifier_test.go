@@ -0,0 +1,309 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package email
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/webhook"
+)
+
+func TestNotifier_Run(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		receiver   Receiver
+		results    []checks.ResultDTO
+		elapsed    time.Duration
+		wantChecks []string
+	}{
+		{
+			name:     "all checks",
+			receiver: Receiver{Name: "oncall"},
+			results: []checks.ResultDTO{
+				{Name: "health", Result: &checks.Result{}},
+				{Name: "dns", Result: &checks.Result{}},
+			},
+			wantChecks: []string{"health", "dns"},
+		},
+		{
+			name:     "filtered checks",
+			receiver: Receiver{Name: "oncall", Checks: []string{"dns"}},
+			results: []checks.ResultDTO{
+				{Name: "health", Result: &checks.Result{}},
+				{Name: "dns", Result: &checks.Result{}},
+			},
+			wantChecks: []string{"dns"},
+		},
+		{
+			name:     "throttled",
+			receiver: Receiver{Name: "oncall", MaxPerHour: 2},
+			results: []checks.ResultDTO{
+				{Name: "health", Result: &checks.Result{}},
+				{Name: "dns", Result: &checks.Result{}},
+				{Name: "latency", Result: &checks.Result{}},
+			},
+			wantChecks: []string{"health", "dns"},
+		},
+		{
+			name:     "throttle window elapsed",
+			receiver: Receiver{Name: "oncall", MaxPerHour: 2},
+			results: []checks.ResultDTO{
+				{Name: "health", Result: &checks.Result{}},
+				{Name: "dns", Result: &checks.Result{}},
+				{Name: "latency", Result: &checks.Result{}},
+			},
+			elapsed:    time.Hour,
+			wantChecks: []string{"health", "dns", "latency"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.receiver.From = "sparrow@example.com"
+			tt.receiver.To = []string{"oncall@example.com"}
+			tt.receiver.Subject = "{{ .Check }}"
+
+			var mu sync.Mutex
+			var got []string
+			cSent := make(chan struct{}, len(tt.results))
+			n, err := NewNotifier(Config{Receivers: []Receiver{tt.receiver}})
+			if err != nil {
+				t.Fatalf("NewNotifier() error = %v", err)
+			}
+			n.now = func() time.Time {
+				mu.Lock()
+				defer mu.Unlock()
+				// Every mail advances the clock by the elapsed time
+				return now.Add(time.Duration(len(got)) * tt.elapsed)
+			}
+			n.deliver = func(_ context.Context, _ *receiver, msg []byte) error {
+				mu.Lock()
+				defer mu.Unlock()
+				for _, line := range strings.Split(string(msg), "\r\n") {
+					if s, ok := strings.CutPrefix(line, "Subject: "); ok {
+						got = append(got, s)
+					}
+				}
+				cSent <- struct{}{}
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				_ = n.Run(ctx)
+			}()
+			for _, r := range tt.results {
+				n.Submit(r)
+			}
+
+			for range tt.wantChecks {
+				select {
+				case <-cSent:
+				case <-time.After(time.Second):
+					t.Fatal("Notifier didn't send the expected mails")
+				}
+			}
+			// Wait for unexpected mails
+			select {
+			case <-cSent:
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if strings.Join(got, ",") != strings.Join(tt.wantChecks, ",") {
+				t.Errorf("Notifier sent mails for %v, want %v", got, tt.wantChecks)
+			}
+		})
+	}
+}
+
+func TestReceiver_message(t *testing.T) {
+	n, err := NewNotifier(Config{Receivers: []Receiver{{
+		Name:     "oncall",
+		From:     "sparrow@example.com",
+		To:       []string{"a@example.com", "b@example.com"},
+		Template: "{{ range $t, $s := .Data }}{{ $t }} is {{ $s }}\n{{ end }}",
+	}}})
+	if err != nil {
+		t.Fatalf("NewNotifier() error = %v", err)
+	}
+	r := n.receivers[0]
+
+	date := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := checks.ResultDTO{Name: "health", Result: &checks.Result{
+		Data:     map[string]string{"https://example.com": "unhealthy"},
+		Instance: "sparrow.com",
+	}}
+	msg, err := r.message(webhook.NewPayload(result), date)
+	if err != nil {
+		t.Fatalf("receiver.message() error = %v", err)
+	}
+
+	want := "From: sparrow@example.com\r\n" +
+		"To: a@example.com, b@example.com\r\n" +
+		"Subject: [sparrow] health result of sparrow.com\r\n" +
+		"Date: Mon, 01 Jan 2024 12:00:00 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" +
+		"https://example.com is unhealthy\r\n"
+	if string(msg) != want {
+		t.Errorf("receiver.message() = %q, want %q", msg, want)
+	}
+}
+
+func Test_deliver(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+
+	n, err := NewNotifier(Config{Receivers: []Receiver{{
+		Name:     "oncall",
+		Host:     "127.0.0.1",
+		Port:     srv.port,
+		TLS:      TLSNone,
+		Username: "user",
+		Password: "pass",
+		From:     "sparrow@example.com",
+		To:       []string{"oncall@example.com"},
+		Timeout:  time.Second,
+	}}})
+	if err != nil {
+		t.Fatalf("NewNotifier() error = %v", err)
+	}
+
+	err = deliver(context.Background(), n.receivers[0], []byte("Subject: test\r\n\r\nbody\r\n"))
+	if err != nil {
+		t.Fatalf("deliver() error = %v", err)
+	}
+
+	got := srv.commands()
+	for _, want := range []string{"AUTH PLAIN", "MAIL FROM:<sparrow@example.com>", "RCPT TO:<oncall@example.com>", "DATA", "QUIT"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("smtp server received %q, want it to contain %q", got, want)
+		}
+	}
+	if !strings.Contains(srv.data(), "body") {
+		t.Errorf("smtp server received data %q, want it to contain the body", srv.data())
+	}
+}
+
+// fakeSMTPServer is a minimal smtp server accepting a single mail
+type fakeSMTPServer struct {
+	port int
+	mu   sync.Mutex
+	cmds []string
+	msg  strings.Builder
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	s := &fakeSMTPServer{}
+	s.port, _ = strconv.Atoi(port)
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+		reply := func(lines ...string) {
+			for _, l := range lines {
+				_, _ = rw.WriteString(l + "\r\n")
+			}
+			_ = rw.Flush()
+		}
+
+		reply("220 localhost ESMTP")
+		inData := false
+		for {
+			line, err := rw.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			s.mu.Lock()
+			if inData {
+				if line == "." {
+					inData = false
+					s.mu.Unlock()
+					reply("250 OK")
+					continue
+				}
+				s.msg.WriteString(line + "\n")
+				s.mu.Unlock()
+				continue
+			}
+			s.cmds = append(s.cmds, line)
+			s.mu.Unlock()
+
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250-localhost", "250 AUTH PLAIN")
+			case strings.HasPrefix(line, "AUTH"):
+				reply("235 Authenticated")
+			case line == "DATA":
+				inData = true
+				reply("354 Go ahead")
+			case line == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) commands() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.Join(s.cmds, "\n")
+}
+
+func (s *fakeSMTPServer) data() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.msg.String()
+}
+
+func TestNewNotifier_invalidTemplate(t *testing.T) {
+	_, err := NewNotifier(Config{Receivers: []Receiver{{Name: "oncall", Subject: "{{ .Check "}}})
+	if !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("NewNotifier() error = %v, want %v", err, ErrInvalidTemplate)
+	}
+}
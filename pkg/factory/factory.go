@@ -20,17 +20,22 @@ package factory
 
 import (
 	"errors"
+	"slices"
 
 	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/checks/dns"
 	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/httpheaders"
 	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/ntp"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
 	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/caas-team/sparrow/pkg/checks/zone"
 )
 
-// newCheck creates a new check instance from the given name
-func newCheck(cfg checks.Runtime) (checks.Check, error) {
+// NewCheck creates a new check instance from the given runtime configuration
+func NewCheck(cfg checks.Runtime) (checks.Check, error) {
 	if cfg == nil {
 		return nil, errors.New("config is nil")
 	}
@@ -51,7 +56,7 @@ func NewChecksFromConfig(cfg runtime.Config) (map[string]checks.Check, error) {
 
 	result := make(map[string]checks.Check)
 	for _, c := range cfg.Iter() {
-		check, err := newCheck(c)
+		check, err := NewCheck(c)
 		if err != nil {
 			return nil, err
 		}
@@ -60,10 +65,29 @@ func NewChecksFromConfig(cfg runtime.Config) (map[string]checks.Check, error) {
 	return result, nil
 }
 
+// NewChecks creates an unconfigured instance of every known check, ordered by name
+func NewChecks() []checks.Check {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	result := make([]checks.Check, 0, len(names))
+	for _, name := range names {
+		result = append(result, registry[name]())
+	}
+	return result
+}
+
 // registry is a convenience map to create new checks
 var registry = map[string]func() checks.Check{
-	health.CheckName:     health.NewCheck,
-	latency.CheckName:    latency.NewCheck,
-	dns.CheckName:        dns.NewCheck,
-	traceroute.CheckName: traceroute.NewCheck,
+	health.CheckName:      health.NewCheck,
+	latency.CheckName:     latency.NewCheck,
+	dns.CheckName:         dns.NewCheck,
+	traceroute.CheckName:  traceroute.NewCheck,
+	pmtu.CheckName:        pmtu.NewCheck,
+	ntp.CheckName:         ntp.NewCheck,
+	zone.CheckName:        zone.NewCheck,
+	httpheaders.CheckName: httpheaders.NewCheck,
 }
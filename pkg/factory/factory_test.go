@@ -32,12 +32,12 @@ import (
 var (
 	latencyCfg = &latency.Config{
 		Targets:  []string{"http://localhost:8080/health"},
-		Interval: 1 * time.Second,
+		Interval: 10 * time.Second,
 		Timeout:  1 * time.Second,
 	}
 	healthCfg = &health.Config{
 		Targets:  []string{"http://localhost:8080/health"},
-		Interval: 1 * time.Second,
+		Interval: 10 * time.Second,
 		Timeout:  1 * time.Second,
 	}
 )
@@ -148,9 +148,9 @@ func TestNewCheck(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := newCheck(tt.cfg)
+			got, err := NewCheck(tt.cfg)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("newCheck() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("NewCheck() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
@@ -168,3 +168,18 @@ func TestNewCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestNewChecks(t *testing.T) {
+	got := NewChecks()
+	if len(got) != len(registry) {
+		t.Fatalf("NewChecks() returned %d checks, want %d", len(got), len(registry))
+	}
+	for i, c := range got {
+		if _, ok := registry[c.Name()]; !ok {
+			t.Errorf("NewChecks() returned unknown check %q", c.Name())
+		}
+		if i > 0 && got[i-1].Name() >= c.Name() {
+			t.Errorf("NewChecks() isn't ordered by name: %q before %q", got[i-1].Name(), c.Name())
+		}
+	}
+}
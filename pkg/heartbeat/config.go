@@ -0,0 +1,104 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package heartbeat
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+const (
+	// PolicySkip skips the heartbeat while the sparrow is unhealthy,
+	// so the dead man's switch alerts once its grace period elapsed
+	PolicySkip = "skip"
+	// PolicyFail reports the failure to the fail url instead of the heartbeat,
+	// so the dead man's switch alerts immediately, e.g. the /fail endpoint of healthchecks.io
+	PolicyFail = "fail"
+)
+
+// defaultMaxResultAge is the maximum age of the latest check result if none is configured
+const defaultMaxResultAge = 10 * time.Minute
+
+// Config is the configuration for sending heartbeats to a dead man's switch
+type Config struct {
+	// Enabled is a flag to enable or disable the heartbeats
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Url is the URL the heartbeats are sent to
+	Url string `yaml:"url" mapstructure:"url"`
+	// Interval is the interval in which the heartbeats are sent
+	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
+	// Timeout is the timeout of a single heartbeat
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+	// Retry is the configuration of the retries of a failed heartbeat
+	Retry helper.RetryConfig `yaml:"retry" mapstructure:"retry"`
+	// MaxResultAge is the maximum age of the latest check result for the sparrow to be healthy. Defaults to 10m.
+	MaxResultAge time.Duration `yaml:"maxResultAge" mapstructure:"maxResultAge"`
+	// OnFailure is the policy while the sparrow is unhealthy: skip or fail. Defaults to skip.
+	OnFailure string `yaml:"onFailure" mapstructure:"onFailure"`
+	// FailUrl is the URL the failure is reported to with the fail policy
+	FailUrl string `yaml:"failUrl" mapstructure:"failUrl"`
+}
+
+// Validate validates the heartbeat configuration
+func (c *Config) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if !validUrl(c.Url) {
+		log.Error("The heartbeat url is not a valid url", "url", c.Url)
+		return ErrInvalidUrl
+	}
+	if c.Interval <= 0 {
+		log.Error("The heartbeat interval should be above 0", "interval", c.Interval)
+		return ErrInvalidInterval
+	}
+	if c.MaxResultAge < 0 {
+		log.Error("The heartbeat max result age should be equal or above 0", "maxResultAge", c.MaxResultAge)
+		return ErrInvalidMaxResultAge
+	}
+	switch c.OnFailure {
+	case "", PolicySkip:
+	case PolicyFail:
+		if !validUrl(c.FailUrl) {
+			log.Error("The heartbeat fail url is not a valid url", "failUrl", c.FailUrl)
+			return ErrInvalidFailUrl
+		}
+	default:
+		log.Error("The heartbeat failure policy is unknown", "onFailure", c.OnFailure)
+		return ErrInvalidPolicy
+	}
+	return nil
+}
+
+// maxResultAge returns the maximum age of the latest check result for the sparrow to be healthy
+func (c *Config) maxResultAge() time.Duration {
+	if c.MaxResultAge == 0 {
+		return defaultMaxResultAge
+	}
+	return c.MaxResultAge
+}
+
+// validUrl returns true if the url is an absolute http(s) url
+func validUrl(raw string) bool {
+	u, err := url.ParseRequestURI(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
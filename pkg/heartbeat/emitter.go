@@ -0,0 +1,139 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// SelfCheck returns an error if the sparrow is unhealthy.
+// The given maximum age of the latest check result is exceeded if the checks stopped producing results.
+type SelfCheck func(ctx context.Context, maxResultAge time.Duration) error
+
+// Emitter sends heartbeats to a dead man's switch in the configured interval as long as the sparrow is healthy.
+// The dead man's switch alerts if the heartbeats stop, e.g. because the sparrow died.
+type Emitter struct {
+	cfg    Config
+	check  SelfCheck
+	client *http.Client
+	done   chan struct{}
+}
+
+// NewEmitter creates a new Emitter sending heartbeats while the self check passes
+func NewEmitter(cfg Config, check SelfCheck) *Emitter { //nolint:gocritic // no performance concerns yet
+	return &Emitter{
+		cfg:    cfg,
+		check:  check,
+		client: &http.Client{Timeout: cfg.Timeout},
+		done:   make(chan struct{}, 1),
+	}
+}
+
+// Run sends a heartbeat in the configured interval until the context is canceled or the Emitter is shut down.
+// The first heartbeat is sent after the first interval, so the checks had the chance to produce results.
+func (e *Emitter) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.beat(ctx); err != nil {
+				log.WarnContext(ctx, "Failed to send heartbeat, retrying in the next interval", "error", err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-e.done:
+			return nil
+		}
+	}
+}
+
+// Shutdown stops the Emitter
+func (e *Emitter) Shutdown(_ context.Context) {
+	select {
+	case e.done <- struct{}{}:
+	default:
+	}
+}
+
+// beat sends a heartbeat if the sparrow is healthy.
+// Otherwise the heartbeat is skipped or the failure is reported, depending on the failure policy.
+func (e *Emitter) beat(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if err := e.check(ctx, e.cfg.maxResultAge()); err != nil {
+		if e.cfg.OnFailure != PolicyFail {
+			log.WarnContext(ctx, "Sparrow is unhealthy, skipping heartbeat", "error", err)
+			return nil
+		}
+		log.WarnContext(ctx, "Sparrow is unhealthy, reporting failure", "error", err)
+		return e.send(ctx, e.cfg.FailUrl, err.Error())
+	}
+
+	if err := e.send(ctx, e.cfg.Url, ""); err != nil {
+		return err
+	}
+	log.DebugContext(ctx, "Sent heartbeat")
+	return nil
+}
+
+// send posts the message to the url, retrying according to the retry configuration
+func (e *Emitter) send(ctx context.Context, url, msg string) error {
+	return helper.Retry(func(ctx context.Context) error {
+		return e.post(ctx, url, msg)
+	}, e.cfg.Retry)(ctx)
+}
+
+// post posts the message to the url. Client errors other than timeouts and rate limits aren't retried.
+func (e *Emitter) post(ctx context.Context, url, msg string) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(msg))
+	if err != nil {
+		return helper.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		err = errors.Join(err, resp.Body.Close())
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		err = fmt.Errorf("request failed, status is %s", resp.Status)
+		if !helper.RetryableStatus(resp.StatusCode) {
+			return helper.Permanent(err)
+		}
+		return err
+	}
+	return nil
+}
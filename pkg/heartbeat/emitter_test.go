@@ -0,0 +1,135 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+)
+
+func TestEmitter_beat(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    string
+		healthy   bool
+		status    int
+		want      []string
+		wantError bool
+	}{
+		{name: "healthy", healthy: true, status: http.StatusOK, want: []string{"/ping:"}},
+		{name: "unhealthy with skip policy", policy: PolicySkip, status: http.StatusOK, want: nil},
+		{name: "unhealthy with fail policy", policy: PolicyFail, status: http.StatusOK, want: []string{"/ping/fail:no results"}},
+		{
+			name:      "server error is retried",
+			healthy:   true,
+			status:    http.StatusInternalServerError,
+			want:      []string{"/ping:", "/ping:", "/ping:"},
+			wantError: true,
+		},
+		{name: "client error isn't retried", healthy: true, status: http.StatusNotFound, want: []string{"/ping:"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var got []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, r.URL.Path+":"+string(body))
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			var maxAge time.Duration
+			e := NewEmitter(Config{
+				Url:       srv.URL + "/ping",
+				FailUrl:   srv.URL + "/ping/fail",
+				OnFailure: tt.policy,
+				Interval:  time.Minute,
+				Timeout:   time.Second,
+				Retry:     helper.RetryConfig{Count: 2, Delay: time.Millisecond},
+			}, func(_ context.Context, maxResultAge time.Duration) error {
+				maxAge = maxResultAge
+				if tt.healthy {
+					return nil
+				}
+				return errors.New("no results")
+			})
+
+			err := e.beat(context.Background())
+			if (err != nil) != tt.wantError {
+				t.Errorf("beat() error = %v, wantError %v", err, tt.wantError)
+			}
+			if maxAge != defaultMaxResultAge {
+				t.Errorf("beat() checked max result age %v, want %v", maxAge, defaultMaxResultAge)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(got) != len(tt.want) {
+				t.Fatalf("beat() sent %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("beat() sent %q, want %q", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmitter_Run(t *testing.T) {
+	cBeat := make(chan struct{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		cBeat <- struct{}{}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	e := NewEmitter(Config{Url: srv.URL, Interval: 10 * time.Millisecond, Timeout: time.Second}, func(context.Context, time.Duration) error {
+		return nil
+	})
+
+	cErr := make(chan error, 1)
+	go func() {
+		cErr <- e.Run(context.Background())
+	}()
+	for range 2 {
+		select {
+		case <-cBeat:
+		case <-time.After(time.Second):
+			t.Fatal("Emitter didn't send the expected heartbeats")
+		}
+	}
+
+	e.Shutdown(context.Background())
+	if err := <-cErr; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package heartbeat
+
+import "errors"
+
+var (
+	// ErrInvalidUrl is returned when the heartbeat url is invalid
+	ErrInvalidUrl = errors.New("invalid heartbeat url")
+	// ErrInvalidFailUrl is returned when the heartbeat fail url is invalid or missing for the fail policy
+	ErrInvalidFailUrl = errors.New("invalid heartbeat fail url")
+	// ErrInvalidInterval is returned when the heartbeat interval is invalid
+	ErrInvalidInterval = errors.New("invalid heartbeat interval")
+	// ErrInvalidMaxResultAge is returned when the maximum age of the latest result is invalid
+	ErrInvalidMaxResultAge = errors.New("invalid heartbeat max result age")
+	// ErrInvalidPolicy is returned when the failure policy is unknown
+	ErrInvalidPolicy = errors.New("invalid heartbeat failure policy")
+)
@@ -0,0 +1,115 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hub
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// Config is the configuration for the hub mode
+type Config struct {
+	// Push is the configuration for pushing the results to a hub
+	Push PushConfig `yaml:"push" mapstructure:"push"`
+	// Receiver is the configuration for accepting results pushed by other sparrows
+	Receiver ReceiverConfig `yaml:"receiver" mapstructure:"receiver"`
+}
+
+// PushConfig is the configuration for pushing the results to a hub
+type PushConfig struct {
+	// Enabled is a flag to enable or disable the push of the results
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Url is the URL of the hub's submission endpoint
+	Url string `yaml:"url" mapstructure:"url"`
+	// Secret is the shared secret used to sign the submissions
+	Secret string `yaml:"secret" mapstructure:"secret"`
+	// Interval is the interval in which the collected results are pushed
+	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
+	// BatchSize is the amount of results after which a push is triggered before the interval elapsed
+	BatchSize int `yaml:"batchSize" mapstructure:"batchSize"`
+	// Timeout is the timeout of a single push
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// ReceiverConfig is the configuration for accepting results pushed by other sparrows
+type ReceiverConfig struct {
+	// Enabled is a flag to enable or disable the acceptance of submissions
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Secret is the shared secret used to verify the signature of the submissions
+	Secret string `yaml:"secret" mapstructure:"secret"`
+	// UnhealthyThreshold is the amount of time without submissions after which an instance is considered unhealthy.
+	// A duration of 0 means instances are never considered unhealthy.
+	UnhealthyThreshold time.Duration `yaml:"unhealthyThreshold" mapstructure:"unhealthyThreshold"`
+}
+
+// Validate validates the hub configuration
+func (c *Config) Validate(ctx context.Context) error {
+	if c.Push.Enabled {
+		if err := c.Push.Validate(ctx); err != nil {
+			return err
+		}
+	}
+	if c.Receiver.Enabled {
+		if err := c.Receiver.Validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the push configuration
+func (c *PushConfig) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if _, err := url.ParseRequestURI(c.Url); err != nil {
+		log.Error("The hub url is not a valid url", "url", c.Url)
+		return ErrInvalidUrl
+	}
+	if c.Secret == "" {
+		log.Error("The hub secret cannot be empty")
+		return ErrMissingSecret
+	}
+	if c.Interval <= 0 {
+		log.Error("The hub push interval should be above 0", "interval", c.Interval)
+		return ErrInvalidInterval
+	}
+	if c.BatchSize < 0 {
+		log.Error("The hub batch size should be equal or above 0", "batchSize", c.BatchSize)
+		return ErrInvalidBatchSize
+	}
+	return nil
+}
+
+// Validate validates the receiver configuration
+func (c *ReceiverConfig) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if c.Secret == "" {
+		log.Error("The hub receiver secret cannot be empty")
+		return ErrMissingSecret
+	}
+	if c.UnhealthyThreshold < 0 {
+		log.Error("The hub unhealthy threshold should be equal or above 0", "unhealthyThreshold", c.UnhealthyThreshold)
+		return ErrInvalidThreshold
+	}
+	return nil
+}
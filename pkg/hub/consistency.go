@@ -0,0 +1,121 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package hub
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// queryParamAll is the query parameter to return the verdicts on all compared targets
+const queryParamAll = "all"
+
+var descTargetDisagreement = prometheus.NewDesc(
+	"sparrow_hub_target_disagreement",
+	"Whether the alive instances disagree on the health of the target",
+	[]string{"check", "target"}, nil,
+)
+
+// Verdicts are the verdicts of the instances on the health of a target of a check
+type Verdicts struct {
+	// Check is the name of the check
+	Check string `json:"check"`
+	// Target is the target of the check
+	Target string `json:"target"`
+	// Healthy are the sorted instances the target is healthy from
+	Healthy []string `json:"healthy"`
+	// Failing are the sorted instances the target fails from
+	Failing []string `json:"failing"`
+}
+
+// Disagree returns true if the target is healthy from some instances but fails from others
+func (v *Verdicts) Disagree() bool {
+	return len(v.Healthy) > 0 && len(v.Failing) > 0
+}
+
+// HandleConsistency returns the verdicts on the targets the alive instances disagree on.
+// The verdicts on all targets reported by at least two instances are returned if the query parameter all is true.
+func (rc *Receiver) HandleConsistency(w http.ResponseWriter, r *http.Request) {
+	all := false
+	if q := r.URL.Query().Get(queryParamAll); q != "" {
+		var err error
+		if all, err = strconv.ParseBool(q); err != nil {
+			writeStatus(w, r, http.StatusBadRequest)
+			return
+		}
+	}
+
+	verdicts := rc.Consistency()
+	if !all {
+		verdicts = slices.DeleteFunc(verdicts, func(v Verdicts) bool { return !v.Disagree() })
+	}
+	writeResponse(w, r, verdicts)
+}
+
+// Consistency compares the latest results of the alive instances. It returns the verdicts on every
+// target of a check that is reported by at least two instances, sorted by check and target.
+func (rc *Receiver) Consistency() []Verdicts {
+	alive := map[string]bool{}
+	for _, i := range rc.Instances() {
+		alive[i.Name] = i.Alive
+	}
+
+	byTarget := map[[2]string]*Verdicts{}
+	for instance, results := range rc.Results() {
+		if !alive[instance] {
+			continue
+		}
+		for check, res := range results {
+			for target, failed := range runtime.TargetFailures(check, res.Data) {
+				key := [2]string{check, target}
+				v, ok := byTarget[key]
+				if !ok {
+					v = &Verdicts{Check: check, Target: target, Healthy: []string{}, Failing: []string{}}
+					byTarget[key] = v
+				}
+				if failed {
+					v.Failing = append(v.Failing, instance)
+				} else {
+					v.Healthy = append(v.Healthy, instance)
+				}
+			}
+		}
+	}
+
+	verdicts := make([]Verdicts, 0, len(byTarget))
+	for _, v := range byTarget {
+		if len(v.Healthy)+len(v.Failing) < 2 {
+			continue
+		}
+		slices.Sort(v.Healthy)
+		slices.Sort(v.Failing)
+		verdicts = append(verdicts, *v)
+	}
+	slices.SortFunc(verdicts, func(a, b Verdicts) int {
+		if c := strings.Compare(a.Check, b.Check); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Target, b.Target)
+	})
+	return verdicts
+}
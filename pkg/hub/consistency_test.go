@@ -0,0 +1,110 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package hub
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestReceiver_Consistency(t *testing.T) {
+	now := time.Now()
+	// the results are decoded from the JSON of the submissions
+	health := func(statuses map[string]string) *checks.Result {
+		data := map[string]any{}
+		for target, status := range statuses {
+			data[target] = map[string]any{"status": status}
+		}
+		return &checks.Result{Timestamp: now, SchemaVersion: 2, Data: data}
+	}
+
+	rc := NewReceiver(ReceiverConfig{Enabled: true, Secret: "secret", UnhealthyThreshold: time.Minute})
+	rc.store(&Submission{Instance: "sparrow-a.com", Results: []checks.ResultDTO{
+		{Name: "health", Result: health(map[string]string{"https://a.com": "healthy", "https://b.com": "healthy", "https://c.com": "healthy"})},
+	}}, time.Now())
+	rc.store(&Submission{Instance: "sparrow-b.com", Results: []checks.ResultDTO{
+		{Name: "health", Result: health(map[string]string{"https://a.com": "unhealthy", "https://b.com": "healthy"})},
+		{Name: "unknown", Result: &checks.Result{Timestamp: now, Data: map[string]any{"https://a.com": 1.0}}},
+	}}, time.Now())
+	rc.store(&Submission{Instance: "sparrow-c.com", Results: []checks.ResultDTO{
+		{Name: "health", Result: health(map[string]string{"https://b.com": "unhealthy"})},
+	}}, time.Now())
+	rc.mu.Lock()
+	rc.lastSeen["sparrow-c.com"] = now.Add(-time.Hour)
+	rc.mu.Unlock()
+
+	want := []Verdicts{
+		{Check: "health", Target: "https://a.com", Healthy: []string{"sparrow-a.com"}, Failing: []string{"sparrow-b.com"}},
+		{Check: "health", Target: "https://b.com", Healthy: []string{"sparrow-a.com", "sparrow-b.com"}, Failing: []string{}},
+	}
+	if got := rc.Consistency(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Receiver.Consistency() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReceiver_HandleConsistency(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantCount int
+	}{
+		{name: "disagreements", query: "", wantCode: http.StatusOK, wantCount: 1},
+		{name: "all verdicts", query: "?all=true", wantCode: http.StatusOK, wantCount: 2},
+		{name: "invalid query", query: "?all=maybe", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := NewReceiver(ReceiverConfig{Enabled: true, Secret: "secret"})
+			for instance, status := range map[string]string{"sparrow-a.com": "healthy", "sparrow-b.com": "unhealthy"} {
+				rc.store(&Submission{Instance: instance, Results: []checks.ResultDTO{
+					{Name: "health", Result: &checks.Result{Timestamp: time.Now(), Data: map[string]any{
+						"https://a.com": map[string]any{"status": status},
+						"https://b.com": map[string]any{"status": "healthy"},
+					}}},
+				}}, time.Now())
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/fleet/consistency"+tt.query, http.NoBody)
+			rc.HandleConsistency(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Receiver.HandleConsistency() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var got []Verdicts
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("Receiver.HandleConsistency() returned %d verdicts, want %d", len(got), tt.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hub
+
+import "errors"
+
+var (
+	// ErrInvalidUrl is returned when the hub url is invalid
+	ErrInvalidUrl = errors.New("invalid hub url")
+	// ErrMissingSecret is returned when no secret is configured
+	ErrMissingSecret = errors.New("missing hub secret")
+	// ErrInvalidInterval is returned when the push interval is invalid
+	ErrInvalidInterval = errors.New("invalid hub push interval")
+	// ErrInvalidBatchSize is returned when the batch size is invalid
+	ErrInvalidBatchSize = errors.New("invalid hub batch size")
+	// ErrInvalidThreshold is returned when the unhealthy threshold is invalid
+	ErrInvalidThreshold = errors.New("invalid hub unhealthy threshold")
+	// ErrInvalidSignature is returned when the signature of a submission is invalid
+	ErrInvalidSignature = errors.New("invalid submission signature")
+	// ErrInvalidTimestamp is returned when the signing time of a submission is missing or malformed
+	ErrInvalidTimestamp = errors.New("invalid submission timestamp")
+	// ErrStaleSubmission is returned when a submission was signed outside of the accepted clock skew
+	ErrStaleSubmission = errors.New("stale submission")
+)
@@ -0,0 +1,187 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// maxBufferedResults is the maximum amount of results kept while the hub is unreachable.
+// The oldest results are dropped if the limit is exceeded.
+const maxBufferedResults = 1000
+
+// Pusher collects the results of the checks and pushes them in signed batches to a hub
+type Pusher struct {
+	instance string
+	cfg      PushConfig
+	client   *http.Client
+	mu       sync.Mutex
+	buffer   []checks.ResultDTO
+	cFlush   chan struct{}
+	done     chan struct{}
+}
+
+// NewPusher creates a new Pusher for the given instance identity
+func NewPusher(instance string, cfg PushConfig) *Pusher {
+	return &Pusher{
+		instance: instance,
+		cfg:      cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+		buffer: []checks.ResultDTO{},
+		cFlush: make(chan struct{}, 1),
+		done:   make(chan struct{}, 1),
+	}
+}
+
+// Submit adds a result to the next batch.
+// A push is triggered if the configured batch size is reached.
+func (p *Pusher) Submit(result checks.ResultDTO) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.buffer = append(p.buffer, result)
+	if len(p.buffer) > maxBufferedResults {
+		p.buffer = p.buffer[len(p.buffer)-maxBufferedResults:]
+	}
+
+	if p.cfg.BatchSize > 0 && len(p.buffer) >= p.cfg.BatchSize {
+		select {
+		case p.cFlush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Pending returns the amount of collected results not pushed to the hub yet
+func (p *Pusher) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.buffer)
+}
+
+// Run pushes the collected results in the configured interval
+// or whenever the batch size is reached until the context is canceled or the Pusher is shut down
+func (p *Pusher) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.cFlush:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.done:
+			return nil
+		}
+
+		if err := p.flush(ctx); err != nil {
+			log.WarnContext(ctx, "Failed to push results to hub, retrying with the next batch", "error", err)
+		}
+	}
+}
+
+// Shutdown pushes the remaining results and stops the Pusher
+func (p *Pusher) Shutdown(ctx context.Context) error {
+	err := p.flush(ctx)
+	select {
+	case p.done <- struct{}{}:
+	default:
+	}
+	return err
+}
+
+// flush pushes all collected results to the hub.
+// The results are kept for the next push if the push fails.
+func (p *Pusher) flush(ctx context.Context) error {
+	p.mu.Lock()
+	batch := p.buffer
+	p.buffer = []checks.ResultDTO{}
+	p.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := p.push(ctx, batch); err != nil {
+		p.mu.Lock()
+		p.buffer = append(batch, p.buffer...)
+		if len(p.buffer) > maxBufferedResults {
+			p.buffer = p.buffer[len(p.buffer)-maxBufferedResults:]
+		}
+		p.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// push sends a signed submission with the given results to the hub
+func (p *Pusher) push(ctx context.Context, results []checks.ResultDTO) (err error) {
+	log := logger.FromContext(ctx)
+
+	now := time.Now()
+	body, err := json.Marshal(Submission{
+		Instance:  p.instance,
+		Timestamp: now,
+		Results:   results,
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to marshal submission", "error", err)
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Url, bytes.NewReader(body))
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to create request", "error", err)
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(TimestampHeader, FormatTimestamp(now))
+	req.Header.Add(SignatureHeader, Sign(p.cfg.Secret, now, body))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to push submission", "error", err)
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+
+	if resp.StatusCode != http.StatusAccepted {
+		log.ErrorContext(ctx, "Failed to push submission", "status", resp.Status)
+		return fmt.Errorf("request failed, status is %s", resp.Status)
+	}
+
+	log.DebugContext(ctx, "Pushed results to hub", "results", len(results))
+	return nil
+}
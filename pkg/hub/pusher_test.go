@@ -0,0 +1,164 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestPusher_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		batchSize  int
+		status     int
+		submit     int
+		wantPushed int
+		wantBuffer int
+	}{
+		{
+			name:       "push on batch size",
+			batchSize:  2,
+			status:     http.StatusAccepted,
+			submit:     2,
+			wantPushed: 2,
+			wantBuffer: 0,
+		},
+		{
+			name:       "keep results on failure",
+			batchSize:  1,
+			status:     http.StatusInternalServerError,
+			submit:     1,
+			wantPushed: 0,
+			wantBuffer: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			pushed := 0
+			cPushed := make(chan struct{}, 1)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				signedAt, err := ParseTimestamp(r.Header.Get(TimestampHeader))
+				if err != nil || !Verify("secret", signedAt, body, r.Header.Get(SignatureHeader)) {
+					t.Errorf("Pusher sent an invalid signature")
+				}
+				var sub Submission
+				if err := json.Unmarshal(body, &sub); err != nil {
+					t.Errorf("Pusher sent an invalid submission: %v", err)
+				}
+				if sub.Instance != "sparrow.com" {
+					t.Errorf("Submission.Instance = %q, want %q", sub.Instance, "sparrow.com")
+				}
+				if tt.status == http.StatusAccepted {
+					mu.Lock()
+					pushed += len(sub.Results)
+					mu.Unlock()
+				}
+				w.WriteHeader(tt.status)
+				select {
+				case cPushed <- struct{}{}:
+				default:
+				}
+			}))
+			defer srv.Close()
+
+			p := NewPusher("sparrow.com", PushConfig{
+				Enabled:   true,
+				Url:       srv.URL,
+				Secret:    "secret",
+				Interval:  time.Hour,
+				BatchSize: tt.batchSize,
+				Timeout:   time.Second,
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				_ = p.Run(ctx)
+			}()
+
+			for i := 0; i < tt.submit; i++ {
+				p.Submit(checks.ResultDTO{Name: "health", Result: &checks.Result{Timestamp: time.Now(), Data: i}})
+			}
+
+			select {
+			case <-cPushed:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Pusher did not push the batch")
+			}
+			// wait for the pusher to handle the response
+			time.Sleep(50 * time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if pushed != tt.wantPushed {
+				t.Errorf("Pusher pushed %d results, want %d", pushed, tt.wantPushed)
+			}
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if len(p.buffer) != tt.wantBuffer {
+				t.Errorf("Pusher buffered %d results, want %d", len(p.buffer), tt.wantBuffer)
+			}
+		})
+	}
+}
+
+func TestPusher_Shutdown(t *testing.T) {
+	cPushed := make(chan Submission, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var sub Submission
+		_ = json.NewDecoder(r.Body).Decode(&sub)
+		cPushed <- sub
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	p := NewPusher("sparrow.com", PushConfig{
+		Enabled:  true,
+		Url:      srv.URL,
+		Secret:   "secret",
+		Interval: time.Hour,
+		Timeout:  time.Second,
+	})
+	p.Submit(checks.ResultDTO{Name: "health", Result: &checks.Result{Timestamp: time.Now()}})
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Pusher.Shutdown() error = %v", err)
+	}
+
+	select {
+	case sub := <-cPushed:
+		if len(sub.Results) != 1 {
+			t.Errorf("Pusher.Shutdown() pushed %d results, want 1", len(sub.Results))
+		}
+	default:
+		t.Error("Pusher.Shutdown() did not push the remaining results")
+	}
+}
@@ -0,0 +1,335 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/api"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// maxSubmissionSize is the maximum accepted size of a submission in bytes
+	maxSubmissionSize = 10 << 20
+	// maxClockSkew is the maximum difference between the signing time of a submission and the time it's received.
+	// Older submissions are rejected, so captured submissions can't be replayed.
+	maxClockSkew = 5 * time.Minute
+
+	urlParamInstance  = "instance"
+	urlParamCheckName = "checkName"
+)
+
+var _ prometheus.Collector = (*Receiver)(nil)
+
+var (
+	descInstanceAlive = prometheus.NewDesc(
+		"sparrow_hub_instance_alive",
+		"Whether the instance submitted results within the unhealthy threshold",
+		[]string{"instance"}, nil,
+	)
+	descInstanceLastSeen = prometheus.NewDesc(
+		"sparrow_hub_instance_last_submission_timestamp_seconds",
+		"Unix timestamp of the last submission of the instance",
+		[]string{"instance"}, nil,
+	)
+)
+
+// InstanceStatus is the liveness status of an instance submitting results
+type InstanceStatus struct {
+	// Name is the identity of the instance
+	Name string `json:"name"`
+	// LastSeen is the time of the last submission of the instance
+	LastSeen time.Time `json:"lastSeen"`
+	// Alive is true if the instance submitted results within the unhealthy threshold
+	Alive bool `json:"alive"`
+}
+
+// Receiver accepts the submissions of other sparrows,
+// stores their results per instance and tracks their liveness
+type Receiver struct {
+	cfg   ReceiverConfig
+	fleet db.Fleet
+	mu    sync.RWMutex
+	// lastSeen is the time of the last submission mapped by instance
+	lastSeen map[string]time.Time
+}
+
+// NewReceiver creates a new Receiver
+func NewReceiver(cfg ReceiverConfig) *Receiver {
+	return &Receiver{
+		cfg:      cfg,
+		fleet:    db.NewInMemoryFleet(),
+		lastSeen: map[string]time.Time{},
+	}
+}
+
+// Routes returns the API routes of the receiver
+func (rc *Receiver) Routes() []api.Route {
+	return []api.Route{
+		{
+			Path: "/v1/hub/results", Method: http.MethodPost,
+			Handler: rc.HandleSubmit,
+		},
+		{
+			Path: "/v1/fleet", Method: http.MethodGet,
+			Handler: rc.HandleInstances,
+		},
+		{
+			Path: "/v1/fleet/metrics", Method: http.MethodGet,
+			Handler: rc.HandleResults,
+		},
+		{
+			Path: "/v1/fleet/consistency", Method: http.MethodGet,
+			Handler: rc.HandleConsistency,
+		},
+		{
+			Path: fmt.Sprintf("/v1/fleet/{%s}/metrics", urlParamInstance), Method: http.MethodGet,
+			Handler: rc.HandleInstanceResults,
+		},
+		{
+			Path: fmt.Sprintf("/v1/fleet/{%s}/metrics/{%s}", urlParamInstance, urlParamCheckName), Method: http.MethodGet,
+			Handler: rc.HandleInstanceCheckResult,
+		},
+	}
+}
+
+// HandleSubmit verifies and stores a submission
+func (rc *Receiver) HandleSubmit(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSubmissionSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Warn("Rejected submission exceeding the maximum size", "limit", tooLarge.Limit)
+			writeStatus(w, r, http.StatusRequestEntityTooLarge)
+			return
+		}
+		log.Error("Failed to read submission", "error", err)
+		writeStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	signedAt, err := ParseTimestamp(r.Header.Get(TimestampHeader))
+	if err != nil {
+		log.Warn("Rejected submission", "error", err)
+		writeStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+	if !Verify(rc.cfg.Secret, signedAt, body, r.Header.Get(SignatureHeader)) {
+		log.Warn("Rejected submission", "error", ErrInvalidSignature)
+		writeStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+	if skew := time.Since(signedAt); skew > maxClockSkew || skew < -maxClockSkew {
+		log.Warn("Rejected submission", "error", ErrStaleSubmission, "signedAt", signedAt)
+		writeStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	var sub Submission
+	if err = json.Unmarshal(body, &sub); err != nil || sub.Instance == "" {
+		log.Warn("Rejected malformed submission", "error", err)
+		writeStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	rc.store(&sub, signedAt)
+	writeStatus(w, r, http.StatusAccepted)
+}
+
+// HandleInstances returns the liveness status of all instances
+func (rc *Receiver) HandleInstances(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, r, rc.Instances())
+}
+
+// HandleResults returns the merged latest results of all instances
+func (rc *Receiver) HandleResults(w http.ResponseWriter, r *http.Request) {
+	writeMap(w, r, rc.Results())
+}
+
+// HandleInstanceResults returns the latest results of a single instance
+func (rc *Receiver) HandleInstanceResults(w http.ResponseWriter, r *http.Request) {
+	idb, ok := rc.fleet.Get(chi.URLParam(r, urlParamInstance))
+	if !ok {
+		writeStatus(w, r, http.StatusNotFound)
+		return
+	}
+	writeMap(w, r, idb.List())
+}
+
+// HandleInstanceCheckResult returns the latest result of a single check of an instance
+func (rc *Receiver) HandleInstanceCheckResult(w http.ResponseWriter, r *http.Request) {
+	idb, ok := rc.fleet.Get(chi.URLParam(r, urlParamInstance))
+	if !ok {
+		writeStatus(w, r, http.StatusNotFound)
+		return
+	}
+	res, ok := idb.Get(chi.URLParam(r, urlParamCheckName))
+	if !ok {
+		writeStatus(w, r, http.StatusNotFound)
+		return
+	}
+	writeResponse(w, r, res)
+}
+
+// Results returns the latest results mapped by instance and check name
+func (rc *Receiver) Results() map[string]map[string]checks.Result {
+	instances := rc.fleet.List()
+	results := make(map[string]map[string]checks.Result, len(instances))
+	for name, idb := range instances {
+		results[name] = idb.List()
+	}
+	return results
+}
+
+// Restore stores the given results mapped by instance and check name, e.g. the results
+// exported by the instance replaced by this one. The instances are considered seen at the
+// time of their latest result.
+func (rc *Receiver) Restore(results map[string]map[string]checks.Result) {
+	for instance, res := range results {
+		var latest time.Time
+		dtos := make([]checks.ResultDTO, 0, len(res))
+		for name, r := range res {
+			if r.Timestamp.After(latest) {
+				latest = r.Timestamp
+			}
+			dtos = append(dtos, checks.ResultDTO{Name: name, Result: &r})
+		}
+		rc.save(instance, dtos)
+
+		rc.mu.Lock()
+		if latest.After(rc.lastSeen[instance]) {
+			rc.lastSeen[instance] = latest
+		}
+		rc.mu.Unlock()
+	}
+}
+
+// Instances returns the liveness status of all instances sorted by name
+func (rc *Receiver) Instances() []InstanceStatus {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	now := time.Now()
+	instances := make([]InstanceStatus, 0, len(rc.lastSeen))
+	for name, lastSeen := range rc.lastSeen {
+		instances = append(instances, InstanceStatus{
+			Name:     name,
+			LastSeen: lastSeen,
+			Alive:    rc.cfg.UnhealthyThreshold == 0 || now.Sub(lastSeen) <= rc.cfg.UnhealthyThreshold,
+		})
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Name < instances[j].Name
+	})
+	return instances
+}
+
+// Describe sends the descriptors of the liveness and consistency metrics to the channel
+func (rc *Receiver) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descInstanceAlive
+	ch <- descInstanceLastSeen
+	ch <- descTargetDisagreement
+}
+
+// Collect sends the liveness metrics of all instances and the consistency metrics of all compared targets to the channel
+func (rc *Receiver) Collect(ch chan<- prometheus.Metric) {
+	for _, i := range rc.Instances() {
+		alive := 0.0
+		if i.Alive {
+			alive = 1
+		}
+		ch <- prometheus.MustNewConstMetric(descInstanceAlive, prometheus.GaugeValue, alive, i.Name)
+		ch <- prometheus.MustNewConstMetric(descInstanceLastSeen, prometheus.GaugeValue, float64(i.LastSeen.Unix()), i.Name)
+	}
+	for _, v := range rc.Consistency() {
+		disagree := 0.0
+		if v.Disagree() {
+			disagree = 1
+		}
+		ch <- prometheus.MustNewConstMetric(descTargetDisagreement, prometheus.GaugeValue, disagree, v.Check, v.Target)
+	}
+}
+
+// store stores the results of the submission and marks the instance as seen at the signing time
+// of the submission. A replayed submission doesn't move the time the instance was seen forward.
+func (rc *Receiver) store(sub *Submission, signedAt time.Time) {
+	rc.mu.Lock()
+	if signedAt.After(rc.lastSeen[sub.Instance]) {
+		rc.lastSeen[sub.Instance] = signedAt
+	}
+	rc.mu.Unlock()
+
+	rc.save(sub.Instance, sub.Results)
+}
+
+// save stores the results of the given instance, keeping only the latest result per check
+func (rc *Receiver) save(instance string, results []checks.ResultDTO) {
+	idb, _ := rc.fleet.Get(instance)
+	for _, r := range results {
+		if r.Result == nil {
+			continue
+		}
+		if idb != nil {
+			if cur, ok := idb.Get(r.Name); ok && cur.Timestamp.After(r.Result.Timestamp) {
+				continue
+			}
+		}
+		rc.fleet.Save(instance, r)
+	}
+}
+
+// writeResponse writes the given value to the response
+// in the format negotiated via the Accept header
+func writeResponse(w http.ResponseWriter, r *http.Request, v any) {
+	if err := api.NewEncoder(w, r).Encode(v); err != nil {
+		logger.FromContext(r.Context()).Error("failed to encode response", "error", err)
+		writeStatus(w, r, http.StatusInternalServerError)
+	}
+}
+
+// writeMap streams the given map to the response
+// in the format negotiated via the Accept header
+func writeMap[V any](w http.ResponseWriter, r *http.Request, m map[string]V) {
+	if err := api.EncodeMap(w, r, m); err != nil {
+		logger.FromContext(r.Context()).Error("failed to encode response", "error", err)
+		writeStatus(w, r, http.StatusInternalServerError)
+	}
+}
+
+// writeStatus writes the given status code and its text to the response
+func writeStatus(w http.ResponseWriter, r *http.Request, code int) {
+	w.WriteHeader(code)
+	if _, err := w.Write([]byte(http.StatusText(code))); err != nil {
+		logger.FromContext(r.Context()).Error("Failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,306 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestReceiver_HandleSubmit(t *testing.T) {
+	now := time.Now()
+	valid, _ := json.Marshal(Submission{
+		Instance:  "sparrow-a.com",
+		Timestamp: now,
+		Results: []checks.ResultDTO{
+			{Name: "health", Result: &checks.Result{Timestamp: now, Data: 1}},
+		},
+	})
+	anonymous, _ := json.Marshal(Submission{Timestamp: now})
+
+	tooLarge := bytes.Repeat([]byte(" "), maxSubmissionSize+1)
+
+	tests := []struct {
+		name      string
+		body      []byte
+		timestamp string
+		signature string
+		wantCode  int
+		wantStore bool
+	}{
+		{
+			name:      "valid submission",
+			body:      valid,
+			timestamp: FormatTimestamp(now),
+			signature: Sign("secret", now, valid),
+			wantCode:  http.StatusAccepted,
+			wantStore: true,
+		},
+		{
+			name:      "invalid signature",
+			body:      valid,
+			timestamp: FormatTimestamp(now),
+			signature: Sign("other", now, valid),
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "missing signature",
+			body:      valid,
+			timestamp: FormatTimestamp(now),
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "missing timestamp",
+			body:      valid,
+			signature: Sign("secret", now, valid),
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "timestamp not covered by the signature",
+			body:      valid,
+			timestamp: FormatTimestamp(now),
+			signature: Sign("secret", now.Add(-time.Minute), valid),
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "replayed submission",
+			body:      valid,
+			timestamp: FormatTimestamp(now.Add(-time.Hour)),
+			signature: Sign("secret", now.Add(-time.Hour), valid),
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "submission signed in the future",
+			body:      valid,
+			timestamp: FormatTimestamp(now.Add(time.Hour)),
+			signature: Sign("secret", now.Add(time.Hour), valid),
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "submission too large",
+			body:      tooLarge,
+			timestamp: FormatTimestamp(now),
+			signature: Sign("secret", now, tooLarge),
+			wantCode:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:      "malformed submission",
+			body:      []byte("not json"),
+			timestamp: FormatTimestamp(now),
+			signature: Sign("secret", now, []byte("not json")),
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "missing instance",
+			body:      anonymous,
+			timestamp: FormatTimestamp(now),
+			signature: Sign("secret", now, anonymous),
+			wantCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := NewReceiver(ReceiverConfig{Enabled: true, Secret: "secret"})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/v1/hub/results", bytes.NewReader(tt.body))
+			if tt.timestamp != "" {
+				r.Header.Set(TimestampHeader, tt.timestamp)
+			}
+			if tt.signature != "" {
+				r.Header.Set(SignatureHeader, tt.signature)
+			}
+
+			rc.HandleSubmit(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Receiver.HandleSubmit() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			_, ok := rc.Results()["sparrow-a.com"]["health"]
+			if ok != tt.wantStore {
+				t.Errorf("Receiver.HandleSubmit() stored = %v, want %v", ok, tt.wantStore)
+			}
+		})
+	}
+}
+
+func TestReceiver_store(t *testing.T) {
+	now := time.Now()
+	rc := NewReceiver(ReceiverConfig{Enabled: true, Secret: "secret"})
+
+	rc.store(&Submission{Instance: "sparrow-a.com", Results: []checks.ResultDTO{
+		{Name: "health", Result: &checks.Result{Timestamp: now, Data: 2}},
+	}}, time.Now())
+	rc.store(&Submission{Instance: "sparrow-a.com", Results: []checks.ResultDTO{
+		{Name: "health", Result: &checks.Result{Timestamp: now.Add(-time.Minute), Data: 1}},
+		{Name: "latency", Result: nil},
+	}}, time.Now())
+	rc.store(&Submission{Instance: "sparrow-b.com", Results: []checks.ResultDTO{
+		{Name: "health", Result: &checks.Result{Timestamp: now, Data: 3}},
+	}}, time.Now())
+
+	results := rc.Results()
+	if len(results) != 2 {
+		t.Fatalf("Receiver.Results() has %d instances, want 2", len(results))
+	}
+	if got := results["sparrow-a.com"]["health"].Data; got != 2 {
+		t.Errorf("Receiver.store() kept %v, want the latest result 2", got)
+	}
+	if _, ok := results["sparrow-a.com"]["latency"]; ok {
+		t.Error("Receiver.store() stored an empty result")
+	}
+}
+
+func TestReceiver_store_lastSeen(t *testing.T) {
+	signedAt := time.Now().Truncate(time.Second)
+	rc := NewReceiver(ReceiverConfig{Enabled: true, Secret: "secret"})
+
+	rc.store(&Submission{Instance: "sparrow-a.com"}, signedAt)
+	// a replayed older submission doesn't mark the instance as seen later on
+	rc.store(&Submission{Instance: "sparrow-a.com"}, signedAt.Add(-time.Minute))
+
+	instances := rc.Instances()
+	if len(instances) != 1 || !instances[0].LastSeen.Equal(signedAt) {
+		t.Errorf("Receiver.Instances() = %v, want sparrow-a.com seen at the signing time %v", instances, signedAt)
+	}
+}
+
+func TestReceiver_Restore(t *testing.T) {
+	now := time.Now()
+	rc := NewReceiver(ReceiverConfig{Enabled: true, Secret: "secret"})
+	rc.store(&Submission{Instance: "sparrow-a.com", Results: []checks.ResultDTO{
+		{Name: "health", Result: &checks.Result{Timestamp: now, Data: 2}},
+	}}, time.Now())
+
+	rc.Restore(map[string]map[string]checks.Result{
+		"sparrow-a.com": {"health": {Timestamp: now.Add(-time.Minute), Data: 1}},
+		"sparrow-b.com": {"health": {Timestamp: now.Add(-time.Minute), Data: 3}},
+	})
+
+	results := rc.Results()
+	if got := results["sparrow-a.com"]["health"].Data; got != 2 {
+		t.Errorf("Receiver.Restore() replaced the newer result with %v", got)
+	}
+	if got := results["sparrow-b.com"]["health"].Data; got != 3 {
+		t.Errorf("Receiver.Restore() restored %v, want 3", got)
+	}
+	for _, i := range rc.Instances() {
+		if i.Name == "sparrow-b.com" && !i.LastSeen.Equal(now.Add(-time.Minute)) {
+			t.Errorf("Receiver.Restore() marked %s as seen at %v, want the time of its latest result", i.Name, i.LastSeen)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"instance":"sparrow.com"}`)
+	signedAt := time.Now()
+	tests := []struct {
+		name      string
+		signature string
+		want      bool
+	}{
+		{name: "valid signature", signature: Sign("secret", signedAt, body), want: true},
+		{name: "wrong secret", signature: Sign("other", signedAt, body), want: false},
+		{name: "other signing time", signature: Sign("secret", signedAt.Add(time.Second), body), want: false},
+		{name: "missing prefix", signature: Sign("secret", signedAt, body)[len(signaturePrefix):], want: false},
+		{name: "empty signature", signature: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify("secret", signedAt, body, tt.signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReceiver_Instances(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		lastSeen  time.Duration
+		wantAlive bool
+	}{
+		{name: "recent submission", threshold: time.Minute, lastSeen: time.Second, wantAlive: true},
+		{name: "outdated submission", threshold: time.Minute, lastSeen: time.Hour, wantAlive: false},
+		{name: "no threshold", threshold: 0, lastSeen: time.Hour, wantAlive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := NewReceiver(ReceiverConfig{Enabled: true, Secret: "secret", UnhealthyThreshold: tt.threshold})
+			rc.lastSeen["sparrow-a.com"] = time.Now().Add(-tt.lastSeen)
+
+			instances := rc.Instances()
+			if len(instances) != 1 {
+				t.Fatalf("Receiver.Instances() returned %d instances, want 1", len(instances))
+			}
+			if instances[0].Alive != tt.wantAlive {
+				t.Errorf("Receiver.Instances() alive = %v, want %v", instances[0].Alive, tt.wantAlive)
+			}
+		})
+	}
+}
+
+func TestReceiver_HandleInstanceCheckResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance string
+		check    string
+		wantCode int
+	}{
+		{name: "known check", instance: "sparrow-a.com", check: "health", wantCode: http.StatusOK},
+		{name: "unknown check", instance: "sparrow-a.com", check: "latency", wantCode: http.StatusNotFound},
+		{name: "unknown instance", instance: "sparrow-b.com", check: "health", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := NewReceiver(ReceiverConfig{Enabled: true, Secret: "secret"})
+			rc.store(&Submission{Instance: "sparrow-a.com", Results: []checks.ResultDTO{
+				{Name: "health", Result: &checks.Result{Timestamp: time.Now(), Data: 1}},
+			}}, time.Now())
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/fleet/"+tt.instance+"/metrics/"+tt.check, http.NoBody)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add(urlParamInstance, tt.instance)
+			rctx.URLParams.Add(urlParamCheckName, tt.check)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			rc.HandleInstanceCheckResult(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Receiver.HandleInstanceCheckResult() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ServerConfig is the startup configuration of the hub server
+type ServerConfig struct {
+	// Api is the configuration for the api server
+	Api api.Config `yaml:"api" mapstructure:"api"`
+	// Hub is the configuration of the hub mode.
+	// Only the receiver configuration is used by the hub server.
+	Hub Config `yaml:"hub" mapstructure:"hub"`
+}
+
+// Validate validates the hub server configuration
+func (c *ServerConfig) Validate(ctx context.Context) (err error) {
+	log := logger.FromContext(ctx)
+
+	if vErr := c.Hub.Receiver.Validate(ctx); vErr != nil {
+		log.Error("The hub receiver configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if vErr := c.Api.Validate(); vErr != nil {
+		log.Error("The api configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("validation of configuration failed: %w", err)
+	}
+	return nil
+}
+
+// Server is the hub server accepting the submissions of the fleet
+// and exposing the fleet-wide results via its API
+type Server struct {
+	api      api.API
+	receiver *Receiver
+	registry *prometheus.Registry
+}
+
+// NewServer creates a new hub server
+func NewServer(cfg *ServerConfig) *Server {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	receiver := NewReceiver(cfg.Hub.Receiver)
+	registry.MustRegister(receiver)
+
+	return &Server{
+		api:      api.New(cfg.Api),
+		receiver: receiver,
+		registry: registry,
+	}
+}
+
+// Run registers the routes of the hub server and serves its API.
+// Blocks until the context is done.
+func (s *Server) Run(ctx context.Context) error {
+	routes := append(s.receiver.Routes(), api.Route{
+		Path: "/metrics", Method: "*",
+		Handler: promhttp.HandlerFor(
+			s.registry,
+			promhttp.HandlerOpts{Registry: s.registry},
+		).ServeHTTP,
+	})
+
+	if err := s.api.RegisterRoutes(ctx, routes...); err != nil {
+		logger.FromContext(ctx).Error("Error while registering routes", "error", err)
+		return err
+	}
+	return s.api.Run(ctx)
+}
+
+// Shutdown shuts down the hub server gracefully
+func (s *Server) Shutdown(ctx context.Context) error {
+	logger.FromContext(ctx).Info("Shutting down hub server")
+	return s.api.Shutdown(ctx)
+}
@@ -0,0 +1,80 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hub
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+const (
+	// SignatureHeader is the header containing the signature of a submission
+	SignatureHeader = "X-Sparrow-Signature"
+	// TimestampHeader is the header containing the unix time the submission was signed at
+	TimestampHeader = "X-Sparrow-Timestamp"
+	// signaturePrefix is the prefix of the signature denoting the used algorithm
+	signaturePrefix = "sha256="
+)
+
+// Submission is a batch of results pushed by a sparrow to a hub
+type Submission struct {
+	// Instance is the identity of the submitting sparrow
+	Instance string `json:"instance"`
+	// Timestamp is the time the submission was sent
+	Timestamp time.Time `json:"timestamp"`
+	// Results are the check results of the submission
+	Results []checks.ResultDTO `json:"results"`
+}
+
+// Sign returns the signature of the body signed at the given time using the given secret.
+// The time is part of the signature, so a captured submission can't be replayed later on.
+func Sign(secret string, signedAt time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(FormatTimestamp(signedAt) + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks if the signature of the body signed at the given time is valid for the given secret
+func Verify(secret string, signedAt time.Time, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, signedAt, body)), []byte(signature))
+}
+
+// FormatTimestamp returns the value of the timestamp header for the given signing time
+func FormatTimestamp(signedAt time.Time) string {
+	return strconv.FormatInt(signedAt.Unix(), 10)
+}
+
+// ParseTimestamp returns the signing time of the given value of the timestamp header
+func ParseTimestamp(v string) (time.Time, error) {
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrInvalidTimestamp
+	}
+	return time.Unix(sec, 0), nil
+}
@@ -0,0 +1,199 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+var (
+	// ErrMissingKey is returned when a receiver has no routing or api key
+	ErrMissingKey = errors.New("missing incident receiver key")
+	// ErrInvalidUrl is returned when the url of a receiver is invalid
+	ErrInvalidUrl = errors.New("invalid incident receiver url")
+	// ErrInvalidSeverity is returned when the severity of a PagerDuty receiver is invalid
+	ErrInvalidSeverity = errors.New("invalid pagerduty severity")
+	// ErrInvalidPriority is returned when the priority of an Opsgenie receiver is invalid
+	ErrInvalidPriority = errors.New("invalid opsgenie priority")
+	// ErrInvalidTimeout is returned when the timeout of a receiver is invalid
+	ErrInvalidTimeout = errors.New("invalid incident receiver timeout")
+	// ErrInvalidResolveAfter is returned when the amount of results to resolve an incident after is invalid
+	ErrInvalidResolveAfter = errors.New("invalid incident resolve after")
+)
+
+const (
+	// defaultPagerDutyUrl is the endpoint of the PagerDuty Events API v2
+	defaultPagerDutyUrl = "https://events.pagerduty.com/v2/enqueue"
+	// defaultSeverity is the severity of the PagerDuty events if none is configured
+	defaultSeverity = "error"
+	// defaultOpsgenieUrl is the base URL of the Opsgenie API
+	defaultOpsgenieUrl = "https://api.opsgenie.com"
+	// defaultPriority is the priority of the Opsgenie alerts if none is configured
+	defaultPriority = "P3"
+)
+
+var (
+	// severities are the severities supported by the PagerDuty Events API v2
+	severities = []string{"critical", "error", "warning", "info"}
+	// priorities are the priorities supported by Opsgenie
+	priorities = []string{"P1", "P2", "P3", "P4", "P5"}
+)
+
+// Config is the configuration of the incident management systems
+// the failed targets of the checks are reported to
+type Config struct {
+	// PagerDuty are the PagerDuty services the incidents are reported to
+	PagerDuty []PagerDuty `yaml:"pagerduty" mapstructure:"pagerduty"`
+	// Opsgenie are the Opsgenie teams the incidents are reported to
+	Opsgenie []Opsgenie `yaml:"opsgenie" mapstructure:"opsgenie"`
+	// ResolveAfter is the amount of consecutive results a failed target has to recover in
+	// before its incident is resolved, so flapping targets keep their incident open. Defaults to 1.
+	ResolveAfter int `yaml:"resolveAfter" mapstructure:"resolveAfter"`
+}
+
+// PagerDuty reports incidents through the PagerDuty Events API v2
+type PagerDuty struct {
+	// Name is the unique name of the receiver
+	Name string `yaml:"name" mapstructure:"name"`
+	// RoutingKey is the integration key of the PagerDuty service
+	RoutingKey string `yaml:"routingKey" mapstructure:"routingKey"`
+	// Url is the endpoint of the Events API. Defaults to https://events.pagerduty.com/v2/enqueue.
+	Url string `yaml:"url" mapstructure:"url"`
+	// Severity is the severity of the events: critical, error, warning or info. Defaults to error.
+	Severity string `yaml:"severity" mapstructure:"severity"`
+	// Checks limits the incidents to the given checks.
+	// The failed targets of all checks are reported if empty.
+	Checks []string `yaml:"checks" mapstructure:"checks"`
+	// Timeout is the timeout of a single request
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// Opsgenie reports incidents through the Opsgenie Alert API
+type Opsgenie struct {
+	// Name is the unique name of the receiver
+	Name string `yaml:"name" mapstructure:"name"`
+	// ApiKey is the key of the Opsgenie API integration
+	ApiKey string `yaml:"apiKey" mapstructure:"apiKey"`
+	// Url is the base URL of the Opsgenie API. Defaults to https://api.opsgenie.com.
+	Url string `yaml:"url" mapstructure:"url"`
+	// Priority is the priority of the alerts: P1 to P5. Defaults to P3.
+	Priority string `yaml:"priority" mapstructure:"priority"`
+	// Tags are additional tags of the alerts
+	Tags []string `yaml:"tags" mapstructure:"tags"`
+	// Checks limits the incidents to the given checks.
+	// The failed targets of all checks are reported if empty.
+	Checks []string `yaml:"checks" mapstructure:"checks"`
+	// Timeout is the timeout of a single request
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// Enabled returns true if any receiver is configured
+func (c *Config) Enabled() bool {
+	return len(c.PagerDuty) > 0 || len(c.Opsgenie) > 0
+}
+
+// Validate validates the incident configuration
+func (c *Config) Validate(ctx context.Context) error {
+	if c.ResolveAfter < 0 {
+		logger.FromContext(ctx).Error("The incident resolve after should be equal or above 0", "resolveAfter", c.ResolveAfter)
+		return ErrInvalidResolveAfter
+	}
+
+	names := make([]string, 0, len(c.PagerDuty)+len(c.Opsgenie))
+	for _, p := range c.PagerDuty {
+		names = append(names, p.Name)
+	}
+	for _, o := range c.Opsgenie {
+		names = append(names, o.Name)
+	}
+	if err := notifier.ValidateNames(ctx, "incident", names); err != nil {
+		return err
+	}
+
+	for i := range c.PagerDuty {
+		if err := c.PagerDuty[i].Validate(ctx); err != nil {
+			return err
+		}
+	}
+	for i := range c.Opsgenie {
+		if err := c.Opsgenie[i].Validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the PagerDuty receiver configuration
+func (p *PagerDuty) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx).With("name", p.Name)
+
+	if p.RoutingKey == "" {
+		log.Error("The pagerduty routing key cannot be empty")
+		return ErrMissingKey
+	}
+	if p.Url != "" && !validUrl(p.Url) {
+		log.Error("The pagerduty url is not a valid url", "url", p.Url)
+		return ErrInvalidUrl
+	}
+	if p.Severity != "" && !slices.Contains(severities, p.Severity) {
+		log.Error("The pagerduty severity is unknown", "severity", p.Severity)
+		return ErrInvalidSeverity
+	}
+	if p.Timeout < 0 {
+		log.Error("The pagerduty timeout should be equal or above 0", "timeout", p.Timeout)
+		return ErrInvalidTimeout
+	}
+	return nil
+}
+
+// Validate validates the Opsgenie receiver configuration
+func (o *Opsgenie) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx).With("name", o.Name)
+
+	if o.ApiKey == "" {
+		log.Error("The opsgenie api key cannot be empty")
+		return ErrMissingKey
+	}
+	if o.Url != "" && !validUrl(o.Url) {
+		log.Error("The opsgenie url is not a valid url", "url", o.Url)
+		return ErrInvalidUrl
+	}
+	if o.Priority != "" && !slices.Contains(priorities, o.Priority) {
+		log.Error("The opsgenie priority is unknown", "priority", o.Priority)
+		return ErrInvalidPriority
+	}
+	if o.Timeout < 0 {
+		log.Error("The opsgenie timeout should be equal or above 0", "timeout", o.Timeout)
+		return ErrInvalidTimeout
+	}
+	return nil
+}
+
+// validUrl returns true if the url is an absolute http or https url
+func validUrl(raw string) bool {
+	u, err := url.ParseRequestURI(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
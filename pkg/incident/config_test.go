@@ -0,0 +1,107 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name:    "no receivers",
+			config:  Config{},
+			wantErr: nil,
+		},
+		{
+			name: "valid receivers",
+			config: Config{
+				PagerDuty: []PagerDuty{{Name: "pd", RoutingKey: "key", Severity: "critical", Timeout: time.Second}},
+				Opsgenie:  []Opsgenie{{Name: "og", ApiKey: "key", Url: "https://api.eu.opsgenie.com", Priority: "P1"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "missing name",
+			config:  Config{PagerDuty: []PagerDuty{{RoutingKey: "key"}}},
+			wantErr: notifier.ErrMissingName,
+		},
+		{
+			name: "duplicate name across systems",
+			config: Config{
+				PagerDuty: []PagerDuty{{Name: "oncall", RoutingKey: "key"}},
+				Opsgenie:  []Opsgenie{{Name: "oncall", ApiKey: "key"}},
+			},
+			wantErr: notifier.ErrDuplicateName,
+		},
+		{
+			name:    "missing routing key",
+			config:  Config{PagerDuty: []PagerDuty{{Name: "pd"}}},
+			wantErr: ErrMissingKey,
+		},
+		{
+			name:    "missing api key",
+			config:  Config{Opsgenie: []Opsgenie{{Name: "og"}}},
+			wantErr: ErrMissingKey,
+		},
+		{
+			name:    "invalid url",
+			config:  Config{Opsgenie: []Opsgenie{{Name: "og", ApiKey: "key", Url: "api.opsgenie.com"}}},
+			wantErr: ErrInvalidUrl,
+		},
+		{
+			name:    "invalid severity",
+			config:  Config{PagerDuty: []PagerDuty{{Name: "pd", RoutingKey: "key", Severity: "high"}}},
+			wantErr: ErrInvalidSeverity,
+		},
+		{
+			name:    "invalid priority",
+			config:  Config{Opsgenie: []Opsgenie{{Name: "og", ApiKey: "key", Priority: "P0"}}},
+			wantErr: ErrInvalidPriority,
+		},
+		{
+			name:    "invalid resolve after",
+			config:  Config{ResolveAfter: -1, PagerDuty: []PagerDuty{{Name: "pd", RoutingKey: "key"}}},
+			wantErr: ErrInvalidResolveAfter,
+		},
+		{
+			name:    "invalid timeout",
+			config:  Config{PagerDuty: []PagerDuty{{Name: "pd", RoutingKey: "key", Timeout: -time.Second}}},
+			wantErr: ErrInvalidTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
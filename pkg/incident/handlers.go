@@ -0,0 +1,139 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/api"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// maxSilenceSize is the maximum accepted size of a silence request in bytes
+	maxSilenceSize = 1 << 20
+
+	urlParamSilence = "silenceID"
+)
+
+// silenceRequest is the body of a request creating a silence
+type silenceRequest struct {
+	// Check only silences the alerts of the check with the given name
+	Check string `json:"check"`
+	// Target only silences the alerts of the given target
+	Target string `json:"target"`
+	// Labels only silences the alerts with the given labels
+	Labels map[string]string `json:"labels"`
+	// Duration is the duration the silence is active for, e.g. 2h
+	Duration string `json:"duration"`
+	// Comment describes why the alerts are silenced
+	Comment string `json:"comment"`
+}
+
+// Routes returns the API routes managing the silences
+func (n *Notifier) Routes() []api.Route {
+	return []api.Route{
+		{
+			Path: "/v1/silences", Method: http.MethodPost,
+			Handler: n.HandleCreateSilence,
+		},
+		{
+			Path: "/v1/silences", Method: http.MethodGet,
+			Handler: n.HandleSilences,
+		},
+		{
+			Path: fmt.Sprintf("/v1/silences/{%s}", urlParamSilence), Method: http.MethodDelete,
+			Handler: n.HandleDeleteSilence,
+		},
+	}
+}
+
+// HandleCreateSilence creates a silence and returns it
+func (n *Notifier) HandleCreateSilence(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	var req silenceRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxSilenceSize)).Decode(&req); err != nil {
+		log.Warn("Rejected malformed silence", "error", err)
+		writeError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		log.Warn("Rejected silence with invalid duration", "duration", req.Duration)
+		writeError(w, r, http.StatusBadRequest, ErrInvalidDuration)
+		return
+	}
+
+	silence, err := n.silences.Add(Silence{
+		Check:   req.Check,
+		Target:  req.Target,
+		Labels:  req.Labels,
+		Comment: req.Comment,
+	}, duration)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrEmptySelector) || errors.Is(err, ErrInvalidDuration) {
+			status = http.StatusBadRequest
+		}
+		log.Warn("Failed to create silence", "error", err)
+		writeError(w, r, status, err)
+		return
+	}
+
+	log.Info("Created silence", "id", silence.ID, "check", silence.Check, "target", silence.Target, "endsAt", silence.EndsAt)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err = json.NewEncoder(w).Encode(silence); err != nil {
+		log.Error("Failed to encode response", "error", err)
+	}
+}
+
+// HandleSilences returns the active silences
+func (n *Notifier) HandleSilences(w http.ResponseWriter, r *http.Request) {
+	if err := api.NewEncoder(w, r).Encode(n.silences.List()); err != nil {
+		logger.FromContext(r.Context()).Error("Failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// HandleDeleteSilence deletes a silence
+func (n *Notifier) HandleDeleteSilence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, urlParamSilence)
+	if !n.silences.Delete(id) {
+		writeError(w, r, http.StatusNotFound, fmt.Errorf("silence %q not found", id))
+		return
+	}
+	logger.FromContext(r.Context()).Info("Deleted silence", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError writes the given status code and error to the response
+func writeError(w http.ResponseWriter, r *http.Request, code int, err error) {
+	w.WriteHeader(code)
+	if _, wErr := w.Write([]byte(err.Error())); wErr != nil {
+		logger.FromContext(r.Context()).Error("Failed to write response", "error", wErr)
+	}
+}
@@ -0,0 +1,76 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestNotifier_silenceRoutes(t *testing.T) {
+	n := NewNotifier(Config{})
+	router := chi.NewRouter()
+	for _, route := range n.Routes() {
+		router.MethodFunc(route.Method, route.Path, route.Handler)
+	}
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "valid silence", body: `{"check": "health", "target": "https://a.com", "duration": "2h", "comment": "maintenance"}`, wantStatus: http.StatusCreated},
+		{name: "malformed body", body: `{"check": `, wantStatus: http.StatusBadRequest},
+		{name: "invalid duration", body: `{"check": "health", "duration": "forever"}`, wantStatus: http.StatusBadRequest},
+		{name: "empty selector", body: `{"duration": "2h"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(http.MethodPost, "/v1/silences", tt.body); rec.Code != tt.wantStatus {
+				t.Errorf("POST /v1/silences status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	rec := do(http.MethodGet, "/v1/silences", "")
+	var silences []Silence
+	if err := json.Unmarshal(rec.Body.Bytes(), &silences); err != nil {
+		t.Fatalf("GET /v1/silences returned invalid JSON: %v", err)
+	}
+	if len(silences) != 1 || silences[0].Comment != "maintenance" {
+		t.Fatalf("GET /v1/silences = %+v, want the created silence", silences)
+	}
+
+	if rec = do(http.MethodDelete, "/v1/silences/"+silences[0].ID, ""); rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE /v1/silences/{id} status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec = do(http.MethodDelete, "/v1/silences/"+silences[0].ID, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("DELETE /v1/silences/{id} status = %d, want %d for deleted silence", rec.Code, http.StatusNotFound)
+	}
+}
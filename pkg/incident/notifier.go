@@ -0,0 +1,266 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/notifier"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// alert is the state change of a target reported to the receivers
+type alert struct {
+	// Key identifies the incident of the target, so repeated failures update the same incident
+	Key string
+	// Check is the name of the check that reported the target
+	Check string
+	// Target is the failed or recovered target
+	Target string
+	// Instance is the identity of the sparrow that ran the check
+	Instance string
+	// Timestamp is the time the check was run
+	Timestamp time.Time
+	// Firing is true if the target failed and false if it recovered
+	Firing bool
+}
+
+// summary returns a short description of the alert
+func (a alert) summary() string {
+	if a.Firing {
+		return fmt.Sprintf("%s check of %s failed for %s", a.Check, a.Instance, a.Target)
+	}
+	return fmt.Sprintf("%s check of %s recovered for %s", a.Check, a.Instance, a.Target)
+}
+
+// dedupKey returns the key identifying the incident of a target of a check
+func dedupKey(check, target string) string {
+	return fmt.Sprintf("sparrow/%s/%s", check, target)
+}
+
+// sender reports alerts to an incident management system
+type sender interface {
+	send(ctx context.Context, a alert) error
+}
+
+// receiver is a configured incident receiver with the targets it reported as failed
+type receiver struct {
+	name   string
+	checks []string
+	sender sender
+	// resolveAfter is the amount of consecutive results a target has to recover in to be resolved
+	resolveAfter int
+	// firing are the failed targets reported to the receiver, mapped by check.
+	// The value is the amount of consecutive results the target recovered in.
+	firing map[string]map[string]int
+	// silences suppress the incidents of the matching alerts
+	silences *Silences
+	// state exposes the state of the alerts of the failed targets
+	state *prometheus.GaugeVec
+}
+
+// Notifier reports the targets failed in the results of the checks
+// to the configured incident management systems and resolves the
+// incidents once the targets recovered
+type Notifier struct {
+	*notifier.Queue
+	receivers []*receiver
+	silences  *Silences
+	state     *prometheus.GaugeVec
+}
+
+// NewNotifier creates a new Notifier for the given configuration
+func NewNotifier(cfg Config) *Notifier {
+	n := &Notifier{
+		silences: NewSilences(),
+		state: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sparrow_incident_alert_state",
+			Help: "State of the alerts of the failed targets, either firing or silenced",
+		}, []string{"receiver", "check", "target", "state"}),
+	}
+	for _, p := range cfg.PagerDuty {
+		n.receivers = append(n.receivers, n.newReceiver(p.Name, p.Checks, cfg.ResolveAfter, newPagerDutySender(p)))
+	}
+	for _, o := range cfg.Opsgenie {
+		n.receivers = append(n.receivers, n.newReceiver(o.Name, o.Checks, cfg.ResolveAfter, newOpsgenieSender(o)))
+	}
+	n.Queue = notifier.NewQueue(n.send)
+	return n
+}
+
+// Silences returns the silences suppressing the incidents of the Notifier
+func (n *Notifier) Silences() *Silences {
+	return n.silences
+}
+
+// Describe sends the descriptors of the alert state metrics to the channel
+func (n *Notifier) Describe(ch chan<- *prometheus.Desc) {
+	n.state.Describe(ch)
+}
+
+// Collect sends the alert state metrics to the channel
+func (n *Notifier) Collect(ch chan<- prometheus.Metric) {
+	n.state.Collect(ch)
+}
+
+// newReceiver creates a new receiver without any failed targets
+func (n *Notifier) newReceiver(name string, checks []string, resolveAfter int, s sender) *receiver {
+	return &receiver{
+		name:         name,
+		checks:       checks,
+		sender:       s,
+		resolveAfter: max(resolveAfter, 1),
+		firing:       map[string]map[string]int{},
+		silences:     n.silences,
+		state:        n.state,
+	}
+}
+
+// send reports the state changes of the targets in the result
+// to the receivers accepting the results of its check
+func (n *Notifier) send(ctx context.Context, result checks.ResultDTO) {
+	failures := runtime.TargetFailures(result.Name, result.Result.Data)
+	if failures == nil {
+		return
+	}
+	for _, r := range n.receivers {
+		if !notifier.Accepts(r.checks, result.Name) {
+			continue
+		}
+		r.update(ctx, result, failures)
+	}
+}
+
+// update reports the targets whose state changed since the last result of the check.
+// Failed targets are resolved once they recovered in resolveAfter consecutive results,
+// targets missing in the result, e.g. because they were removed, are resolved immediately.
+// A state is only changed if it was reported successfully, so failed reports are retried with the next result.
+// Failed targets matching a silence aren't reported until the silence expired.
+func (r *receiver) update(ctx context.Context, result checks.ResultDTO, failures map[string]bool) {
+	log := logger.FromContext(ctx).With("receiver", r.name, "check", result.Name)
+
+	firing := r.firing[result.Name]
+	if firing == nil {
+		firing = map[string]int{}
+		r.firing[result.Name] = firing
+	}
+	changes := map[string]bool{}
+	for target, failed := range failures {
+		recovered, ok := firing[target]
+		switch {
+		case failed && !ok:
+			changes[target] = true
+		case failed:
+			firing[target] = 0
+		case ok && recovered+1 >= r.resolveAfter:
+			changes[target] = false
+		case ok:
+			firing[target] = recovered + 1
+		}
+	}
+	for target := range firing {
+		if _, ok := failures[target]; !ok {
+			changes[target] = false
+		}
+	}
+
+	r.state.DeletePartialMatch(prometheus.Labels{"receiver": r.name, "check": result.Name})
+	for target, failed := range failures {
+		if !failed {
+			continue
+		}
+		state := "firing"
+		if r.silenced(result, target) {
+			state = "silenced"
+		}
+		r.state.WithLabelValues(r.name, result.Name, target, state).Set(1)
+	}
+
+	for target, failed := range changes {
+		if failed && r.silenced(result, target) {
+			log.DebugContext(ctx, "Incident is silenced", "target", target)
+			continue
+		}
+		a := alert{
+			Key:       dedupKey(result.Name, target),
+			Check:     result.Name,
+			Target:    target,
+			Instance:  result.Result.Instance,
+			Timestamp: result.Result.Timestamp,
+			Firing:    failed,
+		}
+		if err := r.sender.send(ctx, a); err != nil {
+			log.WarnContext(ctx, "Failed to report incident", "target", target, "firing", failed, "error", err)
+			continue
+		}
+		if failed {
+			firing[target] = 0
+		} else {
+			delete(firing, target)
+		}
+	}
+}
+
+// silenced returns true if a silence matches the alert of the target
+func (r *receiver) silenced(result checks.ResultDTO, target string) bool {
+	return r.silences.Silenced(map[string]string{
+		"check":    result.Name,
+		"target":   target,
+		"instance": result.Result.Instance,
+		"receiver": r.name,
+	})
+}
+
+// post sends the body as JSON to the url and expects a successful response
+func post(ctx context.Context, client *http.Client, url string, header http.Header, body any) (err error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("request failed, status is %s", resp.Status)
+	}
+	return nil
+}
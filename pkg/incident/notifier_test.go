@@ -0,0 +1,198 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// fakeSender records the alerts sent to it
+type fakeSender struct {
+	alerts []string
+	err    error
+}
+
+func (f *fakeSender) send(_ context.Context, a alert) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.alerts = append(f.alerts, a.Key+"="+strconv.FormatBool(a.Firing))
+	return nil
+}
+
+func TestReceiver_update(t *testing.T) {
+	const (
+		a = "https://a.com"
+		b = "https://b.com"
+	)
+	tests := []struct {
+		name         string
+		resolveAfter int
+		results      []map[string]bool
+		want         []string
+	}{
+		{
+			name:    "healthy targets",
+			results: []map[string]bool{{a: false, b: false}},
+			want:    nil,
+		},
+		{
+			name:    "failed target",
+			results: []map[string]bool{{a: true, b: false}},
+			want:    []string{"sparrow/health/https://a.com=true"},
+		},
+		{
+			name:    "repeated failures update a single incident",
+			results: []map[string]bool{{a: true}, {a: true}, {a: true}},
+			want:    []string{"sparrow/health/https://a.com=true"},
+		},
+		{
+			name:    "recovered target",
+			results: []map[string]bool{{a: true}, {a: false}},
+			want:    []string{"sparrow/health/https://a.com=true", "sparrow/health/https://a.com=false"},
+		},
+		{
+			name:    "flapping target",
+			results: []map[string]bool{{a: true}, {a: false}, {a: true}},
+			want:    []string{"sparrow/health/https://a.com=true", "sparrow/health/https://a.com=false", "sparrow/health/https://a.com=true"},
+		},
+		{
+			name:         "flapping target with resolve after",
+			resolveAfter: 2,
+			results:      []map[string]bool{{a: true}, {a: false}, {a: true}, {a: false}},
+			want:         []string{"sparrow/health/https://a.com=true"},
+		},
+		{
+			name:         "recovered target with resolve after",
+			resolveAfter: 2,
+			results:      []map[string]bool{{a: true}, {a: false}, {a: false}},
+			want:         []string{"sparrow/health/https://a.com=true", "sparrow/health/https://a.com=false"},
+		},
+		{
+			name:         "removed target with resolve after",
+			resolveAfter: 2,
+			results:      []map[string]bool{{a: true}, {}},
+			want:         []string{"sparrow/health/https://a.com=true", "sparrow/health/https://a.com=false"},
+		},
+		{
+			name:    "removed target",
+			results: []map[string]bool{{a: true, b: false}, {b: false}},
+			want:    []string{"sparrow/health/https://a.com=true", "sparrow/health/https://a.com=false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fakeSender{}
+			r := NewNotifier(Config{}).newReceiver("test", nil, tt.resolveAfter, s)
+			for _, failures := range tt.results {
+				r.update(context.Background(), checks.ResultDTO{Name: "health", Result: &checks.Result{}}, failures)
+			}
+			if !reflect.DeepEqual(s.alerts, tt.want) {
+				t.Errorf("receiver.update() sent %v, want %v", s.alerts, tt.want)
+			}
+		})
+	}
+}
+
+func TestReceiver_update_retry(t *testing.T) {
+	s := &fakeSender{err: errors.New("unavailable")}
+	r := NewNotifier(Config{}).newReceiver("test", nil, 0, s)
+	result := checks.ResultDTO{Name: "health", Result: &checks.Result{}}
+
+	r.update(context.Background(), result, map[string]bool{"https://a.com": true})
+	if len(r.firing["health"]) != 0 {
+		t.Fatalf("receiver.update() marked target as firing although the report failed")
+	}
+
+	s.err = nil
+	r.update(context.Background(), result, map[string]bool{"https://a.com": true})
+	if !slices.Equal(s.alerts, []string{"sparrow/health/https://a.com=true"}) {
+		t.Errorf("receiver.update() sent %v, want the failed report to be retried", s.alerts)
+	}
+}
+
+func TestReceiver_update_silenced(t *testing.T) {
+	n := NewNotifier(Config{})
+	s := &fakeSender{}
+	r := n.newReceiver("test", nil, 0, s)
+	result := checks.ResultDTO{Name: "health", Result: &checks.Result{}}
+	failures := map[string]bool{"https://a.com": true, "https://b.com": true}
+
+	silence, err := n.Silences().Add(Silence{Target: "https://a.com"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Silences.Add() error = %v", err)
+	}
+	r.update(context.Background(), result, failures)
+	if !slices.Equal(s.alerts, []string{"sparrow/health/https://b.com=true"}) {
+		t.Errorf("receiver.update() sent %v, want only the unsilenced target", s.alerts)
+	}
+	if got := alertState(t, n, "https://a.com"); got != "silenced" {
+		t.Errorf("alert state of silenced target = %q, want %q", got, "silenced")
+	}
+	if got := alertState(t, n, "https://b.com"); got != "firing" {
+		t.Errorf("alert state of firing target = %q, want %q", got, "firing")
+	}
+
+	n.Silences().Delete(silence.ID)
+	r.update(context.Background(), result, failures)
+	if !slices.Equal(s.alerts, []string{"sparrow/health/https://b.com=true", "sparrow/health/https://a.com=true"}) {
+		t.Errorf("receiver.update() sent %v, want the target to be reported once the silence is deleted", s.alerts)
+	}
+	if got := alertState(t, n, "https://a.com"); got != "firing" {
+		t.Errorf("alert state of unsilenced target = %q, want %q", got, "firing")
+	}
+}
+
+// alertState returns the state label of the alert state series of the target
+func alertState(t *testing.T, n *Notifier, target string) string {
+	t.Helper()
+	ch := make(chan prometheus.Metric, 10)
+	n.Collect(ch)
+	close(ch)
+
+	state := ""
+	for metric := range ch {
+		m := &dto.Metric{}
+		if err := metric.Write(m); err != nil {
+			t.Fatalf("failed to write metric: %v", err)
+		}
+		labels := map[string]string{}
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["target"] == target {
+			if state != "" {
+				t.Fatalf("target %q has multiple alert states", target)
+			}
+			state = labels["state"]
+		}
+	}
+	return state
+}
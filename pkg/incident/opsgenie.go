@@ -0,0 +1,93 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// opsgenieAlert is an alert of the Opsgenie Alert API
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description"`
+	Source      string            `json:"source,omitempty"`
+	Entity      string            `json:"entity"`
+	Priority    string            `json:"priority"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details"`
+}
+
+// opsgenieClose closes an Opsgenie alert
+type opsgenieClose struct {
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note"`
+}
+
+// maxOpsgenieMessage is the maximum length of the message of an Opsgenie alert
+const maxOpsgenieMessage = 130
+
+// opsgenieSender creates and closes Opsgenie alerts
+type opsgenieSender struct {
+	cfg    Opsgenie
+	client *http.Client
+}
+
+// newOpsgenieSender creates a new opsgenieSender with the defaults applied to the configuration
+func newOpsgenieSender(cfg Opsgenie) *opsgenieSender {
+	if cfg.Url == "" {
+		cfg.Url = defaultOpsgenieUrl
+	}
+	cfg.Url = strings.TrimSuffix(cfg.Url, "/")
+	if cfg.Priority == "" {
+		cfg.Priority = defaultPriority
+	}
+	return &opsgenieSender{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// send creates the alert of a failed target or closes it once the target recovered.
+// Opsgenie deduplicates alerts with the same alias while they're open.
+func (o *opsgenieSender) send(ctx context.Context, a alert) error {
+	header := http.Header{"Authorization": []string{"GenieKey " + o.cfg.ApiKey}}
+	if !a.Firing {
+		u := o.cfg.Url + "/v2/alerts/" + url.PathEscape(a.Key) + "/close?identifierType=alias"
+		return post(ctx, o.client, u, header, opsgenieClose{Source: a.Instance, Note: a.summary()})
+	}
+
+	msg := a.summary()
+	if len(msg) > maxOpsgenieMessage {
+		msg = msg[:maxOpsgenieMessage]
+	}
+	return post(ctx, o.client, o.cfg.Url+"/v2/alerts", header, opsgenieAlert{
+		Message:     msg,
+		Alias:       a.Key,
+		Description: a.summary(),
+		Source:      a.Instance,
+		Entity:      a.Target,
+		Priority:    o.cfg.Priority,
+		Tags:        o.cfg.Tags,
+		Details: map[string]string{
+			"check":  a.Check,
+			"target": a.Target,
+		},
+	})
+}
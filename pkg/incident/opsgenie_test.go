@@ -0,0 +1,79 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpsgenieSender_send(t *testing.T) {
+	tests := []struct {
+		name     string
+		firing   bool
+		wantPath string
+	}{
+		{name: "create", firing: true, wantPath: "/v2/alerts"},
+		{name: "close", firing: false, wantPath: "/v2/alerts/sparrow%2Fhealth%2Fhttps:%2F%2Fa.com/close"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath, gotAuth, gotIdentifier string
+			var got opsgenieAlert
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.EscapedPath()
+				gotAuth = r.Header.Get("Authorization")
+				gotIdentifier = r.URL.Query().Get("identifierType")
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer srv.Close()
+
+			o := newOpsgenieSender(Opsgenie{Name: "og", ApiKey: "key", Url: srv.URL + "/", Tags: []string{"sparrow"}})
+			err := o.send(context.Background(), alert{
+				Key:      dedupKey("health", "https://a.com"),
+				Check:    "health",
+				Target:   "https://a.com",
+				Instance: "sparrow.com",
+				Firing:   tt.firing,
+			})
+			if err != nil {
+				t.Fatalf("opsgenieSender.send() error = %v", err)
+			}
+
+			if gotPath != tt.wantPath {
+				t.Errorf("opsgenieSender.send() path = %q, want %q", gotPath, tt.wantPath)
+			}
+			if gotAuth != "GenieKey key" {
+				t.Errorf("opsgenieSender.send() authorization = %q, want %q", gotAuth, "GenieKey key")
+			}
+			if tt.firing {
+				if got.Alias != "sparrow/health/https://a.com" || got.Priority != defaultPriority || got.Entity != "https://a.com" {
+					t.Errorf("opsgenieSender.send() sent %+v", got)
+				}
+			} else if gotIdentifier != "alias" {
+				t.Errorf("opsgenieSender.send() identifierType = %q, want %q", gotIdentifier, "alias")
+			}
+		})
+	}
+}
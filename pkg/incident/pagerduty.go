@@ -0,0 +1,92 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// pagerDutyEvent is an event of the PagerDuty Events API v2
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+// pagerDutyPayload describes the incident of a triggered PagerDuty event
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component"`
+	Group         string            `json:"group"`
+	CustomDetails map[string]string `json:"custom_details"`
+}
+
+// pagerDutySender triggers and resolves PagerDuty incidents
+type pagerDutySender struct {
+	cfg    PagerDuty
+	client *http.Client
+}
+
+// newPagerDutySender creates a new pagerDutySender with the defaults applied to the configuration
+func newPagerDutySender(cfg PagerDuty) *pagerDutySender {
+	if cfg.Url == "" {
+		cfg.Url = defaultPagerDutyUrl
+	}
+	if cfg.Severity == "" {
+		cfg.Severity = defaultSeverity
+	}
+	return &pagerDutySender{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// send triggers the incident of a failed target or resolves it once the target recovered.
+// Triggering an open incident with the same dedup key updates it instead of opening another one.
+func (p *pagerDutySender) send(ctx context.Context, a alert) error {
+	event := pagerDutyEvent{
+		RoutingKey:  p.cfg.RoutingKey,
+		EventAction: "resolve",
+		DedupKey:    a.Key,
+	}
+	if a.Firing {
+		event.EventAction = "trigger"
+		event.Payload = &pagerDutyPayload{
+			Summary:   a.summary(),
+			Source:    a.Instance,
+			Severity:  p.cfg.Severity,
+			Component: a.Target,
+			Group:     a.Check,
+			CustomDetails: map[string]string{
+				"check":  a.Check,
+				"target": a.Target,
+			},
+		}
+		if !a.Timestamp.IsZero() {
+			event.Payload.Timestamp = a.Timestamp.Format(time.RFC3339)
+		}
+		if event.Payload.Source == "" {
+			event.Payload.Source = "sparrow"
+		}
+	}
+	return post(ctx, p.client, p.cfg.Url, nil, event)
+}
@@ -0,0 +1,86 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPagerDutySender_send(t *testing.T) {
+	tests := []struct {
+		name       string
+		firing     bool
+		wantAction string
+	}{
+		{name: "trigger", firing: true, wantAction: "trigger"},
+		{name: "resolve", firing: false, wantAction: "resolve"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got pagerDutyEvent
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer srv.Close()
+
+			p := newPagerDutySender(PagerDuty{Name: "pd", RoutingKey: "key", Url: srv.URL})
+			err := p.send(context.Background(), alert{
+				Key:       dedupKey("health", "https://a.com"),
+				Check:     "health",
+				Target:    "https://a.com",
+				Instance:  "sparrow.com",
+				Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Firing:    tt.firing,
+			})
+			if err != nil {
+				t.Fatalf("pagerDutySender.send() error = %v", err)
+			}
+
+			if got.RoutingKey != "key" || got.EventAction != tt.wantAction || got.DedupKey != "sparrow/health/https://a.com" {
+				t.Errorf("pagerDutySender.send() sent %+v", got)
+			}
+			if tt.firing {
+				if got.Payload == nil || got.Payload.Severity != defaultSeverity || got.Payload.Source != "sparrow.com" || got.Payload.Component != "https://a.com" {
+					t.Errorf("pagerDutySender.send() sent payload %+v", got.Payload)
+				}
+			} else if got.Payload != nil {
+				t.Errorf("pagerDutySender.send() sent payload %+v with resolve event", got.Payload)
+			}
+		})
+	}
+}
+
+func TestPagerDutySender_send_failure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	p := newPagerDutySender(PagerDuty{Name: "pd", RoutingKey: "key", Url: srv.URL})
+	if err := p.send(context.Background(), alert{Key: "key", Firing: true}); err == nil {
+		t.Error("pagerDutySender.send() error = nil, want error")
+	}
+}
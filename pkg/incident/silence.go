@@ -0,0 +1,163 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrEmptySelector is returned when a silence doesn't select any alerts
+	ErrEmptySelector = errors.New("silence needs a check, target or label selector")
+	// ErrInvalidDuration is returned when the duration of a silence is invalid
+	ErrInvalidDuration = errors.New("silence duration must be above 0")
+)
+
+// Silence suppresses the incidents of the alerts matching its selector until it expires.
+// All given selectors have to match.
+type Silence struct {
+	// ID identifies the silence
+	ID string `json:"id"`
+	// Check only matches the alerts of the check with the given name
+	Check string `json:"check,omitempty"`
+	// Target only matches the alerts of the given target
+	Target string `json:"target,omitempty"`
+	// Labels only matches the alerts with the given labels: check, target, instance or receiver
+	Labels map[string]string `json:"labels,omitempty"`
+	// Comment describes why the alerts are silenced
+	Comment string `json:"comment,omitempty"`
+	// StartsAt is the time the silence was created
+	StartsAt time.Time `json:"startsAt"`
+	// EndsAt is the time the silence expires
+	EndsAt time.Time `json:"endsAt"`
+}
+
+// matches returns true if the silence selects the alert with the given labels
+func (s *Silence) matches(labels map[string]string) bool {
+	if s.Check != "" && s.Check != labels["check"] {
+		return false
+	}
+	if s.Target != "" && s.Target != labels["target"] {
+		return false
+	}
+	for k, v := range s.Labels {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Silences stores the silences until they expire
+type Silences struct {
+	mu       sync.Mutex
+	silences map[string]Silence
+	now      func() time.Time
+}
+
+// NewSilences creates a new empty silence store
+func NewSilences() *Silences {
+	return &Silences{
+		silences: map[string]Silence{},
+		now:      time.Now,
+	}
+}
+
+// Add stores a new silence selecting the alerts of the given silence for the given duration.
+// It returns the stored silence with its ID and validity period.
+func (s *Silences) Add(silence Silence, duration time.Duration) (Silence, error) {
+	if silence.Check == "" && silence.Target == "" && len(silence.Labels) == 0 {
+		return Silence{}, ErrEmptySelector
+	}
+	if duration <= 0 {
+		return Silence{}, ErrInvalidDuration
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return Silence{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	silence.ID = hex.EncodeToString(id)
+	silence.StartsAt = s.now()
+	silence.EndsAt = silence.StartsAt.Add(duration)
+	s.silences[silence.ID] = silence
+	return silence, nil
+}
+
+// List returns the active silences sorted by their expiry
+func (s *Silences) List() []Silence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	silences := make([]Silence, 0, len(s.silences))
+	for _, silence := range s.silences {
+		silences = append(silences, silence)
+	}
+	slices.SortFunc(silences, func(a, b Silence) int {
+		return a.EndsAt.Compare(b.EndsAt)
+	})
+	return silences
+}
+
+// Delete removes the silence with the given ID.
+// It returns false if there's no active silence with the ID.
+func (s *Silences) Delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	if _, ok := s.silences[id]; !ok {
+		return false
+	}
+	delete(s.silences, id)
+	return true
+}
+
+// Silenced returns true if any active silence matches the alert with the given labels
+func (s *Silences) Silenced(labels map[string]string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	for _, silence := range s.silences {
+		if silence.matches(labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// expire removes the expired silences. The caller must hold the lock.
+func (s *Silences) expire() {
+	now := s.now()
+	for id, silence := range s.silences {
+		if !now.Before(silence.EndsAt) {
+			delete(s.silences, id)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package incident
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSilences_Add(t *testing.T) {
+	tests := []struct {
+		name     string
+		silence  Silence
+		duration time.Duration
+		wantErr  error
+	}{
+		{name: "check selector", silence: Silence{Check: "health"}, duration: time.Hour},
+		{name: "label selector", silence: Silence{Labels: map[string]string{"receiver": "pagerduty"}}, duration: time.Hour},
+		{name: "empty selector", silence: Silence{Comment: "all"}, duration: time.Hour, wantErr: ErrEmptySelector},
+		{name: "invalid duration", silence: Silence{Check: "health"}, duration: 0, wantErr: ErrInvalidDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSilences()
+			got, err := s.Add(tt.silence, tt.duration)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Silences.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.ID == "" || got.EndsAt.Sub(got.StartsAt) != tt.duration {
+				t.Errorf("Silences.Add() = %+v", got)
+			}
+			if l := s.List(); len(l) != 1 || l[0].ID != got.ID {
+				t.Errorf("Silences.List() = %+v, want the added silence", l)
+			}
+		})
+	}
+}
+
+func TestSilences_Silenced(t *testing.T) {
+	labels := map[string]string{"check": "health", "target": "https://a.com", "instance": "sparrow.com", "receiver": "pagerduty"}
+	tests := []struct {
+		name    string
+		silence Silence
+		want    bool
+	}{
+		{name: "matching check", silence: Silence{Check: "health"}, want: true},
+		{name: "matching check and target", silence: Silence{Check: "health", Target: "https://a.com"}, want: true},
+		{name: "other target", silence: Silence{Check: "health", Target: "https://b.com"}, want: false},
+		{name: "matching labels", silence: Silence{Labels: map[string]string{"receiver": "pagerduty", "instance": "sparrow.com"}}, want: true},
+		{name: "other label value", silence: Silence{Labels: map[string]string{"receiver": "opsgenie"}}, want: false},
+		{name: "unknown label", silence: Silence{Labels: map[string]string{"team": "network"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSilences()
+			if _, err := s.Add(tt.silence, time.Hour); err != nil {
+				t.Fatalf("Silences.Add() error = %v", err)
+			}
+			if got := s.Silenced(labels); got != tt.want {
+				t.Errorf("Silences.Silenced() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSilences_expire(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSilences()
+	s.now = func() time.Time { return now }
+
+	silence, err := s.Add(Silence{Check: "health"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Silences.Add() error = %v", err)
+	}
+	if !s.Silenced(map[string]string{"check": "health"}) {
+		t.Fatal("Silences.Silenced() = false, want true before expiry")
+	}
+
+	now = now.Add(time.Hour)
+	if s.Silenced(map[string]string{"check": "health"}) {
+		t.Error("Silences.Silenced() = true, want false after expiry")
+	}
+	if len(s.List()) != 0 {
+		t.Error("Silences.List() contains expired silence")
+	}
+	if s.Delete(silence.ID) {
+		t.Error("Silences.Delete() = true for expired silence")
+	}
+}
@@ -0,0 +1,280 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+var (
+	// ErrInvalidBrokers is returned when a receiver has no brokers or an invalid broker address
+	ErrInvalidBrokers = errors.New("invalid kafka receiver brokers")
+	// ErrMissingTopic is returned when a receiver has no topic
+	ErrMissingTopic = errors.New("missing kafka receiver topic")
+	// ErrInvalidKey is returned when the message key of a receiver is unknown
+	ErrInvalidKey = errors.New("invalid kafka receiver key")
+	// ErrInvalidPartitioner is returned when the partitioner of a receiver is unknown
+	ErrInvalidPartitioner = errors.New("invalid kafka receiver partitioner")
+	// ErrInvalidCompression is returned when the compression of a receiver is unknown
+	ErrInvalidCompression = errors.New("invalid kafka receiver compression")
+	// ErrInvalidSASL is returned when the sasl configuration of a receiver is invalid
+	ErrInvalidSASL = errors.New("invalid kafka receiver sasl configuration")
+	// ErrInvalidTLS is returned when the tls configuration of a receiver is invalid
+	ErrInvalidTLS = errors.New("invalid kafka receiver tls configuration")
+	// ErrInvalidTimeout is returned when the timeout of a receiver is invalid
+	ErrInvalidTimeout = errors.New("invalid kafka receiver timeout")
+)
+
+const (
+	// KeyCheck publishes a message per result keyed by the name of the check
+	KeyCheck = "check"
+	// KeyTarget publishes a message per target keyed by the target
+	KeyTarget = "target"
+)
+
+const (
+	// PartitionerHash assigns the messages with the same key to the same partition,
+	// compatible with the default partitioner of the Java client
+	PartitionerHash = "hash"
+	// PartitionerRoundRobin distributes the messages evenly across the partitions
+	PartitionerRoundRobin = "roundRobin"
+	// PartitionerLeastBytes assigns the messages to the partition that received the least data
+	PartitionerLeastBytes = "leastBytes"
+)
+
+const (
+	// CompressionNone publishes the messages uncompressed
+	CompressionNone = "none"
+	// CompressionGzip compresses the messages with gzip
+	CompressionGzip = "gzip"
+	// CompressionSnappy compresses the messages with snappy
+	CompressionSnappy = "snappy"
+)
+
+const (
+	// SASLPlain authenticates with the PLAIN mechanism
+	SASLPlain = "plain"
+	// SASLScramSHA256 authenticates with the SCRAM-SHA-256 mechanism
+	SASLScramSHA256 = "scram-sha-256"
+	// SASLScramSHA512 authenticates with the SCRAM-SHA-512 mechanism
+	SASLScramSHA512 = "scram-sha-512"
+)
+
+// Config is the configuration of the Kafka clusters the results are published to
+type Config struct {
+	// Receivers are the Kafka topics every result is published to
+	Receivers []Receiver `yaml:"receivers" mapstructure:"receivers"`
+}
+
+// Receiver publishes the results of the checks to a topic of a Kafka cluster
+type Receiver struct {
+	// Name is the unique name of the receiver
+	Name string `yaml:"name" mapstructure:"name"`
+	// Brokers are the host:port addresses of the brokers the cluster is bootstrapped from
+	Brokers []string `yaml:"brokers" mapstructure:"brokers"`
+	// Topic is the topic the messages are published to
+	Topic string `yaml:"topic" mapstructure:"topic"`
+	// Checks limits the results published to the receiver to the given checks.
+	// The results of all checks are published if empty.
+	Checks []string `yaml:"checks" mapstructure:"checks"`
+	// Key is the key of the messages: check or target. Defaults to check.
+	Key string `yaml:"key" mapstructure:"key"`
+	// Partitioner assigns the messages to the partitions of the topic: hash, roundRobin or leastBytes.
+	// Defaults to hash.
+	Partitioner string `yaml:"partitioner" mapstructure:"partitioner"`
+	// Compression is the compression of the messages: none, gzip or snappy. Defaults to none.
+	Compression string `yaml:"compression" mapstructure:"compression"`
+	// SASL is the authentication with the brokers
+	SASL SASLConfig `yaml:"sasl" mapstructure:"sasl"`
+	// TLS is the tls configuration of the connections to the brokers
+	TLS TLSConfig `yaml:"tls" mapstructure:"tls"`
+	// Timeout is the timeout of publishing the messages of a single result
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// SASLConfig is the authentication with the brokers
+type SASLConfig struct {
+	// Mechanism is the SASL mechanism: plain, scram-sha-256 or scram-sha-512. No authentication is used if empty.
+	Mechanism string `yaml:"mechanism" mapstructure:"mechanism"`
+	// Username is the username to authenticate with
+	Username string `yaml:"username" mapstructure:"username"`
+	// Password is the password to authenticate with
+	Password string `yaml:"password" mapstructure:"password"`
+}
+
+// TLSConfig is the tls configuration of the connections to the brokers
+type TLSConfig struct {
+	// Enabled connects to the brokers with tls
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// CAFile is the path of the PEM encoded certificates the brokers' certificates are verified with.
+	// The system's certificates are used if empty.
+	CAFile string `yaml:"caFile" mapstructure:"caFile"`
+	// CertFile is the path of the PEM encoded client certificate to authenticate with
+	CertFile string `yaml:"certFile" mapstructure:"certFile"`
+	// KeyFile is the path of the PEM encoded private key of the client certificate
+	KeyFile string `yaml:"keyFile" mapstructure:"keyFile"`
+	// Insecure skips the verification of the brokers' certificates
+	Insecure bool `yaml:"insecure" mapstructure:"insecure"`
+}
+
+// Enabled returns true if any receiver is configured
+func (c *Config) Enabled() bool {
+	return len(c.Receivers) > 0
+}
+
+// Validate validates the kafka configuration
+func (c *Config) Validate(ctx context.Context) error {
+	names := make([]string, 0, len(c.Receivers))
+	for _, r := range c.Receivers {
+		names = append(names, r.Name)
+	}
+	if err := notifier.ValidateNames(ctx, "kafka", names); err != nil {
+		return err
+	}
+
+	for i := range c.Receivers {
+		if err := c.Receivers[i].Validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the receiver configuration
+func (r *Receiver) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx).With("name", r.Name)
+
+	if len(r.Brokers) == 0 {
+		log.Error("The kafka receiver needs at least one broker")
+		return ErrInvalidBrokers
+	}
+	for _, b := range r.Brokers {
+		if host, port, err := net.SplitHostPort(b); err != nil || host == "" || port == "" {
+			log.Error("The kafka receiver broker is not a valid host:port address", "broker", b)
+			return ErrInvalidBrokers
+		}
+	}
+	if r.Topic == "" {
+		log.Error("The kafka receiver topic cannot be empty")
+		return ErrMissingTopic
+	}
+	if !slices.Contains([]string{"", KeyCheck, KeyTarget}, r.Key) {
+		log.Error("The kafka receiver key is unknown", "key", r.Key)
+		return ErrInvalidKey
+	}
+	if _, err := r.balancer(); err != nil {
+		log.Error("The kafka receiver partitioner is unknown", "partitioner", r.Partitioner)
+		return err
+	}
+	if _, err := r.compression(); err != nil {
+		log.Error("The kafka receiver compression is unknown", "compression", r.Compression)
+		return err
+	}
+	if _, err := r.SASL.mechanism(); err != nil {
+		log.Error("The kafka receiver sasl configuration is invalid", "error", err)
+		return err
+	}
+	if _, err := r.TLS.config(); err != nil {
+		log.Error("The kafka receiver tls configuration is invalid", "error", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTLS, err)
+	}
+	if r.Timeout < 0 {
+		log.Error("The kafka receiver timeout should be equal or above 0", "timeout", r.Timeout)
+		return ErrInvalidTimeout
+	}
+	return nil
+}
+
+// balancer returns the balancer assigning the messages to the partitions
+func (r *Receiver) balancer() (kafka.Balancer, error) {
+	switch r.Partitioner {
+	case "", PartitionerHash:
+		return kafka.Murmur2Balancer{}, nil
+	case PartitionerRoundRobin:
+		return &kafka.RoundRobin{}, nil
+	case PartitionerLeastBytes:
+		return &kafka.LeastBytes{}, nil
+	default:
+		return nil, ErrInvalidPartitioner
+	}
+}
+
+// compression returns the compression of the messages or zero if they're published uncompressed
+func (r *Receiver) compression() (kafka.Compression, error) {
+	switch r.Compression {
+	case "", CompressionNone:
+		return 0, nil
+	case CompressionGzip:
+		return kafka.Gzip, nil
+	case CompressionSnappy:
+		return kafka.Snappy, nil
+	default:
+		return 0, ErrInvalidCompression
+	}
+}
+
+// mechanism returns the SASL mechanism or nil if no authentication is used
+func (c *SASLConfig) mechanism() (sasl.Mechanism, error) {
+	if c.Mechanism == "" {
+		return nil, nil
+	}
+	if c.Username == "" {
+		return nil, fmt.Errorf("%w: missing username", ErrInvalidSASL)
+	}
+
+	switch c.Mechanism {
+	case SASLPlain:
+		return plain.Mechanism{Username: c.Username, Password: c.Password}, nil
+	case SASLScramSHA256, SASLScramSHA512:
+		algo := scram.SHA256
+		if c.Mechanism == SASLScramSHA512 {
+			algo = scram.SHA512
+		}
+		m, err := scram.Mechanism(algo, c.Username, c.Password)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSASL, err)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown mechanism %q", ErrInvalidSASL, c.Mechanism)
+	}
+}
+
+// config returns the tls configuration of the connections or nil if tls is disabled
+func (c *TLSConfig) config() (*tls.Config, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	return helper.LoadTLSConfig(c.CAFile, c.CertFile, c.KeyFile, c.Insecure)
+}
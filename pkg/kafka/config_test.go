@@ -0,0 +1,136 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func(mod func(r *Receiver)) Config {
+		r := Receiver{
+			Name:    "analytics",
+			Brokers: []string{"kafka-0.example.com:9092", "kafka-1.example.com:9092"},
+			Topic:   "sparrow-results",
+		}
+		mod(&r)
+		return Config{Receivers: []Receiver{r}}
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name:    "no receivers",
+			config:  Config{},
+			wantErr: nil,
+		},
+		{
+			name: "valid receiver",
+			config: valid(func(r *Receiver) {
+				r.Key = KeyTarget
+				r.Partitioner = PartitionerRoundRobin
+				r.Compression = CompressionGzip
+				r.SASL = SASLConfig{Mechanism: SASLScramSHA512, Username: "sparrow", Password: "secret"}
+				r.TLS = TLSConfig{Enabled: true}
+				r.Timeout = 10 * time.Second
+			}),
+			wantErr: nil,
+		},
+		{
+			name:    "missing name",
+			config:  valid(func(r *Receiver) { r.Name = "" }),
+			wantErr: notifier.ErrMissingName,
+		},
+		{
+			name: "duplicate name",
+			config: Config{Receivers: []Receiver{
+				valid(func(*Receiver) {}).Receivers[0],
+				valid(func(*Receiver) {}).Receivers[0],
+			}},
+			wantErr: notifier.ErrDuplicateName,
+		},
+		{
+			name:    "missing brokers",
+			config:  valid(func(r *Receiver) { r.Brokers = nil }),
+			wantErr: ErrInvalidBrokers,
+		},
+		{
+			name:    "broker without port",
+			config:  valid(func(r *Receiver) { r.Brokers = []string{"kafka.example.com"} }),
+			wantErr: ErrInvalidBrokers,
+		},
+		{
+			name:    "missing topic",
+			config:  valid(func(r *Receiver) { r.Topic = "" }),
+			wantErr: ErrMissingTopic,
+		},
+		{
+			name:    "unknown key",
+			config:  valid(func(r *Receiver) { r.Key = "instance" }),
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "unknown partitioner",
+			config:  valid(func(r *Receiver) { r.Partitioner = "random" }),
+			wantErr: ErrInvalidPartitioner,
+		},
+		{
+			name:    "unknown compression",
+			config:  valid(func(r *Receiver) { r.Compression = "lz4" }),
+			wantErr: ErrInvalidCompression,
+		},
+		{
+			name:    "unknown sasl mechanism",
+			config:  valid(func(r *Receiver) { r.SASL = SASLConfig{Mechanism: "oauthbearer", Username: "sparrow"} }),
+			wantErr: ErrInvalidSASL,
+		},
+		{
+			name:    "sasl without username",
+			config:  valid(func(r *Receiver) { r.SASL = SASLConfig{Mechanism: SASLPlain} }),
+			wantErr: ErrInvalidSASL,
+		},
+		{
+			name:    "missing ca file",
+			config:  valid(func(r *Receiver) { r.TLS = TLSConfig{Enabled: true, CAFile: "testdata/missing.pem"} }),
+			wantErr: ErrInvalidTLS,
+		},
+		{
+			name:    "negative timeout",
+			config:  valid(func(r *Receiver) { r.Timeout = -time.Second }),
+			wantErr: ErrInvalidTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
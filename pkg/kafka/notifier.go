@@ -0,0 +1,184 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/notifier"
+	"github.com/caas-team/sparrow/pkg/webhook"
+)
+
+// batchTimeout is the time the writer waits for further messages of a partition before it publishes them.
+// The messages of a result are written at once, so the writer doesn't need to wait for more.
+const batchTimeout = 10 * time.Millisecond
+
+// dialTimeout is the timeout of connecting to a broker if the receiver has no timeout
+const dialTimeout = 10 * time.Second
+
+// writer publishes messages to the topic of a receiver
+type writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// receiver is a configured kafka receiver with its writer
+type receiver struct {
+	Receiver
+	writer writer
+}
+
+// Notifier publishes the results of the checks to the configured Kafka topics
+type Notifier struct {
+	*notifier.Queue
+	receivers []*receiver
+}
+
+// NewNotifier creates a new Notifier for the given configuration.
+// It returns an error if the writer of a receiver can't be created from its configuration.
+func NewNotifier(cfg Config) (*Notifier, error) {
+	n := &Notifier{}
+	for _, r := range cfg.Receivers {
+		w, err := newWriter(r)
+		if err != nil {
+			return nil, err
+		}
+		n.receivers = append(n.receivers, &receiver{Receiver: r, writer: w})
+	}
+	n.Queue = notifier.NewQueue(n.send)
+	return n, nil
+}
+
+// Run publishes the queued results to the receivers until the context is canceled or the Notifier is shut down.
+// The writers of the receivers are closed when it returns.
+func (n *Notifier) Run(ctx context.Context) error {
+	defer n.close(ctx)
+	return n.Queue.Run(ctx)
+}
+
+// send publishes the result to the receivers accepting the results of its check
+func (n *Notifier) send(ctx context.Context, result checks.ResultDTO) {
+	log := logger.FromContext(ctx)
+	for _, r := range n.receivers {
+		if !notifier.Accepts(r.Checks, result.Name) {
+			continue
+		}
+		if err := r.publish(ctx, result); err != nil {
+			log.WarnContext(ctx, "Failed to publish result to kafka receiver", "receiver", r.Name, "check", result.Name, "error", err)
+		}
+	}
+}
+
+// close closes the writers of all receivers
+func (n *Notifier) close(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	for _, r := range n.receivers {
+		if err := r.writer.Close(); err != nil {
+			log.WarnContext(ctx, "Failed to close kafka writer", "receiver", r.Name, "error", err)
+		}
+	}
+}
+
+// publish publishes the messages of the result to the topic of the receiver
+func (r *receiver) publish(ctx context.Context, result checks.ResultDTO) error {
+	msgs, err := r.messages(webhook.NewPayload(result))
+	if err != nil {
+		return err
+	}
+
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+	return r.writer.WriteMessages(ctx, msgs...)
+}
+
+// messages returns the messages of the payload: a message per target keyed by the target if the receiver is keyed by target,
+// otherwise a single message keyed by the check. Results whose data isn't mapped by target are published as a single message.
+func (r *receiver) messages(p webhook.Payload) ([]kafka.Message, error) {
+	payloads := []webhook.TargetPayload{{Payload: p}}
+	if r.Key == KeyTarget {
+		if targets, ok := p.Targets(); ok {
+			payloads = targets
+		}
+	}
+
+	msgs := make([]kafka.Message, 0, len(payloads))
+	for _, t := range payloads {
+		value, err := json.Marshal(t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal message: %w", err)
+		}
+
+		key := t.Check
+		headers := []kafka.Header{{Key: "check", Value: []byte(t.Check)}}
+		if t.Target != "" {
+			key = t.Target
+			headers = append(headers, kafka.Header{Key: "target", Value: []byte(t.Target)})
+		}
+		msgs = append(msgs, kafka.Message{
+			Key:     []byte(key),
+			Value:   value,
+			Headers: headers,
+			Time:    t.Timestamp,
+		})
+	}
+	return msgs, nil
+}
+
+// newWriter creates the writer of the receiver.
+// It returns an error if the balancer, compression, SASL or tls configuration of the receiver is invalid.
+func newWriter(r Receiver) (writer, error) {
+	balancer, bErr := r.balancer()
+	compression, cErr := r.compression()
+	mechanism, sErr := r.SASL.mechanism()
+	tlsCfg, tErr := r.TLS.config()
+	if err := errors.Join(bErr, cErr, sErr, tErr); err != nil {
+		return nil, fmt.Errorf("invalid kafka receiver %q: %w", r.Name, err)
+	}
+
+	timeout := dialTimeout
+	if r.Timeout > 0 {
+		timeout = r.Timeout
+	}
+
+	return &kafka.Writer{
+		Addr:         kafka.TCP(r.Brokers...),
+		Topic:        r.Topic,
+		Balancer:     balancer,
+		BatchTimeout: batchTimeout,
+		RequiredAcks: kafka.RequireAll,
+		Compression:  compression,
+		Transport: &kafka.Transport{
+			ClientID:    "sparrow",
+			DialTimeout: timeout,
+			TLS:         tlsCfg,
+			SASL:        mechanism,
+		},
+	}, nil
+}
@@ -0,0 +1,201 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// fakeWriter records the messages written to it
+type fakeWriter struct {
+	mu     sync.Mutex
+	msgs   []kafka.Message
+	cSent  chan struct{}
+	closed bool
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.msgs = append(w.msgs, msgs...)
+	w.cSent <- struct{}{}
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return nil
+}
+
+func TestNotifier_Run(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	health := checks.ResultDTO{Name: "health", Result: &checks.Result{
+		Instance:  "sparrow.example.com",
+		Timestamp: ts,
+		Data:      map[string]string{"https://b.example.com": "unhealthy", "https://a.example.com": "healthy"},
+	}}
+	dns := checks.ResultDTO{Name: "dns", Result: &checks.Result{Instance: "sparrow.example.com", Timestamp: ts, Data: 1}}
+
+	// message is the key, check header and value of a written message
+	type message struct {
+		key   string
+		check string
+		value string
+	}
+	tests := []struct {
+		name     string
+		receiver Receiver
+		results  []checks.ResultDTO
+		want     []message
+	}{
+		{
+			name:     "keyed by check",
+			receiver: Receiver{Name: "analytics"},
+			results:  []checks.ResultDTO{health, dns},
+			want: []message{
+				{
+					key:   "health",
+					check: "health",
+					value: `{"check":"health","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":{"https://a.example.com":"healthy","https://b.example.com":"unhealthy"}}`,
+				},
+				{
+					key:   "dns",
+					check: "dns",
+					value: `{"check":"dns","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":1}`,
+				},
+			},
+		},
+		{
+			name:     "filtered checks",
+			receiver: Receiver{Name: "analytics", Checks: []string{"dns"}},
+			results:  []checks.ResultDTO{health, dns},
+			want: []message{
+				{
+					key:   "dns",
+					check: "dns",
+					value: `{"check":"dns","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":1}`,
+				},
+			},
+		},
+		{
+			name:     "keyed by target",
+			receiver: Receiver{Name: "analytics", Key: KeyTarget},
+			results:  []checks.ResultDTO{health, dns},
+			want: []message{
+				{
+					key:   "https://a.example.com",
+					check: "health",
+					value: `{"check":"health","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":"healthy","target":"https://a.example.com"}`,
+				},
+				{
+					key:   "https://b.example.com",
+					check: "health",
+					value: `{"check":"health","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":"unhealthy","target":"https://b.example.com"}`,
+				},
+				{
+					key:   "dns",
+					check: "dns",
+					value: `{"check":"dns","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":1}`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &fakeWriter{cSent: make(chan struct{}, len(tt.results))}
+			n, err := NewNotifier(Config{Receivers: []Receiver{tt.receiver}})
+			if err != nil {
+				t.Fatalf("NewNotifier() error = %v", err)
+			}
+			n.receivers[0].writer = w
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cDone := make(chan struct{})
+			go func() {
+				_ = n.Run(ctx)
+				close(cDone)
+			}()
+			for _, r := range tt.results {
+				n.Submit(r)
+			}
+
+			for {
+				w.mu.Lock()
+				got := len(w.msgs)
+				w.mu.Unlock()
+				if got >= len(tt.want) {
+					break
+				}
+				select {
+				case <-w.cSent:
+				case <-time.After(time.Second):
+					t.Fatal("Notifier didn't publish the expected messages")
+				}
+			}
+			cancel()
+			<-cDone
+
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			if !w.closed {
+				t.Error("Notifier didn't close the writer")
+			}
+			if len(w.msgs) != len(tt.want) {
+				t.Fatalf("Notifier published %d messages, want %d", len(w.msgs), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := message{key: string(w.msgs[i].Key), check: string(w.msgs[i].Headers[0].Value), value: string(w.msgs[i].Value)}
+				if got != want {
+					t.Errorf("Notifier published %+v, want %+v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewNotifier_invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		receiver Receiver
+		wantErr  error
+	}{
+		{name: "unknown partitioner", receiver: Receiver{Name: "analytics", Partitioner: "sticky"}, wantErr: ErrInvalidPartitioner},
+		{name: "unknown compression", receiver: Receiver{Name: "analytics", Compression: "lz4"}, wantErr: ErrInvalidCompression},
+		{name: "sasl without username", receiver: Receiver{Name: "analytics", SASL: SASLConfig{Mechanism: SASLPlain}}, wantErr: ErrInvalidSASL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNotifier(Config{Receivers: []Receiver{tt.receiver}}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewNotifier() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/notifier"
+	"github.com/caas-team/sparrow/pkg/webhook"
+)
+
+var (
+	// ErrInvalidBroker is returned when the broker url of a receiver is invalid
+	ErrInvalidBroker = errors.New("invalid mqtt receiver broker url")
+	// ErrInvalidQoS is returned when the quality of service of a receiver is invalid
+	ErrInvalidQoS = errors.New("invalid mqtt receiver qos")
+	// ErrInvalidTopic is returned when the topic template of a receiver is invalid
+	ErrInvalidTopic = errors.New("invalid mqtt receiver topic")
+	// ErrInvalidTemplate is returned when the payload template of a receiver is invalid
+	ErrInvalidTemplate = errors.New("invalid mqtt receiver template")
+	// ErrInvalidTLS is returned when the tls configuration of a receiver is invalid
+	ErrInvalidTLS = errors.New("invalid mqtt receiver tls configuration")
+	// ErrInvalidTimeout is returned when the timeout of a receiver is invalid
+	ErrInvalidTimeout = errors.New("invalid mqtt receiver timeout")
+)
+
+const (
+	// defaultTopic is the topic template used if none is configured
+	defaultTopic = `sparrow/{{ .Instance }}/{{ .Check }}`
+	// defaultTargetTopic is the topic template used if none is configured and the results are published per target
+	defaultTargetTopic = `sparrow/{{ .Instance }}/{{ .Check }}/{{ urlquery .Target }}`
+	// defaultTemplate renders the result as plain JSON
+	defaultTemplate = `{{ json . }}`
+	// maxQoS is the highest quality of service level, exactly once
+	maxQoS = 2
+)
+
+// schemes are the supported schemes of the broker urls
+var schemes = []string{"tcp", "mqtt", "ssl", "tls", "mqtts", "ws", "wss"}
+
+// Config is the configuration of the MQTT brokers the results are published to
+type Config struct {
+	// Receivers are the MQTT brokers every result is published to
+	Receivers []Receiver `yaml:"receivers" mapstructure:"receivers"`
+}
+
+// Receiver publishes the results of the checks to a MQTT broker
+type Receiver struct {
+	// Name is the unique name of the receiver
+	Name string `yaml:"name" mapstructure:"name"`
+	// Broker is the URL of the broker, e.g. tls://mqtt.example.com:8883
+	Broker string `yaml:"broker" mapstructure:"broker"`
+	// ClientID is the client identifier of the connection. The broker assigns one if empty.
+	ClientID string `yaml:"clientId" mapstructure:"clientId"`
+	// Username is the username to authenticate with. No authentication is used if empty.
+	Username string `yaml:"username" mapstructure:"username"`
+	// Password is the password to authenticate with
+	Password string `yaml:"password" mapstructure:"password"`
+	// Checks limits the results published to the receiver to the given checks.
+	// The results of all checks are published if empty.
+	Checks []string `yaml:"checks" mapstructure:"checks"`
+	// PerTarget publishes the result of every target as its own message instead of a message per result
+	PerTarget bool `yaml:"perTarget" mapstructure:"perTarget"`
+	// Topic is the Go template rendering the topic of a message.
+	// Defaults to sparrow/<instance>/<check> and to sparrow/<instance>/<check>/<target> if published per target.
+	Topic string `yaml:"topic" mapstructure:"topic"`
+	// Template is the Go template rendering the payload of a message.
+	// Defaults to the result as JSON.
+	Template string `yaml:"template" mapstructure:"template"`
+	// QoS is the quality of service level of the messages: 0, 1 or 2
+	QoS int `yaml:"qos" mapstructure:"qos"`
+	// Retain makes the broker keep the last message of every topic for new subscribers
+	Retain bool `yaml:"retain" mapstructure:"retain"`
+	// TLS is the tls configuration of the connection to a broker with a tls, ssl, mqtts or wss URL
+	TLS TLSConfig `yaml:"tls" mapstructure:"tls"`
+	// Timeout is the timeout of connecting to the broker and publishing a single message
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// TLSConfig is the tls configuration of the connection to a broker
+type TLSConfig struct {
+	// CAFile is the path of the PEM encoded certificates the broker's certificate is verified with.
+	// The system's certificates are used if empty.
+	CAFile string `yaml:"caFile" mapstructure:"caFile"`
+	// CertFile is the path of the PEM encoded client certificate to authenticate with
+	CertFile string `yaml:"certFile" mapstructure:"certFile"`
+	// KeyFile is the path of the PEM encoded private key of the client certificate
+	KeyFile string `yaml:"keyFile" mapstructure:"keyFile"`
+	// Insecure skips the verification of the broker's certificate
+	Insecure bool `yaml:"insecure" mapstructure:"insecure"`
+}
+
+// Enabled returns true if any receiver is configured
+func (c *Config) Enabled() bool {
+	return len(c.Receivers) > 0
+}
+
+// Validate validates the mqtt configuration
+func (c *Config) Validate(ctx context.Context) error {
+	names := make([]string, 0, len(c.Receivers))
+	for _, r := range c.Receivers {
+		names = append(names, r.Name)
+	}
+	if err := notifier.ValidateNames(ctx, "mqtt", names); err != nil {
+		return err
+	}
+
+	for i := range c.Receivers {
+		if err := c.Receivers[i].Validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the receiver configuration
+func (r *Receiver) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx).With("name", r.Name)
+
+	u, err := url.Parse(r.Broker)
+	if err != nil || !slices.Contains(schemes, u.Scheme) || u.Host == "" {
+		log.Error("The mqtt receiver broker is not a valid url", "broker", r.Broker, "schemes", schemes)
+		return ErrInvalidBroker
+	}
+	if r.QoS < 0 || r.QoS > maxQoS {
+		log.Error("The mqtt receiver qos should be 0, 1 or 2", "qos", r.QoS)
+		return ErrInvalidQoS
+	}
+	if r.Timeout < 0 {
+		log.Error("The mqtt receiver timeout should be equal or above 0", "timeout", r.Timeout)
+		return ErrInvalidTimeout
+	}
+	if _, err = r.TLS.config(); err != nil {
+		log.Error("The mqtt receiver tls configuration is invalid", "error", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTLS, err)
+	}
+
+	topic, payload, err := r.templates()
+	if err != nil {
+		log.Error("The mqtt receiver template cannot be parsed", "error", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	// The templates are rendered once with an empty message to detect
+	// references to fields the message doesn't have
+	var b strings.Builder
+	if err = topic.Execute(&b, webhook.TargetPayload{Payload: webhook.Payload{Data: map[string]any{}}}); err != nil {
+		log.Error("The mqtt receiver topic cannot be rendered", "error", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTopic, err)
+	}
+	if strings.ContainsAny(b.String(), "+#") {
+		log.Error("The mqtt receiver topic cannot contain wildcards", "topic", r.Topic)
+		return ErrInvalidTopic
+	}
+	if err = payload.Execute(io.Discard, webhook.TargetPayload{Payload: webhook.Payload{Data: map[string]any{}}}); err != nil {
+		log.Error("The mqtt receiver template cannot be rendered", "error", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return nil
+}
+
+// templates parses the topic and payload templates of the receiver
+func (r *Receiver) templates() (topic, payload *template.Template, err error) {
+	text := r.Topic
+	if text == "" {
+		text = defaultTopic
+		if r.PerTarget {
+			text = defaultTargetTopic
+		}
+	}
+	topic, err = template.New(r.Name + "-topic").Funcs(webhook.Funcs).Parse(text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	text = r.Template
+	if text == "" {
+		text = defaultTemplate
+	}
+	payload, err = template.New(r.Name).Funcs(webhook.Funcs).Parse(text)
+	if err != nil {
+		return nil, nil, err
+	}
+	return topic, payload, nil
+}
+
+// config returns the tls configuration of the connection.
+// It returns nil if the system's defaults are used.
+func (c *TLSConfig) config() (*tls.Config, error) {
+	if c.CAFile == "" && c.CertFile == "" && c.KeyFile == "" && !c.Insecure {
+		return nil, nil
+	}
+	return helper.LoadTLSConfig(c.CAFile, c.CertFile, c.KeyFile, c.Insecure)
+}
@@ -0,0 +1,131 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func(mod func(r *Receiver)) Config {
+		r := Receiver{
+			Name:   "iot",
+			Broker: "tcp://mqtt.example.com:1883",
+		}
+		mod(&r)
+		return Config{Receivers: []Receiver{r}}
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name:    "no receivers",
+			config:  Config{},
+			wantErr: nil,
+		},
+		{
+			name: "valid receiver",
+			config: valid(func(r *Receiver) {
+				r.Broker = "tls://mqtt.example.com:8883"
+				r.PerTarget = true
+				r.Topic = `monitoring/{{ .Check }}/{{ urlquery .Target }}`
+				r.Template = `{{ json .Data }}`
+				r.QoS = 1
+				r.Retain = true
+				r.TLS = TLSConfig{Insecure: true}
+			}),
+			wantErr: nil,
+		},
+		{
+			name:    "missing name",
+			config:  valid(func(r *Receiver) { r.Name = "" }),
+			wantErr: notifier.ErrMissingName,
+		},
+		{
+			name: "duplicate name",
+			config: Config{Receivers: []Receiver{
+				valid(func(*Receiver) {}).Receivers[0],
+				valid(func(*Receiver) {}).Receivers[0],
+			}},
+			wantErr: notifier.ErrDuplicateName,
+		},
+		{
+			name:    "missing broker",
+			config:  valid(func(r *Receiver) { r.Broker = "" }),
+			wantErr: ErrInvalidBroker,
+		},
+		{
+			name:    "unknown broker scheme",
+			config:  valid(func(r *Receiver) { r.Broker = "https://mqtt.example.com" }),
+			wantErr: ErrInvalidBroker,
+		},
+		{
+			name:    "invalid qos",
+			config:  valid(func(r *Receiver) { r.QoS = 3 }),
+			wantErr: ErrInvalidQoS,
+		},
+		{
+			name:    "negative timeout",
+			config:  valid(func(r *Receiver) { r.Timeout = -time.Second }),
+			wantErr: ErrInvalidTimeout,
+		},
+		{
+			name:    "missing ca file",
+			config:  valid(func(r *Receiver) { r.TLS.CAFile = "testdata/missing.pem" }),
+			wantErr: ErrInvalidTLS,
+		},
+		{
+			name:    "client certificate without key",
+			config:  valid(func(r *Receiver) { r.TLS.CertFile = "testdata/missing.pem" }),
+			wantErr: ErrInvalidTLS,
+		},
+		{
+			name:    "topic with wildcard",
+			config:  valid(func(r *Receiver) { r.Topic = "sparrow/#" }),
+			wantErr: ErrInvalidTopic,
+		},
+		{
+			name:    "topic with unknown field",
+			config:  valid(func(r *Receiver) { r.Topic = `{{ .Host }}` }),
+			wantErr: ErrInvalidTopic,
+		},
+		{
+			name:    "invalid payload template",
+			config:  valid(func(r *Receiver) { r.Template = `{{ .Check ` }),
+			wantErr: ErrInvalidTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/notifier"
+	"github.com/caas-team/sparrow/pkg/webhook"
+)
+
+// disconnectQuiesce is the time the in-flight messages are given to complete on shutdown
+const disconnectQuiesce = 250 * time.Millisecond
+
+// receiver is a configured mqtt receiver with its parsed templates and connection
+type receiver struct {
+	Receiver
+	topic   *template.Template
+	payload *template.Template
+	tls     *tls.Config
+	// mu guards client
+	mu sync.Mutex
+	// client is the connection to the broker, established with the first message
+	client paho.Client
+}
+
+// publishFunc publishes a message to the broker of the receiver
+type publishFunc func(ctx context.Context, r *receiver, topic string, payload []byte) error
+
+// Notifier publishes the results of the checks to the configured MQTT brokers
+type Notifier struct {
+	*notifier.Queue
+	receivers []*receiver
+	publish   publishFunc
+}
+
+// NewNotifier creates a new Notifier for the given configuration.
+// It returns an error if a template or the tls configuration of a receiver is invalid.
+func NewNotifier(cfg Config) (*Notifier, error) {
+	n := &Notifier{
+		publish: publish,
+	}
+	for _, r := range cfg.Receivers {
+		topic, payload, err := r.templates()
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidTemplate, r.Name, err)
+		}
+		tlsCfg, err := r.TLS.config()
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidTLS, r.Name, err)
+		}
+		n.receivers = append(n.receivers, &receiver{
+			Receiver: r,
+			topic:    topic,
+			payload:  payload,
+			tls:      tlsCfg,
+		})
+	}
+	n.Queue = notifier.NewQueue(n.send)
+	return n, nil
+}
+
+// Run publishes the queued results to the receivers until the context is canceled or the Notifier is shut down.
+// The connections to the brokers are closed when it returns.
+func (n *Notifier) Run(ctx context.Context) error {
+	defer n.disconnect()
+	return n.Queue.Run(ctx)
+}
+
+// send publishes the result to the receivers accepting the results of its check
+func (n *Notifier) send(ctx context.Context, result checks.ResultDTO) {
+	log := logger.FromContext(ctx)
+	for _, r := range n.receivers {
+		if !notifier.Accepts(r.Checks, result.Name) {
+			continue
+		}
+		if err := n.notify(ctx, r, result); err != nil {
+			log.WarnContext(ctx, "Failed to publish result to mqtt receiver", "receiver", r.Name, "check", result.Name, "error", err)
+		}
+	}
+}
+
+// disconnect closes the connections of all receivers
+func (n *Notifier) disconnect() {
+	for _, r := range n.receivers {
+		r.mu.Lock()
+		if r.client != nil {
+			r.client.Disconnect(uint(disconnectQuiesce.Milliseconds()))
+			r.client = nil
+		}
+		r.mu.Unlock()
+	}
+}
+
+// notify renders the messages of the result and publishes them to the receiver
+func (n *Notifier) notify(ctx context.Context, r *receiver, result checks.ResultDTO) error {
+	var err error
+	for _, m := range r.messages(webhook.NewPayload(result)) {
+		topic, payload, rErr := r.render(m)
+		if rErr != nil {
+			err = errors.Join(err, rErr)
+			continue
+		}
+		err = errors.Join(err, n.publish(ctx, r, topic, payload))
+	}
+	return err
+}
+
+// messages returns the messages of the payload, one per target if the receiver publishes per target.
+// Results whose data isn't mapped by target are published as a single message.
+func (r *receiver) messages(p webhook.Payload) []webhook.TargetPayload {
+	if r.PerTarget {
+		if targets, ok := p.Targets(); ok {
+			return targets
+		}
+	}
+	return []webhook.TargetPayload{{Payload: p}}
+}
+
+// render renders the topic and payload of the message
+func (r *receiver) render(m webhook.TargetPayload) (topic string, payload []byte, err error) {
+	var t, p bytes.Buffer
+	if err = r.topic.Execute(&t, m); err != nil {
+		return "", nil, fmt.Errorf("failed to render topic: %w", err)
+	}
+	topic = strings.TrimSpace(t.String())
+	if topic == "" || strings.ContainsAny(topic, "+#") {
+		return "", nil, fmt.Errorf("%w: %q", ErrInvalidTopic, topic)
+	}
+	if err = r.payload.Execute(&p, m); err != nil {
+		return "", nil, fmt.Errorf("failed to render payload: %w", err)
+	}
+	return topic, p.Bytes(), nil
+}
+
+// publish publishes the message to the broker of the receiver, connecting to it if not connected yet
+func publish(ctx context.Context, r *receiver, topic string, payload []byte) error {
+	c, err := r.connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to broker: %w", err)
+	}
+	return wait(ctx, c.Publish(topic, byte(r.QoS), r.Retain, payload), r.Timeout) //nolint:gosec // QoS is validated
+}
+
+// connect returns the connection to the broker of the receiver.
+// It connects to the broker if there's no connection yet.
+func (r *receiver) connect(ctx context.Context) (paho.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != nil {
+		return r.client, nil
+	}
+
+	opts := paho.NewClientOptions().
+		AddBroker(r.Broker).
+		SetClientID(r.ClientID).
+		SetUsername(r.Username).
+		SetPassword(r.Password).
+		SetAutoReconnect(true).
+		SetCleanSession(true)
+	if r.tls != nil {
+		opts.SetTLSConfig(r.tls)
+	}
+	if r.Timeout > 0 {
+		opts.SetConnectTimeout(r.Timeout).SetWriteTimeout(r.Timeout)
+	}
+
+	c := paho.NewClient(opts)
+	if err := wait(ctx, c.Connect(), r.Timeout); err != nil {
+		c.Disconnect(0)
+		return nil, err
+	}
+	r.client = c
+	return c, nil
+}
+
+// wait waits until the token completes, the timeout elapses or the context is canceled
+func wait(ctx context.Context, token paho.Token, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
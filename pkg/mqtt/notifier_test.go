@@ -0,0 +1,189 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// published is a message captured by the fake publish function
+type published struct {
+	topic   string
+	payload string
+}
+
+func TestNotifier_Run(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	health := checks.ResultDTO{Name: "health", Result: &checks.Result{
+		Instance:  "sparrow.example.com",
+		Timestamp: ts,
+		Data:      map[string]string{"https://b.example.com": "unhealthy", "https://a.example.com": "healthy"},
+	}}
+	dns := checks.ResultDTO{Name: "dns", Result: &checks.Result{Instance: "sparrow.example.com", Timestamp: ts, Data: 1}}
+
+	tests := []struct {
+		name     string
+		receiver Receiver
+		results  []checks.ResultDTO
+		want     []published
+	}{
+		{
+			name:     "message per result",
+			receiver: Receiver{Name: "iot"},
+			results:  []checks.ResultDTO{health, dns},
+			want: []published{
+				{
+					topic:   "sparrow/sparrow.example.com/health",
+					payload: `{"check":"health","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":{"https://a.example.com":"healthy","https://b.example.com":"unhealthy"}}`,
+				},
+				{
+					topic:   "sparrow/sparrow.example.com/dns",
+					payload: `{"check":"dns","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":1}`,
+				},
+			},
+		},
+		{
+			name:     "filtered checks",
+			receiver: Receiver{Name: "iot", Checks: []string{"dns"}, Template: "{{ .Data }}"},
+			results:  []checks.ResultDTO{health, dns},
+			want:     []published{{topic: "sparrow/sparrow.example.com/dns", payload: "1"}},
+		},
+		{
+			name:     "message per target",
+			receiver: Receiver{Name: "iot", PerTarget: true},
+			results:  []checks.ResultDTO{health},
+			want: []published{
+				{
+					topic:   "sparrow/sparrow.example.com/health/https%3A%2F%2Fa.example.com",
+					payload: `{"check":"health","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":"healthy","target":"https://a.example.com"}`,
+				},
+				{
+					topic:   "sparrow/sparrow.example.com/health/https%3A%2F%2Fb.example.com",
+					payload: `{"check":"health","instance":"sparrow.example.com","timestamp":"2024-01-01T12:00:00Z","data":"unhealthy","target":"https://b.example.com"}`,
+				},
+			},
+		},
+		{
+			name:     "per target without targets",
+			receiver: Receiver{Name: "iot", PerTarget: true, Topic: "{{ .Check }}/{{ .Target }}", Template: "{{ .Data }}"},
+			results:  []checks.ResultDTO{dns},
+			want:     []published{{topic: "dns/", payload: "1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.receiver.Broker = "tcp://mqtt.example.com:1883"
+
+			var mu sync.Mutex
+			var got []published
+			cSent := make(chan struct{}, 10)
+			n, err := NewNotifier(Config{Receivers: []Receiver{tt.receiver}})
+			if err != nil {
+				t.Fatalf("NewNotifier() error = %v", err)
+			}
+			n.publish = func(_ context.Context, _ *receiver, topic string, payload []byte) error {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, published{topic: topic, payload: strings.TrimSpace(string(payload))})
+				cSent <- struct{}{}
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				_ = n.Run(ctx)
+			}()
+			for _, r := range tt.results {
+				n.Submit(r)
+			}
+
+			for range tt.want {
+				select {
+				case <-cSent:
+				case <-time.After(time.Second):
+					t.Fatal("Notifier didn't publish the expected messages")
+				}
+			}
+			// Wait for unexpected messages
+			select {
+			case <-cSent:
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Notifier published %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Notifier published %+v, want %+v", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNotifier_Run_connectionRefused(t *testing.T) {
+	n, err := NewNotifier(Config{Receivers: []Receiver{{
+		Name:    "iot",
+		Broker:  "tcp://127.0.0.1:1",
+		Timeout: time.Second,
+	}}})
+	if err != nil {
+		t.Fatalf("NewNotifier() error = %v", err)
+	}
+
+	r := n.receivers[0]
+	err = n.notify(context.Background(), r, checks.ResultDTO{Name: "dns", Result: &checks.Result{Data: 1}})
+	if err == nil {
+		t.Fatal("notify() error = nil, want connection error")
+	}
+	if r.client != nil {
+		t.Error("notify() kept the failed connection")
+	}
+}
+
+func TestNewNotifier_invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		receiver Receiver
+		wantErr  error
+	}{
+		{name: "invalid template", receiver: Receiver{Name: "iot", Topic: "{{ .Check "}, wantErr: ErrInvalidTemplate},
+		{name: "missing tls files", receiver: Receiver{Name: "iot", TLS: TLSConfig{CAFile: "missing.pem"}}, wantErr: ErrInvalidTLS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNotifier(Config{Receivers: []Receiver{tt.receiver}}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewNotifier() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
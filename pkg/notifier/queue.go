@@ -0,0 +1,127 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package notifier provides the bounded queue and the worker shared by the
+// notifiers sending the results of the checks to external systems.
+// A notifier only implements how a single result is sent to its receivers.
+package notifier
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// maxQueuedResults is the maximum amount of results waiting to be sent.
+// New results are dropped if the limit is exceeded.
+const maxQueuedResults = 100
+
+var (
+	// ErrMissingName is returned when a receiver has no name
+	ErrMissingName = errors.New("missing receiver name")
+	// ErrDuplicateName is returned when multiple receivers have the same name
+	ErrDuplicateName = errors.New("duplicate receiver name")
+)
+
+// SendFunc sends a result to the receivers of a notifier.
+// It's responsible for logging the failures of the single receivers.
+type SendFunc func(ctx context.Context, result checks.ResultDTO)
+
+// Queue queues the results of the checks and sends them one after another
+// with the SendFunc of the notifier
+type Queue struct {
+	send    SendFunc
+	cResult chan checks.ResultDTO
+	done    chan struct{}
+}
+
+// NewQueue creates a new Queue sending the results with the given function
+func NewQueue(send SendFunc) *Queue {
+	return &Queue{
+		send:    send,
+		cResult: make(chan checks.ResultDTO, maxQueuedResults),
+		done:    make(chan struct{}, 1),
+	}
+}
+
+// Submit queues a result to be sent.
+// The result is dropped if the queue is full.
+func (q *Queue) Submit(result checks.ResultDTO) {
+	if result.Result == nil {
+		return
+	}
+	select {
+	case q.cResult <- result:
+	default:
+	}
+}
+
+// Pending returns the amount of queued results not sent yet
+func (q *Queue) Pending() int {
+	return len(q.cResult)
+}
+
+// Run sends the queued results until the context is canceled or the Queue is shut down
+func (q *Queue) Run(ctx context.Context) error {
+	for {
+		select {
+		case result := <-q.cResult:
+			q.send(ctx, result)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-q.done:
+			return nil
+		}
+	}
+}
+
+// Shutdown stops the Queue
+func (q *Queue) Shutdown(_ context.Context) {
+	select {
+	case q.done <- struct{}{}:
+	default:
+	}
+}
+
+// Accepts returns true if a receiver limited to the given checks accepts the results of the check.
+// A receiver without any checks accepts the results of all checks.
+func Accepts(checks []string, check string) bool {
+	return len(checks) == 0 || slices.Contains(checks, check)
+}
+
+// ValidateNames validates that the receivers of the given kind have unique names
+func ValidateNames(ctx context.Context, kind string, names []string) error {
+	log := logger.FromContext(ctx)
+
+	seen := map[string]struct{}{}
+	for i, name := range names {
+		if name == "" {
+			log.Error("The "+kind+" receiver name cannot be empty", "index", i)
+			return ErrMissingName
+		}
+		if _, ok := seen[name]; ok {
+			log.Error("The "+kind+" receiver name must be unique", "name", name)
+			return ErrDuplicateName
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
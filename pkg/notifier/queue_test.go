@@ -0,0 +1,116 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestQueue_Run(t *testing.T) {
+	cSent := make(chan string, 1)
+	q := NewQueue(func(_ context.Context, result checks.ResultDTO) {
+		cSent <- result.Name
+	})
+
+	q.Submit(checks.ResultDTO{Name: "empty"})
+	q.Submit(checks.ResultDTO{Name: "health", Result: &checks.Result{}})
+	if got := q.Pending(); got != 1 {
+		t.Fatalf("Queue.Pending() = %d, want 1 without the empty result", got)
+	}
+
+	cDone := make(chan error, 1)
+	go func() {
+		cDone <- q.Run(context.Background())
+	}()
+
+	select {
+	case got := <-cSent:
+		if got != "health" {
+			t.Errorf("Queue sent result of %q, want %q", got, "health")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Queue didn't send the result")
+	}
+
+	q.Shutdown(context.Background())
+	select {
+	case err := <-cDone:
+		if err != nil {
+			t.Errorf("Queue.Run() error = %v, want nil after shutdown", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Queue.Run() didn't return after shutdown")
+	}
+}
+
+func TestQueue_Submit_full(t *testing.T) {
+	q := NewQueue(func(context.Context, checks.ResultDTO) {})
+	for range maxQueuedResults + 1 {
+		q.Submit(checks.ResultDTO{Name: "health", Result: &checks.Result{}})
+	}
+	if got := q.Pending(); got != maxQueuedResults {
+		t.Errorf("Queue.Pending() = %d, want the queue limited to %d", got, maxQueuedResults)
+	}
+}
+
+func TestAccepts(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []string
+		check  string
+		want   bool
+	}{
+		{name: "all checks", checks: nil, check: "health", want: true},
+		{name: "listed check", checks: []string{"dns", "health"}, check: "health", want: true},
+		{name: "unlisted check", checks: []string{"dns"}, check: "health", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Accepts(tt.checks, tt.check); got != tt.want {
+				t.Errorf("Accepts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr error
+	}{
+		{name: "unique names", names: []string{"slack", "teams"}},
+		{name: "missing name", names: []string{"slack", ""}, wantErr: ErrMissingName},
+		{name: "duplicate name", names: []string{"slack", "slack"}, wantErr: ErrDuplicateName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateNames(context.Background(), "webhook", tt.names); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateNames() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+// Package oauth acquires access tokens with the OAuth2 client credentials grant
+package oauth
+
+import (
+	"errors"
+	"net/url"
+)
+
+var (
+	// ErrInvalidTokenURL is returned when the token url is not a valid http(s) url
+	ErrInvalidTokenURL = errors.New("invalid oauth2 token url")
+	// ErrMissingClientID is returned when no client id is configured
+	ErrMissingClientID = errors.New("missing oauth2 client id")
+)
+
+// Config is the configuration of the OAuth2 client credentials grant
+type Config struct {
+	// TokenURL is the token endpoint of the authorization server
+	TokenURL string `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty" mapstructure:"tokenUrl"`
+	// ClientID is the id of the client
+	ClientID string `json:"clientId,omitempty" yaml:"clientId,omitempty" mapstructure:"clientId"`
+	// ClientSecret is the secret of the client
+	ClientSecret string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty" mapstructure:"clientSecret"`
+	// Scopes are the scopes requested for the access tokens
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty" mapstructure:"scopes"`
+}
+
+// Enabled returns true if a token endpoint is configured
+func (c *Config) Enabled() bool {
+	return c.TokenURL != ""
+}
+
+// Validate validates the OAuth2 configuration
+func (c *Config) Validate() error {
+	u, err := url.ParseRequestURI(c.TokenURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return ErrInvalidTokenURL
+	}
+	if c.ClientID == "" {
+		return ErrMissingClientID
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package oauth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			config:  Config{TokenURL: "https://idp.example.com/token", ClientID: "sparrow", ClientSecret: "secret", Scopes: []string{"config.read"}},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid token url",
+			config:  Config{TokenURL: "idp.example.com/token", ClientID: "sparrow"},
+			wantErr: ErrInvalidTokenURL,
+		},
+		{
+			name:    "unsupported token url scheme",
+			config:  Config{TokenURL: "ftp://idp.example.com/token", ClientID: "sparrow"},
+			wantErr: ErrInvalidTokenURL,
+		},
+		{
+			name:    "missing client id",
+			config:  Config{TokenURL: "https://idp.example.com/token"},
+			wantErr: ErrMissingClientID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
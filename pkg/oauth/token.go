@@ -0,0 +1,177 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// expiryDelta is the time before their expiry tokens are refreshed,
+// so a token doesn't expire while a request is in flight
+const expiryDelta = 10 * time.Second
+
+// AcquireFunc acquires a new access token. It returns the token and its lifetime, 0 if it doesn't expire.
+type AcquireFunc func(ctx context.Context) (token string, lifetime time.Duration, err error)
+
+// TokenSource acquires access tokens with the client credentials grant or a custom AcquireFunc.
+// A token is cached and reused until it's about to expire.
+type TokenSource struct {
+	cfg     Config
+	client  *http.Client
+	acquire AcquireFunc
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// tokenResponse is the successful response of the token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// errorResponse is the error response of the token endpoint
+type errorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// NewTokenSource returns a token source requesting the tokens with the given client
+func NewTokenSource(cfg Config, client *http.Client) *TokenSource {
+	s := &TokenSource{
+		cfg:    cfg,
+		client: client,
+	}
+	s.acquire = s.clientCredentials
+	return s
+}
+
+// NewTokenSourceFunc returns a token source caching the tokens acquired with the function,
+// e.g. from the metadata endpoint of a cloud provider
+func NewTokenSourceFunc(acquire AcquireFunc) *TokenSource {
+	return &TokenSource{acquire: acquire}
+}
+
+// Token returns a valid access token, either the cached one or a newly acquired one
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Now().Add(expiryDelta).Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	token, lifetime, err := s.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiry = time.Time{}
+	if lifetime > 0 {
+		s.expiry = time.Now().Add(lifetime)
+	}
+	return s.token, nil
+}
+
+// Authorize sets the access token as bearer token of the request
+func (s *TokenSource) Authorize(ctx context.Context, req *http.Request) error {
+	token, err := s.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return nil
+}
+
+// clientCredentials acquires a new access token with the client credentials grant
+func (s *TokenSource) clientCredentials(ctx context.Context) (string, time.Duration, error) {
+	res, err := s.fetch(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	return res.AccessToken, time.Duration(res.ExpiresIn) * time.Second, nil
+}
+
+// fetch requests a new access token from the token endpoint
+func (s *TokenSource) fetch(ctx context.Context) (tokenResponse, error) {
+	log := logger.FromContext(ctx).With("tokenUrl", s.cfg.TokenURL)
+	var res tokenResponse
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return res, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.cfg.ClientID), url.QueryEscape(s.cfg.ClientSecret))
+
+	resp, err := s.client.Do(req) //nolint:bodyclose // Closed in defer below
+	if err != nil {
+		return res, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			log.Error("Failed to close response body", "error", err)
+		}
+	}(resp.Body)
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return res, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e errorResponse
+		if json.Unmarshal(b, &e) == nil && e.Error != "" {
+			return res, fmt.Errorf("token request failed, status is %s: %s %s", resp.Status, e.Error, e.Description)
+		}
+		return res, fmt.Errorf("token request failed, status is %s", resp.Status)
+	}
+
+	if err := json.Unmarshal(b, &res); err != nil {
+		return res, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if res.AccessToken == "" {
+		return res, errors.New("token response contains no access token")
+	}
+	if res.TokenType != "" && !strings.EqualFold(res.TokenType, "bearer") {
+		return res, fmt.Errorf("unsupported token type %q", res.TokenType)
+	}
+
+	log.Debug("Acquired access token", "expiresIn", res.ExpiresIn)
+	return res, nil
+}
@@ -0,0 +1,173 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTokenServer returns a token endpoint issuing tokens valid for the given seconds
+// and counting the issued tokens
+func newTokenServer(t *testing.T, expiresIn int, issued *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "sparrow" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if got := r.PostForm.Get("scope"); got != "config.read targets.read" {
+			t.Errorf("Scope = %q, want %q", got, "config.read targets.read")
+		}
+
+		n := issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+}
+
+func TestTokenSource_Token(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiresIn  int
+		secret     string
+		wantTokens []string
+		wantErr    bool
+	}{
+		{
+			name:       "cached until expiry",
+			expiresIn:  3600,
+			secret:     "secret",
+			wantTokens: []string{"token-1", "token-1"},
+		},
+		{
+			name:       "refreshed before expiry",
+			expiresIn:  5,
+			secret:     "secret",
+			wantTokens: []string{"token-1", "token-2"},
+		},
+		{
+			name:       "never expiring",
+			expiresIn:  0,
+			secret:     "secret",
+			wantTokens: []string{"token-1", "token-1"},
+		},
+		{
+			name:      "invalid client",
+			expiresIn: 3600,
+			secret:    "wrong",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issued atomic.Int32
+			srv := newTokenServer(t, tt.expiresIn, &issued)
+			defer srv.Close()
+
+			ts := NewTokenSource(Config{
+				TokenURL:     srv.URL,
+				ClientID:     "sparrow",
+				ClientSecret: tt.secret,
+				Scopes:       []string{"config.read", "targets.read"},
+			}, srv.Client())
+
+			if tt.wantErr {
+				if _, err := ts.Token(context.Background()); err == nil {
+					t.Error("TokenSource.Token() error = nil, want an error")
+				}
+				return
+			}
+
+			for i, want := range tt.wantTokens {
+				got, err := ts.Token(context.Background())
+				if err != nil {
+					t.Fatalf("TokenSource.Token() error = %v", err)
+				}
+				if got != want {
+					t.Errorf("TokenSource.Token() call %d = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenSource_Authorize(t *testing.T) {
+	var issued atomic.Int32
+	srv := newTokenServer(t, 3600, &issued)
+	defer srv.Close()
+
+	ts := NewTokenSource(Config{
+		TokenURL:     srv.URL,
+		ClientID:     "sparrow",
+		ClientSecret: "secret",
+		Scopes:       []string{"config.read", "targets.read"},
+	}, srv.Client())
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+	if err := ts.Authorize(context.Background(), req); err != nil {
+		t.Fatalf("TokenSource.Authorize() error = %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer token-1" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer token-1")
+	}
+}
+
+func TestNewTokenSourceFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		lifetime time.Duration
+		want     []string
+	}{
+		{name: "valid token is cached", lifetime: time.Hour, want: []string{"token-1", "token-1"}},
+		{name: "expiring token is refreshed", lifetime: expiryDelta, want: []string{"token-1", "token-2"}},
+		{name: "token without expiry is cached", want: []string{"token-1", "token-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var acquired int
+			ts := NewTokenSourceFunc(func(context.Context) (string, time.Duration, error) {
+				acquired++
+				return fmt.Sprintf("token-%d", acquired), tt.lifetime, nil
+			})
+
+			for i, want := range tt.want {
+				got, err := ts.Token(context.Background())
+				if err != nil {
+					t.Fatalf("TokenSource.Token() error = %v", err)
+				}
+				if got != want {
+					t.Errorf("TokenSource.Token() #%d = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package pushgateway
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// defaultJob is the job label of the pushed metrics if none is configured
+const defaultJob = "sparrow"
+
+// Config is the configuration for pushing the metrics to a Prometheus Pushgateway
+type Config struct {
+	// Enabled is a flag to enable or disable the push of the metrics
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Url is the URL of the Pushgateway
+	Url string `yaml:"url" mapstructure:"url"`
+	// Job is the job label of the pushed metrics. Defaults to "sparrow".
+	Job string `yaml:"job" mapstructure:"job"`
+	// Grouping are additional grouping labels of the pushed metrics.
+	// The metrics are grouped by the name of the sparrow as instance label unless it's overridden.
+	Grouping map[string]string `yaml:"grouping" mapstructure:"grouping"`
+	// Interval is the interval in which the metrics are pushed
+	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
+	// Timeout is the timeout of a single push
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+	// Username is the username of the basic authentication with the Pushgateway
+	Username string `yaml:"username" mapstructure:"username"`
+	// Password is the password of the basic authentication with the Pushgateway
+	Password string `yaml:"password" mapstructure:"password"`
+}
+
+// Validate validates the pushgateway configuration
+func (c *Config) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if _, err := url.ParseRequestURI(c.Url); err != nil {
+		log.Error("The pushgateway url is not a valid url", "url", c.Url)
+		return ErrInvalidUrl
+	}
+	if c.Interval <= 0 {
+		log.Error("The pushgateway push interval should be above 0", "interval", c.Interval)
+		return ErrInvalidInterval
+	}
+	for name := range c.Grouping {
+		if !model.LabelName(name).IsValid() || name == model.JobLabel {
+			log.Error("The pushgateway grouping label is not a valid label name", "label", name)
+			return ErrInvalidGrouping
+		}
+	}
+	return nil
+}
+
+// job returns the job label of the pushed metrics
+func (c *Config) job() string {
+	if c.Job == "" {
+		return defaultJob
+	}
+	return c.Job
+}
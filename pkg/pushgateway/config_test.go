@@ -0,0 +1,58 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package pushgateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{name: "valid", cfg: Config{Enabled: true, Url: "http://pushgateway:9091", Interval: time.Minute}},
+		{
+			name: "valid grouping",
+			cfg:  Config{Enabled: true, Url: "http://pushgateway:9091", Interval: time.Minute, Grouping: map[string]string{"zone": "eu-de-01"}},
+		},
+		{name: "invalid url", cfg: Config{Enabled: true, Url: "pushgateway", Interval: time.Minute}, wantErr: ErrInvalidUrl},
+		{name: "invalid interval", cfg: Config{Enabled: true, Url: "http://pushgateway:9091"}, wantErr: ErrInvalidInterval},
+		{
+			name:    "invalid grouping label",
+			cfg:     Config{Enabled: true, Url: "http://pushgateway:9091", Interval: time.Minute, Grouping: map[string]string{"availability-zone": "eu-de-01"}},
+			wantErr: ErrInvalidGrouping,
+		},
+		{
+			name:    "job as grouping label",
+			cfg:     Config{Enabled: true, Url: "http://pushgateway:9091", Interval: time.Minute, Grouping: map[string]string{"job": "other"}},
+			wantErr: ErrInvalidGrouping,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
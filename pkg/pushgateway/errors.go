@@ -0,0 +1,29 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package pushgateway
+
+import "errors"
+
+var (
+	// ErrInvalidUrl is returned when the pushgateway url is invalid
+	ErrInvalidUrl = errors.New("invalid pushgateway url")
+	// ErrInvalidInterval is returned when the push interval is invalid
+	ErrInvalidInterval = errors.New("invalid pushgateway push interval")
+	// ErrInvalidGrouping is returned when a grouping label is invalid
+	ErrInvalidGrouping = errors.New("invalid pushgateway grouping label")
+)
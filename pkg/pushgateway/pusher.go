@@ -0,0 +1,100 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package pushgateway
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/prometheus/common/model"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+// Pusher pushes the gathered metrics to a Prometheus Pushgateway in the configured interval.
+// It complements the metrics endpoint of the API for instances that can't be scraped.
+type Pusher struct {
+	cfg    Config
+	pusher *push.Pusher
+	done   chan struct{}
+}
+
+// NewPusher creates a new Pusher pushing the metrics of the gatherer.
+// The metrics are grouped by the given instance identity.
+func NewPusher(instance string, cfg Config, g prometheus.Gatherer) *Pusher { //nolint:gocritic // no performance concerns yet
+	p := push.New(cfg.Url, cfg.job()).
+		Gatherer(g).
+		Client(&http.Client{Timeout: cfg.Timeout})
+	if _, ok := cfg.Grouping[model.InstanceLabel]; !ok {
+		p = p.Grouping(model.InstanceLabel, instance)
+	}
+	for name, value := range cfg.Grouping {
+		p = p.Grouping(name, value)
+	}
+	if cfg.Username != "" {
+		p = p.BasicAuth(cfg.Username, cfg.Password)
+	}
+
+	return &Pusher{
+		cfg:    cfg,
+		pusher: p,
+		done:   make(chan struct{}, 1),
+	}
+}
+
+// Run pushes the metrics in the configured interval until the context is canceled or the Pusher is shut down
+func (p *Pusher) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.push(ctx); err != nil {
+				log.WarnContext(ctx, "Failed to push metrics to pushgateway, retrying in the next interval", "error", err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.done:
+			return nil
+		}
+	}
+}
+
+// Shutdown pushes the final metrics and stops the Pusher
+func (p *Pusher) Shutdown(ctx context.Context) error {
+	err := p.push(ctx)
+	select {
+	case p.done <- struct{}{}:
+	default:
+	}
+	return err
+}
+
+// push replaces the metrics of the grouping in the Pushgateway with the gathered metrics
+func (p *Pusher) push(ctx context.Context) error {
+	if err := p.pusher.PushContext(ctx); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).DebugContext(ctx, "Pushed metrics to pushgateway")
+	return nil
+}
@@ -0,0 +1,106 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package pushgateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPusher_Run(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		user, pass, _ := r.BasicAuth()
+		if r.Method != http.MethodPut || user != "sparrow" || pass != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "sparrow_test", Help: "Test gauge"})
+	gauge.Set(1)
+	reg.MustRegister(gauge)
+
+	p := NewPusher("sparrow.com", Config{
+		Url:      srv.URL,
+		Grouping: map[string]string{"zone": "eu"},
+		Interval: 10 * time.Millisecond,
+		Timeout:  time.Second,
+		Username: "sparrow",
+		Password: "secret",
+	}, reg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cErr := make(chan error, 1)
+	go func() {
+		cErr <- p.Run(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if err := p.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := <-cErr; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) < 2 {
+		t.Fatalf("Pushed %d times, want at least 2", len(paths))
+	}
+	for _, path := range paths {
+		if !strings.HasPrefix(path, "/metrics/job/sparrow/") || !strings.Contains(path, "/instance/sparrow.com") || !strings.Contains(path, "/zone/eu") {
+			t.Errorf("Pushed to %s, want the job and grouping labels in the path", path)
+		}
+	}
+	if !strings.Contains(bodies[len(bodies)-1], "sparrow_test") {
+		t.Errorf("Pushed body doesn't contain the gathered metrics")
+	}
+}
+
+func TestPusher_Shutdown_failure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	p := NewPusher("sparrow.com", Config{Url: srv.URL, Interval: time.Minute, Timeout: time.Second}, prometheus.NewRegistry())
+	if err := p.Shutdown(context.Background()); err == nil {
+		t.Error("Shutdown() expected an error")
+	}
+}
@@ -0,0 +1,156 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+const (
+	// DistributionUniform spreads the latency evenly between the minimum and maximum
+	DistributionUniform = "uniform"
+	// DistributionNormal spreads the latency normally around the middle of the minimum and maximum
+	DistributionNormal = "normal"
+
+	// FaultExpired serves a certificate that expired
+	FaultExpired = "expired"
+	// FaultWrongHost serves a certificate issued for another host
+	FaultWrongHost = "wrongHost"
+	// FaultSelfSigned serves a valid self-signed certificate, which isn't trusted by the clients
+	FaultSelfSigned = "selfSigned"
+)
+
+var (
+	// ErrMissingAddress is returned when no listening address is configured
+	ErrMissingAddress = errors.New("missing listening address")
+	// ErrInvalidPath is returned when the path of a target is invalid
+	ErrInvalidPath = errors.New("invalid target path")
+	// ErrDuplicatePath is returned when multiple targets have the same path
+	ErrDuplicatePath = errors.New("duplicate target path")
+	// ErrInvalidLatency is returned when the latency of a target is invalid
+	ErrInvalidLatency = errors.New("invalid target latency")
+	// ErrInvalidErrorRate is returned when the error rate of a target isn't within [0, 1]
+	ErrInvalidErrorRate = errors.New("invalid target error rate")
+	// ErrInvalidStatus is returned when the status code of a target is invalid
+	ErrInvalidStatus = errors.New("invalid target status code")
+	// ErrInvalidFault is returned when the TLS fault is unknown
+	ErrInvalidFault = errors.New("invalid tls fault")
+)
+
+// faults are the supported TLS faults
+var faults = []string{FaultExpired, FaultWrongHost, FaultSelfSigned}
+
+// Config is the configuration of the simulator serving simulated targets
+type Config struct {
+	// Address is the address the simulated targets are served on via http
+	Address string `yaml:"address" mapstructure:"address"`
+	// Tls is the configuration of the listener serving the simulated targets via https with a faulty certificate
+	Tls TLSConfig `yaml:"tls" mapstructure:"tls"`
+	// Seed is the seed of the random behaviors, so runs with the same seed behave the same
+	Seed uint64 `yaml:"seed" mapstructure:"seed"`
+	// Targets are the simulated targets
+	Targets []Target `yaml:"targets" mapstructure:"targets"`
+}
+
+// TLSConfig is the configuration of the listener serving the simulated targets with a faulty certificate
+type TLSConfig struct {
+	// Address is the address of the https listener. Disabled if empty.
+	Address string `yaml:"address" mapstructure:"address"`
+	// Fault is the fault of the served certificate: expired, wrongHost or selfSigned
+	Fault string `yaml:"fault" mapstructure:"fault"`
+}
+
+// Target is a simulated target
+type Target struct {
+	// Path is the path the target is served at
+	Path string `yaml:"path" mapstructure:"path"`
+	// Status is the status code of successful responses. Defaults to 200.
+	Status int `yaml:"status" mapstructure:"status"`
+	// ErrorRate is the share of requests answered with 500 Internal Server Error
+	ErrorRate float64 `yaml:"errorRate" mapstructure:"errorRate"`
+	// Latency is the delay before the target responds
+	Latency Latency `yaml:"latency" mapstructure:"latency"`
+}
+
+// Latency is the distribution of the delay before a target responds
+type Latency struct {
+	// Min is the minimum delay
+	Min time.Duration `yaml:"min" mapstructure:"min"`
+	// Max is the maximum delay. The delay is always Min if not set.
+	Max time.Duration `yaml:"max" mapstructure:"max"`
+	// Distribution is the distribution of the delay between Min and Max: uniform (default) or normal
+	Distribution string `yaml:"distribution" mapstructure:"distribution"`
+}
+
+// Validate validates the simulator configuration
+func (c *Config) Validate(ctx context.Context) (err error) {
+	log := logger.FromContext(ctx)
+
+	if c.Address == "" {
+		log.Error("The simulator listening address cannot be empty")
+		err = errors.Join(err, ErrMissingAddress)
+	}
+	if c.Tls.Address != "" && !slices.Contains(faults, c.Tls.Fault) {
+		log.Error("The simulator tls fault is unknown", "fault", c.Tls.Fault, "supported", faults)
+		err = errors.Join(err, ErrInvalidFault)
+	}
+
+	paths := map[string]struct{}{}
+	for i, t := range c.Targets {
+		if vErr := t.validate(); vErr != nil {
+			log.Error("The simulated target is invalid", "index", i, "path", t.Path, "error", vErr)
+			err = errors.Join(err, vErr)
+		}
+		if _, ok := paths[t.Path]; ok {
+			log.Error("The path of the simulated target is used multiple times", "path", t.Path)
+			err = errors.Join(err, fmt.Errorf("%w: %s", ErrDuplicatePath, t.Path))
+		}
+		paths[t.Path] = struct{}{}
+	}
+
+	return err
+}
+
+// validate validates the simulated target
+func (t *Target) validate() error {
+	if !strings.HasPrefix(t.Path, "/") {
+		return ErrInvalidPath
+	}
+	if t.Status != 0 && (t.Status < 100 || t.Status > 599) {
+		return ErrInvalidStatus
+	}
+	if t.ErrorRate < 0 || t.ErrorRate > 1 {
+		return ErrInvalidErrorRate
+	}
+	l := t.Latency
+	if l.Min < 0 || (l.Max != 0 && l.Max < l.Min) {
+		return ErrInvalidLatency
+	}
+	if l.Distribution != "" && l.Distribution != DistributionUniform && l.Distribution != DistributionNormal {
+		return ErrInvalidLatency
+	}
+	return nil
+}
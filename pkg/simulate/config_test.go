@@ -0,0 +1,65 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package simulate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "valid",
+			cfg: Config{Address: ":8090", Tls: TLSConfig{Address: ":8443", Fault: FaultExpired}, Targets: []Target{
+				{Path: "/", Status: 204, ErrorRate: 0.1, Latency: Latency{Min: time.Millisecond, Max: time.Second, Distribution: DistributionNormal}},
+			}},
+		},
+		{name: "missing address", cfg: Config{}, wantErr: ErrMissingAddress},
+		{name: "unknown fault", cfg: Config{Address: ":8090", Tls: TLSConfig{Address: ":8443", Fault: "broken"}}, wantErr: ErrInvalidFault},
+		{name: "relative path", cfg: Config{Address: ":8090", Targets: []Target{{Path: "health"}}}, wantErr: ErrInvalidPath},
+		{name: "duplicate path", cfg: Config{Address: ":8090", Targets: []Target{{Path: "/a"}, {Path: "/a"}}}, wantErr: ErrDuplicatePath},
+		{name: "invalid status", cfg: Config{Address: ":8090", Targets: []Target{{Path: "/", Status: 42}}}, wantErr: ErrInvalidStatus},
+		{name: "invalid error rate", cfg: Config{Address: ":8090", Targets: []Target{{Path: "/", ErrorRate: 1.5}}}, wantErr: ErrInvalidErrorRate},
+		{
+			name:    "max below min latency",
+			cfg:     Config{Address: ":8090", Targets: []Target{{Path: "/", Latency: Latency{Min: time.Second, Max: time.Millisecond}}}},
+			wantErr: ErrInvalidLatency,
+		},
+		{
+			name:    "unknown distribution",
+			cfg:     Config{Address: ":8090", Targets: []Target{{Path: "/", Latency: Latency{Distribution: "pareto"}}}},
+			wantErr: ErrInvalidLatency,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
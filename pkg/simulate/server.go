@@ -0,0 +1,187 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package simulate
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 30 * time.Second
+)
+
+// Server serves the simulated targets
+type Server struct {
+	cfg Config
+	// mu guards the random source, so the behaviors of a seed don't depend on the scheduling of the requests
+	mu  sync.Mutex
+	rnd *rand.Rand
+	// servers are the http and https servers of the simulator
+	servers []*http.Server
+}
+
+// NewServer creates a new simulator serving the configured targets.
+// A single healthy target at "/" is served if no targets are configured.
+func NewServer(cfg *Config) *Server {
+	c := *cfg
+	if len(c.Targets) == 0 {
+		c.Targets = []Target{{Path: "/"}}
+	}
+	return &Server{
+		cfg: c,
+		rnd: rand.New(rand.NewPCG(c.Seed, c.Seed)), // #nosec G404 // Reproducible behaviors are intended
+	}
+}
+
+// Handler returns the handler serving the simulated targets
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, t := range s.cfg.Targets {
+		mux.HandleFunc(t.Path, s.handleTarget(t))
+	}
+	return mux
+}
+
+// Run serves the simulated targets until the context is done
+func (s *Server) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	handler := s.Handler()
+
+	s.servers = append(s.servers, &http.Server{Addr: s.cfg.Address, Handler: handler, ReadHeaderTimeout: readHeaderTimeout})
+	if s.cfg.Tls.Address != "" {
+		cert, err := faultyCertificate(s.cfg.Tls.Fault, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to create the %s certificate: %w", s.cfg.Tls.Fault, err)
+		}
+		s.servers = append(s.servers, &http.Server{
+			Addr:              s.cfg.Tls.Address,
+			Handler:           handler,
+			ReadHeaderTimeout: readHeaderTimeout,
+			TLSConfig:         &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12},
+		})
+	}
+
+	cErr := make(chan error, len(s.servers))
+	for _, srv := range s.servers {
+		go func() {
+			var err error
+			if srv.TLSConfig != nil {
+				log.Info("Serving simulated targets", "addr", srv.Addr, "scheme", "https", "fault", s.cfg.Tls.Fault)
+				err = srv.ListenAndServeTLS("", "")
+			} else {
+				log.Info("Serving simulated targets", "addr", srv.Addr, "scheme", "http")
+				err = srv.ListenAndServe()
+			}
+			cErr <- err
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-cErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		log.Error("Failed to serve simulated targets", "error", err)
+		return fmt.Errorf("failed serving simulated targets: %w", err)
+	}
+}
+
+// Shutdown gracefully shuts down the servers of the simulator
+func (s *Server) Shutdown(ctx context.Context) error {
+	logger.FromContext(ctx).Info("Shutting down simulator")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	var err error
+	for _, srv := range s.servers {
+		err = errors.Join(err, srv.Shutdown(shutdownCtx))
+	}
+	return err
+}
+
+// handleTarget returns the handler simulating the behavior of the given target
+func (s *Server) handleTarget(t Target) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The ServeMux matches the subtree of a path ending with a slash,
+		// only the path itself is the target
+		if r.URL.Path != t.Path {
+			http.NotFound(w, r)
+			return
+		}
+
+		delay, fail := s.draw(t)
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(delay):
+		}
+
+		code := t.Status
+		if code == 0 {
+			code = http.StatusOK
+		}
+		if fail {
+			code = http.StatusInternalServerError
+		}
+		w.WriteHeader(code)
+		if _, err := w.Write([]byte(http.StatusText(code))); err != nil {
+			logger.FromContext(r.Context()).Error("Failed to write response", "error", err)
+		}
+	}
+}
+
+// draw draws the delay of the next response of the target and whether it fails
+func (s *Server) draw(t Target) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fail := t.ErrorRate > 0 && s.rnd.Float64() < t.ErrorRate
+	return t.Latency.draw(s.rnd), fail
+}
+
+// draw draws a delay of the latency distribution
+func (l Latency) draw(rnd *rand.Rand) time.Duration {
+	if l.Max <= l.Min {
+		return l.Min
+	}
+
+	span := float64(l.Max - l.Min)
+	var d float64
+	switch l.Distribution {
+	case DistributionNormal:
+		// 99.7% of the delays are within the bounds, the others are clamped
+		d = span/2 + rnd.NormFloat64()*span/6 //nolint:mnd // three standard deviations per half
+		d = min(max(d, 0), span)
+	default:
+		d = rnd.Float64() * span
+	}
+	return l.Min + time.Duration(d)
+}
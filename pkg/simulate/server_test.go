@@ -0,0 +1,113 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package simulate
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestServer_Handler(t *testing.T) {
+	s := NewServer(&Config{Targets: []Target{
+		{Path: "/healthy"},
+		{Path: "/created", Status: http.StatusCreated},
+		{Path: "/broken", ErrorRate: 1},
+		{Path: "/dir/"},
+	}})
+	h := s.Handler()
+
+	tests := []struct {
+		path     string
+		wantCode int
+	}{
+		{path: "/healthy", wantCode: http.StatusOK},
+		{path: "/created", wantCode: http.StatusCreated},
+		{path: "/broken", wantCode: http.StatusInternalServerError},
+		{path: "/dir/", wantCode: http.StatusOK},
+		{path: "/dir/sub", wantCode: http.StatusNotFound},
+		{path: "/unknown", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if w.Code != tt.wantCode {
+				t.Errorf("Handler() = %d, want %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServer_Handler_defaultTarget(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewServer(&Config{}).Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Errorf("Handler() = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestServer_draw_seed(t *testing.T) {
+	target := Target{Path: "/", ErrorRate: 0.5, Latency: Latency{Min: time.Millisecond, Max: time.Second}}
+	run := func(seed uint64) []time.Duration {
+		s := NewServer(&Config{Seed: seed})
+		var delays []time.Duration
+		for range 20 {
+			d, fail := s.draw(target)
+			if fail {
+				d = -d
+			}
+			delays = append(delays, d)
+		}
+		return delays
+	}
+
+	if !slices.Equal(run(42), run(42)) {
+		t.Error("draw() behaves differently with the same seed")
+	}
+	if slices.Equal(run(42), run(7)) {
+		t.Error("draw() behaves the same with different seeds")
+	}
+}
+
+func TestLatency_draw(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 1)) //nolint:gosec // test
+	tests := []struct {
+		name    string
+		latency Latency
+	}{
+		{name: "fixed", latency: Latency{Min: 10 * time.Millisecond}},
+		{name: "uniform", latency: Latency{Min: 10 * time.Millisecond, Max: 20 * time.Millisecond}},
+		{name: "normal", latency: Latency{Min: 10 * time.Millisecond, Max: 20 * time.Millisecond, Distribution: DistributionNormal}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 1000 {
+				d := tt.latency.draw(rnd)
+				upper := max(tt.latency.Max, tt.latency.Min)
+				if d < tt.latency.Min || d > upper {
+					t.Fatalf("draw() = %v, want within [%v, %v]", d, tt.latency.Min, upper)
+				}
+			}
+		})
+	}
+}
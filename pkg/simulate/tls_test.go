@@ -0,0 +1,74 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package simulate
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFaultyCertificate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		fault string
+	}{
+		{fault: FaultExpired},
+		{fault: FaultWrongHost},
+		{fault: FaultSelfSigned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fault, func(t *testing.T) {
+			cert, err := faultyCertificate(tt.fault, now)
+			if err != nil {
+				t.Fatalf("faultyCertificate() error = %v", err)
+			}
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				t.Fatalf("Failed to parse certificate: %v", err)
+			}
+
+			// Trusting the certificate itself isolates the fault from the missing trust of the self-signed certificate
+			roots := x509.NewCertPool()
+			roots.AddCert(leaf)
+			_, err = leaf.Verify(x509.VerifyOptions{DNSName: "localhost", Roots: roots, CurrentTime: now})
+
+			switch tt.fault {
+			case FaultExpired:
+				var invalid x509.CertificateInvalidError
+				if !errors.As(err, &invalid) || invalid.Reason != x509.Expired {
+					t.Errorf("Verify() error = %v, want an expired certificate", err)
+				}
+			case FaultWrongHost:
+				var host x509.HostnameError
+				if !errors.As(err, &host) {
+					t.Errorf("Verify() error = %v, want a hostname mismatch", err)
+				}
+			case FaultSelfSigned:
+				if err != nil {
+					t.Errorf("Verify() error = %v, want a valid certificate if trusted", err)
+				}
+				if _, err = leaf.Verify(x509.VerifyOptions{DNSName: "localhost", CurrentTime: now}); err == nil {
+					t.Error("Verify() accepted the self-signed certificate without trusting it")
+				}
+			}
+		})
+	}
+}
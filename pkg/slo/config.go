@@ -0,0 +1,160 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package slo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+)
+
+var (
+	// ErrMissingName is returned when an objective has no name
+	ErrMissingName = errors.New("missing slo name")
+	// ErrDuplicateName is returned when multiple objectives have the same name
+	ErrDuplicateName = errors.New("duplicate slo name")
+	// ErrMissingCheck is returned when an objective has no check
+	ErrMissingCheck = errors.New("missing slo check")
+	// ErrInvalidGoal is returned when the goal of an objective isn't between 0 and 1
+	ErrInvalidGoal = errors.New("invalid slo goal")
+	// ErrInvalidLatency is returned when the latency threshold of an objective is invalid
+	ErrInvalidLatency = errors.New("invalid slo latency")
+	// ErrInvalidResolution is returned when the resolution is invalid
+	ErrInvalidResolution = errors.New("invalid slo resolution")
+	// ErrInvalidWindow is returned when a window isn't a multiple of the resolution
+	ErrInvalidWindow = errors.New("invalid slo window")
+)
+
+const (
+	// defaultResolution is the width of the buckets the results are counted in if none is configured
+	defaultResolution = 5 * time.Minute
+)
+
+// defaultWindows are the windows the objectives are evaluated over if none are configured
+var defaultWindows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, 30 * 24 * time.Hour}
+
+// Config is the configuration of the service level objectives
+// evaluated from the results of the checks
+type Config struct {
+	// Objectives are the service level objectives of the checks
+	Objectives []Objective `yaml:"objectives" mapstructure:"objectives"`
+	// Windows are the windows the attainment and burn rate of the objectives are computed over.
+	// Defaults to 1h, 6h, 24h and 720h.
+	Windows []time.Duration `yaml:"windows" mapstructure:"windows"`
+	// Resolution is the width of the buckets the results are counted in.
+	// The windows have to be multiples of it. Defaults to 5m.
+	Resolution time.Duration `yaml:"resolution" mapstructure:"resolution"`
+}
+
+// Objective is a service level objective of the targets of a check
+type Objective struct {
+	// Name is the unique name of the objective
+	Name string `yaml:"name" mapstructure:"name"`
+	// Check is the name of the check whose results are evaluated
+	Check string `yaml:"check" mapstructure:"check"`
+	// Targets limits the objective to the given targets.
+	// All targets of the check are evaluated if empty.
+	Targets []string `yaml:"targets" mapstructure:"targets"`
+	// Goal is the ratio of good probes to reach, e.g. 0.999
+	Goal float64 `yaml:"goal" mapstructure:"goal"`
+	// Latency makes the objective a latency objective, in which a probe is only good if the
+	// target responded successfully within the threshold. Only supported by the latency check.
+	Latency time.Duration `yaml:"latency" mapstructure:"latency"`
+}
+
+// Enabled returns true if any objective is configured
+func (c *Config) Enabled() bool {
+	return len(c.Objectives) > 0
+}
+
+// resolution returns the configured resolution or the default one
+func (c *Config) resolution() time.Duration {
+	if c.Resolution == 0 {
+		return defaultResolution
+	}
+	return c.Resolution
+}
+
+// windows returns the configured windows or the default ones
+func (c *Config) windows() []time.Duration {
+	if len(c.Windows) == 0 {
+		return defaultWindows
+	}
+	return c.Windows
+}
+
+// Validate validates the slo configuration
+func (c *Config) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if c.Resolution < 0 {
+		log.Error("The slo resolution should be equal or above 0", "resolution", c.Resolution)
+		return ErrInvalidResolution
+	}
+	for _, w := range c.Windows {
+		if w < c.resolution() || w%c.resolution() != 0 {
+			log.Error("The slo window should be a multiple of the resolution", "window", w, "resolution", c.resolution())
+			return ErrInvalidWindow
+		}
+	}
+
+	names := map[string]struct{}{}
+	for i := range c.Objectives {
+		o := &c.Objectives[i]
+		if o.Name == "" {
+			log.Error("The slo name cannot be empty")
+			return ErrMissingName
+		}
+		if _, ok := names[o.Name]; ok {
+			log.Error("The slo name must be unique", "name", o.Name)
+			return ErrDuplicateName
+		}
+		names[o.Name] = struct{}{}
+		if err := o.Validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the objective
+func (o *Objective) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx).With("name", o.Name)
+
+	if o.Check == "" {
+		log.Error("The slo check cannot be empty")
+		return ErrMissingCheck
+	}
+	if o.Goal <= 0 || o.Goal >= 1 {
+		log.Error("The slo goal should be above 0 and below 1", "goal", o.Goal)
+		return ErrInvalidGoal
+	}
+	if o.Latency < 0 {
+		log.Error("The slo latency should be equal or above 0", "latency", o.Latency)
+		return ErrInvalidLatency
+	}
+	if o.Latency > 0 && o.Check != latency.CheckName {
+		log.Error("The slo latency is only supported by the latency check", "check", o.Check)
+		return ErrInvalidLatency
+	}
+	return nil
+}
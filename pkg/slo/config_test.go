@@ -0,0 +1,107 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package slo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name:    "no objectives",
+			config:  Config{},
+			wantErr: nil,
+		},
+		{
+			name: "valid objectives",
+			config: Config{
+				Objectives: []Objective{
+					{Name: "availability", Check: "health", Goal: 0.999},
+					{Name: "latency", Check: "latency", Goal: 0.99, Latency: 500 * time.Millisecond},
+				},
+				Windows:    []time.Duration{time.Hour, 30 * 24 * time.Hour},
+				Resolution: time.Minute,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "missing name",
+			config:  Config{Objectives: []Objective{{Check: "health", Goal: 0.9}}},
+			wantErr: ErrMissingName,
+		},
+		{
+			name: "duplicate name",
+			config: Config{Objectives: []Objective{
+				{Name: "slo", Check: "health", Goal: 0.9},
+				{Name: "slo", Check: "dns", Goal: 0.9},
+			}},
+			wantErr: ErrDuplicateName,
+		},
+		{
+			name:    "missing check",
+			config:  Config{Objectives: []Objective{{Name: "slo", Goal: 0.9}}},
+			wantErr: ErrMissingCheck,
+		},
+		{
+			name:    "goal of 1",
+			config:  Config{Objectives: []Objective{{Name: "slo", Check: "health", Goal: 1}}},
+			wantErr: ErrInvalidGoal,
+		},
+		{
+			name:    "missing goal",
+			config:  Config{Objectives: []Objective{{Name: "slo", Check: "health"}}},
+			wantErr: ErrInvalidGoal,
+		},
+		{
+			name:    "latency of the health check",
+			config:  Config{Objectives: []Objective{{Name: "slo", Check: "health", Goal: 0.9, Latency: time.Second}}},
+			wantErr: ErrInvalidLatency,
+		},
+		{
+			name:    "negative resolution",
+			config:  Config{Resolution: -time.Minute},
+			wantErr: ErrInvalidResolution,
+		},
+		{
+			name:    "window below the default resolution",
+			config:  Config{Windows: []time.Duration{time.Minute}},
+			wantErr: ErrInvalidWindow,
+		},
+		{
+			name:    "window not a multiple of the resolution",
+			config:  Config{Windows: []time.Duration{90 * time.Second}, Resolution: time.Minute},
+			wantErr: ErrInvalidWindow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package slo
+
+import (
+	"net/http"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/api"
+)
+
+// Routes returns the API routes exposing the attainment of the objectives
+func (e *Engine) Routes() []api.Route {
+	return []api.Route{
+		{
+			Path: "/v1/slos", Method: http.MethodGet,
+			Handler: e.HandleStatus,
+		},
+	}
+}
+
+// HandleStatus returns the attainment and burn rates of the objectives per target
+func (e *Engine) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	if err := api.NewEncoder(w, r).Encode(e.Status()); err != nil {
+		logger.FromContext(r.Context()).Error("Failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,54 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package slo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestEngine_HandleStatus(t *testing.T) {
+	now := time.Now()
+	e := NewEngine(Config{Objectives: []Objective{{Name: "availability", Check: "latency", Goal: 0.9}}})
+	e.now = func() time.Time { return now }
+	e.Submit(latencyResult(now, map[string]float64{"https://a.com": 0.1}))
+
+	router := chi.NewRouter()
+	for _, route := range e.Routes() {
+		router.MethodFunc(route.Method, route.Path, route.Handler)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/slos", http.NoBody))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /v1/slos status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got []Status
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(got) != 1 || got[0].Target != "https://a.com" || len(got[0].Windows) != len(defaultWindows) {
+		t.Errorf("GET /v1/slos = %+v, want the default windows of https://a.com", got)
+	}
+}
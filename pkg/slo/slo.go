@@ -0,0 +1,263 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package slo
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// bucket counts the probes of a target in a slot of the width of the resolution
+type bucket struct {
+	// slot is the index of the slot since the unix epoch, so stale buckets of the ring are detected
+	slot  int64
+	good  uint32
+	total uint32
+}
+
+// series are the buckets of a target covering the longest window as a ring
+type series []bucket
+
+// add counts a probe in the bucket of the given slot
+func (s series) add(slot int64, good bool) {
+	b := &s[slot%int64(len(s))]
+	if b.slot != slot {
+		*b = bucket{slot: slot}
+	}
+	b.total++
+	if good {
+		b.good++
+	}
+}
+
+// count returns the good and total probes in the given amount of slots up to the given slot
+func (s series) count(slot, slots int64) (good, total int) {
+	for _, b := range s {
+		if b.slot <= slot && b.slot > slot-slots {
+			good += int(b.good)
+			total += int(b.total)
+		}
+	}
+	return good, total
+}
+
+// Window is the attainment of an objective over a window
+type Window struct {
+	// Window is the duration of the window, e.g. 6h or 30d
+	Window string `json:"window"`
+	// Good is the amount of good probes in the window
+	Good int `json:"good"`
+	// Total is the amount of probes in the window
+	Total int `json:"total"`
+	// Attainment is the ratio of good probes in the window
+	Attainment float64 `json:"attainment"`
+	// BurnRate is the rate the error budget is consumed at in the window.
+	// A burn rate of 1 consumes exactly the error budget of the goal.
+	BurnRate float64 `json:"burnRate"`
+}
+
+// Status is the attainment of an objective for a target of its check
+type Status struct {
+	// Objective is the name of the objective
+	Objective string `json:"objective"`
+	// Check is the name of the check
+	Check string `json:"check"`
+	// Target is the target of the check
+	Target string `json:"target"`
+	// Goal is the ratio of good probes to reach
+	Goal float64 `json:"goal"`
+	// Windows are the windows with probes of the target
+	Windows []Window `json:"windows"`
+}
+
+// Engine computes the attainment and burn rates of the service level
+// objectives per target from the results of the checks
+type Engine struct {
+	mu         sync.Mutex
+	objectives []Objective
+	windows    []time.Duration
+	resolution time.Duration
+	// slots is the amount of buckets covering the longest window
+	slots int64
+	// series are the counted probes mapped by objective and target
+	series map[string]map[string]series
+	// now returns the current time
+	now func() time.Time
+
+	attainment *prometheus.Desc
+	burnRate   *prometheus.Desc
+}
+
+// NewEngine creates a new Engine for the given configuration
+func NewEngine(cfg Config) *Engine {
+	windows := slices.Clone(cfg.windows())
+	slices.Sort(windows)
+	res := cfg.resolution()
+
+	e := &Engine{
+		objectives: cfg.Objectives,
+		windows:    windows,
+		resolution: res,
+		slots:      int64(windows[len(windows)-1] / res),
+		series:     map[string]map[string]series{},
+		now:        time.Now,
+		attainment: prometheus.NewDesc(
+			"sparrow_slo_attainment",
+			"Ratio of good probes of a target in the window",
+			[]string{"slo", "check", "target", "window"}, nil,
+		),
+		burnRate: prometheus.NewDesc(
+			"sparrow_slo_burn_rate",
+			"Rate the error budget of a target is consumed at in the window, 1 consumes exactly the budget",
+			[]string{"slo", "check", "target", "window"}, nil,
+		),
+	}
+	for _, o := range cfg.Objectives {
+		e.series[o.Name] = map[string]series{}
+	}
+	return e
+}
+
+// Submit counts the probes of the targets in the result for the objectives of its check.
+// Results older than the longest window are ignored.
+func (e *Engine) Submit(result checks.ResultDTO) {
+	if result.Result == nil {
+		return
+	}
+	ts := result.Result.Timestamp
+	if ts.IsZero() {
+		ts = e.now()
+	}
+	slot := ts.UnixNano() / int64(e.resolution)
+	if slot <= e.slot()-e.slots {
+		return
+	}
+
+	var failures map[string]bool
+	var states map[string]checks.TargetState
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, o := range e.objectives {
+		if o.Check != result.Name {
+			continue
+		}
+		if failures == nil {
+			failures = runtime.TargetFailures(result.Name, result.Result.Data)
+		}
+		if o.Latency > 0 && states == nil {
+			states = latency.TargetStates(result.Result.Data)
+		}
+		for target, failed := range failures {
+			if len(o.Targets) > 0 && !slices.Contains(o.Targets, target) {
+				continue
+			}
+			good := !failed
+			if o.Latency > 0 {
+				good = good && states[target].Latency <= o.Latency
+			}
+			s, ok := e.series[o.Name][target]
+			if !ok {
+				s = make(series, e.slots)
+				e.series[o.Name][target] = s
+			}
+			s.add(slot, good)
+		}
+	}
+}
+
+// Status returns the attainment of the objectives for all targets with probes in the longest window,
+// sorted by objective and target. Targets without any probes in the longest window are dropped.
+func (e *Engine) Status() []Status {
+	slot := e.slot()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	statuses := []Status{}
+	for _, o := range e.objectives {
+		for target, s := range e.series[o.Name] {
+			st := Status{Objective: o.Name, Check: o.Check, Target: target, Goal: o.Goal, Windows: []Window{}}
+			for _, w := range e.windows {
+				good, total := s.count(slot, int64(w/e.resolution))
+				if total == 0 {
+					continue
+				}
+				attainment := float64(good) / float64(total)
+				st.Windows = append(st.Windows, Window{
+					Window:     formatWindow(w),
+					Good:       good,
+					Total:      total,
+					Attainment: attainment,
+					BurnRate:   (1 - attainment) / (1 - o.Goal),
+				})
+			}
+			if len(st.Windows) == 0 {
+				delete(e.series[o.Name], target)
+				continue
+			}
+			statuses = append(statuses, st)
+		}
+	}
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return cmp.Or(cmp.Compare(a.Objective, b.Objective), cmp.Compare(a.Target, b.Target))
+	})
+	return statuses
+}
+
+// Describe sends the descriptors of the slo metrics to the channel
+func (e *Engine) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.attainment
+	ch <- e.burnRate
+}
+
+// Collect sends the attainment and burn rates of the objectives to the channel
+func (e *Engine) Collect(ch chan<- prometheus.Metric) {
+	for _, st := range e.Status() {
+		for _, w := range st.Windows {
+			ch <- prometheus.MustNewConstMetric(e.attainment, prometheus.GaugeValue, w.Attainment, st.Objective, st.Check, st.Target, w.Window)
+			ch <- prometheus.MustNewConstMetric(e.burnRate, prometheus.GaugeValue, w.BurnRate, st.Objective, st.Check, st.Target, w.Window)
+		}
+	}
+}
+
+// slot returns the index of the current slot
+func (e *Engine) slot() int64 {
+	return e.now().UnixNano() / int64(e.resolution)
+}
+
+// formatWindow formats the window in the largest unit it's a multiple of, e.g. 30d instead of 720h0m0s
+func formatWindow(w time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}}
+	for _, u := range units {
+		if w%u.unit == 0 {
+			return strconv.FormatInt(int64(w/u.unit), 10) + u.name
+		}
+	}
+	return w.String()
+}
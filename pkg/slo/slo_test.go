@@ -0,0 +1,131 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package slo
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// latencyResult returns a latency check result with the given latencies in seconds.
+// Targets with a negative latency failed.
+func latencyResult(ts time.Time, latencies map[string]float64) checks.ResultDTO {
+	data := map[string]any{}
+	for target, l := range latencies {
+		if l < 0 {
+			data[target] = map[string]any{"code": 0, "error": "connection refused", "total": 0}
+			continue
+		}
+		data[target] = map[string]any{"code": 200, "total": l}
+	}
+	return checks.ResultDTO{Name: "latency", Result: &checks.Result{Data: data, Timestamp: ts}}
+}
+
+func TestEngine_Status(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := NewEngine(Config{
+		Objectives: []Objective{
+			{Name: "availability", Check: "latency", Goal: 0.9},
+			{Name: "fast", Check: "latency", Targets: []string{"https://a.com"}, Goal: 0.5, Latency: 100 * time.Millisecond},
+		},
+		Windows:    []time.Duration{time.Hour, 2 * time.Hour},
+		Resolution: time.Minute,
+	})
+	e.now = func() time.Time { return now }
+
+	// outside of both windows
+	e.Submit(latencyResult(now.Add(-3*time.Hour), map[string]float64{"https://a.com": -1, "https://b.com": 0.05}))
+	// only in the 2h window
+	e.Submit(latencyResult(now.Add(-90*time.Minute), map[string]float64{"https://a.com": -1, "https://b.com": 0.05}))
+	e.Submit(latencyResult(now.Add(-10*time.Minute), map[string]float64{"https://a.com": 0.2, "https://b.com": 0.05}))
+	e.Submit(latencyResult(now, map[string]float64{"https://a.com": 0.05, "https://b.com": 0.05}))
+	e.Submit(checks.ResultDTO{Name: "dns", Result: &checks.Result{Data: map[string]any{}, Timestamp: now}})
+
+	got := e.Status()
+	if len(got) != 3 {
+		t.Fatalf("Status() = %+v, want 3 statuses", got)
+	}
+
+	a := got[0]
+	if a.Objective != "availability" || a.Target != "https://a.com" || len(a.Windows) != 2 {
+		t.Fatalf("Status()[0] = %+v", a)
+	}
+	if w := a.Windows[0]; w.Window != "1h" || w.Good != 2 || w.Total != 2 || w.BurnRate != 0 {
+		t.Errorf("1h window = %+v, want 2 of 2 good probes", w)
+	}
+	if w := a.Windows[1]; w.Window != "2h" || w.Good != 2 || w.Total != 3 || math.Abs(w.BurnRate-10.0/3) > 1e-9 {
+		t.Errorf("2h window = %+v, want 2 of 3 good probes burning at 3.33", w)
+	}
+
+	if b := got[1]; b.Objective != "availability" || b.Target != "https://b.com" || b.Windows[1].Attainment != 1 {
+		t.Errorf("Status()[1] = %+v", b)
+	}
+
+	f := got[2]
+	if f.Objective != "fast" || f.Target != "https://a.com" {
+		t.Fatalf("Status()[2] = %+v", f)
+	}
+	if w := f.Windows[0]; w.Good != 1 || w.Total != 2 || w.BurnRate != 1 {
+		t.Errorf("latency 1h window = %+v, want 1 of 2 good probes burning at 1", w)
+	}
+
+	// the targets are dropped once their probes left the longest window
+	e.now = func() time.Time { return now.Add(3 * time.Hour) }
+	if got := e.Status(); len(got) != 0 {
+		t.Errorf("Status() = %+v, want no statuses", got)
+	}
+}
+
+func TestEngine_Collect(t *testing.T) {
+	now := time.Now()
+	e := NewEngine(Config{
+		Objectives: []Objective{{Name: "availability", Check: "latency", Goal: 0.5}},
+		Windows:    []time.Duration{time.Hour},
+	})
+	e.now = func() time.Time { return now }
+	e.Submit(latencyResult(now, map[string]float64{"https://a.com": -1}))
+
+	want := `
+# HELP sparrow_slo_burn_rate Rate the error budget of a target is consumed at in the window, 1 consumes exactly the budget
+# TYPE sparrow_slo_burn_rate gauge
+sparrow_slo_burn_rate{check="latency",slo="availability",target="https://a.com",window="1h"} 2
+`
+	if err := testutil.CollectAndCompare(e, strings.NewReader(want), "sparrow_slo_burn_rate"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFormatWindow(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * 24 * time.Hour:     "30d",
+		6 * time.Hour:           "6h",
+		90 * time.Minute:        "90m",
+		1500 * time.Millisecond: "1.5s",
+	}
+	for w, want := range tests {
+		if got := formatWindow(w); got != want {
+			t.Errorf("formatWindow(%v) = %q, want %q", w, got, want)
+		}
+	}
+}
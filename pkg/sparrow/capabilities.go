@@ -0,0 +1,99 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	capabilityRawICMP          = "raw_icmp"
+	capabilityUnprivilegedICMP = "unprivileged_icmp"
+)
+
+// DegradedCheck is a check that runs degraded because the process lacks a capability
+type DegradedCheck struct {
+	// Name is the name of the check
+	Name string `json:"name"`
+	// Reason describes what the check can't do and how to grant the capability
+	Reason string `json:"reason"`
+}
+
+// degradedChecks returns the checks running degraded with the capabilities of the process
+func degradedChecks(c checks.Capabilities) []DegradedCheck {
+	if c.RawICMP {
+		return []DegradedCheck{}
+	}
+	return []DegradedCheck{
+		{
+			Name:   pmtu.CheckName,
+			Reason: "raw ICMP sockets require CAP_NET_RAW, the path MTU can't be discovered",
+		},
+		{
+			Name:   traceroute.CheckName,
+			Reason: "raw ICMP sockets require CAP_NET_RAW, hops that don't accept TCP connections aren't resolved",
+		},
+	}
+}
+
+// newCapabilityCollectors creates the gauges reporting the capabilities of the process and the degraded checks
+func newCapabilityCollectors(c checks.Capabilities) []prometheus.Collector {
+	available := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sparrow_capability_available",
+			Help: "Whether the process has the capability required by some checks (1) or not (0).",
+		},
+		[]string{"capability"},
+	)
+	available.WithLabelValues(capabilityRawICMP).Set(checks.BoolToFloat(c.RawICMP))
+	available.WithLabelValues(capabilityUnprivilegedICMP).Set(checks.BoolToFloat(c.UnprivilegedICMP))
+
+	degraded := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sparrow_check_degraded",
+			Help: "Set to 1 for every check running degraded because the process lacks a capability.",
+		},
+		[]string{"check"},
+	)
+	for _, d := range degradedChecks(c) {
+		degraded.WithLabelValues(d.Name).Set(1)
+	}
+
+	return []prometheus.Collector{available, degraded}
+}
+
+// logCapabilities logs the checks running degraded with the capabilities of the process
+func logCapabilities(ctx context.Context, c checks.Capabilities) {
+	log := logger.FromContext(ctx)
+	degraded := degradedChecks(c)
+	if len(degraded) == 0 {
+		log.Debug("All capabilities required by the checks are available", "capabilities", c)
+		return
+	}
+	for _, d := range degraded {
+		log.Warn("Check will run degraded", "check", d.Name, "reason", d.Reason)
+	}
+	log.Warn("Grant the NET_RAW capability to run all checks, e.g. with securityContext.capabilities.add [\"NET_RAW\"] in Kubernetes")
+}
@@ -0,0 +1,84 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCapabilityCollectors(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities checks.Capabilities
+		want         string
+	}{
+		{
+			name:         "all capabilities",
+			capabilities: checks.Capabilities{RawICMP: true, UnprivilegedICMP: true},
+			want: `
+# HELP sparrow_capability_available Whether the process has the capability required by some checks (1) or not (0).
+# TYPE sparrow_capability_available gauge
+sparrow_capability_available{capability="raw_icmp"} 1
+sparrow_capability_available{capability="unprivileged_icmp"} 1
+`,
+		},
+		{
+			name:         "no raw icmp",
+			capabilities: checks.Capabilities{UnprivilegedICMP: true},
+			want: `
+# HELP sparrow_capability_available Whether the process has the capability required by some checks (1) or not (0).
+# TYPE sparrow_capability_available gauge
+sparrow_capability_available{capability="raw_icmp"} 0
+sparrow_capability_available{capability="unprivileged_icmp"} 1
+# HELP sparrow_check_degraded Set to 1 for every check running degraded because the process lacks a capability.
+# TYPE sparrow_check_degraded gauge
+sparrow_check_degraded{check="` + pmtu.CheckName + `"} 1
+sparrow_check_degraded{check="` + traceroute.CheckName + `"} 1
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := prometheus.NewRegistry()
+			reg.MustRegister(newCapabilityCollectors(tt.capabilities)...)
+
+			err := testutil.GatherAndCompare(reg, strings.NewReader(tt.want))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDegradedChecks(t *testing.T) {
+	assert.Empty(t, degradedChecks(checks.Capabilities{RawICMP: true}))
+
+	var names []string
+	for _, d := range degradedChecks(checks.Capabilities{}) {
+		names = append(names, d.Name)
+		assert.Contains(t, d.Reason, "CAP_NET_RAW")
+	}
+	assert.Equal(t, []string{pmtu.CheckName, traceroute.CheckName}, names)
+}
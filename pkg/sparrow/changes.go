@@ -0,0 +1,88 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// changeFilter passes only the results whose data differs from the last passed result of their check,
+// so stable environments don't flood the exporters with identical results
+type changeFilter struct {
+	// full is the interval in which a result is passed even if it's unchanged. Disabled if 0.
+	full time.Duration
+	// last are the last passed results mapped by the name of their check
+	last map[string]passedResult
+	// unchanged reports the number of unchanged results of every check that weren't passed
+	unchanged *prometheus.CounterVec
+}
+
+// passedResult is the fingerprint of a passed result
+type passedResult struct {
+	// digest is the hash of the result's data and schema version
+	digest [sha256.Size]byte
+	// at is the time the result was passed
+	at time.Time
+}
+
+// newChangeFilter creates a filter passing only changed results and every result after the full interval
+func newChangeFilter(full time.Duration) *changeFilter {
+	return &changeFilter{
+		full: full,
+		last: map[string]passedResult{},
+		unchanged: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "sparrow_check_results_unchanged_total",
+				Help: "Number of results of a check not exported, because they didn't change since the last exported result.",
+			},
+			[]string{"check"},
+		),
+	}
+}
+
+// Changed returns true if the result differs from the last passed result of its check beyond its timestamp
+// or the full interval elapsed since then. The result is recorded as passed if true is returned.
+// Results that can't be compared are always passed.
+func (f *changeFilter) Changed(result checks.ResultDTO, now time.Time) bool {
+	if result.Result == nil {
+		return true
+	}
+	b, err := json.Marshal(struct {
+		Data          any `json:"data"`
+		SchemaVersion int `json:"schemaVersion"`
+	}{result.Result.Data, result.Result.SchemaVersion})
+	if err != nil {
+		return true
+	}
+
+	digest := sha256.Sum256(b)
+	last, ok := f.last[result.Name]
+	if ok && last.digest == digest && (f.full == 0 || now.Sub(last.at) < f.full) {
+		f.unchanged.WithLabelValues(result.Name).Inc()
+		return false
+	}
+	f.last[result.Name] = passedResult{digest: digest, at: now}
+	return true
+}
@@ -0,0 +1,116 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+type recordingSubmitter struct {
+	results []checks.ResultDTO
+}
+
+func (r *recordingSubmitter) Submit(result checks.ResultDTO) {
+	r.results = append(r.results, result)
+}
+
+func TestChangeFilter_Changed(t *testing.T) {
+	start := time.Now()
+	result := func(status string, at time.Time) checks.ResultDTO {
+		return checks.ResultDTO{Name: "health", Result: &checks.Result{
+			Data:          map[string]string{"https://example.com": status},
+			Timestamp:     at,
+			SchemaVersion: 1,
+		}}
+	}
+
+	type step struct {
+		result checks.ResultDTO
+		now    time.Time
+		want   bool
+	}
+	tests := []struct {
+		name  string
+		full  time.Duration
+		steps []step
+	}{
+		{
+			name: "unchanged results are skipped",
+			steps: []step{
+				{result("healthy", start), start, true},
+				{result("healthy", start.Add(time.Minute)), start.Add(time.Minute), false},
+				{result("unhealthy", start.Add(2*time.Minute)), start.Add(2 * time.Minute), true},
+				{result("healthy", start.Add(3*time.Minute)), start.Add(3 * time.Minute), true},
+				{result("healthy", start.Add(time.Hour)), start.Add(time.Hour), false},
+			},
+		},
+		{
+			name: "unchanged results are passed after the full interval",
+			full: 5 * time.Minute,
+			steps: []step{
+				{result("healthy", start), start, true},
+				{result("healthy", start.Add(4*time.Minute)), start.Add(4 * time.Minute), false},
+				{result("healthy", start.Add(5*time.Minute)), start.Add(5 * time.Minute), true},
+				{result("healthy", start.Add(6*time.Minute)), start.Add(6 * time.Minute), false},
+			},
+		},
+		{
+			name: "results without data are passed",
+			steps: []step{
+				{checks.ResultDTO{Name: "health"}, start, true},
+				{checks.ResultDTO{Name: "health"}, start, true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newChangeFilter(tt.full)
+			for i, s := range tt.steps {
+				if got := f.Changed(s.result, s.now); got != s.want {
+					t.Errorf("Changed() of step %d = %v, want %v", i, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestChecksController_submit_changesOnly(t *testing.T) {
+	r, o := &recordingSubmitter{}, &recordingSubmitter{}
+	cc := &ChecksController{submitters: []resultSubmitter{r}, observers: []resultSubmitter{o}, changes: newChangeFilter(0)}
+
+	healthy := checks.ResultDTO{Name: "health", Result: &checks.Result{Data: map[string]string{"https://example.com": "healthy"}}}
+	cc.submit(healthy)
+	cc.submit(healthy)
+	cc.submit(checks.ResultDTO{Name: "dns", Result: &checks.Result{Data: map[string]string{"example.com": "ok"}}})
+
+	if len(r.results) != 2 {
+		t.Fatalf("submit() passed %d results, want 2", len(r.results))
+	}
+	if len(o.results) != 3 {
+		t.Errorf("submit() passed %d results to the observers, want 3", len(o.results))
+	}
+	if got := testutil.ToFloat64(cc.changes.unchanged.WithLabelValues("health")); got != 1 {
+		t.Errorf("Unchanged results of health = %v, want 1", got)
+	}
+}
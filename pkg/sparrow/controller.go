@@ -23,6 +23,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/caas-team/sparrow/internal/logger"
 	"github.com/caas-team/sparrow/pkg/checks"
@@ -31,6 +35,14 @@ import (
 	"github.com/caas-team/sparrow/pkg/factory"
 	"github.com/caas-team/sparrow/pkg/sparrow/metrics"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// labelTenant is the metric label containing the name of the tenant
+	labelTenant = "tenant"
+	// seriesPruneInterval is the interval in which the metric series of targets no longer configured are pruned
+	seriesPruneInterval = 5 * time.Minute
 )
 
 // ChecksController is responsible for managing checks.
@@ -41,28 +53,191 @@ type ChecksController struct {
 	cResult chan checks.ResultDTO
 	cErr    chan error
 	done    chan struct{}
+	// instance is the identity of the sparrow attached to every result
+	instance string
+	// tenant is the name of the tenant the checks belong to.
+	// It is empty for the checks of the default runtime configuration.
+	tenant string
+	// registerer is used to register the metric collectors of the checks
+	registerer prometheus.Registerer
+	// overflow reports the number of targets of every check exceeding the series limit
+	overflow *prometheus.GaugeVec
+	// duplicates reports the number of duplicate targets of every check collapsed into one target
+	duplicates *prometheus.GaugeVec
+	// submitters receive every result in addition to the database, e.g. to push it to a hub
+	submitters []resultSubmitter
+	// changes passes only the changed results to the submitters if set
+	changes *changeFilter
+	// observers receive every result regardless of the changes filter,
+	// e.g. to track the state of the targets over consecutive results
+	observers []resultSubmitter
+	// mu guards the runtime configurations used for the dependency gating
+	mu sync.Mutex
+	// cfg is the last reconciled runtime configuration
+	cfg runtime.Config
+	// gated is the runtime configuration currently applied to the checks,
+	// in which the targets of dependent checks are reduced by their dependencies
+	gated runtime.Config
+	// events are the recorded changes of the runtime configuration
+	events eventLog
+	// history keeps the latest probe outcomes of every target
+	history targetHistory
+	// runsMu guards the running checks
+	runsMu sync.Mutex
+	// runs are the running checks mapped by their name
+	runs map[string]checkRun
+	// onDemand are the numbers of the runs on demand of the checks mapped by their name, guarded by runsMu
+	onDemand map[string]int
+	// stopped are the configurations of the checks stopped by the watchdog mapped by their name.
+	// A stopped check is started again once its configuration changes.
+	stopped map[string]checks.Runtime
+	// hung reports the number of iterations of every check canceled by the watchdog
+	hung *prometheus.CounterVec
+	// generation counts the changed runtime configurations applied
+	generation prometheus.Counter
+	// lastApplied is the time the last changed runtime configuration was applied
+	lastApplied prometheus.Gauge
+	// targetInfo exposes the targets of the latest results
+	targetInfo *targetInfo
+}
+
+// resultSubmitter receives the results of the checks
+type resultSubmitter interface {
+	Submit(result checks.ResultDTO)
 }
 
 // NewChecksController creates a new ChecksController.
-func NewChecksController(dbase db.DB, m metrics.Provider) *ChecksController {
+// The instance is the identity of the sparrow, which is attached to the results of the checks.
+func NewChecksController(dbase db.DB, m metrics.Provider, instance string) *ChecksController {
 	return &ChecksController{
-		db:      dbase,
-		metrics: m,
-		checks:  runtime.Checks{},
-		cResult: make(chan checks.ResultDTO, 8), //nolint:mnd // Buffered channel to avoid blocking the checks
-		cErr:    make(chan error, 1),
-		done:    make(chan struct{}, 1),
+		db:          dbase,
+		metrics:     m,
+		instance:    instance,
+		registerer:  m.GetRegistry(),
+		overflow:    newSeriesOverflow(),
+		duplicates:  newTargetDuplicates(),
+		hung:        newHungIterations(),
+		generation:  newConfigGeneration(),
+		lastApplied: newConfigLastApplied(),
+		targetInfo:  newTargetInfo(dbase),
+		checks:      runtime.Checks{},
+		cResult:     make(chan checks.ResultDTO, 8), //nolint:mnd // Buffered channel to avoid blocking the checks
+		cErr:        make(chan error, 1),
+		done:        make(chan struct{}, 1),
 	}
 }
 
+// newSeriesOverflow creates the gauge reporting the number of targets
+// of every check exceeding the series limit
+func newSeriesOverflow() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sparrow_check_series_overflow",
+			Help: "Number of targets of a check whose metrics are aggregated into the \"other\" series because the series limit is exceeded.",
+		},
+		[]string{"check"},
+	)
+}
+
+// newConfigGeneration creates the counter of the changed runtime configurations applied
+func newConfigGeneration() prometheus.Counter {
+	return prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "sparrow_config_generation_total",
+			Help: "Number of changed runtime configurations applied, which is the generation of the current configuration.",
+		},
+	)
+}
+
+// newConfigLastApplied creates the gauge reporting the time the last changed runtime configuration was applied
+func newConfigLastApplied() prometheus.Gauge {
+	return prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "sparrow_config_last_applied_timestamp",
+			Help: "Unix timestamp of the last applied changed runtime configuration.",
+		},
+	)
+}
+
+// newTargetDuplicates creates the gauge reporting the number of duplicate
+// targets of every check collapsed into one target
+func newTargetDuplicates() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sparrow_check_target_duplicates",
+			Help: "Number of targets of a check collapsed into another target, because they spell the same endpoint differently.",
+		},
+		[]string{"check"},
+	)
+}
+
+// NewTenantChecksController creates a new ChecksController for the checks of a tenant.
+// The metric collectors of the checks are labelled with the name of the tenant.
+// An empty tenant name is used for the default checks if tenants are configured,
+// so all check metrics share the same label dimensions.
+func NewTenantChecksController(dbase db.DB, m metrics.Provider, instance, tenant string) *ChecksController {
+	cc := NewChecksController(dbase, m, instance)
+	cc.tenant = tenant
+	cc.registerer = prometheus.WrapRegistererWith(prometheus.Labels{labelTenant: tenant}, m.GetRegistry())
+	return cc
+}
+
 // Run runs the ChecksController with handling results and errors.
 func (cc *ChecksController) Run(ctx context.Context) error {
 	log := logger.FromContext(ctx)
 
+	if err := cc.registerer.Register(cc.overflow); err != nil {
+		log.ErrorContext(ctx, "Could not add series overflow collector to registry", "error", err)
+	}
+	if err := cc.registerer.Register(cc.duplicates); err != nil {
+		log.ErrorContext(ctx, "Could not add target duplicates collector to registry", "error", err)
+	}
+	if err := cc.registerer.Register(cc.hung); err != nil {
+		log.ErrorContext(ctx, "Could not add watchdog collector to registry", "error", err)
+	}
+	if err := cc.registerer.Register(cc.generation); err != nil {
+		log.ErrorContext(ctx, "Could not add config generation collector to registry", "error", err)
+	}
+	if err := cc.registerer.Register(cc.lastApplied); err != nil {
+		log.ErrorContext(ctx, "Could not add config last applied collector to registry", "error", err)
+	}
+	if err := cc.registerer.Register(cc.targetInfo); err != nil {
+		log.ErrorContext(ctx, "Could not add target info collector to registry", "error", err)
+	}
+	if cc.changes != nil {
+		if err := cc.registerer.Register(cc.changes.unchanged); err != nil {
+			log.ErrorContext(ctx, "Could not add unchanged results collector to registry", "error", err)
+		}
+	}
+
+	prune := time.NewTicker(seriesPruneInterval)
+	defer prune.Stop()
+	watchdog := time.NewTicker(watchdogInterval)
+	defer watchdog.Stop()
+
 	for {
 		select {
-		case result := <-cc.cResult:
-			cc.db.Save(result)
+		case now := <-prune.C:
+			cc.pruneSeries(ctx)
+			cc.history.prune(now.Add(-historyRetention))
+		case now := <-watchdog.C:
+			cc.watch(ctx, now)
+		case result, ok := <-cc.cResult:
+			if !ok {
+				// the controller was shut down
+				return nil
+			}
+			if result.Result != nil {
+				result.Result.Instance = cc.instance
+				result.Result.Provenance = cc.Config().Provenance
+			}
+			if err := cc.db.Save(result); err != nil {
+				log.ErrorContext(ctx, "Could not save result", "check", result.Name, "error", err)
+			}
+			cc.history.record(result)
+			cc.submit(result)
+			cc.scheduled(result.Name)
+			cc.gate(ctx, result.Name)
 		case err := <-cc.cErr:
 			var runErr *ErrRunningCheck
 			if errors.As(err, &runErr) {
@@ -78,6 +253,20 @@ func (cc *ChecksController) Run(ctx context.Context) error {
 	}
 }
 
+// submit passes the result to the submitters unless only changed results are exported
+// and the result didn't change since the last exported result of its check
+func (cc *ChecksController) submit(result checks.ResultDTO) {
+	for _, o := range cc.observers {
+		o.Submit(result)
+	}
+	if cc.changes != nil && !cc.changes.Changed(result, time.Now()) {
+		return
+	}
+	for _, s := range cc.submitters {
+		s.Submit(result)
+	}
+}
+
 // Shutdown shuts down the ChecksController.
 func (cc *ChecksController) Shutdown(ctx context.Context) {
 	log := logger.FromContext(ctx)
@@ -96,12 +285,39 @@ func (cc *ChecksController) Shutdown(ctx context.Context) {
 func (cc *ChecksController) Reconcile(ctx context.Context, cfg runtime.Config) {
 	log := logger.FromContext(ctx)
 
+	cfg = cc.dedup(ctx, cfg)
+	cc.mu.Lock()
+	diff := cfg.Diff(cc.cfg)
+	cc.cfg = cfg
+	cfg = cfg.Gate(cc.db.List())
+	cc.gated = cfg
+	cc.mu.Unlock()
+
 	newChecks, err := factory.NewChecksFromConfig(cfg)
 	if err != nil {
 		log.ErrorContext(ctx, "Failed to create checks from config", "error", err)
 		return
 	}
 
+	if !diff.Empty() {
+		log.InfoContext(ctx, "Applying changed runtime configuration",
+			"added", diff.Added, "removed", diff.Removed, "changed", diff.Changed, "settings", diff.Settings)
+		cc.events.add(Event{Timestamp: time.Now(), Type: eventConfigApplied, Diff: &diff, Provenance: cfg.Provenance})
+		cc.generation.Inc()
+		cc.lastApplied.SetToCurrentTime()
+	}
+
+	// Checks stopped by the watchdog stay stopped until their configuration changes
+	cc.mu.Lock()
+	for name, conf := range cc.stopped {
+		if reflect.DeepEqual(cfg.For(name), conf) {
+			delete(newChecks, name)
+			continue
+		}
+		delete(cc.stopped, name)
+	}
+	cc.mu.Unlock()
+
 	// Update existing checks and create a list of checks to unregister
 	var unregList []checks.Check
 	for _, c := range cc.checks.Iter() {
@@ -111,10 +327,8 @@ func (cc *ChecksController) Reconcile(ctx context.Context, cfg runtime.Config) {
 			continue
 		}
 
-		err = c.UpdateConfig(conf)
-		if err != nil {
-			log.ErrorContext(ctx, "Failed to set config for check", "check", c.Name(), "error", err)
-		}
+		cc.limitSeries(c, cfg.MaxSeries)
+		cc.updateConfig(ctx, c, conf)
 		delete(newChecks, c.Name())
 	}
 
@@ -125,24 +339,106 @@ func (cc *ChecksController) Reconcile(ctx context.Context, cfg runtime.Config) {
 
 	// Register new checks
 	for _, c := range newChecks {
+		cc.limitSeries(c, cfg.MaxSeries)
 		cc.RegisterCheck(ctx, c)
 	}
 }
 
+// dedup collapses the duplicate targets of the checks and reports their number
+func (cc *ChecksController) dedup(ctx context.Context, cfg runtime.Config) runtime.Config {
+	cfg, collapsed := cfg.Dedup()
+	for _, c := range cfg.Iter() {
+		n := collapsed[c.For()]
+		if n > 0 {
+			logger.FromContext(ctx).WarnContext(ctx, "Collapsed duplicate targets of check", "check", c.For(), "duplicates", n)
+		}
+		cc.duplicates.WithLabelValues(c.For()).Set(float64(n))
+	}
+	return cfg
+}
+
+// limitSeries applies the series limit to the check
+func (cc *ChecksController) limitSeries(c checks.Check, limit int) {
+	if l, ok := c.(checks.SeriesLimited); ok {
+		l.SetSeriesLimit(limit, cc.overflow.WithLabelValues(c.Name()))
+	}
+}
+
+// pruneSeries removes the metric series of the targets the checks no longer have configured.
+// It cleans up the series left behind if a target was removed while it was probed.
+func (cc *ChecksController) pruneSeries(ctx context.Context) {
+	for _, c := range cc.checks.Iter() {
+		if p, ok := c.(checks.SeriesPruner); ok {
+			if err := p.PruneSeries(); err != nil {
+				logger.FromContext(ctx).ErrorContext(ctx, "Failed to prune metric series of check", "check", c.Name(), "error", err)
+			}
+		}
+	}
+}
+
+// gate re-evaluates the dependencies on the check with the given name
+// and updates the configuration of every dependent check whose targets changed
+func (cc *ChecksController) gate(ctx context.Context, name string) {
+	log := logger.FromContext(ctx)
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if !cc.cfg.HasDependency(name) {
+		return
+	}
+
+	gated := cc.cfg.Gate(cc.db.List())
+	for _, c := range cc.checks.Iter() {
+		conf := gated.For(c.Name())
+		if conf == nil || reflect.DeepEqual(conf, cc.gated.For(c.Name())) {
+			continue
+		}
+
+		log.DebugContext(ctx, "Updating targets of dependent check", "check", c.Name(), "dependency", name)
+		cc.updateConfig(ctx, c, conf)
+	}
+	cc.gated = gated
+}
+
+// updateConfig updates the configuration of the check and calls its configuration change hook
+func (cc *ChecksController) updateConfig(ctx context.Context, c checks.Check, conf checks.Runtime) {
+	log := logger.FromContext(ctx).With("check", c.Name())
+	if err := c.UpdateConfig(conf); err != nil {
+		log.ErrorContext(ctx, "Failed to set config for check", "error", err)
+		return
+	}
+	if err := c.OnConfigChange(ctx, conf); err != nil {
+		log.ErrorContext(ctx, "Failed to handle config change of check", "error", err)
+	}
+}
+
 // RegisterCheck registers a new check.
 func (cc *ChecksController) RegisterCheck(ctx context.Context, check checks.Check) {
 	log := logger.FromContext(ctx).With("check", check.Name())
 
 	// Add prometheus collectors of check to registry
 	for _, collector := range check.GetMetricCollectors() {
-		if err := cc.metrics.GetRegistry().Register(collector); err != nil {
+		if err := cc.registerer.Register(collector); err != nil {
 			log.ErrorContext(ctx, "Could not add metrics collector to registry", "error", err)
 		}
 	}
 
+	if err := check.OnRegister(ctx); err != nil {
+		log.ErrorContext(ctx, "Failed to prepare check", "error", err)
+	}
+
+	// The check runs with its own context, so a hanging iteration can be canceled by the watchdog
+	runCtx, cancel := context.WithCancelCause(ctx)
+	cc.runsMu.Lock()
+	if cc.runs == nil {
+		cc.runs = map[string]checkRun{}
+	}
+	cc.runs[check.Name()] = checkRun{cancel: cancel, started: time.Now()}
+	cc.runsMu.Unlock()
+
 	go func() {
-		err := check.Run(ctx, cc.cResult)
-		if err != nil {
+		err := check.Run(runCtx, cc.cResult)
+		if err != nil && !errors.Is(context.Cause(runCtx), errCheckUnregistered) {
 			log.ErrorContext(ctx, "Failed to run check", "error", err)
 			cc.cErr <- &ErrRunningCheck{
 				Check: check,
@@ -160,13 +456,23 @@ func (cc *ChecksController) UnregisterCheck(ctx context.Context, check checks.Ch
 
 	// Remove prometheus collectors of check from registry
 	for _, metricsCollector := range check.GetMetricCollectors() {
-		if !cc.metrics.GetRegistry().Unregister(metricsCollector) {
+		if !cc.registerer.Unregister(metricsCollector) {
 			log.ErrorContext(ctx, "Could not remove metrics collector from registry")
 		}
 	}
 
+	cc.overflow.DeleteLabelValues(check.Name())
+	cc.duplicates.DeleteLabelValues(check.Name())
+	check.OnShutdown(ctx)
 	check.Shutdown()
 	cc.checks.Delete(check)
+
+	cc.runsMu.Lock()
+	defer cc.runsMu.Unlock()
+	if run, ok := cc.runs[check.Name()]; ok {
+		run.cancel(errCheckUnregistered)
+		delete(cc.runs, check.Name())
+	}
 }
 
 var oapiBoilerplate = openapi3.T{
@@ -175,6 +481,7 @@ var oapiBoilerplate = openapi3.T{
 	Info: &openapi3.Info{
 		Title:       "Sparrow Metrics API",
 		Description: "Serves metrics collected by sparrows checks",
+		Version:     "v1",
 		Contact: &openapi3.Contact{
 			URL:   "https://caas.telekom.de",
 			Email: "caas-request@telekom.de",
@@ -196,6 +503,16 @@ var oapiBoilerplate = openapi3.T{
 func (cc *ChecksController) GenerateCheckSpecs(ctx context.Context) (openapi3.T, error) {
 	log := logger.FromContext(ctx)
 	doc := oapiBoilerplate
+	doc.Paths = &openapi3.Paths{
+		Extensions: make(map[string]any),
+	}
+	components, err := newOapiComponents()
+	if err != nil {
+		log.Error("Failed to create the shared schemas", "error", err)
+		return openapi3.T{}, err
+	}
+	doc.Components = components
+
 	for _, c := range cc.checks.Iter() {
 		name := c.Name()
 		ref, err := c.Schema()
@@ -204,24 +521,224 @@ func (cc *ChecksController) GenerateCheckSpecs(ctx context.Context) (openapi3.T,
 			return openapi3.T{}, &ErrCreateOpenapiSchema{name: name, err: err}
 		}
 
+		// The results are described by a component of the current version of the check's
+		// data layout, the unversioned component refers to it for existing consumers
+		schemaName := checkSchemaName(name)
+		if version, ok := runtime.SchemaVersion(name); ok {
+			versioned := versionedSchemaName(schemaName, version)
+			setSchemaVersion(ref, version)
+			doc.Components.Schemas[versioned] = ref
+			doc.Components.Schemas[schemaName] = schemaRef(versioned)
+			schemaName = versioned
+		} else {
+			doc.Components.Schemas[schemaName] = ref
+		}
+
 		routeDesc := fmt.Sprintf("Returns the performance data for check %s", name)
 		bodyDesc := fmt.Sprintf("Metrics for check %s", name)
-		responses := &openapi3.Responses{}
-		responses.Set(fmt.Sprint(http.StatusOK), &openapi3.ResponseRef{
-			Value: &openapi3.Response{
-				Description: &bodyDesc,
-				Content:     openapi3.NewContentWithSchemaRef(ref, []string{"application/json"}),
-			},
-		})
-		doc.Paths.Set(fmt.Sprintf("/v1/metrics/%s", name), &openapi3.PathItem{
+		responses := openapi3.NewResponses(
+			openapi3.WithStatus(http.StatusOK, resultResponse(bodyDesc, schemaRef(schemaName))),
+			openapi3.WithStatus(http.StatusBadRequest, responseRef(responseBadRequest)),
+			openapi3.WithStatus(http.StatusNotFound, responseRef(responseNotFound)),
+			openapi3.WithStatus(http.StatusNotAcceptable, responseRef(responseNotAcceptable)),
+			openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+			openapi3.WithStatus(http.StatusServiceUnavailable, resultResponse(
+				fmt.Sprintf("Metrics for check %s, which is failing. Only returned if requested with %s", name, queryParamFailWithStatus),
+				schemaRef(schemaName))),
+		)
+		doc.Paths.Set(cc.metricsPath(name), &openapi3.PathItem{
 			Description: name,
 			Get: &openapi3.Operation{
+				OperationID: cc.operationID(name),
 				Description: routeDesc,
-				Tags:        []string{"Metrics", name},
-				Responses:   responses,
+				Tags:        cc.specTags(name),
+				Parameters: openapi3.Parameters{
+					{Value: openapi3.NewQueryParameter(queryParamSchemaVersion).
+						WithDescription("Requires the result data to have the layout of the given schema version").
+						WithSchema(openapi3.NewIntegerSchema())},
+					{Value: openapi3.NewQueryParameter(queryParamOffset).
+						WithDescription("Skips the given number of targets of the result data, ordered by target").
+						WithSchema(openapi3.NewIntegerSchema().WithMin(0))},
+					{Value: openapi3.NewQueryParameter(queryParamLimit).
+						WithDescription("Limits the targets of the result data to the given number. The total number is returned in the X-Total-Count header").
+						WithSchema(openapi3.NewIntegerSchema().WithMin(0))},
+					{Value: openapi3.NewQueryParameter(queryParamFailWithStatus).
+						WithDescription("Responds with 503 Service Unavailable and the result if the check is failing").
+						WithSchema(openapi3.NewBoolSchema())},
+					{Value: openapi3.NewQueryParameter(queryParamFailureBudget).
+						WithDescription("Fraction of the targets allowed to fail before the check is failing. Defaults to 0, any failed target").
+						WithSchema(openapi3.NewFloat64Schema().WithMin(0).WithExclusiveMax(true).WithMax(1))},
+				},
+				Responses: responses,
+			},
+		})
+
+		run := openapi3.NewObjectSchema().
+			WithPropertyRef("result", schemaRef(schemaName)).
+			WithProperty("failed", openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()))
+		doc.Paths.Set(cc.runPath(name), &openapi3.PathItem{
+			Description: name,
+			Post: &openapi3.Operation{
+				OperationID: cc.runOperationID(name),
+				Description: fmt.Sprintf("Runs check %s once on demand and returns the fresh result without storing it", name),
+				Tags:        cc.specTags(name),
+				Security:    openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate(securityBearer)),
+				Parameters: openapi3.Parameters{
+					{Value: openapi3.NewQueryParameter(queryParamTarget).
+						WithDescription("Restricts the run to the given target. Can be passed multiple times.").
+						WithSchema(openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()))},
+				},
+				Responses: openapi3.NewResponses(
+					openapi3.WithStatus(http.StatusOK, jsonResponse(fmt.Sprintf("Fresh result of check %s and its failed targets", name), openapi3.NewSchemaRef("", run))),
+					openapi3.WithStatus(http.StatusBadRequest, responseRef(responseBadRequest)),
+					openapi3.WithStatus(http.StatusUnauthorized, responseRef(responseUnauthorized)),
+					openapi3.WithStatus(http.StatusNotFound, responseRef(responseNotFound)),
+					openapi3.WithStatus(http.StatusTooManyRequests, responseRef(responseTooManyRequests)),
+					openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+				),
 			},
 		})
 	}
 
 	return doc, nil
 }
+
+// metricsPath returns the API path serving the results of the check with the given name
+func (cc *ChecksController) metricsPath(name string) string {
+	if cc.tenant == "" {
+		return fmt.Sprintf("/v1/metrics/%s", name)
+	}
+	return fmt.Sprintf("/v1/%s/metrics/%s", cc.tenant, name)
+}
+
+// runPath returns the API path running the check with the given name on demand
+func (cc *ChecksController) runPath(name string) string {
+	if cc.tenant == "" {
+		return fmt.Sprintf("/v1/checks/%s/run", name)
+	}
+	return fmt.Sprintf("/v1/%s/checks/%s/run", cc.tenant, name)
+}
+
+// specTags returns the OpenAPI tags of the route serving the results of the check with the given name
+func (cc *ChecksController) specTags(name string) []string {
+	if cc.tenant == "" {
+		return []string{"Metrics", name}
+	}
+	return []string{"Metrics", name, cc.tenant}
+}
+
+// operationID returns the OpenAPI operation id of the route serving the results of the check with the given name
+func (cc *ChecksController) operationID(name string) string {
+	if cc.tenant == "" {
+		return fmt.Sprintf("getCheckMetrics_%s", name)
+	}
+	return fmt.Sprintf("getCheckMetrics_%s_%s", cc.tenant, name)
+}
+
+// runOperationID returns the OpenAPI operation id of the route running the check with the given name on demand
+func (cc *ChecksController) runOperationID(name string) string {
+	if cc.tenant == "" {
+		return runOperationPrefix + name
+	}
+	return fmt.Sprintf("%s%s_%s", runOperationPrefix, cc.tenant, name)
+}
+
+// maxRunsOnDemand is the maximum number of concurrent runs on demand of a check
+const maxRunsOnDemand = 2
+
+// acquireRun reserves a run on demand of the check with the given name.
+// It returns false if the check already has the maximum number of runs on demand.
+func (cc *ChecksController) acquireRun(name string) bool {
+	cc.runsMu.Lock()
+	defer cc.runsMu.Unlock()
+	if cc.onDemand[name] >= maxRunsOnDemand {
+		return false
+	}
+	if cc.onDemand == nil {
+		cc.onDemand = map[string]int{}
+	}
+	cc.onDemand[name]++
+	return true
+}
+
+// releaseRun releases a run on demand of the check with the given name reserved by acquireRun
+func (cc *ChecksController) releaseRun(name string) {
+	cc.runsMu.Lock()
+	defer cc.runsMu.Unlock()
+	cc.onDemand[name]--
+	if cc.onDemand[name] <= 0 {
+		delete(cc.onDemand, name)
+	}
+}
+
+// RunCheck runs the registered check with the given name once out of band and returns
+// its result and the sorted targets that failed. If targets are given, only these targets are probed.
+// The check runs as a separate instance with the currently applied configuration,
+// so neither the scheduled runs nor the stored results and metrics of the check are affected.
+// A check has at most maxRunsOnDemand concurrent runs on demand, further runs fail with ErrTooManyRuns.
+func (cc *ChecksController) RunCheck(ctx context.Context, name string, targets []string) (*checks.Result, []string, error) {
+	cc.mu.Lock()
+	cfg := cc.gated
+	cc.mu.Unlock()
+
+	if cfg.For(name) == nil {
+		return nil, nil, ErrCheckNotRegistered
+	}
+	if !cc.acquireRun(name) {
+		return nil, nil, ErrTooManyRuns
+	}
+	defer cc.releaseRun(name)
+	if len(targets) > 0 {
+		var unknown []string
+		cfg, unknown = cfg.SelectTargets(name, targets)
+		if len(unknown) > 0 {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownTargets, strings.Join(unknown, ", "))
+		}
+	}
+
+	c, err := factory.NewCheck(cfg.For(name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create check: %w", err)
+	}
+	defer c.Shutdown()
+
+	o, ok := c.(checks.Oneshot)
+	if !ok {
+		return nil, nil, ErrCheckNotRunnable
+	}
+
+	logger.FromContext(ctx).InfoContext(ctx, "Running check on demand", "check", name, "targets", targets)
+	res, failed := o.RunOnce(ctx)
+	res.Instance = cc.instance
+	res.Provenance = cfg.Provenance
+	return res, failed, nil
+}
+
+// Config returns the last reconciled runtime configuration
+func (cc *ChecksController) Config() runtime.Config {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.cfg
+}
+
+// Events returns the recorded changes of the runtime configuration, oldest first
+func (cc *ChecksController) Events() []Event {
+	return cc.events.list()
+}
+
+// History returns the latest probe outcomes of the target of the check with the given name, oldest first.
+// It returns false if there's no outcome of the target.
+func (cc *ChecksController) History(check, target string) ([]Outcome, bool) {
+	return cc.history.list(check, target)
+}
+
+// NextRun returns the time the registered check with the given name runs next after
+// the run at the given time. It returns false if no such check is registered.
+func (cc *ChecksController) NextRun(name string, last time.Time) (time.Time, bool) {
+	for _, c := range cc.checks.Iter() {
+		if c.Name() == name {
+			return runtime.NextRun(c.GetConfig(), last)
+		}
+	}
+	return time.Time{}, false
+}
@@ -19,11 +19,23 @@
 package sparrow
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/caas-team/sparrow/pkg/checks"
 )
 
+var (
+	// ErrCheckNotRegistered is returned if a check is run on demand that isn't registered
+	ErrCheckNotRegistered = errors.New("check is not registered")
+	// ErrUnknownTargets is returned if a check is run on demand for targets it doesn't have
+	ErrUnknownTargets = errors.New("targets are not configured for the check")
+	// ErrCheckNotRunnable is returned if a check is run on demand that can't run a single iteration
+	ErrCheckNotRunnable = errors.New("check can't run on demand")
+	// ErrTooManyRuns is returned if a check is run on demand while it already has the maximum number of runs on demand
+	ErrTooManyRuns = errors.New("too many runs of the check on demand")
+)
+
 type ErrRunningCheck struct {
 	Check checks.Check
 	Err   error
@@ -22,6 +22,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,10 +34,12 @@ import (
 	"github.com/caas-team/sparrow/pkg/checks/health"
 	"github.com/caas-team/sparrow/pkg/checks/latency"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
 	"github.com/caas-team/sparrow/pkg/db"
 	"github.com/caas-team/sparrow/pkg/sparrow/metrics"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,7 +47,7 @@ func TestRun_CheckRunError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
 	mockCheck := &checks.CheckMock{
 		NameFunc: func() string { return "mockCheck" },
 		RunFunc: func(ctx context.Context, cResult chan checks.ResultDTO) error {
@@ -51,7 +56,9 @@ func TestRun_CheckRunError(t *testing.T) {
 		GetMetricCollectorsFunc: func() []prometheus.Collector {
 			return []prometheus.Collector{}
 		},
-		ShutdownFunc: func() {},
+		OnRegisterFunc: func(ctx context.Context) error { return nil },
+		OnShutdownFunc: func(ctx context.Context) {},
+		ShutdownFunc:   func() {},
 	}
 
 	cc.RegisterCheck(ctx, mockCheck)
@@ -82,7 +89,7 @@ func TestRun_CheckRunError(t *testing.T) {
 func TestRun_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
 
 	done := make(chan struct{})
 	go func() {
@@ -117,7 +124,7 @@ func TestChecksController_Reconcile(t *testing.T) {
 			checks: []checks.Check{},
 			newRuntimeConfig: runtime.Config{Health: &health.Config{
 				Targets:  []string{"https://gitlab.com"},
-				Interval: 1 * time.Second,
+				Interval: 10 * time.Second,
 				Timeout:  1 * time.Second,
 			}},
 		},
@@ -127,17 +134,17 @@ func TestChecksController_Reconcile(t *testing.T) {
 			newRuntimeConfig: runtime.Config{
 				Health: &health.Config{
 					Targets:  []string{"https://gitlab.com"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 				Latency: &latency.Config{
 					Targets:  []string{"https://gitlab.com"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 				Dns: &dns.Config{
 					Targets:  []string{"gitlab.com"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 			},
@@ -150,12 +157,12 @@ func TestChecksController_Reconcile(t *testing.T) {
 			newRuntimeConfig: runtime.Config{
 				Latency: &latency.Config{
 					Targets:  []string{"https://gitlab.com"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 				Health: &health.Config{
 					Targets:  []string{"https://gitlab.com"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 			},
@@ -175,7 +182,7 @@ func TestChecksController_Reconcile(t *testing.T) {
 			newRuntimeConfig: runtime.Config{
 				Latency: &latency.Config{
 					Targets:  []string{"https://gitlab.com"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 			},
@@ -189,7 +196,7 @@ func TestChecksController_Reconcile(t *testing.T) {
 			newRuntimeConfig: runtime.Config{
 				Health: &health.Config{
 					Targets:  []string{"https://gitlab.com"},
-					Interval: 1 * time.Second,
+					Interval: 10 * time.Second,
 					Timeout:  1 * time.Second,
 				},
 			},
@@ -206,7 +213,7 @@ func TestChecksController_Reconcile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
 
 			for _, c := range tt.checks {
 				cc.checks.Add(c)
@@ -235,6 +242,144 @@ func TestChecksController_Reconcile(t *testing.T) {
 	}
 }
 
+func TestChecksController_Reconcile_seriesLimit(t *testing.T) {
+	ctx, cancel := logger.NewContextWithLogger(context.Background())
+	defer cancel()
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
+	cfg := runtime.Config{
+		Health: &health.Config{
+			Targets:  []string{"https://gitlab.com", "https://github.com"},
+			Interval: 10 * time.Second,
+			Timeout:  1 * time.Second,
+		},
+		MaxSeries: 1,
+	}
+
+	cc.Reconcile(ctx, cfg)
+	defer cc.Shutdown(ctx)
+
+	c, ok := cc.checks.Iter()[0].(interface{ SeriesLabel(target string) string })
+	if !ok {
+		t.Fatalf("Check %T doesn't limit its series", cc.checks.Iter()[0])
+	}
+	assert.Equal(t, "https://gitlab.com", c.SeriesLabel("https://gitlab.com"))
+	assert.Equal(t, checks.OverflowLabel, c.SeriesLabel("https://github.com"))
+
+	// Raising the limit is applied to the registered check
+	cfg.MaxSeries = 0
+	cc.Reconcile(ctx, cfg)
+	assert.Equal(t, "https://example.com", c.SeriesLabel("https://example.com"))
+}
+
+func TestChecksController_Reconcile_duplicates(t *testing.T) {
+	ctx, cancel := logger.NewContextWithLogger(context.Background())
+	defer cancel()
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
+	defer cc.Shutdown(ctx)
+
+	cc.Reconcile(ctx, runtime.Config{Health: &health.Config{
+		Targets:  []string{"https://gitlab.com", "HTTPS://GitLab.com/", "https://github.com"},
+		Interval: 10 * time.Second,
+		Timeout:  1 * time.Second,
+	}})
+
+	assert.Equal(t, []string{"https://gitlab.com", "https://github.com"}, cc.checks.Iter()[0].GetConfig().(*health.Config).Targets)
+	assert.Equal(t, 1.0, testutil.ToFloat64(cc.duplicates.WithLabelValues(health.CheckName)))
+}
+
+func TestChecksController_Reconcile_events(t *testing.T) {
+	ctx, cancel := logger.NewContextWithLogger(context.Background())
+	defer cancel()
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
+	defer cc.Shutdown(ctx)
+
+	cfg := runtime.Config{Health: &health.Config{
+		Targets:  []string{"https://gitlab.com"},
+		Interval: 10 * time.Second,
+		Timeout:  1 * time.Second,
+	}}
+	cc.Reconcile(ctx, cfg)
+	// Applying an unchanged configuration isn't recorded
+	cc.Reconcile(ctx, cfg)
+
+	changed := runtime.Config{Health: &health.Config{
+		Targets:  []string{"https://github.com"},
+		Interval: 20 * time.Second,
+		Timeout:  1 * time.Second,
+	}}
+	cc.Reconcile(ctx, changed)
+
+	events := cc.Events()
+	if !assert.Len(t, events, 2) {
+		return
+	}
+	assert.Equal(t, eventConfigApplied, events[0].Type)
+	assert.Equal(t, []string{health.CheckName}, events[0].Diff.Added)
+	assert.Equal(t, []runtime.CheckDiff{{
+		Check:          health.CheckName,
+		AddedTargets:   []string{"https://github.com"},
+		RemovedTargets: []string{"https://gitlab.com"},
+		Interval:       &runtime.IntervalChange{Old: "10s", New: "20s"},
+	}}, events[1].Diff.Changed)
+	assert.Equal(t, 2.0, testutil.ToFloat64(cc.generation))
+	assert.NotZero(t, testutil.ToFloat64(cc.lastApplied))
+}
+
+// TestChecksController_Reconcile_runningCheck reconciles the configuration of a check while it's running,
+// so the race detector reports it if the check reads its configuration without holding its lock
+func TestChecksController_Reconcile_runningCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := logger.NewContextWithLogger(context.Background())
+	defer cancel()
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
+	var results atomic.Int32
+	go func() {
+		for range cc.cResult {
+			results.Add(1)
+		}
+	}()
+
+	const generations = 10
+	for i := range generations {
+		cc.Reconcile(ctx, runtime.Config{Health: &health.Config{
+			Targets:  []string{fmt.Sprintf("%s/%d", srv.URL, i)},
+			Interval: 1500 * time.Millisecond,
+			Timeout:  1 * time.Second,
+		}})
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	assert.Len(t, cc.Events(), generations)
+	assert.Positive(t, results.Load())
+}
+
+func TestEventLog_loadEvents(t *testing.T) {
+	errLoad := errors.New("connection refused")
+	provenance := &checks.Provenance{Loader: "http", Digest: "sha256:abc"}
+	var l eventLog
+	observe := l.loadEvents()
+
+	observe(config.LoadResult{Result: config.LoadSuccess, Provenance: provenance})
+	observe(config.LoadResult{Result: config.LoadError, Err: errLoad})
+	// Consecutive failures are recorded once
+	observe(config.LoadResult{Result: config.LoadError, Err: errLoad})
+	observe(config.LoadResult{Result: config.LoadUnchanged, Provenance: provenance})
+	observe(config.LoadResult{Result: config.LoadUnchanged, Provenance: provenance})
+
+	events := l.list()
+	if !assert.Len(t, events, 2) {
+		return
+	}
+	assert.Equal(t, eventConfigLoadFailed, events[0].Type)
+	assert.Equal(t, errLoad.Error(), events[0].Reason)
+	assert.Equal(t, eventConfigLoadRecovered, events[1].Type)
+	assert.Equal(t, provenance, events[1].Provenance)
+}
+
 func TestChecksController_RegisterCheck(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -244,7 +389,7 @@ func TestChecksController_RegisterCheck(t *testing.T) {
 		{
 			name: "register one check",
 			setup: func() *ChecksController {
-				return NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+				return NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
 			},
 			check: health.NewCheck(),
 		},
@@ -261,6 +406,43 @@ func TestChecksController_RegisterCheck(t *testing.T) {
 	}
 }
 
+func TestChecksController_lifecycleHooks(t *testing.T) {
+	var calls []string
+	run := make(chan struct{})
+	mockCheck := &checks.CheckMock{
+		NameFunc: func() string { return "mockCheck" },
+		RunFunc: func(ctx context.Context, cResult chan checks.ResultDTO) error {
+			close(run)
+			return nil
+		},
+		GetMetricCollectorsFunc: func() []prometheus.Collector { return nil },
+		UpdateConfigFunc: func(config checks.Runtime) error {
+			calls = append(calls, "UpdateConfig")
+			return nil
+		},
+		OnRegisterFunc: func(ctx context.Context) error {
+			calls = append(calls, "OnRegister")
+			return errors.New("cache not warmed")
+		},
+		OnConfigChangeFunc: func(ctx context.Context, config checks.Runtime) error {
+			calls = append(calls, "OnConfigChange")
+			return nil
+		},
+		OnShutdownFunc: func(ctx context.Context) { calls = append(calls, "OnShutdown") },
+		ShutdownFunc:   func() { calls = append(calls, "Shutdown") },
+	}
+
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
+	cc.RegisterCheck(context.Background(), mockCheck)
+	// The check is run even though it couldn't be prepared
+	<-run
+
+	cc.updateConfig(context.Background(), mockCheck, &health.Config{})
+	cc.UnregisterCheck(context.Background(), mockCheck)
+
+	assert.Equal(t, []string{"OnRegister", "UpdateConfig", "OnConfigChange", "OnShutdown", "Shutdown"}, calls)
+}
+
 func TestChecksController_UnregisterCheck(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -274,7 +456,7 @@ func TestChecksController_UnregisterCheck(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
 
 			cc.UnregisterCheck(context.Background(), tt.check)
 
@@ -0,0 +1,105 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+)
+
+// maxEvents is the maximum amount of events kept. The oldest events are dropped if the limit is exceeded.
+const maxEvents = 100
+
+const (
+	// eventConfigApplied is the type of the event recorded when a changed runtime configuration is applied
+	eventConfigApplied = "configApplied"
+	// eventCheckRestarted is the type of the event recorded when the watchdog restarted a hanging check
+	eventCheckRestarted = "checkRestarted"
+	// eventCheckStopped is the type of the event recorded when the watchdog stopped a hanging check
+	eventCheckStopped = "checkStopped"
+	// eventConfigLoadFailed is the type of the event recorded when the loader fails to load the runtime configuration
+	eventConfigLoadFailed = "configLoadFailed"
+	// eventConfigLoadRecovered is the type of the event recorded when the loader loads the runtime configuration again after failing
+	eventConfigLoadRecovered = "configLoadRecovered"
+)
+
+// Event is a change of the checks recorded for auditing
+type Event struct {
+	// Timestamp is the time the event occurred
+	Timestamp time.Time `json:"timestamp"`
+	// Type is the type of the event
+	Type string `json:"type"`
+	// Diff are the changes of the runtime configuration
+	Diff *runtime.ConfigDiff `json:"diff,omitempty"`
+	// Provenance describes where the applied runtime configuration was loaded from
+	Provenance *checks.Provenance `json:"provenance,omitempty"`
+	// Check is the name of the check the event refers to, if any
+	Check string `json:"check,omitempty"`
+	// Reason is the reason of the event, if any
+	Reason string `json:"reason,omitempty"`
+}
+
+// eventLog keeps the latest events
+type eventLog struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// add records the event
+func (l *eventLog) add(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+	if len(l.events) > maxEvents {
+		l.events = l.events[len(l.events)-maxEvents:]
+	}
+}
+
+// list returns the recorded events, oldest first
+func (l *eventLog) list() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := make([]Event, len(l.events))
+	copy(events, l.events)
+	return events
+}
+
+// loadEvents returns the function recording the failures of a loader and its recovery.
+// Consecutive failures are recorded once, so a failing loader doesn't flood the event log.
+func (l *eventLog) loadEvents() func(config.LoadResult) {
+	failing := false
+	return func(r config.LoadResult) {
+		switch {
+		case r.Result == config.LoadError && !failing:
+			failing = true
+			e := Event{Timestamp: time.Now(), Type: eventConfigLoadFailed}
+			if r.Err != nil {
+				e.Reason = r.Err.Error()
+			}
+			l.add(e)
+		case r.Result != config.LoadError && failing:
+			failing = false
+			l.add(Event{Timestamp: time.Now(), Type: eventConfigLoadRecovered, Provenance: r.Provenance})
+		}
+	}
+}
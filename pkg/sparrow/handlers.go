@@ -20,23 +20,100 @@ package sparrow
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/caas-team/sparrow/internal/logger"
 	"github.com/caas-team/sparrow/pkg/api"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"gopkg.in/yaml.v3"
 )
 
-type encoder interface {
-	Encode(v any) error
+// Status is the status of the sparrow served by the status endpoint
+type Status struct {
+	// Instance is the identity of the sparrow
+	Instance string `json:"instance"`
+	// Checks are the checks of the default runtime configuration
+	Checks []CheckStatus `json:"checks"`
+	// Tenants are the names of the configured tenants
+	Tenants []string `json:"tenants,omitempty"`
+	// Capabilities are the privileges of the process some checks depend on
+	Capabilities checks.Capabilities `json:"capabilities"`
+	// Degraded are the checks running degraded because the process lacks a capability
+	Degraded []DegradedCheck `json:"degraded"`
 }
 
-const urlParamCheckName = "checkName"
+// CheckRun is the result of a check run on demand
+type CheckRun struct {
+	// Result is the fresh result of the check
+	Result *checks.Result `json:"result"`
+	// Failed are the sorted targets that failed
+	Failed []string `json:"failed"`
+}
+
+// CheckStatus is the status of a single check
+type CheckStatus struct {
+	// Name is the name of the check
+	Name string `json:"name"`
+	// LastRun is the time of the latest result of the check
+	LastRun *time.Time `json:"lastRun,omitempty"`
+}
+
+const (
+	urlParamCheckName = "checkName"
+	urlParamTenant    = "tenant"
+	// urlParamTarget is the URL encoded target of a check, e.g. https%3A%2F%2Fexample.com
+	urlParamTarget = "target"
+)
+
+const (
+	// queryParamCheck filters the bulk results by check name. Can be passed multiple times.
+	queryParamCheck = "check"
+	// queryParamSince filters the bulk results by the minimum timestamp (RFC 3339)
+	queryParamSince = "since"
+	// queryParamSchemaVersion requires the result of a check to have the layout of the given schema version
+	queryParamSchemaVersion = "schemaVersion"
+	// queryParamOffset skips the given number of targets of a check's result, ordered by target
+	queryParamOffset = "offset"
+	// queryParamLimit limits the targets of a check's result to the given number
+	queryParamLimit = "limit"
+	// queryParamTarget restricts a check run on demand to the given target. Can be passed multiple times.
+	queryParamTarget = "target"
+	// queryParamKind selects the configuration whose JSON Schema is returned: startup or runtime
+	queryParamKind = "kind"
+	// queryParamFailWithStatus responds to the request for the result of a failing check with 503 Service Unavailable
+	queryParamFailWithStatus = "failWithStatus"
+	// queryParamFailureBudget is the fraction of targets of a check allowed to fail before the check is failing
+	queryParamFailureBudget = "failureBudget"
+)
+
+const (
+	// headerTotalCount is the response header containing the total number of targets of a paginated result
+	headerTotalCount = "X-Total-Count"
+	// headerNextRun is the response header containing the time of the next run of the checks whose results are returned
+	headerNextRun = "X-Next-Run"
+)
+
+// maxPushedConfigSize is the maximum accepted size of a pushed runtime configuration in bytes
+const maxPushedConfigSize = 1 << 20
 
 func (s *Sparrow) startupAPI(ctx context.Context) error {
 	routes := []api.Route{
@@ -44,19 +121,112 @@ func (s *Sparrow) startupAPI(ctx context.Context) error {
 			Path: "/openapi", Method: http.MethodGet,
 			Handler: s.handleOpenAPI,
 		},
+		{
+			Path: "/readyz", Method: http.MethodGet,
+			Handler: s.handleReady,
+		},
+		{
+			Path: "/v1/status", Method: http.MethodGet,
+			Handler: s.handleStatus,
+		},
+		{
+			Path: "/v1/targets", Method: http.MethodGet,
+			Handler: s.handleTargets,
+		},
+		{
+			Path: "/v1/events", Method: http.MethodGet,
+			Handler: s.handleEvents,
+		},
+		{
+			Path: "/v1/config", Method: http.MethodGet,
+			Handler: s.handleConfig,
+		},
+		{
+			Path: "/v1/config/schema", Method: http.MethodGet,
+			Handler: s.handleConfigSchema,
+		},
+		{
+			Path: "/v1/metrics", Method: http.MethodGet,
+			Handler: api.Compress(s.handleMetrics),
+		},
 		{
 			Path: fmt.Sprintf("/v1/metrics/{%s}", urlParamCheckName), Method: http.MethodGet,
-			Handler: s.handleCheckMetrics,
+			Handler: api.Compress(s.handleCheckMetrics),
+		},
+		{
+			Path: fmt.Sprintf("/v1/metrics/{%s}/targets/{%s}/history", urlParamCheckName, urlParamTarget), Method: http.MethodGet,
+			Handler: s.handleTargetHistory,
 		},
 		{
 			Path: "/metrics", Method: "*",
 			Handler: promhttp.HandlerFor(
 				s.metrics.GetRegistry(),
-				promhttp.HandlerOpts{Registry: s.metrics.GetRegistry()},
+				promhttp.HandlerOpts{Registry: s.metrics.GetRegistry(), EnableOpenMetrics: true},
 			).ServeHTTP,
 		},
 	}
 
+	if len(s.tenants) > 0 {
+		routes = append(routes,
+			api.Route{
+				Path: fmt.Sprintf("/v1/{%s}/metrics", urlParamTenant), Method: http.MethodGet,
+				Handler: api.Compress(s.handleMetrics),
+			},
+			api.Route{
+				Path: fmt.Sprintf("/v1/{%s}/metrics/{%s}", urlParamTenant, urlParamCheckName), Method: http.MethodGet,
+				Handler: api.Compress(s.handleCheckMetrics),
+			},
+			api.Route{
+				Path: fmt.Sprintf("/v1/{%s}/metrics/{%s}/targets/{%s}/history", urlParamTenant, urlParamCheckName, urlParamTarget), Method: http.MethodGet,
+				Handler: s.handleTargetHistory,
+			},
+			api.Route{
+				Path: fmt.Sprintf("/v1/{%s}/events", urlParamTenant), Method: http.MethodGet,
+				Handler: s.handleEvents,
+			},
+			api.Route{
+				Path: fmt.Sprintf("/v1/{%s}/config", urlParamTenant), Method: http.MethodGet,
+				Handler: s.handleConfig,
+			},
+		)
+	}
+
+	if s.config.HasCheckRun() {
+		routes = append(routes, api.Route{
+			Path: fmt.Sprintf("/v1/checks/{%s}/run", urlParamCheckName), Method: http.MethodPost,
+			Handler: s.handleRunCheck,
+		})
+		if len(s.tenants) > 0 {
+			routes = append(routes, api.Route{
+				Path: fmt.Sprintf("/v1/{%s}/checks/{%s}/run", urlParamTenant, urlParamCheckName), Method: http.MethodPost,
+				Handler: s.handleRunCheck,
+			})
+		}
+	}
+
+	if s.config.HasConfigPush() {
+		routes = append(routes, api.Route{
+			Path: "/v1/config/runtime", Method: http.MethodPut,
+			Handler: s.handlePushConfig,
+		})
+		if len(s.tenants) > 0 {
+			routes = append(routes, api.Route{
+				Path: fmt.Sprintf("/v1/{%s}/config/runtime", urlParamTenant), Method: http.MethodPut,
+				Handler: s.handlePushConfig,
+			})
+		}
+	}
+
+	if s.receiver != nil {
+		routes = append(routes, s.receiver.Routes()...)
+	}
+	if s.incidents != nil {
+		routes = append(routes, s.incidents.Routes()...)
+	}
+	if s.slos != nil {
+		routes = append(routes, s.slos.Routes()...)
+	}
+
 	err := s.api.RegisterRoutes(ctx, routes...)
 	if err != nil {
 		logger.FromContext(ctx).Error("Error while registering routes", "error", err)
@@ -67,7 +237,7 @@ func (s *Sparrow) startupAPI(ctx context.Context) error {
 
 func (s *Sparrow) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromContext(r.Context())
-	oapi, err := s.controller.GenerateCheckSpecs(r.Context())
+	oapi, err := s.generateCheckSpecs(r.Context())
 	if err != nil {
 		log.Error("failed to create openapi", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -80,7 +250,7 @@ func (s *Sparrow) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
 
 	mime := r.Header.Get("Accept")
 
-	var marshaler encoder
+	var marshaler api.Encoder
 	switch mime {
 	case "application/json":
 		marshaler = json.NewEncoder(w)
@@ -102,8 +272,44 @@ func (s *Sparrow) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// generateCheckSpecs generates the OpenAPI specifications for the checks of the default runtime configuration,
+// all tenants and the routes served independently of the checks
+func (s *Sparrow) generateCheckSpecs(ctx context.Context) (openapi3.T, error) {
+	doc, err := s.controller.GenerateCheckSpecs(ctx)
+	if err != nil {
+		return openapi3.T{}, err
+	}
+
+	for _, t := range s.tenants {
+		tdoc, err := t.controller.GenerateCheckSpecs(ctx)
+		if err != nil {
+			return openapi3.T{}, err
+		}
+		for path, item := range tdoc.Paths.Map() {
+			doc.Paths.Set(path, item)
+		}
+		for name, schema := range tdoc.Components.Schemas {
+			doc.Components.Schemas[name] = schema
+		}
+	}
+
+	// The routes running the checks on demand are only documented if they're served
+	if s.config == nil || !s.config.HasCheckRun() {
+		for path, item := range doc.Paths.Map() {
+			if item.Post != nil && strings.HasPrefix(item.Post.OperationID, runOperationPrefix) {
+				doc.Paths.Delete(path)
+			}
+		}
+	}
+
+	s.addAPISpecs(&doc)
+	return doc, nil
+}
+
 func (s *Sparrow) handleCheckMetrics(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromContext(r.Context())
+	// Errors aren't cached, the caching headers of a result replace the directive
+	w.Header().Set("Cache-Control", "no-store")
 	name := chi.URLParam(r, urlParamCheckName)
 	if name == "" {
 		w.WriteHeader(http.StatusBadRequest)
@@ -113,7 +319,8 @@ func (s *Sparrow) handleCheckMetrics(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	res, ok := s.db.Get(name)
+
+	dbase, ok := s.dbFor(r)
 	if !ok {
 		w.WriteHeader(http.StatusNotFound)
 		_, err := w.Write([]byte(http.StatusText(http.StatusNotFound)))
@@ -123,9 +330,86 @@ func (s *Sparrow) handleCheckMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
+	var version int
+	if v := r.URL.Query().Get(queryParamSchemaVersion); v != "" {
+		var err error
+		version, err = strconv.Atoi(v)
+		if err != nil || version <= 0 {
+			log.Debug("Invalid schema version query parameter", "schemaVersion", v, "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_, err = w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+			if err != nil {
+				log.Error("Failed to write response", "error", err)
+			}
+			return
+		}
+	}
 
+	offset, limit, err := pageParams(r)
+	if err != nil {
+		log.Debug("Invalid pagination query parameters", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	failWithStatus, budget, err := failParams(r)
+	if err != nil {
+		log.Debug("Invalid failure query parameters", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	res, ok := dbase.Get(name)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(http.StatusText(http.StatusNotFound)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	if version > 0 && !res.Compatible(version) {
+		log.Debug("Result has an incompatible schema version", "name", name, "schemaVersion", res.Version(), "requested", version)
+		w.WriteHeader(http.StatusNotAcceptable)
+		_, err := w.Write([]byte(http.StatusText(http.StatusNotAcceptable)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	// The status reflects all targets, not only the ones of the requested page
+	failed := failWithStatus && failing(name, res.Data, budget)
+
+	if offset > 0 || limit > 0 {
+		data, total, ok := paginate(res.Data, offset, limit)
+		if !ok {
+			log.Debug("Result of check can't be paginated", "name", name)
+			w.WriteHeader(http.StatusBadRequest)
+			_, err := w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+			if err != nil {
+				log.Error("Failed to write response", "error", err)
+			}
+			return
+		}
+		res.Data = data
+		w.Header().Set(headerTotalCount, strconv.Itoa(total))
+	}
+	enc := api.NewEncoder(w, r)
+	if failed {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		setCacheHeaders(w, s.controllerFor(r), map[string]checks.Result{name: res}, time.Now())
+	}
 	if err := enc.Encode(res); err != nil {
 		log.Error("failed to encode response", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -135,5 +419,548 @@ func (s *Sparrow) handleCheckMetrics(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+}
+
+// pageParams returns the offset and limit of the pagination query parameters.
+// A limit of 0 means all targets after the offset are returned.
+func pageParams(r *http.Request) (offset, limit int, err error) {
+	query := r.URL.Query()
+	if v := query.Get(queryParamOffset); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid offset %q", v)
+		}
+	}
+	if v := query.Get(queryParamLimit); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			return 0, 0, fmt.Errorf("invalid limit %q", v)
+		}
+	}
+	return offset, limit, nil
+}
+
+// failParams returns whether the result of a failing check is responded with 503 Service Unavailable
+// and the fraction of targets allowed to fail before the check is failing
+func failParams(r *http.Request) (fail bool, budget float64, err error) {
+	query := r.URL.Query()
+	if v := query.Get(queryParamFailWithStatus); v != "" {
+		if fail, err = strconv.ParseBool(v); err != nil {
+			return false, 0, fmt.Errorf("invalid %s %q", queryParamFailWithStatus, v)
+		}
+	}
+	if v := query.Get(queryParamFailureBudget); v != "" {
+		if budget, err = strconv.ParseFloat(v, 64); err != nil || budget < 0 || budget >= 1 {
+			return false, 0, fmt.Errorf("invalid %s %q, must be at least 0 and below 1", queryParamFailureBudget, v)
+		}
+	}
+	return fail, budget, nil
+}
+
+// failing returns whether the share of failed targets of the check's result data exceeds the failure budget.
+// A check whose result data doesn't report failed targets is never failing.
+func failing(check string, data any, budget float64) bool {
+	failures := runtime.TargetFailures(check, data)
+	if len(failures) == 0 {
+		return false
+	}
+	failed := 0
+	for _, f := range failures {
+		if f {
+			failed++
+		}
+	}
+	return float64(failed)/float64(len(failures)) > budget
+}
+
+// paginate returns the page of the result data starting at the offset with at most limit targets
+// and the total number of targets. The data of most checks maps the targets to their results,
+// the targets are ordered by name. It returns false if the data isn't a map keyed by target.
+func paginate(data any, offset, limit int) (page any, total int, ok bool) {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, 0, false
+	}
+
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	total = len(keys)
+	keys = keys[min(offset, total):]
+	if limit > 0 {
+		keys = keys[:min(limit, len(keys))]
+	}
+
+	p := reflect.MakeMapWithSize(v.Type(), len(keys))
+	for _, k := range keys {
+		p.SetMapIndex(k, v.MapIndex(k))
+	}
+	return p.Interface(), total, true
+}
+
+// dbFor returns the database of the tenant addressed by the request
+// or the default database if no tenant is addressed
+func (s *Sparrow) dbFor(r *http.Request) (db.DB, bool) {
+	tn := chi.URLParam(r, urlParamTenant)
+	if tn == "" {
+		return s.db, true
+	}
+	t, ok := s.tenants[tn]
+	if !ok {
+		return nil, false
+	}
+	return t.db, true
+}
+
+// controllerFor returns the checks controller of the tenant addressed by the request
+// or the default controller if no tenant is addressed
+func (s *Sparrow) controllerFor(r *http.Request) *ChecksController {
+	tn := chi.URLParam(r, urlParamTenant)
+	if tn == "" {
+		return s.controller
+	}
+	if t, ok := s.tenants[tn]; ok {
+		return t.controller
+	}
+	return nil
+}
+
+// setCacheHeaders sets the caching headers of a response containing the results of the given checks,
+// so clients and intermediary caches don't refetch them before the next run of a check.
+// The max-age is the interval between the latest and the next run of the check running next,
+// the Age is the time elapsed since its latest run. No headers are set if the next run of a check is unknown.
+// They must only be set on successful responses.
+func setCacheHeaders(w http.ResponseWriter, cc *ChecksController, results map[string]checks.Result, now time.Time) {
+	if cc == nil || len(results) == 0 {
+		return
+	}
+
+	var last, next time.Time
+	for name, res := range results {
+		n, ok := cc.NextRun(name, res.Timestamp)
+		if !ok {
+			return
+		}
+		if next.IsZero() || n.Before(next) {
+			last, next = res.Timestamp, n
+		}
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(next.Sub(last).Seconds())))
+	w.Header().Set("Age", strconv.Itoa(int(max(now.Sub(last), 0).Seconds())))
+	w.Header().Set(headerNextRun, next.UTC().Format(time.RFC3339))
+}
+
+// handleMetrics returns the latest results of all checks in a single response.
+// The results can be filtered by check name and minimum timestamp using query parameters.
+// The response is encoded in the format negotiated via the Accept header.
+func (s *Sparrow) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	// Errors aren't cached, the caching headers of the results replace the directive
+	w.Header().Set("Cache-Control", "no-store")
+
+	dbase, ok := s.dbFor(r)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(http.StatusText(http.StatusNotFound)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	query := r.URL.Query()
+	var since time.Time
+	if v := query.Get(queryParamSince); v != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			log.Debug("Invalid since query parameter", "since", v, "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_, err = w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+			if err != nil {
+				log.Error("Failed to write response", "error", err)
+			}
+			return
+		}
+	}
+
+	names := map[string]struct{}{}
+	for _, v := range query[queryParamCheck] {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names[name] = struct{}{}
+			}
+		}
+	}
+
+	results := map[string]checks.Result{}
+	for name, res := range dbase.List() {
+		if _, ok := names[name]; len(names) > 0 && !ok {
+			continue
+		}
+		if res.Timestamp.Before(since) {
+			continue
+		}
+		results[name] = res
+	}
+	setCacheHeaders(w, s.controllerFor(r), results, time.Now())
+
+	if err := api.EncodeMap(w, r, results); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handleStatus returns the status of the sparrow and the time of the latest result of every check
+func (s *Sparrow) handleStatus(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	status := Status{
+		Instance:     s.controller.instance,
+		Checks:       []CheckStatus{},
+		Capabilities: s.capabilities,
+		Degraded:     degradedChecks(s.capabilities),
+	}
+	for _, c := range s.controller.checks.Iter() {
+		cs := CheckStatus{Name: c.Name()}
+		if res, ok := s.db.Get(cs.Name); ok {
+			cs.LastRun = &res.Timestamp
+		}
+		status.Checks = append(status.Checks, cs)
+	}
+	slices.SortFunc(status.Checks, func(a, b CheckStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for name := range s.tenants {
+		status.Tenants = append(status.Tenants, name)
+	}
+	slices.Sort(status.Tenants)
+
 	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handleReady reports whether the sparrow is ready. It isn't ready while the database of the checks
+// of any tenant is degraded, because it fails to save the results and buffers them in memory.
+func (s *Sparrow) handleReady(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	err := db.Healthy(s.db)
+	for _, name := range slices.Sorted(maps.Keys(s.tenants)) {
+		if tErr := db.Healthy(s.tenants[name].db); tErr != nil {
+			err = errors.Join(err, fmt.Errorf("tenant %s: %w", name, tErr))
+		}
+	}
+
+	status, body := http.StatusOK, "ok"
+	if err != nil {
+		log.Warn("Sparrow is degraded", "error", err)
+		status, body = http.StatusServiceUnavailable, fmt.Sprintf("degraded: %v", err)
+	}
+	w.Header().Add("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	if _, err := w.Write([]byte(body)); err != nil {
+		log.Error("Failed to write response", "error", err)
+	}
+}
+
+// handleTargets returns the global targets known to the target manager.
+// The list is empty if no target manager is configured.
+func (s *Sparrow) handleTargets(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	targets := []checks.GlobalTarget{}
+	if s.tarMan != nil {
+		targets = append(targets, s.tarMan.GetTargets()...)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(targets); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handleConfigSchema returns the JSON Schema of the startup or the runtime configuration.
+// Without a kind, the schema accepts both configurations.
+func (s *Sparrow) handleConfigSchema(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	schema, err := config.Schema(r.URL.Query().Get(queryParamKind))
+	if err != nil {
+		log.Debug("Invalid configuration schema kind", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/schema+json")
+	if err := json.NewEncoder(w).Encode(schema); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handleEvents returns the recorded changes of the runtime configuration
+// of the checks of the tenant addressed by the request, oldest first
+func (s *Sparrow) handleEvents(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	controller := s.controller
+	if tn := chi.URLParam(r, urlParamTenant); tn != "" {
+		t, ok := s.tenants[tn]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(http.StatusText(http.StatusNotFound)))
+			if err != nil {
+				log.Error("Failed to write response", "error", err)
+			}
+			return
+		}
+		controller = t.controller
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(controller.Events()); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handleTargetHistory returns the latest probe outcomes of a target of a check, oldest first,
+// so a flapping target can be spotted without access to Prometheus
+func (s *Sparrow) handleTargetHistory(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	name := chi.URLParam(r, urlParamCheckName)
+	target, err := url.PathUnescape(chi.URLParam(r, urlParamTarget))
+	if name == "" || target == "" || err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	var outcomes []Outcome
+	ok := false
+	if controller := s.controllerFor(r); controller != nil {
+		outcomes, ok = controller.History(name, target)
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(http.StatusText(http.StatusNotFound)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(outcomes); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handleConfig returns the runtime configuration applied to the checks of the tenant addressed by the request,
+// including where it was loaded from. The secrets of the checks are redacted.
+func (s *Sparrow) handleConfig(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	controller := s.controllerFor(r)
+	if controller == nil {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(http.StatusText(http.StatusNotFound)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(controller.Config().Redacted()); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handlePushConfig validates the pushed runtime configuration and applies it to the checks of the tenant
+// addressed by the request like a configuration loaded by the loader. The configuration is applied
+// asynchronously and replaced by the next configuration the loader loads.
+func (s *Sparrow) handlePushConfig(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	if !authorize(w, r, s.config.Loader.Push.Token) {
+		log.Warn("Rejected unauthorized runtime configuration push")
+		return
+	}
+
+	cRuntime := s.cRuntime
+	if tn := chi.URLParam(r, urlParamTenant); tn != "" {
+		t, ok := s.tenants[tn]
+		if !ok {
+			writeStatus(w, r, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+		cRuntime = t.cRuntime
+	}
+
+	b, err := io.ReadAll(io.LimitReader(r.Body, maxPushedConfigSize+1))
+	if err != nil {
+		writeStatus(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(b) > maxPushedConfigSize {
+		writeStatus(w, r, http.StatusRequestEntityTooLarge, http.StatusText(http.StatusRequestEntityTooLarge))
+		return
+	}
+	cfg, err := parsePushedConfig(b, s.config.SparrowName)
+	if err != nil {
+		log.Warn("Rejected invalid runtime configuration push", "error", err)
+		writeStatus(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	select {
+	case cRuntime <- cfg:
+		log.Info("Applying pushed runtime configuration", "digest", cfg.Provenance.Digest)
+		w.WriteHeader(http.StatusAccepted)
+	case <-r.Context().Done():
+		writeStatus(w, r, http.StatusServiceUnavailable, http.StatusText(http.StatusServiceUnavailable))
+	}
+}
+
+// parsePushedConfig renders, decodes and validates a pushed runtime configuration
+func parsePushedConfig(b []byte, instanceName string) (runtime.Config, error) {
+	cfg, err := config.ParseRuntimeConfig(b, instanceName)
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.Empty() {
+		return cfg, errors.New("no checks are configured")
+	}
+	cfg.Provenance = checks.NewProvenance("push", "api", b)
+	return cfg, nil
+}
+
+// authorize compares the bearer token of the request with the token in constant time.
+// If they don't match, it responds with 401 Unauthorized and returns false.
+func authorize(w http.ResponseWriter, r *http.Request, token string) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	writeStatus(w, r, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	return false
+}
+
+// writeStatus writes the status code and the message to the response
+func writeStatus(w http.ResponseWriter, r *http.Request, code int, msg string) {
+	w.WriteHeader(code)
+	if _, err := w.Write([]byte(msg)); err != nil {
+		logger.FromContext(r.Context()).Error("Failed to write response", "error", err)
+	}
+}
+
+// handleRunCheck runs a check of the tenant addressed by the request once on demand and returns
+// the fresh result. The run is restricted to the targets passed as query parameters, if any.
+func (s *Sparrow) handleRunCheck(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	if !authorize(w, r, s.config.Api.RunToken) {
+		log.Warn("Rejected unauthorized run of check on demand")
+		return
+	}
+
+	controller := s.controllerFor(r)
+	if controller == nil {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(http.StatusText(http.StatusNotFound)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	name := chi.URLParam(r, urlParamCheckName)
+	res, failed, err := controller.RunCheck(r.Context(), name, r.URL.Query()[queryParamTarget])
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrCheckNotRegistered):
+			log.Debug("Check to run on demand is not registered", "check", name)
+			code = http.StatusNotFound
+		case errors.Is(err, ErrUnknownTargets):
+			log.Debug("Invalid targets of check to run on demand", "check", name, "error", err)
+			code = http.StatusBadRequest
+		case errors.Is(err, ErrTooManyRuns):
+			log.Warn("Rejected run of check on demand, it already has the maximum number of runs", "check", name)
+			code = http.StatusTooManyRequests
+		default:
+			log.Error("Failed to run check on demand", "check", name, "error", err)
+		}
+		w.WriteHeader(code)
+		_, err = w.Write([]byte(http.StatusText(code)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	if failed == nil {
+		failed = []string{}
+	}
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(CheckRun{Result: res, Failed: failed}); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
 }
@@ -22,16 +22,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/api"
 	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/client"
+	"github.com/caas-team/sparrow/pkg/config"
 	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/oauth"
+	"github.com/caas-team/sparrow/pkg/sparrow/metrics"
+	"github.com/caas-team/sparrow/pkg/sparrow/targets"
+	managermock "github.com/caas-team/sparrow/pkg/sparrow/targets/test"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"gopkg.in/yaml.v3"
@@ -177,3 +191,983 @@ func testDb() *db.InMemory {
 
 	return d
 }
+
+func TestSparrow_handleCheckMetrics_schemaVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+	}{
+		{name: "no version requested", query: "", wantCode: http.StatusOK},
+		{name: "legacy version requested", query: "1", wantCode: http.StatusOK},
+		{name: "other version requested", query: "2", wantCode: http.StatusNotAcceptable},
+		{name: "invalid version", query: "v1", wantCode: http.StatusBadRequest},
+		{name: "negative version", query: "-1", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{db: testDb()}
+
+			w := httptest.NewRecorder()
+			r := chiRequest(httptest.NewRequest(http.MethodGet, "/v1/metrics/alpha?schemaVersion="+tt.query, http.NoBody), "alpha")
+
+			s.handleCheckMetrics(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Sparrow.handleCheckMetrics() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleCheckMetrics_failWithStatus(t *testing.T) {
+	dbase := db.NewInMemory()
+	dbase.Save(checks.ResultDTO{Name: health.CheckName, Result: &checks.Result{Timestamp: time.Now(), Data: map[string]string{
+		"https://a.example.com": "healthy",
+		"https://b.example.com": "healthy",
+		"https://c.example.com": "healthy",
+		"https://d.example.com": "unhealthy",
+	}}})
+	dbase.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Timestamp: time.Now(), Data: 1}})
+	hc := health.NewCheck()
+	if err := hc.UpdateConfig(&health.Config{Interval: time.Minute}); err != nil {
+		t.Fatalf("Failed to configure health check: %v", err)
+	}
+	cc := &ChecksController{checks: runtime.Checks{}}
+	cc.checks.Add(hc)
+
+	tests := []struct {
+		name      string
+		check     string
+		query     string
+		wantCode  int
+		wantCache string
+	}{
+		{name: "not requested", check: health.CheckName, query: "", wantCode: http.StatusOK, wantCache: "max-age=60"},
+		{name: "failing check", check: health.CheckName, query: "failWithStatus=true", wantCode: http.StatusServiceUnavailable, wantCache: "no-store"},
+		{name: "disabled", check: health.CheckName, query: "failWithStatus=false", wantCode: http.StatusOK, wantCache: "max-age=60"},
+		{name: "within budget", check: health.CheckName, query: "failWithStatus=true&failureBudget=0.25", wantCode: http.StatusOK, wantCache: "max-age=60"},
+		{name: "budget exceeded", check: health.CheckName, query: "failWithStatus=true&failureBudget=0.2", wantCode: http.StatusServiceUnavailable, wantCache: "no-store"},
+		{name: "failing target on another page", check: health.CheckName, query: "failWithStatus=true&limit=1", wantCode: http.StatusServiceUnavailable, wantCache: "no-store"},
+		{name: "check without targets", check: "alpha", query: "failWithStatus=true", wantCode: http.StatusOK, wantCache: "no-store"},
+		{name: "invalid flag", check: health.CheckName, query: "failWithStatus=yes", wantCode: http.StatusBadRequest, wantCache: "no-store"},
+		{name: "invalid budget", check: health.CheckName, query: "failWithStatus=true&failureBudget=1", wantCode: http.StatusBadRequest, wantCache: "no-store"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{db: dbase, controller: cc}
+
+			w := httptest.NewRecorder()
+			r := chiRequest(httptest.NewRequest(http.MethodGet, "/v1/metrics/"+tt.check+"?"+tt.query, http.NoBody), tt.check)
+
+			s.handleCheckMetrics(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Sparrow.handleCheckMetrics() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Cache-Control"); got != tt.wantCache {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCache)
+			}
+			if tt.wantCache == "no-store" && (resp.Header.Get("Age") != "" || resp.Header.Get(headerNextRun) != "") {
+				t.Error("Expected no caching headers besides Cache-Control: no-store")
+			}
+			if tt.wantCode != http.StatusServiceUnavailable {
+				return
+			}
+			var got checks.Result
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if got.Data == nil {
+				t.Error("Sparrow.handleCheckMetrics() didn't return the result of the failing check")
+			}
+		})
+	}
+}
+
+func TestSparrow_handleCheckMetrics_pagination(t *testing.T) {
+	dbase := db.NewInMemory()
+	dbase.Save(checks.ResultDTO{Name: "health", Result: &checks.Result{Data: map[string]string{
+		"https://a.example.com": "healthy",
+		"https://b.example.com": "unhealthy",
+		"https://c.example.com": "healthy",
+	}}})
+	dbase.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: 1}})
+
+	tests := []struct {
+		name      string
+		check     string
+		query     string
+		wantCode  int
+		wantData  map[string]string
+		wantTotal string
+	}{
+		{
+			name:      "first page",
+			check:     "health",
+			query:     "limit=2",
+			wantCode:  http.StatusOK,
+			wantData:  map[string]string{"https://a.example.com": "healthy", "https://b.example.com": "unhealthy"},
+			wantTotal: "3",
+		},
+		{
+			name:      "last page",
+			check:     "health",
+			query:     "offset=2&limit=2",
+			wantCode:  http.StatusOK,
+			wantData:  map[string]string{"https://c.example.com": "healthy"},
+			wantTotal: "3",
+		},
+		{
+			name:      "offset beyond targets",
+			check:     "health",
+			query:     "offset=5",
+			wantCode:  http.StatusOK,
+			wantData:  map[string]string{},
+			wantTotal: "3",
+		},
+		{name: "invalid offset", check: "health", query: "offset=-1", wantCode: http.StatusBadRequest},
+		{name: "invalid limit", check: "health", query: "limit=all", wantCode: http.StatusBadRequest},
+		{name: "data not keyed by target", check: "alpha", query: "limit=1", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{db: dbase}
+
+			w := httptest.NewRecorder()
+			r := chiRequest(httptest.NewRequest(http.MethodGet, "/v1/metrics/"+tt.check+"?"+tt.query, http.NoBody), tt.check)
+
+			s.handleCheckMetrics(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Sparrow.handleCheckMetrics() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var got struct {
+				Data map[string]string `json:"data"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Expected valid json: %v", err)
+			}
+			if !reflect.DeepEqual(got.Data, tt.wantData) {
+				t.Errorf("Sparrow.handleCheckMetrics() data = %v, want %v", got.Data, tt.wantData)
+			}
+			if total := resp.Header.Get(headerTotalCount); total != tt.wantTotal {
+				t.Errorf("Sparrow.handleCheckMetrics() total = %s, want %s", total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleCheckMetrics_tenant(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenant   string
+		check    string
+		wantCode int
+	}{
+		{
+			name:     "tenant has data",
+			tenant:   "team-a",
+			check:    "alpha",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "tenant has no data",
+			tenant:   "team-a",
+			check:    "gamma",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unknown tenant",
+			tenant:   "team-b",
+			check:    "alpha",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{
+				db: db.NewInMemory(),
+				tenants: map[string]*tenant{
+					"team-a": {name: "team-a", db: testDb()},
+				},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/"+tt.tenant+"/metrics/"+tt.check, bytes.NewBuffer([]byte{}))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("tenant", tt.tenant)
+			rctx.URLParams.Add("checkName", tt.check)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			s.handleCheckMetrics(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Sparrow.handleCheckMetrics() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantNames []string
+	}{
+		{
+			name:      "all results",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"alpha", "beta"},
+		},
+		{
+			name:      "filtered by check",
+			query:     "?check=alpha",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"alpha"},
+		},
+		{
+			name:      "filtered by multiple checks",
+			query:     "?check=alpha,beta&check=gamma",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"alpha", "beta"},
+		},
+		{
+			name:      "filtered by since",
+			query:     "?since=" + time.Now().Add(time.Hour).Format(time.RFC3339),
+			wantCode:  http.StatusOK,
+			wantNames: []string{},
+		},
+		{
+			name:     "invalid since",
+			query:    "?since=yesterday",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{
+				db: testDb(),
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/metrics"+tt.query, bytes.NewBuffer([]byte{}))
+
+			s.handleMetrics(w, r)
+			resp := w.Result() //nolint:bodyclose
+			body, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Sparrow.handleMetrics() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var got map[string]checks.Result
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("Expected valid json: %v", err)
+			}
+			if len(got) != len(tt.wantNames) {
+				t.Errorf("Sparrow.handleMetrics() returned %d results, want %d", len(got), len(tt.wantNames))
+			}
+			for _, name := range tt.wantNames {
+				if _, ok := got[name]; !ok {
+					t.Errorf("Sparrow.handleMetrics() is missing result for %q", name)
+				}
+			}
+		})
+	}
+}
+
+func TestSparrow_generateCheckSpecs(t *testing.T) {
+	ctx := context.Background()
+	newController := func(tenant string) *ChecksController {
+		cc := &ChecksController{checks: runtime.Checks{}, tenant: tenant}
+		cc.checks.Add(health.NewCheck())
+		cc.checks.Add(latency.NewCheck())
+		return cc
+	}
+	s := &Sparrow{
+		controller: newController(""),
+		tenants: map[string]*tenant{
+			"team-a": {name: "team-a", controller: newController("team-a")},
+		},
+		config: &config.Config{Api: api.Config{RunToken: "secret"}},
+	}
+
+	doc, err := s.generateCheckSpecs(ctx)
+	if err != nil {
+		t.Fatalf("Sparrow.generateCheckSpecs() error = %v", err)
+	}
+
+	// Load the marshaled document to resolve the references before validating it
+	b, err := json.Marshal(&doc)
+	if err != nil {
+		t.Fatalf("Failed to marshal openapi document: %v", err)
+	}
+	loaded, err := openapi3.NewLoader().LoadFromData(b)
+	if err != nil {
+		t.Fatalf("Failed to load openapi document: %v", err)
+	}
+	if err = loaded.Validate(ctx); err != nil {
+		t.Errorf("Sparrow.generateCheckSpecs() returned an invalid document: %v", err)
+	}
+
+	for _, path := range []string{
+		"/openapi", "/readyz", "/v1/status", "/v1/targets", "/v1/metrics", "/v1/team-a/metrics",
+		"/v1/metrics/health", "/v1/metrics/latency", "/v1/team-a/metrics/health",
+		"/v1/checks/health/run", "/v1/team-a/checks/health/run",
+	} {
+		if loaded.Paths.Find(path) == nil {
+			t.Errorf("Expected path %q not found", path)
+		}
+	}
+	for _, schema := range []string{schemaResult, schemaError, schemaStatus, schemaGlobalTarget, "HealthResult", "HealthResultV2", "LatencyResult", "LatencyResultV1"} {
+		if _, ok := loaded.Components.Schemas[schema]; !ok {
+			t.Errorf("Expected schema component %q not found", schema)
+		}
+	}
+
+	s.config = &config.Config{}
+	doc, err = s.generateCheckSpecs(ctx)
+	if err != nil {
+		t.Fatalf("Sparrow.generateCheckSpecs() error = %v", err)
+	}
+	for _, path := range []string{"/v1/checks/health/run", "/v1/team-a/checks/health/run"} {
+		if doc.Paths.Value(path) != nil {
+			t.Errorf("Path %q is documented, although running on demand is disabled", path)
+		}
+	}
+	for _, response := range []string{responseBadRequest, responseNotFound, responseNotAcceptable, responseInternalServerError} {
+		if _, ok := loaded.Components.Responses[response]; !ok {
+			t.Errorf("Expected response component %q not found", response)
+		}
+	}
+}
+
+func TestSetCacheHeaders(t *testing.T) {
+	now := time.Date(2024, 7, 26, 15, 0, 0, 0, time.UTC)
+	hc := health.NewCheck()
+	if err := hc.UpdateConfig(&health.Config{Interval: time.Minute}); err != nil {
+		t.Fatalf("Failed to configure health check: %v", err)
+	}
+	lc := latency.NewCheck()
+	if err := lc.UpdateConfig(&latency.Config{Interval: 5 * time.Minute}); err != nil {
+		t.Fatalf("Failed to configure latency check: %v", err)
+	}
+	cc := &ChecksController{checks: runtime.Checks{}}
+	cc.checks.Add(hc)
+	cc.checks.Add(lc)
+
+	tests := []struct {
+		name    string
+		results map[string]checks.Result
+		want    http.Header
+	}{
+		{
+			name:    "single check",
+			results: map[string]checks.Result{health.CheckName: {Timestamp: now.Add(-20 * time.Second)}},
+			want: http.Header{
+				"Cache-Control": {"max-age=60"},
+				"Age":           {"20"},
+				"X-Next-Run":    {"2024-07-26T15:00:40Z"},
+			},
+		},
+		{
+			name: "check running next",
+			results: map[string]checks.Result{
+				health.CheckName:  {Timestamp: now.Add(-50 * time.Second)},
+				latency.CheckName: {Timestamp: now.Add(-time.Minute)},
+			},
+			want: http.Header{
+				"Cache-Control": {"max-age=60"},
+				"Age":           {"50"},
+				"X-Next-Run":    {"2024-07-26T15:00:10Z"},
+			},
+		},
+		{
+			name: "unknown check",
+			results: map[string]checks.Result{
+				health.CheckName: {Timestamp: now},
+				"unknown":        {Timestamp: now},
+			},
+			want: http.Header{},
+		},
+		{
+			name:    "no results",
+			results: map[string]checks.Result{},
+			want:    http.Header{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			setCacheHeaders(w, cc, tt.results, now)
+			if got := w.Header(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setCacheHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleStatus(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	dbase := db.NewInMemory()
+	dbase.Save(checks.ResultDTO{Name: health.CheckName, Result: &checks.Result{Timestamp: now, Data: 1}})
+
+	cc := &ChecksController{checks: runtime.Checks{}, instance: "sparrow.example.com"}
+	cc.checks.Add(latency.NewCheck())
+	cc.checks.Add(health.NewCheck())
+	s := &Sparrow{
+		db:         dbase,
+		controller: cc,
+		tenants: map[string]*tenant{
+			"team-b": {name: "team-b"},
+			"team-a": {name: "team-a"},
+		},
+		capabilities: checks.Capabilities{UnprivilegedICMP: true},
+	}
+
+	w := httptest.NewRecorder()
+	s.handleStatus(w, httptest.NewRequest(http.MethodGet, "/v1/status", http.NoBody))
+	resp := w.Result() //nolint:bodyclose
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Sparrow.handleStatus() = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	var got Status
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	want := Status{
+		Instance: "sparrow.example.com",
+		Checks: []CheckStatus{
+			{Name: health.CheckName, LastRun: &now},
+			{Name: latency.CheckName},
+		},
+		Tenants:      []string{"team-a", "team-b"},
+		Capabilities: checks.Capabilities{UnprivilegedICMP: true},
+		Degraded:     degradedChecks(checks.Capabilities{UnprivilegedICMP: true}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sparrow.handleStatus() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSparrow_handleReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenantDB   db.DB
+		wantStatus int
+	}{
+		{name: "ready", tenantDB: db.NewInMemory(), wantStatus: http.StatusOK},
+		{name: "degraded tenant database", tenantDB: failingBuffered(), wantStatus: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{
+				db:      db.NewBuffered(db.NewInMemory(), resultBufferSize),
+				tenants: map[string]*tenant{"team-a": {name: "team-a", db: tt.tenantDB}},
+			}
+
+			w := httptest.NewRecorder()
+			s.handleReady(w, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Sparrow.handleReady() = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+// brokenDB is a database failing to save any result
+type brokenDB struct{ *db.InMemory }
+
+func (brokenDB) Save(checks.ResultDTO) error { return errors.New("database is locked") }
+
+// failingBuffered returns a buffered database with a result its backend failed to save
+func failingBuffered() db.DB {
+	b := db.NewBuffered(brokenDB{db.NewInMemory()}, 1)
+	_ = b.Save(checks.ResultDTO{Name: health.CheckName, Result: &checks.Result{}})
+	return b
+}
+
+func TestSparrow_handleTargets(t *testing.T) {
+	lastSeen := time.Now().UTC().Truncate(time.Second)
+	tests := []struct {
+		name   string
+		tarMan targets.TargetManager
+		want   []checks.GlobalTarget
+	}{
+		{
+			name: "no target manager",
+			want: []checks.GlobalTarget{},
+		},
+		{
+			name: "with targets",
+			tarMan: &managermock.MockTargetManager{
+				Targets: []checks.GlobalTarget{{Url: "https://sparrow.example.com", LastSeen: lastSeen}},
+			},
+			want: []checks.GlobalTarget{{Url: "https://sparrow.example.com", LastSeen: lastSeen}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{tarMan: tt.tarMan}
+
+			w := httptest.NewRecorder()
+			s.handleTargets(w, httptest.NewRequest(http.MethodGet, "/v1/targets", http.NoBody))
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Sparrow.handleTargets() = %v, want %v", resp.StatusCode, http.StatusOK)
+			}
+			var got []checks.GlobalTarget
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sparrow.handleTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleConfigSchema(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		want      int
+		wantTitle string
+	}{
+		{name: "both configurations", want: http.StatusOK, wantTitle: "sparrow configuration"},
+		{name: "startup configuration", query: "?kind=startup", want: http.StatusOK, wantTitle: "sparrow startup configuration"},
+		{name: "runtime configuration", query: "?kind=runtime", want: http.StatusOK, wantTitle: "sparrow runtime configuration"},
+		{name: "unknown kind", query: "?kind=unknown", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{}
+
+			w := httptest.NewRecorder()
+			s.handleConfigSchema(w, httptest.NewRequest(http.MethodGet, "/v1/config/schema"+tt.query, http.NoBody))
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.want {
+				t.Fatalf("Sparrow.handleConfigSchema() = %v, want %v", resp.StatusCode, tt.want)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != "application/schema+json" {
+				t.Errorf("Content-Type = %q, want %q", ct, "application/schema+json")
+			}
+			var got map[string]any
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if got["title"] != tt.wantTitle {
+				t.Errorf("Sparrow.handleConfigSchema() title = %v, want %v", got["title"], tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleConfig(t *testing.T) {
+	provenance := checks.NewProvenance("http", "https://gitlab.example.com/config.yaml", []byte("health: {}"))
+	provenance.Commit = "8a1f2b3c"
+	cfg := runtime.Config{
+		Health: &health.Config{
+			Targets: []string{"https://a.example.com"},
+			Auth: []checks.TargetAuth{{
+				Targets: []string{"https://a.example.com"},
+				OAuth2:  oauth.Config{TokenURL: "https://idp.example.com/token", ClientID: "sparrow", ClientSecret: "secret"},
+			}},
+		},
+		Provenance: provenance,
+	}
+
+	tests := []struct {
+		name     string
+		tenant   string
+		wantCode int
+	}{
+		{name: "default configuration", wantCode: http.StatusOK},
+		{name: "tenant configuration", tenant: "team-a", wantCode: http.StatusOK},
+		{name: "unknown tenant", tenant: "team-b", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{
+				controller: &ChecksController{cfg: cfg},
+				tenants:    map[string]*tenant{"team-a": {name: "team-a", controller: &ChecksController{cfg: cfg}}},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/config", http.NoBody)
+			if tt.tenant != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add(urlParamTenant, tt.tenant)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+			s.handleConfig(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Sparrow.handleConfig() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var got runtime.Config
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if got.Provenance == nil || got.Provenance.Commit != provenance.Commit || got.Provenance.Digest != provenance.Digest {
+				t.Errorf("Sparrow.handleConfig() provenance = %+v, want %+v", got.Provenance, provenance)
+			}
+			if secret := got.Health.Auth[0].OAuth2.ClientSecret; secret == "secret" {
+				t.Error("Sparrow.handleConfig() exposed the client secret")
+			}
+			if cfg.Health.Auth[0].OAuth2.ClientSecret != "secret" {
+				t.Error("Sparrow.handleConfig() redacted the applied configuration")
+			}
+		})
+	}
+}
+
+func TestSparrow_handlePushConfig(t *testing.T) {
+	const valid = "health:\n  interval: 20s\n  timeout: 5s\n  targets:\n    - https://{{ .instanceName }}\n"
+
+	tests := []struct {
+		name     string
+		tenant   string
+		token    string
+		body     string
+		wantCode int
+	}{
+		{name: "valid configuration", token: "secret", body: valid, wantCode: http.StatusAccepted},
+		{name: "tenant configuration", tenant: "team-a", token: "secret", body: valid, wantCode: http.StatusAccepted},
+		{name: "missing token", body: valid, wantCode: http.StatusUnauthorized},
+		{name: "wrong token", token: "guess", body: valid, wantCode: http.StatusUnauthorized},
+		{name: "unknown tenant", tenant: "team-b", token: "secret", body: valid, wantCode: http.StatusNotFound},
+		{name: "empty configuration", token: "secret", body: "{}", wantCode: http.StatusBadRequest},
+		{name: "malformed configuration", token: "secret", body: "health: [", wantCode: http.StatusBadRequest},
+		{
+			name:     "invalid configuration",
+			token:    "secret",
+			body:     "health:\n  interval: 20s\n  timeout: 5s\n  targets:\n    - not a url\n",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{
+				config:   &config.Config{SparrowName: "sparrow.example.com", Loader: config.LoaderConfig{Push: config.PushLoaderConfig{Token: "secret"}}},
+				cRuntime: make(chan runtime.Config, 1),
+				tenants:  map[string]*tenant{"team-a": {name: "team-a", cRuntime: make(chan runtime.Config, 1)}},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/v1/config/runtime", bytes.NewBufferString(tt.body))
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			cRuntime := s.cRuntime
+			if tt.tenant != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add(urlParamTenant, tt.tenant)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+				cRuntime = s.tenants["team-a"].cRuntime
+			}
+			s.handlePushConfig(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Sparrow.handlePushConfig() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusAccepted {
+				if len(s.cRuntime) > 0 || len(s.tenants["team-a"].cRuntime) > 0 {
+					t.Error("Sparrow.handlePushConfig() applied a rejected configuration")
+				}
+				return
+			}
+
+			select {
+			case cfg := <-cRuntime:
+				if got := cfg.Health.Targets; len(got) != 1 || got[0] != "https://sparrow.example.com" {
+					t.Errorf("Sparrow.handlePushConfig() targets = %v, want the rendered target", got)
+				}
+				if cfg.Provenance == nil || cfg.Provenance.Loader != "push" {
+					t.Errorf("Sparrow.handlePushConfig() provenance = %+v, want a push provenance", cfg.Provenance)
+				}
+			default:
+				t.Fatal("Sparrow.handlePushConfig() did not apply the configuration")
+			}
+		})
+	}
+}
+
+func TestSparrow_handlePushConfig_tooLarge(t *testing.T) {
+	s := &Sparrow{
+		config:   &config.Config{Loader: config.LoaderConfig{Push: config.PushLoaderConfig{Token: "secret"}}},
+		cRuntime: make(chan runtime.Config, 1),
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPut, "/v1/config/runtime", bytes.NewReader(make([]byte, maxPushedConfigSize+1)))
+	r.Header.Set("Authorization", "Bearer secret")
+	s.handlePushConfig(w, r)
+
+	if code := w.Result().StatusCode; code != http.StatusRequestEntityTooLarge { //nolint:bodyclose
+		t.Errorf("Sparrow.handlePushConfig() = %v, want %v", code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+// TestSparrow_client ensures the client generated from the OpenAPI specification is compatible with the handlers of the API
+func TestSparrow_client(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	dbase := db.NewInMemory()
+	data := map[string]any{"https://example.com": map[string]any{"status": "healthy"}}
+	dbase.Save(checks.ResultDTO{Name: health.CheckName, Result: &checks.Result{Timestamp: now, Data: data, Instance: "sparrow"}})
+
+	cc := &ChecksController{checks: runtime.Checks{}, instance: "sparrow"}
+	cc.checks.Add(health.NewCheck())
+	s := &Sparrow{
+		db:         dbase,
+		controller: cc,
+		tarMan: &managermock.MockTargetManager{
+			Targets: []checks.GlobalTarget{{Url: "https://sparrow.example.com", LastSeen: now}},
+		},
+	}
+
+	r := chi.NewRouter()
+	r.Get("/openapi", s.handleOpenAPI)
+	r.Get("/v1/status", s.handleStatus)
+	r.Get("/v1/targets", s.handleTargets)
+	r.Get("/v1/events", s.handleEvents)
+	r.Get("/v1/metrics", s.handleMetrics)
+	r.Get(fmt.Sprintf("/v1/metrics/{%s}", urlParamCheckName), s.handleCheckMetrics)
+	r.Get(fmt.Sprintf("/v1/metrics/{%s}/targets/{%s}/history", urlParamCheckName, urlParamTarget), s.handleTargetHistory)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	c, err := client.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("client.NewClientWithResponses() error = %v", err)
+	}
+
+	status, err := c.GetStatusWithResponse(ctx)
+	if err != nil || status.JSON200 == nil {
+		t.Fatalf("Client.GetStatus() = %v, %v", status, err)
+	}
+	if *status.JSON200.Instance != "sparrow" || len(*status.JSON200.Checks) != 1 ||
+		(*status.JSON200.Checks)[0].LastRun == nil || !(*status.JSON200.Checks)[0].LastRun.Equal(now) ||
+		status.JSON200.Capabilities == nil {
+		t.Errorf("Client.GetStatus() = %s", status.Body)
+	}
+
+	tars, err := c.GetTargetsWithResponse(ctx)
+	if err != nil || tars.JSON200 == nil || len(*tars.JSON200) != 1 || *(*tars.JSON200)[0].Url != "https://sparrow.example.com" {
+		t.Errorf("Client.GetTargets() = %v, %v", tars, err)
+	}
+
+	events, err := c.GetEventsWithResponse(ctx)
+	if err != nil || events.JSON200 == nil {
+		t.Errorf("Client.GetEvents() = %v, %v", events, err)
+	}
+
+	since := now.Add(-time.Minute)
+	results, err := c.GetMetricsWithResponse(ctx, &client.GetMetricsParams{Check: &[]string{health.CheckName}, Since: &since})
+	if err != nil || results.JSON200 == nil || len(*results.JSON200) != 1 || *(*results.JSON200)[health.CheckName].Instance != "sparrow" {
+		t.Errorf("Client.GetMetrics() = %v, %v", results, err)
+	}
+
+	res, err := c.GetCheckMetricsHealthWithResponse(ctx, &client.GetCheckMetricsHealthParams{})
+	if err != nil || res.JSON200 == nil || *(*res.JSON200.Data)["https://example.com"].Status != "healthy" || !res.JSON200.Timestamp.Equal(now) {
+		t.Errorf("Client.GetCheckMetricsHealth() = %v, %v", res, err)
+	}
+
+	missing, err := c.GetCheckMetricsLatencyWithResponse(ctx, &client.GetCheckMetricsLatencyParams{})
+	if err != nil || missing.StatusCode() != http.StatusNotFound {
+		t.Errorf("Client.GetCheckMetricsLatency() = %v, %v, want not found", missing, err)
+	}
+
+	history, err := c.GetTargetHistoryWithResponse(ctx, health.CheckName, "https://example.com")
+	if err != nil || history.StatusCode() != http.StatusNotFound {
+		t.Errorf("Client.GetTargetHistory() = %v, %v, want not found", history, err)
+	}
+
+	doc, err := c.GetOpenapiWithResponse(ctx, func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Accept", "application/json")
+		return nil
+	})
+	if err != nil || doc.StatusCode() != http.StatusOK || !strings.Contains(string(doc.Body), "/v1/metrics/health") {
+		t.Errorf("Client.GetOpenapi() = %v, %v, want the path of the health check", doc, err)
+	}
+}
+
+func TestSparrow_handleMetrics_contentNegotiation(t *testing.T) {
+	s := &Sparrow{db: testDb()}
+
+	for _, accept := range []string{api.ContentTypeJSON, api.ContentTypeCBOR, api.ContentTypeMsgpack} {
+		t.Run(accept, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/metrics", http.NoBody)
+			r.Header.Set("Accept", accept)
+
+			s.handleMetrics(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Sparrow.handleMetrics() = %v, want %v", resp.StatusCode, http.StatusOK)
+			}
+			if got := resp.Header.Get("Content-Type"); got != accept {
+				t.Errorf("Sparrow.handleMetrics() content type = %q, want %q", got, accept)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleRunCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := logger.NewContextWithLogger(context.Background())
+	defer cancel()
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
+	defer cc.Shutdown(ctx)
+	cc.Reconcile(ctx, runtime.Config{Health: &health.Config{
+		Targets:  []string{srv.URL, "https://unreachable.invalid"},
+		Interval: time.Hour,
+		Timeout:  time.Second,
+	}})
+	s := &Sparrow{controller: cc, db: cc.db, config: &config.Config{Api: api.Config{RunToken: "secret"}}}
+
+	tests := []struct {
+		name       string
+		check      string
+		targets    []string
+		token      string
+		running    int
+		wantCode   int
+		wantFailed []string
+	}{
+		{
+			name:       "runs the check for the selected targets",
+			check:      health.CheckName,
+			targets:    []string{srv.URL},
+			token:      "secret",
+			wantCode:   http.StatusOK,
+			wantFailed: []string{},
+		},
+		{
+			name:     "unknown target",
+			check:    health.CheckName,
+			targets:  []string{"https://example.com"},
+			token:    "secret",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "check not registered",
+			check:    latency.CheckName,
+			token:    "secret",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "missing token",
+			check:    health.CheckName,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "wrong token",
+			check:    health.CheckName,
+			token:    "guess",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "too many runs",
+			check:    health.CheckName,
+			token:    "secret",
+			running:  maxRunsOnDemand,
+			wantCode: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range tt.running {
+				if !cc.acquireRun(tt.check) {
+					t.Fatal("ChecksController.acquireRun() = false, want true")
+				}
+				defer cc.releaseRun(tt.check)
+			}
+
+			query := url.Values{queryParamTarget: tt.targets}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/v1/checks/"+tt.check+"/run?"+query.Encode(), http.NoBody)
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("checkName", tt.check)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			s.handleRunCheck(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Sparrow.handleRunCheck() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var got struct {
+				Result struct {
+					Data     map[string]any `json:"data"`
+					Instance string         `json:"instance"`
+				} `json:"result"`
+				Failed []string `json:"failed"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(got.Result.Data) != len(tt.targets) {
+				t.Errorf("Sparrow.handleRunCheck() data = %v, want results of %v", got.Result.Data, tt.targets)
+			}
+			if got.Result.Instance != "sparrow.com" {
+				t.Errorf("Sparrow.handleRunCheck() instance = %q, want %q", got.Result.Instance, "sparrow.com")
+			}
+			if !reflect.DeepEqual(got.Failed, tt.wantFailed) {
+				t.Errorf("Sparrow.handleRunCheck() failed = %v, want %v", got.Failed, tt.wantFailed)
+			}
+			if _, ok := s.db.Get(health.CheckName); ok {
+				t.Error("Sparrow.handleRunCheck() stored the result of the run")
+			}
+		})
+	}
+}
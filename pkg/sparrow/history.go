@@ -0,0 +1,190 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+)
+
+const (
+	// historySize is the number of probe outcomes kept per target. The oldest outcomes are dropped if the limit is exceeded.
+	historySize = 50
+	// historyRetention is the time the history of a target is kept after its latest outcome,
+	// so the histories of removed targets are dropped eventually
+	historyRetention = 24 * time.Hour
+)
+
+// The categories of the errors of failed probes
+const (
+	errorTimeout     = "timeout"
+	errorDNS         = "dns"
+	errorRefused     = "refused"
+	errorReset       = "reset"
+	errorUnreachable = "unreachable"
+	errorTLS         = "tls"
+	errorOther       = "other"
+	// errorUnknown is the category of failed probes whose check doesn't report an error message
+	errorUnknown = "unknown"
+)
+
+// Outcome is the outcome of the probe of a target
+type Outcome struct {
+	// Timestamp is the time of the result the outcome is part of
+	Timestamp time.Time `json:"timestamp"`
+	// Failed is true if the probe failed
+	Failed bool `json:"failed"`
+	// Latency is the duration of the probe in seconds, if the check measures it
+	Latency *float64 `json:"latency,omitempty"`
+	// Error is the category of the error of a failed probe
+	Error string `json:"error,omitempty"`
+}
+
+// targetHistory keeps the latest probe outcomes of every target of the checks
+type targetHistory struct {
+	mu sync.Mutex
+	// outcomes are the outcomes mapped by check name and target, oldest first
+	outcomes map[string]map[string][]Outcome
+}
+
+// record adds the outcome of every target of the result to its history.
+// Results of unknown checks and results without targets are ignored.
+func (h *targetHistory) record(result checks.ResultDTO) {
+	if result.Result == nil {
+		return
+	}
+	failures := runtime.TargetFailures(result.Name, result.Result.Data)
+	if len(failures) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.outcomes == nil {
+		h.outcomes = map[string]map[string][]Outcome{}
+	}
+	targets, ok := h.outcomes[result.Name]
+	if !ok {
+		targets = map[string][]Outcome{}
+		h.outcomes[result.Name] = targets
+	}
+
+	for target, failed := range failures {
+		o := Outcome{Timestamp: result.Result.Timestamp, Failed: failed}
+		latency, msg := probeDetails(result.Result.Data, target)
+		o.Latency = latency
+		if failed {
+			o.Error = errorCategory(msg)
+		}
+
+		outcomes := append(targets[target], o)
+		if len(outcomes) > historySize {
+			outcomes = outcomes[len(outcomes)-historySize:]
+		}
+		targets[target] = outcomes
+	}
+}
+
+// list returns the outcomes of the target of the check, oldest first.
+// It returns false if there's no outcome of the target.
+func (h *targetHistory) list(check, target string) ([]Outcome, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	outcomes, ok := h.outcomes[check][target]
+	if !ok {
+		return nil, false
+	}
+	res := make([]Outcome, len(outcomes))
+	copy(res, outcomes)
+	return res, true
+}
+
+// prune drops the histories of the targets without an outcome since the given time
+func (h *targetHistory) prune(since time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for check, targets := range h.outcomes {
+		for target, outcomes := range targets {
+			if outcomes[len(outcomes)-1].Timestamp.Before(since) {
+				delete(targets, target)
+			}
+		}
+		if len(targets) == 0 {
+			delete(h.outcomes, check)
+		}
+	}
+}
+
+// probeDetails returns the latency in seconds and the error message of the target's result in the result data,
+// if the check reports them in the common total and error fields
+func probeDetails(data any, target string) (latency *float64, msg string) {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, ""
+	}
+	res := v.MapIndex(reflect.ValueOf(target).Convert(v.Type().Key()))
+	if !res.IsValid() {
+		return nil, ""
+	}
+
+	b, err := json.Marshal(res.Interface())
+	if err != nil {
+		return nil, ""
+	}
+	var details struct {
+		Total *float64 `json:"total"`
+		Error *string  `json:"error"`
+	}
+	if err = json.Unmarshal(b, &details); err != nil {
+		return nil, ""
+	}
+	if details.Error != nil {
+		msg = *details.Error
+	}
+	return details.Total, msg
+}
+
+// errorCategory returns the category of the error message of a failed probe
+func errorCategory(msg string) string {
+	m := strings.ToLower(msg)
+	switch {
+	case m == "":
+		return errorUnknown
+	case strings.Contains(m, "timeout"), strings.Contains(m, "deadline exceeded"), strings.Contains(m, "timed out"):
+		return errorTimeout
+	case strings.Contains(m, "no such host"), strings.Contains(m, "server misbehaving"), strings.Contains(m, "lookup "):
+		return errorDNS
+	case strings.Contains(m, "connection refused"):
+		return errorRefused
+	case strings.Contains(m, "connection reset"), strings.Contains(m, "broken pipe"), strings.Contains(m, "eof"):
+		return errorReset
+	case strings.Contains(m, "network is unreachable"), strings.Contains(m, "no route to host"):
+		return errorUnreachable
+	case strings.Contains(m, "tls:"), strings.Contains(m, "x509:"), strings.Contains(m, "certificate"):
+		return errorTLS
+	default:
+		return errorOther
+	}
+}
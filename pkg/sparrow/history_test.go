@@ -0,0 +1,179 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/sparrow/metrics"
+	"github.com/go-chi/chi/v5"
+)
+
+// latencyResult returns a latency result with the given per target outcomes.
+// A target with an error message failed.
+func latencyResult(ts time.Time, totals map[string]float64, errs map[string]string) checks.ResultDTO {
+	data := map[string]any{}
+	for target, total := range totals {
+		r := map[string]any{"code": http.StatusOK, "total": total, "error": nil}
+		if msg, ok := errs[target]; ok {
+			r["code"] = 0
+			r["error"] = msg
+		}
+		data[target] = r
+	}
+	return checks.ResultDTO{Name: latency.CheckName, Result: &checks.Result{Data: data, Timestamp: ts}}
+}
+
+func TestTargetHistory_record(t *testing.T) {
+	now := time.Now()
+	var h targetHistory
+	h.record(latencyResult(now, map[string]float64{"https://a": 0.1, "https://b": 0.2}, nil))
+	h.record(latencyResult(now.Add(time.Minute), map[string]float64{"https://a": 1.5, "https://b": 0.2},
+		map[string]string{"https://a": "Get \"https://a\": context deadline exceeded"}))
+	h.record(checks.ResultDTO{Name: "unknown", Result: &checks.Result{Data: map[string]any{"x": 1}}})
+	h.record(checks.ResultDTO{Name: latency.CheckName})
+
+	got, ok := h.list(latency.CheckName, "https://a")
+	if !ok || len(got) != 2 {
+		t.Fatalf("list() = %v, %v, want 2 outcomes", got, ok)
+	}
+	if got[0].Failed || got[0].Error != "" || got[0].Latency == nil || *got[0].Latency != 0.1 {
+		t.Errorf("First outcome = %+v, want a successful probe of 0.1s", got[0])
+	}
+	if !got[1].Failed || got[1].Error != errorTimeout || !got[1].Timestamp.Equal(now.Add(time.Minute)) {
+		t.Errorf("Second outcome = %+v, want a timed out probe", got[1])
+	}
+	if _, ok := h.list("unknown", "x"); ok {
+		t.Error("list() should not return outcomes of unknown checks")
+	}
+	if _, ok := h.list(latency.CheckName, "https://c"); ok {
+		t.Error("list() should not return outcomes of unknown targets")
+	}
+}
+
+func TestTargetHistory_size(t *testing.T) {
+	now := time.Now()
+	var h targetHistory
+	for i := range historySize + 10 {
+		h.record(latencyResult(now.Add(time.Duration(i)*time.Second), map[string]float64{"https://a": float64(i)}, nil))
+	}
+
+	got, _ := h.list(latency.CheckName, "https://a")
+	if len(got) != historySize {
+		t.Fatalf("list() returned %d outcomes, want %d", len(got), historySize)
+	}
+	if *got[0].Latency != 10 || *got[historySize-1].Latency != historySize+9 {
+		t.Errorf("list() = %v ... %v, want the latest outcomes oldest first", *got[0].Latency, *got[historySize-1].Latency)
+	}
+}
+
+func TestTargetHistory_prune(t *testing.T) {
+	now := time.Now()
+	var h targetHistory
+	h.record(latencyResult(now.Add(-2*historyRetention), map[string]float64{"https://old": 0.1}, nil))
+	h.record(latencyResult(now, map[string]float64{"https://new": 0.1}, nil))
+
+	h.prune(now.Add(-historyRetention))
+	if _, ok := h.list(latency.CheckName, "https://old"); ok {
+		t.Error("prune() should have dropped the history of the removed target")
+	}
+	if _, ok := h.list(latency.CheckName, "https://new"); !ok {
+		t.Error("prune() should have kept the history of the current target")
+	}
+}
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{msg: "", want: errorUnknown},
+		{msg: "Get \"https://a\": context deadline exceeded (Client.Timeout exceeded while awaiting headers)", want: errorTimeout},
+		{msg: "lookup a.example.com on 10.0.0.10:53: no such host", want: errorDNS},
+		{msg: "dial tcp 10.0.0.1:443: connect: connection refused", want: errorRefused},
+		{msg: "read tcp 10.0.0.2:51234->10.0.0.1:443: read: connection reset by peer", want: errorReset},
+		{msg: "dial tcp 10.0.0.1:443: connect: no route to host", want: errorUnreachable},
+		{msg: "tls: failed to verify certificate: x509: certificate has expired", want: errorTLS},
+		{msg: "unexpected status code 503", want: errorOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := errorCategory(tt.msg); got != tt.want {
+				t.Errorf("errorCategory(%q) = %q, want %q", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleTargetHistory(t *testing.T) {
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow"), "sparrow")
+	cc.history.record(latencyResult(time.Now(), map[string]float64{"https://example.com/": 0.1}, nil))
+	s := &Sparrow{controller: cc}
+
+	tests := []struct {
+		name     string
+		check    string
+		target   string
+		wantCode int
+	}{
+		{name: "known target", check: latency.CheckName, target: url.PathEscape("https://example.com/"), wantCode: http.StatusOK},
+		{name: "unknown target", check: latency.CheckName, target: url.PathEscape("https://other.com/"), wantCode: http.StatusNotFound},
+		{name: "unknown check", check: "health", target: url.PathEscape("https://example.com/"), wantCode: http.StatusNotFound},
+		{name: "invalid target", check: latency.CheckName, target: "%zz", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/metrics/%s/targets/x/history", tt.check), http.NoBody)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("checkName", tt.check)
+			rctx.URLParams.Add("target", tt.target)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			s.handleTargetHistory(w, r)
+			resp := w.Result() //nolint:bodyclose
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Sparrow.handleTargetHistory() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var outcomes []Outcome
+			if err := json.NewDecoder(resp.Body).Decode(&outcomes); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(outcomes) != 1 || outcomes[0].Failed {
+				t.Errorf("Sparrow.handleTargetHistory() = %v, want one successful outcome", outcomes)
+			}
+		})
+	}
+}
@@ -49,12 +49,15 @@ type manager struct {
 	config   Config
 	registry *prometheus.Registry
 	tp       *sdktrace.TracerProvider
+	// instance is the identity of the sparrow instance
+	instance string
 }
 
-// New initializes the metrics and returns the PrometheusMetrics
+// New initializes the metrics and returns the PrometheusMetrics.
+// The instance is the identity of the sparrow used as the service instance id of the traces.
 //
 //nolint:gocritic
-func New(config Config) Provider {
+func New(config Config, instance string) Provider {
 	registry := prometheus.NewRegistry()
 
 	registry.MustRegister(
@@ -65,6 +68,7 @@ func New(config Config) Provider {
 	return &manager{
 		config:   config,
 		registry: registry,
+		instance: instance,
 	}
 }
 
@@ -83,6 +87,7 @@ func (m *manager) InitTracing(ctx context.Context) error {
 			semconv.ServiceNameKey.String("sparrow-metrics-api"),
 			// TODO: Maybe we should use the version that is set on build time in the main package
 			semconv.ServiceVersionKey.String("0.1.0"),
+			semconv.ServiceInstanceIDKey.String(m.instance),
 		),
 	)
 	if err != nil {
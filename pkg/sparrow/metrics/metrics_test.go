@@ -53,7 +53,7 @@ func TestPrometheusMetrics_GetRegistry(t *testing.T) {
 }
 
 func TestNewMetrics(t *testing.T) {
-	testMetrics := New(Config{})
+	testMetrics := New(Config{}, "sparrow.com")
 	testGauge := prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "TEST_GAUGE",
@@ -121,7 +121,7 @@ func TestMetrics_InitTracing(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := New(tt.config)
+			m := New(tt.config, "sparrow.com")
 			if err := m.InitTracing(context.Background()); (err != nil) != tt.wantErr {
 				t.Errorf("Metrics.InitTracing() error = %v", err)
 			}
@@ -0,0 +1,184 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/factory"
+)
+
+// ErrChecksFailed is returned by RunOnce if any target of a check failed
+var ErrChecksFailed = errors.New("checks failed")
+
+// onceReport is the report written after running the checks once
+type onceReport struct {
+	// Results are the results of the checks mapped by their name
+	Results map[string]*checks.Result `json:"results"`
+	// Failed are the failed targets mapped by the name of their check
+	Failed map[string][]string `json:"failed,omitempty"`
+}
+
+// RunOnce loads the runtime configuration, runs every configured check once
+// and writes the results as JSON to the configured output.
+// It returns ErrChecksFailed if any target of a check failed.
+func RunOnce(ctx context.Context, cfg *config.Config) error {
+	log := logger.FromContext(ctx)
+
+	rtCfg, err := loadOnce(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	log.InfoContext(ctx, "Running checks once", "checks", len(rtCfg.Iter()))
+	report, err := runChecksOnce(ctx, cfg.SparrowName, rtCfg)
+	if err != nil {
+		return err
+	}
+
+	if err = writeReport(cfg.Once.Output, report); err != nil {
+		return fmt.Errorf("failed to write the results: %w", err)
+	}
+
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("%w: %d of %d checks reported failed targets", ErrChecksFailed, len(report.Failed), len(report.Results))
+	}
+	return nil
+}
+
+// loadOnce loads the runtime configuration a single time with the configured loader
+func loadOnce(ctx context.Context, cfg *config.Config) (runtime.Config, error) {
+	lcfg := *cfg
+	lcfg.Loader.Interval = 0
+
+	// The loader sends the configuration before it returns
+	cRuntime := make(chan runtime.Config, 1)
+	err := config.NewLoader(&lcfg, cRuntime).Run(ctx)
+	rtCfg := <-cRuntime
+	if err != nil {
+		return rtCfg, fmt.Errorf("failed to load the runtime configuration: %w", err)
+	}
+	if rtCfg.Empty() {
+		return rtCfg, errors.New("no checks are configured")
+	}
+	return rtCfg, nil
+}
+
+// runChecksOnce runs every check of the runtime configuration once.
+// The checks run concurrently, except for dependent checks, which run
+// after the checks they depend on with their targets gated by the results.
+func runChecksOnce(ctx context.Context, instance string, cfg runtime.Config) (onceReport, error) {
+	log := logger.FromContext(ctx)
+	if err := cfg.Validate(); err != nil {
+		return onceReport{}, fmt.Errorf("invalid runtime configuration: %w", err)
+	}
+
+	pending := map[string]bool{}
+	for _, c := range cfg.Iter() {
+		pending[c.For()] = true
+	}
+
+	report := onceReport{Results: map[string]*checks.Result{}, Failed: map[string][]string{}}
+	results := map[string]checks.Result{}
+	var mu sync.Mutex
+	for len(pending) > 0 {
+		wave := nextWave(cfg, pending)
+		cs, err := factory.NewChecksFromConfig(cfg.Gate(results))
+		if err != nil {
+			return onceReport{}, fmt.Errorf("failed to create checks: %w", err)
+		}
+
+		var wg sync.WaitGroup
+		for _, name := range wave {
+			delete(pending, name)
+			o, ok := cs[name].(checks.Oneshot)
+			if !ok {
+				log.WarnContext(ctx, "Check can't run once, skipping it", "check", name)
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, failed := o.RunOnce(ctx)
+				res.Instance = instance
+				res.Provenance = cfg.Provenance
+
+				mu.Lock()
+				defer mu.Unlock()
+				results[name] = *res
+				report.Results[name] = res
+				if len(failed) > 0 {
+					report.Failed[name] = failed
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	return report, nil
+}
+
+// nextWave returns the names of the pending checks whose dependencies have all run.
+// If the dependencies are cyclic, all pending checks are returned.
+func nextWave(cfg runtime.Config, pending map[string]bool) []string {
+	var wave []string
+	for name := range pending {
+		ready := true
+		for _, d := range cfg.Dependencies {
+			if d.Check == name && pending[d.DependsOn] {
+				ready = false
+			}
+		}
+		if ready {
+			wave = append(wave, name)
+		}
+	}
+
+	if len(wave) == 0 {
+		for name := range pending {
+			wave = append(wave, name)
+		}
+	}
+	return wave
+}
+
+// writeReport writes the report as JSON to the given file or to stdout if no file is given
+func writeReport(path string, report any) error {
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if path == "" {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
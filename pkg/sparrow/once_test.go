@@ -0,0 +1,185 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// newOnceServer returns a server answering /ok with 200 and every other path with 500
+func newOnceServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRunChecksOnce(t *testing.T) {
+	// The failing target is addressed by another host name, because dependencies match targets by their host
+	srv := newOnceServer(t)
+	ok, fail := srv.URL+"/ok", strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)+"/fail"
+
+	tests := []struct {
+		name        string
+		cfg         runtime.Config
+		wantFailed  map[string][]string
+		wantLatency []string
+	}{
+		{
+			name: "health check",
+			cfg: runtime.Config{
+				Health: &health.Config{Targets: []string{ok, fail}, Interval: time.Minute, Timeout: time.Second},
+			},
+			wantFailed: map[string][]string{health.CheckName: {fail}},
+		},
+		{
+			name: "dependent check runs after its dependency",
+			cfg: runtime.Config{
+				Health:  &health.Config{Targets: []string{ok}, Interval: time.Minute, Timeout: time.Second},
+				Latency: &latency.Config{Targets: []string{ok, srv.URL + "/other"}, Interval: time.Minute, Timeout: time.Second},
+				Dependencies: []runtime.Dependency{
+					{Check: latency.CheckName, DependsOn: health.CheckName, Condition: runtime.ConditionHealthy},
+				},
+			},
+			wantFailed:  map[string][]string{latency.CheckName: {srv.URL + "/other"}},
+			wantLatency: []string{ok, srv.URL + "/other"},
+		},
+		{
+			name: "dependent check only probes targets fulfilling the condition",
+			cfg: runtime.Config{
+				Health:  &health.Config{Targets: []string{ok, fail}, Interval: time.Minute, Timeout: time.Second},
+				Latency: &latency.Config{Targets: []string{ok, fail}, Interval: time.Minute, Timeout: time.Second},
+				Dependencies: []runtime.Dependency{
+					{Check: latency.CheckName, DependsOn: health.CheckName, Condition: runtime.ConditionHealthy},
+				},
+			},
+			wantFailed:  map[string][]string{health.CheckName: {fail}},
+			wantLatency: []string{ok},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := runChecksOnce(context.Background(), "sparrow.com", tt.cfg)
+			require.NoError(t, err)
+
+			assert.Len(t, report.Results, len(tt.cfg.Iter()))
+			for _, res := range report.Results {
+				assert.Equal(t, "sparrow.com", res.Instance)
+			}
+			assert.Equal(t, tt.wantFailed, report.Failed)
+
+			if tt.wantLatency != nil {
+				data, err := json.Marshal(report.Results[latency.CheckName].Data)
+				require.NoError(t, err)
+				var targets map[string]any
+				require.NoError(t, json.Unmarshal(data, &targets))
+
+				var got []string
+				for target := range targets {
+					got = append(got, target)
+				}
+				assert.ElementsMatch(t, tt.wantLatency, got)
+			}
+		})
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	srv := newOnceServer(t)
+	dir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		targets    []string
+		wantErr    error
+		wantFailed bool
+	}{
+		{name: "all targets healthy", targets: []string{srv.URL + "/ok"}},
+		{name: "failed target", targets: []string{srv.URL + "/ok", srv.URL + "/fail"}, wantErr: ErrChecksFailed, wantFailed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := yaml.Marshal(runtime.Config{
+				Health: &health.Config{Targets: tt.targets, Interval: time.Minute, Timeout: time.Second},
+			})
+			require.NoError(t, err)
+			path := filepath.Join(dir, "config.yaml")
+			require.NoError(t, os.WriteFile(path, b, 0o600))
+			output := filepath.Join(dir, "results.json")
+
+			cfg := &config.Config{
+				SparrowName: "sparrow.com",
+				Loader:      config.LoaderConfig{Type: "file", File: config.FileLoaderConfig{Path: path}},
+				Once:        config.OnceConfig{Enabled: true, Output: output},
+			}
+			err = RunOnce(context.Background(), cfg)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "RunOnce() error = %v, want %v", err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			out, err := os.ReadFile(output)
+			require.NoError(t, err)
+			var report struct {
+				Results map[string]json.RawMessage `json:"results"`
+				Failed  map[string][]string        `json:"failed"`
+			}
+			require.NoError(t, json.Unmarshal(out, &report))
+			assert.Contains(t, report.Results, health.CheckName)
+			assert.Equal(t, tt.wantFailed, len(report.Failed) > 0)
+		})
+	}
+}
+
+func TestRunOnce_noChecks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+
+	cfg := &config.Config{
+		SparrowName: "sparrow.com",
+		Loader:      config.LoaderConfig{Type: "file", File: config.FileLoaderConfig{Path: path}},
+		Once:        config.OnceConfig{Enabled: true},
+	}
+	assert.Error(t, RunOnce(context.Background(), cfg))
+}
@@ -0,0 +1,397 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/caas-team/sparrow/pkg/api"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/factory"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+)
+
+const (
+	// schemaResult is the name of the schema component of the result envelope shared by all checks
+	schemaResult = "Result"
+	// schemaError is the name of the schema component of error responses
+	schemaError = "Error"
+	// schemaStatus is the name of the schema component of the status of the sparrow
+	schemaStatus = "Status"
+	// schemaGlobalTarget is the name of the schema component of a global target
+	schemaGlobalTarget = "GlobalTarget"
+	// schemaOutcome is the name of the schema component of the outcome of a probe
+	schemaOutcome = "Outcome"
+	// schemaEvent is the name of the schema component of an event of the runtime configuration
+	schemaEvent = "Event"
+
+	// securityBearer is the name of the security scheme of the routes requiring the bearer token
+	securityBearer = "bearerAuth"
+	// runOperationPrefix is the prefix of the operation ids of the routes running the checks on demand
+	runOperationPrefix = "runCheck_"
+
+	responseBadRequest          = "BadRequest"
+	responseUnauthorized        = "Unauthorized"
+	responseNotFound            = "NotFound"
+	responseNotAcceptable       = "NotAcceptable"
+	responseTooManyRequests     = "TooManyRequests"
+	responseInternalServerError = "InternalServerError"
+)
+
+// newOapiComponents returns the components shared by all routes of the OpenAPI specification
+func newOapiComponents() (*openapi3.Components, error) {
+	components := &openapi3.Components{
+		Schemas:   make(openapi3.Schemas),
+		Responses: make(openapi3.ResponseBodies),
+		SecuritySchemes: openapi3.SecuritySchemes{
+			securityBearer: &openapi3.SecuritySchemeRef{
+				Value: openapi3.NewSecurityScheme().WithType("http").WithScheme("bearer"),
+			},
+		},
+	}
+
+	for name, v := range map[string]any{
+		schemaResult:       checks.Result{},
+		schemaStatus:       Status{},
+		schemaGlobalTarget: checks.GlobalTarget{},
+		schemaOutcome:      Outcome{},
+		schemaEvent:        Event{},
+	} {
+		ref, err := openapi3gen.NewSchemaRefForValue(v, openapi3.Schemas{})
+		if err != nil {
+			return nil, &ErrCreateOpenapiSchema{name: name, err: err}
+		}
+		components.Schemas[name] = ref
+	}
+	errSchema := openapi3.NewStringSchema()
+	errSchema.Description = "The status text of the HTTP status code"
+	components.Schemas[schemaError] = openapi3.NewSchemaRef("", errSchema)
+
+	for name, code := range map[string]int{
+		responseBadRequest:          http.StatusBadRequest,
+		responseUnauthorized:        http.StatusUnauthorized,
+		responseNotFound:            http.StatusNotFound,
+		responseNotAcceptable:       http.StatusNotAcceptable,
+		responseTooManyRequests:     http.StatusTooManyRequests,
+		responseInternalServerError: http.StatusInternalServerError,
+	} {
+		components.Responses[name] = &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().
+				WithDescription(http.StatusText(code)).
+				WithContent(openapi3.NewContentWithSchemaRef(schemaRef(schemaError), []string{"text/plain"})),
+		}
+	}
+
+	return components, nil
+}
+
+// checkSchemaName returns the name of the schema component of the results of the check with the given name
+func checkSchemaName(name string) string {
+	if name == "" {
+		return schemaResult
+	}
+	return strings.ToUpper(name[:1]) + name[1:] + schemaResult
+}
+
+// versionedSchemaName returns the name of the schema component of the given version of a check's results
+func versionedSchemaName(name string, version int) string {
+	return fmt.Sprintf("%sV%d", name, version)
+}
+
+// setSchemaVersion restricts the schema version property of the result schema to the given version
+func setSchemaVersion(ref *openapi3.SchemaRef, version int) {
+	if ref.Value == nil {
+		return
+	}
+	schema := openapi3.NewIntegerSchema()
+	schema.Description = "Version of the layout of the result data"
+	schema.Enum = []any{version}
+	ref.Value.Properties["schemaVersion"] = openapi3.NewSchemaRef("", schema)
+}
+
+// schemaRef returns a reference to the schema component with the given name
+func schemaRef(name string) *openapi3.SchemaRef {
+	return openapi3.NewSchemaRef(fmt.Sprintf("#/components/schemas/%s", name), nil)
+}
+
+// responseRef returns a reference to the response component with the given name
+func responseRef(name string) *openapi3.ResponseRef {
+	return &openapi3.ResponseRef{Ref: fmt.Sprintf("#/components/responses/%s", name)}
+}
+
+// arrayOf returns an array schema of the referenced schema
+func arrayOf(items *openapi3.SchemaRef) *openapi3.SchemaRef {
+	schema := openapi3.NewArraySchema()
+	schema.Items = items
+	return openapi3.NewSchemaRef("", schema)
+}
+
+// mapOf returns an object schema mapping arbitrary keys to the referenced schema
+func mapOf(values *openapi3.SchemaRef) *openapi3.SchemaRef {
+	schema := openapi3.NewObjectSchema()
+	schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: values}
+	return openapi3.NewSchemaRef("", schema)
+}
+
+// jsonResponse returns a response with the given description and JSON content of the given schema
+func jsonResponse(desc string, schema *openapi3.SchemaRef) *openapi3.ResponseRef {
+	return &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription(desc).
+			WithContent(openapi3.NewContentWithSchemaRef(schema, []string{"application/json"})),
+	}
+}
+
+// resultResponse returns a response with the given description and content of the given schema
+// in all formats the results can be encoded in
+func resultResponse(desc string, schema *openapi3.SchemaRef) *openapi3.ResponseRef {
+	return &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription(desc).
+			WithContent(openapi3.NewContentWithSchemaRef(schema, api.ResultContentTypes)),
+	}
+}
+
+// addAPISpecs adds the specifications of the routes served
+// independently of the configured checks to the OpenAPI document
+func (s *Sparrow) addAPISpecs(doc *openapi3.T) {
+	doc.Paths.Set("/openapi", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "getOpenapi",
+			Description: "Returns the OpenAPI specification of the API. The format is selected via the Accept header.",
+			Tags:        []string{"Admin"},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(http.StatusOK, &openapi3.ResponseRef{
+					Value: openapi3.NewResponse().
+						WithDescription("OpenAPI specification of the API").
+						WithContent(openapi3.NewContentWithSchema(openapi3.NewObjectSchema(), []string{"text/yaml", "application/json"})),
+				}),
+				openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+			),
+		},
+	})
+
+	doc.Paths.Set("/readyz", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "getReady",
+			Description: "Returns whether the sparrow is ready. It isn't ready while its database fails to save results.",
+			Tags:        []string{"Admin"},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(http.StatusOK, &openapi3.ResponseRef{
+					Value: openapi3.NewResponse().
+						WithDescription("The sparrow is ready").
+						WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{"text/plain"})),
+				}),
+				openapi3.WithStatus(http.StatusServiceUnavailable, &openapi3.ResponseRef{
+					Value: openapi3.NewResponse().
+						WithDescription("The sparrow is degraded").
+						WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{"text/plain"})),
+				}),
+			),
+		},
+	})
+
+	doc.Paths.Set("/v1/status", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "getStatus",
+			Description: "Returns the status of the sparrow and its checks",
+			Tags:        []string{"Admin"},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(http.StatusOK, jsonResponse("Status of the sparrow", schemaRef(schemaStatus))),
+				openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+			),
+		},
+	})
+
+	doc.Paths.Set("/v1/targets", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "getTargets",
+			Description: "Returns the global targets known to the target manager",
+			Tags:        []string{"Admin"},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(http.StatusOK, jsonResponse("Global targets", arrayOf(schemaRef(schemaGlobalTarget)))),
+				openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+			),
+		},
+	})
+
+	events := &openapi3.Operation{
+		OperationID: "getEvents",
+		Description: "Returns the changes of the runtime configuration applied to the checks, oldest first",
+		Tags:        []string{"Admin"},
+		Responses: openapi3.NewResponses(
+			openapi3.WithStatus(http.StatusOK, jsonResponse("Events of the runtime configuration", arrayOf(schemaRef(schemaEvent)))),
+			openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+		),
+	}
+	doc.Paths.Set("/v1/events", &openapi3.PathItem{Get: events})
+
+	cfg := &openapi3.Operation{
+		OperationID: "getConfig",
+		Description: "Returns the applied runtime configuration and where it was loaded from. Secrets are redacted.",
+		Tags:        []string{"Admin"},
+		Responses: openapi3.NewResponses(
+			openapi3.WithStatus(http.StatusOK, jsonResponse("Applied runtime configuration", openapi3.NewObjectSchema().NewRef())),
+			openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+		),
+	}
+	doc.Paths.Set("/v1/config", &openapi3.PathItem{Get: cfg})
+
+	push := &openapi3.Operation{
+		OperationID: "putRuntimeConfig",
+		Description: "Applies the pushed runtime configuration until the loader loads the next one",
+		Tags:        []string{"Admin"},
+		Security:    openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate(securityBearer)),
+		RequestBody: &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().
+				WithRequired(true).
+				WithDescription("Runtime configuration in YAML or JSON").
+				WithContent(openapi3.NewContentWithSchema(openapi3.NewObjectSchema(), []string{"application/yaml", "application/json"})),
+		},
+		Responses: openapi3.NewResponses(
+			openapi3.WithStatus(http.StatusAccepted, &openapi3.ResponseRef{
+				Value: openapi3.NewResponse().WithDescription("The runtime configuration is applied"),
+			}),
+			openapi3.WithStatus(http.StatusBadRequest, responseRef(responseBadRequest)),
+			openapi3.WithStatus(http.StatusUnauthorized, responseRef(responseUnauthorized)),
+			openapi3.WithStatus(http.StatusNotFound, responseRef(responseNotFound)),
+		),
+	}
+	hasPush := s.config != nil && s.config.HasConfigPush()
+	if hasPush {
+		doc.Paths.Set("/v1/config/runtime", &openapi3.PathItem{Put: push})
+	}
+
+	doc.Paths.Set("/v1/config/schema", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "getConfigSchema",
+			Description: "Returns the JSON Schema of the startup and the runtime configuration",
+			Tags:        []string{"Admin"},
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewQueryParameter(queryParamKind).
+					WithDescription("Selects the configuration: startup or runtime. The schema accepts both if omitted.").
+					WithSchema(openapi3.NewStringSchema().WithEnum(config.SchemaStartup, config.SchemaRuntime))},
+			},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(http.StatusOK, &openapi3.ResponseRef{
+					Value: openapi3.NewResponse().
+						WithDescription("JSON Schema of the configuration").
+						WithContent(openapi3.NewContentWithSchema(openapi3.NewObjectSchema(), []string{"application/schema+json"})),
+				}),
+				openapi3.WithStatus(http.StatusBadRequest, responseRef(responseBadRequest)),
+				openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+			),
+		},
+	})
+
+	bulk := &openapi3.Operation{
+		OperationID: "getMetrics",
+		Description: "Returns the latest results of all checks mapped by the check name",
+		Tags:        []string{"Metrics"},
+		Parameters: openapi3.Parameters{
+			{Value: openapi3.NewQueryParameter(queryParamCheck).
+				WithDescription("Filters the results by check name. Can be passed multiple times or as a comma separated list.").
+				WithSchema(openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()))},
+			{Value: openapi3.NewQueryParameter(queryParamSince).
+				WithDescription("Filters the results by the minimum timestamp (RFC 3339)").
+				WithSchema(openapi3.NewDateTimeSchema())},
+		},
+		Responses: openapi3.NewResponses(
+			openapi3.WithStatus(http.StatusOK, resultResponse("Results of all checks", mapOf(schemaRef(schemaResult)))),
+			openapi3.WithStatus(http.StatusBadRequest, responseRef(responseBadRequest)),
+			openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+		),
+	}
+	doc.Paths.Set("/v1/metrics", &openapi3.PathItem{Get: bulk})
+
+	history := &openapi3.Operation{
+		OperationID: "getTargetHistory",
+		Description: fmt.Sprintf("Returns the latest %d probe outcomes of a target of a check, oldest first", historySize),
+		Tags:        []string{"Metrics"},
+		Parameters: openapi3.Parameters{
+			{Value: openapi3.NewPathParameter(urlParamCheckName).
+				WithDescription("Name of the check").
+				WithSchema(openapi3.NewStringSchema())},
+			{Value: openapi3.NewPathParameter(urlParamTarget).
+				WithDescription("URL encoded target of the check").
+				WithSchema(openapi3.NewStringSchema())},
+		},
+		Responses: openapi3.NewResponses(
+			openapi3.WithStatus(http.StatusOK, jsonResponse("Probe outcomes of the target", arrayOf(schemaRef(schemaOutcome)))),
+			openapi3.WithStatus(http.StatusBadRequest, responseRef(responseBadRequest)),
+			openapi3.WithStatus(http.StatusNotFound, responseRef(responseNotFound)),
+			openapi3.WithStatus(http.StatusInternalServerError, responseRef(responseInternalServerError)),
+		),
+	}
+	doc.Paths.Set(fmt.Sprintf("/v1/metrics/{%s}/targets/{%s}/history", urlParamCheckName, urlParamTarget), &openapi3.PathItem{Get: history})
+
+	for name := range s.tenants {
+		op := *bulk
+		op.OperationID = fmt.Sprintf("getMetrics_%s", name)
+		op.Tags = []string{"Metrics", name}
+		doc.Paths.Set(fmt.Sprintf("/v1/%s/metrics", name), &openapi3.PathItem{Get: &op})
+
+		hop := *history
+		hop.OperationID = fmt.Sprintf("getTargetHistory_%s", name)
+		hop.Tags = []string{"Metrics", name}
+		doc.Paths.Set(fmt.Sprintf("/v1/%s/metrics/{%s}/targets/{%s}/history", name, urlParamCheckName, urlParamTarget), &openapi3.PathItem{Get: &hop})
+
+		eop := *events
+		eop.OperationID = fmt.Sprintf("getEvents_%s", name)
+		eop.Tags = []string{"Admin", name}
+		doc.Paths.Set(fmt.Sprintf("/v1/%s/events", name), &openapi3.PathItem{Get: &eop})
+
+		cop := *cfg
+		cop.OperationID = fmt.Sprintf("getConfig_%s", name)
+		cop.Tags = []string{"Admin", name}
+		doc.Paths.Set(fmt.Sprintf("/v1/%s/config", name), &openapi3.PathItem{Get: &cop})
+
+		if hasPush {
+			pop := *push
+			pop.OperationID = fmt.Sprintf("putRuntimeConfig_%s", name)
+			pop.Tags = []string{"Admin", name}
+			doc.Paths.Set(fmt.Sprintf("/v1/%s/config/runtime", name), &openapi3.PathItem{Put: &pop})
+		}
+	}
+}
+
+// GenerateSpec generates the OpenAPI specification of the API of a sparrow running every known check
+// without tenants, with the runtime configuration push and the runs on demand enabled.
+// The client in pkg/client is generated from it.
+func GenerateSpec(ctx context.Context) (openapi3.T, error) {
+	cc := &ChecksController{checks: runtime.Checks{}}
+	for _, c := range factory.NewChecks() {
+		cc.checks.Add(c)
+	}
+	s := &Sparrow{
+		controller: cc,
+		// Only the presence of the tokens matters, they enable the routes requiring them
+		config: &config.Config{
+			Loader: config.LoaderConfig{Push: config.PushLoaderConfig{Token: "generated"}},
+			Api:    api.Config{RunToken: "generated"},
+		},
+	}
+	return s.generateCheckSpecs(ctx)
+}
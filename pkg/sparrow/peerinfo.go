@@ -0,0 +1,113 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/sparrow/targets"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// peerTargets maps the checks the global targets are injected into as plain targets
+// to the function rendering their default target from the global target's URL
+var peerTargets = map[string]func(*url.URL) string{
+	health.CheckName:  (*url.URL).String,
+	latency.CheckName: (*url.URL).String,
+	dns.CheckName:     (*url.URL).Hostname,
+	pmtu.CheckName:    (*url.URL).Hostname,
+}
+
+// invalidLabelChars matches the characters not allowed in a Prometheus label name
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// peerInfo exposes the region and labels declared by the peers discovered by the target manager
+// as info-style metric of the targets they were injected as, so the metrics of the checks can be
+// joined with them, e.g. to build cross-region latency matrices
+type peerInfo struct {
+	db        db.DB
+	peers     func() []checks.GlobalTarget
+	injection targets.Injection
+}
+
+// newPeerInfo creates the collector of the peer info metric of the results in the database
+func newPeerInfo(dbase db.DB, peers func() []checks.GlobalTarget, injection targets.Injection) *peerInfo {
+	return &peerInfo{db: dbase, peers: peers, injection: injection}
+}
+
+// Describe sends no descriptors, because the label names depend on the labels declared by the peers
+func (p *peerInfo) Describe(chan<- *prometheus.Desc) {}
+
+// Collect sends a peer info metric for every target of the latest results that was injected from a peer.
+// Every declared label is exposed with the label_ prefix. Peers without the label have it empty.
+func (p *peerInfo) Collect(ch chan<- prometheus.Metric) {
+	byTarget := map[string]map[string]checks.GlobalTarget{}
+	keys := map[string]string{}
+	for _, gt := range p.peers() {
+		for check, def := range peerTargets {
+			if !p.injection.Enabled(check) {
+				continue
+			}
+			target, err := p.injection.Target(check, gt, def)
+			if err != nil {
+				continue
+			}
+			if byTarget[check] == nil {
+				byTarget[check] = map[string]checks.GlobalTarget{}
+			}
+			byTarget[check][target] = gt
+		}
+		for k := range gt.Labels {
+			keys["label_"+invalidLabelChars.ReplaceAllString(k, "_")] = k
+		}
+	}
+	if len(byTarget) == 0 {
+		return
+	}
+
+	names := slices.Sorted(maps.Keys(keys))
+	desc := prometheus.NewDesc(
+		"sparrow_peer_info",
+		"Region and labels declared by the peer a target of a check was discovered from. The value is always 1.",
+		append([]string{"check", "target", "peer", "region"}, names...),
+		nil,
+	)
+	for check, result := range p.db.List() {
+		for _, target := range targetsOf(result.Data) {
+			gt, ok := byTarget[check][target]
+			if !ok {
+				continue
+			}
+			values := []string{check, target, gt.Url, gt.Region}
+			for _, name := range names {
+				values = append(values, gt.Labels[keys[name]])
+			}
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, values...)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/sparrow/targets"
+)
+
+func TestPeerInfo_Collect(t *testing.T) {
+	peers := []checks.GlobalTarget{
+		{Url: "https://sparrow-eu.example.com", Region: "eu-de-1", Labels: map[string]string{"provider": "aws"}},
+		{Url: "https://sparrow-us.example.com", Region: "us-east-1", Labels: map[string]string{"availability-zone": "us-east-1a"}},
+		{Url: "https://sparrow-ap.example.com"},
+	}
+	tests := []struct {
+		name      string
+		peers     []checks.GlobalTarget
+		injection targets.Injection
+		results   []checks.ResultDTO
+		want      string
+	}{
+		{
+			name: "no peers",
+			results: []checks.ResultDTO{
+				{Name: "latency", Result: &checks.Result{Data: map[string]any{"https://sparrow-eu.example.com": map[string]any{}}}},
+			},
+			want: "",
+		},
+		{
+			name:  "targets injected from peers",
+			peers: peers,
+			results: []checks.ResultDTO{
+				{Name: "latency", Result: &checks.Result{Data: map[string]any{
+					"https://sparrow-eu.example.com": map[string]any{},
+					"https://sparrow-us.example.com": map[string]any{},
+					"https://example.com":            map[string]any{},
+				}}},
+				{Name: "dns", Result: &checks.Result{Data: map[string]any{"sparrow-ap.example.com": map[string]any{}}}},
+			},
+			want: `
+# HELP sparrow_peer_info Region and labels declared by the peer a target of a check was discovered from. The value is always 1.
+# TYPE sparrow_peer_info gauge
+sparrow_peer_info{check="dns",label_availability_zone="",label_provider="",peer="https://sparrow-ap.example.com",region="",target="sparrow-ap.example.com"} 1
+sparrow_peer_info{check="latency",label_availability_zone="",label_provider="aws",peer="https://sparrow-eu.example.com",region="eu-de-1",target="https://sparrow-eu.example.com"} 1
+sparrow_peer_info{check="latency",label_availability_zone="us-east-1a",label_provider="",peer="https://sparrow-us.example.com",region="us-east-1",target="https://sparrow-us.example.com"} 1
+`,
+		},
+		{
+			name:      "targets rendered by the injection policy",
+			peers:     peers[:1],
+			injection: targets.Injection{"health": {Template: "{{ .Url }}/healthz"}},
+			results: []checks.ResultDTO{
+				{Name: "health", Result: &checks.Result{Data: map[string]any{"https://sparrow-eu.example.com/healthz": "healthy"}}},
+			},
+			want: `
+# HELP sparrow_peer_info Region and labels declared by the peer a target of a check was discovered from. The value is always 1.
+# TYPE sparrow_peer_info gauge
+sparrow_peer_info{check="health",label_provider="aws",peer="https://sparrow-eu.example.com",region="eu-de-1",target="https://sparrow-eu.example.com/healthz"} 1
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbase := db.NewInMemory()
+			for _, r := range tt.results {
+				_ = dbase.Save(r)
+			}
+
+			p := newPeerInfo(dbase, func() []checks.GlobalTarget { return tt.peers }, tt.injection)
+			if err := testutil.CollectAndCompare(p, strings.NewReader(tt.want), "sparrow_peer_info"); err != nil {
+				t.Errorf("Collect() unexpected metrics: %v", err)
+			}
+		})
+	}
+}
@@ -21,23 +21,48 @@ package sparrow
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/caas-team/sparrow/internal/clock"
 	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/admin"
 	"github.com/caas-team/sparrow/pkg/api"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
 	"github.com/caas-team/sparrow/pkg/config"
 	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/email"
+	"github.com/caas-team/sparrow/pkg/heartbeat"
+	"github.com/caas-team/sparrow/pkg/hub"
+	"github.com/caas-team/sparrow/pkg/incident"
+	"github.com/caas-team/sparrow/pkg/kafka"
+	"github.com/caas-team/sparrow/pkg/mqtt"
+	"github.com/caas-team/sparrow/pkg/pushgateway"
+	"github.com/caas-team/sparrow/pkg/slo"
 	"github.com/caas-team/sparrow/pkg/sparrow/metrics"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets"
+	"github.com/caas-team/sparrow/pkg/webhook"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
 )
 
 const shutdownTimeout = time.Second * 90
 
+// resultBufferSize is the maximum number of results buffered in memory
+// while the database of the checks fails to save them
+const resultBufferSize = 1000
+
 // Sparrow is the main struct of the sparrow application
 type Sparrow struct {
 	// config is the startup configuration of the sparrow
@@ -46,6 +71,8 @@ type Sparrow struct {
 	db db.DB
 	// api is the sparrow's API
 	api api.API
+	// admin serves the debug endpoints on the admin listener
+	admin *admin.Server
 	// loader is used to load the runtime configuration
 	loader config.Loader
 	// tarMan is the target manager that is used to manage global targets
@@ -54,40 +81,177 @@ type Sparrow struct {
 	metrics metrics.Provider
 	// controller is used to manage the checks
 	controller *ChecksController
+	// pusher pushes the results to a hub
+	pusher *hub.Pusher
+	// gateway pushes the metrics to a Prometheus Pushgateway
+	gateway *pushgateway.Pusher
+	// heartbeat sends heartbeats to a dead man's switch while the sparrow is healthy
+	heartbeat *heartbeat.Emitter
+	// started is the time the sparrow was started
+	started time.Time
+	// notifier sends the results to the webhook receivers
+	notifier *webhook.Notifier
+	// mailer sends the results to the email receivers
+	mailer *email.Notifier
+	// publisher publishes the results to the mqtt brokers
+	publisher *mqtt.Notifier
+	// producer publishes the results to the kafka topics
+	producer *kafka.Notifier
+	// incidents reports the failed targets to incident management systems
+	incidents *incident.Notifier
+	// slos computes the attainment of the service level objectives
+	slos *slo.Engine
+	// receiver accepts the results pushed by other sparrows
+	receiver *hub.Receiver
+	// tenants are the additional logical groups of checks, mapped by their name
+	tenants map[string]*tenant
+	// capabilities are the privileges of the process probed on startup
+	capabilities checks.Capabilities
 	// cRuntime is used to signal that the runtime configuration has changed
 	cRuntime chan runtime.Config
-	// cErr is used to handle non-recoverable errors of the sparrow components
-	cErr chan error
-	// cDone is used to signal that the sparrow was shut down because of an error
-	cDone chan struct{}
+	// restarts counts the restarts of the failed components
+	restarts *prometheus.CounterVec
+	// Source is the clock the restarts of the failed components are delayed with
+	clock.Source
 	// shutOnce is used to ensure that the shutdown function is only called once
 	shutOnce sync.Once
 }
 
-// New creates a new sparrow from a given configfile
-func New(cfg *config.Config) *Sparrow {
-	m := metrics.New(cfg.Telemetry)
-	dbase := db.NewInMemory()
+// New creates a new sparrow from a given configfile.
+// It returns an error if the database or a notifier can't be created from the configuration.
+func New(cfg *config.Config) (*Sparrow, error) {
+	m := metrics.New(cfg.Telemetry, cfg.SparrowName)
+	dbase, err := newDB(cfg.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	controller := NewChecksController(dbase, m, cfg.SparrowName)
+	if cfg.HasTenants() {
+		// The default checks are labelled with an empty tenant,
+		// so they share the label dimensions of the tenants' checks
+		controller = NewTenantChecksController(dbase, m, cfg.SparrowName, "")
+	}
+	controller.registerer.MustRegister(dbase)
 
 	sparrow := &Sparrow{
 		config:     cfg,
 		db:         dbase,
 		api:        api.New(cfg.Api),
 		metrics:    m,
-		controller: NewChecksController(dbase, m),
+		controller: controller,
+		tenants:    map[string]*tenant{},
 		cRuntime:   make(chan runtime.Config, 1),
-		cErr:       make(chan error, 1),
-		cDone:      make(chan struct{}, 1),
+		restarts:   newComponentRestarts(),
 		shutOnce:   sync.Once{},
 	}
 
+	m.GetRegistry().MustRegister(sparrow.restarts)
+
+	for _, tc := range cfg.Tenants {
+		t, err := newTenant(cfg, tc, m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tenant %q: %w", tc.Name, err)
+		}
+		sparrow.tenants[tc.Name] = t
+	}
+
+	if cfg.HasChangesOnly() {
+		controller.changes = newChangeFilter(cfg.Results.FullInterval)
+	}
+
+	if cfg.HasHubPush() {
+		sparrow.pusher = hub.NewPusher(cfg.SparrowName, cfg.Hub.Push)
+		controller.submitters = append(controller.submitters, sparrow.pusher)
+	}
+	if cfg.HasWebhooks() {
+		n, err := webhook.NewNotifier(cfg.Webhooks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the webhook notifier: %w", err)
+		}
+		sparrow.notifier = n
+		controller.submitters = append(controller.submitters, sparrow.notifier)
+	}
+	if cfg.HasEmail() {
+		n, err := email.NewNotifier(cfg.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the email notifier: %w", err)
+		}
+		sparrow.mailer = n
+		controller.submitters = append(controller.submitters, sparrow.mailer)
+	}
+	if cfg.HasMqtt() {
+		n, err := mqtt.NewNotifier(cfg.Mqtt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the mqtt notifier: %w", err)
+		}
+		sparrow.publisher = n
+		controller.submitters = append(controller.submitters, sparrow.publisher)
+	}
+	if cfg.HasKafka() {
+		n, err := kafka.NewNotifier(cfg.Kafka)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the kafka notifier: %w", err)
+		}
+		sparrow.producer = n
+		controller.submitters = append(controller.submitters, sparrow.producer)
+	}
+	if cfg.HasIncidents() {
+		sparrow.incidents = incident.NewNotifier(cfg.Incidents)
+		controller.observers = append(controller.observers, sparrow.incidents)
+		m.GetRegistry().MustRegister(sparrow.incidents)
+	}
+	if cfg.HasSLOs() {
+		sparrow.slos = slo.NewEngine(cfg.SLO)
+		controller.observers = append(controller.observers, sparrow.slos)
+		m.GetRegistry().MustRegister(sparrow.slos)
+	}
+	if cfg.HasHubReceiver() {
+		sparrow.receiver = hub.NewReceiver(cfg.Hub.Receiver)
+		m.GetRegistry().MustRegister(sparrow.receiver)
+	}
+
+	if cfg.HasAdmin() {
+		sparrow.admin = admin.NewServer(&cfg.Admin)
+		sparrow.admin.Handle(api.Route{Path: "/v1/admin/export", Method: http.MethodGet, Handler: sparrow.handleExport})
+	}
+	if cfg.HasPushgateway() {
+		sparrow.gateway = pushgateway.NewPusher(cfg.SparrowName, cfg.Pushgateway, m.GetRegistry())
+	}
+	if cfg.HasHeartbeat() {
+		sparrow.heartbeat = heartbeat.NewEmitter(cfg.Heartbeat, sparrow.selfCheck)
+	}
+
 	if cfg.HasTargetManager() {
 		gm := targets.NewManager(cfg.SparrowName, cfg.TargetManager, m)
 		sparrow.tarMan = gm
+		controller.registerer.MustRegister(newPeerInfo(dbase, gm.GetTargets, cfg.TargetManager.Injection))
 	}
 	sparrow.loader = config.NewLoader(cfg, sparrow.cRuntime)
+	registerLoader(controller, sparrow.loader)
 
-	return sparrow
+	return sparrow, nil
+}
+
+// newDB creates the database of the results of the checks, which buffers the results in memory
+// while it fails to save them
+func newDB(cfg db.Config) (*db.Buffered, error) {
+	backend, err := db.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the database: %w", err)
+	}
+	return db.NewBuffered(backend, resultBufferSize), nil
+}
+
+// registerLoader registers the metrics of the loader if it exposes any
+// and records the failures of the loader in the event log of the controller
+func registerLoader(cc *ChecksController, l config.Loader) {
+	if c, ok := l.(prometheus.Collector); ok {
+		cc.registerer.MustRegister(c)
+	}
+	if o, ok := l.(config.Observable); ok {
+		o.OnLoad(cc.events.loadEvents())
+	}
 }
 
 // Run starts the sparrow
@@ -95,57 +259,126 @@ func (s *Sparrow) Run(ctx context.Context) error {
 	ctx, cancel := logger.NewContextWithLogger(ctx)
 	log := logger.FromContext(ctx)
 	defer cancel()
+	s.started = time.Now()
 
 	err := s.metrics.InitTracing(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to initialize tracing: %w", err)
 	}
 
-	go func() {
-		s.cErr <- s.loader.Run(ctx)
-	}()
-	go func() {
-		if s.tarMan != nil {
-			s.cErr <- s.tarMan.Reconcile(ctx)
-		}
-	}()
+	// The capabilities are probed before the API reports them
+	s.capabilities, err = checks.ProbeCapabilities()
+	if err != nil {
+		log.Error("Failed to probe the capabilities of the process", "error", err)
+	}
+	s.metrics.GetRegistry().MustRegister(newCapabilityCollectors(s.capabilities)...)
+	logCapabilities(ctx, s.capabilities)
 
-	go func() {
-		s.cErr <- s.startupAPI(ctx)
-	}()
+	// The results of a replaced sparrow are restored before the API starts serving them
+	if s.config.HasSnapshotImport() {
+		s.importSnapshot(ctx)
+	}
 
-	go func() {
-		s.cErr <- s.controller.Run(ctx)
-	}()
+	// The components run in an errgroup, whose context is canceled if any of them fails,
+	// which shuts the sparrow down
+	g, gctx := errgroup.WithContext(ctx)
+	for _, c := range s.components() {
+		g.Go(func() error {
+			return s.supervise(gctx, c)
+		})
+	}
+	for _, t := range s.tenants {
+		g.Go(func() error {
+			s.runTenant(gctx, g, t)
+			return nil
+		})
+	}
 
 	for {
 		select {
 		case cfg := <-s.cRuntime:
+			s.advertiseChecks(cfg)
 			cfg = s.enrichTargets(ctx, cfg)
 			s.controller.Reconcile(ctx, cfg)
-		case <-ctx.Done():
-			s.shutdown(ctx)
-		case err := <-s.cErr:
-			if err != nil {
+		case <-gctx.Done():
+			s.shutdown(gctx)
+			if err := g.Wait(); err != nil {
 				log.Error("Non-recoverable error in sparrow component", "error", err)
-				s.shutdown(ctx)
+				return fmt.Errorf("sparrow was shut down: %w", err)
 			}
-		case <-s.cDone:
-			return fmt.Errorf("sparrow was shut down")
+			return fmt.Errorf("sparrow was shut down: %w", ctx.Err())
 		}
 	}
 }
 
+// components returns the subsystems of the sparrow supervised while it runs.
+// The components serving or producing the results fail fast, the components
+// exporting them and the ones talking to remote backends are restarted.
+func (s *Sparrow) components() []component {
+	cs := []component{
+		{name: "api", run: s.startupAPI, policy: failFast},
+		{name: "controller", run: s.controller.Run, policy: failFast},
+		{name: "loader", run: s.loader.Run, policy: restartOnFailure},
+	}
+	if s.admin != nil {
+		cs = append(cs, component{name: "admin", run: s.admin.Run, policy: failFast})
+	}
+	if s.tarMan != nil {
+		cs = append(cs, component{name: "targetManager", run: s.tarMan.Reconcile, policy: restartOnFailure})
+	}
+	if s.pusher != nil {
+		cs = append(cs, component{name: "hub", run: s.pusher.Run, policy: restartOnFailure})
+	}
+	if s.gateway != nil {
+		cs = append(cs, component{name: "pushgateway", run: s.gateway.Run, policy: restartOnFailure})
+	}
+	if s.heartbeat != nil {
+		cs = append(cs, component{name: "heartbeat", run: s.heartbeat.Run, policy: restartOnFailure})
+	}
+	if s.notifier != nil {
+		cs = append(cs, component{name: "webhooks", run: s.notifier.Run, policy: restartOnFailure})
+	}
+	if s.mailer != nil {
+		cs = append(cs, component{name: "email", run: s.mailer.Run, policy: restartOnFailure})
+	}
+	if s.publisher != nil {
+		cs = append(cs, component{name: "mqtt", run: s.publisher.Run, policy: restartOnFailure})
+	}
+	if s.producer != nil {
+		cs = append(cs, component{name: "kafka", run: s.producer.Run, policy: restartOnFailure})
+	}
+	if s.incidents != nil {
+		cs = append(cs, component{name: "incidents", run: s.incidents.Run, policy: restartOnFailure})
+	}
+	return cs
+}
+
+// advertiseChecks sets the checks of the runtime configuration
+// advertised in the registration as global target
+func (s *Sparrow) advertiseChecks(cfg runtime.Config) {
+	if s.tarMan == nil {
+		return
+	}
+	names := []string{}
+	for _, c := range cfg.Iter() {
+		names = append(names, c.For())
+	}
+	s.tarMan.SetChecks(names)
+}
+
 // enrichTargets updates the targets of the sparrow's checks with the
 // global targets. Per default, the two target lists are merged.
+// The injection policies of the target manager control per check
+// whether and in which form the global targets are merged.
 func (s *Sparrow) enrichTargets(ctx context.Context, cfg runtime.Config) runtime.Config {
 	l := logger.FromContext(ctx)
 	if cfg.Empty() || s.tarMan == nil {
 		return cfg
 	}
 
+	injection := s.config.TargetManager.Injection
 	for _, gt := range s.tarMan.GetTargets() {
-		u, err := url.Parse(gt.Url)
+		u, err := gt.URL()
 		if err != nil {
 			l.Error("Failed to parse global target URL", "error", err, "url", gt.Url)
 			continue
@@ -157,20 +390,69 @@ func (s *Sparrow) enrichTargets(ctx context.Context, cfg runtime.Config) runtime
 			continue
 		}
 
-		if cfg.HasHealthCheck() && !slices.Contains(cfg.Health.Targets, u.String()) {
-			cfg.Health.Targets = append(cfg.Health.Targets, u.String())
+		if cfg.HasHealthCheck() {
+			cfg.Health.Targets = injectTarget(ctx, injection, health.CheckName, cfg.Health.Targets, gt, (*url.URL).String)
+		}
+		if cfg.HasLatencyCheck() {
+			cfg.Latency.Targets = injectTarget(ctx, injection, latency.CheckName, cfg.Latency.Targets, gt, (*url.URL).String)
+		}
+		if cfg.HasDNSCheck() {
+			cfg.Dns.Targets = injectTarget(ctx, injection, dns.CheckName, cfg.Dns.Targets, gt, (*url.URL).Hostname)
 		}
-		if cfg.HasLatencyCheck() && !slices.Contains(cfg.Latency.Targets, u.String()) {
-			cfg.Latency.Targets = append(cfg.Latency.Targets, u.String())
+		if cfg.HasPmtuCheck() {
+			cfg.Pmtu.Targets = injectTarget(ctx, injection, pmtu.CheckName, cfg.Pmtu.Targets, gt, (*url.URL).Hostname)
 		}
-		if cfg.HasDNSCheck() && !slices.Contains(cfg.Dns.Targets, hostWithoutPort) {
-			cfg.Dns.Targets = append(cfg.Dns.Targets, hostWithoutPort)
+		// Global targets are sparrows, so they are paired for the reverse path detection
+		// if they run the traceroute check themselves
+		if cfg.HasTracerouteCheck() && cfg.Traceroute.Reverse.Enabled && injection.Enabled(traceroute.CheckName) && gt.Runs(traceroute.CheckName) && !slices.ContainsFunc(cfg.Traceroute.Targets, func(t traceroute.Target) bool {
+			return t.Addr == hostWithoutPort
+		}) {
+			tu, err := injection.URL(traceroute.CheckName, gt)
+			if err != nil {
+				l.Error("Failed to parse global target URL", "error", err, "url", gt.Url)
+				continue
+			}
+			cfg.Traceroute.Targets = append(cfg.Traceroute.Targets, traceroute.Target{Addr: hostWithoutPort, Port: port(tu), Peer: tu.String()})
 		}
 	}
 
+	if cfg.HasTracerouteCheck() && cfg.Traceroute.Reverse.Enabled && cfg.Traceroute.Reverse.Name == "" {
+		cfg.Traceroute.Reverse.Name = s.config.SparrowName
+	}
+
 	return cfg
 }
 
+// injectTarget appends the global target to the targets of the check, unless the injection
+// is disabled for the check or the target is already present. The target is rendered by
+// the injection policy of the check or else by the given default function.
+func injectTarget(ctx context.Context, injection targets.Injection, check string, ts []string, gt checks.GlobalTarget, def func(*url.URL) string) []string { //nolint:gocritic // no performance concerns yet
+	if !injection.Enabled(check) {
+		return ts
+	}
+
+	target, err := injection.Target(check, gt, def)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to render global target", "error", err, "check", check, "url", gt.Url)
+		return ts
+	}
+	if slices.Contains(ts, target) {
+		return ts
+	}
+	return append(ts, target)
+}
+
+// port returns the port of the URL or the default port of its scheme
+func port(u *url.URL) int {
+	if p, err := strconv.Atoi(u.Port()); err == nil {
+		return p
+	}
+	if u.Scheme == "http" {
+		return 80 //nolint:mnd // Default http port
+	}
+	return 443 //nolint:mnd // Default https port
+}
+
 // shutdown shuts down the sparrow and all managed components gracefully.
 // It returns an error if one is present in the context or if any of the
 // components fail to shut down.
@@ -183,19 +465,61 @@ func (s *Sparrow) shutdown(ctx context.Context) {
 	s.shutOnce.Do(func() {
 		log.Info("Shutting down sparrow gracefully")
 		var sErrs ErrShutdown
+		// The instance is unregistered concurrently to the shutdown of the other components,
+		// so it fits into short termination grace periods
+		var wg sync.WaitGroup
+		var unregistered time.Duration
 		if s.tarMan != nil {
-			sErrs.errTarMan = s.tarMan.Shutdown(ctx)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				start := time.Now()
+				sErrs.errTarMan = s.tarMan.Shutdown(ctx)
+				unregistered = time.Since(start)
+			}()
+		}
+		// The heartbeats stop before the other components, so no heartbeat is sent by a half shut down sparrow
+		if s.heartbeat != nil {
+			s.heartbeat.Shutdown(ctx)
 		}
 		sErrs.errAPI = s.api.Shutdown(ctx)
+		if s.admin != nil {
+			sErrs.errAdmin = s.admin.Shutdown(ctx)
+		}
 		sErrs.errMetrics = s.metrics.Shutdown(ctx)
 		s.loader.Shutdown(ctx)
 		s.controller.Shutdown(ctx)
+		for _, t := range s.tenants {
+			t.shutdown(ctx)
+		}
+		// The pusher is shut down after the controllers to push their last results
+		if s.pusher != nil {
+			sErrs.errHub = s.pusher.Shutdown(ctx)
+		}
+		if s.notifier != nil {
+			s.notifier.Shutdown(ctx)
+		}
+		if s.mailer != nil {
+			s.mailer.Shutdown(ctx)
+		}
+		if s.publisher != nil {
+			s.publisher.Shutdown(ctx)
+		}
+		if s.producer != nil {
+			s.producer.Shutdown(ctx)
+		}
+		if s.incidents != nil {
+			s.incidents.Shutdown(ctx)
+		}
+		// The metrics are pushed a last time after the checks are shut down
+		if s.gateway != nil {
+			sErrs.errGateway = s.gateway.Shutdown(ctx)
+		}
+		wg.Wait()
 
 		if sErrs.HasError() {
 			log.Error("Failed to shutdown gracefully", "contextError", errC, "errors", sErrs)
 		}
-
-		// Signal that shutdown is complete
-		s.cDone <- struct{}{}
+		s.reportShutdown(ctx, s.shutdownReport(sErrs, unregistered))
 	})
 }
@@ -18,12 +18,48 @@
 
 package sparrow
 
+import "fmt"
+
 type ErrShutdown struct {
 	errAPI     error
+	errAdmin   error
 	errTarMan  error
 	errMetrics error
+	errHub     error
+	errGateway error
 }
 
 func (e ErrShutdown) HasError() bool {
-	return e.errAPI != nil || e.errTarMan != nil || e.errMetrics != nil
+	return e.errAPI != nil || e.errAdmin != nil || e.errTarMan != nil || e.errMetrics != nil || e.errHub != nil || e.errGateway != nil
+}
+
+// Errors returns the messages of the errors mapped by the component that failed to shut down
+func (e ErrShutdown) Errors() map[string]string {
+	errs := map[string]string{}
+	for component, err := range map[string]error{
+		"api": e.errAPI, "admin": e.errAdmin, "targetManager": e.errTarMan,
+		"metrics": e.errMetrics, "hub": e.errHub, "pushgateway": e.errGateway,
+	} {
+		if err != nil {
+			errs[component] = err.Error()
+		}
+	}
+	return errs
+}
+
+// ErrComponentFailed is returned if a component of the sparrow failed and wasn't restarted
+type ErrComponentFailed struct {
+	// Component is the name of the failed component
+	Component string
+	// Restarts is the number of times the component was restarted before it failed
+	Restarts int
+	Err      error
+}
+
+func (e *ErrComponentFailed) Error() string {
+	return fmt.Sprintf("component %s failed after %d restarts: %v", e.Component, e.Restarts, e.Err)
+}
+
+func (e *ErrComponentFailed) Unwrap() error {
+	return e.Err
 }
@@ -20,6 +20,7 @@ package sparrow
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -29,7 +30,9 @@ import (
 	"github.com/caas-team/sparrow/pkg/checks/health"
 	"github.com/caas-team/sparrow/pkg/checks/latency"
 	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
 	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/db"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets/interactor"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets/remote/gitlab"
@@ -65,7 +68,10 @@ func TestSparrow_Run_FullComponentStart(t *testing.T) {
 		},
 	}
 
-	s := New(c)
+	s, err := New(c)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
 	ctx := context.Background()
 	go func() {
 		err := s.Run(ctx)
@@ -78,6 +84,34 @@ func TestSparrow_Run_FullComponentStart(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 }
 
+// TestNew_database tests that New fails instead of falling back to another database
+// if the configured one can't be created
+func TestNew_database(t *testing.T) {
+	_, err := New(&config.Config{DB: db.Config{Compression: "gzip"}})
+	if !errors.Is(err, db.ErrUnknownCompression) {
+		t.Errorf("New() error = %v, want %v", err, db.ErrUnknownCompression)
+	}
+}
+
+// TestNew_bufferedDatabase tests that the results of the default checks and the tenants' checks
+// are buffered while the database fails to save them, so the degradation is reported by /readyz
+func TestNew_bufferedDatabase(t *testing.T) {
+	s, err := New(&config.Config{
+		DB:      db.Config{Compression: db.CompressionZstd},
+		Tenants: []config.TenantConfig{{Name: "team-a"}},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, ok := s.db.(*db.Buffered); !ok {
+		t.Errorf("Expected the database to be buffered, got %T", s.db)
+	}
+	if _, ok := s.tenants["team-a"].db.(*db.Buffered); !ok {
+		t.Errorf("Expected the database of tenant team-a to be buffered, got %T", s.tenants["team-a"].db)
+	}
+}
+
 // TestSparrow_Run_ContextCancel tests that after a context cancels the Run method
 // will return an error and all started components will be shut down.
 func TestSparrow_Run_ContextCancel(t *testing.T) {
@@ -90,7 +124,10 @@ func TestSparrow_Run_ContextCancel(t *testing.T) {
 		},
 	}
 
-	s := New(c)
+	s, err := New(c)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
 	s.tarMan = &managermock.MockTargetManager{}
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -260,6 +297,78 @@ func TestSparrow_enrichTargets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "traceroute without reverse path detection - targets are not added",
+			config: runtime.Config{
+				Traceroute: &traceroute.Config{
+					Targets: []traceroute.Target{{Addr: "gitlab.com", Port: 443}},
+				},
+			},
+			globalTargets: gt,
+			expected: runtime.Config{
+				Traceroute: &traceroute.Config{
+					Targets: []traceroute.Target{{Addr: "gitlab.com", Port: 443}},
+				},
+			},
+		},
+		{
+			name: "traceroute with reverse path detection - sparrows are paired",
+			config: runtime.Config{
+				Traceroute: &traceroute.Config{
+					Targets: []traceroute.Target{{Addr: "gitlab.com", Port: 443}},
+					Reverse: traceroute.Reverse{Enabled: true},
+				},
+			},
+			globalTargets: append(gt, checks.GlobalTarget{Url: "http://az1.sparrow.com:8080"}, checks.GlobalTarget{Url: "https://sparrow.com"}),
+			expected: runtime.Config{
+				Traceroute: &traceroute.Config{
+					Targets: []traceroute.Target{
+						{Addr: "gitlab.com", Port: 443},
+						{Addr: "localhost.de", Port: 443, Peer: testTarget},
+						{Addr: "az1.sparrow.com", Port: 8080, Peer: "http://az1.sparrow.com:8080"},
+					},
+					Reverse: traceroute.Reverse{Enabled: true, Name: "sparrow.com"},
+				},
+			},
+		},
+		{
+			name: "traceroute with reverse path detection - peers not running traceroute are not paired",
+			config: runtime.Config{
+				Traceroute: &traceroute.Config{
+					Reverse: traceroute.Reverse{Enabled: true},
+				},
+			},
+			globalTargets: []checks.GlobalTarget{
+				{Url: "https://az1.sparrow.com", Checks: []string{"health", "traceroute"}},
+				{Url: "https://az2.sparrow.com", Checks: []string{"health"}},
+			},
+			expected: runtime.Config{
+				Traceroute: &traceroute.Config{
+					Targets: []traceroute.Target{
+						{Addr: "az1.sparrow.com", Port: 443, Peer: "https://az1.sparrow.com"},
+					},
+					Reverse: traceroute.Reverse{Enabled: true, Name: "sparrow.com"},
+				},
+			},
+		},
+		{
+			name: "global targets with an advertised port - port is added to the url",
+			config: runtime.Config{
+				Health: &health.Config{},
+				Dns:    &dns.Config{},
+			},
+			globalTargets: []checks.GlobalTarget{
+				{Url: "https://az1.sparrow.com", Port: 8443},
+			},
+			expected: runtime.Config{
+				Health: &health.Config{
+					Targets: []string{"https://az1.sparrow.com:8443"},
+				},
+				Dns: &dns.Config{
+					Targets: []string{"az1.sparrow.com"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -277,3 +386,42 @@ func TestSparrow_enrichTargets(t *testing.T) {
 		})
 	}
 }
+
+// TestSparrow_enrichTargets_injection tests that the injection policies
+// control whether and in which form the global targets are added to the checks.
+func TestSparrow_enrichTargets_injection(t *testing.T) {
+	s := &Sparrow{
+		tarMan: &managermock.MockTargetManager{
+			Targets: []checks.GlobalTarget{{Url: "https://az1.sparrow.com"}},
+		},
+		config: &config.Config{
+			SparrowName: "sparrow.com",
+			TargetManager: targets.TargetManagerConfig{
+				Injection: targets.Injection{
+					health.CheckName:     {Port: 8443},
+					latency.CheckName:    {Template: "{{ .Url }}/health"},
+					dns.CheckName:        {Disabled: true},
+					traceroute.CheckName: {Port: 8443},
+				},
+			},
+		},
+	}
+	cfg := runtime.Config{
+		Health:     &health.Config{},
+		Latency:    &latency.Config{},
+		Dns:        &dns.Config{Targets: []string{"gitlab.com"}},
+		Traceroute: &traceroute.Config{Reverse: traceroute.Reverse{Enabled: true}},
+	}
+
+	got := s.enrichTargets(context.Background(), cfg)
+	want := runtime.Config{
+		Health:  &health.Config{Targets: []string{"https://az1.sparrow.com:8443"}},
+		Latency: &latency.Config{Targets: []string{"https://az1.sparrow.com/health"}},
+		Dns:     &dns.Config{Targets: []string{"gitlab.com"}},
+		Traceroute: &traceroute.Config{
+			Targets: []traceroute.Target{{Addr: "az1.sparrow.com", Port: 8443, Peer: "https://az1.sparrow.com:8443"}},
+			Reverse: traceroute.Reverse{Enabled: true, Name: "sparrow.com"},
+		},
+	}
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,45 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// selfCheck returns an error if the checks stopped producing results within the maximum result age.
+// The results are counted from the start of the sparrow, so restored results don't mask a stalled start.
+// The sparrow is healthy without registered checks, since there's nothing to produce results.
+func (s *Sparrow) selfCheck(_ context.Context, maxResultAge time.Duration) error {
+	if len(s.controller.checks.Iter()) == 0 {
+		return nil
+	}
+
+	latest := s.started
+	for _, res := range s.db.List() {
+		if res.Timestamp.After(latest) {
+			latest = res.Timestamp
+		}
+	}
+	if age := time.Since(latest); age > maxResultAge {
+		return fmt.Errorf("no check produced a result for %s", age.Round(time.Second))
+	}
+	return nil
+}
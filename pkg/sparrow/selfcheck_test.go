@@ -0,0 +1,77 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/db"
+)
+
+func TestSparrow_selfCheck(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		checks  bool
+		started time.Time
+		results map[string]time.Time
+		wantErr bool
+	}{
+		{name: "no checks", started: now.Add(-time.Hour)},
+		{name: "started recently", checks: true, started: now.Add(-time.Minute)},
+		{
+			name:    "recent result",
+			checks:  true,
+			started: now.Add(-time.Hour),
+			results: map[string]time.Time{health.CheckName: now.Add(-time.Minute)},
+		},
+		{name: "no results", checks: true, started: now.Add(-time.Hour), wantErr: true},
+		{
+			name:    "stale results",
+			checks:  true,
+			started: now.Add(-time.Hour),
+			results: map[string]time.Time{health.CheckName: now.Add(-30 * time.Minute)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbase := db.NewInMemory()
+			for name, ts := range tt.results {
+				dbase.Save(checks.ResultDTO{Name: name, Result: &checks.Result{Timestamp: ts}})
+			}
+			cc := &ChecksController{checks: runtime.Checks{}}
+			if tt.checks {
+				cc.checks.Add(health.NewCheck())
+			}
+			s := &Sparrow{db: dbase, controller: cc, started: tt.started}
+
+			err := s.selfCheck(context.Background(), 10*time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Sparrow.selfCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/db"
+)
+
+// shutdownReport summarizes the state of the sparrow when it was shut down,
+// so the restart of an instance can be verified by scripts
+type shutdownReport struct {
+	// Instance is the identity of the sparrow
+	Instance string `json:"instance"`
+	// Timestamp is the time the shutdown finished
+	Timestamp time.Time `json:"timestamp"`
+	// Checks summarize the latest results of the checks
+	Checks []checkSummary `json:"checks"`
+	// Queues are the amounts of results not exported yet, mapped by the exporter
+	Queues map[string]int `json:"queues"`
+	// TargetManager is the outcome of the unregistration as global target.
+	// It's omitted if no target manager is configured.
+	TargetManager *unregistration `json:"targetManager,omitempty"`
+	// Errors are the errors of the components that failed to shut down, mapped by the component
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// checkSummary summarizes the latest result of a check
+type checkSummary struct {
+	// Name is the name of the check
+	Name string `json:"name"`
+	// Tenant is the tenant of the check. Empty for the checks of the top-level runtime configuration.
+	Tenant string `json:"tenant,omitempty"`
+	// Timestamp is the time of the latest result
+	Timestamp time.Time `json:"timestamp"`
+	// Targets is the amount of targets in the latest result
+	Targets int `json:"targets"`
+	// Failed are the targets that failed in the latest result
+	Failed []string `json:"failed,omitempty"`
+}
+
+// unregistration is the outcome of the unregistration as global target
+type unregistration struct {
+	// Unregistered is true if the registration was removed or replaced by a tombstone
+	Unregistered bool `json:"unregistered"`
+	// Duration is the time the unregistration took
+	Duration string `json:"duration"`
+	// Error is the error of a failed unregistration
+	Error string `json:"error,omitempty"`
+}
+
+// shutdownReport returns the report of the sparrow after its components were shut down
+func (s *Sparrow) shutdownReport(sErrs ErrShutdown, unregistered time.Duration) shutdownReport {
+	report := shutdownReport{
+		Instance:  s.config.SparrowName,
+		Timestamp: time.Now().UTC(),
+		Checks:    summarizeChecks("", s.db),
+		Queues:    map[string]int{"db": db.Pending(s.db)},
+		Errors:    sErrs.Errors(),
+	}
+	for _, t := range s.tenants {
+		report.Checks = append(report.Checks, summarizeChecks(t.name, t.db)...)
+		report.Queues["db/"+t.name] = db.Pending(t.db)
+	}
+	slices.SortFunc(report.Checks, func(a, b checkSummary) int {
+		return cmp.Or(cmp.Compare(a.Tenant, b.Tenant), cmp.Compare(a.Name, b.Name))
+	})
+
+	if s.pusher != nil {
+		report.Queues["hub"] = s.pusher.Pending()
+	}
+	if s.notifier != nil {
+		report.Queues["webhooks"] = s.notifier.Pending()
+	}
+	if s.mailer != nil {
+		report.Queues["email"] = s.mailer.Pending()
+	}
+	if s.publisher != nil {
+		report.Queues["mqtt"] = s.publisher.Pending()
+	}
+	if s.producer != nil {
+		report.Queues["kafka"] = s.producer.Pending()
+	}
+
+	if s.tarMan != nil {
+		report.TargetManager = &unregistration{Unregistered: sErrs.errTarMan == nil, Duration: unregistered.String()}
+		if sErrs.errTarMan != nil {
+			report.TargetManager.Error = sErrs.errTarMan.Error()
+		}
+	}
+	return report
+}
+
+// summarizeChecks summarizes the latest results of the checks in the database
+func summarizeChecks(tenant string, dbase db.DB) []checkSummary {
+	summaries := []checkSummary{}
+	for name, result := range dbase.List() {
+		summary := checkSummary{Name: name, Tenant: tenant, Timestamp: result.Timestamp, Targets: len(targetsOf(result.Data))}
+		for target, failed := range runtime.TargetFailures(name, result.Data) {
+			if failed {
+				summary.Failed = append(summary.Failed, target)
+			}
+		}
+		slices.Sort(summary.Failed)
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// reportShutdown logs the shutdown report and writes it to the configured file, if any
+func (s *Sparrow) reportShutdown(ctx context.Context, report shutdownReport) {
+	log := logger.FromContext(ctx)
+	log.InfoContext(ctx, "Shutdown report", "checks", report.Checks, "queues", report.Queues,
+		"targetManager", report.TargetManager, "errors", report.Errors)
+
+	if path := s.config.Shutdown.Report; path != "" {
+		if err := writeReport(path, report); err != nil {
+			log.ErrorContext(ctx, "Failed to write the shutdown report", "path", path, "error", err)
+		}
+	}
+}
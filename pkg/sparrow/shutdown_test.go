@@ -0,0 +1,103 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/db"
+	managermock "github.com/caas-team/sparrow/pkg/sparrow/targets/test"
+	"github.com/caas-team/sparrow/pkg/webhook"
+)
+
+func TestSparrow_shutdownReport(t *testing.T) {
+	ts := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	dbase := db.NewInMemory()
+	_ = dbase.Save(checks.ResultDTO{Name: "health", Result: &checks.Result{
+		Data:      map[string]any{"https://a.example.com": "healthy", "https://b.example.com": "unhealthy"},
+		Timestamp: ts,
+	}})
+	tenantDB := db.NewInMemory()
+	_ = tenantDB.Save(checks.ResultDTO{Name: "dns", Result: &checks.Result{
+		Data:      map[string]any{"example.com": map[string]any{"resolved": []string{"10.0.0.1"}}},
+		Timestamp: ts,
+	}})
+
+	n, err := webhook.NewNotifier(webhook.Config{})
+	if err != nil {
+		t.Fatalf("webhook.NewNotifier() error = %v", err)
+	}
+	s := &Sparrow{
+		config:   &config.Config{SparrowName: "sparrow.example.com"},
+		db:       dbase,
+		tenants:  map[string]*tenant{"team-a": {name: "team-a", db: tenantDB}},
+		tarMan:   &managermock.MockTargetManager{},
+		notifier: n,
+	}
+	s.notifier.Submit(checks.ResultDTO{Name: "health", Result: &checks.Result{}})
+
+	got := s.shutdownReport(ErrShutdown{errTarMan: errors.New("gitlab unavailable")}, time.Second)
+
+	wantChecks := []checkSummary{
+		{Name: "health", Timestamp: ts, Targets: 2, Failed: []string{"https://b.example.com"}},
+		{Name: "dns", Tenant: "team-a", Timestamp: ts, Targets: 1},
+	}
+	if !reflect.DeepEqual(got.Checks, wantChecks) {
+		t.Errorf("shutdownReport().Checks = %+v, want %+v", got.Checks, wantChecks)
+	}
+	wantQueues := map[string]int{"db": 0, "db/team-a": 0, "webhooks": 1}
+	if !reflect.DeepEqual(got.Queues, wantQueues) {
+		t.Errorf("shutdownReport().Queues = %v, want %v", got.Queues, wantQueues)
+	}
+	wantTarMan := &unregistration{Unregistered: false, Duration: "1s", Error: "gitlab unavailable"}
+	if !reflect.DeepEqual(got.TargetManager, wantTarMan) {
+		t.Errorf("shutdownReport().TargetManager = %+v, want %+v", got.TargetManager, wantTarMan)
+	}
+	if got.Errors["targetManager"] != "gitlab unavailable" {
+		t.Errorf("shutdownReport().Errors = %v, want the target manager error", got.Errors)
+	}
+}
+
+func TestSparrow_reportShutdown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shutdown.json")
+	s := &Sparrow{config: &config.Config{SparrowName: "sparrow.example.com", Shutdown: config.ShutdownConfig{Report: path}}}
+
+	s.reportShutdown(context.Background(), shutdownReport{Instance: "sparrow.example.com", Checks: []checkSummary{}, Queues: map[string]int{"db": 0}})
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the shutdown report: %v", err)
+	}
+	var got shutdownReport
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Failed to decode the shutdown report: %v", err)
+	}
+	if got.Instance != "sparrow.example.com" || got.TargetManager != nil {
+		t.Errorf("reportShutdown() wrote %s", b)
+	}
+}
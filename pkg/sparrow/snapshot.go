@@ -0,0 +1,173 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/db"
+)
+
+// Snapshot is a dump of the latest results of a sparrow.
+// It's used to hand the results to a replacement instance, e.g. during node maintenance.
+type Snapshot struct {
+	// Instance is the identity of the exporting sparrow
+	Instance string `json:"instance"`
+	// Timestamp is the time the snapshot was taken
+	Timestamp time.Time `json:"timestamp"`
+	// Results are the results of the default checks mapped by check name
+	Results map[string]checks.Result `json:"results"`
+	// Tenants are the results of the tenants' checks mapped by tenant and check name
+	Tenants map[string]map[string]checks.Result `json:"tenants,omitempty"`
+	// Fleet are the results received from other sparrows mapped by instance and check name
+	Fleet map[string]map[string]checks.Result `json:"fleet,omitempty"`
+}
+
+// snapshot returns a snapshot of the latest results of the sparrow
+func (s *Sparrow) snapshot() Snapshot {
+	snap := Snapshot{
+		Instance:  s.config.SparrowName,
+		Timestamp: time.Now().UTC(),
+		Results:   s.db.List(),
+	}
+	if len(s.tenants) > 0 {
+		snap.Tenants = make(map[string]map[string]checks.Result, len(s.tenants))
+		for name, t := range s.tenants {
+			snap.Tenants[name] = t.db.List()
+		}
+	}
+	if s.receiver != nil {
+		snap.Fleet = s.receiver.Results()
+	}
+	return snap
+}
+
+// restore stores the results of the snapshot. Results older than the already stored ones
+// and results of tenants that aren't configured are skipped.
+func (s *Sparrow) restore(ctx context.Context, snap *Snapshot) {
+	log := logger.FromContext(ctx)
+
+	restored := db.Restore(s.db, snap.Results)
+	for name, results := range snap.Tenants {
+		t, ok := s.tenants[name]
+		if !ok {
+			log.Warn("Skipping results of unknown tenant in snapshot", "tenant", name)
+			continue
+		}
+		restored += db.Restore(t.db, results)
+	}
+	if len(snap.Fleet) > 0 {
+		if s.receiver == nil {
+			log.Warn("Skipping fleet results in snapshot, the hub receiver is disabled")
+		} else {
+			s.receiver.Restore(snap.Fleet)
+		}
+	}
+
+	log.Info("Restored results from snapshot", "instance", snap.Instance, "timestamp", snap.Timestamp,
+		"results", restored, "fleetInstances", len(snap.Fleet))
+}
+
+// importSnapshot restores the results of the snapshot configured to be imported on startup.
+// A snapshot failing to load is logged and skipped, so it never prevents the sparrow from starting.
+func (s *Sparrow) importSnapshot(ctx context.Context) {
+	log := logger.FromContext(ctx)
+
+	snap, err := loadSnapshot(ctx, &s.config.Snapshot)
+	if err != nil {
+		log.Error("Failed to load snapshot, starting without restored results", "import", s.config.Snapshot.Import, "error", err)
+		return
+	}
+	s.restore(ctx, snap)
+}
+
+// loadSnapshot reads the snapshot from the configured file or fetches it from the configured http(s) URL
+func loadSnapshot(ctx context.Context, cfg *config.SnapshotConfig) (*Snapshot, error) {
+	var b []byte
+	var err error
+	if strings.HasPrefix(cfg.Import, "http://") || strings.HasPrefix(cfg.Import, "https://") {
+		b, err = fetchSnapshot(ctx, cfg)
+	} else {
+		b, err = os.ReadFile(cfg.Import)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err = json.Unmarshal(b, &snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// fetchSnapshot fetches the snapshot from the export endpoint on the admin listener of another sparrow
+func fetchSnapshot(ctx context.Context, cfg *config.SnapshotConfig) ([]byte, error) {
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.Import, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// handleExport returns a snapshot of the latest results of the sparrow
+func (s *Sparrow) handleExport(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.snapshot()); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
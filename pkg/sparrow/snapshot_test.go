@@ -0,0 +1,133 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/hub"
+)
+
+func TestSparrow_snapshot(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	old := &Sparrow{
+		config:   &config.Config{SparrowName: "sparrow-old.com"},
+		db:       db.NewInMemory(),
+		receiver: hub.NewReceiver(hub.ReceiverConfig{Enabled: true}),
+		tenants: map[string]*tenant{
+			"team-a": {name: "team-a", db: db.NewInMemory()},
+			"team-b": {name: "team-b", db: db.NewInMemory()},
+		},
+	}
+	old.db.Save(checks.ResultDTO{Name: "health", Result: &checks.Result{Data: "ok", Timestamp: now}})
+	old.tenants["team-a"].db.Save(checks.ResultDTO{Name: "latency", Result: &checks.Result{Data: "ok", Timestamp: now}})
+	old.tenants["team-b"].db.Save(checks.ResultDTO{Name: "dns", Result: &checks.Result{Data: "ok", Timestamp: now}})
+	old.receiver.Restore(map[string]map[string]checks.Result{"sparrow-a.com": {"health": {Data: "ok", Timestamp: now}}})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		old.handleExport(w, r)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		source func(t *testing.T) string
+	}{
+		{
+			name:   "from url",
+			source: func(*testing.T) string { return srv.URL },
+		},
+		{
+			name: "from file",
+			source: func(t *testing.T) string {
+				b, err := json.Marshal(old.snapshot())
+				if err != nil {
+					t.Fatalf("Failed to marshal snapshot: %v", err)
+				}
+				path := filepath.Join(t.TempDir(), "snapshot.json")
+				if err = os.WriteFile(path, b, 0o600); err != nil {
+					t.Fatalf("Failed to write snapshot: %v", err)
+				}
+				return path
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{
+				config: &config.Config{
+					SparrowName: "sparrow-new.com",
+					Snapshot:    config.SnapshotConfig{Import: tt.source(t), Timeout: time.Second, Token: "secret"},
+				},
+				db:       db.NewInMemory(),
+				receiver: hub.NewReceiver(hub.ReceiverConfig{Enabled: true}),
+				tenants:  map[string]*tenant{"team-a": {name: "team-a", db: db.NewInMemory()}},
+			}
+
+			s.importSnapshot(ctx)
+
+			if res, ok := s.db.Get("health"); !ok || !res.Timestamp.Equal(now) {
+				t.Errorf("Expected the health result to be restored, got %v", res)
+			}
+			if _, ok := s.tenants["team-a"].db.Get("latency"); !ok {
+				t.Error("Expected the latency result of tenant team-a to be restored")
+			}
+			if _, ok := s.receiver.Results()["sparrow-a.com"]["health"]; !ok {
+				t.Error("Expected the fleet results to be restored")
+			}
+		})
+	}
+}
+
+func TestSparrow_importSnapshot_failure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	for _, source := range []string{srv.URL, filepath.Join(t.TempDir(), "missing.json")} {
+		s := &Sparrow{
+			config: &config.Config{Snapshot: config.SnapshotConfig{Import: source}},
+			db:     db.NewInMemory(),
+		}
+
+		s.importSnapshot(context.Background())
+
+		if got := s.db.List(); len(got) != 0 {
+			t.Errorf("Expected no results to be restored from %s, got %v", source, got)
+		}
+	}
+}
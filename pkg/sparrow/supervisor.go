@@ -0,0 +1,114 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// restartDelay is the delay before a failed component is restarted the first time
+	restartDelay = time.Second
+	// maxRestartDelay caps the exponentially growing delay between the restarts of a component
+	maxRestartDelay = time.Minute
+	// maxRestarts is the number of consecutive restarts after which a failing component fails the sparrow
+	maxRestarts = 5
+)
+
+// restartPolicy defines how a failed component is handled
+type restartPolicy int
+
+const (
+	// failFast shuts the sparrow down if the component fails
+	failFast restartPolicy = iota
+	// restartOnFailure restarts the component with a backoff if it fails
+	restartOnFailure
+)
+
+// component is a subsystem of the sparrow that runs until the sparrow is shut down
+type component struct {
+	// name is the name of the component reported in logs, metrics and errors
+	name string
+	// run runs the component until the context is done or the component is shut down
+	run func(ctx context.Context) error
+	// policy defines how the component is handled if it fails
+	policy restartPolicy
+}
+
+// newComponentRestarts creates the metric counting the restarts of the sparrow's components
+func newComponentRestarts() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sparrow_component_restarts_total",
+		Help: "Number of restarts of a failed component of the sparrow",
+	}, []string{"component"})
+}
+
+// supervise runs the component until the context is done.
+// A component that panics or returns an error fails. If its restart policy allows it,
+// it's restarted with an exponential backoff. It returns an ErrComponentFailed
+// if the component failed and isn't restarted.
+// A component that returns without an error or because the context is done
+// is considered to be stopped.
+func (s *Sparrow) supervise(ctx context.Context, c component) error {
+	log := logger.FromContext(ctx).With("component", c.name)
+
+	restarts := 0
+	for {
+		start := s.Clock().Now()
+		err := runComponent(ctx, c)
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+
+		// A component that ran for a while before it failed gets a fresh restart budget
+		if s.Clock().Since(start) > maxRestartDelay {
+			restarts = 0
+		}
+		if c.policy == failFast || restarts >= maxRestarts {
+			log.Error("Component failed", "error", err, "restarts", restarts)
+			return &ErrComponentFailed{Component: c.name, Restarts: restarts, Err: err}
+		}
+
+		delay := min(restartDelay<<restarts, maxRestartDelay)
+		log.Warn("Component failed, restarting it", "error", err, "restarts", restarts, "delay", delay)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.Clock().After(delay):
+		}
+		restarts++
+		s.restarts.WithLabelValues(c.name).Inc()
+	}
+}
+
+// runComponent runs the component and converts a panic of the component into an error
+func runComponent(ctx context.Context, c component) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("component panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return c.run(ctx)
+}
@@ -0,0 +1,149 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/caas-team/sparrow/internal/clock"
+)
+
+func TestSparrow_supervise(t *testing.T) {
+	errComponent := errors.New("component failed")
+
+	tests := []struct {
+		name string
+		// failures is the number of runs failing before the component stops, -1 fails forever
+		failures     int
+		panics       bool
+		policy       restartPolicy
+		wantErr      bool
+		wantRestarts int
+	}{
+		{
+			name:     "stopped",
+			failures: 0,
+			policy:   failFast,
+		},
+		{
+			name:     "fail fast",
+			failures: 1,
+			policy:   failFast,
+			wantErr:  true,
+		},
+		{
+			name:     "panic fails fast",
+			failures: 1,
+			panics:   true,
+			policy:   failFast,
+			wantErr:  true,
+		},
+		{
+			name:         "restarted until stopped",
+			failures:     2,
+			policy:       restartOnFailure,
+			wantRestarts: 2,
+		},
+		{
+			name:         "restarted after panic",
+			failures:     1,
+			panics:       true,
+			policy:       restartOnFailure,
+			wantRestarts: 1,
+		},
+		{
+			name:         "fails after too many restarts",
+			failures:     -1,
+			policy:       restartOnFailure,
+			wantErr:      true,
+			wantRestarts: maxRestarts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clock.NewFake(time.Now())
+			s := &Sparrow{restarts: newComponentRestarts()}
+			s.SetClock(fake)
+
+			runs := 0
+			c := component{
+				name: "test",
+				run: func(context.Context) error {
+					runs++
+					if tt.failures >= 0 && runs > tt.failures {
+						return nil
+					}
+					if tt.panics {
+						panic("component crashed")
+					}
+					return errComponent
+				},
+				policy: tt.policy,
+			}
+
+			cErr := make(chan error, 1)
+			go func() {
+				cErr <- s.supervise(context.Background(), c)
+			}()
+			for range tt.wantRestarts {
+				fake.BlockUntil(1)
+				fake.Advance(maxRestartDelay)
+			}
+			err := <-cErr
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("supervise() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var failed *ErrComponentFailed
+			if tt.wantErr && (!errors.As(err, &failed) || failed.Component != "test" || failed.Restarts != tt.wantRestarts) {
+				t.Errorf("supervise() error = %v, want the failure of the component after %d restarts", err, tt.wantRestarts)
+			}
+			if tt.wantErr && !tt.panics && !errors.Is(err, errComponent) {
+				t.Errorf("supervise() error = %v, want it to wrap %v", err, errComponent)
+			}
+			if got := int(testutil.ToFloat64(s.restarts.WithLabelValues("test"))); got != tt.wantRestarts {
+				t.Errorf("restarts metric = %d, want %d", got, tt.wantRestarts)
+			}
+		})
+	}
+}
+
+func TestSparrow_supervise_contextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Sparrow{restarts: newComponentRestarts()}
+
+	c := component{
+		name: "test",
+		run: func(ctx context.Context) error {
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		policy: failFast,
+	}
+
+	if err := s.supervise(ctx, c); err != nil {
+		t.Errorf("supervise() error = %v, want a component stopped by the context not to fail", err)
+	}
+}
@@ -0,0 +1,80 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// targetInfo exposes the targets of the latest results of the checks as info-style metric,
+// so they can be joined with the metrics of the checks without calling the API
+type targetInfo struct {
+	db   db.DB
+	desc *prometheus.Desc
+}
+
+// newTargetInfo creates the collector of the target info metric of the results in the database
+func newTargetInfo(dbase db.DB) *targetInfo {
+	return &targetInfo{
+		db: dbase,
+		desc: prometheus.NewDesc(
+			"sparrow_target_info",
+			"Targets of the latest result of a check. The value is always 1.",
+			[]string{"check", "target", "instance", "schema_version"},
+			nil,
+		),
+	}
+}
+
+// Describe sends the descriptor of the target info metric to the channel
+func (t *targetInfo) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.desc
+}
+
+// Collect sends a target info metric for every target of the latest results to the channel
+func (t *targetInfo) Collect(ch chan<- prometheus.Metric) {
+	for name, result := range t.db.List() {
+		version := strconv.Itoa(max(result.SchemaVersion, checks.LegacySchemaVersion))
+		for _, target := range targetsOf(result.Data) {
+			ch <- prometheus.MustNewConstMetric(t.desc, prometheus.GaugeValue, 1, name, target, result.Instance, version)
+		}
+	}
+}
+
+// targetsOf returns the sorted targets of the result data.
+// The data of most checks maps the targets to their results, the data of other checks has no targets.
+func targetsOf(data any) []string {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+
+	targets := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		targets = append(targets, k.String())
+	}
+	slices.Sort(targets)
+	return targets
+}
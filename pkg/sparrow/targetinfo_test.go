@@ -0,0 +1,83 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/db"
+)
+
+func TestTargetInfo_Collect(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []checks.ResultDTO
+		want    string
+	}{
+		{
+			name: "no results",
+			want: "",
+		},
+		{
+			name: "targets of the results",
+			results: []checks.ResultDTO{
+				{Name: "health", Result: &checks.Result{
+					Data:          map[string]string{"https://b.example.com": "healthy", "https://a.example.com": "unhealthy"},
+					Instance:      "sparrow.example.com",
+					SchemaVersion: 2,
+				}},
+				{Name: "dns", Result: &checks.Result{
+					Data:     map[string]any{"example.com": map[string]any{"total": 0.1}},
+					Instance: "sparrow.example.com",
+				}},
+			},
+			want: `
+# HELP sparrow_target_info Targets of the latest result of a check. The value is always 1.
+# TYPE sparrow_target_info gauge
+sparrow_target_info{check="dns",instance="sparrow.example.com",schema_version="1",target="example.com"} 1
+sparrow_target_info{check="health",instance="sparrow.example.com",schema_version="2",target="https://a.example.com"} 1
+sparrow_target_info{check="health",instance="sparrow.example.com",schema_version="2",target="https://b.example.com"} 1
+`,
+		},
+		{
+			name: "result without targets",
+			results: []checks.ResultDTO{
+				{Name: "custom", Result: &checks.Result{Data: []string{"no", "targets"}}},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbase := db.NewInMemory()
+			for _, r := range tt.results {
+				_ = dbase.Save(r)
+			}
+
+			err := testutil.CollectAndCompare(newTargetInfo(dbase), strings.NewReader(tt.want), "sparrow_target_info")
+			if err != nil {
+				t.Errorf("Collect() unexpected metrics: %v", err)
+			}
+		})
+	}
+}
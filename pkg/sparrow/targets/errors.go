@@ -29,8 +29,24 @@ var (
 	ErrInvalidUnhealthyThreshold = errors.New("invalid unhealthy threshold")
 	// ErrInvalidUpdateInterval is returned when the update interval is invalid
 	ErrInvalidUpdateInterval = errors.New("invalid update interval")
+	// ErrInvalidUpdateDebounce is returned when the update debounce is invalid
+	ErrInvalidUpdateDebounce = errors.New("invalid update debounce")
+	// ErrInvalidAPIPort is returned when the advertised api port is invalid
+	ErrInvalidAPIPort = errors.New("invalid api port")
+	// ErrInvalidInjection is returned when an injection policy of the global targets is invalid
+	ErrInvalidInjection = errors.New("invalid global target injection")
+	// ErrInvalidShutdownTimeout is returned when the shutdown timeout is invalid
+	ErrInvalidShutdownTimeout = errors.New("invalid shutdown timeout")
+	// ErrInvalidTombstone is returned when the tombstone configuration is invalid
+	ErrInvalidTombstone = errors.New("invalid tombstone configuration")
+	// ErrInvalidLabels is returned when an advertised label name is invalid
+	ErrInvalidLabels = errors.New("invalid label name")
 	// ErrInvalidInteractorType is returned when the interactor type isn't recognized
 	ErrInvalidInteractorType = errors.New("invalid interactor type")
+	// ErrInvalidRetry is returned when the retry configuration is invalid
+	ErrInvalidRetry = errors.New("invalid retry configuration")
 	// ErrInvalidScheme is returned when the scheme is not http or https
 	ErrInvalidScheme = errors.New("scheme must be 'http' of 'https'")
+	// ErrShutdown is returned when a write to the remote state backend is canceled by the shutdown
+	ErrShutdown = errors.New("target manager is shut down")
 )
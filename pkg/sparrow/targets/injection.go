@@ -0,0 +1,141 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"text/template"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/dns"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/latency"
+	"github.com/caas-team/sparrow/pkg/checks/pmtu"
+	"github.com/caas-team/sparrow/pkg/checks/traceroute"
+)
+
+// injectableChecks are the checks the global targets are injected into.
+// The traceroute check doesn't support templates, because its targets aren't plain strings.
+var injectableChecks = []string{health.CheckName, latency.CheckName, dns.CheckName, pmtu.CheckName, traceroute.CheckName}
+
+// Injection configures per check name how the global targets are injected into the targets of the check.
+// Checks without a policy get the global targets in their default form.
+type Injection map[string]InjectionPolicy
+
+// InjectionPolicy controls whether and how the global targets are injected into the targets of a check
+type InjectionPolicy struct {
+	// Disabled excludes the check from the injection of the global targets
+	Disabled bool `yaml:"disabled" mapstructure:"disabled"`
+	// Template is the Go template rendering the target from the global target, e.g. `{{ .Url }}/health`.
+	// Defaults to the URL for the health and latency check and to the host for the dns and pmtu check.
+	Template string `yaml:"template" mapstructure:"template"`
+	// Port overrides the port of the global targets' URLs
+	Port int `yaml:"port" mapstructure:"port"`
+	// Transforms are the rules transforming the global targets' URLs in order, after the port override
+	// and before the template is rendered
+	Transforms Transforms `yaml:"transforms" mapstructure:"transforms"`
+}
+
+// InjectionData is the data the injection templates are rendered with
+type InjectionData struct {
+	// Url is the URL of the global target including the port
+	Url string
+	// Scheme is the scheme of the global target's URL
+	Scheme string
+	// Host is the host of the global target without the port
+	Host string
+	// Port is the port of the global target's URL. Empty if it's the default port of the scheme.
+	Port string
+	// Target is the registration of the global target
+	Target checks.GlobalTarget
+}
+
+// Validate validates the injection policies
+func (i Injection) Validate() error {
+	for name, p := range i {
+		if !slices.Contains(injectableChecks, name) {
+			return fmt.Errorf("%w: global targets aren't injected into check %q", ErrInvalidInjection, name)
+		}
+		if p.Port < 0 || p.Port > maxPort {
+			return fmt.Errorf("%w: port of check %q must be between 0 and %d", ErrInvalidInjection, name, maxPort)
+		}
+		if err := p.Transforms.Validate(); err != nil {
+			return fmt.Errorf("%w: transforms of check %q: %w", ErrInvalidInjection, name, err)
+		}
+		if p.Template == "" {
+			continue
+		}
+		if name == traceroute.CheckName {
+			return fmt.Errorf("%w: check %q doesn't support templates", ErrInvalidInjection, name)
+		}
+		if _, err := p.render(InjectionData{Url: "https://sparrow.example.com", Scheme: "https", Host: "sparrow.example.com"}); err != nil {
+			return fmt.Errorf("%w: template of check %q: %w", ErrInvalidInjection, name, err)
+		}
+	}
+	return nil
+}
+
+// Enabled returns true if the global targets are injected into the check
+func (i Injection) Enabled(check string) bool {
+	return !i[check].Disabled
+}
+
+// URL returns the URL of the global target with the port override and the transforms of the check's policy applied
+func (i Injection) URL(check string, gt checks.GlobalTarget) (*url.URL, error) { //nolint:gocritic // no performance concerns yet
+	u, err := gt.URL()
+	if err != nil {
+		return nil, err
+	}
+	if p := i[check].Port; p > 0 {
+		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(p))
+	}
+	return i[check].Transforms.Apply(u, gt)
+}
+
+// Target returns the target of the check rendered from the global target.
+// Without a template, the target is rendered by the given default function.
+func (i Injection) Target(check string, gt checks.GlobalTarget, def func(u *url.URL) string) (string, error) { //nolint:gocritic // no performance concerns yet
+	u, err := i.URL(check, gt)
+	if err != nil {
+		return "", err
+	}
+
+	p := i[check]
+	if p.Template == "" {
+		return def(u), nil
+	}
+	return p.render(InjectionData{Url: u.String(), Scheme: u.Scheme, Host: u.Hostname(), Port: u.Port(), Target: gt})
+}
+
+// render renders the template of the policy with the given data
+func (p InjectionPolicy) render(data InjectionData) (string, error) { //nolint:gocritic // no performance concerns yet
+	tmpl, err := template.New("injection").Option("missingkey=error").Parse(p.Template)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
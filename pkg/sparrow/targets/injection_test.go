@@ -0,0 +1,117 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package targets
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestInjection_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		injection Injection
+		wantErr   bool
+	}{
+		{name: "no policies", injection: nil},
+		{
+			name: "valid policies",
+			injection: Injection{
+				"latency":    {Template: "{{ .Url }}/health"},
+				"dns":        {Disabled: true},
+				"traceroute": {Port: 8443},
+			},
+		},
+		{name: "unknown check", injection: Injection{"ntp": {Disabled: true}}, wantErr: true},
+		{name: "port out of range", injection: Injection{"health": {Port: 70000}}, wantErr: true},
+		{name: "unparsable template", injection: Injection{"health": {Template: "{{ .Url "}}, wantErr: true},
+		{name: "unknown template field", injection: Injection{"health": {Template: "{{ .Path }}"}}, wantErr: true},
+		{name: "template for traceroute", injection: Injection{"traceroute": {Template: "{{ .Host }}"}}, wantErr: true},
+		{name: "invalid transform", injection: Injection{"health": {Transforms: Transforms{{Scheme: "ftp"}}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.injection.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Injection.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidInjection) {
+				t.Errorf("Injection.Validate() error = %v, want %v", err, ErrInvalidInjection)
+			}
+		})
+	}
+}
+
+func TestInjection_Target(t *testing.T) {
+	gt := checks.GlobalTarget{Url: "https://sparrow.com", Version: "v1.0.0"}
+	tests := []struct {
+		name      string
+		injection Injection
+		def       func(*url.URL) string
+		want      string
+	}{
+		{name: "default url", def: (*url.URL).String, want: "https://sparrow.com"},
+		{name: "default host", def: (*url.URL).Hostname, want: "sparrow.com"},
+		{
+			name:      "port override",
+			injection: Injection{"health": {Port: 8443}},
+			def:       (*url.URL).String,
+			want:      "https://sparrow.com:8443",
+		},
+		{
+			name:      "template",
+			injection: Injection{"health": {Template: "{{ .Url }}/health?version={{ .Target.Version }}"}},
+			def:       (*url.URL).String,
+			want:      "https://sparrow.com/health?version=v1.0.0",
+		},
+		{
+			name:      "transforms",
+			injection: Injection{"health": {Port: 8080, Transforms: Transforms{{Scheme: "http"}, {PathSuffix: "/health"}}}},
+			def:       (*url.URL).String,
+			want:      "http://sparrow.com:8080/health",
+		},
+		{
+			name:      "transforms with template",
+			injection: Injection{"health": {Transforms: Transforms{{Port: 8443}}, Template: "{{ .Url }}/health"}},
+			def:       (*url.URL).String,
+			want:      "https://sparrow.com:8443/health",
+		},
+		{
+			name:      "template with port override",
+			injection: Injection{"health": {Template: "{{ .Scheme }}://{{ .Host }}:{{ .Port }}/health", Port: 8443}},
+			def:       (*url.URL).String,
+			want:      "https://sparrow.com:8443/health",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.injection.Target("health", gt, tt.def)
+			if err != nil {
+				t.Fatalf("Injection.Target() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Injection.Target() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,12 +22,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
 	"sync"
 	"time"
 
 	smetrics "github.com/caas-team/sparrow/pkg/sparrow/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/caas-team/sparrow/internal/clock"
+	"github.com/caas-team/sparrow/internal/helper"
 	"github.com/caas-team/sparrow/internal/logger"
 	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets/remote"
@@ -35,7 +40,15 @@ import (
 
 var _ TargetManager = (*manager)(nil)
 
-const shutdownTimeout = 30 * time.Second
+// defaultShutdownTimeout is the time budget for unregistering on shutdown if none is configured
+const defaultShutdownTimeout = 30 * time.Second
+
+const (
+	// retryBudgetRate is the number of retries per second added to the retry budget
+	retryBudgetRate = 0.1
+	// retryBudgetBurst is the maximum number of retries the retry budget holds
+	retryBudgetBurst = 10
+)
 
 // manager implements the TargetManager interface
 type manager struct {
@@ -49,6 +62,18 @@ type manager struct {
 	name string
 	// registered contains whether the instance has already registered itself as a global target
 	registered bool
+	// restored is true if the registration was restored from the state file
+	// and hasn't been verified with a fetch of the global targets yet
+	restored bool
+	// written is the registration last written to the remote state backend
+	written checks.GlobalTarget
+	// stopped is true once the manager is shut down, so no write in flight is committed afterwards
+	stopped bool
+	// cancelWrite cancels the write to the remote state backend in flight, nil if there's none.
+	// The writes are done without holding the lock, so the shutdown doesn't wait for their retries.
+	cancelWrite context.CancelFunc
+	// checks are the names of the checks the instance runs
+	checks []string
 	// cfg contains the general configuration for the target manager
 	cfg General
 	// interactor is the remote interactor used to interact with the remote state backend
@@ -57,11 +82,37 @@ type manager struct {
 	metrics metrics
 	// metricsProvider is the metrics provider used to register the metrics
 	metricsProvider smetrics.Provider
+	// budget limits the retries of all requests to the remote state backend
+	budget *helper.RetryBudget
+	// Source is the clock the reconciliation is scheduled with
+	clock.Source
+	// onReconcile is called after every step of the reconciliation with the step's error.
+	// It allows tests to wait for a step instead of sleeping.
+	onReconcile func(step reconcileStep, err error)
 }
 
+// reconcileStep is a step of the reconciliation
+type reconcileStep string
+
+const (
+	// stepRefreshed is the step that refreshes the global targets
+	stepRefreshed reconcileStep = "refreshed"
+	// stepRegistered is the step that registers the instance as a global target
+	stepRegistered reconcileStep = "registered"
+	// stepUpdated is the step that updates the registration
+	stepUpdated reconcileStep = "updated"
+)
+
 // metrics contains the prometheus metrics for the target manager
 type metrics struct {
 	registered prometheus.Gauge
+	retries    *prometheus.CounterVec
+	// unregisterDuration is the duration of the unregistration on shutdown
+	unregisterDuration prometheus.Gauge
+	// unregisterFailures is the number of failed unregistrations on shutdown
+	unregisterFailures prometheus.Counter
+	// tombstones is the number of unexpired tombstones of gracefully unregistered instances
+	tombstones prometheus.Gauge
 }
 
 // newMetrics creates a new metrics struct
@@ -71,15 +122,31 @@ func newMetrics() metrics {
 			Name: "sparrow_target_manager_registered",
 			Help: "Indicates whether the instance is registered as a global target",
 		}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sparrow_target_manager_retries_total",
+			Help: "Number of retried requests to the remote state backend",
+		}, []string{"operation"}),
+		unregisterDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sparrow_target_manager_unregister_duration_seconds",
+			Help: "Duration of the unregistration as global target on shutdown",
+		}),
+		unregisterFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "sparrow_target_manager_unregister_failures_total",
+			Help: "Number of failed unregistrations as global target on shutdown",
+		}),
+		tombstones: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "sparrow_target_manager_tombstones",
+			Help: "Number of unexpired tombstones of instances that unregistered gracefully",
+		}),
 	}
 }
 
 // NewManager creates a new target manager
 func NewManager(name string, cfg TargetManagerConfig, mp smetrics.Provider) TargetManager { //nolint:gocritic // no performance concerns yet
 	m := newMetrics()
-	mp.GetRegistry().MustRegister(m.registered)
+	mp.GetRegistry().MustRegister(m.registered, m.retries, m.unregisterDuration, m.unregisterFailures, m.tombstones)
 
-	return &manager{
+	t := &manager{
 		name:            name,
 		cfg:             cfg.General,
 		mu:              sync.RWMutex{},
@@ -87,7 +154,14 @@ func NewManager(name string, cfg TargetManagerConfig, mp smetrics.Provider) Targ
 		interactor:      cfg.Type.Interactor(&cfg.Config),
 		metrics:         m,
 		metricsProvider: mp,
+		budget:          helper.NewRetryBudget(retryBudgetRate, retryBudgetBurst),
 	}
+
+	ctx := context.Background()
+	if err := t.restoreState(ctx); err != nil {
+		logger.FromContext(ctx).Warn("Could not restore target manager state", "stateFile", cfg.StateFile, "error", err)
+	}
+	return t
 }
 
 // Reconcile reconciles the targets of the target manager.
@@ -98,9 +172,9 @@ func NewManager(name string, cfg TargetManagerConfig, mp smetrics.Provider) Targ
 func (t *manager) Reconcile(ctx context.Context) error {
 	log := logger.FromContext(ctx)
 
-	checkTimer := startTimer(t.cfg.CheckInterval)
-	registrationTimer := startTimer(t.cfg.RegistrationInterval)
-	updateTimer := startTimer(t.cfg.UpdateInterval)
+	checkTimer := t.startTimer(t.cfg.CheckInterval)
+	registrationTimer := t.startTimer(t.cfg.RegistrationInterval)
+	updateTimer := t.startTimer(t.cfg.UpdateInterval)
 
 	defer checkTimer.Stop()
 	defer registrationTimer.Stop()
@@ -115,23 +189,26 @@ func (t *manager) Reconcile(ctx context.Context) error {
 		case <-t.done:
 			log.Info("Target manager reconciliation stopped")
 			return nil
-		case <-checkTimer.C:
+		case <-checkTimer.C():
 			err := t.refreshTargets(ctx)
 			if err != nil {
 				log.Warn("Failed to get global targets", "error", err)
 			}
+			t.reconciled(stepRefreshed, err)
 			checkTimer.Reset(t.cfg.CheckInterval)
-		case <-registrationTimer.C:
+		case <-registrationTimer.C():
 			err := t.register(ctx)
 			if err != nil {
 				log.Warn("Failed to register self as global target", "error", err)
 			}
+			t.reconciled(stepRegistered, err)
 			registrationTimer.Reset(t.cfg.RegistrationInterval)
-		case <-updateTimer.C:
+		case <-updateTimer.C():
 			err := t.update(ctx)
 			if err != nil {
 				log.Warn("Failed to update registration", "error", err)
 			}
+			t.reconciled(stepUpdated, err)
 			updateTimer.Reset(t.cfg.UpdateInterval)
 		}
 	}
@@ -152,23 +229,28 @@ func (t *manager) Shutdown(ctx context.Context) error {
 	errC := ctx.Err()
 	log := logger.FromContext(ctx)
 	log.Debug("Shut down signal received")
-	ctxS, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	t.stopped = true
+	if t.cancelWrite != nil {
+		t.cancelWrite()
+	}
+	ctxS, cancel := context.WithTimeout(context.Background(), t.shutdownTimeout())
 	defer cancel()
 
-	if t.registered {
-		f := remote.File{
-			AuthorEmail:   fmt.Sprintf("%s@sparrow", t.name),
-			AuthorName:    t.name,
-			CommitMessage: "Unregistering global target",
-		}
-		f.SetFileName(fmt.Sprintf("%s.json", t.name))
-		err := t.interactor.DeleteFile(ctxS, f)
+	if t.registered && t.cfg.DryRun {
+		log.Info("Dry run, skipping unregistration as global target")
+	} else if t.registered {
+		start := t.Clock().Now()
+		left, err := t.unregister(ctxS)
+		t.metrics.unregisterDuration.Set(t.Clock().Since(start).Seconds())
 		if err != nil {
+			t.metrics.unregisterFailures.Inc()
 			log.Error("Failed to shutdown gracefully", "error", err)
 			return fmt.Errorf("failed to shutdown gracefully: %w", errors.Join(errC, err))
 		}
 		t.registered = false
+		t.written = left
 		t.metrics.registered.Set(0)
+		t.saveState(ctx)
 		log.Info("Successfully unregistered as global target")
 	}
 
@@ -181,14 +263,77 @@ func (t *manager) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-// register registers the current instance as a global target
-func (t *manager) register(ctx context.Context) error {
-	log := logger.FromContext(ctx)
+// unregister deletes the registration from the remote state backend or replaces it
+// with a tombstone if a tombstone ttl is configured. It returns the tombstone left behind,
+// which is empty if the registration was deleted. The caller must hold the lock of the manager.
+func (t *manager) unregister(ctx context.Context) (checks.GlobalTarget, error) {
+	f := remote.File{
+		AuthorEmail:   fmt.Sprintf("%s@sparrow", t.name),
+		AuthorName:    t.name,
+		CommitMessage: "Unregistering global target",
+	}
+	f.SetFileName(fmt.Sprintf("%s.json", t.name))
+
+	if t.cfg.Tombstone.TTL == 0 {
+		err := t.retry(ctx, "delete", func(ctx context.Context) error {
+			return t.interactor.DeleteFile(ctx, f)
+		})
+		return checks.GlobalTarget{}, err
+	}
 
+	// The last seen time is kept, so peers unaware of tombstones drop the instance like a crashed one
+	f.Content = t.written
+	if f.Content.Url == "" {
+		f.Content = t.registration()
+	}
+	now := t.Clock().Now().UTC()
+	f.Content.Unregistered = &now
+	f.CommitMessage = "Unregistering global target with tombstone"
+	err := t.retry(ctx, "tombstone", func(ctx context.Context) error {
+		return t.interactor.PutFile(ctx, f)
+	})
+	if err != nil {
+		return checks.GlobalTarget{}, err
+	}
+	return f.Content, nil
+}
+
+// SetChecks sets the names of the checks the instance runs
+func (t *manager) SetChecks(names []string) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	t.checks = slices.Sorted(slices.Values(names))
+}
 
+// registration returns the registration of the instance as a global target
+func (t *manager) registration() checks.GlobalTarget {
+	return checks.GlobalTarget{
+		Url:      fmt.Sprintf("%s://%s", t.cfg.Scheme, t.name),
+		LastSeen: t.Clock().Now().UTC(),
+		Port:     t.cfg.APIPort,
+		Checks:   t.checks,
+		Version:  t.cfg.Version,
+		Region:   t.cfg.Region,
+		Labels:   t.cfg.Labels,
+	}
+}
+
+// shutdownTimeout returns the time budget for unregistering on shutdown
+func (t *manager) shutdownTimeout() time.Duration {
+	if t.cfg.ShutdownTimeout > 0 {
+		return t.cfg.ShutdownTimeout
+	}
+	return defaultShutdownTimeout
+}
+
+// register registers the current instance as a global target.
+// The lock is only held to build the registration and to commit it after it's written.
+func (t *manager) register(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	t.mu.Lock()
 	if t.registered {
+		t.mu.Unlock()
 		log.Debug("Already registered as global target")
 		return nil
 	}
@@ -197,30 +342,56 @@ func (t *manager) register(ctx context.Context) error {
 		AuthorEmail:   fmt.Sprintf("%s@sparrow", t.name),
 		AuthorName:    t.name,
 		CommitMessage: "Initial registration",
-		Content:       checks.GlobalTarget{Url: fmt.Sprintf("%s://%s", t.cfg.Scheme, t.name), LastSeen: time.Now().UTC()},
+		Content:       t.registration(),
 	}
 	f.SetFileName(fmt.Sprintf("%s.json", t.name))
 
+	// In dry run mode, the instance doesn't consider itself registered, so a later
+	// start without dry run still registers it
+	if t.cfg.DryRun {
+		t.mu.Unlock()
+		log.Info("Dry run, skipping registration as global target", "file", f.Name, "content", f.Content)
+		return nil
+	}
+	// The tombstone of the last shutdown is still in place and gets replaced
+	replace := t.written.Tombstone()
+	t.mu.Unlock()
+
 	log.Debug("Registering as global target")
-	err := t.interactor.PostFile(ctx, f)
+	err := t.write(ctx, "register", f, func(ctx context.Context) error {
+		if replace {
+			return t.interactor.PutFile(ctx, f)
+		}
+		return t.interactor.PostFile(ctx, f)
+	})
 	if err != nil {
 		log.Error("Failed to register global gitlabTargetManager", "error", err)
 		return err
 	}
-	log.Info("Successfully registered")
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		log.Warn("Registered as global target while shutting down, the registration is left until it's unhealthy")
+		return ErrShutdown
+	}
 	t.registered = true
+	t.written = f.Content
+	t.saveState(ctx)
+	log.Info("Successfully registered")
 	t.metrics.registered.Set(1)
 
 	return nil
 }
 
-// update updates the registration file of the current sparrow instance
+// update updates the registration file of the current sparrow instance.
+// The lock is only held to build the registration and to commit it after it's written.
 func (t *manager) update(ctx context.Context) error {
 	log := logger.FromContext(ctx)
 
 	t.mu.Lock()
-	defer t.mu.Unlock()
 	if !t.registered {
+		t.mu.Unlock()
 		log.Debug("Not registered as global target, no update done.")
 		return nil
 	}
@@ -229,61 +400,227 @@ func (t *manager) update(ctx context.Context) error {
 		AuthorEmail:   fmt.Sprintf("%s@sparrow", t.name),
 		AuthorName:    t.name,
 		CommitMessage: "Updated registration",
-		Content:       checks.GlobalTarget{Url: fmt.Sprintf("%s://%s", t.cfg.Scheme, t.name), LastSeen: time.Now().UTC()},
+		Content:       t.registration(),
 	}
 	f.SetFileName(fmt.Sprintf("%s.json", t.name))
 
+	if t.debounced(f.Content) {
+		log.Debug("Registration unchanged since the last update, skipping update", "lastSeen", t.written.LastSeen)
+		t.mu.Unlock()
+		return nil
+	}
+	t.mu.Unlock()
+
 	log.Debug("Updating instance registration")
-	err := t.interactor.PutFile(ctx, f)
+	err := t.write(ctx, "update", f, func(ctx context.Context) error {
+		return t.interactor.PutFile(ctx, f)
+	})
 	if err != nil {
 		log.Error("Failed to update registration", "error", err)
 		return err
 	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped || !t.registered {
+		// The registration was replaced in the meantime, e.g. by the tombstone of the shutdown
+		return nil
+	}
+	t.written = f.Content
+	t.saveState(ctx)
 	log.Debug("Successfully updated registration")
 	return nil
 }
 
-// refreshTargets updates the targets with the latest available healthy targets
+// debounced returns true if the update of the registration can be skipped,
+// because it hasn't changed besides the lastSeen time within the update debounce
+func (t *manager) debounced(gt checks.GlobalTarget) bool {
+	if t.cfg.UpdateDebounce == 0 || t.written.LastSeen.IsZero() {
+		return false
+	}
+
+	written := t.written
+	written.LastSeen = gt.LastSeen
+	return reflect.DeepEqual(written, gt) && gt.LastSeen.Sub(t.written.LastSeen) < t.cfg.UpdateDebounce
+}
+
+// refreshTargets updates the targets with the latest available healthy targets.
+// The targets are fetched without holding the lock, so readers of the targets
+// aren't blocked while the fetch is retried.
 func (t *manager) refreshTargets(ctx context.Context) error {
 	log := logger.FromContext(ctx)
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	var healthyTargets []checks.GlobalTarget
-	targets, err := t.interactor.FetchFiles(ctx)
+	var targets []checks.GlobalTarget
+	err := t.retry(ctx, "fetch", func(ctx context.Context) (err error) {
+		targets, err = t.interactor.FetchFiles(ctx)
+		return err
+	})
 	if err != nil {
 		log.Error("Failed to update global targets", "error", err)
 		return err
 	}
 
+	t.mu.Lock()
+	self := slices.IndexFunc(targets, func(gt checks.GlobalTarget) bool {
+		return gt.Url == fmt.Sprintf("%s://%s", t.cfg.Scheme, t.name)
+	})
+	if self >= 0 && targets[self].Tombstone() {
+		// The instance registers again by replacing its tombstone
+		t.registered = false
+		t.written = targets[self]
+		t.metrics.registered.Set(0)
+		self = -1
+	} else if self < 0 && t.written.Tombstone() {
+		// The tombstone was collected, so the instance registers from scratch
+		t.written = checks.GlobalTarget{}
+	}
+	if self >= 0 && !t.registered {
+		log.Debug("Found self as global target", "lastSeenMin", t.Clock().Since(targets[self].LastSeen).Minutes())
+		t.registered = true
+		t.metrics.registered.Set(1)
+	}
+	if self < 0 && t.restored && !t.written.Tombstone() {
+		// The registration was removed while the instance was down, so it registers again
+		log.Info("Restored registration not found in the global targets")
+		t.registered = false
+		t.written = checks.GlobalTarget{}
+		t.metrics.registered.Set(0)
+	}
+	t.restored = false
+
+	targets, expired := t.buryTombstones(ctx, targets, t.Clock().Now())
 	// filter unhealthy targets - this may be removed in the future
-	for _, target := range targets {
-		if !t.registered && target.Url == fmt.Sprintf("%s://%s", t.cfg.Scheme, t.name) {
-			log.Debug("Found self as global target", "lastSeenMin", time.Since(target.LastSeen).Minutes())
-			t.registered = true
-			t.metrics.registered.Set(1)
+	t.targets = t.healthy(targets, t.Clock().Now())
+	t.saveState(ctx)
+	log.Debug("Updated global targets", "targets", len(t.targets))
+	t.mu.Unlock()
+
+	for _, gt := range expired {
+		t.collect(ctx, gt)
+	}
+	return nil
+}
+
+// buryTombstones returns the targets without the tombstones of the instances that unregistered gracefully.
+// Peers that left a tombstone since the last refresh are logged, so they can be told apart from crashed ones.
+// It also returns the expired tombstones to delete from the remote state backend if configured.
+// The caller must hold the lock of the manager.
+func (t *manager) buryTombstones(ctx context.Context, targets []checks.GlobalTarget, now time.Time) (live, expired []checks.GlobalTarget) {
+	log := logger.FromContext(ctx)
+	live = make([]checks.GlobalTarget, 0, len(targets))
+	tombstones := 0
+	for _, gt := range targets {
+		if !gt.Tombstone() {
+			live = append(live, gt)
+			continue
 		}
 
-		if t.cfg.UnhealthyThreshold == 0 {
-			healthyTargets = append(healthyTargets, target)
+		if slices.ContainsFunc(t.targets, func(known checks.GlobalTarget) bool { return known.Url == gt.Url }) {
+			log.Info("Global target unregistered gracefully", "url", gt.Url, "unregistered", gt.Unregistered)
+		}
+		if t.cfg.Tombstone.TTL == 0 || now.Sub(*gt.Unregistered) < t.cfg.Tombstone.TTL {
+			tombstones++
 			continue
 		}
+		if t.cfg.Tombstone.Collect {
+			expired = append(expired, gt)
+		}
+	}
+	t.metrics.tombstones.Set(float64(tombstones))
+	return live, expired
+}
 
-		if time.Now().Add(-t.cfg.UnhealthyThreshold).After(target.LastSeen) {
-			log.Debug("Skipping unhealthy target", "target", target)
+// collect deletes the expired tombstone from the remote state backend without holding the lock of the manager.
+// Failures are only logged, because another instance may have collected it in the meantime.
+func (t *manager) collect(ctx context.Context, tombstone checks.GlobalTarget) {
+	log := logger.FromContext(ctx).With("url", tombstone.Url)
+	u, err := url.Parse(tombstone.Url)
+	if err != nil || u.Host == "" {
+		log.Warn("Cannot collect tombstone with an invalid url", "error", err)
+		return
+	}
+
+	f := remote.File{
+		AuthorEmail:   fmt.Sprintf("%s@sparrow", t.name),
+		AuthorName:    t.name,
+		CommitMessage: "Collecting expired tombstone",
+	}
+	f.SetFileName(fmt.Sprintf("%s.json", u.Host))
+	err = t.write(ctx, "collect", f, func(ctx context.Context) error {
+		return t.interactor.DeleteFile(ctx, f)
+	})
+	if err != nil {
+		log.Warn("Failed to collect expired tombstone", "error", err)
+		return
+	}
+	log.Debug("Collected expired tombstone")
+}
+
+// healthy returns the targets seen within the unhealthy threshold
+func (t *manager) healthy(targets []checks.GlobalTarget, now time.Time) []checks.GlobalTarget {
+	if t.cfg.UnhealthyThreshold == 0 {
+		return targets
+	}
+
+	var healthy []checks.GlobalTarget
+	for _, target := range targets {
+		if now.Add(-t.cfg.UnhealthyThreshold).After(target.LastSeen) {
 			continue
 		}
-		healthyTargets = append(healthyTargets, target)
+		healthy = append(healthy, target)
+	}
+	return healthy
+}
+
+// write calls the remote state backend to write the file with the configured retries.
+// In dry run mode, the write is only logged. The caller must not hold the lock of the manager,
+// the write is canceled if the manager shuts down in the meantime.
+func (t *manager) write(ctx context.Context, operation string, f remote.File, effector helper.Effector) error {
+	if t.cfg.DryRun {
+		logger.FromContext(ctx).Info("Dry run, skipping write to the remote state backend",
+			"operation", operation, "file", f.Name, "commitMessage", f.CommitMessage, "content", f.Content)
+		return nil
 	}
 
-	t.targets = healthyTargets
-	log.Debug("Updated global targets", "targets", len(t.targets))
-	return nil
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return ErrShutdown
+	}
+	t.cancelWrite = cancel
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.cancelWrite = nil
+		t.mu.Unlock()
+	}()
+
+	return t.retry(ctx, operation, effector)
+}
+
+// retry calls the remote state backend with the configured retries.
+// The retries of all operations share the retry budget of the manager.
+func (t *manager) retry(ctx context.Context, operation string, effector helper.Effector) error {
+	return helper.Retry(effector, t.cfg.Retry,
+		helper.WithBudget(t.budget),
+		helper.OnRetry(func(int, error, time.Duration) {
+			t.metrics.retries.WithLabelValues(operation).Inc()
+		}),
+	)(ctx)
+}
+
+// reconciled reports the completion of a reconciliation step to the onReconcile hook
+func (t *manager) reconciled(step reconcileStep, err error) {
+	if t.onReconcile != nil {
+		t.onReconcile(step, err)
+	}
 }
 
 // startTimer creates a new timer with the given duration.
 // If the duration is 0, the timer is stopped.
-func startTimer(d time.Duration) *time.Timer {
-	res := time.NewTimer(d)
+func (t *manager) startTimer(d time.Duration) clock.Timer {
+	res := t.Clock().NewTimer(d)
 	if d == 0 {
 		res.Stop()
 	}
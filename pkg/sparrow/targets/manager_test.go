@@ -22,11 +22,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/caas-team/sparrow/internal/clock"
+	"github.com/caas-team/sparrow/internal/helper"
 	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/sparrow/targets/remote"
+	dto "github.com/prometheus/client_model/go"
 
 	remotemock "github.com/caas-team/sparrow/pkg/sparrow/targets/remote/test"
 )
@@ -321,6 +326,90 @@ func Test_gitlabTargetManager_register(t *testing.T) {
 	}
 }
 
+// Test_gitlabTargetManager_registration tests that the registration
+// advertises the api port, the checks, the version, the region and the labels of the instance
+func Test_gitlabTargetManager_registration(t *testing.T) {
+	gtm := &manager{
+		name: "sparrow.com",
+		cfg:  General{Scheme: "https", APIPort: 8443, Version: "v1.0.0", Region: "eu-de-1", Labels: map[string]string{"provider": "aws"}},
+	}
+	gtm.SetChecks([]string{"traceroute", "health"})
+
+	got := gtm.registration()
+	want := checks.GlobalTarget{
+		Url:      "https://sparrow.com",
+		LastSeen: got.LastSeen,
+		Port:     8443,
+		Checks:   []string{"health", "traceroute"},
+		Version:  "v1.0.0",
+		Region:   "eu-de-1",
+		Labels:   map[string]string{"provider": "aws"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("registration() = %v, want %v", got, want)
+	}
+	if got.LastSeen.IsZero() {
+		t.Error("registration() has no lastSeen time")
+	}
+}
+
+// Test_gitlabTargetManager_register_retry tests that failed
+// registrations are retried unless the error is permanent or the retry budget is exhausted
+func Test_gitlabTargetManager_register_retry(t *testing.T) {
+	tests := []struct {
+		name        string
+		postErr     error
+		budget      int
+		wantRetries int
+	}{
+		{
+			name:        "transient error is retried",
+			postErr:     errors.New("request failed, status is 503 Service Unavailable"),
+			budget:      retryBudgetBurst,
+			wantRetries: 2,
+		},
+		{
+			name:        "permanent error isn't retried",
+			postErr:     helper.Permanent(errors.New("request failed, status is 400 Bad Request")),
+			budget:      retryBudgetBurst,
+			wantRetries: 0,
+		},
+		{
+			name:        "retries are limited by the budget",
+			postErr:     errors.New("request failed, status is 503 Service Unavailable"),
+			budget:      1,
+			wantRetries: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			glmock := remotemock.New(nil)
+			glmock.SetPostFileErr(tt.postErr)
+			gtm := &manager{
+				interactor: glmock,
+				metrics:    newMetrics(),
+				cfg:        General{Retry: helper.RetryConfig{Count: 2, Delay: time.Millisecond}},
+				budget:     helper.NewRetryBudget(0, tt.budget),
+			}
+
+			if err := gtm.register(context.Background()); err == nil {
+				t.Fatal("register() should have failed")
+			}
+			if got := glmock.PostFileCount() - 1; got != tt.wantRetries {
+				t.Errorf("register() retries = %d, want %d", got, tt.wantRetries)
+			}
+
+			var m dto.Metric
+			if err := gtm.metrics.retries.WithLabelValues("register").Write(&m); err != nil {
+				t.Fatalf("failed to read retries metric: %v", err)
+			}
+			if got := int(m.GetCounter().GetValue()); got != tt.wantRetries {
+				t.Errorf("retries metric = %d, want %d", got, tt.wantRetries)
+			}
+		})
+	}
+}
+
 // Test_gitlabTargetManager_update tests that the update
 // method will update the registration of the sparrow instance in the remote instance
 func Test_gitlabTargetManager_update(t *testing.T) {
@@ -354,6 +443,71 @@ func Test_gitlabTargetManager_update(t *testing.T) {
 	}
 }
 
+// Test_gitlabTargetManager_update_debounce tests that the update method
+// skips updates of unchanged registrations within the update debounce
+func Test_gitlabTargetManager_update_debounce(t *testing.T) {
+	tests := []struct {
+		name     string
+		debounce time.Duration
+		written  checks.GlobalTarget
+		wantPut  bool
+	}{
+		{
+			name:     "no debounce",
+			debounce: 0,
+			written:  checks.GlobalTarget{Url: "https://test", LastSeen: time.Now().UTC()},
+			wantPut:  true,
+		},
+		{
+			name:     "unchanged within debounce",
+			debounce: time.Hour,
+			written:  checks.GlobalTarget{Url: "https://test", LastSeen: time.Now().UTC()},
+			wantPut:  false,
+		},
+		{
+			name:     "unchanged after debounce",
+			debounce: time.Hour,
+			written:  checks.GlobalTarget{Url: "https://test", LastSeen: time.Now().Add(-2 * time.Hour).UTC()},
+			wantPut:  true,
+		},
+		{
+			name:     "changed within debounce",
+			debounce: time.Hour,
+			written:  checks.GlobalTarget{Url: "http://test", LastSeen: time.Now().UTC()},
+			wantPut:  true,
+		},
+		{
+			name:     "checks changed within debounce",
+			debounce: time.Hour,
+			written:  checks.GlobalTarget{Url: "https://test", LastSeen: time.Now().UTC(), Checks: []string{"health"}},
+			wantPut:  true,
+		},
+		{
+			name:     "never written",
+			debounce: time.Hour,
+			wantPut:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			glmock := remotemock.New(nil)
+			gtm := &manager{
+				name:       "test",
+				interactor: glmock,
+				registered: true,
+				written:    tt.written,
+				cfg:        General{Scheme: "https", UpdateDebounce: tt.debounce},
+			}
+			if err := gtm.update(context.Background()); err != nil {
+				t.Fatalf("update() error = %v", err)
+			}
+			if glmock.PutFileCalled() != tt.wantPut {
+				t.Errorf("update() wrote registration = %v, want %v", glmock.PutFileCalled(), tt.wantPut)
+			}
+		})
+	}
+}
+
 // Test_gitlabTargetManager_Reconcile_success tests that the Reconcile method
 // will register the target if it is not registered yet and update the
 // registration if it is already registered
@@ -386,6 +540,8 @@ func Test_gitlabTargetManager_Reconcile_success(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gtm := mockGitlabTargetManager(glmock, "test")
+			fake := clock.NewFake(time.Now())
+			gtm.SetClock(fake)
 			ctx := context.Background()
 			go func() {
 				err := gtm.Reconcile(ctx)
@@ -395,7 +551,7 @@ func Test_gitlabTargetManager_Reconcile_success(t *testing.T) {
 				}
 			}()
 
-			time.Sleep(testUpdateInterval * 2)
+			advance(fake, testUpdateInterval, 1)
 			if gtm.GetTargets()[0].Url != testTarget {
 				t.Fatalf("Reconcile() did not receive the correct target")
 			}
@@ -431,6 +587,9 @@ func Test_gitlabTargetManager_Reconcile_Registration_Update(t *testing.T) {
 	gtm.cfg.RegistrationInterval = 10 * time.Millisecond
 	gtm.cfg.UpdateInterval = 100 * time.Millisecond
 
+	fake := clock.NewFake(time.Now())
+	gtm.SetClock(fake)
+
 	ctx := context.Background()
 	go func() {
 		err := gtm.Reconcile(ctx)
@@ -440,36 +599,28 @@ func Test_gitlabTargetManager_Reconcile_Registration_Update(t *testing.T) {
 		}
 	}()
 
-	timeout := time.After(gtm.cfg.UpdateInterval * 3)
-	select {
-	case <-timeout:
-		gtm.mu.Lock()
-		if !gtm.registered {
-			t.Fatalf("Reconcile() should be registered")
-		}
-		gtm.mu.Unlock()
+	// advance past the second update in steps of the registration interval
+	advance(fake, gtm.cfg.RegistrationInterval, 25)
 
-		// check that the post call was made once, to create the registration
-		if glmock.PostFileCount() != 1 {
-			t.Fatalf("Reconcile() should have registered the instance once")
-		}
+	gtm.mu.Lock()
+	if !gtm.registered {
+		t.Fatalf("Reconcile() should be registered")
+	}
+	gtm.mu.Unlock()
 
-		// check that the put call was made twice, to update the registration
-		if glmock.PutFileCount() != 2 {
-			t.Fatalf("Reconcile() should have updated the registration twice")
-		}
+	// check that the post call was made once, to create the registration
+	if glmock.PostFileCount() != 1 {
+		t.Fatalf("Reconcile() should have registered the instance once")
+	}
 
-		gtm.mu.Lock()
-		if !gtm.registered {
-			t.Fatalf("Reconcile() should have registered the sparrow")
-		}
-		gtm.mu.Unlock()
+	// check that the put call was made twice, to update the registration
+	if glmock.PutFileCount() != 2 {
+		t.Fatalf("Reconcile() should have updated the registration twice, got %d", glmock.PutFileCount())
+	}
 
-		err := gtm.Shutdown(ctx)
-		if err != nil {
-			t.Fatalf("Reconcile() failed to shutdown")
-		}
-	default:
+	err := gtm.Shutdown(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile() failed to shutdown")
 	}
 
 	t.Logf("Reconcile() successfully registered and updated the sparrow")
@@ -510,6 +661,7 @@ func Test_gitlabTargetManager_Reconcile_failure(t *testing.T) {
 			glmock.SetPostFileErr(tt.postErr)
 			glmock.SetPutFileErr(tt.putError)
 
+			steps := recordSteps(gtm)
 			ctx := context.Background()
 			go func() {
 				err := gtm.Reconcile(ctx)
@@ -519,7 +671,7 @@ func Test_gitlabTargetManager_Reconcile_failure(t *testing.T) {
 				}
 			}()
 
-			time.Sleep(time.Millisecond * 300)
+			waitForSteps(t, steps, stepRegistered, stepUpdated)
 
 			gtm.mu.Lock()
 			if tt.postErr != nil && gtm.registered {
@@ -553,19 +705,19 @@ func Test_gitlabTargetManager_Reconcile_Context_Canceled(t *testing.T) {
 
 	gtm := mockGitlabTargetManager(glmock, "test")
 
+	steps := recordSteps(gtm)
 	ctx, cancel := context.WithCancel(context.Background())
 
+	errc := make(chan error, 1)
 	go func() {
-		err := gtm.Reconcile(ctx)
-		if err == nil {
-			t.Error("Reconcile() should have returned an error")
-			return
-		}
+		errc <- gtm.Reconcile(ctx)
 	}()
 
-	time.Sleep(time.Millisecond * 250)
+	waitForSteps(t, steps, stepRegistered, stepUpdated)
 	cancel()
-	time.Sleep(time.Millisecond * 250)
+	if err := <-errc; err == nil {
+		t.Fatalf("Reconcile() should have returned an error")
+	}
 
 	gtm.mu.Lock()
 	if !gtm.registered {
@@ -595,15 +747,9 @@ func Test_gitlabTargetManager_Reconcile_Context_Done(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
 	defer cancel()
-	go func() {
-		err := gtm.Reconcile(ctx)
-		if err == nil {
-			t.Error("Reconcile() should have returned an error")
-			return
-		}
-	}()
-
-	time.Sleep(time.Millisecond * 15)
+	if err := gtm.Reconcile(ctx); err == nil {
+		t.Fatalf("Reconcile() should have returned an error")
+	}
 
 	gtm.mu.Lock()
 	if gtm.registered {
@@ -631,6 +777,7 @@ func Test_gitlabTargetManager_Reconcile_Shutdown(t *testing.T) {
 
 	gtm := mockGitlabTargetManager(glmock, "test")
 
+	steps := recordSteps(gtm)
 	ctx := context.Background()
 	go func() {
 		err := gtm.Reconcile(ctx)
@@ -640,7 +787,7 @@ func Test_gitlabTargetManager_Reconcile_Shutdown(t *testing.T) {
 		}
 	}()
 
-	time.Sleep(time.Millisecond * 250)
+	waitForSteps(t, steps, stepRegistered, stepUpdated)
 
 	err := gtm.Shutdown(ctx)
 	if err != nil {
@@ -675,6 +822,7 @@ func Test_gitlabTargetManager_Reconcile_Shutdown_Fail_Unregister(t *testing.T) {
 	gtm := mockGitlabTargetManager(glmock, "test")
 	glmock.SetDeleteFileErr(errors.New("gitlab API error"))
 
+	steps := recordSteps(gtm)
 	ctx := context.Background()
 	go func() {
 		err := gtm.Reconcile(ctx)
@@ -684,7 +832,7 @@ func Test_gitlabTargetManager_Reconcile_Shutdown_Fail_Unregister(t *testing.T) {
 		}
 	}()
 
-	time.Sleep(time.Millisecond * 250)
+	waitForSteps(t, steps, stepRegistered, stepUpdated)
 
 	err := gtm.Shutdown(ctx)
 	if err == nil {
@@ -698,12 +846,305 @@ func Test_gitlabTargetManager_Reconcile_Shutdown_Fail_Unregister(t *testing.T) {
 	}
 	gtm.mu.Unlock()
 
+	var m dto.Metric
+	if err := gtm.metrics.unregisterFailures.Write(&m); err != nil {
+		t.Fatalf("failed to read unregister failures metric: %v", err)
+	}
+	if got := int(m.GetCounter().GetValue()); got != 1 {
+		t.Errorf("unregister failures metric = %d, want 1", got)
+	}
+
 	// assert mock calls
 	if !glmock.PostFileCalled() || !glmock.PutFileCalled() {
 		t.Fatalf("Reconcile() should have made calls to the gitlab API")
 	}
 }
 
+// Test_gitlabTargetManager_Shutdown_Timeout tests that the unregistration
+// on shutdown is aborted after the configured shutdown timeout
+func Test_gitlabTargetManager_Shutdown_Timeout(t *testing.T) {
+	glmock := remotemock.New(nil)
+	glmock.SetDeleteFileErr(errors.New("gitlab API error"))
+
+	gtm := mockGitlabTargetManager(glmock, "test")
+	gtm.registered = true
+	gtm.budget = helper.NewRetryBudget(retryBudgetRate, retryBudgetBurst)
+	gtm.cfg.Retry = helper.RetryConfig{Count: 100, Delay: 50 * time.Millisecond}
+	gtm.cfg.ShutdownTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	if err := gtm.Shutdown(context.Background()); err == nil {
+		t.Fatal("Shutdown() should have failed")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Shutdown() took %v, want it to be aborted after the shutdown timeout", d)
+	}
+
+	var m dto.Metric
+	if err := gtm.metrics.unregisterDuration.Write(&m); err != nil {
+		t.Fatalf("failed to read unregister duration metric: %v", err)
+	}
+	if got := m.GetGauge().GetValue(); got <= 0 || got > 1 {
+		t.Errorf("unregister duration metric = %v, want it to be within the shutdown timeout", got)
+	}
+}
+
+// Test_gitlabTargetManager_Shutdown_Tombstone tests that the registration is replaced
+// with a tombstone on shutdown if a tombstone ttl is configured and that the tombstone
+// is replaced on the next registration
+func Test_gitlabTargetManager_Shutdown_Tombstone(t *testing.T) {
+	glmock := remotemock.New(nil)
+	gtm := mockGitlabTargetManager(glmock, "test")
+	gtm.cfg.Scheme = "https"
+	gtm.cfg.Tombstone = Tombstone{TTL: time.Hour}
+	c := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	gtm.SetClock(c)
+
+	ctx := context.Background()
+	if err := gtm.register(ctx); err != nil {
+		t.Fatalf("register() error = %v", err)
+	}
+	c.Advance(time.Minute)
+	if err := gtm.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if got := glmock.DeletedFiles(); len(got) != 0 {
+		t.Errorf("Shutdown() deleted %v, want the registration to be kept as tombstone", got)
+	}
+	files := glmock.PutFiles()
+	if len(files) != 1 {
+		t.Fatalf("PutFile() called %d times, want 1", len(files))
+	}
+	tombstone := files[0].Content
+	if !tombstone.Tombstone() || !tombstone.Unregistered.Equal(c.Now()) {
+		t.Errorf("Tombstone unregistered = %v, want %v", tombstone.Unregistered, c.Now())
+	}
+	if !tombstone.LastSeen.Equal(c.Now().Add(-time.Minute)) {
+		t.Errorf("Tombstone lastSeen = %v, want the last seen time of the registration", tombstone.LastSeen)
+	}
+	if gtm.registered || !gtm.written.Tombstone() {
+		t.Errorf("registered = %v, written = %v, want the tombstone to be written", gtm.registered, gtm.written)
+	}
+
+	// The next start continues with the state of the shut down manager
+	gtm.stopped = false
+	if err := gtm.register(ctx); err != nil {
+		t.Fatalf("register() error = %v", err)
+	}
+	if glmock.PostFileCount() != 1 || glmock.PutFileCount() != 2 {
+		t.Errorf("PostFile() called %d times, PutFile() called %d times, want the tombstone to be replaced", glmock.PostFileCount(), glmock.PutFileCount())
+	}
+	if gtm.written.Tombstone() {
+		t.Error("Registration should have replaced the tombstone")
+	}
+}
+
+// Test_gitlabTargetManager_refreshTargets_Tombstones tests that tombstones aren't targets
+// and that expired tombstones are collected if configured
+func Test_gitlabTargetManager_refreshTargets_Tombstones(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Minute)
+	expired := now.Add(-2 * time.Hour)
+	peer := checks.GlobalTarget{Url: "https://peer.sparrow", LastSeen: now}
+	gone := checks.GlobalTarget{Url: "https://gone.sparrow", LastSeen: recent, Unregistered: &recent}
+	old := checks.GlobalTarget{Url: "https://old.sparrow", LastSeen: expired, Unregistered: &expired}
+	self := checks.GlobalTarget{Url: "https://test", LastSeen: recent, Unregistered: &recent}
+
+	tests := []struct {
+		name           string
+		collect        bool
+		wantTombstones float64
+		wantDeleted    []string
+	}{
+		{name: "expired tombstones ignored", wantTombstones: 2},
+		{name: "expired tombstones collected", collect: true, wantTombstones: 2, wantDeleted: []string{"old.sparrow.json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			glmock := remotemock.New([]checks.GlobalTarget{peer, gone, old, self})
+			gtm := mockGitlabTargetManager(glmock, "test")
+			gtm.cfg.Scheme = "https"
+			gtm.cfg.Tombstone = Tombstone{TTL: time.Hour, Collect: tt.collect}
+			gtm.targets = []checks.GlobalTarget{peer, {Url: gone.Url, LastSeen: recent}}
+
+			if err := gtm.refreshTargets(context.Background()); err != nil {
+				t.Fatalf("refreshTargets() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(gtm.targets, []checks.GlobalTarget{peer}) {
+				t.Errorf("targets = %v, want only the live peer", gtm.targets)
+			}
+			if gtm.registered || !gtm.written.Tombstone() {
+				t.Errorf("registered = %v, written = %v, want the own tombstone to be replaced on registration", gtm.registered, gtm.written)
+			}
+			var m dto.Metric
+			if err := gtm.metrics.tombstones.Write(&m); err != nil {
+				t.Fatalf("failed to read tombstones metric: %v", err)
+			}
+			if got := m.GetGauge().GetValue(); got != tt.wantTombstones {
+				t.Errorf("tombstones metric = %v, want %v", got, tt.wantTombstones)
+			}
+			if got := glmock.DeletedFiles(); !reflect.DeepEqual(got, tt.wantDeleted) {
+				t.Errorf("DeletedFiles() = %v, want %v", got, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+// blockingFetch is a remote interactor whose fetch blocks until it's released
+type blockingFetch struct {
+	*remotemock.MockClient
+	called  chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingFetch) FetchFiles(ctx context.Context) ([]checks.GlobalTarget, error) {
+	close(b.called)
+	<-b.release
+	return b.MockClient.FetchFiles(ctx)
+}
+
+// Test_gitlabTargetManager_refreshTargets_unlocked tests that the targets can be read
+// while the global targets are fetched
+func Test_gitlabTargetManager_refreshTargets_unlocked(t *testing.T) {
+	glmock := remotemock.New([]checks.GlobalTarget{{Url: "https://some.sparrow", LastSeen: time.Now()}})
+	fetch := &blockingFetch{MockClient: glmock, called: make(chan struct{}), release: make(chan struct{})}
+	gtm := mockGitlabTargetManager(glmock, "test")
+	gtm.interactor = fetch
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gtm.refreshTargets(context.Background())
+	}()
+	<-fetch.called
+
+	read := make(chan []checks.GlobalTarget, 1)
+	go func() {
+		read <- gtm.GetTargets()
+	}()
+	select {
+	case got := <-read:
+		if len(got) != 0 {
+			t.Errorf("GetTargets() = %v, want no targets before the fetch completed", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetTargets() blocked while the global targets were fetched")
+	}
+
+	close(fetch.release)
+	if err := <-done; err != nil {
+		t.Fatalf("refreshTargets() error = %v", err)
+	}
+	if got := gtm.GetTargets(); len(got) != 1 {
+		t.Errorf("GetTargets() = %v, want the fetched global target", got)
+	}
+}
+
+// blockingPost is an interactor whose registrations block until they're canceled
+type blockingPost struct {
+	*remotemock.MockClient
+	called chan struct{}
+}
+
+func (b *blockingPost) PostFile(ctx context.Context, _ remote.File) error {
+	close(b.called)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// Test_gitlabTargetManager_Shutdown_duringRegistration tests that the shutdown
+// cancels a registration in flight instead of waiting for its retries
+func Test_gitlabTargetManager_Shutdown_duringRegistration(t *testing.T) {
+	glmock := remotemock.New(nil)
+	post := &blockingPost{MockClient: glmock, called: make(chan struct{})}
+	gtm := mockGitlabTargetManager(glmock, "test")
+	gtm.interactor = post
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gtm.register(context.Background())
+	}()
+	<-post.called
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- gtm.Shutdown(context.Background())
+	}()
+	select {
+	case err := <-shutdown:
+		if err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown() blocked while the instance was registering")
+	}
+
+	if err := <-done; err == nil {
+		t.Error("register() should have failed after the shutdown")
+	}
+	if gtm.registered {
+		t.Error("The canceled registration should not have been committed")
+	}
+	if got := glmock.DeletedFiles(); len(got) != 0 {
+		t.Errorf("Shutdown() deleted %v, want nothing to unregister", got)
+	}
+}
+
+// Test_gitlabTargetManager_Reconcile_DryRun tests that the Reconcile method
+// fetches the global targets but never writes to the remote state backend in dry run mode
+func Test_gitlabTargetManager_Reconcile_DryRun(t *testing.T) {
+	glmock := remotemock.New(
+		[]checks.GlobalTarget{
+			{
+				Url:      "https://some.sparrow",
+				LastSeen: time.Now(),
+			},
+		},
+	)
+	// the shutdown fails if the registration is deleted
+	glmock.SetDeleteFileErr(errors.New("gitlab API error"))
+
+	gtm := mockGitlabTargetManager(glmock, "test")
+	gtm.cfg.DryRun = true
+
+	steps := recordSteps(gtm)
+	ctx := context.Background()
+	go func() {
+		err := gtm.Reconcile(ctx)
+		if err != nil {
+			t.Error("Reconcile() should not have returned an error")
+			return
+		}
+	}()
+
+	waitForSteps(t, steps, stepRefreshed)
+
+	if got := gtm.GetTargets(); len(got) != 1 {
+		t.Errorf("GetTargets() = %v, want the fetched global target", got)
+	}
+
+	if err := gtm.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() should not have deleted the registration: %v", err)
+	}
+
+	if glmock.PostFileCalled() {
+		t.Error("Reconcile() should not have registered the instance")
+	}
+	if glmock.PutFileCalled() {
+		t.Error("Reconcile() should not have updated the registration")
+	}
+
+	var m dto.Metric
+	if err := gtm.metrics.registered.Write(&m); err != nil {
+		t.Fatalf("failed to read registered metric: %v", err)
+	}
+	if got := m.GetGauge().GetValue(); got != 0 {
+		t.Errorf("registered metric = %v, want 0", got)
+	}
+}
+
 // Test_gitlabTargetManager_Reconcile_No_Registration tests that the Reconcile
 // method will not register the instance if the registration interval is 0
 func Test_gitlabTargetManager_Reconcile_No_Registration(t *testing.T) {
@@ -719,6 +1160,7 @@ func Test_gitlabTargetManager_Reconcile_No_Registration(t *testing.T) {
 	gtm := mockGitlabTargetManager(glmock, "test")
 	gtm.cfg.RegistrationInterval = 0
 
+	steps := recordSteps(gtm)
 	ctx := context.Background()
 	go func() {
 		err := gtm.Reconcile(ctx)
@@ -728,7 +1170,7 @@ func Test_gitlabTargetManager_Reconcile_No_Registration(t *testing.T) {
 		}
 	}()
 
-	time.Sleep(time.Millisecond * 250)
+	waitForSteps(t, steps, stepRefreshed, stepUpdated)
 
 	gtm.mu.Lock()
 	if gtm.registered {
@@ -757,6 +1199,7 @@ func Test_gitlabTargetManager_Reconcile_No_Update(t *testing.T) {
 	gtm := mockGitlabTargetManager(glmock, "test")
 	gtm.cfg.UpdateInterval = 0
 
+	steps := recordSteps(gtm)
 	ctx := context.Background()
 	go func() {
 		err := gtm.Reconcile(ctx)
@@ -766,7 +1209,7 @@ func Test_gitlabTargetManager_Reconcile_No_Update(t *testing.T) {
 		}
 	}()
 
-	time.Sleep(time.Millisecond * 250)
+	waitForSteps(t, steps, stepRegistered)
 
 	gtm.mu.Lock()
 	if !gtm.registered {
@@ -800,6 +1243,7 @@ func Test_gitlabTargetManager_Reconcile_No_Registration_No_Update(t *testing.T)
 	gtm.cfg.RegistrationInterval = 0
 	gtm.cfg.UpdateInterval = 0
 
+	steps := recordSteps(gtm)
 	ctx := context.Background()
 	go func() {
 		err := gtm.Reconcile(ctx)
@@ -809,7 +1253,7 @@ func Test_gitlabTargetManager_Reconcile_No_Registration_No_Update(t *testing.T)
 		}
 	}()
 
-	time.Sleep(time.Millisecond * 250)
+	waitForSteps(t, steps, stepRefreshed)
 
 	gtm.mu.Lock()
 	if gtm.registered {
@@ -826,6 +1270,45 @@ func Test_gitlabTargetManager_Reconcile_No_Registration_No_Update(t *testing.T)
 	}
 }
 
+// recordSteps makes the manager record the completed steps of its reconciliation.
+// It must be called before the reconciliation is started.
+func recordSteps(gtm *manager) <-chan reconcileStep {
+	steps := make(chan reconcileStep, 1000)
+	gtm.onReconcile = func(step reconcileStep, _ error) {
+		select {
+		case steps <- step:
+		default:
+		}
+	}
+	return steps
+}
+
+// waitForSteps waits until the reconciliation completed the given steps in order
+func waitForSteps(t *testing.T, steps <-chan reconcileStep, want ...reconcileStep) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for _, w := range want {
+		for done := false; !done; {
+			select {
+			case step := <-steps:
+				done = step == w
+			case <-timeout:
+				t.Fatalf("Reconcile() didn't complete the step %q", w)
+			}
+		}
+	}
+}
+
+// advance moves the fake clock forward by the given number of steps.
+// Before every step, it waits for the reconciliation to wait on all of its timers again.
+func advance(c *clock.Fake, step time.Duration, steps int) {
+	for range steps {
+		c.BlockUntil(3)
+		c.Advance(step)
+	}
+	c.BlockUntil(3)
+}
+
 func mockGitlabTargetManager(g *remotemock.MockClient, name string) *manager { //nolint: unparam // irrelevant
 	return &manager{
 		targets:    nil,
@@ -33,6 +33,7 @@ import (
 	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets/remote"
 
+	"github.com/caas-team/sparrow/internal/helper"
 	"github.com/caas-team/sparrow/internal/logger"
 )
 
@@ -130,7 +131,7 @@ func (c *client) fetchFile(ctx context.Context, f string) (checks.GlobalTarget,
 
 	if resp.StatusCode != http.StatusOK {
 		log.ErrorContext(ctx, "Failed to fetch file", "status", resp.Status)
-		return res, fmt.Errorf("request failed, status is %s", resp.Status)
+		return res, statusError(resp)
 	}
 
 	err = json.NewDecoder(resp.Body).Decode(&res)
@@ -196,7 +197,7 @@ func (c *client) fetchNextFileList(ctx context.Context, reqUrl string) ([]string
 
 	if resp.StatusCode != http.StatusOK {
 		log.ErrorContext(ctx, "Failed to fetch file list", "status", resp.Status)
-		return nil, fmt.Errorf("request failed, status is %s", resp.Status)
+		return nil, statusError(resp)
 	}
 
 	var fl []file
@@ -269,7 +270,7 @@ func (c *client) PutFile(ctx context.Context, file remote.File) error { //nolint
 	// This is not ideal, but the best we can do with the current API without implementing a full blown error handling mechanism.
 	if resp.StatusCode != http.StatusOK {
 		log.ErrorContext(ctx, "Failed to push registration file", "status", resp.Status)
-		return fmt.Errorf("request failed, status is %s", resp.Status)
+		return statusError(resp)
 	}
 
 	return nil
@@ -318,7 +319,7 @@ func (c *client) PostFile(ctx context.Context, file remote.File) error { //nolin
 	// This is not ideal, but the best we can do with the current API without implementing a full blown error handling mechanism.
 	if resp.StatusCode != http.StatusCreated {
 		log.ErrorContext(ctx, "Failed to post file", "status", resp.Status)
-		return fmt.Errorf("request failed, status is %s", resp.Status)
+		return statusError(resp)
 	}
 
 	return nil
@@ -365,7 +366,7 @@ func (c *client) DeleteFile(ctx context.Context, file remote.File) error { //nol
 
 	if resp.StatusCode != http.StatusNoContent {
 		log.ErrorContext(ctx, "Failed to delete file", "status", resp.Status)
-		return fmt.Errorf("request failed, status is %s", resp.Status)
+		return statusError(resp)
 	}
 
 	return nil
@@ -433,3 +434,13 @@ func (c *client) fetchDefaultBranch() string {
 	log.WarnContext(ctx, "No default branch found, using fallback", "fallback", fallbackBranch)
 	return fallbackBranch
 }
+
+// statusError returns the error of a request answered with an unexpected status code.
+// The error is marked as permanent if retrying the request won't help.
+func statusError(resp *http.Response) error {
+	err := fmt.Errorf("request failed, status is %s", resp.Status)
+	if !helper.RetryableStatus(resp.StatusCode) {
+		return helper.Permanent(err)
+	}
+	return err
+}
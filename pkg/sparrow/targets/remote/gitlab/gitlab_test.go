@@ -20,14 +20,21 @@ package gitlab
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets/remote"
+	remotemock "github.com/caas-team/sparrow/pkg/sparrow/targets/remote/test"
 	"github.com/jarcoal/httpmock"
 )
 
@@ -742,3 +749,134 @@ func Test_client_fetchNextFileList(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Conformance(t *testing.T) {
+	remotemock.RunConformance(t, func(t *testing.T) remotemock.Backend {
+		return newFakeGitlab(t)
+	})
+}
+
+// fakeGitlab is an in-memory gitlab serving the repository files API of a single project
+type fakeGitlab struct {
+	server      *httptest.Server
+	mu          sync.Mutex
+	files       map[string]string
+	unavailable bool
+}
+
+func newFakeGitlab(t *testing.T) *fakeGitlab {
+	t.Helper()
+	g := &fakeGitlab{files: map[string]string{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/1/repository/tree", g.handleTree)
+	mux.HandleFunc("GET /api/v4/projects/1/repository/files/{name}/raw", g.handleRaw)
+	mux.HandleFunc("/api/v4/projects/1/repository/files/{name}", g.handleFile)
+	g.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if g.unavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(g.server.Close)
+	return g
+}
+
+func (g *fakeGitlab) Interactor() remote.Interactor {
+	return New(Config{BaseURL: g.server.URL, Token: "token", ProjectID: 1, Branch: "main"})
+}
+
+func (g *fakeGitlab) SetUnavailable(unavailable bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.unavailable = unavailable
+}
+
+// handleTree lists the files with keyset pagination, linking the next page in the Link header
+func (g *fakeGitlab) handleTree(w http.ResponseWriter, r *http.Request) {
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 20
+	}
+	names := make([]string, 0, len(g.files))
+	for name := range g.files {
+		if name > r.URL.Query().Get("page_token") {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	if len(names) > perPage {
+		names = names[:perPage]
+		next := *r.URL
+		next.Scheme, next.Host = "http", r.Host
+		query := next.Query()
+		query.Set("page_token", names[len(names)-1])
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+	}
+
+	type file struct {
+		Name string `json:"name"`
+	}
+	list := make([]file, 0, len(names))
+	for _, name := range names {
+		list = append(list, file{Name: name})
+	}
+	_ = json.NewEncoder(w).Encode(list)
+}
+
+func (g *fakeGitlab) handleRaw(w http.ResponseWriter, r *http.Request) {
+	content, ok := g.files[r.PathValue("name")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(content))
+}
+
+// handleFile creates, updates and deletes files, answering conflicts with 400 Bad Request like gitlab does
+func (g *fakeGitlab) handleFile(w http.ResponseWriter, r *http.Request) {
+	name, err := url.PathUnescape(r.PathValue("name"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		Content string `json:"content"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	_, exists := g.files[name]
+	switch r.Method {
+	case http.MethodPost:
+		if exists {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		g.files[name] = body.Content
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodPut:
+		if !exists {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		g.files[name] = body.Content
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		if !exists {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		delete(g.files, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,210 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remotemock
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/helper"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/sparrow/targets/remote"
+)
+
+// conformanceFiles is the amount of files used to verify the pagination,
+// exceeding a single page of common remote state backends
+const conformanceFiles = 75
+
+// Backend is a remote state backend the conformance suite runs against
+type Backend interface {
+	// Interactor returns the remote.Interactor under test, interacting with the backend
+	Interactor() remote.Interactor
+	// SetUnavailable makes the backend fail all following requests as temporarily unavailable
+	SetUnavailable(unavailable bool)
+}
+
+// RunConformance verifies the semantics every remote.Interactor has to provide:
+//   - FetchFiles returns all files of the backend, regardless of the backend's pagination
+//   - PostFile creates a new file and fails permanently if the file already exists
+//   - PutFile updates an existing file and fails permanently if the file doesn't exist
+//   - DeleteFile deletes an existing file and fails permanently if the file doesn't exist
+//   - All operations fail with a retryable error if the backend is temporarily unavailable
+//
+// Every test gets a new, empty backend created by newBackend.
+func RunConformance(t *testing.T, newBackend func(t *testing.T) Backend) {
+	t.Helper()
+	ctx := context.Background()
+
+	t.Run("fetch empty", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		assertFiles(t, c)
+	})
+
+	t.Run("post creates file", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		f := conformanceFile(0)
+		if err := c.PostFile(ctx, f); err != nil {
+			t.Fatalf("PostFile() error = %v", err)
+		}
+		assertFiles(t, c, f.Content)
+	})
+
+	t.Run("post existing file fails", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		f := conformanceFile(0)
+		if err := c.PostFile(ctx, f); err != nil {
+			t.Fatalf("PostFile() error = %v", err)
+		}
+		assertPermanent(t, "PostFile()", c.PostFile(ctx, f))
+		assertFiles(t, c, f.Content)
+	})
+
+	t.Run("put updates file", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		f := conformanceFile(0)
+		if err := c.PostFile(ctx, f); err != nil {
+			t.Fatalf("PostFile() error = %v", err)
+		}
+		f.Content.LastSeen = f.Content.LastSeen.Add(time.Minute)
+		if err := c.PutFile(ctx, f); err != nil {
+			t.Fatalf("PutFile() error = %v", err)
+		}
+		assertFiles(t, c, f.Content)
+	})
+
+	t.Run("put missing file fails", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		assertPermanent(t, "PutFile()", c.PutFile(ctx, conformanceFile(0)))
+		assertFiles(t, c)
+	})
+
+	t.Run("delete removes file", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		keep, f := conformanceFile(0), conformanceFile(1)
+		for _, file := range []remote.File{keep, f} {
+			if err := c.PostFile(ctx, file); err != nil {
+				t.Fatalf("PostFile() error = %v", err)
+			}
+		}
+		if err := c.DeleteFile(ctx, f); err != nil {
+			t.Fatalf("DeleteFile() error = %v", err)
+		}
+		assertFiles(t, c, keep.Content)
+	})
+
+	t.Run("delete missing file fails", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		assertPermanent(t, "DeleteFile()", c.DeleteFile(ctx, conformanceFile(0)))
+	})
+
+	t.Run("delete without name fails", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		f := conformanceFile(0)
+		f.Name = ""
+		if err := c.DeleteFile(ctx, f); err == nil {
+			t.Error("DeleteFile() error = nil, want error")
+		}
+	})
+
+	t.Run("fetch paginated", func(t *testing.T) {
+		c := newBackend(t).Interactor()
+		want := make([]checks.GlobalTarget, 0, conformanceFiles)
+		for i := range conformanceFiles {
+			f := conformanceFile(i)
+			if err := c.PostFile(ctx, f); err != nil {
+				t.Fatalf("PostFile() error = %v", err)
+			}
+			want = append(want, f.Content)
+		}
+		assertFiles(t, c, want...)
+	})
+
+	t.Run("unavailable backend fails retryable", func(t *testing.T) {
+		b := newBackend(t)
+		c := b.Interactor()
+		f := conformanceFile(0)
+		if err := c.PostFile(ctx, f); err != nil {
+			t.Fatalf("PostFile() error = %v", err)
+		}
+		b.SetUnavailable(true)
+
+		_, err := c.FetchFiles(ctx)
+		assertRetryable(t, "FetchFiles()", err)
+		assertRetryable(t, "PostFile()", c.PostFile(ctx, conformanceFile(1)))
+		assertRetryable(t, "PutFile()", c.PutFile(ctx, f))
+		assertRetryable(t, "DeleteFile()", c.DeleteFile(ctx, f))
+
+		b.SetUnavailable(false)
+		assertFiles(t, c, f.Content)
+	})
+}
+
+// conformanceFile returns the i-th file used by the conformance suite
+func conformanceFile(i int) remote.File {
+	name := fmt.Sprintf("sparrow-%02d.example.com", i)
+	return remote.File{
+		AuthorEmail:   fmt.Sprintf("%s@sparrow", name),
+		AuthorName:    name,
+		CommitMessage: "conformance test",
+		Name:          name + ".json",
+		Content: checks.GlobalTarget{
+			Url:      "https://" + name,
+			LastSeen: time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+		},
+	}
+}
+
+// assertFiles asserts the backend contains exactly the wanted targets
+func assertFiles(t *testing.T, c remote.Interactor, want ...checks.GlobalTarget) {
+	t.Helper()
+	got, err := c.FetchFiles(context.Background())
+	if err != nil {
+		t.Fatalf("FetchFiles() error = %v", err)
+	}
+
+	byUrl := func(a, b checks.GlobalTarget) int { return strings.Compare(a.Url, b.Url) }
+	got, want = slices.Clone(got), slices.Clone(want)
+	slices.SortFunc(got, byUrl)
+	slices.SortFunc(want, byUrl)
+	if !slices.EqualFunc(got, want, func(a, b checks.GlobalTarget) bool {
+		return a.Url == b.Url && a.LastSeen.Equal(b.LastSeen)
+	}) {
+		t.Errorf("FetchFiles() = %v, want %v", got, want)
+	}
+}
+
+// assertPermanent asserts the operation failed with an error that won't be retried
+func assertPermanent(t *testing.T, op string, err error) {
+	t.Helper()
+	if err == nil || !helper.IsPermanent(err) {
+		t.Errorf("%s error = %v, want permanent error", op, err)
+	}
+}
+
+// assertRetryable asserts the operation failed with an error that is worth retrying
+func assertRetryable(t *testing.T, op string, err error) {
+	t.Helper()
+	if err == nil || helper.IsPermanent(err) {
+		t.Errorf("%s error = %v, want retryable error", op, err)
+	}
+}
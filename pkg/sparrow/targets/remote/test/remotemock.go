@@ -37,13 +37,16 @@ type MockClient struct {
 	deleteFileErr  error
 	putFileCalled  int
 	postFileCalled int
+	putFiles       []remote.File
+	deletedFiles   []string
 }
 
-func (m *MockClient) PutFile(ctx context.Context, _ remote.File) error { //nolint: gocritic // irrelevant
+func (m *MockClient) PutFile(ctx context.Context, file remote.File) error { //nolint: gocritic // irrelevant
 	log := logger.FromContext(ctx)
 	log.Info("MockPutFile called", "err", m.putFileErr)
 	m.mu.Lock()
 	m.putFileCalled++
+	m.putFiles = append(m.putFiles, file)
 	m.mu.Unlock()
 	return m.putFileErr
 }
@@ -66,6 +69,9 @@ func (m *MockClient) FetchFiles(ctx context.Context) ([]checks.GlobalTarget, err
 func (m *MockClient) DeleteFile(ctx context.Context, file remote.File) error { //nolint: gocritic // irrelevant
 	log := logger.FromContext(ctx)
 	log.Info("MockDeleteFile called", "filename", file, "err", m.deleteFileErr)
+	m.mu.Lock()
+	m.deletedFiles = append(m.deletedFiles, file.Name)
+	m.mu.Unlock()
 	return m.deleteFileErr
 }
 
@@ -108,6 +114,20 @@ func (m *MockClient) PostFileCount() int {
 	return m.postFileCalled
 }
 
+// PutFiles returns the files passed to PutFile
+func (m *MockClient) PutFiles() []remote.File {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.putFiles
+}
+
+// DeletedFiles returns the names of the files passed to DeleteFile
+func (m *MockClient) DeletedFiles() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.deletedFiles
+}
+
 // New creates a new MockClient to mock the remote.Interactor
 func New(targets []checks.GlobalTarget) *MockClient {
 	return &MockClient{
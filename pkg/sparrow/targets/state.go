@@ -0,0 +1,105 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package targets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// state is the state of the target manager stored in the state file
+type state struct {
+	// Targets are the last fetched healthy global targets
+	Targets []checks.GlobalTarget `json:"targets"`
+	// Registered is true if the instance is registered as a global target
+	Registered bool `json:"registered"`
+	// Written is the registration last written to the remote state backend
+	Written checks.GlobalTarget `json:"written"`
+	// SavedAt is the time the state was stored
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// saveState writes the state of the target manager to the state file if configured.
+// The file is replaced atomically, so a crash doesn't leave a partially written state behind.
+// The caller must hold the lock of the manager.
+func (t *manager) saveState(ctx context.Context) {
+	if t.cfg.StateFile == "" {
+		return
+	}
+	log := logger.FromContext(ctx).With("stateFile", t.cfg.StateFile)
+
+	b, err := json.Marshal(state{
+		Targets:    t.targets,
+		Registered: t.registered,
+		Written:    t.written,
+		SavedAt:    t.Clock().Now().UTC(),
+	})
+	if err != nil {
+		log.Error("Could not marshal target manager state", "error", err)
+		return
+	}
+
+	tmp := t.cfg.StateFile + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		log.Error("Could not write target manager state", "error", err)
+		return
+	}
+	if err := os.Rename(tmp, t.cfg.StateFile); err != nil {
+		log.Error("Could not replace target manager state", "error", err)
+	}
+}
+
+// restoreState restores the state of the target manager from the state file if configured.
+// Targets exceeding the unhealthy threshold in the meantime are dropped.
+// The restored registration is verified with the first successful fetch of the global targets.
+func (t *manager) restoreState(ctx context.Context) error {
+	if t.cfg.StateFile == "" {
+		return nil
+	}
+
+	b, err := os.ReadFile(t.cfg.StateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read state file: %w", err)
+	}
+	var s state
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.targets = t.healthy(s.Targets, t.Clock().Now())
+	t.registered = s.Registered
+	t.restored = s.Registered
+	t.written = s.Written
+	if t.registered && !t.cfg.DryRun {
+		t.metrics.registered.Set(1)
+	}
+	logger.FromContext(ctx).Info("Restored target manager state", "targets", len(t.targets), "registered", t.registered, "savedAt", s.SavedAt)
+	return nil
+}
@@ -0,0 +1,144 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package targets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+
+	remotemock "github.com/caas-team/sparrow/pkg/sparrow/targets/remote/test"
+)
+
+func TestManager_restoreState(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	self := checks.GlobalTarget{Url: "https://test", LastSeen: now}
+	peer := checks.GlobalTarget{Url: "https://peer", LastSeen: now}
+	stale := checks.GlobalTarget{Url: "https://stale", LastSeen: now.Add(-2 * time.Hour)}
+
+	tests := []struct {
+		name           string
+		fetched        []checks.GlobalTarget
+		wantRegistered bool
+	}{
+		{
+			name:           "registration found in the global targets",
+			fetched:        []checks.GlobalTarget{self, peer},
+			wantRegistered: true,
+		},
+		{
+			name:           "registration removed while the instance was down",
+			fetched:        []checks.GlobalTarget{peer},
+			wantRegistered: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := General{UnhealthyThreshold: time.Hour, Scheme: "https", StateFile: filepath.Join(t.TempDir(), "state.json")}
+			saved := &manager{
+				targets:    []checks.GlobalTarget{self, peer, stale},
+				registered: true,
+				written:    self,
+				name:       "test",
+				cfg:        cfg,
+				metrics:    newMetrics(),
+			}
+			saved.saveState(context.Background())
+
+			gtm := &manager{
+				interactor: remotemock.New(tt.fetched),
+				name:       "test",
+				cfg:        cfg,
+				metrics:    newMetrics(),
+			}
+			if err := gtm.restoreState(context.Background()); err != nil {
+				t.Fatalf("restoreState() error = %v", err)
+			}
+
+			if want := []checks.GlobalTarget{self, peer}; !reflect.DeepEqual(gtm.GetTargets(), want) {
+				t.Errorf("GetTargets() after restore = %v, want %v", gtm.GetTargets(), want)
+			}
+			if !gtm.registered || !reflect.DeepEqual(gtm.written, self) {
+				t.Errorf("Restored registration = %v %v, want the saved registration", gtm.registered, gtm.written)
+			}
+
+			if err := gtm.refreshTargets(context.Background()); err != nil {
+				t.Fatalf("refreshTargets() error = %v", err)
+			}
+			if gtm.registered != tt.wantRegistered {
+				t.Errorf("registered after refresh = %v, want %v", gtm.registered, tt.wantRegistered)
+			}
+		})
+	}
+}
+
+func TestManager_restoreState_errors(t *testing.T) {
+	dir := t.TempDir()
+	malformed := filepath.Join(dir, "malformed.json")
+	if err := os.WriteFile(malformed, []byte("{"), 0o600); err != nil {
+		t.Fatalf("Failed to write state file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{name: "disabled", file: ""},
+		{name: "missing state file", file: filepath.Join(dir, "missing.json")},
+		{name: "malformed state file", file: malformed, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gtm := &manager{cfg: General{StateFile: tt.file}, metrics: newMetrics()}
+			if err := gtm.restoreState(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("restoreState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(gtm.GetTargets()) != 0 || gtm.registered {
+				t.Errorf("restoreState() restored %v, want nothing", gtm.GetTargets())
+			}
+		})
+	}
+}
+
+func TestManager_register_dryRun(t *testing.T) {
+	glmock := remotemock.New(nil)
+	gtm := mockGitlabTargetManager(glmock, "test")
+	gtm.cfg.DryRun = true
+	gtm.cfg.StateFile = filepath.Join(t.TempDir(), "state.json")
+
+	if err := gtm.register(context.Background()); err != nil {
+		t.Fatalf("register() error = %v", err)
+	}
+
+	if gtm.registered || !reflect.DeepEqual(gtm.written, checks.GlobalTarget{}) {
+		t.Errorf("register() changed the registration to %v %v in dry run mode", gtm.registered, gtm.written)
+	}
+	if glmock.PostFileCalled() {
+		t.Error("register() posted the registration in dry run mode")
+	}
+	if _, err := os.Stat(gtm.cfg.StateFile); !os.IsNotExist(err) {
+		t.Errorf("register() wrote the state file in dry run mode: %v", err)
+	}
+}
@@ -20,13 +20,21 @@ package targets
 
 import (
 	"context"
+	"regexp"
 	"time"
 
+	"github.com/caas-team/sparrow/internal/helper"
 	"github.com/caas-team/sparrow/internal/logger"
 	"github.com/caas-team/sparrow/pkg/checks"
 	"github.com/caas-team/sparrow/pkg/sparrow/targets/interactor"
 )
 
+// maxPort is the highest valid port of the advertised api
+const maxPort = 65535
+
+// labelName matches the valid names of the advertised labels
+var labelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 // TargetManager handles the management of globalTargets for
 // a Sparrow instance
 type TargetManager interface {
@@ -38,6 +46,9 @@ type TargetManager interface {
 	// Shutdown shuts down the target manager
 	// and unregisters the instance as a global target
 	Shutdown(ctx context.Context) error
+	// SetChecks sets the names of the checks the instance runs,
+	// which are advertised with the next registration update
+	SetChecks(names []string)
 }
 
 // General is the general configuration of the target manager
@@ -50,6 +61,11 @@ type General struct {
 	// How often the instance should update its registration as a global target.
 	// A duration of 0 means no update.
 	UpdateInterval time.Duration `yaml:"updateInterval" mapstructure:"updateInterval"`
+	// The minimum time between two updates of a registration whose content hasn't changed besides the lastSeen time.
+	// Updates within this time are skipped to keep the history of the remote state backend small.
+	// A registration is written at the latest after the debounce and the update interval, so their sum
+	// must be below the unhealthy threshold. A duration of 0 means every update is written.
+	UpdateDebounce time.Duration `yaml:"updateDebounce" mapstructure:"updateDebounce"`
 	// The amount of time a target can be unhealthy
 	// before it is removed from the global target list.
 	// A duration of 0 means no removal.
@@ -57,6 +73,40 @@ type General struct {
 	// Scheme is the scheme used for the remote target manager
 	// Can either be http or https
 	Scheme string `yaml:"scheme" mapstructure:"scheme"`
+	// APIPort is the port of the instance's API advertised in the registration.
+	// A port of 0 means the peers use the default port of the scheme.
+	APIPort int `yaml:"apiPort" mapstructure:"apiPort"`
+	// Version is the sparrow version advertised in the registration. It's set on startup.
+	Version string `yaml:"-" mapstructure:"-"`
+	// Region is the region of the instance advertised in the registration, e.g. eu-de-1
+	Region string `yaml:"region" mapstructure:"region"`
+	// Labels are additional labels of the instance advertised in the registration.
+	// The keys must be valid Prometheus label names.
+	Labels map[string]string `yaml:"labels" mapstructure:"labels"`
+	// Retry defines if and how to retry failed requests to the remote state backend
+	Retry helper.RetryConfig `yaml:"retry" mapstructure:"retry"`
+	// ShutdownTimeout is the time budget for unregistering the instance on shutdown.
+	// It should fit into the termination grace period of the instance. Defaults to 30s.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" mapstructure:"shutdownTimeout"`
+	// DryRun fetches and evaluates the global targets but never writes to the remote state backend.
+	// The registration, updates and unregistration are only logged.
+	DryRun bool `yaml:"dryRun" mapstructure:"dryRun"`
+	// StateFile is the path of the file the last fetched global targets and the registration state are stored in.
+	// The state is restored on startup, so the checks receive the global targets before the first fetch. Disabled if empty.
+	StateFile string `yaml:"stateFile" mapstructure:"stateFile"`
+	// Tombstone configures the tombstone left behind when unregistering on shutdown
+	Tombstone Tombstone `yaml:"tombstone" mapstructure:"tombstone"`
+}
+
+// Tombstone configures the tombstones written instead of deleting the registration on shutdown.
+// A tombstone tells the peers that the instance went away gracefully rather than crashed.
+type Tombstone struct {
+	// TTL is how long a tombstone is kept before it expires.
+	// A duration of 0 deletes the registration on shutdown instead of writing a tombstone.
+	TTL time.Duration `yaml:"ttl" mapstructure:"ttl"`
+	// Collect deletes the expired tombstones of all instances from the remote state backend
+	// when the global targets are refreshed. Expired tombstones are ignored otherwise.
+	Collect bool `yaml:"collect" mapstructure:"collect"`
 }
 
 // TargetManagerConfig is the configuration for the target manager
@@ -68,6 +118,8 @@ type TargetManagerConfig struct {
 	General `yaml:",inline" mapstructure:",squash"`
 	// Config is the configuration for the Config target manager
 	interactor.Config `yaml:",inline" mapstructure:",squash"`
+	// Injection configures per check how the global targets are injected into its targets
+	Injection Injection `yaml:"injection" mapstructure:"injection"`
 }
 
 func (c *TargetManagerConfig) Validate(ctx context.Context) error {
@@ -89,6 +141,44 @@ func (c *TargetManagerConfig) Validate(ctx context.Context) error {
 		return ErrInvalidUpdateInterval
 	}
 
+	if c.UpdateDebounce < 0 || (c.UpdateDebounce > 0 && c.UnhealthyThreshold > 0 && c.UpdateDebounce+c.UpdateInterval >= c.UnhealthyThreshold) {
+		log.Error("The update debounce should be equal or above 0 and, added to the update interval, below the unhealthy threshold",
+			"debounce", c.UpdateDebounce, "interval", c.UpdateInterval, "threshold", c.UnhealthyThreshold)
+		return ErrInvalidUpdateDebounce
+	}
+
+	if c.ShutdownTimeout < 0 {
+		log.Error("The shutdown timeout should be equal or above 0", "timeout", c.ShutdownTimeout)
+		return ErrInvalidShutdownTimeout
+	}
+
+	if c.Tombstone.TTL < 0 || (c.Tombstone.Collect && c.Tombstone.TTL == 0) {
+		log.Error("The tombstone ttl should be equal or above 0 and set to collect the tombstones", "ttl", c.Tombstone.TTL, "collect", c.Tombstone.Collect)
+		return ErrInvalidTombstone
+	}
+
+	if c.Retry.Count < 0 || c.Retry.Delay < 0 {
+		log.Error("The retry count and delay should be equal or above 0", "count", c.Retry.Count, "delay", c.Retry.Delay)
+		return ErrInvalidRetry
+	}
+
+	if c.APIPort < 0 || c.APIPort > maxPort {
+		log.Error("The api port should be between 0 and 65535", "port", c.APIPort)
+		return ErrInvalidAPIPort
+	}
+
+	for k := range c.Labels {
+		if !labelName.MatchString(k) {
+			log.Error("The label names should be valid prometheus label names", "label", k)
+			return ErrInvalidLabels
+		}
+	}
+
+	if err := c.Injection.Validate(); err != nil {
+		log.Error("The injection of the global targets is invalid", "error", err)
+		return err
+	}
+
 	if c.Scheme != "http" && c.Scheme != "https" {
 		log.Error("The scheme should be either of: 'http', 'https'", "scheme", c.Scheme)
 		return ErrInvalidScheme
@@ -60,6 +60,129 @@ func TestTargetManagerConfig_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invalid config - update debounce above unhealthy threshold",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:             "http",
+					CheckInterval:      1 * time.Second,
+					UnhealthyThreshold: 1 * time.Minute,
+					UpdateDebounce:     2 * time.Minute,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config - update debounce and interval below unhealthy threshold",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:             "http",
+					CheckInterval:      1 * time.Second,
+					UpdateInterval:     120 * time.Minute,
+					UnhealthyThreshold: 360 * time.Minute,
+					UpdateDebounce:     180 * time.Minute,
+				},
+			},
+		},
+		{
+			name: "invalid config - update debounce and interval reach unhealthy threshold",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:             "http",
+					CheckInterval:      1 * time.Second,
+					UpdateInterval:     120 * time.Minute,
+					UnhealthyThreshold: 360 * time.Minute,
+					UpdateDebounce:     240 * time.Minute,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config - collected tombstones",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:        "http",
+					CheckInterval: 1 * time.Second,
+					Tombstone:     Tombstone{TTL: 1 * time.Hour, Collect: true},
+				},
+			},
+		},
+		{
+			name: "invalid config - negative tombstone ttl",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:        "http",
+					CheckInterval: 1 * time.Second,
+					Tombstone:     Tombstone{TTL: -1 * time.Hour},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - collected tombstones without ttl",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:        "http",
+					CheckInterval: 1 * time.Second,
+					Tombstone:     Tombstone{Collect: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid config - region and labels",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:        "http",
+					CheckInterval: 1 * time.Second,
+					Region:        "eu-de-1",
+					Labels:        map[string]string{"provider": "aws", "availability_zone": "eu-de-1a"},
+				},
+			},
+		},
+		{
+			name: "invalid config - invalid label name",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:        "http",
+					CheckInterval: 1 * time.Second,
+					Labels:        map[string]string{"availability-zone": "eu-de-1a"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - api port out of range",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:        "http",
+					CheckInterval: 1 * time.Second,
+					APIPort:       70000,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid config - negative shutdown timeout",
+			cfg: TargetManagerConfig{
+				Type: "gitlab",
+				General: General{
+					Scheme:          "http",
+					CheckInterval:   1 * time.Second,
+					ShutdownTimeout: -1 * time.Second,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid config - zero check interval",
 			cfg: TargetManagerConfig{
@@ -29,6 +29,7 @@ import (
 // MockTargetManager is a mock implementation of the TargetManager interface
 type MockTargetManager struct {
 	Targets []checks.GlobalTarget
+	Checks  []string
 }
 
 func (m *MockTargetManager) Reconcile(ctx context.Context) error {
@@ -48,3 +49,9 @@ func (m *MockTargetManager) GetTargets() []checks.GlobalTarget {
 	log.Info("MockGetTargets called, returning", "targets", len(m.Targets))
 	return m.Targets
 }
+
+func (m *MockTargetManager) SetChecks(names []string) {
+	log := logger.FromContext(context.Background())
+	log.Info("MockSetChecks called", "checks", len(names))
+	m.Checks = names
+}
@@ -0,0 +1,121 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package targets
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+// Transform is a rule transforming the URL of a global target before it's injected into the targets of a check.
+// Exactly one of its fields must be set.
+type Transform struct {
+	// Scheme rewrites the scheme of the URL, either http or https
+	Scheme string `yaml:"scheme" mapstructure:"scheme"`
+	// Port maps the URL to the given port
+	Port int `yaml:"port" mapstructure:"port"`
+	// PathPrefix is prepended to the path of the URL
+	PathPrefix string `yaml:"pathPrefix" mapstructure:"pathPrefix"`
+	// PathSuffix is appended to the path of the URL
+	PathSuffix string `yaml:"pathSuffix" mapstructure:"pathSuffix"`
+	// Template is the Go template rendering the new URL with the injection data of the current URL,
+	// e.g. `{{ .Scheme }}://health.{{ .Host }}`
+	Template string `yaml:"template" mapstructure:"template"`
+}
+
+// Transforms is a pipeline of transformation rules applied in order
+type Transforms []Transform
+
+// Validate validates the transformation rules
+func (t Transforms) Validate() error {
+	for i, tr := range t {
+		if err := tr.validate(); err != nil {
+			return fmt.Errorf("transform %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Apply returns the URL transformed by all rules of the pipeline
+func (t Transforms) Apply(u *url.URL, gt checks.GlobalTarget) (*url.URL, error) { //nolint:gocritic // no performance concerns yet
+	for i, tr := range t {
+		var err error
+		if u, err = tr.apply(u, gt); err != nil {
+			return nil, fmt.Errorf("transform %d: %w", i, err)
+		}
+	}
+	return u, nil
+}
+
+// validate checks that exactly one rule is set and that it's valid
+func (tr *Transform) validate() error {
+	rules := 0
+	for _, set := range []bool{tr.Scheme != "", tr.Port != 0, tr.PathPrefix != "", tr.PathSuffix != "", tr.Template != ""} {
+		if set {
+			rules++
+		}
+	}
+	if rules != 1 {
+		return fmt.Errorf("exactly one rule must be set, got %d", rules)
+	}
+
+	switch {
+	case tr.Scheme != "" && tr.Scheme != "http" && tr.Scheme != "https":
+		return ErrInvalidScheme
+	case tr.Port < 0 || tr.Port > maxPort:
+		return fmt.Errorf("port must be between 1 and %d", maxPort)
+	case tr.Template != "":
+		_, err := tr.apply(&url.URL{Scheme: "https", Host: "sparrow.example.com"}, checks.GlobalTarget{})
+		return err
+	}
+	return nil
+}
+
+// apply returns a copy of the URL transformed by the rule
+func (tr *Transform) apply(u *url.URL, gt checks.GlobalTarget) (*url.URL, error) { //nolint:gocritic // no performance concerns yet
+	res := *u
+	switch {
+	case tr.Scheme != "":
+		res.Scheme = tr.Scheme
+	case tr.Port != 0:
+		res.Host = net.JoinHostPort(res.Hostname(), strconv.Itoa(tr.Port))
+	case tr.PathPrefix != "":
+		res.Path, res.RawPath = path.Join("/", tr.PathPrefix, res.Path), ""
+	case tr.PathSuffix != "":
+		res.Path, res.RawPath = path.Join("/", res.Path, tr.PathSuffix), ""
+	case tr.Template != "":
+		rendered, err := InjectionPolicy{Template: tr.Template}.render(InjectionData{Url: u.String(), Scheme: u.Scheme, Host: u.Hostname(), Port: u.Port(), Target: gt})
+		if err != nil {
+			return nil, err
+		}
+		r, err := url.Parse(rendered)
+		if err != nil {
+			return nil, err
+		}
+		if r.Host == "" {
+			return nil, fmt.Errorf("rendered url %q has no host", rendered)
+		}
+		return r, nil
+	}
+	return &res, nil
+}
@@ -0,0 +1,97 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package targets
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestTransforms_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		transforms Transforms
+		wantErr    bool
+	}{
+		{name: "no transforms", transforms: nil},
+		{
+			name: "valid transforms",
+			transforms: Transforms{
+				{Scheme: "https"},
+				{Port: 8443},
+				{PathPrefix: "/api"},
+				{PathSuffix: "/health"},
+				{Template: "{{ .Scheme }}://health.{{ .Host }}"},
+			},
+		},
+		{name: "no rule", transforms: Transforms{{}}, wantErr: true},
+		{name: "multiple rules", transforms: Transforms{{Scheme: "https", Port: 8443}}, wantErr: true},
+		{name: "invalid scheme", transforms: Transforms{{Scheme: "ftp"}}, wantErr: true},
+		{name: "port out of range", transforms: Transforms{{Port: 70000}}, wantErr: true},
+		{name: "unparsable template", transforms: Transforms{{Template: "{{ .Url "}}, wantErr: true},
+		{name: "template without host", transforms: Transforms{{Template: "/health"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.transforms.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Transforms.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransforms_Apply(t *testing.T) {
+	gt := checks.GlobalTarget{Url: "http://sparrow.com/api", Version: "v1.0.0"}
+	tests := []struct {
+		name       string
+		transforms Transforms
+		want       string
+	}{
+		{name: "no transforms", transforms: nil, want: "http://sparrow.com/api"},
+		{
+			name:       "scheme, port and path",
+			transforms: Transforms{{Scheme: "https"}, {Port: 8443}, {PathSuffix: "/health"}},
+			want:       "https://sparrow.com:8443/api/health",
+		},
+		{name: "path prefix", transforms: Transforms{{PathPrefix: "/v1/"}}, want: "http://sparrow.com/v1/api"},
+		{
+			name:       "template",
+			transforms: Transforms{{Template: "https://{{ .Host }}/{{ .Target.Version }}"}, {Port: 8443}},
+			want:       "https://sparrow.com:8443/v1.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := url.Parse(gt.Url)
+			got, err := tt.transforms.Apply(u, gt)
+			if err != nil {
+				t.Fatalf("Transforms.Apply() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Transforms.Apply() = %s, want %s", got, tt.want)
+			}
+			if u.String() != gt.Url {
+				t.Errorf("Transforms.Apply() modified the given url to %s", u)
+			}
+		})
+	}
+}
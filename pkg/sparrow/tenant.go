@@ -0,0 +1,97 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/config"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/sparrow/metrics"
+	"golang.org/x/sync/errgroup"
+)
+
+// tenant is a logical group of checks with its own runtime configuration,
+// isolated results and a dedicated value of the tenant metrics label
+type tenant struct {
+	// name is the name of the tenant
+	name string
+	// db is the database used to store the check results of the tenant
+	db db.DB
+	// loader is used to load the runtime configuration of the tenant
+	loader config.Loader
+	// controller is used to manage the checks of the tenant
+	controller *ChecksController
+	// cRuntime is used to signal that the runtime configuration of the tenant has changed
+	cRuntime chan runtime.Config
+}
+
+// newTenant creates a new tenant from the given tenant configuration.
+// It returns an error if the database of the tenant can't be created.
+func newTenant(cfg *config.Config, tc config.TenantConfig, m metrics.Provider) (*tenant, error) { //nolint:gocritic // no performance concerns yet
+	dbase, err := newDB(cfg.DB)
+	if err != nil {
+		return nil, err
+	}
+	t := &tenant{
+		name:       tc.Name,
+		db:         dbase,
+		controller: NewTenantChecksController(dbase, m, cfg.SparrowName, tc.Name),
+		cRuntime:   make(chan runtime.Config, 1),
+	}
+
+	t.controller.registerer.MustRegister(dbase)
+
+	tcfg := *cfg
+	tcfg.Loader = tc.Loader
+	t.loader = config.NewLoader(&tcfg, t.cRuntime)
+	registerLoader(t.controller, t.loader)
+	return t, nil
+}
+
+// runTenant starts the supervised loader and checks controller of the tenant in the errgroup
+// and reconciles the tenant's checks whenever its runtime configuration changes
+func (s *Sparrow) runTenant(ctx context.Context, g *errgroup.Group, t *tenant) {
+	ctx = logger.IntoContext(ctx, logger.FromContext(ctx).With("tenant", t.name))
+
+	g.Go(func() error {
+		return s.supervise(ctx, component{name: t.name + "/loader", run: t.loader.Run, policy: restartOnFailure})
+	})
+	g.Go(func() error {
+		return s.supervise(ctx, component{name: t.name + "/controller", run: t.controller.Run, policy: failFast})
+	})
+
+	for {
+		select {
+		case cfg := <-t.cRuntime:
+			cfg = s.enrichTargets(ctx, cfg)
+			t.controller.Reconcile(ctx, cfg)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// shutdown shuts down the loader and the checks controller of the tenant
+func (t *tenant) shutdown(ctx context.Context) {
+	t.loader.Shutdown(ctx)
+	t.controller.Shutdown(ctx)
+}
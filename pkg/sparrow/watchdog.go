@@ -0,0 +1,146 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/factory"
+)
+
+// watchdogInterval is the interval in which the watchdog looks for hanging check iterations
+const watchdogInterval = 10 * time.Second
+
+// errCheckUnregistered is the cause of the cancellation of the context of an unregistered check
+var errCheckUnregistered = errors.New("check unregistered")
+
+// checkRun is a running check
+type checkRun struct {
+	// cancel cancels the context of the check
+	cancel context.CancelCauseFunc
+	// started is the time the check was registered
+	started time.Time
+	// conf is the configuration the check scheduled its next iteration with after its latest result.
+	// The current configuration applies if the check hasn't reported a result yet.
+	conf checks.Runtime
+}
+
+// newHungIterations creates the counter reporting the number of
+// iterations of every check canceled by the watchdog
+func newHungIterations() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sparrow_check_watchdog_cancellations_total",
+			Help: "Number of iterations of a check canceled by the watchdog, because they exceeded the configured multiple of the check's timeout.",
+		},
+		[]string{"check"},
+	)
+}
+
+// watch cancels the checks whose iteration didn't finish by its deadline, records the event
+// and restarts or stops the checks according to the watchdog policy. An iteration's deadline is
+// its scheduled start after the latest result or the registration of the check plus the
+// configured multiple of the check's timeout.
+func (cc *ChecksController) watch(ctx context.Context, now time.Time) {
+	log := logger.FromContext(ctx)
+
+	cc.mu.Lock()
+	cfg := cc.gated
+	cc.mu.Unlock()
+	if !cfg.Watchdog.Enabled() {
+		return
+	}
+
+	for _, c := range cc.checks.Iter() {
+		name := c.Name()
+		conf := cfg.For(name)
+		run, ok := cc.run(name)
+		if conf == nil || !ok {
+			continue
+		}
+
+		last := run.started
+		if res, ok := cc.db.Get(name); ok && res.Timestamp.After(last) {
+			last = res.Timestamp
+		}
+		scheduled := run.conf
+		if scheduled == nil {
+			scheduled = conf
+		}
+		deadline, ok := cfg.Watchdog.Deadline(scheduled, last)
+		if !ok || now.Before(deadline) {
+			continue
+		}
+
+		reason := fmt.Sprintf("iteration didn't finish by %s", deadline.Format(time.RFC3339))
+		log.WarnContext(ctx, "Canceling hanging check iteration", "check", name, "deadline", deadline, "policy", cfg.Watchdog.Policy)
+		cc.hung.WithLabelValues(name).Inc()
+		cc.UnregisterCheck(ctx, c)
+
+		if cfg.Watchdog.Stops() {
+			cc.events.add(Event{Timestamp: now, Type: eventCheckStopped, Check: name, Reason: reason})
+			cc.mu.Lock()
+			if cc.stopped == nil {
+				cc.stopped = map[string]checks.Runtime{}
+			}
+			cc.stopped[name] = conf
+			cc.mu.Unlock()
+			continue
+		}
+
+		cc.events.add(Event{Timestamp: now, Type: eventCheckRestarted, Check: name, Reason: reason})
+		fresh, err := factory.NewCheck(conf)
+		if err != nil {
+			log.ErrorContext(ctx, "Failed to restart check", "check", name, "error", err)
+			continue
+		}
+		cc.limitSeries(fresh, cfg.MaxSeries)
+		cc.RegisterCheck(ctx, fresh)
+	}
+}
+
+// scheduled records the current configuration of the check with the given name after it reported a result,
+// so the deadline of its next iteration is derived from the configuration it was scheduled with
+func (cc *ChecksController) scheduled(name string) {
+	cc.mu.Lock()
+	conf := cc.gated.For(name)
+	cc.mu.Unlock()
+
+	cc.runsMu.Lock()
+	defer cc.runsMu.Unlock()
+	if run, ok := cc.runs[name]; ok {
+		run.conf = conf
+		cc.runs[name] = run
+	}
+}
+
+// run returns the running check with the given name
+func (cc *ChecksController) run(name string) (checkRun, bool) {
+	cc.runsMu.Lock()
+	defer cc.runsMu.Unlock()
+	run, ok := cc.runs[name]
+	return run, ok
+}
@@ -0,0 +1,142 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sparrow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/checks/health"
+	"github.com/caas-team/sparrow/pkg/checks/runtime"
+	"github.com/caas-team/sparrow/pkg/db"
+	"github.com/caas-team/sparrow/pkg/sparrow/metrics"
+)
+
+// newHangingCheck returns a health check mock whose iteration hangs until its context is canceled
+func newHangingCheck(canceled chan<- error) *checks.CheckMock {
+	return &checks.CheckMock{
+		NameFunc: func() string { return health.CheckName },
+		RunFunc: func(ctx context.Context, _ chan checks.ResultDTO) error {
+			<-ctx.Done()
+			canceled <- context.Cause(ctx)
+			return ctx.Err()
+		},
+		GetMetricCollectorsFunc: func() []prometheus.Collector { return nil },
+		OnRegisterFunc:          func(context.Context) error { return nil },
+		OnShutdownFunc:          func(context.Context) {},
+		ShutdownFunc:            func() {},
+	}
+}
+
+func TestChecksController_watch(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     runtime.WatchdogPolicy
+		wantEvent  string
+		wantActive bool
+	}{
+		{name: "restarts the check", policy: runtime.WatchdogRestart, wantEvent: eventCheckRestarted, wantActive: true},
+		{name: "stops the check", policy: runtime.WatchdogStop, wantEvent: eventCheckStopped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := logger.NewContextWithLogger(context.Background())
+			defer cancel()
+			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
+			defer cc.Shutdown(ctx)
+
+			cfg := runtime.Config{
+				Health: &health.Config{
+					Targets:  []string{"https://example.com"},
+					Interval: time.Hour,
+					Timeout:  time.Second,
+				},
+				Watchdog: runtime.Watchdog{Factor: 3, Policy: tt.policy},
+			}
+			cc.cfg, cc.gated = cfg, cfg
+			canceled := make(chan error, 1)
+			hanging := newHangingCheck(canceled)
+			cc.RegisterCheck(ctx, hanging)
+
+			// The iteration is still within its deadline
+			cc.watch(ctx, time.Now().Add(time.Hour))
+			assert.Same(t, hanging, cc.checks.Iter()[0])
+			assert.Empty(t, cc.Events())
+
+			cc.watch(ctx, time.Now().Add(time.Hour+5*time.Second))
+			select {
+			case cause := <-canceled:
+				assert.ErrorIs(t, cause, errCheckUnregistered)
+			case <-time.After(time.Second):
+				t.Fatal("The hanging iteration wasn't canceled")
+			}
+
+			events := cc.Events()
+			if assert.Len(t, events, 1) {
+				assert.Equal(t, tt.wantEvent, events[0].Type)
+				assert.Equal(t, health.CheckName, events[0].Check)
+			}
+			assert.Equal(t, 1.0, testutil.ToFloat64(cc.hung.WithLabelValues(health.CheckName)))
+
+			active := cc.checks.Iter()
+			if !tt.wantActive {
+				assert.Empty(t, active)
+				return
+			}
+			if assert.Len(t, active, 1) {
+				assert.IsType(t, &health.Health{}, active[0])
+			}
+		})
+	}
+}
+
+func TestChecksController_Reconcile_stopped(t *testing.T) {
+	ctx, cancel := logger.NewContextWithLogger(context.Background())
+	defer cancel()
+	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}, "sparrow.com"), "sparrow.com")
+	defer cc.Shutdown(ctx)
+
+	cfg := runtime.Config{Health: &health.Config{
+		Targets:  []string{"https://example.com"},
+		Interval: time.Hour,
+		Timeout:  time.Second,
+	}}
+	cc.stopped = map[string]checks.Runtime{health.CheckName: cfg.Health}
+
+	// The stopped check stays stopped while its configuration is unchanged
+	cc.Reconcile(ctx, cfg)
+	assert.Empty(t, cc.checks.Iter())
+
+	changed := runtime.Config{Health: &health.Config{
+		Targets:  []string{"https://example.com"},
+		Interval: time.Hour,
+		Timeout:  2 * time.Second,
+	}}
+	cc.Reconcile(ctx, changed)
+	assert.Len(t, cc.checks.Iter(), 1)
+	assert.Empty(t, cc.stopped)
+}
@@ -0,0 +1,138 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+var (
+	// ErrInvalidUrl is returned when the url of a receiver is invalid
+	ErrInvalidUrl = errors.New("invalid webhook receiver url")
+	// ErrInvalidTemplate is returned when the payload template of a receiver is invalid
+	ErrInvalidTemplate = errors.New("invalid webhook receiver template")
+	// ErrInvalidTimeout is returned when the timeout of a receiver is invalid
+	ErrInvalidTimeout = errors.New("invalid webhook receiver timeout")
+)
+
+// defaultTemplate renders the result as plain JSON
+const defaultTemplate = `{{ json . }}`
+
+// Config is the configuration of the webhooks the results are sent to
+type Config struct {
+	// Receivers are the webhook receivers every result is sent to
+	Receivers []Receiver `yaml:"receivers" mapstructure:"receivers"`
+}
+
+// Receiver is a webhook the results of the checks are sent to
+type Receiver struct {
+	// Name is the unique name of the receiver
+	Name string `yaml:"name" mapstructure:"name"`
+	// Url is the URL the payload is posted to
+	Url string `yaml:"url" mapstructure:"url"`
+	// Checks limits the results sent to the receiver to the given checks.
+	// The results of all checks are sent if empty.
+	Checks []string `yaml:"checks" mapstructure:"checks"`
+	// Headers are additional headers sent with every request
+	Headers map[string]string `yaml:"headers" mapstructure:"headers"`
+	// Template is the Go template rendering the payload of a result.
+	// Defaults to the result as JSON.
+	Template string `yaml:"template" mapstructure:"template"`
+	// Timeout is the timeout of a single request
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// Enabled returns true if any receiver is configured
+func (c *Config) Enabled() bool {
+	return len(c.Receivers) > 0
+}
+
+// Validate validates the webhook configuration
+func (c *Config) Validate(ctx context.Context) error {
+	names := make([]string, 0, len(c.Receivers))
+	for _, r := range c.Receivers {
+		names = append(names, r.Name)
+	}
+	if err := notifier.ValidateNames(ctx, "webhook", names); err != nil {
+		return err
+	}
+
+	for i := range c.Receivers {
+		if err := c.Receivers[i].Validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the receiver configuration
+func (r *Receiver) Validate(ctx context.Context) error {
+	log := logger.FromContext(ctx).With("name", r.Name)
+
+	u, err := url.ParseRequestURI(r.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		log.Error("The webhook receiver url is not a valid url", "url", r.Url)
+		return ErrInvalidUrl
+	}
+	if r.Timeout < 0 {
+		log.Error("The webhook receiver timeout should be equal or above 0", "timeout", r.Timeout)
+		return ErrInvalidTimeout
+	}
+
+	tmpl, err := r.template()
+	if err != nil {
+		log.Error("The webhook receiver template cannot be parsed", "error", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	// The template is rendered once with an empty payload to detect
+	// references to fields the payload doesn't have
+	if err = tmpl.Execute(io.Discard, Payload{Data: map[string]any{}}); err != nil {
+		log.Error("The webhook receiver template cannot be rendered", "error", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return nil
+}
+
+// template parses the payload template of the receiver
+func (r *Receiver) template() (*template.Template, error) {
+	text := r.Template
+	if text == "" {
+		text = defaultTemplate
+	}
+	return template.New(r.Name).Funcs(Funcs).Parse(text)
+}
+
+// Funcs are the functions available in the payload templates
+var Funcs = template.FuncMap{
+	// json renders the value as JSON, e.g. to embed strings into a JSON payload
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
@@ -0,0 +1,102 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name:    "no receivers",
+			config:  Config{},
+			wantErr: nil,
+		},
+		{
+			name: "valid receivers",
+			config: Config{Receivers: []Receiver{
+				{Name: "default", Url: "https://hooks.example.com/default"},
+				{
+					Name:     "teams",
+					Url:      "https://hooks.example.com/teams",
+					Template: `{"text": {{ printf "%s on %s failed" .Check .Instance | json }}}`,
+					Timeout:  time.Second,
+				},
+			}},
+			wantErr: nil,
+		},
+		{
+			name:    "missing name",
+			config:  Config{Receivers: []Receiver{{Url: "https://hooks.example.com"}}},
+			wantErr: notifier.ErrMissingName,
+		},
+		{
+			name: "duplicate name",
+			config: Config{Receivers: []Receiver{
+				{Name: "slack", Url: "https://hooks.example.com/a"},
+				{Name: "slack", Url: "https://hooks.example.com/b"},
+			}},
+			wantErr: notifier.ErrDuplicateName,
+		},
+		{
+			name:    "invalid url",
+			config:  Config{Receivers: []Receiver{{Name: "slack", Url: "hooks.example.com"}}},
+			wantErr: ErrInvalidUrl,
+		},
+		{
+			name:    "invalid timeout",
+			config:  Config{Receivers: []Receiver{{Name: "slack", Url: "https://hooks.example.com", Timeout: -time.Second}}},
+			wantErr: ErrInvalidTimeout,
+		},
+		{
+			name:    "template syntax error",
+			config:  Config{Receivers: []Receiver{{Name: "slack", Url: "https://hooks.example.com", Template: `{{ .Check `}}},
+			wantErr: ErrInvalidTemplate,
+		},
+		{
+			name:    "template references unknown field",
+			config:  Config{Receivers: []Receiver{{Name: "slack", Url: "https://hooks.example.com", Template: `{{ .Target }}`}}},
+			wantErr: ErrInvalidTemplate,
+		},
+		{
+			name:    "template uses unknown function",
+			config:  Config{Receivers: []Receiver{{Name: "slack", Url: "https://hooks.example.com", Template: `{{ toYaml . }}`}}},
+			wantErr: ErrInvalidTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/caas-team/sparrow/internal/logger"
+	"github.com/caas-team/sparrow/pkg/checks"
+	"github.com/caas-team/sparrow/pkg/notifier"
+)
+
+// Payload is the data the payload templates are rendered with
+type Payload struct {
+	// Check is the name of the check
+	Check string `json:"check"`
+	// Instance is the identity of the sparrow that ran the check
+	Instance string `json:"instance,omitempty"`
+	// Timestamp is the time the check was run
+	Timestamp time.Time `json:"timestamp"`
+	// Data is the result of the check
+	Data any `json:"data"`
+}
+
+// NewPayload returns the template data of the given result
+func NewPayload(result checks.ResultDTO) Payload {
+	return Payload{
+		Check:     result.Name,
+		Instance:  result.Result.Instance,
+		Timestamp: result.Result.Timestamp,
+		Data:      result.Result.Data,
+	}
+}
+
+// TargetPayload is the template data of the result of a single target
+type TargetPayload struct {
+	Payload
+	// Target is the target the data belongs to
+	Target string `json:"target,omitempty"`
+}
+
+// Targets splits the payload into the payloads of its targets, sorted by target.
+// It returns false if the data of the result isn't mapped by target.
+func (p Payload) Targets() ([]TargetPayload, bool) {
+	data := reflect.ValueOf(p.Data)
+	if data.Kind() != reflect.Map || data.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+
+	targets := make([]TargetPayload, 0, data.Len())
+	for _, key := range data.MapKeys() {
+		t := TargetPayload{Payload: p, Target: key.String()}
+		t.Data = data.MapIndex(key).Interface()
+		targets = append(targets, t)
+	}
+	slices.SortFunc(targets, func(a, b TargetPayload) int {
+		return strings.Compare(a.Target, b.Target)
+	})
+	return targets, true
+}
+
+// receiver is a configured webhook receiver with its parsed template
+type receiver struct {
+	Receiver
+	tmpl   *template.Template
+	client *http.Client
+}
+
+// Notifier sends the results of the checks to the configured webhook receivers
+type Notifier struct {
+	*notifier.Queue
+	receivers []receiver
+}
+
+// NewNotifier creates a new Notifier for the given configuration.
+// It returns an error if the template of a receiver is invalid.
+func NewNotifier(cfg Config) (*Notifier, error) {
+	n := &Notifier{}
+	for _, r := range cfg.Receivers {
+		tmpl, err := r.template()
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidTemplate, r.Name, err)
+		}
+		n.receivers = append(n.receivers, receiver{
+			Receiver: r,
+			tmpl:     tmpl,
+			client:   &http.Client{Timeout: r.Timeout},
+		})
+	}
+	n.Queue = notifier.NewQueue(n.send)
+	return n, nil
+}
+
+// send sends the result to the receivers accepting the results of its check
+func (n *Notifier) send(ctx context.Context, result checks.ResultDTO) {
+	log := logger.FromContext(ctx)
+	for _, r := range n.receivers {
+		if !notifier.Accepts(r.Checks, result.Name) {
+			continue
+		}
+		if err := r.send(ctx, result); err != nil {
+			log.WarnContext(ctx, "Failed to send result to webhook receiver", "receiver", r.Name, "check", result.Name, "error", err)
+		}
+	}
+}
+
+// send renders the payload of the result and posts it to the receiver
+func (r *receiver) send(ctx context.Context, result checks.ResultDTO) (err error) {
+	var body bytes.Buffer
+	err = r.tmpl.Execute(&body, NewPayload(result))
+	if err != nil {
+		return fmt.Errorf("failed to render payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("request failed, status is %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// sparrow
+// (C) 2024, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/caas-team/sparrow/pkg/checks"
+)
+
+func TestNotifier_Run(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := checks.ResultDTO{
+		Name: "health",
+		Result: &checks.Result{
+			Data:      map[string]string{"https://example.com": "healthy"},
+			Timestamp: ts,
+			Instance:  "sparrow.com",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		receiver Receiver
+		want     string
+	}{
+		{
+			name:     "default template",
+			receiver: Receiver{Name: "default"},
+			want:     `{"check":"health","instance":"sparrow.com","timestamp":"2024-01-01T00:00:00Z","data":{"https://example.com":"healthy"}}`,
+		},
+		{
+			name: "custom template",
+			receiver: Receiver{
+				Name:     "slack",
+				Template: `{"text": {{ printf "%s reported by %s" .Check .Instance | json }}{{ range $t, $s := .Data }}, "target": {{ json $t }}, "state": {{ json $s }}{{ end }}}`,
+			},
+			want: `{"text": "health reported by sparrow.com", "target": "https://example.com", "state": "healthy"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cBody := make(chan string, 1)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-Token") != "token" {
+					t.Errorf("Header X-Token = %q, want %q", r.Header.Get("X-Token"), "token")
+				}
+				body, _ := io.ReadAll(r.Body)
+				cBody <- string(body)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+
+			tt.receiver.Url = srv.URL
+			tt.receiver.Headers = map[string]string{"X-Token": "token"}
+			cfg := Config{Receivers: []Receiver{tt.receiver}}
+			if err := cfg.Validate(context.Background()); err != nil {
+				t.Fatalf("Config.Validate() error = %v", err)
+			}
+
+			n, err := NewNotifier(cfg)
+			if err != nil {
+				t.Fatalf("NewNotifier() error = %v", err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				_ = n.Run(ctx)
+			}()
+			n.Submit(result)
+
+			select {
+			case got := <-cBody:
+				if !json.Valid([]byte(got)) {
+					t.Errorf("Notifier sent invalid JSON: %s", got)
+				}
+				if got != tt.want {
+					t.Errorf("Notifier sent %s, want %s", got, tt.want)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Notifier didn't send the result")
+			}
+		})
+	}
+}
+
+func TestNotifier_Run_checks(t *testing.T) {
+	cChecks := make(chan string, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p Payload
+		_ = json.NewDecoder(r.Body).Decode(&p)
+		cChecks <- p.Check
+	}))
+	defer srv.Close()
+
+	n, err := NewNotifier(Config{Receivers: []Receiver{{Name: "dns", Url: srv.URL, Checks: []string{"dns"}}}})
+	if err != nil {
+		t.Fatalf("NewNotifier() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = n.Run(ctx)
+	}()
+
+	n.Submit(checks.ResultDTO{Name: "health", Result: &checks.Result{}})
+	n.Submit(checks.ResultDTO{Name: "dns", Result: &checks.Result{}})
+
+	select {
+	case got := <-cChecks:
+		if got != "dns" {
+			t.Errorf("Notifier sent result of %q, want only %q", got, "dns")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Notifier didn't send the result")
+	}
+}
+
+func TestNewNotifier_invalidTemplate(t *testing.T) {
+	_, err := NewNotifier(Config{Receivers: []Receiver{{Name: "slack", Url: "https://hooks.example.com", Template: `{{ .Check `}}})
+	if !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("NewNotifier() error = %v, want %v", err, ErrInvalidTemplate)
+	}
+}
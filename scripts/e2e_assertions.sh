@@ -0,0 +1,55 @@
+# Description: Shared logging and assertion helpers for the e2e tests
+# Source this file from a test.sh and exit with $EXIT_CODE
+#
+
+EXIT_CODE=0
+
+function error() {
+    echo "[ ERROR ]: $@"
+    EXIT_CODE=1
+}
+
+function info() {
+    echo "[ INFO ]: $@"
+}
+
+function success() {
+    echo "[ SUCCESS ]: $@"
+}
+
+# Asserts a series is present in a scraped Prometheus exposition file
+# and optionally that its value lies within [min, max]
+#
+# Usage: assert_metric <file> <series> [min] [max]
+# Example: assert_metric ./shared/prometheus.txt 'sparrow_latency_duration_seconds{target="https://example.com"}' 0 5
+function assert_metric() {
+    local file=$1 series=$2 min=$3 max=$4
+    local value
+    value=$(awk -v series="$series" '
+        /^#/ { next }
+        {
+            # The value follows the series, an optional timestamp may follow the value
+            name = $0
+            sub(/ [^ ]+( [0-9]+)?$/, "", name)
+            if (name == series) {
+                rest = substr($0, length(name) + 2)
+                split(rest, fields, " ")
+                print fields[1]
+                exit
+            }
+        }' "$file")
+
+    if [ -z "$value" ]; then
+        error "The series $series is not present."
+        return 1
+    fi
+    if [ -n "$min" ] && ! awk -v v="$value" -v min="$min" 'BEGIN { exit !(v + 0 >= min + 0) }'; then
+        error "The series $series has the value $value, expected at least $min."
+        return 1
+    fi
+    if [ -n "$max" ] && ! awk -v v="$value" -v max="$max" 'BEGIN { exit !(v + 0 <= max + 0) }'; then
+        error "The series $series has the value $value, expected at most $max."
+        return 1
+    fi
+    success "The series $series is present with the value $value."
+}
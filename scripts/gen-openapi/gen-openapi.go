@@ -0,0 +1,100 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/caas-team/sparrow/pkg/sparrow"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	execute()
+}
+
+func execute() {
+	rootCmd := &cobra.Command{
+		Use:   "gen-openapi",
+		Short: "Generates the OpenAPI specification of the sparrow API",
+	}
+	rootCmd.AddCommand(NewCmdGenOpenAPI())
+
+	if err := rootCmd.Execute(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// NewCmdGenOpenAPI creates a new gen-openapi command
+func NewCmdGenOpenAPI() *cobra.Command {
+	var specPath, tsPath string
+
+	cmd := &cobra.Command{
+		Use:   "gen-openapi",
+		Short: "Generate the OpenAPI specification and the TypeScript types",
+		Long:  `Generate the OpenAPI specification of the API of a sparrow running every known check and the TypeScript types of its operations`,
+		RunE:  runGenOpenAPI(&specPath, &tsPath),
+	}
+
+	cmd.PersistentFlags().StringVar(&specPath, "spec", "openapi.yaml", "file path where the OpenAPI specification will be written")
+	cmd.PersistentFlags().StringVar(&tsPath, "ts", "sparrow.d.ts", "file path where the TypeScript types will be written")
+
+	return cmd
+}
+
+// runGenOpenAPI writes the OpenAPI specification and the TypeScript types
+func runGenOpenAPI(specPath, tsPath *string) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		spec, ts, err := generate(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(*specPath, spec, 0o600); err != nil {
+			return fmt.Errorf("failed to write OpenAPI specification: %w", err)
+		}
+		if err = os.WriteFile(*tsPath, ts, 0o600); err != nil {
+			return fmt.Errorf("failed to write TypeScript types: %w", err)
+		}
+		return nil
+	}
+}
+
+// generate returns the OpenAPI specification in YAML and the TypeScript types of its operations
+func generate(ctx context.Context) (spec, ts []byte, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	doc, err := sparrow.GenerateSpec(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate OpenAPI specification: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2) //nolint:mnd // indentation of the YAML document
+	if err = enc.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode OpenAPI specification: %w", err)
+	}
+	return buf.Bytes(), typescript(&doc), nil
+}
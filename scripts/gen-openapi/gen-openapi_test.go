@@ -0,0 +1,47 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+)
+
+// TestGenerate ensures the generated files of the client package are up to date with the API
+func TestGenerate(t *testing.T) {
+	spec, ts, err := generate(context.Background())
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	for path, want := range map[string][]byte{
+		"../../pkg/client/openapi.yaml": spec,
+		"../../pkg/client/sparrow.d.ts": ts,
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("os.ReadFile(%q) error = %v", path, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is outdated, run go generate ./pkg/client", path)
+		}
+	}
+}
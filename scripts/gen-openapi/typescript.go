@@ -0,0 +1,269 @@
+// sparrow
+// (C) 2023, Deutsche Telekom IT GmbH
+//
+// Deutsche Telekom IT GmbH and all other contributors /
+// copyright owners license this file to you under the Apache
+// License, Version 2.0 (the "License"); you may not use this
+// file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// refPrefixes map the prefixes of the references of the OpenAPI specification to the TypeScript types of the components
+var refPrefixes = map[string]string{
+	"#/components/schemas/":   `components["schemas"]`,
+	"#/components/responses/": `components["responses"]`,
+}
+
+// typescript returns the TypeScript types of the paths, operations and components of the OpenAPI specification.
+// They have the layout of openapi-typescript, so they can be used with a typed fetch client like openapi-fetch.
+func typescript(doc *openapi3.T) []byte {
+	var b strings.Builder
+	b.WriteString("// Code generated by gen-openapi. DO NOT EDIT.\n\n")
+
+	b.WriteString("export interface paths {\n")
+	ops := map[string]*openapi3.Operation{}
+	for _, path := range sortedKeys(doc.Paths.Map()) {
+		fmt.Fprintf(&b, "  %s: {\n", strconv.Quote(path))
+		item := doc.Paths.Value(path)
+		for _, method := range sortedKeys(item.Operations()) {
+			op := item.Operations()[method]
+			ops[op.OperationID] = op
+			fmt.Fprintf(&b, "    %s: operations[%s];\n", strings.ToLower(method), strconv.Quote(op.OperationID))
+		}
+		b.WriteString("  };\n")
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("export interface operations {\n")
+	for _, id := range sortedKeys(ops) {
+		writeOperation(&b, id, ops[id])
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("export interface components {\n  schemas: {\n")
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		s := doc.Components.Schemas[name]
+		writeDoc(&b, "    ", s.Value)
+		fmt.Fprintf(&b, "    %s: %s;\n", strconv.Quote(name), tsType(s, "    "))
+	}
+	b.WriteString("  };\n  responses: {\n")
+	for _, name := range sortedKeys(doc.Components.Responses) {
+		fmt.Fprintf(&b, "    %s: %s;\n", strconv.Quote(name), tsResponse(doc.Components.Responses[name], "    "))
+	}
+	b.WriteString("  };\n}\n")
+
+	return []byte(b.String())
+}
+
+// writeOperation writes the type of the parameters, the request body and the responses of an operation
+func writeOperation(b *strings.Builder, id string, op *openapi3.Operation) {
+	writeComment(b, "  ", op.Description)
+	fmt.Fprintf(b, "  %s: {\n    parameters: {\n", strconv.Quote(id))
+	for _, in := range []string{openapi3.ParameterInQuery, openapi3.ParameterInPath} {
+		var params []*openapi3.Parameter
+		for _, p := range op.Parameters {
+			if p.Value != nil && p.Value.In == in {
+				params = append(params, p.Value)
+			}
+		}
+		if len(params) == 0 {
+			fmt.Fprintf(b, "      %s?: never;\n", in)
+			continue
+		}
+		fmt.Fprintf(b, "      %s%s: {\n", in, optional(in != openapi3.ParameterInPath))
+		for _, p := range params {
+			writeComment(b, "        ", p.Description)
+			fmt.Fprintf(b, "        %s%s: %s;\n", strconv.Quote(p.Name), optional(!p.Required), tsType(p.Schema, "        "))
+		}
+		b.WriteString("      };\n")
+	}
+	b.WriteString("    };\n")
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		body := op.RequestBody.Value
+		fmt.Fprintf(b, "    requestBody%s: {\n      content: %s;\n    };\n", optional(!body.Required), tsContent(body.Content, "      "))
+	} else {
+		b.WriteString("    requestBody?: never;\n")
+	}
+
+	b.WriteString("    responses: {\n")
+	responses := op.Responses.Map()
+	for _, code := range sortedKeys(responses) {
+		fmt.Fprintf(b, "      %s: %s;\n", code, tsResponse(responses[code], "      "))
+	}
+	b.WriteString("    };\n  };\n")
+}
+
+// tsResponse returns the type of a response or the reference to a response component
+func tsResponse(ref *openapi3.ResponseRef, indent string) string {
+	if ref.Ref != "" {
+		return tsRef(ref.Ref)
+	}
+	if ref.Value == nil || len(ref.Value.Content) == 0 {
+		return "{\n" + indent + "  headers: { [name: string]: unknown };\n" + indent + "  content?: never;\n" + indent + "}"
+	}
+	return "{\n" + indent + "  headers: { [name: string]: unknown };\n" +
+		indent + "  content: " + tsContent(ref.Value.Content, indent+"  ") + ";\n" + indent + "}"
+}
+
+// tsContent returns the type of the content of a request or response mapped by its media type
+func tsContent(content openapi3.Content, indent string) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, mime := range sortedKeys(content) {
+		fmt.Fprintf(&b, "%s  %s: %s;\n", indent, strconv.Quote(mime), tsType(content[mime].Schema, indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// tsType returns the TypeScript type of a schema
+func tsType(ref *openapi3.SchemaRef, indent string) string {
+	if ref == nil {
+		return "unknown"
+	}
+	if ref.Ref != "" {
+		return tsRef(ref.Ref)
+	}
+	s := ref.Value
+	if s == nil {
+		return "unknown"
+	}
+
+	t := tsValueType(s, indent)
+	if s.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+// tsValueType returns the TypeScript type of the values a schema permits
+func tsValueType(s *openapi3.Schema, indent string) string {
+	switch {
+	case len(s.Enum) > 0:
+		values := make([]string, 0, len(s.Enum))
+		for _, v := range s.Enum {
+			b, _ := json.Marshal(v)
+			values = append(values, string(b))
+		}
+		return strings.Join(values, " | ")
+	case len(s.OneOf) > 0:
+		return tsTypes(s.OneOf, " | ", indent)
+	case len(s.AnyOf) > 0:
+		return tsTypes(s.AnyOf, " | ", indent)
+	case len(s.AllOf) > 0:
+		return tsTypes(s.AllOf, " & ", indent)
+	}
+
+	switch {
+	case s.Type.Is(openapi3.TypeString):
+		return "string"
+	case s.Type.Is(openapi3.TypeInteger), s.Type.Is(openapi3.TypeNumber):
+		return "number"
+	case s.Type.Is(openapi3.TypeBoolean):
+		return "boolean"
+	case s.Type.Is(openapi3.TypeArray):
+		return "(" + tsType(s.Items, indent) + ")[]"
+	case s.Type.Is(openapi3.TypeObject):
+		return tsObject(s, indent)
+	default:
+		return "unknown"
+	}
+}
+
+// tsTypes joins the TypeScript types of the schemas with the separator
+func tsTypes(refs openapi3.SchemaRefs, sep, indent string) string {
+	types := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		types = append(types, "("+tsType(ref, indent)+")")
+	}
+	return strings.Join(types, sep)
+}
+
+// tsObject returns the TypeScript type of an object schema
+func tsObject(s *openapi3.Schema, indent string) string {
+	additional := s.AdditionalProperties.Schema
+	if len(s.Properties) == 0 {
+		if additional != nil {
+			return "{ [key: string]: " + tsType(additional, indent) + " }"
+		}
+		return "{ [key: string]: unknown }"
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range sortedKeys(s.Properties) {
+		prop := s.Properties[name]
+		writeDoc(&b, indent+"  ", prop.Value)
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, strconv.Quote(name), optional(!slices.Contains(s.Required, name)), tsType(prop, indent+"  "))
+	}
+	if additional != nil {
+		fmt.Fprintf(&b, "%s  [key: string]: %s;\n", indent, tsType(additional, indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// tsRef returns the TypeScript type of the component the reference refers to
+func tsRef(ref string) string {
+	for prefix, component := range refPrefixes {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			return fmt.Sprintf("%s[%s]", component, strconv.Quote(name))
+		}
+	}
+	return "unknown"
+}
+
+// writeDoc writes the description of a schema as a doc comment
+func writeDoc(b *strings.Builder, indent string, s *openapi3.Schema) {
+	if s == nil {
+		return
+	}
+	writeComment(b, indent, s.Description)
+}
+
+// writeComment writes the text as a doc comment
+func writeComment(b *strings.Builder, indent, text string) {
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(b, "%s/** %s */\n", indent, strings.ReplaceAll(text, "*/", "*\\/"))
+}
+
+// optional returns the marker of an optional property
+func optional(ok bool) string {
+	if ok {
+		return "?"
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of the map in ascending order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}